@@ -315,7 +315,7 @@ func handleQuery(path string, data []byte, upaths uniqPaths) error {
 	switch path {
 	case ".app/simulate":
 		return handleTx(data, upaths)
-	case "vm/qrender", "vm/qfile", "vm/qfuncs", "vm/qeval", "vm/qeval_json":
+	case "vm/qrender", "vm/qfile", "vm/qfuncs", "vm/qeval", "vm/qeval_json", "vm/qview":
 		path, _, _ := strings.Cut(string(data), ":") // Cut arguments out
 		upaths.addPath(path)
 	case "vm/qpkg_json":
@@ -767,6 +767,22 @@ func TestConfig_Get_RPC(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"rpc max subscription clients",
+			"rpc.max_subscription_clients",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.MaxSubscriptionClients, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
+		{
+			"rpc max subscriptions per client",
+			"rpc.max_subscriptions_per_client",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.MaxSubscriptionsPerClient, unmarshalJSONCommon[int](t, value))
+			},
+			false,
+		},
 		{
 			"tx commit broadcast timeout",
 			"rpc.timeout_broadcast_tx_commit",
@@ -667,6 +667,26 @@ func TestConfig_Set_RPC(t *testing.T) {
 				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxOpenConnections))
 			},
 		},
+		{
+			"rpc max subscription clients updated",
+			[]string{
+				"rpc.max_subscription_clients",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxSubscriptionClients))
+			},
+		},
+		{
+			"rpc max subscriptions per client updated",
+			[]string{
+				"rpc.max_subscriptions_per_client",
+				"10",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.RPC.MaxSubscriptionsPerClient))
+			},
+		},
 		{
 			"tx commit broadcast timeout updated",
 			[]string{
@@ -746,6 +766,16 @@ func TestConfig_Set_Mempool(t *testing.T) {
 				assert.Equal(t, value, loadedCfg.Mempool.RootDir)
 			},
 		},
+		{
+			"type updated",
+			[]string{
+				"mempool.type",
+				"priority",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.Mempool.Type)
+			},
+		},
 		{
 			"recheck flag updated",
 			[]string{
@@ -841,6 +871,65 @@ func TestConfig_Set_Application(t *testing.T) {
 				assert.Equal(t, types.PruneStrategy(value), loadedCfg.Application.PruneStrategy)
 			},
 		},
+		{
+			"snapshot interval updated",
+			[]string{
+				"application.snapshot_interval",
+				"1000",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Application.SnapshotInterval))
+			},
+		},
+		{
+			"snapshot keep recent updated",
+			[]string{
+				"application.snapshot_keep_recent",
+				"5",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.Application.SnapshotKeepRecent))
+			},
+		},
+	}
+
+	verifySetTestTableCommon(t, testTable)
+}
+
+func TestConfig_Set_StateSync(t *testing.T) {
+	t.Parallel()
+
+	testTable := []testSetCase{
+		{
+			"trust height updated",
+			[]string{
+				"statesync.trust_height",
+				"100",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.StateSync.TrustHeight))
+			},
+		},
+		{
+			"trust hash updated",
+			[]string{
+				"statesync.trust_hash",
+				"0A0B0C",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.StateSync.TrustHash)
+			},
+		},
+		{
+			"chunk fetchers updated",
+			[]string{
+				"statesync.chunk_fetchers",
+				"8",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprintf("%d", loadedCfg.StateSync.ChunkFetchers))
+			},
+		},
 	}
 
 	verifySetTestTableCommon(t, testTable)
@@ -279,6 +279,7 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 		evsw,
 		logger,
 		cfg.BaseConfig.SkipUpgradeHeight,
+		cfg.Mempool.Type,
 	)
 	if err != nil {
 		return fmt.Errorf("unable to create the Gnoland app, %w", err)
@@ -306,6 +307,7 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 	if err != nil {
 		return fmt.Errorf("unable to create the Gnoland node, %w", err)
 	}
+	gnoland.SetPendingTxs(cfg.LocalApp, gnoNode)
 
 	// Start the node (async)
 	if err := gnoNode.Start(); err != nil {
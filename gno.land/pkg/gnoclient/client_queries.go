@@ -3,8 +3,10 @@ package gnoclient
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
@@ -218,6 +220,89 @@ func (c *Client) QEval(pkgPath string, expression string) (string, *ctypes.Resul
 	return string(qres.Response.Data), qres, nil
 }
 
+// QView is like QEval, but evaluates the expression in a read-only view: the
+// query fails if the expression modifies the state of a realm.
+func (c *Client) QView(pkgPath string, expression string) (string, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return "", nil, err
+	}
+
+	path := "vm/qview"
+	data := fmt.Appendf(nil, "%s.%s", pkgPath, expression)
+
+	qres, err := c.RPCClient.ABCIQuery(context.Background(), path, data)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "query qview")
+	}
+	if qres.Response.Error != nil {
+		return "", nil, errors.Wrapf(qres.Response.Error, "QView failed: log:%s", qres.Response.Log)
+	}
+
+	return string(qres.Response.Data), qres, nil
+}
+
+// Profile simulates the transaction against the latest state, and returns the
+// gzipped pprof profile of its gas, for "go tool pprof". The sampleType is one
+// of "gas" (the default if empty), "cpu", "store", "alloc" and "alloc_space".
+// If the transaction fails, for example by running out of its gas wanted, the
+// profile up to the failure is returned, and the error is in the log of the
+// response.
+func (c *Client) Profile(tx *std.Tx, sampleType string) ([]byte, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, nil, err
+	}
+
+	path := "vm/qprofile"
+	if sampleType != "" {
+		path += "?sample=" + url.QueryEscape(sampleType)
+	}
+	data, err := amino.Marshal(tx)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to marshal tx")
+	}
+
+	qres, err := c.RPCClient.ABCIQuery(context.Background(), path, data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query qprofile")
+	}
+	if qres.Response.Error != nil {
+		return nil, nil, errors.Wrapf(qres.Response.Error, "Profile failed: log:%s", qres.Response.Log)
+	}
+
+	return qres.Response.Data, qres, nil
+}
+
+// SimulateWithOverrides runs the transaction of req against the latest state, with the
+// overrides of req applied, and returns its gas used, events, storage deposit
+// and realm changes. Signatures are not checked, and nothing is written. If
+// the transaction fails, the result up to the failure is returned, with the
+// error in its Error field. Unlike Simulate, the ante handler is not run, so
+// the gas of signature verification and fees is not counted.
+func (c *Client) SimulateWithOverrides(req vm.SimulateRequest) (*vm.SimulateResult, *ctypes.ResultABCIQuery, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, nil, err
+	}
+
+	data, err := amino.MarshalJSON(req)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "unable to marshal simulation request")
+	}
+
+	qres, err := c.RPCClient.ABCIQuery(context.Background(), "vm/qsimulate", data)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "query qsimulate")
+	}
+	if qres.Response.Error != nil {
+		return nil, nil, errors.Wrapf(qres.Response.Error, "Simulate failed: log:%s", qres.Response.Log)
+	}
+
+	var res vm.SimulateResult
+	if err := amino.UnmarshalJSON(qres.Response.Data, &res); err != nil {
+		return nil, nil, errors.Wrap(err, "unable to unmarshal simulation result")
+	}
+	return &res, qres, nil
+}
+
 // Block gets the latest block at height, if any
 // Height must be larger than 0
 func (c *Client) Block(height int64) (*ctypes.ResultBlock, error) {
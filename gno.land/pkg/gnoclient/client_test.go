@@ -65,6 +65,74 @@ func TestRender(t *testing.T) {
 	assert.Equal(t, data.Response.Data, expectedRender)
 }
 
+func TestProfile(t *testing.T) {
+	t.Parallel()
+	tx := &std.Tx{Memo: "profile me"}
+	expectedProfile := []byte("profile")
+
+	client := Client{
+		RPCClient: &mockRPCClient{
+			abciQuery: func(ctx context.Context, path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				require.Equal(t, "vm/qprofile?sample=cpu", path)
+				var decoded std.Tx
+				require.NoError(t, amino.Unmarshal(data, &decoded))
+				require.Equal(t, tx.Memo, decoded.Memo)
+
+				res := &ctypes.ResultABCIQuery{
+					Response: abci.ResponseQuery{
+						ResponseBase: abci.ResponseBase{
+							Data: expectedProfile,
+							Log:  "out of gas",
+						},
+					},
+				}
+				return res, nil
+			},
+		},
+	}
+
+	profile, res, err := client.Profile(tx, "cpu")
+	require.NoError(t, err)
+	assert.Equal(t, expectedProfile, profile)
+	assert.Equal(t, "out of gas", res.Response.Log)
+}
+
+func TestSimulateWithOverrides(t *testing.T) {
+	t.Parallel()
+	caller := crypto.AddressFromPreimage([]byte("caller"))
+	req := vm.SimulateRequest{
+		Tx:        std.Tx{Msgs: []std.Msg{vm.NewMsgCall(caller, nil, "gno.land/r/demo/deep/very/deep", "Render", []string{""})}},
+		Overrides: vm.SimulateOverrides{Caller: caller, Height: 100},
+	}
+
+	client := Client{
+		RPCClient: &mockRPCClient{
+			abciQuery: func(ctx context.Context, path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+				require.Equal(t, "vm/qsimulate", path)
+				var decoded vm.SimulateRequest
+				require.NoError(t, amino.UnmarshalJSON(data, &decoded))
+				require.Equal(t, req.Overrides, decoded.Overrides)
+				require.Len(t, decoded.Tx.Msgs, 1)
+
+				res := &ctypes.ResultABCIQuery{
+					Response: abci.ResponseQuery{
+						ResponseBase: abci.ResponseBase{
+							Data: amino.MustMarshalJSON(vm.SimulateResult{GasUsed: 1234, Error: "out of gas"}),
+							Log:  "out of gas",
+						},
+					},
+				}
+				return res, nil
+			},
+		},
+	}
+
+	res, _, err := client.SimulateWithOverrides(req)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1234), res.GasUsed)
+	assert.Equal(t, "out of gas", res.Error)
+}
+
 // Call tests
 func TestCallSingle(t *testing.T) {
 	t.Parallel()
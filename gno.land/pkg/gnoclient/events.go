@@ -0,0 +1,159 @@
+package gnoclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/stdlibs/chain"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+var ErrEventsUnsupported = errors.New("RPCClient does not support event subscriptions")
+
+// Event is a realm event with its attributes decoded into Go values.
+type Event struct {
+	Type    string
+	PkgPath string
+
+	// Attrs maps each attribute key to its decoded value: see
+	// chain.DecodeAttrValue for the Go type of each attribute type. If
+	// several attributes share a key, the last one wins.
+	Attrs map[string]any
+
+	// Indexed lists the keys of the attributes marked for indexing, in
+	// emission order.
+	Indexed []string
+}
+
+// DecodeEvent decodes the attributes of an event emitted by a realm with
+// chain.Emit or chain.EmitTyped. Attributes emitted with chain.Emit are
+// decoded as strings.
+func DecodeEvent(evt chain.Event) (Event, error) {
+	res := Event{
+		Type:    evt.Type,
+		PkgPath: evt.PkgPath,
+		Attrs:   make(map[string]any, len(evt.Attributes)),
+	}
+	for _, attr := range evt.Attributes {
+		v, err := attr.Decode()
+		if err != nil {
+			return Event{}, fmt.Errorf("event %q: attribute %q: %w", evt.Type, attr.Key, err)
+		}
+		res.Attrs[attr.Key] = v
+		if attr.Indexed {
+			res.Indexed = append(res.Indexed, attr.Key)
+		}
+	}
+	return res, nil
+}
+
+// DecodeEvents decodes the realm events among events, such as the events of
+// a transaction result, and skips the others.
+func DecodeEvents(events []abci.Event) ([]Event, error) {
+	var res []Event
+	for _, e := range events {
+		evt, ok := e.(chain.Event)
+		if !ok {
+			continue
+		}
+		dec, err := DecodeEvent(evt)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, dec)
+	}
+	return res, nil
+}
+
+// RealmEvent is a decoded realm event of a committed transaction.
+type RealmEvent struct {
+	Event
+
+	Height int64  // height of the transaction
+	TxHash []byte // hash of the transaction
+}
+
+// SubscribeRealmEvents subscribes to the events emitted by the realm at
+// pkgPath in committed transactions, only of the given type if not empty,
+// and returns the channel they are received on, decoded. Events which cannot
+// be decoded are skipped.
+//
+// The RPCClient must support subscriptions (rpcclient.EventsClient), as the
+// one of rpcclient.NewWSClient does. The subscription is cancelled and the
+// channel closed when ctx is done, or if the node cancels it.
+func (c *Client) SubscribeRealmEvents(ctx context.Context, pkgPath, eventType string) (<-chan RealmEvent, error) {
+	if err := c.validateRPCClient(); err != nil {
+		return nil, err
+	}
+	eventsClient, ok := c.RPCClient.(rpcclient.EventsClient)
+	if !ok {
+		return nil, ErrEventsUnsupported
+	}
+
+	query := "tm.event='Tx' AND event.pkg_path=" + quoteQueryValue(pkgPath)
+	if eventType != "" {
+		query += " AND event.type=" + quoteQueryValue(eventType)
+	}
+
+	results, err := eventsClient.Subscribe(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("unable to subscribe to %q, %w", query, err)
+	}
+
+	events := make(chan RealmEvent)
+	go func() {
+		defer close(events)
+		defer eventsClient.Unsubscribe(context.Background(), query) //nolint:errcheck
+
+		for {
+			var (
+				res ctypes.ResultEvent
+				ok  bool
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case res, ok = <-results:
+				if !ok {
+					return
+				}
+			}
+
+			txEvent, ok := res.Event.(types.EventTx)
+			if !ok {
+				continue
+			}
+			for _, e := range txEvent.Result.Response.Events {
+				evt, ok := e.(chain.Event)
+				if !ok || evt.PkgPath != pkgPath || (eventType != "" && evt.Type != eventType) {
+					continue
+				}
+				dec, err := DecodeEvent(evt)
+				if err != nil {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case events <- RealmEvent{
+					Event:  dec,
+					Height: txEvent.Result.Height,
+					TxHash: txEvent.Result.Tx.Hash(),
+				}:
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// quoteQueryValue quotes s as a string value of an event query.
+func quoteQueryValue(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,166 @@
+package gnoclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnovm/stdlibs/chain"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeEvents(t *testing.T) {
+	t.Parallel()
+
+	addr := crypto.AddressFromPreimage([]byte("from"))
+	events := []abci.Event{
+		chain.StorageDepositEvent{BytesDelta: 10},
+		chain.Event{
+			Type:    "Transfer",
+			PkgPath: "gno.land/r/demo/foo",
+			Attributes: []chain.EventAttribute{
+				{Key: "from", Value: addr.String(), Type: chain.AttrTypeAddress, Indexed: true},
+				{Key: "amount", Value: "10ugnot", Type: chain.AttrTypeCoins},
+				{Key: "memo", Value: `[{"key":"n","type":"int","value":"-1"}]`, Type: chain.AttrTypeObject},
+			},
+		},
+		chain.Event{
+			Type:       "Legacy",
+			PkgPath:    "gno.land/r/demo/foo",
+			Attributes: []chain.EventAttribute{{Key: "k", Value: "v"}},
+		},
+	}
+
+	// Typed attributes must survive the amino encoding of tx results.
+	bz := amino.MustMarshalJSON(events)
+	var decoded []abci.Event
+	amino.MustUnmarshalJSON(bz, &decoded)
+
+	res, err := DecodeEvents(decoded)
+	require.NoError(t, err)
+	assert.Equal(t, []Event{
+		{
+			Type:    "Transfer",
+			PkgPath: "gno.land/r/demo/foo",
+			Attrs: map[string]any{
+				"from":   addr,
+				"amount": std.NewCoins(std.NewCoin("ugnot", 10)),
+				"memo":   map[string]any{"n": int64(-1)},
+			},
+			Indexed: []string{"from"},
+		},
+		{
+			Type:    "Legacy",
+			PkgPath: "gno.land/r/demo/foo",
+			Attrs:   map[string]any{"k": "v"},
+		},
+	}, res)
+
+	_, err = DecodeEvent(chain.Event{
+		Type:       "Bad",
+		Attributes: []chain.EventAttribute{{Key: "n", Value: "x", Type: chain.AttrTypeInt}},
+	})
+	assert.ErrorContains(t, err, `event "Bad": attribute "n"`)
+}
+
+func TestSubscribeRealmEvents(t *testing.T) {
+	t.Parallel()
+
+	const pkgPath = "gno.land/r/demo/it's"
+
+	t.Run("unsupported RPC client", func(t *testing.T) {
+		t.Parallel()
+
+		c := Client{RPCClient: &mockRPCClient{}}
+
+		_, err := c.SubscribeRealmEvents(context.Background(), pkgPath, "")
+		assert.ErrorIs(t, err, ErrEventsUnsupported)
+	})
+
+	t.Run("realm events received", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			results      = make(chan ctypes.ResultEvent, 1)
+			unsubscribed = make(chan string, 1)
+			tx           = types.Tx("tx")
+		)
+
+		c := Client{
+			RPCClient: &mockEventsClient{
+				subscribe: func(_ context.Context, query string) (<-chan ctypes.ResultEvent, error) {
+					assert.Equal(t, `tm.event='Tx' AND event.pkg_path='gno.land/r/demo/it\'s' AND event.type='Transfer'`, query)
+
+					return results, nil
+				},
+				unsubscribe: func(_ context.Context, query string) error {
+					unsubscribed <- query
+
+					return nil
+				},
+			},
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		events, err := c.SubscribeRealmEvents(ctx, pkgPath, "Transfer")
+		require.NoError(t, err)
+
+		// Only the events of the realm with the type are received
+		results <- ctypes.ResultEvent{
+			Event: types.EventTx{Result: types.TxResult{
+				Height: 10,
+				Tx:     tx,
+				Response: abci.ResponseDeliverTx{
+					ResponseBase: abci.ResponseBase{
+						Events: []abci.Event{
+							chain.Event{Type: "Transfer", PkgPath: "gno.land/r/demo/other"},
+							chain.Event{Type: "Mint", PkgPath: pkgPath},
+							chain.Event{
+								Type:       "Transfer",
+								PkgPath:    pkgPath,
+								Attributes: []chain.EventAttribute{{Key: "k", Value: "v"}},
+							},
+						},
+					},
+				},
+			}},
+		}
+
+		select {
+		case event := <-events:
+			assert.Equal(t, RealmEvent{
+				Event: Event{
+					Type:    "Transfer",
+					PkgPath: pkgPath,
+					Attrs:   map[string]any{"k": "v"},
+				},
+				Height: 10,
+				TxHash: tx.Hash(),
+			}, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("event not received")
+		}
+
+		// Cancelling the context unsubscribes
+		cancel()
+
+		select {
+		case query := <-unsubscribed:
+			assert.Contains(t, query, "event.pkg_path=")
+		case <-time.After(5 * time.Second):
+			t.Fatal("not unsubscribed")
+		}
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+}
@@ -1,6 +1,7 @@
 package gnoclient
 
 import (
+	"context"
 	"path/filepath"
 	"testing"
 
@@ -13,10 +14,13 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
 	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -748,3 +752,73 @@ func loadpkgs(t *testing.T, rootdir string, paths ...string) []gnoland.TxWithMet
 	require.NoError(t, err)
 	return meta
 }
+
+func TestLightProxy_Integration(t *testing.T) {
+	// Set up in-memory node
+	config := integration.TestingMinimalNodeConfig(gnoenv.RootDir())
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	// Init Signer & RPCClient
+	signer := newInMemorySigner(t, "tendermint_test")
+	rpcClient, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+
+	// Setup Client
+	client := Client{
+		Signer:    signer,
+		RPCClient: rpcClient,
+	}
+
+	caller, err := client.Signer.Info()
+	require.NoError(t, err)
+
+	// Send coins twice, for the node to commit a few blocks
+	toAddress, _ := crypto.AddressFromBech32("g14a0y9a64dugh3l7hneshdxr4w0rfkkww9ls35p")
+	msg := bank.MsgSend{
+		FromAddress: caller.GetAddress(),
+		ToAddress:   toAddress,
+		Amount:      std.Coins{{Denom: ugnot.Denom, Amount: 10}},
+	}
+	for seq := range uint64(2) {
+		_, err := client.Send(BaseTxCfg{
+			GasFee:         ugnot.ValueString(2100000),
+			GasWanted:      50000000,
+			SequenceNumber: seq,
+		}, msg)
+		require.NoError(t, err)
+	}
+
+	// Trust the first block of the node, and verify its responses
+	ctx := context.Background()
+	first := int64(1)
+	commit, err := rpcClient.Commit(ctx, &first)
+	require.NoError(t, err)
+
+	lc, err := light.NewClient(ctx, config.Genesis.ChainID, light.TrustOptions{
+		Period: light.DefaultTrustingPeriod,
+		Height: first,
+		Hash:   commit.Hash(),
+	}, light.NewRPCProvider(rpcClient), light.NewDBStore(memdb.NewMemDB()))
+	require.NoError(t, err)
+	proxy := light.NewProxy(rpcClient, lc)
+
+	// The account of the recipient is proven to exist
+	res, err := proxy.ABCIQuery(ctx, "/.store/main/key", auth.AddressStoreKey(toAddress))
+	require.NoError(t, err)
+	assert.NotEmpty(t, res.Response.Value)
+
+	// The account of an unknown address is proven not to exist
+	unknown := crypto.AddressFromPreimage([]byte("unknown"))
+	res, err = proxy.ABCIQuery(ctx, "/.store/main/key", auth.AddressStoreKey(unknown))
+	require.NoError(t, err)
+	assert.Empty(t, res.Response.Value)
+
+	// Custom queries cannot be verified
+	_, err = proxy.ABCIQuery(ctx, "auth/accounts/"+toAddress.String(), nil)
+	assert.ErrorIs(t, err, light.ErrUnverifiableQuery)
+
+	block, err := proxy.Block(ctx, nil)
+	require.NoError(t, err)
+	assert.Greater(t, block.Block.Height, first)
+}
@@ -125,6 +125,8 @@ type (
 	mockUnconfirmedTxs       func(ctx context.Context, limit int) (*ctypes.ResultUnconfirmedTxs, error)
 	mockNumUnconfirmedTxs    func(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error)
 	mockTx                   func(ctx context.Context, hash []byte) (*ctypes.ResultTx, error)
+	mockTxSearch             func(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
+	mockBlockSearch          func(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error)
 )
 
 type mockRPCClient struct {
@@ -149,6 +151,8 @@ type mockRPCClient struct {
 	unconfirmedTxs       mockUnconfirmedTxs
 	numUnconfirmedTxs    mockNumUnconfirmedTxs
 	tx                   mockTx
+	txSearch             mockTxSearch
+	blockSearch          mockBlockSearch
 }
 
 func (m *mockRPCClient) BroadcastTxCommit(ctx context.Context, tx types.Tx) (*ctypes.ResultBroadcastTxCommit, error) {
@@ -298,3 +302,58 @@ func (m *mockRPCClient) Tx(ctx context.Context, hash []byte) (*ctypes.ResultTx,
 
 	return nil, nil
 }
+
+func (m *mockRPCClient) TxSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	if m.txSearch != nil {
+		return m.txSearch(ctx, query, page, perPage, orderBy)
+	}
+
+	return nil, nil
+}
+
+func (m *mockRPCClient) BlockSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	if m.blockSearch != nil {
+		return m.blockSearch(ctx, query, page, perPage, orderBy)
+	}
+
+	return nil, nil
+}
+
+// Events client mock
+type (
+	mockSubscribe      func(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error)
+	mockUnsubscribe    func(ctx context.Context, query string) error
+	mockUnsubscribeAll func(ctx context.Context) error
+)
+
+type mockEventsClient struct {
+	mockRPCClient
+
+	subscribe      mockSubscribe
+	unsubscribe    mockUnsubscribe
+	unsubscribeAll mockUnsubscribeAll
+}
+
+func (m *mockEventsClient) Subscribe(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error) {
+	if m.subscribe != nil {
+		return m.subscribe(ctx, query)
+	}
+
+	return nil, nil
+}
+
+func (m *mockEventsClient) Unsubscribe(ctx context.Context, query string) error {
+	if m.unsubscribe != nil {
+		return m.unsubscribe(ctx, query)
+	}
+
+	return nil
+}
+
+func (m *mockEventsClient) UnsubscribeAll(ctx context.Context) error {
+	if m.unsubscribeAll != nil {
+		return m.unsubscribeAll(ctx)
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	memplcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -46,6 +47,10 @@ type AppOptions struct {
 	InitChainerConfig                             // options related to InitChainer
 	MinGasPrices               string             // optional
 	PruneStrategy              types.PruneStrategy
+	SnapshotDir                string // optional, enables state sync snapshots
+	SnapshotInterval           uint64 // blocks between snapshots, 0 to only restore them
+	SnapshotKeepRecent         uint32 // snapshots to keep, 0 to keep all
+	PriorityMempool            bool   // lets a tx replace a pending tx of the prioritized mempool
 }
 
 // TestAppOptions provides a "ready" default [AppOptions] for use with
@@ -78,6 +83,10 @@ func (c AppOptions) validate() error {
 	return nil
 }
 
+// maxReplacedSequences is how far behind the account sequence a tx may replace
+// a pending tx of the prioritized mempool.
+const maxReplacedSequences = 16
+
 // NewAppWithOptions creates the gno.land application with specified options.
 // genesisSignerFunding is what a genesis transaction's signer is minted when it has
 // no account yet, so the transaction can pay for itself. Consensus-relevant: it
@@ -102,6 +111,10 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 
 	appOpts = append(appOpts, sdk.SetPruningOptions(cfg.PruneStrategy.Options()))
 
+	if cfg.SnapshotDir != "" {
+		appOpts = append(appOpts, sdk.SetSnapshot(cfg.SnapshotDir, cfg.SnapshotInterval, cfg.SnapshotKeepRecent))
+	}
+
 	// Create BaseApp.
 	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, baseKey, mainKey, appOpts...)
 	baseApp.SetAppVersion("dev")
@@ -152,6 +165,10 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	authOptions := auth.AnteOptions{
 		VerifyGenesisSignatures: !cfg.SkipGenesisSigVerification,
 	}
+	if cfg.PriorityMempool {
+		// Let pending txs be replaced by fee in the prioritized mempool.
+		authOptions.MaxReplacedSequences = maxReplacedSequences
+	}
 	authAnteHandler := auth.NewAnteHandler(
 		acck, bankk, auth.DefaultSigVerificationGasConsumer, authOptions)
 	baseApp.SetAnteHandler(
@@ -234,10 +251,17 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 			prmk,
 			acck,
 			gpk,
+			vmk,
 			baseApp,
 		),
 	)
 
+	// Reload the VMKeeper from the multistore restored by state sync.
+	baseApp.SetRestorer(func(ms store.MultiStore) {
+		vmk.Reinitialize(cfg.Logger, ms)
+		ms.MultiWrite()
+	})
+
 	// Set a handler Route.
 	baseApp.Router().AddRoute("auth", auth.NewHandler(acck, gpk))
 	baseApp.Router().AddRoute("bank", bank.NewHandler(bankk))
@@ -285,6 +309,7 @@ func NewApp(
 	evsw events.EventSwitch,
 	logger *slog.Logger,
 	skipUpgradeHeight int64,
+	mempoolType string,
 ) (abci.Application, error) {
 	var err error
 
@@ -299,6 +324,10 @@ func NewApp(
 		SkipGenesisSigVerification: genesisCfg.SkipSigVerification,
 		SkipUpgradeHeight:          skipUpgradeHeight,
 		PruneStrategy:              appCfg.PruneStrategy,
+		SnapshotDir:                filepath.Join(dataRootDir, config.DefaultDBDir, "snapshots"),
+		SnapshotInterval:           appCfg.SnapshotInterval,
+		SnapshotKeepRecent:         appCfg.SnapshotKeepRecent,
+		PriorityMempool:            mempoolType == memplcfg.PriorityType,
 	}
 	if genesisCfg.SkipFailingTxs {
 		cfg.GenesisTxResultHandler = NoopGenesisTxResultHandler
@@ -1042,12 +1071,14 @@ func validateSignerInfo(state GnoGenesisState) error {
 }
 
 // EndBlocker defines the logic executed after every block.
-// It checks for a governance-requested chain halt, then reads valset changes
-// from the params keeper and propagates them to consensus.
+// It checks for a governance-requested chain halt, runs the calls scheduled
+// with chain/scheduler, then reads valset changes from the params keeper and
+// propagates them to consensus.
 func EndBlocker(
 	prmk params.ParamsKeeperI,
 	acck auth.AccountKeeperI,
 	gpk auth.GasPriceKeeperI,
+	vmk vm.VMKeeperI,
 	app endBlockerApp,
 ) func(
 	ctx sdk.Context,
@@ -1081,11 +1112,18 @@ func EndBlocker(
 			}
 		}
 
+		// Run the calls due at this height; their events are reported
+		// in the block results.
+		var res abci.ResponseEndBlock
+		if vmk != nil {
+			res.Events = vmk.RunScheduledCalls(ctx)
+		}
+
 		// Check if there are any pending valset changes.
 		dirty := false
 		prmk.GetBool(ctx, valsetDirtyPath, &dirty)
 		if !dirty {
-			return abci.ResponseEndBlock{}
+			return res
 		}
 
 		var currentEntries, proposedEntries []string
@@ -1101,7 +1139,7 @@ func EndBlocker(
 		if err != nil {
 			app.Logger().Error("valset:proposed corrupted; dropping proposal", "err", err)
 			prmk.SetBool(ctx, valsetDirtyPath, false)
-			return abci.ResponseEndBlock{}
+			return res
 		}
 
 		// Parse current; corruption here is chain-internal (only chain
@@ -1153,7 +1191,7 @@ func EndBlocker(
 				"proposed_len", len(proposedSet),
 				"live_count", liveCount)
 			prmk.SetBool(ctx, valsetDirtyPath, false)
-			return abci.ResponseEndBlock{}
+			return res
 		}
 
 		// Compute diff. Whole-reject if any add/update has a disallowed
@@ -1178,7 +1216,7 @@ func EndBlocker(
 					"pubkey_type", amino.GetTypeURL(u.PubKey),
 				)
 				prmk.SetBool(ctx, valsetDirtyPath, false)
-				return abci.ResponseEndBlock{}
+				return res
 			}
 		}
 
@@ -1197,16 +1235,17 @@ func EndBlocker(
 		// side already enforces single-writer via assertValsetCaller.
 		prmk.SetBool(ctx, valsetDirtyPath, false)
 
-		return abci.ResponseEndBlock{
-			ValidatorUpdates: diff,
-		}
+		res.ValidatorUpdates = diff
+		return res
 	}
 }
 
 // checkSessionRestrictions enforces gno.land session key restrictions.
 // Two filters apply, in order:
 //
-//  1. sessionAlwaysDenied — auth/* and vm/add_package. Hard floor: never
+//  1. sessionAlwaysDenied — auth/*, vm/add_package, vm/upgrade_package and
+//     vm/archive_package.
+//     Hard floor: never
 //     permitted, even with "*" entry.
 //  2. AllowPaths match — session's per-msg allow-list (validated at
 //     create-time by handleMsgCreateSession; the "*" entry matches any).
@@ -1250,13 +1289,17 @@ func checkSessionRestrictions(ctx sdk.Context, tx std.Tx) (sdk.Result, bool) {
 
 // sessionAlwaysDenied reports whether a msg can never be signed by a session,
 // regardless of AllowPaths. Auth is denied at the route level (forward-compat
-// against new auth msgs); vm/add_package at the type level.
+// against new auth msgs); vm/add_package, vm/upgrade_package and
+// vm/archive_package at the type level.
 func sessionAlwaysDenied(msg std.Msg) bool {
 	if msg.Route() == "auth" {
 		return true
 	}
-	if msg.Route() == "vm" && msg.Type() == "add_package" {
-		return true
+	if msg.Route() == "vm" {
+		switch msg.Type() {
+		case "add_package", "upgrade_package", "archive_package":
+			return true
+		}
 	}
 	return false
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	bftCfg "github.com/gnolang/gno/tm2/pkg/bft/config"
+	memplcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
@@ -168,7 +169,7 @@ func TestNewApp(t *testing.T) {
 	// NewApp should have good defaults and manage to run InitChain.
 	td := t.TempDir()
 
-	app, err := NewApp(td, NewTestGenesisAppConfig(), config.DefaultAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), 0)
+	app, err := NewApp(td, NewTestGenesisAppConfig(), config.DefaultAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), 0, memplcfg.FIFOType)
 	require.NoError(t, err, "NewApp should be successful")
 
 	resp := app.InitChain(abci.RequestInitChain{
@@ -216,7 +217,7 @@ func TestInitChainer_GenesisValidatorPubKeyType(t *testing.T) {
 	t.Run("ed25519 genesis validator accepted", func(t *testing.T) {
 		t.Parallel()
 
-		app, err := NewApp(t.TempDir(), NewTestGenesisAppConfig(), config.DefaultAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), 0)
+		app, err := NewApp(t.TempDir(), NewTestGenesisAppConfig(), config.DefaultAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), 0, memplcfg.FIFOType)
 		require.NoError(t, err)
 
 		resp := app.InitChain(newInitReq(ed25519.GenPrivKey().PubKey()))
@@ -226,7 +227,7 @@ func TestInitChainer_GenesisValidatorPubKeyType(t *testing.T) {
 	t.Run("secp256k1 genesis validator aborts boot", func(t *testing.T) {
 		t.Parallel()
 
-		app, err := NewApp(t.TempDir(), NewTestGenesisAppConfig(), config.DefaultAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), 0)
+		app, err := NewApp(t.TempDir(), NewTestGenesisAppConfig(), config.DefaultAppConfig(), events.NewEventSwitch(), log.NewNoopLogger(), 0, memplcfg.FIFOType)
 		require.NoError(t, err)
 
 		// getDummyKey produces a secp256k1 key, which is not in the allow-list.
@@ -964,7 +965,7 @@ func newValsetMock(st *valsetState) *endBlockerParamsMock {
 
 func runEndBlocker(t *testing.T, mock *endBlockerParamsMock, pubKeyType string) abci.ResponseEndBlock {
 	t.Helper()
-	eb := EndBlocker(mock, nil, nil, &mockEndBlockerApp{})
+	eb := EndBlocker(mock, nil, nil, nil, &mockEndBlockerApp{})
 	// Use context.Background() as the wrapped context so ctx.Value()
 	// (which the new EndBlocker calls for internalWriteCtxKey) and
 	// ctx.WithValue() don't nil-deref the underlying context.Context.
@@ -1567,6 +1568,7 @@ func newGasPriceTestApp(t *testing.T, storedGasPrice ...std.GasPrice) abci.Appli
 			prmk,
 			acck,
 			gpk,
+			vmk,
 			baseApp,
 		),
 	)
@@ -1708,6 +1710,7 @@ func TestPruneStrategyNothing(t *testing.T) {
 		events.NewEventSwitch(),
 		log.NewNoopLogger(),
 		0,
+		memplcfg.FIFOType,
 	)
 	require.NoError(t, err)
 
@@ -3212,7 +3215,7 @@ func TestEndBlockerHalt(t *testing.T) {
 			int64s: map[string]int64{nodeParamHaltHeight: 100},
 		}
 
-		eb := EndBlocker(mockPrmk, nil, nil, mockApp)
+		eb := EndBlocker(mockPrmk, nil, nil, nil, mockApp)
 		eb(sdk.Context{}, abci.RequestEndBlock{Height: 100})
 
 		assert.Equal(t, uint64(100), haltSet, "SetHaltHeight should be called with halt_height")
@@ -3229,7 +3232,7 @@ func TestEndBlockerHalt(t *testing.T) {
 			int64s: map[string]int64{nodeParamHaltHeight: 100},
 		}
 
-		eb := EndBlocker(mockPrmk, nil, nil, mockApp)
+		eb := EndBlocker(mockPrmk, nil, nil, nil, mockApp)
 		eb(sdk.Context{}, abci.RequestEndBlock{Height: 99})
 
 		assert.Equal(t, uint64(0), haltSet, "SetHaltHeight should NOT be called before halt height")
@@ -3246,7 +3249,7 @@ func TestEndBlockerHalt(t *testing.T) {
 			int64s: map[string]int64{nodeParamHaltHeight: 100},
 		}
 
-		eb := EndBlocker(mockPrmk, nil, nil, mockApp)
+		eb := EndBlocker(mockPrmk, nil, nil, nil, mockApp)
 		// After restart at height 101, halt_height=100 still in params but == doesn't re-fire
 		eb(sdk.Context{}, abci.RequestEndBlock{Height: 101})
 
@@ -3264,7 +3267,7 @@ func TestEndBlockerHalt(t *testing.T) {
 			int64s: map[string]int64{nodeParamHaltHeight: 0},
 		}
 
-		eb := EndBlocker(mockPrmk, nil, nil, mockApp)
+		eb := EndBlocker(mockPrmk, nil, nil, nil, mockApp)
 		eb(sdk.Context{}, abci.RequestEndBlock{Height: 100})
 
 		assert.Equal(t, uint64(0), haltSet, "SetHaltHeight should NOT be called when halt_height=0 (cancelled)")
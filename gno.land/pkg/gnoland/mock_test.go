@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/log"
@@ -53,6 +54,8 @@ func (m *mockEventSwitch) RemoveListener(listenerID string) {
 
 type mockVMKeeper struct {
 	addPackageFn                func(sdk.Context, vm.MsgAddPackage) error
+	upgradePackageFn            func(sdk.Context, vm.MsgUpgradePackage) error
+	archivePackageFn            func(sdk.Context, vm.MsgArchivePackage) error
 	callFn                      func(sdk.Context, vm.MsgCall) (string, error)
 	queryFn                     func(sdk.Context, string, string) (string, error)
 	runFn                       func(sdk.Context, vm.MsgRun) (string, error)
@@ -70,6 +73,22 @@ func (m *mockVMKeeper) AddPackage(ctx sdk.Context, msg vm.MsgAddPackage) error {
 	return nil
 }
 
+func (m *mockVMKeeper) UpgradePackage(ctx sdk.Context, msg vm.MsgUpgradePackage) error {
+	if m.upgradePackageFn != nil {
+		return m.upgradePackageFn(ctx, msg)
+	}
+
+	return nil
+}
+
+func (m *mockVMKeeper) ArchivePackage(ctx sdk.Context, msg vm.MsgArchivePackage) error {
+	if m.archivePackageFn != nil {
+		return m.archivePackageFn(ctx, msg)
+	}
+
+	return nil
+}
+
 func (m *mockVMKeeper) Call(ctx sdk.Context, msg vm.MsgCall) (res string, err error) {
 	if m.callFn != nil {
 		return m.callFn(ctx, msg)
@@ -131,6 +150,8 @@ func (m *mockVMKeeper) CommitGnoTransactionStore(ctx sdk.Context) {
 	}
 }
 
+func (m *mockVMKeeper) RunScheduledCalls(_ sdk.Context) []abci.Event { return nil }
+
 func (m *mockVMKeeper) PopulateStdlibCache() {}
 
 func (m *mockVMKeeper) PopulateStdlibCacheFrom(_ store.MultiStore) {}
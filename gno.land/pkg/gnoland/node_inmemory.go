@@ -10,6 +10,8 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	tmcfg "github.com/gnolang/gno/tm2/pkg/bft/config"
+	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
+	memplcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/node"
 	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
@@ -18,6 +20,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
 )
 
 type InMemoryNodeConfig struct {
@@ -113,6 +116,7 @@ func NewInMemoryNode(logger *slog.Logger, cfg *InMemoryNodeConfig) (*node.Node,
 		InitChainerConfig:          cfg.InitChainerConfig,
 		VMOutput:                   cfg.VMOutput,
 		SkipGenesisSigVerification: cfg.SkipGenesisSigVerification,
+		PriorityMempool:            cfg.TMConfig.Mempool.Type == memplcfg.PriorityType,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("error initializing new app: %w", err)
@@ -137,7 +141,7 @@ func NewInMemoryNode(logger *slog.Logger, cfg *InMemoryNodeConfig) (*node.Node,
 	nodekey := &types.NodeKey{PrivKey: ed25519.GenPrivKey()}
 
 	// Create and return the in-memory node instance
-	return node.NewNode(
+	n, err := node.NewNode(
 		cfg.TMConfig,
 		cfg.PrivValidator,
 		nodekey,
@@ -147,4 +151,22 @@ func NewInMemoryNode(logger *slog.Logger, cfg *InMemoryNodeConfig) (*node.Node,
 		evsw,
 		logger,
 	)
+	if err != nil {
+		return nil, err
+	}
+	SetPendingTxs(gnoApp, n)
+	return n, nil
+}
+
+// SetPendingTxs lets app accept a tx replacing a pending tx of the mempool of
+// n on CheckTx, if it is a prioritized mempool (see
+// auth.AnteOptions.MaxReplacedSequences). It must be called before n starts.
+func SetPendingTxs(app abci.Application, n *node.Node) {
+	pm, ok := n.Mempool().(*mempl.PriorityMempool)
+	if !ok {
+		return
+	}
+	if baseApp, ok := app.(*sdk.BaseApp); ok {
+		baseApp.SetPendingTxs(pm)
+	}
 }
@@ -472,9 +472,9 @@ func TestSessionCreateRejectsFromSession(t *testing.T) {
 
 // TestSessionWildcardDoesNotBypassAlwaysDenied confirms that the "*" entry
 // permits arbitrary msg types but never overrides the always-denied list
-// (auth/* and vm/add_package). Two sub-cases: auth msg (route-level deny)
-// and vm/add_package (type-level deny), since the deny rule is structured
-// differently for each.
+// (auth/*, vm/add_package, vm/upgrade_package and vm/archive_package). The
+// sub-cases cover the auth msg (route-level deny) and the vm msgs (type-level
+// deny), since the deny rule is structured differently for each.
 func TestSessionWildcardDoesNotBypassAlwaysDenied(t *testing.T) {
 	env, anteHandler, _, _, masterAddr := setupSessionGnoEnv(t)
 	ctx := env.ctx
@@ -511,6 +511,31 @@ func TestSessionWildcardDoesNotBypassAlwaysDenied(t *testing.T) {
 		require.True(t, abort, "wildcard must not permit vm/add_package")
 		assert.Contains(t, res.Log, "privilege escalation")
 	})
+
+	t.Run("vm/upgrade_package denied", func(t *testing.T) {
+		sessionPriv, sessionPub, sessionAddr := tu.KeyTestPubAddr()
+		sa := createGnoSession(t, env, masterAddr, sessionPub, ctx.BlockTime().Unix()+3600, []string{"*"})
+
+		msg := vm.MsgUpgradePackage{
+			Upgrader: masterAddr,
+			Package:  &std.MemPackage{Name: "x", Path: "gno.land/r/x", Files: []*std.MemFile{{Name: "x.gno", Body: "package x"}}},
+		}
+		tx := tu.NewSessionTestTx(t, ctx.ChainID(), []std.Msg{msg}, sessionPriv, sessionAddr, sa.GetAccountNumber(), 0, fee)
+		_, res, abort := anteHandler(ctx, tx, false)
+		require.True(t, abort, "wildcard must not permit vm/upgrade_package")
+		assert.Contains(t, res.Log, "privilege escalation")
+	})
+
+	t.Run("vm/archive_package denied", func(t *testing.T) {
+		sessionPriv, sessionPub, sessionAddr := tu.KeyTestPubAddr()
+		sa := createGnoSession(t, env, masterAddr, sessionPub, ctx.BlockTime().Unix()+3600, []string{"*"})
+
+		msg := vm.NewMsgArchivePackage(masterAddr, "gno.land/r/x", masterAddr)
+		tx := tu.NewSessionTestTx(t, ctx.ChainID(), []std.Msg{msg}, sessionPriv, sessionAddr, sa.GetAccountNumber(), 0, fee)
+		_, res, abort := anteHandler(ctx, tx, false)
+		require.True(t, abort, "wildcard must not permit vm/archive_package")
+		assert.Contains(t, res.Log, "privilege escalation")
+	})
 }
 
 // TestSessionWildcardPermitsUnknownMsgType confirms that "*" matches msg
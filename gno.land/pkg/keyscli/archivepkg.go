@@ -0,0 +1,119 @@
+package keyscli
+
+import (
+	"context"
+	"flag"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeArchivePkgCfg struct {
+	RootCfg *client.MakeTxCfg
+	PkgPath string
+	Refund  string
+}
+
+func NewMakeArchivePkgCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeArchivePkgCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "archivepkg",
+			ShortUsage: "archivepkg [flags] <key-name>",
+			ShortHelp:  "deletes the state of an upgradable realm and refunds its deposit",
+			LongHelp: "Deletes all the objects of an upgradable realm and refunds its whole storage " +
+				"deposit. The source of the realm stays readable, but it cannot be called anymore. " +
+				"The key must be the upgrade authority declared in the gnomod.toml of the realm.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeArchivePkg(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeArchivePkgCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.PkgPath,
+		"pkgpath",
+		"",
+		"realm path (required)",
+	)
+
+	fs.StringVar(
+		&c.Refund,
+		"refund",
+		"",
+		"address receiving the storage deposit (defaults to the key address)",
+	)
+}
+
+func execMakeArchivePkg(cfg *MakeArchivePkgCfg, args []string, io commands.IO) error {
+	if cfg.PkgPath == "" {
+		return errors.New("pkgpath not specified")
+	}
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+	caller := info.GetAddress()
+	refund := caller
+	if cfg.Refund != "" {
+		refund, err = crypto.AddressFromBech32(cfg.Refund)
+		if err != nil {
+			return errors.Wrap(err, "parsing refund address")
+		}
+	}
+
+	// parse gas wanted & fee.
+	gaswanted := cfg.RootCfg.GasWanted
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+	// construct msg & tx and marshal.
+	msg := vm.NewMsgArchivePackage(caller, cfg.PkgPath, refund)
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        std.NewFee(gaswanted, gasfee),
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	if cfg.RootCfg.Broadcast {
+		cfg.RootCfg.RootCfg.OnTxSuccess = PrintTxSuccess
+		err := client.ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
+		if err != nil {
+			return err
+		}
+	} else {
+		io.Println(string(amino.MustMarshalJSON(tx)))
+	}
+	return nil
+}
@@ -28,6 +28,8 @@ func NewMakeTxCmd(rootCfg *client.BaseCfg, io commands.IO) *commands.Command {
 		NewMakeAddPkgCmd(cfg, io),
 		NewMakeCallCmd(cfg, io),
 		NewMakeRunCmd(cfg, io),
+		NewMakeUpgradePkgCmd(cfg, io),
+		NewMakeArchivePkgCmd(cfg, io),
 	)
 
 	return cmd
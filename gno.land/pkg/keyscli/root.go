@@ -92,8 +92,13 @@ func PrintTxMetrics(io commands.IO, tx std.Tx, res *ctypes.ResultBroadcastTxComm
 	io.Println("INFO:      ", res.DeliverTx.Info)
 	io.Println("TX HASH:   ", base64.StdEncoding.EncodeToString(res.Hash))
 	for _, msg := range tx.Msgs {
-		if addPkg, ok := msg.(vm.MsgAddPackage); ok {
-			io.Println("PKGPATH:   ", addPkg.Package.Path)
+		switch msg := msg.(type) {
+		case vm.MsgAddPackage:
+			io.Println("PKGPATH:   ", msg.Package.Path)
+		case vm.MsgUpgradePackage:
+			io.Println("PKGPATH:   ", msg.Package.Path)
+		case vm.MsgArchivePackage:
+			io.Println("PKGPATH:   ", msg.PkgPath)
 		}
 	}
 }
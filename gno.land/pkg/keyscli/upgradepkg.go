@@ -0,0 +1,142 @@
+package keyscli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type MakeUpgradePkgCfg struct {
+	RootCfg    *client.MakeTxCfg
+	PkgPath    string
+	PkgDir     string
+	MaxDeposit string
+}
+
+func NewMakeUpgradePkgCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeUpgradePkgCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "upgradepkg",
+			ShortUsage: "upgradepkg [flags] <key-name>",
+			ShortHelp:  "replaces the code of an upgradable realm",
+			LongHelp: "Replaces the code of an upgradable realm, keeping its state. The key must be " +
+				"the upgrade authority declared in the gnomod.toml of the deployed code. " +
+				"If the new code declares func Migrate(cur realm), it is called after the upgrade.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execMakeUpgradePkg(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeUpgradePkgCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.PkgPath,
+		"pkgpath",
+		"",
+		"realm path (required)",
+	)
+
+	fs.StringVar(
+		&c.PkgDir,
+		"pkgdir",
+		"",
+		"path to the new package files (required)",
+	)
+
+	fs.StringVar(
+		&c.MaxDeposit,
+		"max-deposit",
+		"",
+		"max storage deposit",
+	)
+}
+
+func execMakeUpgradePkg(cfg *MakeUpgradePkgCfg, args []string, io commands.IO) error {
+	if cfg.PkgPath == "" {
+		return errors.New("pkgpath not specified")
+	}
+	if cfg.PkgDir == "" {
+		return errors.New("pkgdir not specified")
+	}
+	if cfg.RootCfg.GasWanted == 0 {
+		return errors.New("gas-wanted not specified")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+	upgrader := info.GetAddress()
+	// parse deposit.
+	deposit, err := std.ParseCoins(cfg.MaxDeposit)
+	if err != nil {
+		return errors.Wrap(err, "parsing max deposit")
+	}
+
+	// open files in directory as MemPackage.
+	memPkg := gno.MustReadMemPackage(cfg.PkgDir, cfg.PkgPath, gno.MPUserAll)
+	if memPkg.IsEmpty() {
+		return fmt.Errorf("found an empty package %q", cfg.PkgPath)
+	}
+
+	// parse gas wanted & fee.
+	gaswanted := cfg.RootCfg.GasWanted
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee coin")
+	}
+	// construct msg & tx and marshal.
+	msg := vm.MsgUpgradePackage{
+		Upgrader:   upgrader,
+		Package:    memPkg,
+		MaxDeposit: deposit,
+	}
+	tx := std.Tx{
+		Msgs:       []std.Msg{msg},
+		Fee:        std.NewFee(gaswanted, gasfee),
+		Signatures: nil,
+		Memo:       cfg.RootCfg.Memo,
+	}
+
+	if cfg.RootCfg.Broadcast {
+		cfg.RootCfg.RootCfg.OnTxSuccess = PrintTxSuccess
+		err := client.ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
+		if err != nil {
+			if isCLAError(err) {
+				return wrapCLAError(err, cfg.RootCfg.RootCfg.Remote, cfg.RootCfg.ChainID, nameOrBech32)
+			}
+			return err
+		}
+	} else {
+		io.Println(string(amino.MustMarshalJSON(tx)))
+	}
+	return nil
+}
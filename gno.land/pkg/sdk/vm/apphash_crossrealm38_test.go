@@ -119,7 +119,25 @@ import (
 // stdlib source change and is intended. Note this is the *only* reason this
 // branch moves the pin — the balance split alone does not, because that scenario
 // holds no non-gas denom.
-const expectedCrossrealm38Hash = "914602d9e206bf666cbfdf6e28f9147317e96e2d16b665bda67ffb33b7d0e1a8"
+//
+// Hash bumped by adding the AllowConcurrency vm param: params are stored one
+// key per field, so the new (false by default) vm:p:allow_concurrency key
+// enters genesis state and shifts the committed multistore root. Behavior is
+// unchanged; the crossrealm38 scenario uses no goroutines or channels.
+//
+// Hash bumped by adding the math/cmplx, math/big, encoding/json and
+// crypto/secp256k1 stdlibs: their sources enter genesis state. The scenario
+// uses none of them; only the genesis package set shifted.
+//
+// Hash bumped by adding the chain/params getters: the stdlib source changed.
+// Hash bumped by adding chain/scheduler and the scheduler vm params.
+// Hash bumped by adding chain.EmitTyped: the chain stdlib source changed.
+// Hash bumped by adding chain/random: a new genesis stdlib package.
+// Hash bumped by adding runtime.View: the chain/runtime stdlib source changed.
+// Hash bumped by capping math/big Mul results: the math/big stdlib source changed.
+// Hash bumped by documenting the scheduler gas limits: the chain/scheduler stdlib source changed.
+// Hash bumped by removing chain/random until the beacon is unbiasable.
+const expectedCrossrealm38Hash = "df45d18185565949e7b5a1d107303b4f7c8381248bb3f824af70027fe8f730a6"
 
 func TestAppHashCrossrealm38(t *testing.T) {
 	env := setupTestEnv()
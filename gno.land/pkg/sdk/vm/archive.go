@@ -0,0 +1,124 @@
+package vm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/chain"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Archived realms are recorded in the iavl store, next to the gno objects:
+//
+//	/archived/<pkgpath>            -> ArchivedRealm (amino JSON)
+var archivedRealmPrefix = []byte("/archived/")
+
+// ArchivedRealm records the archival of a realm by MsgArchivePackage.
+type ArchivedRealm struct {
+	PkgPath string         `json:"pkg_path"`
+	Height  int64          `json:"height"`
+	Refund  crypto.Address `json:"refund"`
+	Deposit std.Coin       `json:"deposit"`
+}
+
+func archivedRealmKey(pkgPath string) []byte {
+	return append(slices.Clone(archivedRealmPrefix), pkgPath...)
+}
+
+func (vm *VMKeeper) getArchivedRealm(ctx sdk.Context, pkgPath string) (ar ArchivedRealm, ok bool) {
+	bz := ctx.Store(vm.iavlKey).Get(ctx.GasContext(), archivedRealmKey(pkgPath))
+	if bz == nil {
+		return ar, false
+	}
+	amino.MustUnmarshalJSON(bz, &ar)
+	return ar, true
+}
+
+// errPackageNotFound is the error for a package missing from the store,
+// telling archived realms apart. It is only called once the package is
+// known to be missing, not to charge the lookup to every message.
+func (vm *VMKeeper) errPackageNotFound(ctx sdk.Context, pkgPath string) error {
+	if ar, ok := vm.getArchivedRealm(ctx, pkgPath); ok {
+		return ErrInvalidPkgPath(fmt.Sprintf("realm %s was archived at height %d", pkgPath, ar.Height))
+	}
+	return ErrInvalidPkgPath("package not found: " + pkgPath)
+}
+
+// ArchivePackage deletes all the objects of an upgradable realm, refunds its
+// whole storage deposit to msg.Refund, and rejects any later call to it. The
+// source of the realm stays readable, and its path cannot be reused. Only the
+// upgrade authority of the realm may archive it, and only if no other realm
+// imports it and none of its objects is escaped.
+//
+// Coins held by the realm address are not moved.
+func (vm *VMKeeper) ArchivePackage(ctx sdk.Context, msg MsgArchivePackage) (err error) {
+	pkgPath := msg.PkgPath
+	gnostore := vm.getGnoTransactionStore(ctx)
+	params := vm.GetParams(ctx)
+
+	if pv := gnostore.GetPackage(pkgPath, false); pv == nil {
+		return vm.errPackageNotFound(ctx, pkgPath)
+	}
+	if _, err := checkUpgrader(gnostore, pkgPath, msg.Caller); err != nil {
+		return err
+	}
+	if importers := gno.FindImporters(gnostore, pkgPath); len(importers) > 0 {
+		return ErrInvalidPackage(fmt.Sprintf("realm %s is imported by %s",
+			pkgPath, strings.Join(importers, ", ")))
+	}
+	if gnostore.HasEscapedObjects(pkgPath) {
+		return ErrInvalidPackage(fmt.Sprintf("realm %s has escaped objects, possibly referenced by other realms", pkgPath))
+	}
+
+	rlm := gnostore.GetPackageRealm(pkgPath)
+	gnostore.DelPackageObjects(pkgPath)
+
+	// Refund the whole deposit, as processStorageDeposit does when all the
+	// storage of a realm is released.
+	isRestricted := slices.Contains(vm.bank.RestrictedDenoms(ctx), ugnot.Denom)
+	receiver := msg.Refund
+	if isRestricted {
+		receiver = params.StorageFeeCollector
+	}
+	refund := std.Coin{Denom: ugnot.Denom, Amount: int64(rlm.Deposit)}
+	released := rlm.Storage
+	if rlm.Deposit > 0 {
+		if err := vm.refundStorageDeposit(ctx, receiver, rlm, refund.Amount, int64(released)); err != nil {
+			return err
+		}
+	}
+	rlm.Storage = 0
+	gnostore.SetPackageRealm(rlm)
+	ctx.EventLogger().EmitEvent(chain.StorageUnlockEvent{
+		BytesDelta:     -int64(released),
+		FeeRefund:      refund,
+		PkgPath:        pkgPath,
+		RefundWithheld: isRestricted,
+	})
+
+	ar := ArchivedRealm{
+		PkgPath: pkgPath,
+		Height:  ctx.BlockHeight(),
+		Refund:  receiver,
+		Deposit: refund,
+	}
+	ctx.Store(vm.iavlKey).Set(ctx.GasContext(), archivedRealmKey(pkgPath), amino.MustMarshalJSON(ar))
+
+	// Log the telemetry
+	logTelemetry(
+		ctx.GasMeter().GasConsumed(),
+		0,
+		attribute.KeyValue{
+			Key:   "operation",
+			Value: attribute.StringValue("m_archivepkg"),
+		},
+	)
+	return nil
+}
@@ -0,0 +1,165 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+func TestVMKeeperArchivePackage(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+	refund := crypto.AddressFromPreimage([]byte("refund"))
+	commitMultiStoreHash(t, env)
+
+	res, err := env.vmk.QueryStorage(env.ctx, upgradeTestPath)
+	require.NoError(t, err)
+	var storage, deposit int64
+	_, err = fmt.Sscanf(res, "storage: %d, deposit: %d", &storage, &deposit)
+	require.NoError(t, err)
+	require.Positive(t, deposit)
+	// The package and file blocks are escaped.
+	require.NotEmpty(t, upgradeTestEscapedKeys(env))
+
+	err = upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.ArchivePackage(ctx, NewMsgArchivePackage(addr, upgradeTestPath, refund))
+	})
+	require.NoError(t, err)
+	commitMultiStoreHash(t, env)
+
+	// The whole deposit is refunded, and no object is left.
+	assert.Equal(t, std.Coins{std.NewCoin(ugnot.Denom, deposit)}, env.bankk.GetCoins(env.ctx, refund))
+	res, err = env.vmk.QueryStorage(env.ctx, upgradeTestPath)
+	require.NoError(t, err)
+	assert.Equal(t, "storage: 0, deposit: 0", res)
+	assert.Equal(t, []string{upgradeTestRealmKey()}, upgradeTestObjectKeys(env))
+	assert.Empty(t, upgradeTestEscapedKeys(env))
+
+	// The source is still readable, but the realm cannot be used anymore.
+	assertArchived := func() {
+		t.Helper()
+		res, err := env.vmk.QueryFile(env.ctx, upgradeTestPath+"/counter.gno")
+		require.NoError(t, err)
+		assert.Equal(t, upgradeTestV1, res)
+
+		_, err = env.vmk.QueryEval(env.ctx, upgradeTestPath, "Count()")
+		assert.ErrorContains(t, fmt.Errorf("%+v", err), "realm gno.land/r/test/counter was archived")
+		err = upgradeTestTx(env, func(ctx sdk.Context) error {
+			_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"b"}))
+			return err
+		})
+		assert.ErrorContains(t, fmt.Errorf("%+v", err), "realm gno.land/r/test/counter was archived")
+		err = upgradeTestTx(env, func(ctx sdk.Context) error {
+			return env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, upgradeTestPath, upgradeTestFiles(addr, upgradeTestV1)))
+		})
+		assert.True(t, errors.Is(err, PkgExistError{}))
+		err = upgradeTestTx(env, func(ctx sdk.Context) error {
+			return env.vmk.ArchivePackage(ctx, NewMsgArchivePackage(addr, upgradeTestPath, refund))
+		})
+		assert.ErrorContains(t, fmt.Errorf("%+v", err), "realm gno.land/r/test/counter was archived")
+	}
+	assertArchived()
+
+	// Also after a restart.
+	env.vmk.gnoStore = nil
+	mcw := env.ctx.MultiStore().MultiCacheWrap()
+	env.vmk.Initialize(log.NewNoopLogger(), mcw)
+	mcw.MultiWrite()
+	assertArchived()
+}
+
+func TestVMKeeperArchivePackage_Unauthorized(t *testing.T) {
+	authority := crypto.AddressFromPreimage([]byte("authority"))
+	env, addr := setupUpgradeTest(t, authority)
+
+	err := upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.ArchivePackage(ctx, NewMsgArchivePackage(addr, upgradeTestPath, addr))
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "is not the upgrade authority")
+}
+
+func TestVMKeeperArchivePackage_Imported(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+	const readerPath = "gno.land/r/test/reader"
+	err := upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, readerPath, []*std.MemFile{
+			{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(readerPath)},
+			{Name: "reader.gno", Body: upgradeTestImporter},
+		}))
+	})
+	require.NoError(t, err)
+
+	err = upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.ArchivePackage(ctx, NewMsgArchivePackage(addr, upgradeTestPath, addr))
+	})
+	require.Error(t, err)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "realm gno.land/r/test/counter is imported by gno.land/r/test/reader")
+
+	res, err := env.vmk.QueryEval(env.ctx, readerPath, "Read()")
+	require.NoError(t, err)
+	assert.Equal(t, `("item a" string)`, res)
+}
+
+func TestVMKeeperArchivePackage_Escaped(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+
+	// The first item is now referenced twice.
+	body := upgradeTestV1 + `
+var first = items[0]
+`
+	err := upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, upgradeTestPath, upgradeTestFiles(addr, body)))
+	})
+	require.NoError(t, err)
+
+	err = upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.ArchivePackage(ctx, NewMsgArchivePackage(addr, upgradeTestPath, addr))
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, InvalidPackageError{}))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "realm gno.land/r/test/counter has escaped objects")
+}
+
+// upgradeTestRealmKey returns the backend key of the realm record of the
+// realm at upgradeTestPath.
+func upgradeTestRealmKey() string {
+	return "oid:" + gnolang.ObjectIDFromPkgPath(upgradeTestPath).String() + "#realm"
+}
+
+// upgradeTestObjectKeys returns the backend keys of the objects of the realm
+// at upgradeTestPath, and of its realm record. Only committed keys are seen.
+func upgradeTestObjectKeys(env testEnv) (keys []string) {
+	oid := gnolang.ObjectIDFromPkgPath(upgradeTestPath)
+	prefix := "oid:" + oid.String()[:len(oid.String())-1]
+	iter := store.PrefixIterator(nil, env.ctx.Store(env.vmk.baseKey), []byte(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	return keys
+}
+
+// upgradeTestEscapedKeys returns the iavl keys of the escaped object hashes of
+// the test realm.
+func upgradeTestEscapedKeys(env testEnv) (keys []string) {
+	oid := gnolang.ObjectIDFromPkgPath(upgradeTestPath)
+	prefix := oid.String()[:len(oid.String())-1]
+	iter := store.PrefixIterator(nil, env.ctx.Store(env.vmk.iavlKey), []byte(prefix))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, string(iter.Key()))
+	}
+	return keys
+}
@@ -1,11 +1,14 @@
 package vm
 
 import (
+	"bytes"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
 
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -27,6 +30,10 @@ func (vh vmHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 	switch msg := msg.(type) {
 	case MsgAddPackage:
 		return vh.handleMsgAddPackage(ctx, msg)
+	case MsgUpgradePackage:
+		return vh.handleMsgUpgradePackage(ctx, msg)
+	case MsgArchivePackage:
+		return vh.handleMsgArchivePackage(ctx, msg)
 	case MsgCall:
 		return vh.handleMsgCall(ctx, msg)
 	case MsgRun:
@@ -46,6 +53,24 @@ func (vh vmHandler) handleMsgAddPackage(ctx sdk.Context, msg MsgAddPackage) sdk.
 	return sdk.Result{}
 }
 
+// Handle MsgUpgradePackage.
+func (vh vmHandler) handleMsgUpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) sdk.Result {
+	err := vh.vm.UpgradePackage(ctx, msg)
+	if err != nil {
+		return abciResult(err)
+	}
+	return sdk.Result{}
+}
+
+// Handle MsgArchivePackage.
+func (vh vmHandler) handleMsgArchivePackage(ctx sdk.Context, msg MsgArchivePackage) sdk.Result {
+	err := vh.vm.ArchivePackage(ctx, msg)
+	if err != nil {
+		return abciResult(err)
+	}
+	return sdk.Result{}
+}
+
 // Handle MsgCall.
 func (vh vmHandler) handleMsgCall(ctx sdk.Context, msg MsgCall) (res sdk.Result) {
 	resstr, err := vh.vm.Call(ctx, msg)
@@ -71,18 +96,22 @@ func (vh vmHandler) handleMsgRun(ctx sdk.Context, msg MsgRun) (res sdk.Result) {
 
 // query paths
 const (
-	QueryRender       = "qrender"
-	QueryFuncs        = "qfuncs"
-	QueryEval         = "qeval"
-	QueryEvalJSON     = "qeval_json"
-	QueryObjectJSON   = "qobject_json"
-	QueryObjectBinary = "qobject_binary"
-	QueryFile         = "qfile"
-	QueryDoc          = "qdoc"
-	QueryPaths        = "qpaths"
-	QueryStorage      = "qstorage"
-	QueryPkgJSON      = "qpkg_json"
-	QueryTypeJSON     = "qtype_json"
+	QueryRender         = "qrender"
+	QueryFuncs          = "qfuncs"
+	QueryEval           = "qeval"
+	QueryEvalJSON       = "qeval_json"
+	QueryView           = "qview"
+	QueryObjectJSON     = "qobject_json"
+	QueryObjectBinary   = "qobject_binary"
+	QueryFile           = "qfile"
+	QueryDoc            = "qdoc"
+	QueryPaths          = "qpaths"
+	QueryStorage        = "qstorage"
+	QueryStorageObjects = "qstorage_objects"
+	QueryPkgJSON        = "qpkg_json"
+	QueryTypeJSON       = "qtype_json"
+	QueryProfile        = "qprofile"
+	QuerySimulate       = "qsimulate"
 )
 
 func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
@@ -100,6 +129,8 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Resp
 		res = vh.queryEval(ctx, req)
 	case QueryEvalJSON:
 		res = vh.queryEvalJSON(ctx, req)
+	case QueryView:
+		res = vh.queryView(ctx, req)
 	case QueryObjectJSON:
 		res = vh.queryObjectJSON(ctx, req)
 	case QueryObjectBinary:
@@ -112,10 +143,16 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Resp
 		res = vh.queryPaths(ctx, req)
 	case QueryStorage:
 		res = vh.queryStorage(ctx, req)
+	case QueryStorageObjects:
+		res = vh.queryStorageObjects(ctx, req)
 	case QueryPkgJSON:
 		res = vh.queryPkg(ctx, req)
 	case QueryTypeJSON:
 		res = vh.queryType(ctx, req)
+	case QueryProfile:
+		res = vh.queryProfile(ctx, req)
+	case QuerySimulate:
+		res = vh.querySimulate(ctx, req)
 	default:
 		return sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest(fmt.Sprintf(
@@ -197,6 +234,69 @@ func (vh vmHandler) queryPaths(ctx sdk.Context, req abci.RequestQuery) (res abci
 	return
 }
 
+// queryProfile simulates the amino-encoded transaction of the request data,
+// and returns the gzipped pprof profile of its gas. The sample type of the
+// profile is the "sample" param, "gas" by default. If the transaction fails,
+// the profile is returned nonetheless, with the error in the log.
+func (vh vmHandler) queryProfile(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	var query string
+	if i := strings.IndexByte(req.Path, '?'); i >= 0 {
+		query = req.Path[i+1:]
+	}
+
+	params, _ := url.ParseQuery(query)
+
+	sampleType := gno.ProfileGas // default
+	if s := params.Get("sample"); len(s) > 0 {
+		sampleType = s
+	}
+	// Fail before running the transaction.
+	if err := gno.CheckProfileSampleType(sampleType); err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+
+	var tx std.Tx
+	if err := amino.Unmarshal(req.Data, &tx); err != nil {
+		return sdk.ABCIResponseQueryFromError(
+			std.ErrTxDecode(fmt.Sprintf("invalid transaction: %v", err)))
+	}
+
+	p, err := vh.vm.Profile(ctx, tx)
+	if err != nil {
+		res.Log = err.Error()
+	}
+
+	var buf bytes.Buffer
+	if err := p.WriteProfile(&buf, sampleType, nil); err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+	res.Data = buf.Bytes()
+	return
+}
+
+// querySimulate simulates the transaction of the amino JSON SimulateRequest
+// of the request data, with its overrides, and returns the amino JSON
+// SimulateResult. If the transaction fails, the result is returned
+// nonetheless, with the error in the log too.
+func (vh vmHandler) querySimulate(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	var sreq SimulateRequest
+	if err := amino.UnmarshalJSON(req.Data, &sreq); err != nil {
+		return sdk.ABCIResponseQueryFromError(
+			std.ErrTxDecode(fmt.Sprintf("invalid simulation request: %v", err)))
+	}
+
+	result, err := vh.vm.Simulate(ctx, sreq)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+	res.Log = result.Error
+	res.Data, err = amino.MarshalJSON(result)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+	return
+}
+
 // queryEval evaluates any expression in readonly mode and returns the results.
 func (vh vmHandler) queryEval(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	pkgPath, expr := parseQueryEvalData(string(req.Data))
@@ -221,6 +321,19 @@ func (vh vmHandler) queryEvalJSON(ctx sdk.Context, req abci.RequestQuery) (res a
 	return
 }
 
+// queryView evaluates any expression in a read-only view and returns the
+// results.
+func (vh vmHandler) queryView(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath, expr := parseQueryEvalData(string(req.Data))
+	result, err := vh.vm.QueryView(ctx, pkgPath, expr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = []byte(result)
+	return
+}
+
 // queryObjectJSON retrieves a persisted object by ObjectID and returns its Amino JSON representation.
 func (vh vmHandler) queryObjectJSON(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	oidStr := string(req.Data)
@@ -306,6 +419,19 @@ func (vh vmHandler) queryStorage(ctx sdk.Context, req abci.RequestQuery) (res ab
 	return
 }
 
+// queryStorageObjects returns the storage size and deposit for a realm, and
+// the storage used by each of its objects
+func (vh vmHandler) queryStorageObjects(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgpath := string(req.Data)
+	result, err := vh.vm.QueryStorageObjects(ctx, pkgpath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = []byte(result)
+	return
+}
+
 // queryPkg returns the named block variables of a package as Amino JSON.
 func (vh vmHandler) queryPkg(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	pkgPath := string(req.Data)
@@ -1,13 +1,18 @@
 package vm
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"testing"
 
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
@@ -779,3 +784,92 @@ func TestVmHandlerQuery_TypeJSON_NotFound(t *testing.T) {
 	assert.False(t, res.IsOK(), "should have an error")
 	assert.Regexp(t, `invalid expression`, res.Error.Error())
 }
+
+func TestVmHandlerQuery_Profile(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	vmHandler := env.vmh
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	const pkgpath = "gno.land/r/hello"
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgpath)},
+		{Name: "hello.gno", Body: `
+package hello
+
+var counter int
+
+func Burn(cur realm, n int) {
+	for i := 0; i < n; i++ {
+		counter++
+	}
+}
+`},
+	}
+	msg1 := NewMsgAddPackage(addr, pkgpath, files)
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	profile := func(path string, n int, gasWanted int64) abci.ResponseQuery {
+		msg := NewMsgCall(addr, nil, pkgpath, "Burn", []string{fmt.Sprint(n)})
+		tx := std.NewTx([]std.Msg{msg},
+			std.NewFee(gasWanted, std.MustParseCoin(ugnot.ValueString(1))),
+			[]std.Signature{}, "")
+		req := abci.RequestQuery{
+			Path: path,
+			Data: amino.MustMarshal(tx),
+		}
+		return vmHandler.Query(env.ctx, req)
+	}
+	// decode returns the strings of the profile.
+	decode := func(data []byte) string {
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		require.NoError(t, err)
+		b, err := io.ReadAll(zr)
+		require.NoError(t, err)
+		return string(b)
+	}
+
+	// A successful call is profiled, without changing the state.
+	res := profile("vm/qprofile", 10, 0)
+	require.True(t, res.IsOK(), "qprofile should succeed, got error: %v", res.Error)
+	assert.Empty(t, res.Log)
+	assert.Contains(t, decode(res.Data), "gno.land/r/hello.Burn")
+	res = vmHandler.Query(env.ctx, abci.RequestQuery{
+		Path: "vm/qeval",
+		Data: []byte("gno.land/r/hello.counter"),
+	})
+	require.True(t, res.IsOK(), "qeval should succeed, got error: %v", res.Error)
+	assert.Equal(t, "(0 int)", string(res.Data))
+
+	// A call running out of gas is profiled up to the failure.
+	res = profile("vm/qprofile?sample=cpu", 1_000_000, 10_000_000)
+	require.True(t, res.IsOK(), "qprofile should succeed, got error: %v", res.Error)
+	assert.Contains(t, res.Log, "out of gas")
+	prof := decode(res.Data)
+	assert.Contains(t, prof, "gno.land/r/hello.Burn")
+	assert.Contains(t, prof, "gno.land/r/hello/hello.gno")
+
+	// Invalid requests.
+	res = profile("vm/qprofile?sample=invalid", 10, 0)
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.Contains(t, res.Error.Error(), `invalid profile sample type "invalid"`)
+	// The sample type is checked before the transaction is even decoded.
+	res = vmHandler.Query(env.ctx, abci.RequestQuery{
+		Path: "vm/qprofile?sample=invalid",
+		Data: []byte("invalid"),
+	})
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.Contains(t, res.Error.Error(), `invalid profile sample type "invalid"`)
+	res = vmHandler.Query(env.ctx, abci.RequestQuery{
+		Path: "vm/qprofile",
+		Data: []byte("invalid"),
+	})
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.Contains(t, res.Error.Error(), "tx decode error")
+}
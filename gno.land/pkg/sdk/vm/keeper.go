@@ -30,6 +30,7 @@ import (
 	"github.com/gnolang/gno/gnovm/stdlibs"
 	"github.com/gnolang/gno/gnovm/stdlibs/chain"
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -57,6 +58,8 @@ const (
 // smart contracts programming (scripting).
 type VMKeeperI interface {
 	AddPackage(ctx sdk.Context, msg MsgAddPackage) error
+	UpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) error
+	ArchivePackage(ctx sdk.Context, msg MsgArchivePackage) error
 	Call(ctx sdk.Context, msg MsgCall) (res string, err error)
 	QueryEval(ctx sdk.Context, pkgPath string, expr string) (res string, err error)
 	QueryEvalJSON(ctx sdk.Context, pkgPath string, expr string) (res string, err error)
@@ -67,6 +70,7 @@ type VMKeeperI interface {
 	LoadStdlibCached(ctx sdk.Context, stdlibDir string)
 	MakeGnoTransactionStore(ctx sdk.Context) sdk.Context
 	CommitGnoTransactionStore(ctx sdk.Context)
+	RunScheduledCalls(ctx sdk.Context) []abci.Event
 	PopulateStdlibCache()
 	PopulateStdlibCacheFrom(ms store.MultiStore)
 	InitGenesis(ctx sdk.Context, data GenesisState)
@@ -191,6 +195,18 @@ func (vm *VMKeeper) Initialize(
 	}
 }
 
+// Reinitialize discards the gno store along with its caches, and initializes
+// it again from ms. It is called when the multistore is replaced by a state
+// sync restore.
+func (vm *VMKeeper) Reinitialize(
+	logger *slog.Logger,
+	ms store.MultiStore,
+) {
+	vm.gnoStore = nil
+	vm.typeCheckCache = gno.TypeCheckCache{}
+	vm.Initialize(logger, ms)
+}
+
 // PopulateStdlibCache populates the stdlib byte cache on the gno store.
 func (vm *VMKeeper) PopulateStdlibCache() {
 	vm.gnoStore.PopulateStdlibCache(stdlibs.InitOrder())
@@ -377,9 +393,17 @@ type vmkContextKey int
 const (
 	vmkContextKeyStore vmkContextKey = iota
 	vmkContextKeyTypeCheckCache
+	vmkContextKeyProfiler
+	vmkContextKeyOpsLog
 )
 
 func (vm *VMKeeper) newGnoTransactionStore(ctx sdk.Context) gno.TransactionStore {
+	return vm.beginGnoTransaction(ctx, vm.gnoStore)
+}
+
+// beginGnoTransaction begins a transaction of parent, on the stores and with
+// the gas meter of ctx.
+func (vm *VMKeeper) beginGnoTransaction(ctx sdk.Context, parent gno.Store) gno.TransactionStore {
 	base := ctx.Store(vm.baseKey)
 	iavl := ctx.Store(vm.iavlKey)
 	gctx := ctx.GasContext()
@@ -395,7 +419,7 @@ func (vm *VMKeeper) newGnoTransactionStore(ctx sdk.Context) gno.TransactionStore
 	}
 	gasMeter := ctx.GasMeter()
 
-	return vm.gnoStore.BeginTransaction(base, iavl, gctx, gasMeter)
+	return parent.BeginTransaction(base, iavl, gctx, gasMeter)
 }
 
 func (vm *VMKeeper) MakeGnoTransactionStore(ctx sdk.Context) sdk.Context {
@@ -415,9 +439,33 @@ func (vm *VMKeeper) getTypeCheckCache(ctx sdk.Context) gno.TypeCheckCache {
 func (vm *VMKeeper) getGnoTransactionStore(ctx sdk.Context) gno.TransactionStore {
 	txStore := ctx.Value(vmkContextKeyStore).(gno.TransactionStore)
 	txStore.ClearObjectCache()
+	if w := vm.getOpsLog(ctx); w != nil {
+		txStore.SetLogStoreOps(w) // cleared with the object cache.
+	}
 	return txStore
 }
 
+// getProfiler returns the profiler of a transaction simulated by Profile,
+// or nil. Profiling is never enabled outside of simulations.
+func (vm *VMKeeper) getProfiler(ctx sdk.Context) *gno.Profiler {
+	if ctx.Mode() != sdk.RunTxModeSimulate {
+		return nil
+	}
+	p, _ := ctx.Value(vmkContextKeyProfiler).(*gno.Profiler)
+	return p
+}
+
+// getOpsLog returns the writer of the store operations of a transaction
+// simulated by Simulate, or nil. Like profiling, it is never enabled outside
+// of simulations.
+func (vm *VMKeeper) getOpsLog(ctx sdk.Context) io.Writer {
+	if ctx.Mode() != sdk.RunTxModeSimulate {
+		return nil
+	}
+	w, _ := ctx.Value(vmkContextKeyOpsLog).(io.Writer)
+	return w
+}
+
 // Namespace can be either a user or crypto address.
 var reNamespace = regexp.MustCompile(`^[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}/(?:r|p)/([\.~_a-zA-Z0-9-]+)`)
 
@@ -468,6 +516,7 @@ func (vm *VMKeeper) callRealmBool(
 			Context:            msgCtx,
 			Alloc:              store.GetAllocator(),
 			GasMeter:           ctx.GasMeter(),
+			Profiler:           vm.getProfiler(ctx),
 			BoundedPanicRender: true,
 		})
 	defer m.Release()
@@ -666,6 +715,12 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	if pv != nil && !pv.Private {
 		return ErrPkgAlreadyExists("package already exists: " + pkgPath)
 	}
+	if pv == nil && gno.IsRealmPath(pkgPath) {
+		// The source of an archived realm is kept: its path cannot be reused.
+		if _, ok := vm.getArchivedRealm(ctx, pkgPath); ok {
+			return ErrPkgAlreadyExists("realm was archived: " + pkgPath)
+		}
+	}
 	if pv != nil {
 		// A private package is being redeployed (non-private re-adds were
 		// rejected above). Clear its prior mempackage blobs first: AddMemPackage
@@ -697,6 +752,7 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	// use the parameters before executing the message, as they may change during execution.
 	// The message should not fail due to parameter changes in the same transaction.
 	params := vm.GetParams(ctx)
+	opts.AllowConcurrency = params.AllowConcurrency
 	chargePreprocessGas(ctx, params, memPkg, "AddPackagePreprocess")
 	// Validate Gno syntax and type check.
 	_, err = gno.TypeCheckMemPackage(memPkg, opts)
@@ -726,6 +782,9 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	if memPkg.GetFile("gno.mod") != nil {
 		return ErrInvalidPackage("gno.mod file is deprecated and not allowed, run 'gno mod tidy' to upgrade to gnomod.toml")
 	}
+	if err := checkUpgradeAuthority(gm, pkgPath); err != nil {
+		return err
+	}
 
 	// Patch gnomod.toml metadata
 	gm.Module = pkgPath // XXX: if gm.Module != msg.Package.Path { panic() }?
@@ -768,19 +827,22 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 		SessionAccount:  getSessionAccount(ctx, creator),
 	}
 	// Parse and run the files, construct *PV.
 	m2 := gno.NewMachineWithOptions(
 		gno.MachineOptions{
-			PkgPath:            "",
-			Output:             vm.Output,
-			Store:              gnostore,
-			Alloc:              gnostore.GetAllocator(),
-			Context:            msgCtx,
-			GasMeter:           ctx.GasMeter(),
-			BoundedPanicRender: true,
+			PkgPath:             "",
+			Output:              vm.Output,
+			Store:               gnostore,
+			Alloc:               gnostore.GetAllocator(),
+			Context:             msgCtx,
+			GasMeter:            ctx.GasMeter(),
+			Profiler:            vm.getProfiler(ctx),
+			BoundedPanicRender:  true,
+			DisallowConcurrency: !params.AllowConcurrency,
 		})
 	defer m2.Release()
 	defer doRecover(m2, &err)
@@ -835,6 +897,9 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	gnostore := vm.getGnoTransactionStore(ctx)
 	// Get the package and function type.
 	pv := gnostore.GetPackage(pkgPath, false)
+	if pv == nil {
+		return "", vm.errPackageNotFound(ctx, pkgPath)
+	}
 	pl := gno.PackageNodeLocation(pkgPath)
 	pn := gnostore.GetBlockNode(pl).(*gno.PackageNode)
 	ft := pn.GetStaticTypeOf(gnostore, gno.Name(fnc)).(*gno.FuncType)
@@ -879,6 +944,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 		SessionAccount:  getSessionAccount(ctx, caller),
 	}
@@ -889,13 +955,15 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	// Construct machine and evaluate.
 	m := gno.NewMachineWithOptions(
 		gno.MachineOptions{
-			PkgPath:            "",
-			Output:             vm.Output,
-			Store:              gnostore,
-			Context:            msgCtx,
-			Alloc:              gnostore.GetAllocator(),
-			GasMeter:           ctx.GasMeter(),
-			BoundedPanicRender: true,
+			PkgPath:             "",
+			Output:              vm.Output,
+			Store:               gnostore,
+			Context:             msgCtx,
+			Alloc:               gnostore.GetAllocator(),
+			GasMeter:            ctx.GasMeter(),
+			Profiler:            vm.getProfiler(ctx),
+			BoundedPanicRender:  true,
+			DisallowConcurrency: !params.AllowConcurrency,
 		})
 	xn := m.MustParseExpr(expr)
 	// Send send-coins to pkg from caller.
@@ -1081,10 +1149,11 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 	chargePreprocessGas(ctx, params, memPkg, "RunPreprocess")
 	// Validate Gno syntax and type check.
 	_, err = gno.TypeCheckMemPackage(memPkg, gno.TypeCheckOptions{
-		Getter:     gnostore,
-		TestGetter: vm.testStdlibCache.memPackageGetter(gnostore),
-		Mode:       gno.TCLatestRelaxed,
-		Cache:      vm.getTypeCheckCache(ctx),
+		Getter:           gnostore,
+		TestGetter:       vm.testStdlibCache.memPackageGetter(gnostore),
+		Mode:             gno.TCLatestRelaxed,
+		Cache:            vm.getTypeCheckCache(ctx),
+		AllowConcurrency: params.AllowConcurrency,
 	})
 	if err != nil {
 		return "", ErrTypeCheck(err)
@@ -1109,6 +1178,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		OriginSendSpent: new(std.Coins),
 		Banker:          NewSDKBanker(vm, ctx),
 		Params:          NewSDKParams(vm.prmk, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
 		EventLogger:     ctx.EventLogger(),
 		SessionAccount:  getSessionAccount(ctx, caller),
 	}
@@ -1139,13 +1209,15 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		}
 		m := gno.NewMachineWithOptions(
 			gno.MachineOptions{
-				PkgPath:            "",
-				Output:             output,
-				Store:              gnostore,
-				Alloc:              alloc,
-				Context:            msgCtx,
-				GasMeter:           ctx.GasMeter(),
-				BoundedPanicRender: true,
+				PkgPath:             "",
+				Output:              output,
+				Store:               gnostore,
+				Alloc:               alloc,
+				Context:             msgCtx,
+				GasMeter:            ctx.GasMeter(),
+				Profiler:            vm.getProfiler(ctx),
+				BoundedPanicRender:  true,
+				DisallowConcurrency: !params.AllowConcurrency,
 			})
 		defer m.Release()
 		defer doRecover(m, &err)
@@ -1160,13 +1232,15 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 
 	m2 := gno.NewMachineWithOptions(
 		gno.MachineOptions{
-			PkgPath:            "",
-			Output:             output,
-			Store:              gnostore,
-			Alloc:              alloc,
-			Context:            msgCtx,
-			GasMeter:           ctx.GasMeter(),
-			BoundedPanicRender: true,
+			PkgPath:             "",
+			Output:              output,
+			Store:               gnostore,
+			Alloc:               alloc,
+			Context:             msgCtx,
+			GasMeter:            ctx.GasMeter(),
+			Profiler:            vm.getProfiler(ctx),
+			BoundedPanicRender:  true,
+			DisallowConcurrency: !params.AllowConcurrency,
 		})
 	defer m2.Release()
 	m2.SetActivePackage(pv)
@@ -1279,9 +1353,7 @@ func (vm *VMKeeper) QueryFuncs(ctx sdk.Context, pkgPath string) (fsigs FunctionS
 	// Get Package.
 	pv := store.GetPackage(pkgPath, false)
 	if pv == nil {
-		err = ErrInvalidPkgPath(fmt.Sprintf(
-			"package not found: %s", pkgPath))
-		return nil, err
+		return nil, vm.errPackageNotFound(ctx, pkgPath)
 	}
 	// Iterate over public functions.
 	pblock := pv.GetBlock(store)
@@ -1333,7 +1405,19 @@ func (vm *VMKeeper) QueryFuncs(ctx sdk.Context, pkgPath string) (fsigs FunctionS
 
 // QueryEval evaluates a gno expression (readonly, for ABCI queries).
 func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
-	err = vm.withQueryEvalMachine(ctx, pkgPath, expr, func(m *gno.Machine, rtvs []gno.TypedValue) {
+	return vm.queryEval(ctx, pkgPath, expr, false)
+}
+
+// QueryView is like QueryEval, but evaluates expr in a read-only view (see
+// runtime.View): an expression trying to modify a persisted object fails,
+// instead of returning results based on changes that will never be
+// committed.
+func (vm *VMKeeper) QueryView(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
+	return vm.queryEval(ctx, pkgPath, expr, true)
+}
+
+func (vm *VMKeeper) queryEval(ctx sdk.Context, pkgPath string, expr string, view bool) (res string, err error) {
+	err = vm.withQueryEvalMachine(ctx, pkgPath, expr, view, func(m *gno.Machine, rtvs []gno.TypedValue) {
 		for i, rtv := range rtvs {
 			res += rtv.String()
 			if i < len(rtvs)-1 {
@@ -1351,7 +1435,7 @@ func (vm *VMKeeper) QueryEval(ctx sdk.Context, pkgPath string, expr string) (res
 // The result is expected to be a single string (not a tuple).
 func (vm *VMKeeper) QueryEvalString(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
 	var cbErr error
-	err = vm.withQueryEvalMachine(ctx, pkgPath, expr, func(m *gno.Machine, rtvs []gno.TypedValue) {
+	err = vm.withQueryEvalMachine(ctx, pkgPath, expr, false, func(m *gno.Machine, rtvs []gno.TypedValue) {
 		if len(rtvs) != 1 {
 			cbErr = errors.New("expected 1 string result, got %d", len(rtvs))
 			return
@@ -1375,8 +1459,8 @@ func (vm *VMKeeper) QueryEvalString(ctx sdk.Context, pkgPath string, expr string
 // with the live machine and its result values before releasing the machine.
 // Callers that need to invoke methods on result values (e.g. call .Error() on
 // an error-implementing return) must use this helper so the machine is still
-// alive when fn runs.
-func (vm *VMKeeper) withQueryEvalMachine(ctx sdk.Context, pkgPath string, expr string, fn func(m *gno.Machine, rtvs []gno.TypedValue)) (err error) {
+// alive when fn runs. If view is true, expr is evaluated in a read-only view.
+func (vm *VMKeeper) withQueryEvalMachine(ctx sdk.Context, pkgPath string, expr string, view bool, fn func(m *gno.Machine, rtvs []gno.TypedValue)) (err error) {
 	ctx = ctx.WithGasMeter(store.NewGasMeter(maxGasQuery))
 	alloc := gno.NewAllocator(maxAllocQuery)
 	gnostore := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
@@ -1387,8 +1471,7 @@ func (vm *VMKeeper) withQueryEvalMachine(ctx sdk.Context, pkgPath string, expr s
 	// Get Package.
 	pv := gnostore.GetPackage(pkgPath, false)
 	if pv == nil {
-		return ErrInvalidPkgPath(fmt.Sprintf(
-			"package not found: %s", pkgPath))
+		return vm.errPackageNotFound(ctx, pkgPath)
 	}
 	// Construct new machine.
 	chainDomain := vm.getChainDomainParam(ctx)
@@ -1422,6 +1505,7 @@ func (vm *VMKeeper) withQueryEvalMachine(ctx sdk.Context, pkgPath string, expr s
 		})
 	defer m.Release()
 	defer doRecoverQuery(m, &err)
+	m.InView = view
 	xx, err := m.ParseExpr(expr)
 	if err != nil {
 		return err
@@ -1496,9 +1580,76 @@ func (vm *VMKeeper) QueryStorage(ctx sdk.Context, pkgPath string) (string, error
 	return res, nil
 }
 
+// QueryStorageObjects returns storage and deposit for a realm, like
+// QueryStorage, followed by the size, reference count and path of each of its
+// objects reachable from its package value, largest first.
+func (vm *VMKeeper) QueryStorageObjects(ctx sdk.Context, pkgPath string) (res string, err error) {
+	defer doRecoverQueryNoMachine(&err)
+	ctx = ctx.WithGasMeter(store.NewGasMeter(maxGasQuery))
+	gnostore := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+	rlm := gnostore.GetPackageRealm(pkgPath)
+	if rlm == nil {
+		return "", ErrInvalidPkgPath("realm not found: " + pkgPath)
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "storage: %d, deposit: %d\n", rlm.Storage, rlm.Deposit)
+	if err := gno.WriteObjectsStorage(&sb, gno.RealmObjectsStorage(gnostore, pkgPath)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// Profile simulates the messages of tx against the current state, and
+// returns the profile of the gas they used. Signatures and fees are not
+// checked, and the state is left untouched. The gas is limited by the gas
+// wanted of tx, if any, and by maxGasQuery. Simulation stops at the first
+// failing message, whose error is returned along with the profile up to and
+// including the failure, so that an out of gas transaction can be profiled.
+func (vm *VMKeeper) Profile(ctx sdk.Context, tx std.Tx) (p *gno.Profiler, err error) {
+	gasLimit := int64(maxGasQuery)
+	if tx.Fee.GasWanted > 0 {
+		gasLimit = min(tx.Fee.GasWanted, gasLimit)
+	}
+	p = gno.NewProfiler()
+	// Creating the store may already run out of gas.
+	defer doRecoverQueryNoMachine(&err)
+	ctx, _ = ctx.CacheContext() // never written
+	// Wrap the gas meter before creating the store, to profile its gas too.
+	ctx = ctx.
+		WithMode(sdk.RunTxModeSimulate).
+		WithGasMeter(p.GasMeter(store.NewGasMeter(gasLimit))).
+		WithValue(vmkContextKeyProfiler, p)
+	ctx = vm.MakeGnoTransactionStore(ctx) // never committed
+	for i, msg := range tx.GetMsgs() {
+		if err := vm.simulateMsg(ctx, msg); err != nil {
+			return p, fmt.Errorf("msg #%d: %w", i, err)
+		}
+	}
+	return p, nil
+}
+
+func (vm *VMKeeper) simulateMsg(ctx sdk.Context, msg std.Msg) (err error) {
+	defer doRecoverQueryNoMachine(&err)
+	switch msg := msg.(type) {
+	case MsgAddPackage:
+		err = vm.AddPackage(ctx, msg)
+	case MsgUpgradePackage:
+		err = vm.UpgradePackage(ctx, msg)
+	case MsgArchivePackage:
+		err = vm.ArchivePackage(ctx, msg)
+	case MsgCall:
+		_, err = vm.Call(ctx, msg)
+	case MsgRun:
+		_, err = vm.Run(ctx, msg)
+	default:
+		err = std.ErrUnknownRequest(fmt.Sprintf("unrecognized vm message type: %T", msg))
+	}
+	return err
+}
+
 // QueryEvalJSON evaluates a gno expression and returns JSON (Amino-encoded) results.
 func (vm *VMKeeper) QueryEvalJSON(ctx sdk.Context, pkgPath string, expr string) (res string, err error) {
-	err = vm.withQueryEvalMachine(ctx, pkgPath, expr, func(m *gno.Machine, rtvs []gno.TypedValue) {
+	err = vm.withQueryEvalMachine(ctx, pkgPath, expr, false, func(m *gno.Machine, rtvs []gno.TypedValue) {
 		res = stringifyJSONResults(m, rtvs, nil)
 	})
 	if err != nil {
@@ -1570,7 +1721,7 @@ func (vm *VMKeeper) QueryPkg(ctx sdk.Context, pkgPath string) (res string, err e
 
 	pv := gnostore.GetPackage(pkgPath, false)
 	if pv == nil {
-		return "", ErrInvalidPkgPath(fmt.Sprintf("package not found: %s", pkgPath))
+		return "", vm.errPackageNotFound(ctx, pkgPath)
 	}
 
 	block := resolveBlock(gnostore, pv.Block)
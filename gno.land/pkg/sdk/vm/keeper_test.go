@@ -127,6 +127,78 @@ func Echo(cur realm) string {
 	assert.Nil(t, memFile)
 }
 
+// Goroutines, channels and select are rejected unless the allow_concurrency
+// param is set.
+func TestVMKeeperAddPackage_Concurrency(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	// Create test package.
+	const pkgPath = "gno.land/p/test/conc"
+	files := []*std.MemFile{
+		{
+			Name: "conc.gno",
+			Body: `package conc
+
+func Sum(xs []int) int {
+	ch := make(chan int)
+	for _, x := range xs {
+		go func() { ch <- x }()
+	}
+	sum := 0
+	for range xs {
+		sum += <-ch
+	}
+	return sum
+}`,
+		},
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+	}
+	msg1 := NewMsgAddPackage(addr, pkgPath, files)
+
+	err := env.vmk.AddPackage(ctx, msg1)
+	require.Error(t, err)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "goroutines are not permitted")
+	assert.Nil(t, env.vmk.getGnoTransactionStore(ctx).GetPackage(pkgPath, false))
+
+	params := env.vmk.GetParams(ctx)
+	params.AllowConcurrency = true
+	require.NoError(t, env.vmk.SetParams(ctx, params))
+
+	err = env.vmk.AddPackage(ctx, msg1)
+	require.NoError(t, err)
+	assert.NotNil(t, env.vmk.getGnoTransactionStore(ctx).GetPackage(pkgPath, false))
+
+	// Code added while concurrency was allowed can still be run, as the
+	// VM rejects it too once the param is unset.
+	script := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest("gno.land/e/test/script")},
+		{Name: "script.gno", Body: `package main
+
+import "gno.land/p/test/conc"
+
+func main() {
+	println(conc.Sum([]int{1, 2, 3}))
+}`},
+	}
+	res, err := env.vmk.Run(ctx, NewMsgRun(addr, std.Coins{}, script))
+	require.NoError(t, err)
+	assert.Equal(t, "6\n", res)
+
+	params.AllowConcurrency = false
+	require.NoError(t, env.vmk.SetParams(ctx, params))
+
+	_, err = env.vmk.Run(ctx, NewMsgRun(addr, std.Coins{}, script))
+	require.Error(t, err)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "channels are not permitted")
+}
+
 // A package with no production .gno files (only _test.gno / _filetest.gno)
 // must be rejected: the storage split writes no prod blob for it, so a
 // restarted node would rebuild no PackageNode while a non-restarted node
@@ -1794,6 +1866,31 @@ func TestStorageDepositPriceDecrease(t *testing.T) {
 		"residual deposit must value retained storage at the lock price")
 }
 
+func TestVMKeeperQueryStorageObjects(t *testing.T) {
+	env, _ := setupUpgradeTest(t, crypto.Address{})
+
+	res, err := env.vmk.QueryStorageObjects(env.ctx, upgradeTestPath)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(res), "\n")
+	require.Greater(t, len(lines), 3)
+	storage, _ := parseStorageInfo(t, lines[0])
+	assert.Regexp(t, `^SIZE +REFS +OBJECT +KIND +TYPE +PATH$`, lines[1])
+
+	// The objects account for the whole storage of the realm.
+	var total uint64
+	_, err = fmt.Sscanf(lines[len(lines)-1], "%d", &total)
+	require.NoError(t, err)
+	assert.Equal(t, storage, total)
+
+	assert.Contains(t, res, "(package block)")
+	assert.Contains(t, res, "(file block counter.gno)")
+	assert.Regexp(t, `StructValue +gno\.land/r/test/counter\.Item +items\[0\]`, res)
+
+	_, err = env.vmk.QueryStorageObjects(env.ctx, "gno.land/r/test/missing")
+	assert.True(t, errors.Is(err, InvalidPkgPathError{}))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "realm not found")
+}
+
 // parseStorageInfo parses the "storage: X, deposit: Y" string from QueryStorage
 // into storage and deposit values.
 func parseStorageInfo(t *testing.T, info string) (storage uint64, deposit uint64) {
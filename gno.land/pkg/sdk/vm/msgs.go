@@ -95,6 +95,119 @@ func (msg MsgAddPackage) SpendForSigner(signer crypto.Address) std.Coins {
 	return msg.Send
 }
 
+//----------------------------------------
+// MsgUpgradePackage
+
+// MsgUpgradePackage - replace the code of an upgradable realm
+type MsgUpgradePackage struct {
+	Upgrader   crypto.Address  `json:"upgrader" yaml:"upgrader"`
+	Package    *std.MemPackage `json:"package" yaml:"package"`
+	MaxDeposit std.Coins       `json:"max_deposit" yaml:"max_deposit"`
+}
+
+var _ std.Msg = MsgUpgradePackage{}
+
+// NewMsgUpgradePackage - upload the new files of a realm.
+func NewMsgUpgradePackage(upgrader crypto.Address, pkgPath string, files []*std.MemFile) MsgUpgradePackage {
+	msg := NewMsgAddPackage(upgrader, pkgPath, files)
+	return MsgUpgradePackage{
+		Upgrader: upgrader,
+		Package:  msg.Package,
+	}
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) Route() string { return RouterKey }
+
+// Implements Msg.
+func (msg MsgUpgradePackage) Type() string { return "upgrade_package" }
+
+// Implements Msg.
+func (msg MsgUpgradePackage) ValidateBasic() error {
+	if msg.Upgrader.IsZero() {
+		return std.ErrInvalidAddress("missing upgrader address")
+	}
+	if msg.Package == nil || msg.Package.Path == "" {
+		return ErrInvalidPkgPath("missing package path")
+	}
+	if !gno.IsRealmPath(msg.Package.Path) {
+		return ErrInvalidPkgPath("pkgpath must be of a realm")
+	}
+	if !msg.MaxDeposit.IsValid() {
+		return std.ErrInvalidCoins(msg.MaxDeposit.String())
+	}
+	if len(msg.Package.Files) == 0 {
+		return ErrInvalidFile("no files in MsgUpgradePackage")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// Implements Msg.
+func (msg MsgUpgradePackage) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Upgrader}
+}
+
+//----------------------------------------
+// MsgArchivePackage
+
+// MsgArchivePackage - delete the state of an upgradable realm, keeping its
+// source, and refund its storage deposit
+type MsgArchivePackage struct {
+	Caller  crypto.Address `json:"caller" yaml:"caller"`
+	PkgPath string         `json:"pkg_path" yaml:"pkg_path"`
+	Refund  crypto.Address `json:"refund" yaml:"refund"`
+}
+
+var _ std.Msg = MsgArchivePackage{}
+
+// NewMsgArchivePackage - archive the realm at pkgPath, refunding its deposit
+// to refund.
+func NewMsgArchivePackage(caller crypto.Address, pkgPath string, refund crypto.Address) MsgArchivePackage {
+	return MsgArchivePackage{
+		Caller:  caller,
+		PkgPath: pkgPath,
+		Refund:  refund,
+	}
+}
+
+// Implements Msg.
+func (msg MsgArchivePackage) Route() string { return RouterKey }
+
+// Implements Msg.
+func (msg MsgArchivePackage) Type() string { return "archive_package" }
+
+// Implements Msg.
+func (msg MsgArchivePackage) ValidateBasic() error {
+	if msg.Caller.IsZero() {
+		return std.ErrInvalidAddress("missing caller address")
+	}
+	if msg.Refund.IsZero() {
+		return std.ErrInvalidAddress("missing refund address")
+	}
+	if msg.PkgPath == "" {
+		return ErrInvalidPkgPath("missing package path")
+	}
+	if !gno.IsRealmPath(msg.PkgPath) {
+		return ErrInvalidPkgPath("pkgpath must be of a realm")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgArchivePackage) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// Implements Msg.
+func (msg MsgArchivePackage) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.Caller}
+}
+
 //----------------------------------------
 // MsgCall
 
@@ -17,6 +17,8 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	MsgCall{}, "m_call",
 	MsgRun{}, "m_run",
 	MsgAddPackage{}, "m_addpkg", // TODO rename both to MsgAddPkg?
+	MsgUpgradePackage{}, "m_upgradepkg",
+	MsgArchivePackage{}, "m_archivepkg",
 
 	// errors
 	InvalidPkgPathError{}, "InvalidPkgPathError",
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
@@ -50,6 +51,11 @@ const (
 	// machine, and the host-machine calibration factor is the dominant
 	// uncertainty).
 	preprocessGasPerByteDefault = int64(1_250)
+	// Calls scheduled with chain/scheduler share a budget of 100M gas per
+	// block (1/30 of the default max block gas), and prepay their gas at
+	// the chain's initial gas price.
+	schedulerBlockGasDefault = int64(100_000_000)
+	schedulerGasPriceDefault = "1ugnot/1000gas"
 )
 
 var ASCIIDomain = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)+[A-Za-z]{2,}$`)
@@ -79,6 +85,18 @@ type Params struct {
 	// MsgAddPackage and MsgRun; it is NOT part of store.GasConfig (charged
 	// directly in the keeper), so it has no ApplyToGasConfig entry.
 	PreprocessGasPerByte int64 `json:"preprocess_gas_per_byte" yaml:"preprocess_gas_per_byte"`
+	// AllowConcurrency permits goroutines, channels and select statements
+	// in packages added with MsgAddPackage and in MsgRun scripts. Defaults
+	// to false: concurrency is available to off-chain tooling only.
+	AllowConcurrency bool `json:"allow_concurrency" yaml:"allow_concurrency"`
+	// SchedulerBlockGas is the gas available each block to the calls
+	// scheduled with chain/scheduler, each of which also uses a fixed
+	// overhead of it on top of its gas limit. Must leave room for at least
+	// one call of the minimum gas limit.
+	SchedulerBlockGas int64 `json:"scheduler_block_gas" yaml:"scheduler_block_gas"`
+	// SchedulerGasPrice converts the gas limit of a scheduled call into
+	// the ugnot deposit escrowed from the scheduling realm.
+	SchedulerGasPrice string `json:"scheduler_gas_price" yaml:"scheduler_gas_price"`
 }
 
 // NewParams creates a new Params object
@@ -98,6 +116,8 @@ func NewParams(namesPkgPath, claPkgPath, chainDomain, defaultDeposit, storagePri
 		FixedWriteDepth100:   minWriteDepth100,
 		IterNextCostFlat:     iterNextCostFlat,
 		PreprocessGasPerByte: preprocessGasPerByte,
+		SchedulerBlockGas:    schedulerBlockGasDefault,
+		SchedulerGasPrice:    schedulerGasPriceDefault,
 	}
 }
 
@@ -127,6 +147,9 @@ func (p Params) String() string {
 	sb.WriteString(fmt.Sprintf("FixedWriteDepth100: %d\n", p.FixedWriteDepth100))
 	sb.WriteString(fmt.Sprintf("IterNextCostFlat: %d\n", p.IterNextCostFlat))
 	sb.WriteString(fmt.Sprintf("PreprocessGasPerByte: %d\n", p.PreprocessGasPerByte))
+	sb.WriteString(fmt.Sprintf("AllowConcurrency: %t\n", p.AllowConcurrency))
+	sb.WriteString(fmt.Sprintf("SchedulerBlockGas: %d\n", p.SchedulerBlockGas))
+	sb.WriteString(fmt.Sprintf("SchedulerGasPrice: %q\n", p.SchedulerGasPrice))
 	return sb.String()
 }
 
@@ -199,6 +222,21 @@ func (p Params) Validate() error {
 	if p.PreprocessGasPerByte > maxPreprocessGasPerByte {
 		return fmt.Errorf("PreprocessGasPerByte must be <= %d, got %d", maxPreprocessGasPerByte, p.PreprocessGasPerByte)
 	}
+	// SchedulerBlockGas is spent inside EndBlock, on top of the block's
+	// transactions; cap it at the default max block gas so that a
+	// proposal cannot make a block take several times longer to run.
+	const maxSchedulerBlockGas = 3_000_000_000
+	const minSchedulerBlockGas = schedulerMinCallGas + schedulerCallOverheadGas
+	if p.SchedulerBlockGas < minSchedulerBlockGas {
+		return fmt.Errorf("SchedulerBlockGas must be >= %d, got %d", minSchedulerBlockGas, p.SchedulerBlockGas)
+	}
+	if p.SchedulerBlockGas > maxSchedulerBlockGas {
+		return fmt.Errorf("SchedulerBlockGas must be <= %d, got %d", maxSchedulerBlockGas, p.SchedulerBlockGas)
+	}
+	gp, err := std.ParseGasPrice(p.SchedulerGasPrice)
+	if err != nil || gp.Gas <= 0 || gp.Price.Denom != ugnot.Denom {
+		return fmt.Errorf("invalid scheduler gas price %q", p.SchedulerGasPrice)
+	}
 	return nil
 }
 
@@ -247,6 +285,14 @@ func (p Params) applyLegacyDefaults() Params {
 	if p.PreprocessGasPerByte == 0 {
 		p.PreprocessGasPerByte = preprocessGasPerByteDefault
 	}
+	// Same reasoning for the scheduler params: Validate rejects their zero
+	// values, so zero can only mean state written before they existed.
+	if p.SchedulerBlockGas == 0 {
+		p.SchedulerBlockGas = schedulerBlockGasDefault
+	}
+	if p.SchedulerGasPrice == "" {
+		p.SchedulerGasPrice = schedulerGasPriceDefault
+	}
 	return p
 }
 
@@ -318,6 +364,12 @@ func (vm *VMKeeper) WillSetParam(ctx sdk.Context, key string, value any) {
 		params.IterNextCostFlat = sdkparams.MustParamInt64("iter_next_cost_flat", value)
 	case "p:preprocess_gas_per_byte":
 		params.PreprocessGasPerByte = sdkparams.MustParamInt64("preprocess_gas_per_byte", value)
+	case "p:allow_concurrency":
+		params.AllowConcurrency = sdkparams.MustParamBool("allow_concurrency", value)
+	case "p:scheduler_block_gas":
+		params.SchedulerBlockGas = sdkparams.MustParamInt64("scheduler_block_gas", value)
+	case "p:scheduler_gas_price":
+		params.SchedulerGasPrice = sdkparams.MustParamString("scheduler_gas_price", value)
 	default:
 		if strings.HasPrefix(key, "p:") {
 			panic(fmt.Sprintf("unknown vm param key: %q", key))
@@ -32,7 +32,10 @@ func TestParamsString(t *testing.T) {
 		fmt.Sprintf("FixedSetReadDepth100: %d\n", p.FixedSetReadDepth100) +
 		fmt.Sprintf("FixedWriteDepth100: %d\n", p.FixedWriteDepth100) +
 		fmt.Sprintf("IterNextCostFlat: %d\n", p.IterNextCostFlat) +
-		fmt.Sprintf("PreprocessGasPerByte: %d\n", p.PreprocessGasPerByte)
+		fmt.Sprintf("PreprocessGasPerByte: %d\n", p.PreprocessGasPerByte) +
+		fmt.Sprintf("AllowConcurrency: %t\n", p.AllowConcurrency) +
+		fmt.Sprintf("SchedulerBlockGas: %d\n", p.SchedulerBlockGas) +
+		fmt.Sprintf("SchedulerGasPrice: %q\n", p.SchedulerGasPrice)
 
 	// Assert: check if the result matches the expected string.
 	if result != expected {
@@ -176,6 +179,26 @@ func TestWillSetParam(t *testing.T) {
 			isUpdated:   false,
 			isEqual:     false,
 		},
+		// scheduler_gas_price
+		{
+			name:  "update scheduler_gas_price",
+			key:   "scheduler_gas_price",
+			value: "1ugnot/100gas",
+			getExpectedValue: func(prms Params) string {
+				return prms.SchedulerGasPrice
+			},
+			shouldPanic: false,
+			isUpdated:   true,
+			isEqual:     true,
+		},
+		{
+			name:        "non-ugnot scheduler_gas_price panics",
+			key:         "scheduler_gas_price",
+			value:       "1foo/100gas",
+			shouldPanic: true,
+			isUpdated:   false,
+			isEqual:     false,
+		},
 		// unknown
 		{
 			name:        "unknown param panics",
@@ -0,0 +1,340 @@
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/chain"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// Scheduled calls live in the iavl store, next to the gno objects:
+//
+//	/sched/next                    -> next call id (uint64, big endian)
+//	/sched/c/<id>                  -> ScheduledCall (amino JSON)
+//	/sched/h/<height><id>          -> empty; the due index, in run order
+var (
+	schedNextIDKey  = []byte("/sched/next")
+	schedCallPrefix = []byte("/sched/c/")
+	schedDuePrefix  = []byte("/sched/h/")
+)
+
+const (
+	// schedulerMinCallGas is the least gas limit a call can be scheduled
+	// with: loading the realm alone costs more than that.
+	schedulerMinCallGas = 100_000
+	// schedulerCallOverheadGas is charged against Params.SchedulerBlockGas
+	// for each call on top of its gas limit, for the unmetered work of
+	// loading, running and settling it.
+	schedulerCallOverheadGas = 200_000
+	// maxScheduledCallsPerBlock is the most calls run in a single block,
+	// whatever their gas limits.
+	maxScheduledCallsPerBlock = 100
+)
+
+// schedulerEscrowAddr holds the deposits of pending scheduled calls.
+var schedulerEscrowAddr = crypto.AddressFromPreimage([]byte("scheduler_escrow"))
+
+// ScheduledCall is a call registered with chain/scheduler, waiting to be run
+// by the EndBlocker at Height.
+type ScheduledCall struct {
+	ID       uint64   `json:"id"`
+	Height   int64    `json:"height"`
+	PkgPath  string   `json:"pkg_path"`
+	Func     string   `json:"func"`
+	Args     []string `json:"args"`
+	GasLimit int64    `json:"gas_limit"`
+	Deposit  std.Coin `json:"deposit"`
+}
+
+func schedCallKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte(nil), schedCallPrefix...), id)
+}
+
+func schedDueKey(height int64, id uint64) []byte {
+	key := binary.BigEndian.AppendUint64(append([]byte(nil), schedDuePrefix...), uint64(height))
+	return binary.BigEndian.AppendUint64(key, id)
+}
+
+func (vm *VMKeeper) getScheduledCall(ctx sdk.Context, id uint64) (sc ScheduledCall, ok bool) {
+	bz := ctx.Store(vm.iavlKey).Get(ctx.GasContext(), schedCallKey(id))
+	if bz == nil {
+		return sc, false
+	}
+	amino.MustUnmarshalJSON(bz, &sc)
+	return sc, true
+}
+
+func (vm *VMKeeper) setScheduledCall(ctx sdk.Context, sc ScheduledCall) {
+	stor := ctx.Store(vm.iavlKey)
+	stor.Set(ctx.GasContext(), schedCallKey(sc.ID), amino.MustMarshalJSON(sc))
+	stor.Set(ctx.GasContext(), schedDueKey(sc.Height, sc.ID), []byte{})
+}
+
+func (vm *VMKeeper) deleteScheduledCall(ctx sdk.Context, sc ScheduledCall) {
+	stor := ctx.Store(vm.iavlKey)
+	stor.Delete(ctx.GasContext(), schedCallKey(sc.ID))
+	stor.Delete(ctx.GasContext(), schedDueKey(sc.Height, sc.ID))
+}
+
+func (vm *VMKeeper) nextScheduledCallID(ctx sdk.Context) uint64 {
+	stor := ctx.Store(vm.iavlKey)
+	id := uint64(1)
+	if bz := stor.Get(ctx.GasContext(), schedNextIDKey); bz != nil {
+		id = binary.BigEndian.Uint64(bz)
+	}
+	stor.Set(ctx.GasContext(), schedNextIDKey, binary.BigEndian.AppendUint64(nil, id+1))
+	return id
+}
+
+// mulDivCeil returns ceil(a*b/c) for non-negative a, b and positive c, and
+// false if the result does not fit in an int64.
+func mulDivCeil(a, b, c int64) (int64, bool) {
+	res := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	res.Add(res, big.NewInt(c-1))
+	res.Quo(res, big.NewInt(c))
+	if !res.IsInt64() {
+		return 0, false
+	}
+	return res.Int64(), true
+}
+
+// ----------------------------------------
+// SDKScheduler
+
+type SDKScheduler struct {
+	vmk *VMKeeper
+	ctx sdk.Context
+}
+
+func NewSDKScheduler(vmk *VMKeeper, ctx sdk.Context) *SDKScheduler {
+	return &SDKScheduler{
+		vmk: vmk,
+		ctx: ctx,
+	}
+}
+
+// Schedule escrows the deposit for gasLimit from the realm at pkgPath and
+// registers the call. chain/scheduler has already checked that pkgPath is the
+// calling realm; the remaining checks are repeated here because they guard
+// chain state.
+func (ssc *SDKScheduler) Schedule(pkgPath string, height int64, fn string, args []string, gasLimit int64) (uint64, error) {
+	ctx := ssc.ctx
+	params := ssc.vmk.GetParams(ctx)
+	// A MsgRun package is gone once its transaction ends.
+	if !gno.IsRealmPath(pkgPath) || gno.IsEphemeralPath(pkgPath) {
+		return 0, fmt.Errorf("only realms can schedule calls, got %q", pkgPath)
+	}
+	if height <= ctx.BlockHeight() {
+		return 0, fmt.Errorf("height %d is not in the future", height)
+	}
+	maxGasLimit := params.SchedulerBlockGas - schedulerCallOverheadGas
+	if gasLimit < schedulerMinCallGas || gasLimit > maxGasLimit {
+		return 0, fmt.Errorf("gas limit must be between %d and %d, got %d", schedulerMinCallGas, maxGasLimit, gasLimit)
+	}
+	if err := ssc.checkCrossing(pkgPath, fn); err != nil {
+		return 0, err
+	}
+
+	price, err := std.ParseGasPrice(params.SchedulerGasPrice)
+	if err != nil {
+		return 0, err
+	}
+	amount, ok := mulDivCeil(gasLimit, price.Price.Amount, price.Gas)
+	if !ok {
+		return 0, fmt.Errorf("deposit for %d gas overflows", gasLimit)
+	}
+	deposit := std.Coin{Denom: ugnot.Denom, Amount: amount}
+	// Like storage deposits, the escrow bypasses restricted-denom checks:
+	// the coins never leave the chain's control until they are refunded.
+	pkgAddr := gno.DerivePkgCryptoAddr(pkgPath)
+	if err := ssc.vmk.bank.SendCoinsUnrestricted(ctx, pkgAddr, schedulerEscrowAddr, std.Coins{deposit}); err != nil {
+		return 0, fmt.Errorf("unable to escrow scheduled call deposit: %w", err)
+	}
+
+	sc := ScheduledCall{
+		ID:       ssc.vmk.nextScheduledCallID(ctx),
+		Height:   height,
+		PkgPath:  pkgPath,
+		Func:     fn,
+		Args:     args,
+		GasLimit: gasLimit,
+		Deposit:  deposit,
+	}
+	ssc.vmk.setScheduledCall(ctx, sc)
+	return sc.ID, nil
+}
+
+// checkCrossing rejects calls that can never succeed because fn is not a
+// crossing function of pkgPath. It is skipped while pkgPath itself is being
+// added, as its package node is not stored yet.
+func (ssc *SDKScheduler) checkCrossing(pkgPath, fn string) error {
+	gnostore, ok := ssc.ctx.Value(vmkContextKeyStore).(gno.TransactionStore)
+	if !ok {
+		return nil
+	}
+	pn, ok := gnostore.GetBlockNodeSafe(gno.PackageNodeLocation(pkgPath)).(*gno.PackageNode)
+	if !ok || pn == nil {
+		return nil
+	}
+	if _, exists := pn.GetLocalIndex(gno.Name(fn)); !exists {
+		return fmt.Errorf("function %s not found in %s", fn, pkgPath)
+	}
+	ft, ok := pn.GetStaticTypeOf(gnostore, gno.Name(fn)).(*gno.FuncType)
+	if !ok || len(ft.Params) == 0 || ft.Params[0].Type.String() != ".uverse.realm" {
+		return fmt.Errorf("%s.%s is not a crossing function", pkgPath, fn)
+	}
+	return nil
+}
+
+// Cancel removes a pending call of the realm at pkgPath and refunds its
+// deposit in full.
+func (ssc *SDKScheduler) Cancel(pkgPath string, id uint64) bool {
+	ctx := ssc.ctx
+	sc, ok := ssc.vmk.getScheduledCall(ctx, id)
+	if !ok || sc.PkgPath != pkgPath {
+		return false
+	}
+	ssc.vmk.deleteScheduledCall(ctx, sc)
+	pkgAddr := gno.DerivePkgCryptoAddr(pkgPath)
+	if err := ssc.vmk.bank.SendCoinsUnrestricted(ctx, schedulerEscrowAddr, pkgAddr, std.Coins{sc.Deposit}); err != nil {
+		panic(fmt.Sprintf("unable to refund scheduled call %d: %v", id, err))
+	}
+	return true
+}
+
+// ----------------------------------------
+// EndBlocker
+
+// RunScheduledCalls runs the calls due at or before the current height, in
+// (height, id) order, until the next one does not fit in what is left of
+// Params.SchedulerBlockGas, or maxScheduledCallsPerBlock calls have run; calls
+// left over stay due and run in a later block. Each call uses its gas limit
+// plus schedulerCallOverheadGas of the block budget.
+// Each call runs like a MsgCall from the realm that scheduled it, in its own
+// cache context: a failing call is reverted but not retried.
+//
+// The gas used is paid from the call's deposit to the fee collector and the
+// rest refunded to the realm. The returned events hold one
+// chain.ScheduledCallEvent per call, preceded by the events of the calls that
+// succeeded.
+func (vm *VMKeeper) RunScheduledCalls(ctx sdk.Context) []abci.Event {
+	params := vm.GetParams(ctx)
+	gasCfg := store.DefaultGasConfig()
+	params.ApplyToGasConfig(&gasCfg)
+	ctx = ctx.WithGasConfig(gasCfg)
+
+	var authParams auth.Params
+	vm.prmk.GetStruct(ctx.WithGasMeter(nil), "auth:p", &authParams)
+	feeCollector := authParams.FeeCollector
+
+	// Collect first: the calls modify the store being iterated.
+	var due []ScheduledCall
+	budget := params.SchedulerBlockGas
+	end := schedDueKey(ctx.BlockHeight()+1, 0)
+	stor := ctx.Store(vm.iavlKey)
+	iter := stor.Iterator(ctx.GasContext(), schedDuePrefix, end)
+	for ; iter.Valid() && len(due) < maxScheduledCallsPerBlock; iter.Next() {
+		key := iter.Key()
+		id := binary.BigEndian.Uint64(key[len(key)-8:])
+		sc, ok := vm.getScheduledCall(ctx, id)
+		if !ok {
+			panic(fmt.Sprintf("scheduled call %d is indexed but missing", id))
+		}
+		// SchedulerBlockGas may have been lowered since the call was
+		// scheduled; such a call would block the queue forever.
+		cost := sc.GasLimit + schedulerCallOverheadGas
+		if cost > params.SchedulerBlockGas || cost <= budget {
+			due = append(due, sc)
+			if cost <= budget {
+				budget -= cost
+			}
+			continue
+		}
+		break
+	}
+	iter.Close()
+
+	var events []abci.Event
+	for _, sc := range due {
+		evt, callEvents := vm.runScheduledCall(ctx, sc, params, feeCollector)
+		events = append(events, callEvents...)
+		events = append(events, evt)
+	}
+	return events
+}
+
+func (vm *VMKeeper) runScheduledCall(ctx sdk.Context, sc ScheduledCall, params Params, feeCollector crypto.Address) (chain.ScheduledCallEvent, []abci.Event) {
+	// Delete first, so that the call cannot cancel itself for a refund.
+	vm.deleteScheduledCall(ctx, sc)
+	pkgAddr := gno.DerivePkgCryptoAddr(sc.PkgPath)
+	evt := chain.ScheduledCallEvent{
+		ID:      sc.ID,
+		PkgPath: sc.PkgPath,
+		Func:    sc.Func,
+		Height:  sc.Height,
+		Fee:     std.Coin{Denom: sc.Deposit.Denom},
+		Refund:  sc.Deposit,
+	}
+
+	var callEvents []abci.Event
+	if sc.GasLimit+schedulerCallOverheadGas > params.SchedulerBlockGas {
+		evt.Error = fmt.Sprintf("gas limit %d exceeds the scheduler block gas %d", sc.GasLimit, params.SchedulerBlockGas-schedulerCallOverheadGas)
+	} else {
+		cctx, write := ctx.CacheContext()
+		cctx = cctx.WithGasMeter(store.NewGasMeter(sc.GasLimit))
+		evt.Error = vm.callScheduled(cctx, sc, pkgAddr)
+		evt.GasUsed = min(cctx.GasMeter().GasConsumed(), sc.GasLimit)
+		if evt.Error == "" {
+			write()
+			callEvents = cctx.EventLogger().Events()
+		}
+		fee, _ := mulDivCeil(sc.Deposit.Amount, evt.GasUsed, sc.GasLimit)
+		evt.Fee.Amount = fee
+		evt.Refund.Amount = sc.Deposit.Amount - fee
+	}
+
+	if evt.Fee.Amount > 0 {
+		if err := vm.bank.SendCoinsUnrestricted(ctx, schedulerEscrowAddr, feeCollector, std.Coins{evt.Fee}); err != nil {
+			panic(fmt.Sprintf("unable to collect scheduled call %d fee: %v", sc.ID, err))
+		}
+	}
+	if evt.Refund.Amount > 0 {
+		if err := vm.bank.SendCoinsUnrestricted(ctx, schedulerEscrowAddr, pkgAddr, std.Coins{evt.Refund}); err != nil {
+			panic(fmt.Sprintf("unable to refund scheduled call %d: %v", sc.ID, err))
+		}
+	}
+	return evt, callEvents
+}
+
+// callScheduled runs sc through Call in its own gno transaction store, and
+// returns the error or panic (including running out of gas) it failed with.
+// The store is only written to ctx if the call succeeds.
+func (vm *VMKeeper) callScheduled(ctx sdk.Context, sc ScheduledCall, caller crypto.Address) (errMsg string) {
+	defer func() {
+		if r := recover(); r != nil {
+			errMsg = boundedString(r, 0)
+		}
+	}()
+	ctx = vm.MakeGnoTransactionStore(ctx)
+	_, err := vm.Call(ctx, MsgCall{
+		Caller:  caller,
+		PkgPath: sc.PkgPath,
+		Func:    sc.Func,
+		Args:    sc.Args,
+	})
+	if err != nil {
+		return boundedString(err, 0)
+	}
+	vm.CommitGnoTransactionStore(ctx)
+	return ""
+}
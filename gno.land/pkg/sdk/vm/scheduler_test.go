@@ -0,0 +1,322 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/chain"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	authm "github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const schedulerTestPkgPath = "gno.land/r/test/sched"
+
+const schedulerTestRealm = `package sched
+
+import (
+	"chain/runtime"
+	"chain/scheduler"
+	"strconv"
+	"strings"
+)
+
+var runs []string
+
+func ScheduleCall(cur realm, height int64, fn string, gasLimit int64, args ...string) uint64 {
+	return scheduler.Schedule(height, fn, gasLimit, cur, args...)
+}
+
+func CancelCall(cur realm, id uint64) bool {
+	return scheduler.Cancel(id, cur)
+}
+
+func Tick(cur realm, arg string) {
+	runs = append(runs, arg+"@"+strconv.FormatInt(runtime.ChainHeight(), 10))
+}
+
+func Fail(cur realm) {
+	runs = append(runs, "failed")
+	panic("boom")
+}
+
+func Loop(cur realm) {
+	for {
+	}
+}
+
+func NotCrossing() {}
+
+func Runs() string {
+	return strings.Join(runs, ",")
+}
+`
+
+var schedulerTestRealmBalance = std.MustParseCoins(ugnot.ValueString(1_000_000))
+
+// setupSchedulerTest deploys schedulerTestRealm at height 42 and funds it.
+func setupSchedulerTest(t *testing.T) (testEnv, crypto.Address) {
+	t.Helper()
+
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+	env.bankk.SetCoins(ctx, gnolang.DerivePkgCryptoAddr(schedulerTestPkgPath), schedulerTestRealmBalance)
+
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(schedulerTestPkgPath)},
+		{Name: "sched.gno", Body: schedulerTestRealm},
+	}
+	msg := NewMsgAddPackage(addr, schedulerTestPkgPath, files)
+	require.NoError(t, env.vmk.AddPackage(ctx, msg))
+	env.vmk.CommitGnoTransactionStore(ctx)
+	return env, addr
+}
+
+func schedulerTestCall(env testEnv, caller crypto.Address, fn string, args ...string) (string, error) {
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	res, err := env.vmk.Call(ctx, NewMsgCall(caller, nil, schedulerTestPkgPath, fn, args))
+	if err == nil {
+		env.vmk.CommitGnoTransactionStore(ctx)
+	}
+	return res, err
+}
+
+func schedulerTestRuns(t *testing.T, env testEnv) string {
+	t.Helper()
+
+	res, err := env.vmk.QueryEval(env.ctx, schedulerTestPkgPath, "Runs()")
+	require.NoError(t, err)
+	return res
+}
+
+func runScheduledCallsAt(env testEnv, height int64) []chain.ScheduledCallEvent {
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: height})
+	var evts []chain.ScheduledCallEvent
+	for _, evt := range env.vmk.RunScheduledCalls(ctx) {
+		if sce, ok := evt.(chain.ScheduledCallEvent); ok {
+			evts = append(evts, sce)
+		}
+	}
+	return evts
+}
+
+func TestScheduler(t *testing.T) {
+	env, addr := setupSchedulerTest(t)
+	pkgAddr := gnolang.DerivePkgCryptoAddr(schedulerTestPkgPath)
+	feeCollector := authm.DefaultParams().FeeCollector
+
+	// 10_000_000 gas at the default 1ugnot/1000gas.
+	res, err := schedulerTestCall(env, addr, "ScheduleCall", "43", "Tick", "10000000", "a")
+	require.NoError(t, err)
+	assert.Equal(t, "(1 uint64)\n\n", res)
+	assert.Equal(t, int64(990_000), env.bankk.GetCoin(env.ctx, pkgAddr, ugnot.Denom))
+	assert.Equal(t, int64(10_000), env.bankk.GetCoin(env.ctx, schedulerEscrowAddr, ugnot.Denom))
+
+	// Not due yet.
+	assert.Empty(t, runScheduledCallsAt(env, 42))
+
+	evts := runScheduledCallsAt(env, 43)
+	require.Len(t, evts, 1)
+	evt := evts[0]
+	assert.Equal(t, uint64(1), evt.ID)
+	assert.Equal(t, "Tick", evt.Func)
+	assert.Empty(t, evt.Error)
+	assert.Positive(t, evt.GasUsed)
+	assert.Positive(t, evt.Fee.Amount)
+	assert.Equal(t, int64(10_000), evt.Fee.Amount+evt.Refund.Amount)
+	assert.Equal(t, evt.Fee.Amount, env.bankk.GetCoin(env.ctx, feeCollector, ugnot.Denom))
+	assert.Zero(t, env.bankk.GetCoin(env.ctx, schedulerEscrowAddr, ugnot.Denom))
+	assert.Equal(t, `("a@43" string)`, schedulerTestRuns(t, env))
+
+	// A call runs once.
+	assert.Empty(t, runScheduledCallsAt(env, 44))
+}
+
+func TestScheduler_Cancel(t *testing.T) {
+	env, addr := setupSchedulerTest(t)
+	pkgAddr := gnolang.DerivePkgCryptoAddr(schedulerTestPkgPath)
+
+	_, err := schedulerTestCall(env, addr, "ScheduleCall", "43", "Tick", "10000000", "a")
+	require.NoError(t, err)
+
+	res, err := schedulerTestCall(env, addr, "CancelCall", "1")
+	require.NoError(t, err)
+	assert.Equal(t, "(true bool)\n\n", res)
+	assert.Equal(t, int64(1_000_000), env.bankk.GetCoin(env.ctx, pkgAddr, ugnot.Denom))
+	assert.Zero(t, env.bankk.GetCoin(env.ctx, schedulerEscrowAddr, ugnot.Denom))
+
+	res, err = schedulerTestCall(env, addr, "CancelCall", "1")
+	require.NoError(t, err)
+	assert.Equal(t, "(false bool)\n\n", res)
+
+	assert.Empty(t, runScheduledCallsAt(env, 43))
+	assert.Equal(t, `("" string)`, schedulerTestRuns(t, env))
+}
+
+func TestScheduler_Failures(t *testing.T) {
+	env, addr := setupSchedulerTest(t)
+	pkgAddr := gnolang.DerivePkgCryptoAddr(schedulerTestPkgPath)
+
+	_, err := schedulerTestCall(env, addr, "ScheduleCall", "43", "Fail", "10000000")
+	require.NoError(t, err)
+	_, err = schedulerTestCall(env, addr, "ScheduleCall", "43", "Loop", "100000")
+	require.NoError(t, err)
+
+	evts := runScheduledCallsAt(env, 43)
+	require.Len(t, evts, 2)
+
+	// A panicking call is reverted, but pays for the gas it used.
+	assert.Equal(t, "Fail", evts[0].Func)
+	assert.Contains(t, evts[0].Error, "boom")
+	assert.Positive(t, evts[0].Fee.Amount)
+	assert.Positive(t, evts[0].Refund.Amount)
+
+	// A call running out of gas pays its whole deposit.
+	assert.Equal(t, "Loop", evts[1].Func)
+	assert.Contains(t, evts[1].Error, "out of gas")
+	assert.Equal(t, int64(100_000), evts[1].GasUsed)
+	assert.Equal(t, int64(100), evts[1].Fee.Amount)
+	assert.Zero(t, evts[1].Refund.Amount)
+
+	assert.Equal(t, `("" string)`, schedulerTestRuns(t, env))
+	assert.Zero(t, env.bankk.GetCoin(env.ctx, schedulerEscrowAddr, ugnot.Denom))
+	paid := evts[0].Fee.Amount + evts[1].Fee.Amount
+	assert.Equal(t, int64(1_000_000)-paid, env.bankk.GetCoin(env.ctx, pkgAddr, ugnot.Denom))
+}
+
+func TestScheduler_BlockBudget(t *testing.T) {
+	env, addr := setupSchedulerTest(t)
+
+	params := env.vmk.GetParams(env.ctx)
+	params.SchedulerBlockGas = 15_000_000
+	require.NoError(t, env.vmk.SetParams(env.ctx, params))
+
+	for _, arg := range []string{"a", "b", "c"} {
+		_, err := schedulerTestCall(env, addr, "ScheduleCall", "43", "Tick", "10000000", arg)
+		require.NoError(t, err)
+	}
+
+	// One call fits in each block, in scheduling order.
+	for i, height := range []int64{43, 44, 45} {
+		evts := runScheduledCallsAt(env, height)
+		require.Len(t, evts, 1)
+		assert.Equal(t, uint64(i+1), evts[0].ID)
+		assert.Equal(t, int64(43), evts[0].Height)
+	}
+	assert.Equal(t, `("a@43,b@44,c@45" string)`, schedulerTestRuns(t, env))
+}
+
+func TestScheduler_TinyCalls(t *testing.T) {
+	env, addr := setupSchedulerTest(t)
+
+	const n = maxScheduledCallsPerBlock + 20
+	for range n {
+		_, err := schedulerTestCall(env, addr, "ScheduleCall", "43", "Tick", "100000", "x")
+		require.NoError(t, err)
+	}
+
+	// Each call uses its gas limit plus the overhead of the block budget.
+	params := env.vmk.GetParams(env.ctx)
+	params.SchedulerBlockGas = 3 * (schedulerMinCallGas + schedulerCallOverheadGas)
+	require.NoError(t, env.vmk.SetParams(env.ctx, params))
+	require.Len(t, runScheduledCallsAt(env, 43), 3)
+
+	// However large the budget, no more than maxScheduledCallsPerBlock calls
+	// run in a block.
+	params.SchedulerBlockGas = 3_000_000_000
+	require.NoError(t, env.vmk.SetParams(env.ctx, params))
+	evts := runScheduledCallsAt(env, 44)
+	require.Len(t, evts, maxScheduledCallsPerBlock)
+	assert.Equal(t, uint64(4), evts[0].ID)
+	assert.Len(t, runScheduledCallsAt(env, 45), n-3-maxScheduledCallsPerBlock)
+	assert.Empty(t, runScheduledCallsAt(env, 46))
+}
+
+func TestScheduler_BudgetLowered(t *testing.T) {
+	env, addr := setupSchedulerTest(t)
+	pkgAddr := gnolang.DerivePkgCryptoAddr(schedulerTestPkgPath)
+
+	_, err := schedulerTestCall(env, addr, "ScheduleCall", "43", "Tick", "10000000", "a")
+	require.NoError(t, err)
+
+	// A call that can no longer fit in any block is dropped and refunded,
+	// rather than blocking the calls after it.
+	params := env.vmk.GetParams(env.ctx)
+	params.SchedulerBlockGas = 5_000_000
+	require.NoError(t, env.vmk.SetParams(env.ctx, params))
+
+	evts := runScheduledCallsAt(env, 43)
+	require.Len(t, evts, 1)
+	assert.Contains(t, evts[0].Error, "exceeds the scheduler block gas")
+	assert.Zero(t, evts[0].Fee.Amount)
+	assert.Equal(t, int64(1_000_000), env.bankk.GetCoin(env.ctx, pkgAddr, ugnot.Denom))
+	assert.Equal(t, `("" string)`, schedulerTestRuns(t, env))
+}
+
+func TestScheduler_ScheduleErrors(t *testing.T) {
+	env, addr := setupSchedulerTest(t)
+
+	tests := []struct {
+		name string
+		args []string
+		err  string
+	}{
+		{"past height", []string{"42", "Tick", "1000", "a"}, "is not in the future"},
+		{"unknown function", []string{"43", "Nope", "100000"}, "function Nope not found"},
+		{"non-crossing function", []string{"43", "NotCrossing", "100000"}, "is not a crossing function"},
+		{"gas limit under minimum", []string{"43", "Tick", "99999", "a"}, "gas limit must be between"},
+		{"gas limit over budget", []string{"43", "Tick", "99800001", "a"}, "gas limit must be between"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := schedulerTestCall(env, addr, "ScheduleCall", tc.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.err)
+		})
+	}
+
+	// 10_000_000 gas now costs 1_000_000 ugnot, more than the realm has.
+	params := env.vmk.GetParams(env.ctx)
+	params.SchedulerGasPrice = "1ugnot/10gas"
+	require.NoError(t, env.vmk.SetParams(env.ctx, params))
+	_, err := schedulerTestCall(env, addr, "ScheduleCall", "43", "Tick", "10000001", "a")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to escrow scheduled call deposit")
+
+	assert.Zero(t, env.bankk.GetCoin(env.ctx, schedulerEscrowAddr, ugnot.Denom))
+}
+
+// Schedule must not be reachable through a non-current realm.
+func TestScheduler_NonCurrentRealm(t *testing.T) {
+	env, addr := setupSchedulerTest(t)
+
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	const pkgPath = "gno.land/r/test/sched2"
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		{Name: "sched2.gno", Body: `package sched2
+
+import "chain/scheduler"
+
+func ScheduleForCaller(cur realm) uint64 {
+	return scheduler.Schedule(43, "Tick", 1000, cur.Previous(), "x")
+}
+`},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files)))
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "ScheduleForCaller", nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Schedule can only be used by the current realm")
+}
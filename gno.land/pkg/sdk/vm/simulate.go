@@ -0,0 +1,277 @@
+package vm
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gnolang/gno/gnovm/stdlibs/chain"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// SimulateRequest is the request of the vm/qsimulate query, in amino JSON:
+// the transaction to simulate, and the overrides of the state to simulate it
+// against.
+type SimulateRequest struct {
+	Tx        std.Tx            `json:"tx"`
+	Overrides SimulateOverrides `json:"overrides"`
+}
+
+// SimulateOverrides are changes applied to the state before a simulation.
+// They are applied in a throwaway branch of the state, and neither their gas
+// nor their events are reported, but their gas counts against the gas limit
+// of the simulation.
+type SimulateOverrides struct {
+	// If not zero, the block height and time of the simulation.
+	Height int64     `json:"height"`
+	Time   time.Time `json:"time"`
+	// If not empty, the caller of all the messages, replacing their caller,
+	// creator or upgrader. Signatures are never checked.
+	Caller crypto.Address `json:"caller"`
+	// The balances of accounts, replacing their coins. Accounts are
+	// created if needed.
+	Balances []SimulateBalance `json:"balances"`
+	// Packages not yet deployed. They are added before the balances are
+	// set, by the caller, or else by the first signer of the transaction,
+	// which pays their storage deposit. There are at most
+	// maxSimulatePackages of them, of maxSimulatePackagesBytes in total.
+	Packages []*std.MemPackage `json:"packages"`
+}
+
+const (
+	// Bounds of the overridden packages of a simulation, checked before they
+	// are parsed. A transaction adding them would be bounded by the block
+	// size.
+	maxSimulatePackages      = 16
+	maxSimulatePackagesBytes = 1_000_000
+)
+
+// SimulateBalance is the overridden balance of an account.
+type SimulateBalance struct {
+	Address crypto.Address `json:"address"`
+	Coins   std.Coins      `json:"coins"`
+}
+
+// SimulateResult is the result of the vm/qsimulate query, in amino JSON.
+type SimulateResult struct {
+	GasUsed int64        `json:"gas_used"`
+	Events  []abci.Event `json:"events"`
+	// Sum of the storage deposit events: the bytes of realm storage used,
+	// and the ugnot locked, negative if freed and refunded.
+	StorageDelta int64 `json:"storage_delta"`
+	DepositDelta int64 `json:"deposit_delta"`
+	// Log of the objects created, updated and deleted in each realm, as in
+	// the "Realm:" directive of filetests.
+	RealmDiffs string `json:"realm_diffs"`
+	// Error of the first failing message, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Simulate runs the messages of req.Tx against the current state with
+// req.Overrides applied, and returns their gas, events and state changes.
+// Signatures and fees are not checked, and the state is left untouched. The
+// gas of the overrides and of the messages is limited like in Profile.
+// Simulation stops at the first failing
+// message, whose error is set in the result. The returned error is only
+// set if the overrides cannot be applied.
+func (vm *VMKeeper) Simulate(ctx sdk.Context, req SimulateRequest) (res SimulateResult, err error) {
+	defer doRecoverQueryNoMachine(&err)
+	ctx, _ = ctx.CacheContext() // never written
+	ctx = ctx.WithMode(sdk.RunTxModeSimulate)
+	// The overrides and the messages share the gas limit of the query.
+	gm := store.NewGasMeter(maxGasQuery)
+	ctx, octx, err := vm.applySimulateOverrides(ctx, req, gm)
+	if err != nil {
+		return res, err
+	}
+
+	gasLimit := gm.Remaining()
+	if req.Tx.Fee.GasWanted > 0 {
+		gasLimit = min(req.Tx.Fee.GasWanted, gasLimit)
+	}
+	var ops bytes.Buffer
+	ctx = ctx.
+		WithGasMeter(store.NewGasMeter(gasLimit)).
+		WithEventLogger(sdk.NewEventLogger()).
+		WithValue(vmkContextKeyOpsLog, io.Writer(&ops))
+	defer func() {
+		res.GasUsed = ctx.GasMeter().GasConsumed()
+		res.RealmDiffs = ops.String()
+	}()
+	// Creating the store may already run out of gas.
+	if err := func() (err error) {
+		defer doRecoverQueryNoMachine(&err)
+		if octx.Value(vmkContextKeyStore) == nil {
+			ctx = vm.MakeGnoTransactionStore(ctx) // never committed
+			return nil
+		}
+		// Nested in the store of the overridden packages, which is never
+		// committed either: committing it would share their nodes with the
+		// store of the keeper.
+		parent := vm.getGnoTransactionStore(octx)
+		ctx = ctx.
+			WithValue(vmkContextKeyTypeCheckCache, vm.getTypeCheckCache(octx)).
+			WithValue(vmkContextKeyStore, vm.beginGnoTransaction(ctx, parent))
+		return nil
+	}(); err != nil {
+		res.Error = err.Error()
+		return res, nil
+	}
+	for i, msg := range req.Tx.GetMsgs() {
+		if !req.Overrides.Caller.IsZero() {
+			msg = withCaller(msg, req.Overrides.Caller)
+		}
+		err := vm.simulateMsg(ctx, msg)
+		res.addEvents(ctx.EventLogger().Events())
+		if err != nil {
+			res.Error = fmt.Sprintf("msg #%d: %v", i, err)
+			return res, nil
+		}
+		ctx = ctx.WithEventLogger(sdk.NewEventLogger())
+	}
+	return res, nil
+}
+
+// applySimulateOverrides returns ctx with the overrides of req applied, and
+// the context they were applied with, holding the gno transaction store of the
+// overridden packages, if any. They consume the gas of gm.
+func (vm *VMKeeper) applySimulateOverrides(ctx sdk.Context, req SimulateRequest, gm store.GasMeter) (_, octx sdk.Context, err error) {
+	ov := req.Overrides
+	if err := checkSimulatePackages(ov.Packages); err != nil {
+		return ctx, ctx, err
+	}
+	if ov.Height != 0 || !ov.Time.IsZero() {
+		header, ok := ctx.BlockHeader().(*bft.Header)
+		if !ok {
+			header = &bft.Header{ChainID: ctx.ChainID()}
+		}
+		if ov.Height != 0 {
+			header.Height = ov.Height
+		}
+		if !ov.Time.IsZero() {
+			header.Time = ov.Time
+		}
+		ctx = ctx.WithBlockHeader(header)
+	}
+
+	octx = ctx.
+		WithGasMeter(gm).
+		WithEventLogger(sdk.NewEventLogger())
+	if len(ov.Packages) > 0 {
+		creator := ov.Caller
+		if creator.IsZero() {
+			signers := req.Tx.GetSigners()
+			if len(signers) == 0 {
+				return ctx, octx, std.ErrInvalidAddress("missing caller of the overridden packages")
+			}
+			creator = signers[0]
+		}
+		octx = vm.MakeGnoTransactionStore(octx)
+		for _, mpkg := range ov.Packages {
+			if err := vm.simulateMsg(octx, NewMsgAddPackage(creator, mpkg.Path, mpkg.Files)); err != nil {
+				return ctx, octx, fmt.Errorf("overridden package %s: %w", mpkg.Path, err)
+			}
+		}
+	}
+	for _, bal := range ov.Balances {
+		if err := vm.setBalance(octx, bal.Address, bal.Coins); err != nil {
+			return ctx, octx, fmt.Errorf("overridden balance of %s: %w", bal.Address, err)
+		}
+	}
+	return ctx, octx, nil
+}
+
+// checkSimulatePackages returns an error if there are more than
+// maxSimulatePackages packages, or more than maxSimulatePackagesBytes in
+// their files.
+func checkSimulatePackages(pkgs []*std.MemPackage) error {
+	if len(pkgs) > maxSimulatePackages {
+		return ErrInvalidPackage(fmt.Sprintf(
+			"too many overridden packages: %d > %d", len(pkgs), maxSimulatePackages))
+	}
+	size := 0
+	for _, mpkg := range pkgs {
+		if mpkg == nil {
+			return ErrInvalidPackage("missing overridden package")
+		}
+		size += len(mpkg.Name) + len(mpkg.Path)
+		for _, file := range mpkg.Files {
+			if file != nil {
+				size += len(file.Name) + len(file.Body)
+			}
+		}
+		if size > maxSimulatePackagesBytes {
+			return ErrInvalidPackage(fmt.Sprintf(
+				"overridden packages too large: more than %d bytes", maxSimulatePackagesBytes))
+		}
+	}
+	return nil
+}
+
+// setBalance mints or burns the coins of addr, so that it holds exactly coins.
+func (vm *VMKeeper) setBalance(ctx sdk.Context, addr crypto.Address, coins std.Coins) error {
+	have := vm.bank.GetCoins(ctx, addr)
+	var mint, burn std.Coins
+	for _, coin := range coins.SubUnsafe(have) {
+		switch {
+		case coin.Amount > 0:
+			mint = mint.Add(std.Coins{coin})
+		case coin.Amount < 0:
+			burn = burn.Add(std.Coins{std.NewCoin(coin.Denom, -coin.Amount)})
+		}
+	}
+	if len(burn) > 0 {
+		if err := vm.bank.BurnCoins(ctx, addr, burn); err != nil {
+			return err
+		}
+	}
+	if len(mint) > 0 {
+		return vm.bank.MintCoins(ctx, addr, mint)
+	}
+	return nil
+}
+
+// withCaller returns msg with its caller replaced by caller.
+func withCaller(msg std.Msg, caller crypto.Address) std.Msg {
+	switch msg := msg.(type) {
+	case MsgAddPackage:
+		msg.Creator = caller
+		return msg
+	case MsgUpgradePackage:
+		msg.Upgrader = caller
+		return msg
+	case MsgArchivePackage:
+		msg.Caller = caller
+		return msg
+	case MsgCall:
+		msg.Caller = caller
+		return msg
+	case MsgRun:
+		msg.Caller = caller
+		return msg
+	default:
+		return msg
+	}
+}
+
+func (res *SimulateResult) addEvents(events []abci.Event) {
+	for _, event := range events {
+		switch ev := event.(type) {
+		case chain.StorageDepositEvent:
+			res.StorageDelta += ev.BytesDelta
+			res.DepositDelta += ev.FeeDelta.Amount
+		case chain.StorageUnlockEvent:
+			res.StorageDelta += ev.BytesDelta
+			if !ev.RefundWithheld {
+				res.DepositDelta -= ev.FeeRefund.Amount
+			}
+		}
+		res.Events = append(res.Events, event)
+	}
+}
@@ -0,0 +1,206 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/chain"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const simulateTestClock = `package clock
+
+import (
+	"chain"
+	"chain/runtime"
+	"strconv"
+	"time"
+)
+
+var ticks []int64
+
+func Tick(cur realm) {
+	ticks = append(ticks, runtime.ChainHeight())
+	chain.Emit("Tick",
+		"height", strconv.Itoa(int(runtime.ChainHeight())),
+		"time", time.Now().UTC().Format(time.RFC3339))
+}
+`
+
+func TestVMKeeperSimulate(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+	commitMultiStoreHash(t, env)
+
+	// A caller with no account, nor signature.
+	spoofed := crypto.AddressFromPreimage([]byte("spoofed"))
+	coins := std.MustParseCoins(ugnot.ValueString(5_000_000))
+	req := SimulateRequest{
+		Tx: std.Tx{
+			Msgs: []std.Msg{
+				NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"b"}),
+				NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"c"}),
+			},
+		},
+		Overrides: SimulateOverrides{
+			Caller:   spoofed,
+			Balances: []SimulateBalance{{Address: spoofed, Coins: coins}},
+		},
+	}
+	res, err := env.vmk.Simulate(env.ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, res.Error)
+	assert.Positive(t, res.GasUsed)
+	assert.Positive(t, res.StorageDelta)
+	assert.Equal(t, res.StorageDelta*100, res.DepositDelta)
+	require.Len(t, res.Events, 2)
+	assert.Equal(t, upgradeTestPath, res.Events[0].(chain.StorageDepositEvent).PkgPath)
+	assert.Contains(t, res.RealmDiffs, `finalizerealm["gno.land/r/test/counter"]`)
+	assert.Contains(t, res.RealmDiffs, `"value": "c"`)
+
+	// The state is left untouched.
+	count, err := env.vmk.QueryEval(env.ctx, upgradeTestPath, "Count()")
+	require.NoError(t, err)
+	assert.Equal(t, "(1 int)", count)
+	assert.Nil(t, env.acck.GetAccount(env.ctx, spoofed))
+
+	// Without the overridden caller, the messages are run by addr.
+	req.Overrides = SimulateOverrides{}
+	res2, err := env.vmk.Simulate(env.ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, res2.Error)
+	assert.Equal(t, res.StorageDelta, res2.StorageDelta)
+}
+
+func TestVMKeeperSimulate_Packages(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+	const clockPath = "gno.land/r/test/clock"
+	at := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	req := SimulateRequest{
+		Tx: std.Tx{
+			Msgs: []std.Msg{NewMsgCall(addr, nil, clockPath, "Tick", nil)},
+		},
+		Overrides: SimulateOverrides{
+			Height: 1000,
+			Time:   at,
+			Packages: []*std.MemPackage{{
+				Name: "clock",
+				Path: clockPath,
+				Files: []*std.MemFile{
+					{Name: "clock.gno", Body: simulateTestClock},
+					{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(clockPath)},
+				},
+			}},
+		},
+	}
+	res, err := env.vmk.Simulate(env.ctx, req)
+	require.NoError(t, err)
+	require.Empty(t, res.Error)
+
+	// Only the events of the transaction are reported, not those of the
+	// deployment of the package.
+	require.Len(t, res.Events, 2)
+	tick := res.Events[0].(chain.Event)
+	assert.Equal(t, "Tick", tick.Type)
+	assert.Equal(t, []chain.EventAttribute{
+		{Key: "height", Value: "1000"},
+		{Key: "time", Value: at.Format(time.RFC3339)},
+	}, tick.Attributes)
+	assert.Positive(t, res.StorageDelta)
+
+	// The package was never deployed.
+	_, err = env.vmk.QueryFile(env.ctx, clockPath)
+	assert.Error(t, err)
+}
+
+func TestVMKeeperSimulate_Errors(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+
+	// A failing message is reported in the result.
+	res, err := env.vmk.Simulate(env.ctx, SimulateRequest{
+		Tx: std.Tx{
+			Msgs: []std.Msg{
+				NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"b"}),
+				NewMsgCall(addr, nil, upgradeTestPath, "Missing", nil),
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, res.Error, "msg #1:")
+	assert.Positive(t, res.GasUsed)
+	assert.Len(t, res.Events, 1) // of the first message.
+
+	// Running out of gas too, even before running the messages.
+	res, err = env.vmk.Simulate(env.ctx, SimulateRequest{
+		Tx: std.Tx{
+			Fee:  std.Fee{GasWanted: 10_000},
+			Msgs: []std.Msg{NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"b"})},
+		},
+	})
+	require.NoError(t, err)
+	assert.Contains(t, res.Error, "out of gas")
+	assert.GreaterOrEqual(t, res.GasUsed, int64(10_000))
+
+	// Invalid overrides fail the simulation.
+	_, err = env.vmk.Simulate(env.ctx, SimulateRequest{
+		Tx: std.Tx{
+			Msgs: []std.Msg{NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"b"})},
+		},
+		Overrides: SimulateOverrides{
+			Packages: []*std.MemPackage{{
+				Name:  "counter",
+				Path:  upgradeTestPath,
+				Files: upgradeTestFiles(addr, upgradeTestV1),
+			}},
+		},
+	})
+	assert.ErrorContains(t, fmt.Errorf("%+v", err), "overridden package gno.land/r/test/counter")
+
+	// Too many or too large overridden packages are rejected before parsing.
+	pkgs := make([]*std.MemPackage, maxSimulatePackages+1)
+	for i := range pkgs {
+		pkgs[i] = &std.MemPackage{Name: "p", Path: fmt.Sprintf("gno.land/p/test/p%d", i)}
+	}
+	_, err = env.vmk.Simulate(env.ctx, SimulateRequest{Overrides: SimulateOverrides{Packages: pkgs}})
+	assert.ErrorContains(t, fmt.Errorf("%+v", err), "too many overridden packages")
+	pkgs = pkgs[:2]
+	for _, mpkg := range pkgs {
+		mpkg.Files = []*std.MemFile{{Name: "p.gno", Body: "package p\n//" + strings.Repeat("x", maxSimulatePackagesBytes/2)}}
+	}
+	_, err = env.vmk.Simulate(env.ctx, SimulateRequest{Overrides: SimulateOverrides{Packages: pkgs}})
+	assert.ErrorContains(t, fmt.Errorf("%+v", err), "overridden packages too large")
+}
+
+func TestVMHandler_QuerySimulate(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+	h := NewHandler(env.vmk)
+
+	data, err := amino.MarshalJSON(SimulateRequest{
+		Tx: std.Tx{
+			Msgs: []std.Msg{NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"b"})},
+		},
+	})
+	require.NoError(t, err)
+	qres := h.Query(env.ctx, abci.RequestQuery{Path: "vm/" + QuerySimulate, Data: data})
+	require.Nil(t, qres.Error, qres.Log)
+	var res SimulateResult
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &res))
+	assert.Empty(t, res.Error)
+	assert.Positive(t, res.GasUsed)
+	require.Len(t, res.Events, 1)
+	assert.IsType(t, chain.StorageDepositEvent{}, res.Events[0])
+
+	qres = h.Query(env.ctx, abci.RequestQuery{Path: "vm/" + QuerySimulate, Data: []byte("{")})
+	require.NotNil(t, qres.Error)
+	assert.Contains(t, qres.Log, "invalid simulation request")
+}
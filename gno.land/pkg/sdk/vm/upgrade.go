@@ -0,0 +1,250 @@
+package vm
+
+import (
+	"fmt"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/stdlibs"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// migrateFuncName is the function of an upgraded realm called by
+// UpgradePackage, if the new code declares it.
+const migrateFuncName = "Migrate"
+
+// checkUpgradeAuthority validates the upgrade section of the gnomod.toml of
+// a package being added or upgraded.
+func checkUpgradeAuthority(gm *gnomod.File, pkgPath string) error {
+	if !gm.IsUpgradable() {
+		return nil
+	}
+	if !gno.IsRealmPath(pkgPath) {
+		return ErrInvalidPackage("only realm packages can be upgradable")
+	}
+	if _, err := crypto.AddressFromBech32(gm.Upgrade.Authority); err != nil {
+		return ErrInvalidPackage(fmt.Sprintf("invalid upgrade authority %q: %v", gm.Upgrade.Authority, err))
+	}
+	return nil
+}
+
+// checkUpgrader returns the gnomod.toml of the realm stored at pkgPath, or an
+// error if addr is not its upgrade authority.
+func checkUpgrader(gnostore gno.Store, pkgPath string, addr crypto.Address) (*gnomod.File, error) {
+	gm, err := gnomod.ParseMemPackage(gnostore.GetMemPackage(pkgPath))
+	if err != nil || !gm.IsUpgradable() {
+		return nil, ErrUnauthorizedUser("package is not upgradable: " + pkgPath)
+	}
+	if gm.Upgrade.Authority != addr.String() {
+		return nil, ErrUnauthorizedUser(fmt.Sprintf("%s is not the upgrade authority of %s", addr, pkgPath))
+	}
+	return gm, nil
+}
+
+// UpgradePackage replaces the code of an upgradable realm, keeping its
+// address and state, then calls its Migrate function if the new code
+// declares one. Only the upgrade authority named in the gnomod.toml of the
+// running code may upgrade a realm; the new gnomod.toml may name another
+// authority, or none to make the realm immutable.
+//
+// The upgrade and the migration are atomic: if either fails, or if function
+// values of the old code are still persisted after the migration, the realm
+// keeps its previous code and state.
+func (vm *VMKeeper) UpgradePackage(ctx sdk.Context, msg MsgUpgradePackage) (err error) {
+	upgrader := msg.Upgrader
+	pkgPath := msg.Package.Path
+	memPkg := msg.Package
+	gnostore := vm.getGnoTransactionStore(ctx)
+	chainDomain := vm.getChainDomainParam(ctx)
+
+	memPkg.Type = gno.MPUserAll
+
+	// Validate arguments.
+	if upgrader.IsZero() {
+		return std.ErrInvalidAddress("missing upgrader address")
+	}
+	if vm.acck.GetAccount(ctx, upgrader) == nil {
+		return std.ErrUnknownAddress(fmt.Sprintf("account %s does not exist, it must receive coins to be created", upgrader))
+	}
+	if err := gno.ValidateMemPackageAny(memPkg); err != nil {
+		return ErrInvalidPkgPath(err.Error())
+	}
+	if !hasProdGnoFile(memPkg) {
+		return ErrInvalidPackage("package has no production .gno files")
+	}
+	if !gno.IsRealmPath(pkgPath) {
+		return ErrInvalidPkgPath("only realm packages can be upgraded")
+	}
+	pv := gnostore.GetPackage(pkgPath, false)
+	if pv == nil {
+		return vm.errPackageNotFound(ctx, pkgPath)
+	}
+
+	// Only the authority of the running code may upgrade it.
+	oldMod, err := checkUpgrader(gnostore, pkgPath, upgrader)
+	if err != nil {
+		return err
+	}
+	if err := vm.checkCLASignature(ctx, upgrader); err != nil {
+		return err
+	}
+
+	opts := gno.TypeCheckOptions{
+		Getter:     gnostore,
+		TestGetter: vm.testStdlibCache.memPackageGetter(gnostore),
+		Mode:       gno.TCLatestStrict,
+		Cache:      vm.getTypeCheckCache(ctx),
+	}
+	params := vm.GetParams(ctx)
+	opts.AllowConcurrency = params.AllowConcurrency
+	chargePreprocessGas(ctx, params, memPkg, "UpgradePackagePreprocess")
+	// Validate Gno syntax and type check.
+	_, err = gno.TypeCheckMemPackage(memPkg, opts)
+	if err != nil {
+		return ErrTypeCheck(err)
+	}
+
+	// Extra keeper-only checks, as for AddPackage.
+	gm, err := gnomod.ParseMemPackage(memPkg)
+	if err != nil {
+		return ErrInvalidPackage(err.Error())
+	}
+	if gm.HasReplaces() {
+		return ErrInvalidPackage("development packages are not allowed")
+	}
+	if gm.Private != pv.Private {
+		return ErrInvalidPackage("an upgrade cannot change whether a package is private")
+	}
+	if gm.Draft && ctx.BlockHeight() > 0 {
+		return ErrInvalidPackage("draft packages can only be deployed at genesis time")
+	}
+	if memPkg.GetFile("gno.mod") != nil {
+		return ErrInvalidPackage("gno.mod file is deprecated and not allowed, run 'gno mod tidy' to upgrade to gnomod.toml")
+	}
+	if err := checkUpgradeAuthority(gm, pkgPath); err != nil {
+		return err
+	}
+
+	// Patch gnomod.toml metadata; the addpkg section is kept.
+	gm.Module = pkgPath
+	gm.AddPkg = oldMod.AddPkg
+	memPkg.SetFile("gnomod.toml", gm.WriteString())
+
+	// Seed per-message accumulator before NewSDKParams captures ctx.
+	ctx = ContextWithParamsAccum(ctx)
+	msgCtx := stdlibs.ExecContext{
+		ChainID:         ctx.ChainID(),
+		ChainDomain:     chainDomain,
+		Height:          ctx.BlockHeight(),
+		Timestamp:       ctx.BlockTime().Unix(),
+		OriginCaller:    upgrader.Bech32(),
+		OriginSendSpent: new(std.Coins),
+		Banker:          NewSDKBanker(vm, ctx),
+		Params:          NewSDKParams(vm.prmk, ctx),
+		Scheduler:       NewSDKScheduler(vm, ctx),
+		EventLogger:     ctx.EventLogger(),
+		SessionAccount:  getSessionAccount(ctx, upgrader),
+	}
+	m2 := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath:             "",
+			Output:              vm.Output,
+			Store:               gnostore,
+			Alloc:               gnostore.GetAllocator(),
+			Context:             msgCtx,
+			GasMeter:            ctx.GasMeter(),
+			Profiler:            vm.getProfiler(ctx),
+			BoundedPanicRender:  true,
+			DisallowConcurrency: !params.AllowConcurrency,
+		})
+	defer m2.Release()
+	defer doRecover(m2, &err)
+	// See AddPackage.
+	preAlloc := gno.NewAllocator(maxAllocTx)
+	preAlloc.SetGasMeter(ctx.GasMeter())
+	gnostore.SetPreprocessAllocator(preAlloc)
+	defer gnostore.SetPreprocessAllocator(nil)
+	since := gnostore.GetPackageRealm(pkgPath).Time
+	pn, _ := m2.UpgradeMemPackage(memPkg)
+
+	// Type checks of later messages must see the new code, and the
+	// packages importing it.
+	tcCache := vm.getTypeCheckCache(ctx)
+	for path := range tcCache {
+		if !gno.IsStdlib(path) {
+			delete(tcCache, path)
+		}
+	}
+
+	if _, ok := pn.GetLocalIndex(migrateFuncName); ok {
+		// Objects loaded so far refer to the old types: reload them, but
+		// keep the storage changes of the upgrade for the deposit.
+		diffs := gnostore.RealmStorageDiffs()
+		gnostore.ClearObjectCache()
+		for path, diff := range diffs {
+			gnostore.RealmStorageDiffs()[path] += diff
+		}
+		if err := vm.runMigrate(ctx, gnostore, msgCtx, pkgPath, params.AllowConcurrency); err != nil {
+			return err
+		}
+	}
+	// Migrate may replace the function values of the old code.
+	if err := gno.CheckUpgradeFuncValues(gnostore, pkgPath, since); err != nil {
+		return ErrInvalidPackage(err.Error())
+	}
+
+	err = vm.processStorageDeposit(ctx, upgrader, msg.MaxDeposit, gnostore, params)
+	if err != nil {
+		return err
+	}
+	// Log the telemetry
+	logTelemetry(
+		m2.GasMeter.GasConsumed(),
+		m2.Cycles,
+		attribute.KeyValue{
+			Key:   "operation",
+			Value: attribute.StringValue("m_upgradepkg"),
+		},
+	)
+
+	return nil
+}
+
+// runMigrate calls Migrate(cur realm) on the upgraded realm at pkgPath,
+// crossing into it as a MsgCall from the upgrader would. allowConc is the
+// allow_concurrency param, as read before the upgrade.
+func (vm *VMKeeper) runMigrate(ctx sdk.Context, gnostore gno.Store, msgCtx stdlibs.ExecContext, pkgPath string, allowConc bool) (err error) {
+	pv := gnostore.GetPackage(pkgPath, false)
+	pn := gnostore.GetBlockNode(gno.PackageNodeLocation(pkgPath)).(*gno.PackageNode)
+	ft, ok := pn.GetStaticTypeOf(gnostore, migrateFuncName).(*gno.FuncType)
+	if !ok || len(ft.Params) != 1 || ft.Params[0].Type.String() != ".uverse.realm" || len(ft.Results) != 0 {
+		return ErrInvalidPackage(fmt.Sprintf("%s must be declared as func %s(cur realm)", migrateFuncName, migrateFuncName))
+	}
+
+	mpn := gno.NewPackageNode("main", "", nil)
+	mpn.Define("pkg", gno.TypedValue{T: &gno.PackageType{}, V: pv})
+	mpv := mpn.NewPackage(gnostore.GetAllocator())
+	m := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath:             "",
+			Output:              vm.Output,
+			Store:               gnostore,
+			Context:             msgCtx,
+			Alloc:               gnostore.GetAllocator(),
+			GasMeter:            ctx.GasMeter(),
+			Profiler:            vm.getProfiler(ctx),
+			BoundedPanicRender:  true,
+			DisallowConcurrency: !allowConc,
+		})
+	defer m.Release()
+	cx := m.MustParseExpr(fmt.Sprintf("pkg.%s(cross)", migrateFuncName)).(*gno.CallExpr)
+	// See Call.
+	cx.Args[0] = gno.Nx(".origin")
+	m.SetActivePackage(mpv)
+	defer doRecover(m, &err)
+	m.Eval(cx)
+	return nil
+}
@@ -0,0 +1,363 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const upgradeTestPath = "gno.land/r/test/counter"
+
+const upgradeTestV1 = `package counter
+
+type Item struct {
+	Name string
+}
+
+func (it *Item) Label() string { return "item " + it.Name }
+
+var (
+	count int
+	items []*Item
+)
+
+func Inc(cur realm, name string) {
+	count++
+	items = append(items, &Item{Name: name})
+}
+
+func Count() int { return count }
+
+func Last() string { return items[len(items)-1].Label() }
+`
+
+const upgradeTestV2 = `package counter
+
+type Item struct {
+	Name string
+}
+
+func (it *Item) Label() string { return "ITEM " + it.Name }
+
+func (it *Item) Upper() string { return it.Label() + "!" }
+
+var (
+	count int
+	items []*Item
+)
+
+func Inc(cur realm, name string) {
+	count += 10
+	items = append(items, &Item{Name: name})
+}
+
+func Count() int { return count }
+
+func Last() string { return items[len(items)-1].Upper() }
+
+var version = count + 1
+
+func Version() int { return version }
+
+func Migrate(cur realm) {
+	version = 2
+	items = append(items, &Item{Name: "migrated"})
+}
+`
+
+const upgradeTestImporter = `package reader
+
+import "gno.land/r/test/counter"
+
+func Read() string { return counter.Last() }
+`
+
+// upgradeTestFiles returns the files of the realm at upgradeTestPath, with an
+// upgrade authority if authority is not empty.
+func upgradeTestFiles(authority crypto.Address, body string) []*std.MemFile {
+	gnomod := gnolang.GenGnoModLatest(upgradeTestPath)
+	if !authority.IsZero() {
+		gnomod += fmt.Sprintf("\n[upgrade]\n  authority = %q\n", authority.String())
+	}
+	return []*std.MemFile{
+		{Name: "counter.gno", Body: body},
+		{Name: "gnomod.toml", Body: gnomod},
+	}
+}
+
+func setupUpgradeTest(t *testing.T, authority crypto.Address) (testEnv, crypto.Address) {
+	t.Helper()
+
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	if authority.IsZero() {
+		authority = addr
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, upgradeTestPath, upgradeTestFiles(authority, upgradeTestV1)))
+	require.NoError(t, err)
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"a"}))
+	require.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+	return env, addr
+}
+
+// upgradeTestTx runs fn in a new transaction, committed if fn succeeds.
+func upgradeTestTx(env testEnv, fn func(ctx sdk.Context) error) error {
+	msCache := env.ctx.MultiStore().MultiCacheWrap()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx.WithMultiStore(msCache))
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	env.vmk.CommitGnoTransactionStore(ctx)
+	msCache.MultiWrite()
+	return nil
+}
+
+func TestVMKeeperUpgradePackage(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+	const readerPath = "gno.land/r/test/reader"
+	err := upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, readerPath, []*std.MemFile{
+			{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(readerPath)},
+			{Name: "reader.gno", Body: upgradeTestImporter},
+		}))
+	})
+	require.NoError(t, err)
+
+	eval := func(pkgPath, expr string) string {
+		t.Helper()
+		res, err := env.vmk.QueryEval(env.ctx, pkgPath, expr)
+		require.NoError(t, err)
+		return res
+	}
+	assert.Equal(t, `("item a" string)`, eval(readerPath, "Read()"))
+
+	err = upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, upgradeTestPath, upgradeTestFiles(addr, upgradeTestV2)))
+	})
+	require.NoError(t, err)
+
+	// State is kept, new code runs, Migrate was called.
+	assert.Equal(t, `(1 int)`, eval(upgradeTestPath, "Count()"))
+	assert.Equal(t, `(2 int)`, eval(upgradeTestPath, "Version()"))
+	assert.Equal(t, `("ITEM migrated!" string)`, eval(upgradeTestPath, "Last()"))
+	assert.Equal(t, `("ITEM migrated!" string)`, eval(readerPath, "Read()"))
+	err = upgradeTestTx(env, func(ctx sdk.Context) error {
+		_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, upgradeTestPath, "Inc", []string{"b"}))
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `(11 int)`, eval(upgradeTestPath, "Count()"))
+
+	// The addpkg metadata is kept.
+	mpkg := env.vmk.getGnoTransactionStore(env.vmk.MakeGnoTransactionStore(env.ctx)).GetMemPackage(upgradeTestPath)
+	assert.Contains(t, mpkg.GetFile("gnomod.toml").Body, "creator = \""+addr.String()+"\"")
+
+	// The same code and state are found after a restart.
+	env.vmk.gnoStore = nil
+	mcw := env.ctx.MultiStore().MultiCacheWrap()
+	env.vmk.Initialize(log.NewNoopLogger(), mcw)
+	mcw.MultiWrite()
+	assert.Equal(t, `(11 int)`, eval(upgradeTestPath, "Count()"))
+	assert.Equal(t, `("ITEM b!" string)`, eval(readerPath, "Read()"))
+}
+
+func TestVMKeeperUpgradePackage_Unauthorized(t *testing.T) {
+	authority := crypto.AddressFromPreimage([]byte("authority"))
+	env, addr := setupUpgradeTest(t, authority)
+
+	err := upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, upgradeTestPath, upgradeTestFiles(authority, upgradeTestV2)))
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "is not the upgrade authority")
+}
+
+func TestVMKeeperUpgradePackage_NotUpgradable(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, upgradeTestPath, upgradeTestFiles(crypto.Address{}, upgradeTestV1)))
+	require.NoError(t, err)
+
+	err = env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, upgradeTestPath, upgradeTestFiles(addr, upgradeTestV2)))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "package is not upgradable")
+}
+
+func TestVMKeeperUpgradePackage_Incompatible(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		errMsg string
+	}{
+		{
+			name: "variable type changed",
+			body: `package counter
+
+type Item struct {
+	Name string
+}
+
+func (it *Item) Label() string { return it.Name }
+
+var (
+	count int64
+	items []*Item
+)
+`,
+			errMsg: "cannot upgrade gno.land/r/test/counter: variable count changed type from int to int64",
+		},
+		{
+			name: "variable removed",
+			body: `package counter
+
+type Item struct {
+	Name string
+}
+
+func (it *Item) Label() string { return it.Name }
+
+var items []*Item
+`,
+			errMsg: "cannot upgrade gno.land/r/test/counter: variable count was removed",
+		},
+		{
+			name: "field added",
+			body: `package counter
+
+type Item struct {
+	Name string
+	Age  int
+}
+
+func (it *Item) Label() string { return it.Name }
+
+var (
+	count int
+	items []*Item
+)
+`,
+			errMsg: "cannot upgrade gno.land/r/test/counter: type Item changed underlying type",
+		},
+		{
+			name: "method removed",
+			body: `package counter
+
+type Item struct {
+	Name string
+}
+
+var (
+	count int
+	items []*Item
+)
+`,
+			errMsg: "cannot upgrade gno.land/r/test/counter: method Item.Label was removed",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			env, addr := setupUpgradeTest(t, crypto.Address{})
+
+			err := upgradeTestTx(env, func(ctx sdk.Context) error {
+				return env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, upgradeTestPath, upgradeTestFiles(addr, tc.body)))
+			})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+
+			// The realm is left untouched.
+			res, err := env.vmk.QueryEval(env.ctx, upgradeTestPath, "Last()")
+			require.NoError(t, err)
+			assert.Equal(t, `("item a" string)`, res)
+		})
+	}
+}
+
+func TestVMKeeperUpgradePackage_InvalidMigrate(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+
+	body := upgradeTestV1 + `
+func Migrate(cur realm, v int) {}
+`
+	err := upgradeTestTx(env, func(ctx sdk.Context) error {
+		return env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, upgradeTestPath, upgradeTestFiles(addr, body)))
+	})
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, InvalidPackageError{}))
+	assert.Contains(t, fmt.Sprintf("%+v", err), "Migrate must be declared as func Migrate(cur realm)")
+}
+
+func TestVMKeeperUpgradePackage_FuncValues(t *testing.T) {
+	env, addr := setupUpgradeTest(t, crypto.Address{})
+	// As within a block, so that the store iterators see the writes of the
+	// previous transactions.
+	env.ctx = env.ctx.WithMultiStore(env.ctx.MultiStore().MultiCacheWrap())
+
+	hookBody := upgradeTestV1 + `
+var hook func() string
+
+func SetHook(cur realm) {
+	hook = func() string { return "hook " + Last() }
+}
+
+func Hook() string { return hook() }
+`
+	upgrade := func(body string) error {
+		return upgradeTestTx(env, func(ctx sdk.Context) error {
+			return env.vmk.UpgradePackage(ctx, NewMsgUpgradePackage(addr, upgradeTestPath, upgradeTestFiles(addr, body)))
+		})
+	}
+	eval := func(expr string) string {
+		t.Helper()
+		res, err := env.vmk.QueryEval(env.ctx, upgradeTestPath, expr)
+		require.NoError(t, err)
+		return res
+	}
+
+	// The declared functions of the old code are replaced.
+	require.NoError(t, upgrade(hookBody))
+	err := upgradeTestTx(env, func(ctx sdk.Context) error {
+		_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, upgradeTestPath, "SetHook", nil))
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `("hook item a" string)`, eval("Hook()"))
+
+	// The stored closure runs the old code: the upgrade is rejected, and
+	// the closure can still be called.
+	err = upgrade(hookBody + "\nfunc Other() {}\n")
+	require.Error(t, err)
+	assert.ErrorContains(t, fmt.Errorf("%+v", err), "cannot upgrade gno.land/r/test/counter: function value")
+	assert.Contains(t, fmt.Sprintf("%+v", err), "of the old code is persisted")
+	assert.Equal(t, `("hook item a" string)`, eval("Hook()"))
+
+	// Unless Migrate replaces it.
+	err = upgrade(hookBody + `
+func Migrate(cur realm) {
+	hook = func() string { return "new hook " + Last() }
+}
+`)
+	require.NoError(t, err)
+	assert.Equal(t, `("new hook item a" string)`, eval("Hook()"))
+}
@@ -0,0 +1,106 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestReadonlyView(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	const oraclePath = "gno.land/r/test/oracle"
+	oracle := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(oraclePath)},
+		{Name: "oracle.gno", Body: `package oracle
+
+var prices = map[string]int64{"ugnot": 42}
+var reads int
+
+func Price(denom string) int64 { return prices[denom] }
+
+func CountedPrice(denom string) int64 {
+	reads++
+	return prices[denom]
+}
+
+func Reads(cur realm) int { return reads }
+
+func Render(path string) string {
+	reads++
+	return "rendered"
+}
+`},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, oraclePath, oracle)))
+
+	const consumerPath = "gno.land/r/test/consumer"
+	consumer := []*std.MemFile{
+		{Name: "consumer.gno", Body: `package consumer
+
+import (
+	"chain/runtime"
+
+	"gno.land/r/test/oracle"
+)
+
+func Value(cur realm, amount int64) int64 {
+	var price int64
+	runtime.View(func() { price = oracle.Price("ugnot") })
+	return amount * price
+}
+
+func CountedValue(cur realm, amount int64) int64 {
+	var price int64
+	runtime.View(func() { price = oracle.CountedPrice("ugnot") })
+	return amount * price
+}
+`},
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(consumerPath)},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, consumerPath, consumer)))
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	call := func(pkgPath, fn string, args ...string) (string, error) {
+		ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+		defer env.vmk.CommitGnoTransactionStore(ctx)
+		return env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, fn, args))
+	}
+
+	// A view reads the state of another realm.
+	res, err := call(consumerPath, "Value", "10")
+	require.NoError(t, err)
+	assert.Equal(t, "(420 int64)\n\n", res)
+
+	// Modifying it fails, although the same call outside of a view would
+	// borrow the oracle realm and succeed.
+	_, err = call(consumerPath, "CountedValue", "10")
+	assert.ErrorContains(t, err, "cannot modify object in a read-only view: reads")
+	res, err = env.vmk.QueryEval(env.ctx, oraclePath, "Reads()")
+	require.NoError(t, err)
+	assert.Equal(t, "(0 int)", res)
+
+	// vm/qview evaluates queries in a read-only view, vm/qeval does not.
+	_, err = env.vmk.QueryView(env.ctx, oraclePath, `CountedPrice("ugnot")`)
+	assert.ErrorContains(t, err, "cannot modify object in a read-only view: reads")
+	res, err = env.vmk.QueryView(env.ctx, oraclePath, `Price("ugnot")`)
+	require.NoError(t, err)
+	assert.Equal(t, "(42 int64)", res)
+	res, err = env.vmk.QueryEval(env.ctx, oraclePath, `CountedPrice("ugnot")`)
+	require.NoError(t, err)
+	assert.Equal(t, "(42 int64)", res)
+	res, err = env.vmk.QueryEvalString(env.ctx, oraclePath, `Render("")`)
+	require.NoError(t, err)
+	assert.Equal(t, "rendered", res)
+}
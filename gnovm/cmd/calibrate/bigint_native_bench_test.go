@@ -0,0 +1,406 @@
+package calibrate
+
+// Native function calibration benchmarks for math/big. Same harness
+// conventions as native_bench_test.go. Operands are passed the way big.gno
+// passes them: a sign and a big-endian magnitude per Int, plus a
+// denominator magnitude per Rat.
+//
+// Bench names encode the per-operand magnitude size in bytes. For natives
+// charged on two operands (Slope + Slope2), both operands have that size,
+// and the fitted slope is split evenly between the two in native_gas.go.
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// bigOperand returns an n-byte magnitude with the top bit set, so that
+// every operand of a given size has the same bit length.
+func bigOperand(n int, seed byte) []byte {
+	x := make([]byte, n)
+	for i := range x {
+		x[i] = byte(i*31) ^ seed
+	}
+	if n > 0 {
+		x[0] |= 0x80
+		x[n-1] |= 1 // odd, for the modulus-taking natives
+	}
+	return x
+}
+
+func benchBigIntNative(b *testing.B, fn gno.Name, nReturns int, params ...any) {
+	b.Helper()
+	m := newDispatchMachine(len(params))
+	for i, p := range params {
+		setBlockValueFromGo(m, i, p)
+	}
+	h := &dispatchHarness{m: m, wrapper: resolveWrapper(b, "math/big", fn), nReturns: nReturns}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.call()
+	}
+}
+
+// ----- intAdd & co: (xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) -----
+
+func benchBigIntBinary(b *testing.B, fn gno.Name, n int) {
+	benchBigIntNative(b, fn, 2, false, bigOperand(n, 1), true, bigOperand(n, 2))
+}
+
+func BenchmarkNative_BigInt_Add_8(b *testing.B)   { benchBigIntBinary(b, "intAdd", 8) }
+func BenchmarkNative_BigInt_Add_32(b *testing.B)  { benchBigIntBinary(b, "intAdd", 32) }
+func BenchmarkNative_BigInt_Add_128(b *testing.B) { benchBigIntBinary(b, "intAdd", 128) }
+func BenchmarkNative_BigInt_Add_512(b *testing.B) { benchBigIntBinary(b, "intAdd", 512) }
+
+func BenchmarkNative_BigInt_Sub_8(b *testing.B)   { benchBigIntBinary(b, "intSub", 8) }
+func BenchmarkNative_BigInt_Sub_32(b *testing.B)  { benchBigIntBinary(b, "intSub", 32) }
+func BenchmarkNative_BigInt_Sub_128(b *testing.B) { benchBigIntBinary(b, "intSub", 128) }
+func BenchmarkNative_BigInt_Sub_512(b *testing.B) { benchBigIntBinary(b, "intSub", 512) }
+
+func BenchmarkNative_BigInt_Mul_8(b *testing.B)   { benchBigIntBinary(b, "intMul", 8) }
+func BenchmarkNative_BigInt_Mul_32(b *testing.B)  { benchBigIntBinary(b, "intMul", 32) }
+func BenchmarkNative_BigInt_Mul_128(b *testing.B) { benchBigIntBinary(b, "intMul", 128) }
+func BenchmarkNative_BigInt_Mul_512(b *testing.B) { benchBigIntBinary(b, "intMul", 512) }
+
+func BenchmarkNative_BigInt_And_8(b *testing.B)   { benchBigIntBinary(b, "intAnd", 8) }
+func BenchmarkNative_BigInt_And_32(b *testing.B)  { benchBigIntBinary(b, "intAnd", 32) }
+func BenchmarkNative_BigInt_And_128(b *testing.B) { benchBigIntBinary(b, "intAnd", 128) }
+func BenchmarkNative_BigInt_And_512(b *testing.B) { benchBigIntBinary(b, "intAnd", 512) }
+
+func BenchmarkNative_BigInt_AndNot_8(b *testing.B)   { benchBigIntBinary(b, "intAndNot", 8) }
+func BenchmarkNative_BigInt_AndNot_32(b *testing.B)  { benchBigIntBinary(b, "intAndNot", 32) }
+func BenchmarkNative_BigInt_AndNot_128(b *testing.B) { benchBigIntBinary(b, "intAndNot", 128) }
+func BenchmarkNative_BigInt_AndNot_512(b *testing.B) { benchBigIntBinary(b, "intAndNot", 512) }
+
+func BenchmarkNative_BigInt_Or_8(b *testing.B)   { benchBigIntBinary(b, "intOr", 8) }
+func BenchmarkNative_BigInt_Or_32(b *testing.B)  { benchBigIntBinary(b, "intOr", 32) }
+func BenchmarkNative_BigInt_Or_128(b *testing.B) { benchBigIntBinary(b, "intOr", 128) }
+func BenchmarkNative_BigInt_Or_512(b *testing.B) { benchBigIntBinary(b, "intOr", 512) }
+
+func BenchmarkNative_BigInt_Xor_8(b *testing.B)   { benchBigIntBinary(b, "intXor", 8) }
+func BenchmarkNative_BigInt_Xor_32(b *testing.B)  { benchBigIntBinary(b, "intXor", 32) }
+func BenchmarkNative_BigInt_Xor_128(b *testing.B) { benchBigIntBinary(b, "intXor", 128) }
+func BenchmarkNative_BigInt_Xor_512(b *testing.B) { benchBigIntBinary(b, "intXor", 512) }
+
+func BenchmarkNative_BigInt_GCD_8(b *testing.B) {
+	benchBigIntNative(b, "intGCD", 5, false, bigOperand(8, 1), false, bigOperand(8, 2))
+}
+func BenchmarkNative_BigInt_GCD_32(b *testing.B) {
+	benchBigIntNative(b, "intGCD", 5, false, bigOperand(32, 1), false, bigOperand(32, 2))
+}
+func BenchmarkNative_BigInt_GCD_128(b *testing.B) {
+	benchBigIntNative(b, "intGCD", 5, false, bigOperand(128, 1), false, bigOperand(128, 2))
+}
+func BenchmarkNative_BigInt_GCD_512(b *testing.B) {
+	benchBigIntNative(b, "intGCD", 5, false, bigOperand(512, 1), false, bigOperand(512, 2))
+}
+
+func benchBigIntModInverse(b *testing.B, n int) {
+	benchBigIntNative(b, "intModInverse", 3, false, bigOperand(n, 1), false, bigOperand(n, 2))
+}
+
+func BenchmarkNative_BigInt_ModInverse_8(b *testing.B)   { benchBigIntModInverse(b, 8) }
+func BenchmarkNative_BigInt_ModInverse_32(b *testing.B)  { benchBigIntModInverse(b, 32) }
+func BenchmarkNative_BigInt_ModInverse_128(b *testing.B) { benchBigIntModInverse(b, 128) }
+func BenchmarkNative_BigInt_ModInverse_512(b *testing.B) { benchBigIntModInverse(b, 512) }
+
+// ----- division natives -----
+//
+// Schoolbook division costs O(len(y)·(len(x)-len(y))), which peaks at
+// len(y) == len(x)/2. The bench uses that shape and the fitted slope is
+// charged on len(x) alone.
+
+func benchBigIntDivision(b *testing.B, fn gno.Name, n int) {
+	benchBigIntNative(b, fn, 2, false, bigOperand(n, 1), true, bigOperand(n/2, 2))
+}
+
+func BenchmarkNative_BigInt_Quo_8(b *testing.B)   { benchBigIntDivision(b, "intQuo", 8) }
+func BenchmarkNative_BigInt_Quo_32(b *testing.B)  { benchBigIntDivision(b, "intQuo", 32) }
+func BenchmarkNative_BigInt_Quo_128(b *testing.B) { benchBigIntDivision(b, "intQuo", 128) }
+func BenchmarkNative_BigInt_Quo_512(b *testing.B) { benchBigIntDivision(b, "intQuo", 512) }
+func BenchmarkNative_BigInt_Rem_8(b *testing.B)   { benchBigIntDivision(b, "intRem", 8) }
+func BenchmarkNative_BigInt_Rem_32(b *testing.B)  { benchBigIntDivision(b, "intRem", 32) }
+func BenchmarkNative_BigInt_Rem_128(b *testing.B) { benchBigIntDivision(b, "intRem", 128) }
+func BenchmarkNative_BigInt_Rem_512(b *testing.B) { benchBigIntDivision(b, "intRem", 512) }
+func BenchmarkNative_BigInt_Div_8(b *testing.B)   { benchBigIntDivision(b, "intDiv", 8) }
+func BenchmarkNative_BigInt_Div_32(b *testing.B)  { benchBigIntDivision(b, "intDiv", 32) }
+func BenchmarkNative_BigInt_Div_128(b *testing.B) { benchBigIntDivision(b, "intDiv", 128) }
+func BenchmarkNative_BigInt_Div_512(b *testing.B) { benchBigIntDivision(b, "intDiv", 512) }
+func BenchmarkNative_BigInt_Mod_8(b *testing.B)   { benchBigIntDivision(b, "intMod", 8) }
+func BenchmarkNative_BigInt_Mod_32(b *testing.B)  { benchBigIntDivision(b, "intMod", 32) }
+func BenchmarkNative_BigInt_Mod_128(b *testing.B) { benchBigIntDivision(b, "intMod", 128) }
+func BenchmarkNative_BigInt_Mod_512(b *testing.B) { benchBigIntDivision(b, "intMod", 512) }
+
+// ----- single-operand natives -----
+
+func BenchmarkNative_BigInt_Not_8(b *testing.B) {
+	benchBigIntNative(b, "intNot", 2, true, bigOperand(8, 1))
+}
+func BenchmarkNative_BigInt_Not_32(b *testing.B) {
+	benchBigIntNative(b, "intNot", 2, true, bigOperand(32, 1))
+}
+func BenchmarkNative_BigInt_Not_128(b *testing.B) {
+	benchBigIntNative(b, "intNot", 2, true, bigOperand(128, 1))
+}
+func BenchmarkNative_BigInt_Not_512(b *testing.B) {
+	benchBigIntNative(b, "intNot", 2, true, bigOperand(512, 1))
+}
+
+func BenchmarkNative_BigInt_Rsh_8(b *testing.B) {
+	benchBigIntNative(b, "intRsh", 2, true, bigOperand(8, 1), uint(3))
+}
+func BenchmarkNative_BigInt_Rsh_32(b *testing.B) {
+	benchBigIntNative(b, "intRsh", 2, true, bigOperand(32, 1), uint(3))
+}
+func BenchmarkNative_BigInt_Rsh_128(b *testing.B) {
+	benchBigIntNative(b, "intRsh", 2, true, bigOperand(128, 1), uint(3))
+}
+func BenchmarkNative_BigInt_Rsh_512(b *testing.B) {
+	benchBigIntNative(b, "intRsh", 2, true, bigOperand(512, 1), uint(3))
+}
+
+func BenchmarkNative_BigInt_Bit_8(b *testing.B) {
+	benchBigIntNative(b, "intBit", 1, true, bigOperand(8, 1), 5)
+}
+func BenchmarkNative_BigInt_Bit_32(b *testing.B) {
+	benchBigIntNative(b, "intBit", 1, true, bigOperand(32, 1), 5)
+}
+func BenchmarkNative_BigInt_Bit_128(b *testing.B) {
+	benchBigIntNative(b, "intBit", 1, true, bigOperand(128, 1), 5)
+}
+func BenchmarkNative_BigInt_Bit_512(b *testing.B) {
+	benchBigIntNative(b, "intBit", 1, true, bigOperand(512, 1), 5)
+}
+
+func BenchmarkNative_BigInt_Sqrt_8(b *testing.B) {
+	benchBigIntNative(b, "intSqrt", 1, bigOperand(8, 1))
+}
+func BenchmarkNative_BigInt_Sqrt_32(b *testing.B) {
+	benchBigIntNative(b, "intSqrt", 1, bigOperand(32, 1))
+}
+func BenchmarkNative_BigInt_Sqrt_128(b *testing.B) {
+	benchBigIntNative(b, "intSqrt", 1, bigOperand(128, 1))
+}
+func BenchmarkNative_BigInt_Sqrt_512(b *testing.B) {
+	benchBigIntNative(b, "intSqrt", 1, bigOperand(512, 1))
+}
+
+func BenchmarkNative_BigInt_CmpAbs_8(b *testing.B) {
+	benchBigIntNative(b, "intCmpAbs", 1, bigOperand(8, 1), bigOperand(8, 1))
+}
+func BenchmarkNative_BigInt_CmpAbs_32(b *testing.B) {
+	benchBigIntNative(b, "intCmpAbs", 1, bigOperand(32, 1), bigOperand(32, 1))
+}
+func BenchmarkNative_BigInt_CmpAbs_128(b *testing.B) {
+	benchBigIntNative(b, "intCmpAbs", 1, bigOperand(128, 1), bigOperand(128, 1))
+}
+func BenchmarkNative_BigInt_CmpAbs_512(b *testing.B) {
+	benchBigIntNative(b, "intCmpAbs", 1, bigOperand(512, 1), bigOperand(512, 1))
+}
+
+func BenchmarkNative_BigInt_Text_8(b *testing.B) {
+	benchBigIntNative(b, "intText", 1, true, bigOperand(8, 1), 10)
+}
+func BenchmarkNative_BigInt_Text_32(b *testing.B) {
+	benchBigIntNative(b, "intText", 1, true, bigOperand(32, 1), 10)
+}
+func BenchmarkNative_BigInt_Text_128(b *testing.B) {
+	benchBigIntNative(b, "intText", 1, true, bigOperand(128, 1), 10)
+}
+func BenchmarkNative_BigInt_Text_512(b *testing.B) {
+	benchBigIntNative(b, "intText", 1, true, bigOperand(512, 1), 10)
+}
+
+// SetString benches decimal input, the slowest base to parse; the name
+// encodes the number of digits.
+func benchBigIntSetString(b *testing.B, n int) {
+	benchBigIntNative(b, "intSetString", 3, strings.Repeat("9", n), 10)
+}
+
+func BenchmarkNative_BigInt_SetString_16(b *testing.B)   { benchBigIntSetString(b, 16) }
+func BenchmarkNative_BigInt_SetString_64(b *testing.B)   { benchBigIntSetString(b, 64) }
+func BenchmarkNative_BigInt_SetString_256(b *testing.B)  { benchBigIntSetString(b, 256) }
+func BenchmarkNative_BigInt_SetString_1024(b *testing.B) { benchBigIntSetString(b, 1024) }
+
+// ----- natives charged on their result -----
+//
+// Lsh and SetBit can grow x arbitrarily, so they are charged on the length
+// of the returned magnitude; the name encodes that length.
+
+func BenchmarkNative_BigInt_Lsh_16(b *testing.B) {
+	benchBigIntNative(b, "intLsh", 2, false, bigOperand(8, 1), uint(64))
+}
+func BenchmarkNative_BigInt_Lsh_64(b *testing.B) {
+	benchBigIntNative(b, "intLsh", 2, false, bigOperand(32, 1), uint(256))
+}
+func BenchmarkNative_BigInt_Lsh_256(b *testing.B) {
+	benchBigIntNative(b, "intLsh", 2, false, bigOperand(128, 1), uint(1024))
+}
+func BenchmarkNative_BigInt_Lsh_1024(b *testing.B) {
+	benchBigIntNative(b, "intLsh", 2, false, bigOperand(512, 1), uint(4096))
+}
+
+func BenchmarkNative_BigInt_SetBit_16(b *testing.B) {
+	benchBigIntNative(b, "intSetBit", 2, false, bigOperand(8, 1), 127, uint(1))
+}
+func BenchmarkNative_BigInt_SetBit_64(b *testing.B) {
+	benchBigIntNative(b, "intSetBit", 2, false, bigOperand(32, 1), 511, uint(1))
+}
+func BenchmarkNative_BigInt_SetBit_256(b *testing.B) {
+	benchBigIntNative(b, "intSetBit", 2, false, bigOperand(128, 1), 2047, uint(1))
+}
+func BenchmarkNative_BigInt_SetBit_1024(b *testing.B) {
+	benchBigIntNative(b, "intSetBit", 2, false, bigOperand(512, 1), 8191, uint(1))
+}
+
+// ----- intExp(xneg, x, yneg, y, mneg, m) (bool, []byte, bool) -----
+//
+// With a modulus the cost is O(len(y)·len(m)²), like crypto/modexp; the
+// bench uses x, y and m of the same size, up to the 256-byte modulus limit
+// of int.gno. Without one, it is dominated
+// by the last squaring and is charged on the result length, which the
+// ExpNoMod name encodes (x is 8 bytes and y is n/8).
+
+func benchBigIntExp(b *testing.B, n int) {
+	benchBigIntNative(b, "intExp", 3, false, bigOperand(n, 1), false, bigOperand(n, 2), false, bigOperand(n, 3))
+}
+
+func BenchmarkNative_BigInt_Exp_8(b *testing.B)   { benchBigIntExp(b, 8) }
+func BenchmarkNative_BigInt_Exp_32(b *testing.B)  { benchBigIntExp(b, 32) }
+func BenchmarkNative_BigInt_Exp_64(b *testing.B)  { benchBigIntExp(b, 64) }
+func BenchmarkNative_BigInt_Exp_128(b *testing.B) { benchBigIntExp(b, 128) }
+func BenchmarkNative_BigInt_Exp_256(b *testing.B) { benchBigIntExp(b, 256) }
+
+func benchBigIntExpNoMod(b *testing.B, n int) {
+	y := new(big.Int).SetInt64(int64(n / 8)).Bytes()
+	benchBigIntNative(b, "intExp", 3, false, bigOperand(8, 1), false, y, false, []byte(nil))
+}
+
+func BenchmarkNative_BigInt_ExpNoMod_64(b *testing.B)    { benchBigIntExpNoMod(b, 64) }
+func BenchmarkNative_BigInt_ExpNoMod_512(b *testing.B)   { benchBigIntExpNoMod(b, 512) }
+func BenchmarkNative_BigInt_ExpNoMod_4096(b *testing.B)  { benchBigIntExpNoMod(b, 4096) }
+func BenchmarkNative_BigInt_ExpNoMod_32768(b *testing.B) { benchBigIntExpNoMod(b, 32768) }
+func BenchmarkNative_BigInt_ExpNoMod_131072(b *testing.B) {
+	benchBigIntExpNoMod(b, 131072)
+}
+
+// ----- intProbablyPrime(x []byte, n int) bool -----
+//
+// Primes take every round, so the bench uses primes at the maximum round
+// count (maxPrimeRounds in int.gno). Like the modulus of intExp, x is at
+// most 256 bytes.
+
+func benchBigIntProbablyPrime(b *testing.B, n int) {
+	p, err := rand.Prime(rand.Reader, 8*n)
+	if err != nil {
+		b.Fatal(err)
+	}
+	benchBigIntNative(b, "intProbablyPrime", 1, p.Bytes(), 20)
+}
+
+func BenchmarkNative_BigInt_ProbablyPrime_8(b *testing.B)   { benchBigIntProbablyPrime(b, 8) }
+func BenchmarkNative_BigInt_ProbablyPrime_32(b *testing.B)  { benchBigIntProbablyPrime(b, 32) }
+func BenchmarkNative_BigInt_ProbablyPrime_64(b *testing.B)  { benchBigIntProbablyPrime(b, 64) }
+func BenchmarkNative_BigInt_ProbablyPrime_128(b *testing.B) { benchBigIntProbablyPrime(b, 128) }
+func BenchmarkNative_BigInt_ProbablyPrime_256(b *testing.B) { benchBigIntProbablyPrime(b, 256) }
+
+// ----- Rat natives -----
+
+func benchBigRatNorm(b *testing.B, n int) {
+	// a and b share a factor of about n/2 bytes, so the result is reduced.
+	f := new(big.Int).SetBytes(bigOperand(n/2, 3))
+	x := new(big.Int).Mul(f, new(big.Int).SetBytes(bigOperand(n/2, 1)))
+	y := new(big.Int).Mul(f, new(big.Int).SetBytes(bigOperand(n/2, 2)))
+	benchBigIntNative(b, "ratNorm", 3, false, x.Bytes(), y.Bytes())
+}
+
+func BenchmarkNative_BigRat_Norm_8(b *testing.B)   { benchBigRatNorm(b, 8) }
+func BenchmarkNative_BigRat_Norm_32(b *testing.B)  { benchBigRatNorm(b, 32) }
+func BenchmarkNative_BigRat_Norm_128(b *testing.B) { benchBigRatNorm(b, 128) }
+func BenchmarkNative_BigRat_Norm_512(b *testing.B) { benchBigRatNorm(b, 512) }
+
+func BenchmarkNative_BigRat_Float64_8(b *testing.B) {
+	benchBigIntNative(b, "ratFloat64", 2, false, bigOperand(8, 1), bigOperand(8, 2))
+}
+func BenchmarkNative_BigRat_Float64_32(b *testing.B) {
+	benchBigIntNative(b, "ratFloat64", 2, false, bigOperand(32, 1), bigOperand(32, 2))
+}
+func BenchmarkNative_BigRat_Float64_128(b *testing.B) {
+	benchBigIntNative(b, "ratFloat64", 2, false, bigOperand(128, 1), bigOperand(128, 2))
+}
+func BenchmarkNative_BigRat_Float64_512(b *testing.B) {
+	benchBigIntNative(b, "ratFloat64", 2, false, bigOperand(512, 1), bigOperand(512, 2))
+}
+
+func BenchmarkNative_BigRat_SetFloat64(b *testing.B) {
+	benchBigIntNative(b, "ratSetFloat64", 4, 1.0/3)
+}
+
+// SetString is also charged on the length of the numerator it returns,
+// since an exponent can make it much longer than s; the name encodes that
+// exponent, which gives a numerator of about 0.415·n bytes.
+func benchBigRatSetString(b *testing.B, exp int) {
+	benchBigIntNative(b, "ratSetString", 4, "1.5e"+strconv.Itoa(exp))
+}
+
+func BenchmarkNative_BigRat_SetString_16(b *testing.B)   { benchBigRatSetString(b, 16) }
+func BenchmarkNative_BigRat_SetString_128(b *testing.B)  { benchBigRatSetString(b, 128) }
+func BenchmarkNative_BigRat_SetString_1024(b *testing.B) { benchBigRatSetString(b, 1024) }
+func BenchmarkNative_BigRat_SetString_8192(b *testing.B) { benchBigRatSetString(b, 8192) }
+func BenchmarkNative_BigRat_SetString_262144(b *testing.B) {
+	benchBigRatSetString(b, 262144)
+}
+
+// ----- product slopes -----
+//
+// Mul, the divisions, GCD, ModInverse and ratNorm are superlinear in their
+// operands, so on top of the linear fit above they may charge ProdSlope on
+// len(x)*len(y). These benches run them from 1 KiB up to MaxBitLen-sized
+// operands (for the divisions len(x) is the size in the name and len(y)
+// half of it). ProdSlope in native_gas.go is the largest time left over
+// after the linear charge, divided by len(x)*len(y) and rounded up, so no
+// size is undercharged; it is zero where the linear charge already covers
+// every size. Results are in bigint_prod_bench_output.txt.
+
+func benchBigIntGCD(b *testing.B, n int) {
+	benchBigIntNative(b, "intGCD", 5, false, bigOperand(n, 1), false, bigOperand(n, 2))
+}
+
+func BenchmarkNative_BigIntProd_Mul_1024(b *testing.B)          { benchBigIntBinary(b, "intMul", 1024) }
+func BenchmarkNative_BigIntProd_Mul_4096(b *testing.B)          { benchBigIntBinary(b, "intMul", 4096) }
+func BenchmarkNative_BigIntProd_Mul_16384(b *testing.B)         { benchBigIntBinary(b, "intMul", 16384) }
+func BenchmarkNative_BigIntProd_Mul_65536(b *testing.B)         { benchBigIntBinary(b, "intMul", 65536) }
+func BenchmarkNative_BigIntProd_Quo_2048(b *testing.B)          { benchBigIntDivision(b, "intQuo", 2048) }
+func BenchmarkNative_BigIntProd_Quo_8192(b *testing.B)          { benchBigIntDivision(b, "intQuo", 8192) }
+func BenchmarkNative_BigIntProd_Quo_32768(b *testing.B)         { benchBigIntDivision(b, "intQuo", 32768) }
+func BenchmarkNative_BigIntProd_Quo_131072(b *testing.B)        { benchBigIntDivision(b, "intQuo", 131072) }
+func BenchmarkNative_BigIntProd_Rem_2048(b *testing.B)          { benchBigIntDivision(b, "intRem", 2048) }
+func BenchmarkNative_BigIntProd_Rem_8192(b *testing.B)          { benchBigIntDivision(b, "intRem", 8192) }
+func BenchmarkNative_BigIntProd_Rem_32768(b *testing.B)         { benchBigIntDivision(b, "intRem", 32768) }
+func BenchmarkNative_BigIntProd_Rem_131072(b *testing.B)        { benchBigIntDivision(b, "intRem", 131072) }
+func BenchmarkNative_BigIntProd_Div_2048(b *testing.B)          { benchBigIntDivision(b, "intDiv", 2048) }
+func BenchmarkNative_BigIntProd_Div_8192(b *testing.B)          { benchBigIntDivision(b, "intDiv", 8192) }
+func BenchmarkNative_BigIntProd_Div_32768(b *testing.B)         { benchBigIntDivision(b, "intDiv", 32768) }
+func BenchmarkNative_BigIntProd_Div_131072(b *testing.B)        { benchBigIntDivision(b, "intDiv", 131072) }
+func BenchmarkNative_BigIntProd_Mod_2048(b *testing.B)          { benchBigIntDivision(b, "intMod", 2048) }
+func BenchmarkNative_BigIntProd_Mod_8192(b *testing.B)          { benchBigIntDivision(b, "intMod", 8192) }
+func BenchmarkNative_BigIntProd_Mod_32768(b *testing.B)         { benchBigIntDivision(b, "intMod", 32768) }
+func BenchmarkNative_BigIntProd_Mod_131072(b *testing.B)        { benchBigIntDivision(b, "intMod", 131072) }
+func BenchmarkNative_BigIntProd_GCD_1024(b *testing.B)          { benchBigIntGCD(b, 1024) }
+func BenchmarkNative_BigIntProd_GCD_4096(b *testing.B)          { benchBigIntGCD(b, 4096) }
+func BenchmarkNative_BigIntProd_GCD_16384(b *testing.B)         { benchBigIntGCD(b, 16384) }
+func BenchmarkNative_BigIntProd_GCD_65536(b *testing.B)         { benchBigIntGCD(b, 65536) }
+func BenchmarkNative_BigIntProd_ModInverse_1024(b *testing.B)   { benchBigIntModInverse(b, 1024) }
+func BenchmarkNative_BigIntProd_ModInverse_4096(b *testing.B)   { benchBigIntModInverse(b, 4096) }
+func BenchmarkNative_BigIntProd_ModInverse_16384(b *testing.B)  { benchBigIntModInverse(b, 16384) }
+func BenchmarkNative_BigIntProd_ModInverse_65536(b *testing.B)  { benchBigIntModInverse(b, 65536) }
+func BenchmarkNative_BigIntProd_ModInverse_131072(b *testing.B) { benchBigIntModInverse(b, 131072) }
+func BenchmarkNative_BigRatProd_Norm_1024(b *testing.B)         { benchBigRatNorm(b, 1024) }
+func BenchmarkNative_BigRatProd_Norm_4096(b *testing.B)         { benchBigRatNorm(b, 4096) }
+func BenchmarkNative_BigRatProd_Norm_16384(b *testing.B)        { benchBigRatNorm(b, 16384) }
+func BenchmarkNative_BigRatProd_Norm_65536(b *testing.B)        { benchBigRatNorm(b, 65536) }
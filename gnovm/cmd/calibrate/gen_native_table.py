@@ -189,6 +189,94 @@ NATIVE_SPECS = [
     ("crypto/merkle", "verifySimpleProof", 4, "LenBytes",
      # Bench name encodes `total`; aunt count = log2(total), aunts bytes = 32*log2(total).
      r"BenchmarkNative_Merkle_VerifySimpleProof_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+
+    # ---- math/big (draft) ----
+    # Two-operand natives are benched with equal-size operands (the divisions
+    # with len(y) = len(x)/2); the fitted slope is split between Slope and
+    # Slope2 by hand. intExp, intProbablyPrime and ratSetString are
+    # calibrated at their largest input by hand, like crypto/modexp. The
+    # ProdSlope of the quadratic natives is also set by hand, from the
+    # BenchmarkNative_Big*Prod benches (bigint_prod_bench_output.txt).
+    ("math/big", "intAdd", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Add_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intSub", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Sub_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intMul", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Mul_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intQuo", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Quo_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intRem", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Rem_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intDiv", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Div_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intMod", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Mod_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intAnd", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_And_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intAndNot", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_AndNot_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intOr", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Or_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intXor", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Xor_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intNot", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Not_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intRsh", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Rsh_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intBit", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Bit_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intCmpAbs", 0, "LenBytes",
+     r"BenchmarkNative_BigInt_CmpAbs_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intSqrt", 0, "LenBytes",
+     r"BenchmarkNative_BigInt_Sqrt_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intText", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_Text_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intSetString", 0, "LenString",
+     r"BenchmarkNative_BigInt_SetString_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intGCD", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_GCD_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intModInverse", 1, "LenBytes",
+     r"BenchmarkNative_BigInt_ModInverse_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intLsh", 1, "ReturnLen",
+     r"BenchmarkNative_BigInt_Lsh_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "intSetBit", 1, "ReturnLen",
+     r"BenchmarkNative_BigInt_SetBit_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "ratNorm", 1, "LenBytes",
+     r"BenchmarkNative_BigRat_Norm_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "ratFloat64", 1, "LenBytes",
+     r"BenchmarkNative_BigRat_Float64_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("math/big", "ratSetFloat64", None, "Flat",
+     r"BenchmarkNative_BigRat_SetFloat64-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+
+    # ---- crypto/secp256k1 (draft) ----
+    ("crypto/secp256k1", "verify", 1, "LenBytes",
+     r"BenchmarkNative_Secp256k1_Verify_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("crypto/secp256k1", "pubKeyAddress", None, "Flat",
+     r"BenchmarkNative_Secp256k1_PubKeyAddress-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("crypto/secp256k1", "recoverPubKey", 0, "LenBytes",
+     r"BenchmarkNative_Secp256k1_RecoverPubKey_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+
+    # ---- chain/scheduler (draft) ----
+    # Keeper work (store writes, deposit transfer) is metered by the store;
+    # these rows are the native call overhead only.
+    ("chain/scheduler", "schedule", 4, "LenSlice",
+     r"BenchmarkNative_Scheduler_Schedule_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("chain/scheduler", "cancel", None, "Flat",
+     r"BenchmarkNative_Scheduler_Cancel-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+
+    # ---- chain/runtime views (draft) ----
+    ("chain/runtime", "IsView", None, "Flat",
+     r"BenchmarkNative_Runtime_IsView-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("chain/runtime", "enterView", None, "Flat",
+     r"BenchmarkNative_Runtime_EnterView-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+
+    # ---- encoding/json (draft) ----
+    # marshal, unmarshal and indentJSON charge per value and per byte inside
+    # the VM; their rows are the marshal call overhead, set by hand.
+    ("encoding/json", "compact", 0, "LenBytes",
+     r"BenchmarkNative_JSON_Compact_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
+    ("encoding/json", "valid", 0, "LenBytes",
+     r"BenchmarkNative_JSON_Valid_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op"),
 ]
 
 
@@ -204,6 +292,8 @@ NATIVE_SPECS = [
 NATIVE_SPECS_2D = [
     ("chain", "emit", 1, "LenSlice",
      r"BenchmarkNative_Chain_Emit_(\d+)_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op", False),
+    ("chain", "emitTyped", 1, "LenSlice",
+     r"BenchmarkNative_Chain_EmitTyped_(\d+)_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op", False),
     ("chain/params", "SetStrings", 1, "LenSlice",
      r"BenchmarkNative_Params_SetStrings_(\d+)_(\d+)-\d+\s+\d+\s+([\d.]+)\s+ns/op", False),
     ("chain/params", "UpdateParamStrings", 1, "LenSlice",
@@ -0,0 +1,190 @@
+package calibrate
+
+// Native function calibration benchmarks for encoding/json. marshal,
+// unmarshal and indentJSON charge the machine per value and per byte
+// themselves (gno.OpCPUJSONValue, gno.OpCPUSlopeJSONByte), so only their
+// call overhead is benched, on a single bool; compact and valid are charged
+// entirely by their table row. The per value and per byte charges are fit on
+// the machine's encoder and decoder directly, below.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// jsonBenchInput returns an indented JSON array of objects, padded with
+// trailing spaces to exactly n bytes.
+func jsonBenchInput(n int) []byte {
+	items := []map[string]any{}
+	b, _ := json.MarshalIndent(items, "", "  ")
+	for {
+		next := append(items, map[string]any{"id": len(items), "name": "item", "ok": true})
+		nb, _ := json.MarshalIndent(next, "", "  ")
+		if len(nb) > n {
+			break
+		}
+		items, b = next, nb
+	}
+	return append(b, bytes.Repeat([]byte(" "), n-len(b))...)
+}
+
+// ----- encoding/json.marshal(v any, escapeHTML bool) -----
+
+func BenchmarkNative_JSON_Marshal_Bool(b *testing.B) {
+	m := newDispatchMachine(2)
+	setBlockValueFromGo(m, 0, true)
+	setBlockValueFromGo(m, 1, true)
+	h := &dispatchHarness{m: m, wrapper: resolveWrapper(b, "encoding/json", "marshal"), nReturns: 5}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.call()
+	}
+}
+
+// ----- encoding/json.compact(src []byte) / valid(data []byte) -----
+
+func benchJSONSrc(b *testing.B, fn gno.Name, n, nReturns int) {
+	b.Helper()
+	src := jsonBenchInput(n)
+	m := newDispatchMachine(1)
+	setBlockValueFromGo(m, 0, src)
+	h := &dispatchHarness{m: m, wrapper: resolveWrapper(b, "encoding/json", fn), nReturns: nReturns}
+	b.ResetTimer()
+	b.SetBytes(int64(len(src)))
+	for i := 0; i < b.N; i++ {
+		h.call()
+	}
+}
+
+func BenchmarkNative_JSON_Compact_64(b *testing.B)    { benchJSONSrc(b, "compact", 64, 3) }
+func BenchmarkNative_JSON_Compact_256(b *testing.B)   { benchJSONSrc(b, "compact", 256, 3) }
+func BenchmarkNative_JSON_Compact_1024(b *testing.B)  { benchJSONSrc(b, "compact", 1024, 3) }
+func BenchmarkNative_JSON_Compact_4096(b *testing.B)  { benchJSONSrc(b, "compact", 4096, 3) }
+func BenchmarkNative_JSON_Compact_16384(b *testing.B) { benchJSONSrc(b, "compact", 16384, 3) }
+func BenchmarkNative_JSON_Valid_64(b *testing.B)      { benchJSONSrc(b, "valid", 64, 1) }
+func BenchmarkNative_JSON_Valid_256(b *testing.B)     { benchJSONSrc(b, "valid", 256, 1) }
+func BenchmarkNative_JSON_Valid_1024(b *testing.B)    { benchJSONSrc(b, "valid", 1024, 1) }
+func BenchmarkNative_JSON_Valid_4096(b *testing.B)    { benchJSONSrc(b, "valid", 4096, 1) }
+func BenchmarkNative_JSON_Valid_16384(b *testing.B)   { benchJSONSrc(b, "valid", 16384, 1) }
+
+// ----- Machine.EncodeJSON / DecodeJSON: per value and per byte -----
+//
+// Ints_N is dominated by the per value cost (N+2 charges of 5 bytes each),
+// Map_N by the per value cost of sorted object members (N+2 charges, one per
+// member), and String_N by the per byte cost (3 charges, for 9N/4+4 bytes as
+// the HTML-escaped "<" takes 6). See json_bench_output.txt for the fit.
+
+func jsonBenchInts(n int) []int {
+	xs := make([]int, n)
+	for i := range xs {
+		xs[i] = 1000 + i
+	}
+	return xs
+}
+
+// jsonBenchMap returns a map[string]int of n entries, which Go2GnoValue
+// doesn't convert.
+func jsonBenchMap(m *gno.Machine, n int) gno.TypedValue {
+	mt := &gno.MapType{Key: gno.StringType, Value: gno.IntType}
+	mv := m.Alloc.NewMap(mt)
+	for i := range n {
+		key := gno.Go2GnoValue(m.Alloc, m.Store, reflect.ValueOf(fmt.Sprintf("k%05d", i)))
+		ptr := mv.GetPointerForKey(m, m.Alloc, m.Store, key)
+		*ptr.TV = gno.Go2GnoValue(m.Alloc, m.Store, reflect.ValueOf(i))
+	}
+	return gno.TypedValue{T: mt, V: mv}
+}
+
+func jsonBenchString(n int) []string {
+	return []string{strings.Repeat("abc<", n/4)}
+}
+
+func benchJSONEncode(b *testing.B, m *gno.Machine, tv gno.TypedValue) {
+	b.Helper()
+	data, err := m.EncodeJSON(tv, true)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		m.EncodeJSON(tv, true)
+	}
+}
+
+// benchJSONDecode decodes the encoding of tv into a value of its type.
+func benchJSONDecode(b *testing.B, m *gno.Machine, tv gno.TypedValue) {
+	b.Helper()
+	data, err := m.EncodeJSON(tv, true)
+	if err != nil {
+		b.Fatal(err)
+	}
+	hiv := m.Alloc.NewHeapItem(tv.T, gno.TypedValue{T: tv.T})
+	ptr := gno.TypedValue{
+		T: &gno.PointerType{Elt: tv.T},
+		V: gno.PointerValue{TV: &hiv.Value, Base: hiv},
+	}
+	if err := m.DecodeJSON(data, ptr); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		m.DecodeJSON(data, ptr)
+	}
+}
+
+func benchJSONGo(b *testing.B, decode bool, v any) {
+	b.Helper()
+	m := newDispatchMachine(0)
+	tv := gno.Go2GnoValue(m.Alloc, m.Store, reflect.ValueOf(v))
+	if decode {
+		benchJSONDecode(b, m, tv)
+	} else {
+		benchJSONEncode(b, m, tv)
+	}
+}
+
+func benchJSONMap(b *testing.B, decode bool, n int) {
+	b.Helper()
+	m := newDispatchMachine(0)
+	tv := jsonBenchMap(m, n)
+	if decode {
+		benchJSONDecode(b, m, tv)
+	} else {
+		benchJSONEncode(b, m, tv)
+	}
+}
+
+func BenchmarkNative_JSON_Encode_Ints_16(b *testing.B)   { benchJSONGo(b, false, jsonBenchInts(16)) }
+func BenchmarkNative_JSON_Encode_Ints_256(b *testing.B)  { benchJSONGo(b, false, jsonBenchInts(256)) }
+func BenchmarkNative_JSON_Encode_Ints_4096(b *testing.B) { benchJSONGo(b, false, jsonBenchInts(4096)) }
+func BenchmarkNative_JSON_Encode_Map_16(b *testing.B)    { benchJSONMap(b, false, 16) }
+func BenchmarkNative_JSON_Encode_Map_256(b *testing.B)   { benchJSONMap(b, false, 256) }
+func BenchmarkNative_JSON_Encode_Map_4096(b *testing.B)  { benchJSONMap(b, false, 4096) }
+func BenchmarkNative_JSON_Encode_String_64(b *testing.B) { benchJSONGo(b, false, jsonBenchString(64)) }
+func BenchmarkNative_JSON_Encode_String_1024(b *testing.B) {
+	benchJSONGo(b, false, jsonBenchString(1024))
+}
+func BenchmarkNative_JSON_Encode_String_16384(b *testing.B) {
+	benchJSONGo(b, false, jsonBenchString(16384))
+}
+func BenchmarkNative_JSON_Decode_Ints_16(b *testing.B)   { benchJSONGo(b, true, jsonBenchInts(16)) }
+func BenchmarkNative_JSON_Decode_Ints_256(b *testing.B)  { benchJSONGo(b, true, jsonBenchInts(256)) }
+func BenchmarkNative_JSON_Decode_Ints_4096(b *testing.B) { benchJSONGo(b, true, jsonBenchInts(4096)) }
+func BenchmarkNative_JSON_Decode_Map_16(b *testing.B)    { benchJSONMap(b, true, 16) }
+func BenchmarkNative_JSON_Decode_Map_256(b *testing.B)   { benchJSONMap(b, true, 256) }
+func BenchmarkNative_JSON_Decode_Map_4096(b *testing.B)  { benchJSONMap(b, true, 4096) }
+func BenchmarkNative_JSON_Decode_String_64(b *testing.B) { benchJSONGo(b, true, jsonBenchString(64)) }
+func BenchmarkNative_JSON_Decode_String_1024(b *testing.B) {
+	benchJSONGo(b, true, jsonBenchString(1024))
+}
+func BenchmarkNative_JSON_Decode_String_16384(b *testing.B) {
+	benchJSONGo(b, true, jsonBenchString(16384))
+}
@@ -107,6 +107,14 @@ func (p *mockParams) GetStrings(k string, ptr *[]string) bool {
 	return ok
 }
 
+type mockScheduler struct{ next uint64 }
+
+func (s *mockScheduler) Schedule(pkgPath string, height int64, fn string, args []string, gasLimit int64) (uint64, error) {
+	s.next++
+	return s.next, nil
+}
+func (s *mockScheduler) Cancel(pkgPath string, id uint64) bool { return false }
+
 // addContextAndFrames extends a dispatch Machine with an ExecContext and a
 // stack of pkgPath call frames (last entry is the topmost). Pass empty
 // string for a "chain"-edge frame (Frames[0] in real runtime is created
@@ -135,6 +143,7 @@ func addContextAndFrames(m *gno.Machine, pkgPaths ...string) (*mockBanker, *mock
 		OriginSendSpent: &spent,
 		Banker:          bk,
 		Params:          pm,
+		Scheduler:       &mockScheduler{},
 		EventLogger:     sdk.NewEventLogger(),
 	}
 	return bk, pm
@@ -301,6 +310,41 @@ func BenchmarkNative_Chain_Emit_2_50(b *testing.B)   { benchChainEmit(b, 2, 50)
 func BenchmarkNative_Chain_Emit_2_500(b *testing.B)  { benchChainEmit(b, 2, 500) }
 func BenchmarkNative_Chain_Emit_2_1024(b *testing.B) { benchChainEmit(b, 2, 1024) }
 
+// ---------------- chain.emitTyped ----------------
+// Same 2-D grid as emit. Every value is a list holding one string of
+// perElemBytes bytes: lists and objects are the only types whose
+// validation decodes JSON, so they set the byte slope.
+
+func benchChainEmitTyped(b *testing.B, nAttrs, perElemBytes int) {
+	b.Helper()
+	value := `[{"type":"string","value":"` + strings.Repeat("k", perElemBytes) + `"}]`
+	keys := make([]string, nAttrs)
+	types := make([]string, nAttrs)
+	values := make([]string, nAttrs)
+	for i := range keys {
+		keys[i], types[i], values[i] = "k", "list", value
+	}
+	m := newDispatchMachine(5)
+	addContextAndFrames(m, "gno.land/r/x", "gno.land/r/y")
+	setBlockValueFromGo(m, 0, "T")
+	setBlockValueFromGo(m, 1, keys)
+	setBlockValueFromGo(m, 2, types)
+	setBlockValueFromGo(m, 3, values)
+	setBlockValueFromGo(m, 4, make([]bool, nAttrs))
+	h := &dispatchHarness{m: m, wrapper: resolveWrapper(b, "chain", "emitTyped"), nReturns: 0}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.call()
+	}
+}
+
+func BenchmarkNative_Chain_EmitTyped_1_1(b *testing.B)    { benchChainEmitTyped(b, 1, 1) }
+func BenchmarkNative_Chain_EmitTyped_16_1(b *testing.B)   { benchChainEmitTyped(b, 16, 1) }
+func BenchmarkNative_Chain_EmitTyped_64_1(b *testing.B)   { benchChainEmitTyped(b, 64, 1) }
+func BenchmarkNative_Chain_EmitTyped_1_500(b *testing.B)  { benchChainEmitTyped(b, 1, 500) }
+func BenchmarkNative_Chain_EmitTyped_1_2000(b *testing.B) { benchChainEmitTyped(b, 1, 2000) }
+func BenchmarkNative_Chain_EmitTyped_1_4000(b *testing.B) { benchChainEmitTyped(b, 1, 4000) }
+
 // ---------------- chain/params ----------------
 // Per-native cost is X_ wrapper overhead only; per-byte storage cost is
 // metered separately by the KVStore via gctx (see params keeper fix).
@@ -754,6 +798,22 @@ func BenchmarkNative_Runtime_ChainHeight(b *testing.B) {
 	}
 }
 
+func BenchmarkNative_Runtime_IsView(b *testing.B) {
+	h := newRuntimeBench(b, "IsView", 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.call()
+	}
+}
+
+func BenchmarkNative_Runtime_EnterView(b *testing.B) {
+	h := newRuntimeBench(b, "enterView", 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.call()
+	}
+}
+
 func BenchmarkNative_Runtime_OriginCaller(b *testing.B) {
 	h := newRuntimeBench(b, "originCaller", 1)
 	b.ResetTimer()
@@ -818,3 +878,43 @@ func BenchmarkNative_Time_Now(b *testing.B) {
 		h.call()
 	}
 }
+
+// ---------------- chain/scheduler ----------------
+
+// X_schedule(m, pkgPath string, height int64, fn string, gasLimit int64, args []string) (uint64, string)
+func benchSchedulerSchedule(b *testing.B, n int) {
+	b.Helper()
+	args := make([]string, n)
+	for i := range args {
+		args[i] = "arg"
+	}
+	m := newDispatchMachine(5)
+	addContextAndFrames(m, "gno.land/r/x", "chain/scheduler")
+	setBlockValueFromGo(m, 0, "gno.land/r/x")
+	setBlockValueFromGo(m, 1, int64(2))
+	setBlockValueFromGo(m, 2, "Settle")
+	setBlockValueFromGo(m, 3, int64(1_000_000))
+	setBlockValueFromGo(m, 4, args)
+	h := &dispatchHarness{m: m, wrapper: resolveWrapper(b, "chain/scheduler", "schedule"), nReturns: 2}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.call()
+	}
+}
+
+func BenchmarkNative_Scheduler_Schedule_1(b *testing.B)    { benchSchedulerSchedule(b, 1) }
+func BenchmarkNative_Scheduler_Schedule_10(b *testing.B)   { benchSchedulerSchedule(b, 10) }
+func BenchmarkNative_Scheduler_Schedule_100(b *testing.B)  { benchSchedulerSchedule(b, 100) }
+func BenchmarkNative_Scheduler_Schedule_1000(b *testing.B) { benchSchedulerSchedule(b, 1000) }
+
+func BenchmarkNative_Scheduler_Cancel(b *testing.B) {
+	m := newDispatchMachine(2)
+	addContextAndFrames(m, "gno.land/r/x", "chain/scheduler")
+	setBlockValueFromGo(m, 0, "gno.land/r/x")
+	setBlockValueFromGo(m, 1, uint64(1))
+	h := &dispatchHarness{m: m, wrapper: resolveWrapper(b, "chain/scheduler", "cancel"), nReturns: 1}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.call()
+	}
+}
@@ -279,11 +279,12 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 			}
 			tcFset := token.NewFileSet()
 			tcPkg, errs := lintTypeCheck(io, dir, mpkg, gno.TypeCheckOptions{
-				Getter:     newProdGnoStore(),
-				TestGetter: newTestGnoStore(true),
-				Mode:       tcmode,
-				Cache:      cache,
-				Fset:       tcFset,
+				Getter:           newProdGnoStore(),
+				TestGetter:       newTestGnoStore(true),
+				Mode:             tcmode,
+				Cache:            cache,
+				Fset:             tcFset,
+				AllowConcurrency: true,
 			})
 			if errs != nil {
 				// io.ErrPrintln(errs) printed above.
@@ -16,15 +16,19 @@ import (
 	"github.com/gnolang/gno/gnovm/pkg/test"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	storetypes "github.com/gnolang/gno/tm2/pkg/store/types"
 )
 
 type runCmd struct {
-	verbose   bool
-	rootDir   string
-	expr      string
-	debug     bool
-	debugAddr string
-	pkgPath   string
+	verbose    bool
+	rootDir    string
+	expr       string
+	debug      bool
+	debugAddr  string
+	dapAddr    string
+	pkgPath    string
+	cpuProfile string
+	gasProfile string
 }
 
 func newRunCmd(cio commands.IO) *commands.Command {
@@ -79,12 +83,33 @@ func (c *runCmd) RegisterFlags(fs *flag.FlagSet) {
 		"enable interactive debugger using tcp address in the form [host]:port",
 	)
 
+	fs.StringVar(
+		&c.dapAddr,
+		"dap-addr",
+		"",
+		"serve the Debug Adapter Protocol for editors to debug the program, using tcp address in the form [host]:port",
+	)
+
 	fs.StringVar(
 		&c.pkgPath,
 		"pkgpath",
 		"",
 		"run with this package path, overriding the \"// PKGPATH:\" file directive and the gnomod.toml module path",
 	)
+
+	fs.StringVar(
+		&c.cpuProfile,
+		"cpuprofile",
+		"",
+		"write a profile of the CPU gas to the file, in the format of 'go tool pprof'",
+	)
+
+	fs.StringVar(
+		&c.gasProfile,
+		"gasprofile",
+		"",
+		"write a profile of the gas to the file, in the format of 'go tool pprof'",
+	)
 }
 
 func packageNameFromFiles(args []string) (string, error) {
@@ -182,7 +207,7 @@ func updatePackageInfo(
 	return firstPkgName, firstPkgFile, nil
 }
 
-func execRun(cfg *runCmd, args []string, cio commands.IO) error {
+func execRun(cfg *runCmd, args []string, cio commands.IO) (err error) {
 	if len(args) == 0 {
 		return flag.ErrHelp
 	}
@@ -222,11 +247,22 @@ func execRun(cfg *runCmd, args []string, cio commands.IO) error {
 		pkgPath = pkgName
 	}
 
+	// Profile the gas used by the program, including that of the store of
+	// realms.
+	var (
+		prof     *gno.Profiler
+		gasMeter storetypes.GasMeter
+	)
+	if cfg.cpuProfile != "" || cfg.gasProfile != "" {
+		prof = gno.NewProfiler()
+		gasMeter = prof.GasMeter(storetypes.NewInfiniteGasMeter())
+	}
+
 	// Realm packages persist state; run them in a transaction store.
 	realmMode := gno.IsRealmPath(pkgPath)
 	store := testStore
 	if realmMode {
-		store = testStore.BeginTransaction(nil, nil, nil, nil)
+		store = testStore.BeginTransaction(nil, nil, nil, gasMeter)
 	}
 
 	ctx := test.Context("", pkgPath, send)
@@ -237,10 +273,27 @@ func execRun(cfg *runCmd, args []string, cio commands.IO) error {
 		MaxAllocBytes: maxAllocRun,
 		Context:       ctx,
 		Debug:         cfg.debug || cfg.debugAddr != "",
+		GasMeter:      gasMeter,
+		Profiler:      prof,
 	})
 
 	defer m.Release()
 
+	if prof != nil {
+		// Also write the profiles if the program fails.
+		defer func() {
+			perr := writeProfiles(prof, cfg.cpuProfile, cfg.gasProfile, func(pkgPath, file string) string {
+				if p := test.SourcePath(cfg.rootDir, pkgPath, file); p != "" {
+					return p
+				}
+				return pkgPath + "/" + file
+			})
+			if err == nil {
+				err = perr
+			}
+		}()
+	}
+
 	// Construct the package to run; don't use MachineOptions.PkgPath, which
 	// would load an existing package at the same path from the store,
 	// conflicting with the files to run ("package fork" simulation).
@@ -288,6 +341,25 @@ func execRun(cfg *runCmd, args []string, cio commands.IO) error {
 		}
 	}
 
+	// Likewise, if the DAP address is set, wait for an editor to connect.
+	if cfg.dapAddr != "" {
+		var da *gno.DebugAdapter
+		da, err = gno.ListenDAP(cfg.dapAddr, func(pkgPath, name string) string {
+			return test.SourcePath(cfg.rootDir, pkgPath, name)
+		})
+		if err != nil {
+			return err
+		}
+		m.Debugger.EnableDAP(da, nil)
+		defer func() {
+			exitCode := 0
+			if err != nil {
+				exitCode = 1
+			}
+			da.Close(exitCode)
+		}()
+	}
+
 	if realmMode {
 		// Set the origin caller before running package init, so that
 		// package-level initializers see the proper caller (matches the
@@ -303,7 +375,8 @@ func execRun(cfg *runCmd, args []string, cio commands.IO) error {
 		// finalization by RunFiles.
 		m.SetActivePackage(m.Store.GetPackage(pkgPath, false))
 	}
-	return runExpr(m, cfg.expr)
+	err = runExpr(m, cfg.expr)
+	return err
 }
 
 // derivePkgPath derives the package path of the files to run from the first
@@ -13,6 +13,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"runtime/debug"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -36,8 +37,24 @@ type testCmd struct {
 	updateGoldenTests   bool
 	printRuntimeMetrics bool
 	printEvents         bool
+	printStorageObjects bool
 	debug               bool
+	dapAddr             string
 	parallel            int
+	cover               bool
+	coverMode           string
+	coverProfile        string
+	bench               string
+	benchTime           durationOrCountFlag
+	fuzz                string
+	fuzzTime            durationOrCountFlag
+	cpuProfile          string
+	gasProfile          string
+
+	mu       sync.Mutex        // guards the fields below, in parallel runs
+	coverage *gno.Coverage     // merged coverage of all tested packages
+	profiler *gno.Profiler     // merged profile of all tested packages
+	pkgDirs  map[string]string // pkgPath -> directory, for the profiles
 }
 
 func newTestCmd(io commands.IO) *commands.Command {
@@ -55,9 +72,9 @@ file pattern "*_test.gno" or "*_filetest.gno".
 
 The <package> can be directory or file path (relative or absolute).
 
-- "*_test.gno" files work like "*_test.go" files, but they contain only test
-functions. Benchmark and fuzz functions aren't supported yet. Similarly, only
-tests that belong to the same package are supported for now (no "xxx_test").
+- "*_test.gno" files work like "*_test.go" files, and contain test, benchmark,
+fuzz and example functions. Only tests that belong to the same package are
+supported for now (no "xxx_test").
 
 The package path used to execute the "*_test.gno" file is fetched from the
 module name found in 'gno.mod', or else it is set to
@@ -98,6 +115,43 @@ GnoVM execution, acting as "golden tests":
 To speed up execution, imports of pure packages are processed separately from
 the execution of the tests. This makes testing faster, but means that the
 initialization of imported pure packages cannot be checked in filetests.
+
+Benchmarks are run after the tests of a package, when selected by -bench.
+Alongside the wall time per operation (ns/op), they report the CPU cycles
+(cycles/op) and gas (gas/op) consumed by the GnoVM, which, unlike the wall time,
+are deterministic and suitable to track gas regressions between commits; with
+b.ReportAllocs(), the bytes allocated by the GnoVM (B/op) are reported too.
+Use -benchtime=Nx to run each benchmark exactly N times.
+
+Fuzz tests run their fuzz target with each entry of their seed corpus, added
+with f.Add, and of the files in the package's testdata/fuzz/FuzzXxx directory,
+like tests. With -fuzz, the fuzz test it selects, which must be the only match
+in a single package, is then fuzzed: inputs mutated from the corpus are run
+until one fails, or -fuzztime is elapsed. Inputs reaching statements of the
+package a new number of times are added to the corpus. The first failing input
+is minimized, and written to testdata/fuzz/FuzzXxx, so that it is run by later
+invocations of 'gno test'.
+
+With -cover, the statements of each tested package executed by its tests,
+filetests and initialization are recorded, along with the branches of its if
+and switch statements, and the percentages of statements and branches covered
+are printed. -coverprofile writes the statement coverage of all tested
+packages to a file in the format of 'go test -coverprofile', with absolute file
+paths, so that it can be inspected with 'go tool cover -html' or
+'go tool cover -func'.
+
+-cpuprofile and -gasprofile write a profile of the gas used by the tests,
+benchmarks, filetests and initialization of all tested packages, attributed to
+the Gno functions and lines that used it, in the format of 'go tool pprof'. The
+profiles have the same samples: the total gas, and the gas for CPU cycles,
+store reads and writes and allocations, as well as the bytes allocated, which
+can be selected with 'go tool pprof -sample_index'. The default sample is the
+gas for CPU cycles with -cpuprofile, and the total gas with -gasprofile.
+
+-print-storage-objects prints, after each test and filetest, the objects of the
+realms it changed, reachable from their package values: their size as stored,
+reference count, and the path of the package variable they are reached from,
+largest first, as in 'gnokey query vm/qstorage_objects'.
 `,
 		},
 		cmd,
@@ -171,6 +225,13 @@ func (c *testCmd) RegisterFlags(fs *flag.FlagSet) {
 		"print emitted events",
 	)
 
+	fs.BoolVar(
+		&c.printStorageObjects,
+		"print-storage-objects",
+		false,
+		"print the size of the objects of the realms changed by each test",
+	)
+
 	fs.BoolVar(
 		&c.debug,
 		"debug",
@@ -178,15 +239,85 @@ func (c *testCmd) RegisterFlags(fs *flag.FlagSet) {
 		"enable interactive debugger using stdin and stdout",
 	)
 
+	fs.StringVar(
+		&c.dapAddr,
+		"dap-addr",
+		"",
+		"serve the Debug Adapter Protocol for editors to debug the tests, using tcp address in the form [host]:port",
+	)
+
 	fs.IntVar(
 		&c.parallel,
 		"p",
 		0,
 		fmt.Sprintf("number of packages to test in parallel; n <= 0 means GOMAXPROCS (%d). "+
 			"When above 1, the output of each package is buffered and printed once the package's tests complete. "+
-			"-debug enforces -p 1.",
+			"-debug and -dap-addr enforce -p 1.",
 			runtime.GOMAXPROCS(0)),
 	)
+
+	fs.StringVar(
+		&c.bench,
+		"bench",
+		"",
+		"run only those benchmarks matching a regular expression",
+	)
+
+	c.benchTime = durationOrCountFlag{d: time.Second}
+	fs.Var(
+		&c.benchTime,
+		"benchtime",
+		"run enough iterations of each benchmark to take t, specified as a duration (e.g. 1h30s), or run it exactly N times with Nx",
+	)
+
+	fs.StringVar(
+		&c.fuzz,
+		"fuzz",
+		"",
+		"run the fuzz test matching the regular expression; the seed corpus is run first, then inputs generated from it",
+	)
+
+	c.fuzzTime = durationOrCountFlag{allowZero: true}
+	fs.Var(
+		&c.fuzzTime,
+		"fuzztime",
+		"time spent fuzzing, specified as a duration (e.g. 1h30s), or as a number of inputs with Nx; the default is to fuzz until an input fails",
+	)
+
+	fs.BoolVar(
+		&c.cover,
+		"cover",
+		false,
+		"enable coverage analysis",
+	)
+
+	fs.StringVar(
+		&c.coverMode,
+		"covermode",
+		"",
+		`set the mode for coverage analysis: "set" or "count"; implies -cover (default "set")`,
+	)
+
+	fs.StringVar(
+		&c.coverProfile,
+		"coverprofile",
+		"",
+		"write a coverage profile to the file after all tests have passed; implies -cover",
+	)
+
+	fs.StringVar(
+		&c.cpuProfile,
+		"cpuprofile",
+		"",
+		"write a profile of the CPU gas to the file after all tests have run",
+	)
+
+	fs.StringVar(
+		&c.gasProfile,
+		"gasprofile",
+		"",
+		"write a profile of the gas to the file after all tests have run",
+	)
 }
 
 func execTest(cmd *testCmd, args []string, io commands.IO) error {
@@ -217,6 +348,18 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		return nil
 	}
 
+	if cmd.fuzz != "" {
+		matched := 0
+		for _, pkg := range pkgs {
+			if len(pkg.Match) > 0 {
+				matched++
+			}
+		}
+		if matched > 1 {
+			return errors.New("cannot use -fuzz flag with multiple packages")
+		}
+	}
+
 	if cmd.timeout > 0 {
 		go func() {
 			time.Sleep(cmd.timeout)
@@ -232,8 +375,15 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		opts.Verbose = cmd.verbose
 		opts.Metrics = cmd.printRuntimeMetrics
 		opts.Events = cmd.printEvents
+		opts.StorageObjects = cmd.printStorageObjects
 		opts.Debug = cmd.debug
 		opts.FailfastFlag = cmd.failfast
+		opts.BenchFlag = cmd.bench
+		opts.BenchTime = cmd.benchTime.d
+		opts.BenchN = cmd.benchTime.n
+		opts.FuzzFlag = cmd.fuzz
+		opts.FuzzTime = cmd.fuzzTime.d
+		opts.FuzzN = cmd.fuzzTime.n
 		return opts
 	}
 
@@ -247,13 +397,31 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		return fmt.Errorf("FAIL: %d build errors, %d test errors", buildErrCount, testErrCount)
 	}
 
-	// enforce -p 1 for -debug
-	if cmd.debug {
+	if cmd.coverMode != "" || cmd.coverProfile != "" {
+		cmd.cover = true
+	}
+	if cmd.cover {
+		if cmd.coverMode == "" {
+			cmd.coverMode = gno.CoverModeSet
+		}
+		if cmd.coverMode != gno.CoverModeSet && cmd.coverMode != gno.CoverModeCount {
+			return fmt.Errorf("invalid -covermode %q: must be %q or %q",
+				cmd.coverMode, gno.CoverModeSet, gno.CoverModeCount)
+		}
+		cmd.coverage = gno.NewCoverage(cmd.coverMode)
+	}
+	if cmd.cpuProfile != "" || cmd.gasProfile != "" {
+		cmd.profiler = gno.NewProfiler()
+	}
+	cmd.pkgDirs = make(map[string]string)
+
+	// enforce -p 1 for -debug and -dap-addr
+	if cmd.debug || cmd.dapAddr != "" {
 		if cmd.parallel <= 1 {
 			// 0 or 1 jobs
 			cmd.parallel = 1
 		} else {
-			return errors.New("the interactive debugger can only be used with -p 1")
+			return errors.New("the debugger can only be used with -p 1")
 		}
 	}
 
@@ -267,6 +435,17 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		opts := newOpts(stdout, io.Err())
 		cache := make(gno.TypeCheckCache, 64)
 
+		if cmd.dapAddr != "" {
+			da, err := gno.ListenDAP(cmd.dapAddr, func(pkgPath, name string) string {
+				return test.SourcePath(cmd.rootDir, pkgPath, name)
+			})
+			if err != nil {
+				return err
+			}
+			opts.DebugAdapter = da
+			defer func() { da.Close(min(buildErrCount+testErrCount, 1)) }()
+		}
+
 		for _, pkg := range pkgs {
 			buildErrs, testErrs := cmd.testPkg(pkg, opts, cache, io)
 			buildErrCount += buildErrs
@@ -346,10 +525,83 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 			testErrCount += res.testErrs
 		}
 	}
+	// Profiles are written even if tests fail, to find out why they ran
+	// out of gas.
+	if cmd.profiler != nil {
+		if err := writeProfiles(cmd.profiler, cmd.cpuProfile, cmd.gasProfile, cmd.profileFilePath); err != nil {
+			return err
+		}
+	}
+
 	if testErrCount > 0 || buildErrCount > 0 {
 		return fail()
 	}
 
+	if cmd.coverProfile != "" {
+		if err := cmd.writeCoverProfile(); err != nil {
+			return fmt.Errorf("writing coverage profile: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// writeCoverProfile writes the coverage of all tested packages to
+// c.coverProfile. Files are named with their absolute path, as `go tool
+// cover` cannot resolve Gno package paths.
+func (c *testCmd) writeCoverProfile() error {
+	f, err := os.Create(c.coverProfile)
+	if err != nil {
+		return err
+	}
+	err = c.coverage.WriteProfile(f, func(pkgPath, file string) string {
+		if dir, ok := c.pkgDirs[pkgPath]; ok {
+			return filepath.Join(dir, file)
+		}
+		return pkgPath + "/" + file
+	})
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// profileFilePath returns the path of the file named file of the package
+// pkgPath, for the profiles. Files are named with their absolute path when
+// found, including those of the imported packages, so that 'go tool pprof
+// -list' can show their source.
+func (c *testCmd) profileFilePath(pkgPath, file string) string {
+	if dir, ok := c.pkgDirs[pkgPath]; ok {
+		return filepath.Join(dir, file)
+	}
+	if p := test.SourcePath(c.rootDir, pkgPath, file); p != "" {
+		return p
+	}
+	return pkgPath + "/" + file
+}
+
+// writeProfiles writes the profile of p to cpuProfile and gasProfile, if
+// set, with the CPU gas and the total gas as default samples.
+func writeProfiles(p *gno.Profiler, cpuProfile, gasProfile string, filePath func(pkgPath, file string) string) error {
+	for _, prof := range []struct{ file, sampleType string }{
+		{cpuProfile, gno.ProfileCPU},
+		{gasProfile, gno.ProfileGas},
+	} {
+		if prof.file == "" {
+			continue
+		}
+		f, err := os.Create(prof.file)
+		if err != nil {
+			return err
+		}
+		err = p.WriteProfile(f, prof.sampleType, filePath)
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			return fmt.Errorf("writing %s profile: %w", prof.sampleType, err)
+		}
+	}
 	return nil
 }
 
@@ -437,15 +689,28 @@ func (c *testCmd) testPkg(
 
 	// Read MemPackage with all files.
 	mpkg := gno.MustReadMemPackage(pkg.Dir, pkgPath, gno.MPAnyAll)
+	var cov *gno.Coverage
+	if c.cover {
+		cov = gno.NewCoverage(c.coverMode)
+		opts.Coverage = cov
+		defer func() { opts.Coverage = nil }()
+	}
+	var prof *gno.Profiler
+	if c.profiler != nil {
+		prof = gno.NewProfiler()
+		opts.Profiler = prof
+		defer func() { opts.Profiler = nil }()
+	}
 	var didPanic, didError bool
 	startedAt := time.Now()
 	didPanic = catchPanic(pkg.Dir, pkgPath, io.Err(), func() {
 		if mod == nil || !mod.Ignore {
 			_, errs := lintTypeCheck(io, pkg.Dir, mpkg, gno.TypeCheckOptions{
-				Getter:     opts.TestStore,
-				TestGetter: opts.TestStore,
-				Mode:       gno.TCLatestRelaxed,
-				Cache:      cache,
+				Getter:           opts.TestStore,
+				TestGetter:       opts.TestStore,
+				Mode:             gno.TCLatestRelaxed,
+				Cache:            cache,
+				AllowConcurrency: true,
 			})
 			if errs != nil {
 				didError = true
@@ -467,9 +732,15 @@ func (c *testCmd) testPkg(
 		}
 	})
 
-	// Print status with duration.
+	// Print status with duration, and coverage if enabled.
 	duration := time.Since(startedAt)
 	dstr := fmtDuration(duration)
+	if cov != nil {
+		dstr += "\t" + c.recordCoverage(cov, pkgPath, pkg.Dir)
+	}
+	if prof != nil {
+		c.recordProfile(prof, pkgPath, pkg.Dir)
+	}
 	if didPanic || didError {
 		io.ErrPrintfln("FAIL    %s \t%s", prettyDir, dstr)
 		testErrCount++
@@ -479,6 +750,76 @@ func (c *testCmd) testPkg(
 	return
 }
 
+// recordCoverage merges the coverage of the package at pkgPath, tested in
+// dir, into the coverage of the whole run, and returns its summary.
+func (c *testCmd) recordCoverage(cov *gno.Coverage, pkgPath, dir string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.coverage.Merge(cov)
+	c.recordDir(pkgPath, dir)
+
+	percent, ok := cov.Percent(pkgPath)
+	if !ok {
+		return "coverage: [no statements]"
+	}
+	summary := fmt.Sprintf("coverage: %.1f%% of statements", percent)
+	if percent, ok := cov.BranchPercent(pkgPath); ok {
+		summary += fmt.Sprintf(", %.1f%% of branches", percent)
+	}
+	return summary
+}
+
+// recordProfile merges the profile of the package at pkgPath, tested in dir,
+// into the profile of the whole run.
+func (c *testCmd) recordProfile(prof *gno.Profiler, pkgPath, dir string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.profiler.Merge(prof)
+	c.recordDir(pkgPath, dir)
+}
+
+// recordDir records dir as the directory of the package at pkgPath, for the
+// file names of the profiles. c.mu must be held.
+func (c *testCmd) recordDir(pkgPath, dir string) {
+	if absDir, err := filepath.Abs(dir); err == nil {
+		dir = absDir
+	}
+	c.pkgDirs[pkgPath] = dir
+}
+
+// durationOrCountFlag is the value of -benchtime and -fuzztime: either a
+// duration, or a number of iterations followed by "x". Zero values are only
+// accepted if allowZero is set.
+type durationOrCountFlag struct {
+	d         time.Duration
+	n         int
+	allowZero bool
+}
+
+func (f *durationOrCountFlag) String() string {
+	if f.n > 0 {
+		return fmt.Sprintf("%dx", f.n)
+	}
+	return f.d.String()
+}
+
+func (f *durationOrCountFlag) Set(s string) error {
+	if strings.HasSuffix(s, "x") {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n < 0 || (n == 0 && !f.allowZero) {
+			return fmt.Errorf("invalid count %q", s)
+		}
+		*f = durationOrCountFlag{n: n, allowZero: f.allowZero}
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 || (d == 0 && !f.allowZero) {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*f = durationOrCountFlag{d: d, allowZero: f.allowZero}
+	return nil
+}
+
 func determinePkgPath(mod *gnomod.File, dir, rootDir string) (string, bool) {
 	if mod != nil {
 		return mod.Module, true
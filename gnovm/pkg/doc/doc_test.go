@@ -112,6 +112,12 @@ func TestResolveDocumentable(t *testing.T) {
 			false, nil,
 			&Documentable{bfsDir: getDir("rand")}, "",
 		},
+		{
+			"genericMethod",
+			[]string{"rand.Pool.Get"},
+			false, nil,
+			&Documentable{bfsDir: getDir("rand"), symbol: "Pool", accessible: "Get", pkgData: pdata("rand", false)}, "",
+		},
 		{
 			"wdSymbol",
 			[]string{"WdConst"},
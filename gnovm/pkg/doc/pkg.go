@@ -218,6 +218,11 @@ func typeExprString(expr ast.Expr) string {
 		return t.Name
 	case *ast.StarExpr:
 		return typeExprString(t.X)
+	case *ast.IndexExpr:
+		// generic type, e.g. List[T].
+		return typeExprString(t.X)
+	case *ast.IndexListExpr:
+		return typeExprString(t.X)
 	}
 	return ""
 }
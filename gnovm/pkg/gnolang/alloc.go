@@ -23,10 +23,12 @@ import (
 // construct values without a budget. Because nil holds no mutable state, it is
 // also safe to share across goroutines.
 type Allocator struct {
-	maxBytes int64
-	bytes    int64
-	collect  func() (left int64, ok bool) // gc callback
-	gasMeter store.GasMeter
+	maxBytes  int64
+	bytes     int64
+	allocated int64                        // total allocated, not reset by GC
+	collect   func() (left int64, ok bool) // gc callback
+	gasMeter  store.GasMeter
+	profiler  *Profiler // if set, records allocations; see profiler.go
 
 	// currentRealmID mirrors m.Realm.ID at all times. Synced via
 	// Machine.setRealm at every realm transition. Used by allocator
@@ -81,6 +83,7 @@ const (
 	_allocSliceValue       = 40  // unsafe.Sizeof(SliceValue{})
 	_allocFuncValue        = 352 // unsafe.Sizeof(FuncValue{})
 	_allocMapValue         = 168 // unsafe.Sizeof(MapValue{})
+	_allocChanValue        = 96  // unsafe.Sizeof(ChanValue{})
 	_allocBoundMethodValue = 232 // unsafe.Sizeof(BoundMethodValue{})
 	_allocBlock            = 528 // unsafe.Sizeof(Block{})
 	_allocPackageValue     = 296 // unsafe.Sizeof(PackageValue{}) — interrealm v2 +24 bytes for PkgID field (Hashlet + alignment)
@@ -113,6 +116,12 @@ const (
 	allocBigdec     = _allocHeap + _allocBigdec
 	allocBigdecByte = 1
 
+	// Complex128Value (16 bytes, by value, escapes to heap via interface).
+	allocComplex128 = _allocHeap + 16
+
+	// BigcomplexValue holds two BigdecValues.
+	allocBigcomplex = _allocHeap + 2*allocBigdec
+
 	// PointerValue (32 bytes, by value, escapes to heap via interface).
 	allocPointer = _allocHeap + _allocPointerValue
 
@@ -125,6 +134,8 @@ const (
 	allocFunc        = _allocHeap + _allocFuncValue
 	allocMap         = _allocHeap + _allocMapValue
 	allocMapItem     = _allocTypedValue * 2 // key + value TypedValues
+	allocChan        = _allocHeap + _allocChanValue
+	allocChanItem    = _allocTypedValue
 	allocBoundMethod = _allocHeap + _allocBoundMethodValue
 	allocBlock       = _allocHeap + _allocBlock
 	allocBlockItem   = _allocTypedValue
@@ -155,6 +166,7 @@ func init() {
 	check("_allocSliceValue", _allocSliceValue, unsafe.Sizeof(SliceValue{}))
 	check("_allocFuncValue", _allocFuncValue, unsafe.Sizeof(FuncValue{}))
 	check("_allocMapValue", _allocMapValue, unsafe.Sizeof(MapValue{}))
+	check("_allocChanValue", _allocChanValue, unsafe.Sizeof(ChanValue{}))
 	check("_allocBoundMethodValue", _allocBoundMethodValue, unsafe.Sizeof(BoundMethodValue{}))
 	check("_allocBlock", _allocBlock, unsafe.Sizeof(Block{}))
 	check("_allocPackageValue", _allocPackageValue, unsafe.Sizeof(PackageValue{}))
@@ -261,6 +273,15 @@ func (alloc *Allocator) SetGasMeter(gasMeter store.GasMeter) {
 	alloc.gasMeter = gasMeter
 }
 
+// SetProfiler sets the profiler recording the allocations, or unsets it if
+// p is nil.
+func (alloc *Allocator) SetProfiler(p *Profiler) {
+	if alloc == nil {
+		return
+	}
+	alloc.profiler = p
+}
+
 func (alloc *Allocator) MemStats() string {
 	if alloc == nil {
 		return "nil allocator"
@@ -276,6 +297,15 @@ func (alloc *Allocator) Status() (maxBytes int64, bytes int64) {
 	return alloc.maxBytes, alloc.bytes
 }
 
+// Allocated returns the total number of bytes allocated so far. Unlike the
+// bytes of Status, it is not decreased by garbage collection.
+func (alloc *Allocator) Allocated() int64 {
+	if alloc == nil {
+		return 0
+	}
+	return alloc.allocated
+}
+
 func (alloc *Allocator) Reset() *Allocator {
 	if alloc == nil {
 		return nil
@@ -348,11 +378,15 @@ func (alloc *Allocator) Allocate(size int64) {
 	} else {
 		alloc.bytes += size
 	}
+	alloc.allocated += size
 
 	// Charge allocation gas based on calibrated lookup table.
 	// Models actual CPU time of Go's malloc + zero-fill.
 	if alloc.gasMeter != nil {
-		alloc.gasMeter.ConsumeGas(allocGas(size), "memory allocation")
+		alloc.gasMeter.ConsumeGas(allocGas(size), GasAllocDesc)
+	}
+	if alloc.profiler != nil {
+		alloc.profiler.recordAlloc(size)
 	}
 }
 
@@ -412,6 +446,12 @@ func (alloc *Allocator) AllocateMapItem() {
 	alloc.Allocate(allocMapItem)
 }
 
+// AllocateChan charges the channel header and its whole buffer up front;
+// unlike maps, a channel's capacity is fixed at make() time.
+func (alloc *Allocator) AllocateChan(capacity int64) {
+	alloc.Allocate(allocChan + allocChanItem*capacity)
+}
+
 func (alloc *Allocator) AllocateBoundMethod() {
 	alloc.Allocate(allocBoundMethod)
 }
@@ -645,6 +685,15 @@ func (alloc *Allocator) NewMap(t Type) *MapValue {
 	return mv
 }
 
+func (alloc *Allocator) NewChan(capacity int) *ChanValue {
+	alloc.AllocateChan(int64(capacity))
+	// NOTE: the buffer grows as values are sent, but is charged
+	// for its full capacity here.
+	return &ChanValue{
+		Cap: capacity,
+	}
+}
+
 // Only used for constructing the main package. Both the PackageValue
 // and its top-level Block share the package's own PkgID — they live
 // in the package's authority.
@@ -743,6 +792,10 @@ func (mv *MapValue) GetShallowSize() int64 {
 	return allocMap + allocMapItem*int64(mv.GetLength())
 }
 
+func (cv *ChanValue) GetShallowSize() int64 {
+	return allocChan + allocChanItem*int64(cv.Cap)
+}
+
 func (bmv *BoundMethodValue) GetShallowSize() int64 {
 	// skip .uverse (Func == nil for an unresolved lazy interface bind)
 	if bmv.Func != nil && bmv.Func.PkgPath == ".uverse" {
@@ -795,6 +848,14 @@ func (bdv BigdecValue) GetShallowSize() int64 {
 	return allocBigdec
 }
 
+func (cv Complex128Value) GetShallowSize() int64 {
+	return allocComplex128
+}
+
+func (bcv BigcomplexValue) GetShallowSize() int64 {
+	return allocBigcomplex
+}
+
 func (dbv DataByteValue) GetShallowSize() int64 {
 	return allocDataByte
 }
@@ -890,6 +951,10 @@ func internalRefSize(val Value) int64 {
 		// do nothing
 	case BigdecValue:
 		// do nothing
+	case Complex128Value:
+		// do nothing
+	case BigcomplexValue:
+		// do nothing
 	case DataByteValue:
 		// do nothing
 	case TypeValue:
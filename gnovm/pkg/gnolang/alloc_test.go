@@ -71,3 +71,25 @@ func TestBlockGetShallowSize_WithRefNodeSource(t *testing.T) {
 			refNodeSize, expectedRefNodeSize, normalSize, allocRefNode)
 	}
 }
+
+// TestAllocatorAllocated checks that the total allocated bytes are not
+// reset by garbage collection, which resets and recounts the live bytes.
+func TestAllocatorAllocated(t *testing.T) {
+	t.Parallel()
+
+	alloc := NewAllocator(math.MaxInt64)
+	alloc.Allocate(100)
+	alloc.Allocate(50)
+
+	// As done by Machine.GarbageCollect.
+	alloc.Reset()
+	alloc.Recount(30)
+
+	if _, bytes := alloc.Status(); bytes != 30 {
+		t.Errorf("expected 30 live bytes, got %d", bytes)
+	}
+	alloc.Allocate(20)
+	if allocated := alloc.Allocated(); allocated != 170 {
+		t.Errorf("expected 170 allocated bytes, got %d", allocated)
+	}
+}
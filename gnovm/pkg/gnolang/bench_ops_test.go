@@ -5642,8 +5642,269 @@ func BenchmarkOpUnrefCopy_Int_1k(b *testing.B)   { benchOpUnrefCopyInt(b, 1024)
 func BenchmarkOpUnrefCopy_Int_10k(b *testing.B)  { benchOpUnrefCopyInt(b, 10*1024) }
 func BenchmarkOpUnrefCopy_Int_100k(b *testing.B) { benchOpUnrefCopyInt(b, 100*1024) }
 
-// BenchmarkOpChanType removed: channels are no longer supported in Gno
-// (upstream banned them; doOpChanType was deleted).
+// ---------------------------------------------------------------------------
+// Goroutines and channels (goroutine.go, op_chan.go)
+// ---------------------------------------------------------------------------
+
+var benchChanType = &ChanType{Dir: BOTH, Elt: IntType}
+
+// benchGoMachine returns a machine with a block for startGoroutine, and the
+// main goroutine already created, as by a first go statement.
+func benchGoMachine() *Machine {
+	m := benchMachine()
+	m.Blocks = append(m.Blocks, &Block{})
+	m.curGoroutine()
+	return m
+}
+
+// benchGoCall returns the call of a goroutine running a nullary function.
+func benchGoCall() (*CallExpr, []TypedValue) {
+	ft := &FuncType{Params: []FieldType{}, Results: []FieldType{}}
+	fv := &FuncValue{Type: ft, PkgPath: "bench"}
+	return &CallExpr{NumArgs: 0}, []TypedValue{{T: ft, V: fv}}
+}
+
+// --- doOpGo: copy the function value and arguments, create a goroutine ---
+
+func BenchmarkOpGo(b *testing.B) {
+	m := benchGoMachine()
+	defer m.Release()
+	cx, vals := benchGoCall()
+	gs := &GoStmt{Call: *cx}
+
+	bm.InitMeasure()
+	bm.BeginOpCode(bmSetup)
+	for range b.N {
+		m.PushValue(vals[0])
+		m.PushStmt(gs)
+		bm.SwitchOpCode(bmTarget)
+		m.doOpGo()
+		bm.SwitchOpCode(bmSetup)
+		if len(m.sched.runq) != 1 {
+			b.Fatalf("expected 1 runnable goroutine, got %d", len(m.sched.runq))
+		}
+		m.sched.runq = m.sched.runq[:0]
+		m.sched.all = m.sched.all[:1]
+	}
+	reportBenchops(b)
+}
+
+// --- schedule: save the running goroutine, load the next runnable one ---
+
+func BenchmarkOpSchedule(b *testing.B) {
+	m := benchGoMachine()
+	defer m.Release()
+	cx, vals := benchGoCall()
+	main := m.sched.main
+
+	bm.InitMeasure()
+	bm.BeginOpCode(bmSetup)
+	for range b.N {
+		m.startGoroutine(cx, append([]TypedValue(nil), vals...))
+		bm.SwitchOpCode(bmTarget)
+		m.schedule()
+		bm.SwitchOpCode(bmSetup)
+		if m.sched.cur == main {
+			b.Fatal("expected the new goroutine to be running")
+		}
+		// Switch back to the main goroutine.
+		m.sched.runq = append(m.sched.runq, main)
+		m.schedule()
+		m.sched.all = m.sched.all[:1]
+	}
+	reportBenchops(b)
+}
+
+// --- goexit: drop the running goroutine, then schedule ---
+// Includes the schedule() it ends with, charged as OpCPUSchedule.
+
+func BenchmarkOpGoexit(b *testing.B) {
+	m := benchGoMachine()
+	defer m.Release()
+	cx, vals := benchGoCall()
+	main := m.sched.main
+
+	bm.InitMeasure()
+	bm.BeginOpCode(bmSetup)
+	for range b.N {
+		m.startGoroutine(cx, append([]TypedValue(nil), vals...))
+		m.schedule()
+		m.sched.runq = append(m.sched.runq, main)
+		bm.SwitchOpCode(bmTarget)
+		m.doOpGoexit()
+		bm.SwitchOpCode(bmSetup)
+		if m.sched.cur != main || len(m.sched.all) != 1 {
+			b.Fatal("expected only the main goroutine left, running")
+		}
+	}
+	reportBenchops(b)
+}
+
+// benchWaiter returns a wait item of a goroutine blocked on cv.
+func benchWaiter(cv *ChanValue, v TypedValue) *waitItem {
+	ws := &waitState{g: &Goroutine{}}
+	wi := &waitItem{ws: ws, ch: cv, value: v}
+	ws.items = []*waitItem{wi}
+	return wi
+}
+
+// --- doOpSend: into the buffer, or handed to a blocked receiver ---
+
+func benchOpSend(b *testing.B, waiter bool) {
+	b.Helper()
+	m := benchMachine()
+	defer m.Release()
+	cv := m.Alloc.NewChan(1)
+
+	bm.InitMeasure()
+	bm.BeginOpCode(bmSetup)
+	for range b.N {
+		if waiter {
+			cv.recvq = append(cv.recvq[:0], benchWaiter(cv, TypedValue{}))
+		}
+		m.PushValue(TypedValue{T: benchChanType, V: cv})
+		m.PushValue(TypedValue{T: IntType, N: i2n(42)})
+		bm.SwitchOpCode(bmTarget)
+		m.doOpSend()
+		bm.SwitchOpCode(bmSetup)
+		if len(m.Values) != 0 {
+			b.Fatalf("expected the send to complete, got %d values", len(m.Values))
+		}
+		cv.Buffer = cv.Buffer[:0]
+		m.sched.runq = m.sched.runq[:0]
+	}
+	reportBenchops(b)
+}
+
+func BenchmarkOpSend_Buffer(b *testing.B) { benchOpSend(b, false) }
+func BenchmarkOpSend_Waiter(b *testing.B) { benchOpSend(b, true) }
+
+// --- doOpUrecv, doOpUrecv2: from the buffer, or from a blocked sender ---
+
+func benchOpUrecv(b *testing.B, waiter, two bool) {
+	b.Helper()
+	m := benchMachine()
+	defer m.Release()
+	cv := m.Alloc.NewChan(1)
+	ux := &UnaryExpr{Op: ARROW}
+	v := TypedValue{T: IntType, N: i2n(42)}
+
+	bm.InitMeasure()
+	bm.BeginOpCode(bmSetup)
+	for range b.N {
+		if waiter {
+			cv.sendq = append(cv.sendq[:0], benchWaiter(cv, v))
+		} else {
+			cv.Buffer = append(cv.Buffer[:0], v)
+		}
+		m.PushValue(TypedValue{T: benchChanType, V: cv})
+		m.PushExpr(ux)
+		bm.SwitchOpCode(bmTarget)
+		if two {
+			m.doOpUrecv2()
+		} else {
+			m.doOpUrecv()
+		}
+		bm.SwitchOpCode(bmSetup)
+		if res := m.Values[0]; res.GetInt() != 42 {
+			b.Fatalf("expected 42, got %d", res.GetInt())
+		}
+		m.Values = m.Values[:0]
+		m.sched.runq = m.sched.runq[:0]
+	}
+	reportBenchops(b)
+}
+
+func BenchmarkOpUrecv_Buffer(b *testing.B)  { benchOpUrecv(b, false, false) }
+func BenchmarkOpUrecv_Waiter(b *testing.B)  { benchOpUrecv(b, true, false) }
+func BenchmarkOpUrecv2_Buffer(b *testing.B) { benchOpUrecv(b, false, true) }
+func BenchmarkOpUrecv2_Waiter(b *testing.B) { benchOpUrecv(b, true, true) }
+
+// benchSelectStmt returns a select statement with n receive cases, followed
+// by a default case.
+func benchSelectStmt(n int) *SelectStmt {
+	cases := make([]SelectCaseStmt, n+1)
+	for i := range n {
+		cases[i] = SelectCaseStmt{Comm: &ExprStmt{X: &UnaryExpr{Op: ARROW}}}
+	}
+	return &SelectStmt{Cases: cases}
+}
+
+// --- doOpSelect: evaluation of the operands of one case ---
+// Charged OpCPUSelect, once per communication case.
+
+func BenchmarkOpSelect_Case(b *testing.B) {
+	m := benchMachine()
+	defer m.Release()
+	ss := benchSelectStmt(1)
+	m.Blocks = append(m.Blocks, &Block{})
+
+	bm.InitMeasure()
+	bm.BeginOpCode(bmSetup)
+	for range b.N {
+		m.PushStmt(ss)
+		m.PushFrameBasic(ss)
+		m.PushValue(typedInt(0))
+		bm.SwitchOpCode(bmTarget)
+		m.doOpSelect()
+		bm.SwitchOpCode(bmSetup)
+		if len(m.Exprs) != 1 {
+			b.Fatalf("expected the channel to evaluate, got %d exprs", len(m.Exprs))
+		}
+		m.Ops = m.Ops[:0]
+		m.Values = m.Values[:0]
+		m.Exprs = m.Exprs[:0]
+		m.Stmts = m.Stmts[:0]
+		m.Blocks = m.Blocks[:1]
+		m.Frames = m.Frames[:0]
+	}
+	reportBenchops(b)
+}
+
+// --- doOpSelect: choice among the evaluated cases ---
+// n receive cases on empty channels, none ready, so the default case is
+// chosen after trying each. Charged OpCPUSelect + OpCPUSlopeSelectCase per
+// case.
+
+func benchOpSelectChoose(b *testing.B, n int) {
+	b.Helper()
+	m := benchMachine()
+	defer m.Release()
+	ss := benchSelectStmt(n)
+	m.Blocks = append(m.Blocks, &Block{})
+	vals := make([]TypedValue, 0, 2*n)
+	for i := range n {
+		vals = append(vals,
+			TypedValue{V: m.Alloc.NewBlock(&ss.Cases[i], m.LastBlock())},
+			TypedValue{T: benchChanType, V: m.Alloc.NewChan(0)})
+	}
+
+	bm.InitMeasure()
+	bm.BeginOpCode(bmSetup)
+	for range b.N {
+		m.PushStmt(ss)
+		m.PushFrameBasic(ss)
+		m.PushValue(typedInt(n + 1))
+		for _, v := range vals {
+			m.PushValue(v)
+		}
+		bm.SwitchOpCode(bmTarget)
+		m.doOpSelect()
+		bm.SwitchOpCode(bmSetup)
+		if len(m.Stmts) != 0 {
+			b.Fatal("expected the select to complete")
+		}
+		m.Ops = m.Ops[:0]
+		m.Values = m.Values[:0]
+		m.Blocks = m.Blocks[:1]
+		m.Frames = m.Frames[:0]
+	}
+	reportBenchops(b)
+}
+
+func BenchmarkOpSelect_Choose_1(b *testing.B)   { benchOpSelectChoose(b, 1) }
+func BenchmarkOpSelect_Choose_10(b *testing.B)  { benchOpSelectChoose(b, 10) }
+func BenchmarkOpSelect_Choose_100(b *testing.B) { benchOpSelectChoose(b, 100) }
 
 // ---------------------------------------------------------------------------
 // Helper: encode int64/uint64 into [8]byte (little-endian, matching unsafe cast)
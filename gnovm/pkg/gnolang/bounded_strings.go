@@ -382,6 +382,10 @@ func boundedSprintPrimitiveTV(w *boundedBuf, tv TypedValue) {
 		fmt.Fprintf(w, "%g", math.Float32frombits(tv.GetFloat32()))
 	case Float64Type:
 		fmt.Fprintf(w, "%g", math.Float64frombits(tv.GetFloat64()))
+	case Complex64Type:
+		fmt.Fprintf(w, "%g", complex64Of(tv.GetComplex64()))
+	case Complex128Type:
+		fmt.Fprintf(w, "%g", complex128Of(tv.GetComplex128()))
 	case UntypedBigintType:
 		// Guard against tv.V == nil: GetBigInt does a typed
 		// assertion on tv.V and would panic on a zero-value slot.
@@ -398,6 +402,19 @@ func boundedSprintPrimitiveTV(w *boundedBuf, tv TypedValue) {
 		} else {
 			boundedSprintBigDecValue(w, tv.GetBigDec())
 		}
+	case UntypedBigcomplexType:
+		if tv.V == nil {
+			w.WriteString("<nil>")
+		} else {
+			bcv := tv.GetBigComplex()
+			w.WriteString("(")
+			boundedSprintBigDecValue(w, bcv.Real)
+			if bcv.Imag.Sign() >= 0 {
+				w.WriteString("+")
+			}
+			boundedSprintBigDecValue(w, bcv.Imag)
+			w.WriteString("i)")
+		}
 	default:
 		fmt.Fprintf(w, "<%v>", pt)
 	}
@@ -421,6 +438,8 @@ func boundedSprintValue(w *boundedBuf, v Value, depth int) {
 		boundedSprintBigInt(w, x.V)
 	case BigdecValue:
 		boundedSprintBigDecValue(w, x)
+	case Complex128Value:
+		fmt.Fprintf(w, "%g", complex128Of(x.Real, x.Imag))
 	case *ArrayValue:
 		boundedSprintArrayValue(w, x, depth)
 	case *SliceValue:
@@ -435,6 +454,8 @@ func boundedSprintValue(w *boundedBuf, v Value, depth int) {
 		boundedSprintBoundMethodValue(w, x)
 	case PointerValue:
 		boundedSprintPointerValue(w, x, depth)
+	case *ChanValue:
+		fmt.Fprintf(w, "chan{len:%d,cap:%d}", len(x.Buffer), x.Cap)
 	case *PackageValue:
 		fmt.Fprintf(w, "<package %s>", x.PkgPath)
 	case TypeValue:
@@ -0,0 +1,396 @@
+package gnolang
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// ----------------------------------------
+// Coverage
+//
+// Statement coverage is recorded by the machine as it executes statements:
+// when Machine.Coverage is set, every statement reaching OpExec is looked up
+// by the location of its enclosing block and by its own span, and its
+// counter is incremented. Only statements of the files registered through
+// AddMemPackage are counted.
+//
+// Statements are identified by source position rather than by node, so that
+// coverage accumulates across the different stores and machines running a
+// package (unit tests, filetests, package initialization), each of which
+// parses and preprocesses its own copy of the package.
+//
+// Branch coverage is recorded along: the outcomes of each if statement (then
+// and else, even if there is no else block) and of each switch statement
+// (each clause, and no clause if there is no default) are counted when the
+// machine takes them.
+//
+// Profiles are written in the format of `go test -coverprofile`, with one
+// block per statement, so they can be consumed by `go tool cover`. They have
+// no notion of branches, which are only reported by BranchPercent.
+
+// Coverage modes, matching those of `go test -covermode`.
+const (
+	CoverModeSet   = "set"   // whether each statement ran.
+	CoverModeCount = "count" // how many times each statement ran.
+)
+
+// Coverage records the statements executed, and the branches taken, by the
+// machines it is set on.
+// A Coverage is not safe for concurrent use; use one per machine or per
+// sequence of machines, and Merge them afterwards.
+type Coverage struct {
+	Mode string
+
+	pkgs map[string]map[string]*coverFile // pkgPath -> file name -> file
+}
+
+// CoverBlock is a block of a coverage profile.
+type CoverBlock struct {
+	Span          // as written in the profile.
+	NumStmt int   // always 1, as blocks are statements.
+	Count   int64 // number of executions; 0 or 1 in CoverModeSet.
+}
+
+type coverFile struct {
+	blocks   []*CoverBlock
+	stmts    map[Span]*CoverBlock // by span of the statement.
+	branches map[Span][]int64     // counts of the outcomes, by span of the statement.
+}
+
+// NewCoverage returns an empty Coverage for the given mode, which must be one
+// of CoverModeSet or CoverModeCount.
+func NewCoverage(mode string) *Coverage {
+	switch mode {
+	case CoverModeSet, CoverModeCount:
+	default:
+		panic(fmt.Sprintf("invalid coverage mode %q", mode))
+	}
+	return &Coverage{
+		Mode: mode,
+		pkgs: make(map[string]map[string]*coverFile),
+	}
+}
+
+// AddMemPackage registers the statements of the production files of mpkg,
+// so that their execution is recorded. Test files and filetests are not
+// registered. Registering a package a second time is a no-op.
+func (c *Coverage) AddMemPackage(mpkg *std.MemPackage) {
+	if _, ok := c.pkgs[mpkg.Path]; ok {
+		return
+	}
+	files := make(map[string]*coverFile)
+	c.pkgs[mpkg.Path] = files
+	var m *Machine // only used for parsing.
+	for _, mfile := range mpkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") ||
+			endsWithAny(mfile.Name, []string{"_test.gno", "_filetest.gno"}) {
+			continue
+		}
+		fn, err := m.ParseFile(mfile.Name, mfile.Body)
+		if err != nil || string(fn.PkgName) != mpkg.Name {
+			// Errors are reported when running the package.
+			continue
+		}
+		files[mfile.Name] = newCoverFile(fn)
+	}
+}
+
+func newCoverFile(fn *FileNode) *coverFile {
+	cf := &coverFile{
+		stmts:    make(map[Span]*CoverBlock),
+		branches: make(map[Span][]int64),
+	}
+	Transcribe(fn, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER {
+			return n, TRANS_CONTINUE
+		}
+		// Skip package-level declarations; only statements in
+		// function bodies are executed as statements.
+		if len(ns) <= 1 {
+			return n, TRANS_CONTINUE
+		}
+		s, ok := n.(Stmt)
+		if !ok || !isCoverStmt(s) {
+			return n, TRANS_CONTINUE
+		}
+		span := s.GetSpan()
+		if _, exists := cf.stmts[span]; exists || span.IsZero() {
+			return n, TRANS_CONTINUE
+		}
+		cb := &CoverBlock{Span: span, NumStmt: 1}
+		cf.blocks = append(cf.blocks, cb)
+		cf.stmts[span] = cb
+		if nb := numBranches(s); nb > 0 {
+			cf.branches[span] = make([]int64, nb)
+		}
+		return n, TRANS_CONTINUE
+	})
+	sort.Slice(cf.blocks, func(i, j int) bool {
+		return cf.blocks[i].Span.Compare(cf.blocks[j].Span) < 0
+	})
+	// Blocks in a profile must not overlap: a statement containing
+	// other statements (if, for, switch, function literals...) is
+	// truncated where the first statement it contains starts, the
+	// same way as a Go basic block ends where a nested one begins.
+	for i := 0; i+1 < len(cf.blocks); i++ {
+		cb, next := cf.blocks[i], cf.blocks[i+1]
+		if next.Pos.Compare(cb.End) < 0 {
+			cb.End = next.Pos
+		}
+	}
+	return cf
+}
+
+// isCoverStmt returns whether s is counted as a statement. Blocks and
+// clauses are not, as their statements are counted on their own.
+func isCoverStmt(s Stmt) bool {
+	switch s.(type) {
+	case *BlockStmt, *EmptyStmt, *DeclStmt,
+		*IfCaseStmt, *SwitchClauseStmt, *SelectCaseStmt, *bodyStmt:
+		return false
+	default:
+		return true
+	}
+}
+
+// numBranches returns the number of outcomes of s: 2 for an if statement,
+// and for a switch statement one per clause, plus one if there is no default
+// clause, for when no clause matches.
+func numBranches(s Stmt) int {
+	switch s := s.(type) {
+	case *IfStmt:
+		return 2
+	case *SwitchStmt:
+		n := len(s.Clauses) + 1
+		for _, cl := range s.Clauses {
+			if len(cl.Cases) == 0 {
+				n--
+				break
+			}
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+// file returns the registered file of the last block of m, or nil.
+func (c *Coverage) file(m *Machine) *coverFile {
+	loc := m.LastBlock().GetSource(m.Store).GetLocation()
+	return c.pkgs[loc.PkgPath][loc.File]
+}
+
+// hit records the execution of s, about to be executed by m.
+func (c *Coverage) hit(m *Machine, s Stmt) {
+	cf := c.file(m)
+	if cf == nil {
+		return
+	}
+	cb := cf.stmts[s.GetSpan()]
+	if cb == nil {
+		return
+	}
+	if c.Mode == CoverModeSet {
+		cb.Count = 1
+	} else {
+		cb.Count++
+	}
+}
+
+// branch records that the outcome i of s (see numBranches) was taken by m,
+// i being len(Clauses) for a switch statement without default in which no
+// clause matched.
+func (c *Coverage) branch(m *Machine, s Stmt, i int) {
+	cf := c.file(m)
+	if cf == nil {
+		return
+	}
+	counts := cf.branches[s.GetSpan()]
+	if i >= len(counts) {
+		return
+	}
+	if c.Mode == CoverModeSet {
+		counts[i] = 1
+	} else {
+		counts[i]++
+	}
+}
+
+// Merge adds the counts of other to c. Packages registered in other but
+// not in c are added to c.
+func (c *Coverage) Merge(other *Coverage) {
+	for pkgPath, files := range other.pkgs {
+		cfiles, ok := c.pkgs[pkgPath]
+		if !ok {
+			cfiles = make(map[string]*coverFile, len(files))
+			c.pkgs[pkgPath] = cfiles
+		}
+		for name, of := range files {
+			cf, ok := cfiles[name]
+			if !ok {
+				cf = &coverFile{
+					stmts:    make(map[Span]*CoverBlock, len(of.stmts)),
+					branches: make(map[Span][]int64, len(of.branches)),
+				}
+				for span, ob := range of.stmts {
+					cb := &CoverBlock{Span: ob.Span, NumStmt: ob.NumStmt}
+					cf.stmts[span] = cb
+					cf.blocks = append(cf.blocks, cb)
+				}
+				for span, ocounts := range of.branches {
+					cf.branches[span] = make([]int64, len(ocounts))
+				}
+				sort.Slice(cf.blocks, func(i, j int) bool {
+					return cf.blocks[i].Span.Compare(cf.blocks[j].Span) < 0
+				})
+				cfiles[name] = cf
+			}
+			for span, ob := range of.stmts {
+				cb := cf.stmts[span]
+				if cb == nil {
+					continue
+				}
+				if c.Mode == CoverModeSet {
+					cb.Count = max(cb.Count, min(ob.Count, 1))
+				} else {
+					cb.Count += ob.Count
+				}
+			}
+			for span, ocounts := range of.branches {
+				counts := cf.branches[span]
+				if len(counts) != len(ocounts) {
+					continue
+				}
+				for i, ocount := range ocounts {
+					if c.Mode == CoverModeSet {
+						counts[i] = max(counts[i], min(ocount, 1))
+					} else {
+						counts[i] += ocount
+					}
+				}
+			}
+		}
+	}
+}
+
+// Blocks returns the blocks of all registered files, sorted by package path,
+// file name and position. The order is stable as long as no package is
+// registered, so that blocks can be tracked by index, as fuzzers do.
+func (c *Coverage) Blocks() []*CoverBlock {
+	var blocks []*CoverBlock
+	pkgPaths := make([]string, 0, len(c.pkgs))
+	for pkgPath := range c.pkgs {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+	for _, pkgPath := range pkgPaths {
+		files := c.pkgs[pkgPath]
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			blocks = append(blocks, files[name].blocks...)
+		}
+	}
+	return blocks
+}
+
+// Reset sets the counts of all blocks to zero.
+func (c *Coverage) Reset() {
+	for _, files := range c.pkgs {
+		for _, cf := range files {
+			for _, cb := range cf.blocks {
+				cb.Count = 0
+			}
+			for _, counts := range cf.branches {
+				clear(counts)
+			}
+		}
+	}
+}
+
+// Percent returns the percentage of statements of pkgPath which were
+// executed, and false if the package has no registered statements.
+func (c *Coverage) Percent(pkgPath string) (float64, bool) {
+	var total, covered int
+	for _, cf := range c.pkgs[pkgPath] {
+		for _, cb := range cf.blocks {
+			total += cb.NumStmt
+			if cb.Count > 0 {
+				covered += cb.NumStmt
+			}
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return 100 * float64(covered) / float64(total), true
+}
+
+// BranchPercent returns the percentage of the branches of pkgPath which were
+// taken, and false if the package has no registered branches.
+func (c *Coverage) BranchPercent(pkgPath string) (float64, bool) {
+	var total, covered int
+	for _, cf := range c.pkgs[pkgPath] {
+		for _, counts := range cf.branches {
+			total += len(counts)
+			for _, count := range counts {
+				if count > 0 {
+					covered++
+				}
+			}
+		}
+	}
+	if total == 0 {
+		return 0, false
+	}
+	return 100 * float64(covered) / float64(total), true
+}
+
+// WriteProfile writes c to w as a Go coverage profile. fileName returns the
+// name to use for a file of a package in the profile; if it is nil, the
+// import-path style "pkgPath/file" is used, as `go test` does. Note that
+// `go tool cover` can only locate files named with an absolute path, as it
+// resolves import paths using the go command.
+func (c *Coverage) WriteProfile(w io.Writer, fileName func(pkgPath, file string) string) error {
+	if fileName == nil {
+		fileName = func(pkgPath, file string) string {
+			return path.Join(pkgPath, file)
+		}
+	}
+	if _, err := fmt.Fprintf(w, "mode: %s\n", c.Mode); err != nil {
+		return err
+	}
+	pkgPaths := make([]string, 0, len(c.pkgs))
+	for pkgPath := range c.pkgs {
+		pkgPaths = append(pkgPaths, pkgPath)
+	}
+	sort.Strings(pkgPaths)
+	for _, pkgPath := range pkgPaths {
+		files := c.pkgs[pkgPath]
+		names := make([]string, 0, len(files))
+		for name := range files {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fname := fileName(pkgPath, name)
+			for _, cb := range files[name].blocks {
+				_, err := fmt.Fprintf(w, "%s:%d.%d,%d.%d %d %d\n",
+					fname, cb.Pos.Line, cb.Pos.Column,
+					cb.End.Line, cb.End.Column, cb.NumStmt, cb.Count)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package gnolang
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	stypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const coverageTestBody = `package cov
+
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func Loop(n int) (s int) {
+	for i := 0; i < n; i++ {
+		s += i
+	}
+	return
+}
+`
+
+const coverageBranchTestBody = `package cov
+
+func Abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func Sign(x int) int {
+	switch {
+	case x < 0:
+		return -1
+	case x > 0:
+		return 1
+	}
+	return 0
+}
+`
+
+func runCoverageTest(t *testing.T, mode string, exprs ...Expr) *Coverage {
+	t.Helper()
+	return runCoverageTestWithBody(t, coverageTestBody, mode, exprs...)
+}
+
+func runCoverageTestWithBody(t *testing.T, body, mode string, exprs ...Expr) *Coverage {
+	t.Helper()
+
+	db := memdb.NewMemDB()
+	baseStore := dbadapter.StoreConstructor(db, stypes.StoreOptions{})
+	iavlStore := iavl.StoreConstructor(db, stypes.StoreOptions{})
+	store := NewStore(nil, baseStore, iavlStore)
+	mpkg := &std.MemPackage{
+		Type: MPUserProd,
+		Name: "cov",
+		Path: "gno.land/p/demo/cov",
+		Files: []*std.MemFile{
+			{Name: "cov.gno", Body: body},
+			{Name: "cov_test.gno", Body: "package cov\n\nfunc helper() { _ = 1 }\n"},
+		},
+	}
+	cov := NewCoverage(mode)
+	cov.AddMemPackage(mpkg)
+
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath:  mpkg.Path,
+		Store:    store,
+		Coverage: cov,
+	})
+	defer m.Release()
+	m.RunMemPackage(MPFProd.FilterMemPackage(mpkg), true)
+	for _, x := range exprs {
+		m.Eval(x)
+	}
+	return cov
+}
+
+func TestCoverage(t *testing.T) {
+	cov := runCoverageTest(t, CoverModeCount,
+		Call(X("Abs"), Num("-1")),
+		Call(X("Loop"), Num("3")),
+	)
+
+	var sb strings.Builder
+	require.NoError(t, cov.WriteProfile(&sb, nil))
+	// Statements stop where the first statement they contain starts, so that
+	// blocks don't overlap. The test file is not registered.
+	assert.Equal(t, `mode: count
+gno.land/p/demo/cov/cov.gno:4.2,5.3 1 1
+gno.land/p/demo/cov/cov.gno:5.3,5.12 1 1
+gno.land/p/demo/cov/cov.gno:7.2,7.10 1 0
+gno.land/p/demo/cov/cov.gno:11.2,11.6 1 1
+gno.land/p/demo/cov/cov.gno:11.6,11.12 1 1
+gno.land/p/demo/cov/cov.gno:11.21,11.24 1 3
+gno.land/p/demo/cov/cov.gno:12.3,12.9 1 3
+gno.land/p/demo/cov/cov.gno:14.2,14.8 1 1
+`, sb.String())
+
+	percent, ok := cov.Percent("gno.land/p/demo/cov")
+	assert.True(t, ok)
+	assert.InDelta(t, 87.5, percent, 0.01)
+
+	_, ok = cov.Percent("gno.land/p/demo/other")
+	assert.False(t, ok)
+}
+
+func TestCoverage_Branches(t *testing.T) {
+	cov := runCoverageTestWithBody(t, coverageBranchTestBody, CoverModeCount,
+		Call(X("Abs"), Num("-1")),
+		Call(X("Abs"), Num("-2")),
+		Call(X("Sign"), Num("0")),
+	)
+
+	// The then branch of Abs, and no clause of Sign: 2 of the 2+3 branches.
+	percent, ok := cov.BranchPercent("gno.land/p/demo/cov")
+	assert.True(t, ok)
+	assert.InDelta(t, 40, percent, 0.01)
+
+	other := runCoverageTestWithBody(t, coverageBranchTestBody, CoverModeCount,
+		Call(X("Abs"), Num("1")),
+		Call(X("Sign"), Num("1")),
+	)
+	cov.Merge(other)
+	percent, _ = cov.BranchPercent("gno.land/p/demo/cov")
+	assert.InDelta(t, 80, percent, 0.01)
+
+	cov.Reset()
+	percent, _ = cov.BranchPercent("gno.land/p/demo/cov")
+	assert.Zero(t, percent)
+}
+
+func TestCoverage_Merge(t *testing.T) {
+	a := runCoverageTest(t, CoverModeSet, Call(X("Abs"), Num("-1")))
+	b := runCoverageTest(t, CoverModeSet, Call(X("Abs"), Num("1")))
+
+	merged := NewCoverage(CoverModeSet)
+	merged.Merge(a)
+	merged.Merge(b)
+
+	percent, ok := merged.Percent("gno.land/p/demo/cov")
+	assert.True(t, ok)
+	// All of Abs, none of Loop.
+	assert.InDelta(t, 37.5, percent, 0.01)
+}
+
+func TestCoverage_BlocksReset(t *testing.T) {
+	cov := runCoverageTest(t, CoverModeCount, Call(X("Loop"), Num("2")))
+
+	blocks := cov.Blocks()
+	require.Len(t, blocks, 8)
+	counts := make([]int64, len(blocks))
+	for i, cb := range blocks {
+		counts[i] = cb.Count
+	}
+	assert.Equal(t, []int64{0, 0, 0, 1, 1, 2, 2, 1}, counts)
+
+	cov.Reset()
+	for _, cb := range cov.Blocks() {
+		assert.Zero(t, cb.Count)
+	}
+}
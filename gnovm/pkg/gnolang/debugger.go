@@ -61,6 +61,7 @@ type Debugger struct {
 	nextDepth   int                         // function call depth at the 'next' command
 	getSrc      func(string, string) string // helper to access source from repl or others
 	rootDir     string
+	dap         *DebugAdapter // if not nil, the debugger is driven by a DAP client
 }
 
 // Enable makes the debugger d active, using in as input reader, out as output writer and f as a source helper.
@@ -76,6 +77,7 @@ func (d *Debugger) Enable(in io.Reader, out io.Writer, f func(string, string) st
 // Disable makes the debugger d inactive.
 func (d *Debugger) Disable() {
 	d.enabled = false
+	d.dap = nil
 	d.loc = Location{}
 	d.prevLoc = Location{}
 	d.nextLoc = Location{}
@@ -137,6 +139,10 @@ func init() {
 
 // Debug is the debug callback invoked at each VM execution step. It implements the DebugState FSA.
 func (m *Machine) Debug() {
+	if m.Debugger.dap != nil {
+		dapDebug(m)
+		return
+	}
 loop:
 	for {
 		switch m.Debugger.state {
@@ -174,6 +180,8 @@ loop:
 				}
 			case "stepout", "so":
 				if callDepth(m) < m.Debugger.nextDepth {
+					// The location was computed before the return.
+					debugUpdateLocation(m)
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
 					debugList(m, "")
@@ -194,8 +202,11 @@ loop:
 	}
 	m.Debugger.prevLoc = m.Debugger.loc
 	debugUpdateLocation(m)
+	debugTrackCalls(m)
+}
 
-	// Keep track of exact locations when performing calls.
+// debugTrackCalls keeps track of exact locations when performing calls.
+func debugTrackCalls(m *Machine) {
 	if len(m.Ops) == 0 {
 		return
 	}
@@ -316,8 +327,18 @@ func debugUpdateLocation(m *Machine) {
 	// The location computed from above points to the block start. Examine
 	// expressions and statements to have the exact line within the block.
 
+	// Only the expressions and statements of the current function call are
+	// considered: those of its callers are still in the machine stacks.
+	var fx, fs int
+	for i := len(m.Frames) - 1; i >= 0; i-- {
+		if f := &m.Frames[i]; f.Func != nil {
+			fx, fs = f.NumExprs, f.NumStmts
+			break
+		}
+	}
+
 	nx := len(m.Exprs)
-	for i := nx - 1; i >= 0; i-- {
+	for i := nx - 1; i >= fx; i-- {
 		expr := m.Exprs[i]
 		if l := expr.GetLine(); l > 0 {
 			if col := expr.GetColumn(); col > 0 {
@@ -328,7 +349,7 @@ func debugUpdateLocation(m *Machine) {
 		}
 	}
 
-	if len(m.Stmts) > 0 {
+	if len(m.Stmts) > fs {
 		if stmt := m.PeekStmt1(); stmt != nil {
 			if l := stmt.GetLine(); l > 0 {
 				if col := stmt.GetColumn(); col > 0 {
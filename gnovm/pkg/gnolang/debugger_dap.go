@@ -0,0 +1,719 @@
+package gnolang
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/parser"
+	"io"
+	"net"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DebugAdapter implements the server side of the Debug Adapter Protocol
+// (DAP), which editors such as VS Code or Neovim use to drive debuggers. See
+// https://microsoft.github.io/debug-adapter-protocol/specification.
+//
+// A DebugAdapter is a session with a single client. It is shared by all the
+// machines debugged during the session (one per test, for instance), so that
+// breakpoints persist across them. The VM being single threaded, requests are
+// served synchronously while the program is stopped, and polled at each VM
+// instruction while it runs.
+type DebugAdapter struct {
+	conn io.ReadWriteCloser
+	w    *bufio.Writer
+	seq  int
+	reqs chan *dapRequest // requests read from the client
+
+	breakpoints map[string]map[int]int            // source path -> line -> breakpoint id
+	nextBpID    int                               // id of the next breakpoint
+	resolve     func(pkgPath, name string) string // source path of a file of a package
+	srcPaths    map[Location]string               // cached results of resolve
+
+	mode     dapMode // when to stop, while the program runs
+	entry    bool    // stop at the first source location of the next machine
+	stopped  bool    // the program is stopped, requests are served
+	detached bool    // the client disconnected, the program runs freely
+	closed   bool    // the session is over
+
+	handles []func() []dapVariable // variable references of the current stop
+}
+
+type dapMode int
+
+const (
+	dapContinue dapMode = iota // stop at breakpoints
+	dapPause                   // stop as soon as possible
+	dapNext                    // stop at the next line of the current function
+	dapStepIn                  // stop at the next line
+	dapStepOut                 // stop when the current function returns
+)
+
+// dapThreadID is the id of the only thread reported to the client.
+const dapThreadID = 1
+
+// dapCapabilities are the optional features of the protocol supported by
+// DebugAdapter, returned on initialization.
+var dapCapabilities = map[string]any{
+	"supportsConfigurationDoneRequest": true,
+	"supportsEvaluateForHovers":        true,
+	"supportsTerminateRequest":         true,
+}
+
+// dapRequest is a request sent by the client.
+type dapRequest struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type dapResponse struct {
+	Seq        int    `json:"seq"`
+	Type       string `json:"type"`
+	RequestSeq int    `json:"request_seq"`
+	Command    string `json:"command"`
+	Success    bool   `json:"success"`
+	Message    string `json:"message,omitempty"`
+	Body       any    `json:"body,omitempty"`
+}
+
+type dapEvent struct {
+	Seq   int    `json:"seq"`
+	Type  string `json:"type"`
+	Event string `json:"event"`
+	Body  any    `json:"body,omitempty"`
+}
+
+type dapSource struct {
+	Name string `json:"name"`
+	Path string `json:"path,omitempty"`
+}
+
+type dapVariable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+}
+
+// ListenDAP waits for a DAP client to connect to addr, and returns the
+// session once configured; see NewDebugAdapter.
+func ListenDAP(addr string, resolve func(pkgPath, name string) string) (*DebugAdapter, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+	print("Waiting for DAP client to connect at ", addr)
+	conn, err := l.Accept()
+	if err != nil {
+		return nil, err
+	}
+	println(" connected!")
+	return NewDebugAdapter(conn, resolve)
+}
+
+// NewDebugAdapter starts a DAP session over conn. It performs the
+// configuration sequence of the protocol, and returns once the client has
+// sent its attach (or launch) and configurationDone requests.
+// resolve returns the path of the source file name of package pkgPath, as
+// shown to the client and used to match its breakpoints.
+func NewDebugAdapter(conn io.ReadWriteCloser, resolve func(pkgPath, name string) string) (*DebugAdapter, error) {
+	da := &DebugAdapter{
+		conn:        conn,
+		w:           bufio.NewWriter(conn),
+		reqs:        make(chan *dapRequest, 16),
+		breakpoints: map[string]map[int]int{},
+		nextBpID:    1,
+		resolve:     resolve,
+		srcPaths:    map[Location]string{},
+	}
+	go da.read(conn)
+
+	var attached, configured bool
+	for !attached || !configured {
+		req := <-da.reqs
+		switch req.Command {
+		case "initialize":
+			da.respond(req, dapCapabilities)
+			da.event("initialized", nil)
+		case "launch", "attach":
+			var args struct {
+				StopOnEntry bool `json:"stopOnEntry"`
+			}
+			_ = json.Unmarshal(req.Arguments, &args)
+			da.entry = args.StopOnEntry
+			attached = true
+			da.respond(req, nil)
+		case "configurationDone":
+			configured = true
+			da.respond(req, nil)
+		case "disconnect", "terminate":
+			da.respond(req, nil)
+			da.closed = true
+			conn.Close()
+			return nil, errors.New("DAP client disconnected")
+		default:
+			da.handle(nil, req)
+		}
+	}
+	return da, nil
+}
+
+// EnableDAP makes the debugger d active, driven by the DAP session da.
+// If not nil, resolve replaces the function finding source files given to
+// NewDebugAdapter, for the packages run by this machine.
+func (d *Debugger) EnableDAP(da *DebugAdapter, resolve func(pkgPath, name string) string) {
+	if da.detached || da.closed {
+		return
+	}
+	if resolve != nil {
+		da.resolve = resolve
+		clear(da.srcPaths)
+	}
+	if da.mode != dapContinue {
+		// The call depths of a previous machine are meaningless, stop at
+		// the beginning of this one.
+		da.mode = dapStepIn
+	}
+	d.enabled = true
+	d.dap = da
+	d.state = DebugAtRun
+}
+
+// Close ends the DAP session, reporting exitCode as the exit code of the
+// debugged program.
+func (da *DebugAdapter) Close(exitCode int) error {
+	if da.closed {
+		return nil
+	}
+	if !da.detached {
+		da.event("exited", map[string]any{"exitCode": exitCode})
+		da.event("terminated", nil)
+	}
+	da.closed = true
+	return da.conn.Close()
+}
+
+// dapDebug is the implementation of Machine.Debug for DAP sessions.
+func dapDebug(m *Machine) {
+	d, da := &m.Debugger, m.Debugger.dap
+	d.prevLoc = d.loc
+	debugUpdateLocation(m)
+
+	// Serve the requests received while running, such as pause.
+	for polling := true; polling; {
+		select {
+		case req := <-da.reqs:
+			da.handle(m, req)
+		default:
+			polling = false
+		}
+	}
+
+	// Only stop at the first instruction of a line, except for a pause.
+	if loc := d.loc; d.enabled && loc.File != "" {
+		newLine := !sameLine(loc, d.prevLoc)
+		var reason string
+		var hit []int
+		switch {
+		case da.entry:
+			reason = "entry"
+		case da.mode == dapPause:
+			reason = "pause"
+		case !newLine:
+		case da.mode == dapStepIn:
+			reason = "step"
+		case da.mode == dapNext:
+			if d.nextDepth == 0 || !sameLine(loc, d.nextLoc) && callDepth(m) <= d.nextDepth {
+				reason = "step"
+			}
+		case da.mode == dapStepOut:
+			if callDepth(m) < d.nextDepth {
+				reason = "step"
+			}
+		}
+		if id, ok := da.breakpoints[da.sourcePath(loc)][loc.Line]; ok && newLine && reason == "" {
+			reason = "breakpoint"
+			hit = []int{id}
+		}
+		if reason != "" {
+			da.entry = false
+			da.mode = dapContinue
+			da.stopped = true
+			da.handles = nil
+			d.frameLevel = 0
+			da.event("stopped", map[string]any{
+				"reason":            reason,
+				"threadId":          dapThreadID,
+				"allThreadsStopped": true,
+				"hitBreakpointIds":  hit,
+			})
+			for da.stopped {
+				da.handle(m, <-da.reqs)
+			}
+		}
+	}
+	debugTrackCalls(m)
+}
+
+// read reads the requests of the client and sends them to da.reqs. When the
+// connection is closed, a disconnect request is sent in lieu of the client.
+func (da *DebugAdapter) read(r io.Reader) {
+	tr := textproto.NewReader(bufio.NewReader(r))
+	defer func() { da.reqs <- &dapRequest{Seq: -1, Command: "disconnect"} }()
+	for {
+		hdr, err := tr.ReadMIMEHeader()
+		if err != nil {
+			return
+		}
+		n, err := strconv.Atoi(hdr.Get("Content-Length"))
+		if err != nil || n < 0 {
+			return
+		}
+		buf := make([]byte, n)
+		if _, err := io.ReadFull(tr.R, buf); err != nil {
+			return
+		}
+		req := new(dapRequest)
+		if err := json.Unmarshal(buf, req); err != nil || req.Type != "request" {
+			continue
+		}
+		da.reqs <- req
+	}
+}
+
+func (da *DebugAdapter) send(msg any) {
+	if da.closed {
+		return
+	}
+	buf, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	// Write errors mean that the client is gone, which read reports.
+	fmt.Fprintf(da.w, "Content-Length: %d\r\n\r\n", len(buf))
+	da.w.Write(buf)
+	da.w.Flush()
+}
+
+func (da *DebugAdapter) respond(req *dapRequest, body any) {
+	if req.Seq < 0 {
+		return // synthetic request.
+	}
+	da.seq++
+	da.send(dapResponse{
+		Seq: da.seq, Type: "response", RequestSeq: req.Seq,
+		Command: req.Command, Success: true, Body: body,
+	})
+}
+
+func (da *DebugAdapter) respondErr(req *dapRequest, err error) {
+	da.seq++
+	da.send(dapResponse{
+		Seq: da.seq, Type: "response", RequestSeq: req.Seq,
+		Command: req.Command, Message: err.Error(),
+	})
+}
+
+func (da *DebugAdapter) event(name string, body any) {
+	da.seq++
+	da.send(dapEvent{Seq: da.seq, Type: "event", Event: name, Body: body})
+}
+
+// handle serves a request. m is nil during the configuration sequence, and
+// the requests inspecting the program are only served while it is stopped.
+func (da *DebugAdapter) handle(m *Machine, req *dapRequest) {
+	var err error
+	var body any
+	switch req.Command {
+	case "threads":
+		body = map[string]any{"threads": []map[string]any{{"id": dapThreadID, "name": "main"}}}
+	case "setBreakpoints":
+		body, err = da.setBreakpoints(req.Arguments)
+	case "setExceptionBreakpoints":
+		body = map[string]any{"breakpoints": []any{}}
+	case "pause":
+		da.mode = dapPause
+	case "continue", "next", "stepIn", "stepOut":
+		if !da.stopped {
+			err = errors.New("the program is not stopped")
+			break
+		}
+		da.resume(m, req.Command)
+		if req.Command == "continue" {
+			body = map[string]any{"allThreadsContinued": true}
+		}
+	case "stackTrace", "scopes", "variables", "evaluate":
+		if !da.stopped {
+			err = errors.New("the program is not stopped")
+			break
+		}
+		switch req.Command {
+		case "stackTrace":
+			body = da.stackTrace(m)
+		case "scopes":
+			body, err = da.scopes(m, req.Arguments)
+		case "variables":
+			body, err = da.variables(req.Arguments)
+		default:
+			body, err = da.evaluate(m, req.Arguments)
+		}
+	case "disconnect", "terminate":
+		var args struct {
+			TerminateDebuggee bool `json:"terminateDebuggee"`
+		}
+		_ = json.Unmarshal(req.Arguments, &args)
+		da.respond(req, nil)
+		if req.Command == "terminate" || args.TerminateDebuggee {
+			da.event("terminated", nil)
+			da.conn.Close()
+			os.Exit(0)
+		}
+		// Resume the program, and let it run to completion.
+		da.detached, da.stopped = true, false
+		da.conn.Close()
+		if m != nil {
+			m.Debugger.enabled = false
+		}
+		return
+	default:
+		err = fmt.Errorf("unsupported command: %s", req.Command)
+	}
+	if err != nil {
+		da.respondErr(req, err)
+		return
+	}
+	da.respond(req, body)
+}
+
+// resume resumes the stopped program, for the command continue, next,
+// stepIn or stepOut.
+func (da *DebugAdapter) resume(m *Machine, cmd string) {
+	switch cmd {
+	case "next":
+		da.mode = dapNext
+	case "stepIn":
+		da.mode = dapStepIn
+	case "stepOut":
+		da.mode = dapStepOut
+	default:
+		da.mode = dapContinue
+	}
+	da.stopped = false
+	m.Debugger.frameLevel = 0
+	m.Debugger.nextDepth = callDepth(m)
+	m.Debugger.nextLoc = m.Debugger.loc
+}
+
+// sourcePath returns the path of the source file of loc.
+func (da *DebugAdapter) sourcePath(loc Location) string {
+	key := Location{PkgPath: loc.PkgPath, File: loc.File}
+	p, ok := da.srcPaths[key]
+	if !ok {
+		switch {
+		case filepath.IsAbs(loc.File):
+			p = loc.File
+		case da.resolve != nil:
+			p = da.resolve(loc.PkgPath, loc.File)
+		}
+		if p != "" {
+			if abs, err := filepath.Abs(p); err == nil {
+				p = abs
+			}
+		}
+		da.srcPaths[key] = p
+	}
+	return p
+}
+
+func (da *DebugAdapter) setBreakpoints(arguments json.RawMessage) (any, error) {
+	var args struct {
+		Source      dapSource `json:"source"`
+		Breakpoints []struct {
+			Line int `json:"line"`
+		} `json:"breakpoints"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+	if args.Source.Path == "" {
+		return nil, errors.New("missing source path")
+	}
+	p, err := filepath.Abs(args.Source.Path)
+	if err != nil {
+		return nil, err
+	}
+	if args.Source.Name == "" {
+		args.Source.Name = filepath.Base(p)
+	}
+	lines := map[int]int{}
+	bps := make([]map[string]any, 0, len(args.Breakpoints))
+	for _, b := range args.Breakpoints {
+		id, ok := lines[b.Line]
+		if !ok {
+			id = da.nextBpID
+			da.nextBpID++
+			lines[b.Line] = id
+		}
+		bps = append(bps, map[string]any{"id": id, "verified": true, "line": b.Line, "source": args.Source})
+	}
+	da.breakpoints[p] = lines
+	return map[string]any{"breakpoints": bps}, nil
+}
+
+func (da *DebugAdapter) stackTrace(m *Machine) any {
+	var frames []map[string]any
+	for i := 0; ; i++ {
+		ff := debugFrameFunc(m, i)
+		if ff == nil && i > 0 {
+			break
+		}
+		loc := debugFrameLoc(m, i)
+		name := m.Package.PkgPath
+		if ff != nil {
+			if ff.IsMethod {
+				name = fmt.Sprintf("%v.(%v).%v", ff.PkgPath, ff.Type.(*FuncType).Params[0].Type, ff.Name)
+			} else {
+				name = fmt.Sprintf("%v.%v", ff.PkgPath, ff.Name)
+			}
+		}
+		frame := map[string]any{"id": i + 1, "name": name, "line": loc.Line, "column": loc.Column}
+		if loc.File != "" {
+			frame["source"] = dapSource{Name: filepath.Base(loc.File), Path: da.sourcePath(loc)}
+		}
+		frames = append(frames, frame)
+		if ff == nil {
+			break
+		}
+	}
+	return map[string]any{"stackFrames": frames, "totalFrames": len(frames)}
+}
+
+func (da *DebugAdapter) scopes(m *Machine, arguments json.RawMessage) (any, error) {
+	var args struct {
+		FrameID int `json:"frameId"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+	level := max(args.FrameID-1, 0)
+	if level > len(m.Debugger.blocks) {
+		return nil, fmt.Errorf("invalid frame id: %d", args.FrameID)
+	}
+	block := m.LastBlock()
+	if level > 0 {
+		block = m.Debugger.blocks[len(m.Debugger.blocks)-level]
+	}
+	pv := m.Package
+	if ff := debugFrameFunc(m, level); ff != nil {
+		pv = ff.GetPackage(m.Store)
+	}
+	locals := da.newHandle(func() []dapVariable { return da.blockVariables(m, block, true) })
+	globals := da.newHandle(func() []dapVariable { return da.blockVariables(m, pv.GetBlock(m.Store), false) })
+	return map[string]any{"scopes": []map[string]any{
+		{"name": "Locals", "presentationHint": "locals", "variablesReference": locals},
+		{"name": "Globals", "variablesReference": globals},
+	}}, nil
+}
+
+// blockVariables returns the variables declared in block. If locals is true,
+// the variables of the enclosing blocks are included, up to the file block.
+func (da *DebugAdapter) blockVariables(m *Machine, block *Block, locals bool) []dapVariable {
+	var vars []dapVariable
+	seen := map[Name]bool{}
+	for b := block; b != nil; b = b.GetParent(m.Store) {
+		source := b.GetSource(m.Store)
+		if locals {
+			switch source.(type) {
+			case *FileNode, *PackageNode:
+				return vars
+			}
+		}
+		for i, n := range source.GetBlockNames() {
+			n = Name(strings.TrimSuffix(string(n), ".loopvar"))
+			if i >= len(b.Values) || n == "" || n == blankIdentifier || n[0] == '.' || seen[n] {
+				continue
+			}
+			seen[n] = true
+			tv := b.GetPointerToInt(m.Store, i).Deref()
+			if !locals {
+				// Only show the variables of the package block.
+				if _, ok := tv.V.(TypeValue); ok {
+					continue
+				}
+				if fv, ok := tv.V.(*FuncValue); ok && fv.Name == n {
+					continue
+				}
+			}
+			vars = append(vars, da.variable(m, string(n), tv))
+		}
+		if !locals {
+			break
+		}
+	}
+	return vars
+}
+
+func (da *DebugAdapter) newHandle(children func() []dapVariable) int {
+	da.handles = append(da.handles, children)
+	return len(da.handles)
+}
+
+func (da *DebugAdapter) variables(arguments json.RawMessage) (any, error) {
+	var args struct {
+		VariablesReference int `json:"variablesReference"`
+		Start              int `json:"start"`
+		Count              int `json:"count"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+	ref := args.VariablesReference
+	if ref <= 0 || ref > len(da.handles) {
+		return nil, fmt.Errorf("invalid variables reference: %d", ref)
+	}
+	vars := da.handles[ref-1]()
+	if args.Start > 0 {
+		vars = vars[min(args.Start, len(vars)):]
+	}
+	if args.Count > 0 {
+		vars = vars[:min(args.Count, len(vars))]
+	}
+	if vars == nil {
+		vars = []dapVariable{}
+	}
+	return map[string]any{"variables": vars}, nil
+}
+
+func (da *DebugAdapter) evaluate(m *Machine, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Expression string `json:"expression"`
+		FrameID    int    `json:"frameId"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+	x, err := parser.ParseExpr(args.Expression)
+	if err != nil {
+		return nil, err
+	}
+	m.Debugger.frameLevel = max(args.FrameID-1, 0)
+	defer func() { m.Debugger.frameLevel = 0 }()
+	tv, err := debugEvalExpr(m, x)
+	if err != nil {
+		return nil, err
+	}
+	v := da.variable(m, args.Expression, tv)
+	return map[string]any{"result": v.Value, "type": v.Type, "variablesReference": v.VariablesReference}, nil
+}
+
+// variable returns the description of value tv of variable name. The values
+// of realms are loaded from the store when needed, and the ids of objects
+// are listed among their children.
+func (da *DebugAdapter) variable(m *Machine, name string, tv TypedValue) (v dapVariable) {
+	fillValueTV(m.Store, &tv)
+	v.Name = name
+	if tv.T == nil {
+		v.Value = "nil"
+		return v
+	}
+	v.Type = tv.T.String()
+
+	var children func() []dapVariable
+	var obj Object
+	switch cv := tv.V.(type) {
+	case nil:
+		v.Value = "nil"
+		if tv.T.Kind() != PointerKind && tv.T.Kind() != SliceKind && tv.T.Kind() != MapKind &&
+			tv.T.Kind() != FuncKind && tv.T.Kind() != InterfaceKind {
+			v.Value = tv.ProtectedSprint(newSeenValues(), false)
+		}
+	case StringValue:
+		v.Value = strconv.Quote(string(cv))
+	case PointerValue:
+		elem := cv.Deref()
+		ev := da.variable(m, "*"+name, elem)
+		v.Value = "&" + ev.Value
+		if ev.VariablesReference != 0 {
+			v.VariablesReference = ev.VariablesReference
+		} else {
+			v.VariablesReference = da.newHandle(func() []dapVariable { return []dapVariable{ev} })
+		}
+		return v
+	case *StructValue:
+		obj = cv
+		v.Value = v.Type + "{...}"
+		st, _ := baseOf(tv.T).(*StructType)
+		children = func() []dapVariable {
+			vars := make([]dapVariable, 0, len(cv.Fields))
+			for i := range cv.Fields {
+				fname := "#" + strconv.Itoa(i)
+				if st != nil && i < len(st.Fields) {
+					fname = string(st.Fields[i].Name)
+				}
+				vars = append(vars, da.variable(m, fname, cv.Fields[i]))
+			}
+			return vars
+		}
+	case *ArrayValue:
+		obj = cv
+		v.Value = fmt.Sprintf("%s len: %d", v.Type, cv.GetLength())
+		children = func() []dapVariable { return da.arrayVariables(m, cv, 0, cv.GetLength()) }
+	case *SliceValue:
+		base := cv.GetBase(m.Store)
+		obj = base
+		v.Value = fmt.Sprintf("%s len: %d, cap: %d", v.Type, cv.Length, cv.Maxcap)
+		children = func() []dapVariable { return da.arrayVariables(m, base, cv.Offset, cv.Length) }
+	case *MapValue:
+		obj = cv
+		v.Value = fmt.Sprintf("%s len: %d", v.Type, cv.GetLength())
+		children = func() []dapVariable {
+			var vars []dapVariable
+			for item := cv.List.Head; item != nil; item = item.Next {
+				key := da.variable(m, "", item.Key)
+				vars = append(vars, da.variable(m, "["+key.Value+"]", item.Value))
+			}
+			return vars
+		}
+	case *PackageValue:
+		v.Value = "package(" + cv.PkgPath + ")"
+	case TypeValue:
+		v.Value = "type " + cv.Type.String()
+	case *FuncValue, *BoundMethodValue:
+		v.Value = v.Type
+	default:
+		v.Value = tv.ProtectedSprint(newSeenValues(), false)
+	}
+	if obj != nil && obj.GetIsReal() {
+		// Persisted realm object: show its id.
+		oid := dapVariable{Name: "(object)", Value: obj.GetObjectID().String()}
+		next := children
+		children = func() []dapVariable { return append([]dapVariable{oid}, next()...) }
+	}
+	if children != nil {
+		v.VariablesReference = da.newHandle(children)
+	}
+	return v
+}
+
+func (da *DebugAdapter) arrayVariables(m *Machine, av *ArrayValue, offset, length int) []dapVariable {
+	vars := make([]dapVariable, 0, length)
+	for i := range length {
+		var elem TypedValue
+		if av.Data != nil {
+			elem = TypedValue{T: Uint8Type}
+			elem.SetUint8(av.Data[offset+i])
+		} else {
+			elem = av.List[offset+i]
+		}
+		vars = append(vars, da.variable(m, "["+strconv.Itoa(i)+"]", elem))
+	}
+	return vars
+}
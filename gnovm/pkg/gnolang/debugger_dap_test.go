@@ -0,0 +1,233 @@
+package gnolang_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dapClient is a minimal DAP client, for testing.
+type dapClient struct {
+	t    *testing.T
+	conn net.Conn
+	seq  int
+	msgs chan map[string]any
+}
+
+func newDAPClient(t *testing.T, conn net.Conn) *dapClient {
+	t.Helper()
+	c := &dapClient{t: t, conn: conn, msgs: make(chan map[string]any, 64)}
+	go func() {
+		defer close(c.msgs)
+		tr := textproto.NewReader(bufio.NewReader(conn))
+		for {
+			hdr, err := tr.ReadMIMEHeader()
+			if err != nil {
+				return
+			}
+			n, _ := strconv.Atoi(hdr.Get("Content-Length"))
+			buf := make([]byte, n)
+			if _, err := io.ReadFull(tr.R, buf); err != nil {
+				return
+			}
+			var msg map[string]any
+			if err := json.Unmarshal(buf, &msg); err != nil {
+				return
+			}
+			c.msgs <- msg
+		}
+	}()
+	return c
+}
+
+func (c *dapClient) send(command string, args any) {
+	c.t.Helper()
+	c.seq++
+	buf, err := json.Marshal(map[string]any{"seq": c.seq, "type": "request", "command": command, "arguments": args})
+	require.NoError(c.t, err)
+	_, err = fmt.Fprintf(c.conn, "Content-Length: %d\r\n\r\n%s", len(buf), buf)
+	require.NoError(c.t, err)
+}
+
+// expect returns the next message, which must be the event or response
+// name.
+func (c *dapClient) expect(typ, name string) map[string]any {
+	c.t.Helper()
+	select {
+	case msg, ok := <-c.msgs:
+		require.True(c.t, ok, "connection closed, expected %s %s", typ, name)
+		key := "command"
+		if typ == "event" {
+			key = "event"
+		}
+		require.Equal(c.t, typ, msg["type"], "message: %v", msg)
+		require.Equal(c.t, name, msg[key], "message: %v", msg)
+		return msg
+	case <-time.After(10 * time.Second):
+		c.t.Fatalf("timeout waiting for %s %s", typ, name)
+		return nil
+	}
+}
+
+// request sends a request and returns the body of its successful response.
+func (c *dapClient) request(command string, args any) map[string]any {
+	c.t.Helper()
+	c.send(command, args)
+	resp := c.expect("response", command)
+	require.Equal(c.t, true, resp["success"], "response: %v", resp)
+	body, _ := resp["body"].(map[string]any)
+	return body
+}
+
+// variables returns the variables of reference ref, by name.
+func (c *dapClient) variables(ref any) map[string]map[string]any {
+	c.t.Helper()
+	body := c.request("variables", map[string]any{"variablesReference": ref})
+	vars := map[string]map[string]any{}
+	for _, v := range body["variables"].([]any) {
+		v := v.(map[string]any)
+		vars[v["name"].(string)] = v
+	}
+	return vars
+}
+
+func TestDAP(t *testing.T) {
+	target, err := filepath.Abs(debugTarget)
+	require.NoError(t, err)
+
+	sconn, cconn := net.Pipe()
+	c := newDAPClient(t, cconn)
+	defer cconn.Close()
+
+	// Run the sample program in the background, once configured.
+	var out bytes.Buffer
+	done := make(chan error, 1)
+	go func() {
+		da, err := gnolang.NewDebugAdapter(sconn, func(pkgPath, name string) string {
+			p, _ := filepath.Abs(name)
+			return p
+		})
+		if err != nil {
+			done <- err
+			return
+		}
+		_, testStore := test.TestStore(gnoenv.RootDir(), &out, nil)
+		m := gnolang.NewMachineWithOptions(gnolang.MachineOptions{
+			PkgPath: "main",
+			Output:  &out,
+			Store:   testStore,
+			Context: test.Context(test.DefaultCaller, "main", nil),
+		})
+		defer m.Release()
+		m.Debugger.EnableDAP(da, nil)
+		m.RunFiles(m.MustReadFile(debugTarget))
+		m.Eval(gnolang.Call(gnolang.Nx("main")))
+		done <- da.Close(0)
+	}()
+
+	// Configuration sequence.
+	caps := c.request("initialize", map[string]any{"adapterID": "gno"})
+	assert.Equal(t, true, caps["supportsConfigurationDoneRequest"])
+	c.expect("event", "initialized")
+	c.request("attach", map[string]any{})
+	bps := c.request("setBreakpoints", map[string]any{
+		"source":      map[string]any{"path": target},
+		"breakpoints": []any{map[string]any{"line": 7}, map[string]any{"line": 21}},
+	})
+	assert.Len(t, bps["breakpoints"], 2)
+	c.request("configurationDone", nil)
+
+	// Stop at the breakpoint in f, called by g, called by main.
+	stopped := c.expect("event", "stopped")["body"].(map[string]any)
+	assert.Equal(t, "breakpoint", stopped["reason"])
+	c.send("continue", nil) // Rejected when running, accepted when stopped.
+	c.expect("response", "continue")
+	stopped = c.expect("event", "stopped")["body"].(map[string]any)
+	assert.Equal(t, "breakpoint", stopped["reason"])
+
+	threads := c.request("threads", nil)
+	assert.Len(t, threads["threads"], 1)
+
+	frames := c.request("stackTrace", map[string]any{"threadId": 1})["stackFrames"].([]any)
+	require.Len(t, frames, 2)
+	top := frames[0].(map[string]any)
+	assert.Equal(t, "main.(*main.T).get", top["name"])
+	assert.EqualValues(t, 21, top["line"])
+	assert.Equal(t, target, top["source"].(map[string]any)["path"])
+	assert.Equal(t, "main.main", frames[1].(map[string]any)["name"])
+
+	scopes := c.request("scopes", map[string]any{"frameId": 1})["scopes"].([]any)
+	require.Len(t, scopes, 2)
+	locals := c.variables(scopes[0].(map[string]any)["variablesReference"])
+	assert.Equal(t, "1", locals["i"]["value"])
+	assert.Equal(t, "int", locals["i"]["type"])
+	tv := locals["t"]
+	assert.Equal(t, "&main.T{...}", tv["value"])
+	fields := c.variables(tv["variablesReference"])
+	assert.Equal(t, "[]int len: 3, cap: 3", fields["A"]["value"])
+	elems := c.variables(fields["A"]["variablesReference"])
+	assert.Equal(t, "3", elems["[2]"]["value"])
+
+	globals := c.variables(scopes[1].(map[string]any)["variablesReference"])
+	assert.Equal(t, `"test"`, globals["global"]["value"])
+	assert.NotContains(t, globals, "main", "functions are not variables")
+	assert.NotContains(t, globals, "T", "types are not variables")
+
+	// The caller frame.
+	scopes = c.request("scopes", map[string]any{"frameId": 2})["scopes"].([]any)
+	locals = c.variables(scopes[0].(map[string]any)["variablesReference"])
+	assert.Equal(t, "5", locals["num"]["value"])
+
+	res := c.request("evaluate", map[string]any{"expression": "t.A[0]", "frameId": 1})
+	assert.Equal(t, "1", res["result"])
+	c.send("evaluate", map[string]any{"expression": "nope", "frameId": 1})
+	resp := c.expect("response", "evaluate")
+	assert.Equal(t, false, resp["success"])
+	assert.Contains(t, resp["message"], "name nope not declared")
+
+	// Step over line 21, then out of get.
+	c.request("next", map[string]any{"threadId": 1})
+	assert.Equal(t, "step", c.expect("event", "stopped")["body"].(map[string]any)["reason"])
+	frames = c.request("stackTrace", map[string]any{"threadId": 1})["stackFrames"].([]any)
+	assert.EqualValues(t, 22, frames[0].(map[string]any)["line"])
+	c.request("stepOut", map[string]any{"threadId": 1})
+	c.expect("event", "stopped")
+	frames = c.request("stackTrace", map[string]any{"threadId": 1})["stackFrames"].([]any)
+	assert.Equal(t, "main.main", frames[0].(map[string]any)["name"])
+
+	// Run to completion.
+	c.request("setBreakpoints", map[string]any{"source": map[string]any{"path": target}, "breakpoints": []any{}})
+	c.request("continue", map[string]any{"threadId": 1})
+	assert.EqualValues(t, 0, c.expect("event", "exited")["body"].(map[string]any)["exitCode"])
+	c.expect("event", "terminated")
+	require.NoError(t, <-done)
+	assert.Contains(t, out.String(), "bye 4")
+}
+
+func TestDAPDisconnect(t *testing.T) {
+	sconn, cconn := net.Pipe()
+	c := newDAPClient(t, cconn)
+	done := make(chan error, 1)
+	go func() {
+		_, err := gnolang.NewDebugAdapter(sconn, nil)
+		done <- err
+	}()
+	c.request("initialize", nil)
+	c.expect("event", "initialized")
+	c.request("disconnect", nil)
+	assert.EqualError(t, <-done, "DAP client disconnected")
+}
@@ -29,6 +29,7 @@ type Frame struct {
 	IsVarg        bool          // is form fncall(???, vargs...)
 	LastPackage   *PackageValue // previous frame's package
 	LastRealm     *Realm        // previous frame's realm
+	LastInView    bool          // previous frame's read-only view mode
 	WithCross     bool          // true if called like cross(fn)(...). expects crossing() after.
 	DidCrossing   bool          // true if crossing() was called.
 	Defers        []Defer       // deferred calls
@@ -249,6 +250,10 @@ func toConstExpTrace(cte *ConstExpr) string {
 			return fmt.Sprintf("%v", math.Float32frombits(tv.GetFloat32()))
 		case Float64Type:
 			return fmt.Sprintf("%v", math.Float64frombits(tv.GetFloat64()))
+		case Complex64Type:
+			return fmt.Sprintf("%v", complex64Of(tv.GetComplex64()))
+		case Complex128Type:
+			return fmt.Sprintf("%v", complex128Of(tv.GetComplex128()))
 		}
 	}
 
@@ -105,6 +105,17 @@ func (m *Machine) GarbageCollect() (left int64, ok bool) {
 		}
 	}
 
+	// Visit goroutines other than the running one.
+	for _, g := range m.sched.all {
+		if g == m.sched.cur {
+			continue
+		}
+		stop := g.visit(m.Alloc, vis)
+		if stop {
+			return -1, false
+		}
+	}
+
 	// Visit package
 	stop := vis(m.Package)
 	if stop {
@@ -196,6 +207,14 @@ func GCVisitorFn(gcCycle int64, alloc *Allocator, visitCount *int64) Visitor {
 				return false // but don't stop
 			}
 		}
+		// Channels are not objects, but may still (indirectly) contain
+		// themselves, so break cycles the same way.
+		if cv, isChan := v.(*ChanValue); isChan {
+			if cv.lastGCCycle == gcCycle {
+				return false // but don't stop
+			}
+			cv.lastGCCycle = gcCycle
+		}
 
 		*visitCount++ // Count operations for gas calculation
 
@@ -315,6 +334,21 @@ func (bmv *BoundMethodValue) VisitAssociated(vis Visitor) (stop bool) {
 	return
 }
 
+func (cv *ChanValue) VisitAssociated(vis Visitor) (stop bool) {
+	// Visit buffered values.
+	for i := range cv.Buffer {
+		v := cv.Buffer[i].V
+		if v == nil {
+			continue
+		}
+		stop = vis(v)
+		if stop {
+			return
+		}
+	}
+	return
+}
+
 func (mv *MapValue) VisitAssociated(vis Visitor) (stop bool) {
 	// visit mv.List.
 	for cur := mv.List.Head; cur != nil; cur = cur.Next {
@@ -438,6 +472,14 @@ func (bdv BigdecValue) VisitAssociated(vis Visitor) (stop bool) {
 	return false
 }
 
+func (cv Complex128Value) VisitAssociated(vis Visitor) (stop bool) {
+	return false
+}
+
+func (bcv BigcomplexValue) VisitAssociated(vis Visitor) (stop bool) {
+	return false
+}
+
 func (dbv DataByteValue) VisitAssociated(vis Visitor) (stop bool) {
 	return false
 }
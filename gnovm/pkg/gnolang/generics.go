@@ -0,0 +1,819 @@
+package gnolang
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Generic functions and types are implemented by instantiation during
+// preprocessing. The declaration of a generic function or type, and of the
+// methods of a generic type, is a template that is never preprocessed
+// itself. Its name is predefined with a *templateType static type, and each
+// use with a distinct list of type arguments preprocesses a copy of the
+// declaration where the type parameters are constant types:
+//
+//   - func Map[T, U any](...), used as Map[int, string] or inferred from the
+//     arguments of a call, becomes a *FuncDecl named "Map[int,string]" that
+//     is not declared in the package block, and is referred to by the
+//     *ConstExpr that replaces the instantiating expression;
+//   - type List[T any] struct{...}, used as List[int], becomes the
+//     *DeclaredType "List[int]" of the package of the template, with
+//     .TypeArgs set and an instance of each method of List[T].
+//
+// The block nodes of instances have the type arguments in their
+// Location.Inst, and instantiated types are saved in the store like any other
+// declared type, so that instances are shared by all packages, and found
+// again when a package that uses them gets preprocessed upon restart.
+//
+// Constraints are only used to check method sets and core types (~[]E).
+// Type sets are checked by the Go type checker (see gotypecheck.go).
+
+// maxGenericInstLen limits the length of the type arguments of an instance,
+// which otherwise may grow forever with recursive instantiations like
+// func F[T any]() { F[[]T]() }.
+const maxGenericInstLen = 1024
+
+// instContext is shared by the block nodes of nested instantiations, which
+// are preprocessed out of the node tree of the package that uses them.
+type instContext struct {
+	owner   *PackageNode             // package being predefined, if any.
+	pending map[TypeID]*DeclaredType // types being instantiated.
+	bodies  []func()                 // bodies to preprocess when no type is pending.
+}
+
+// instContextOf returns the context of the instantiations made from last.
+func instContextOf(last BlockNode) *instContext {
+	for bn := last; bn != nil; bn = bn.GetParentNode(nil) {
+		if ic, ok := bn.GetAttribute(ATTR_GENERIC_CONTEXT).(*instContext); ok {
+			return ic
+		}
+		if pn, ok := bn.(*PackageNode); ok {
+			ic := &instContext{pending: map[TypeID]*DeclaredType{}}
+			if pn.GetAttribute(ATTR_PREDEFINING) == true {
+				ic.owner = pn
+			}
+			return ic
+		}
+	}
+	panic("should not happen")
+}
+
+// preprocessBody runs f, which preprocesses the body of an instance, once all
+// pending types are complete. If the package that uses the instance is still
+// being predefined, the body may refer to names that are not yet predefined,
+// so f is run at the end of the predefinition instead.
+func (ic *instContext) preprocessBody(f func()) {
+	if ic.owner != nil && ic.owner.GetAttribute(ATTR_PREDEFINING) == true {
+		bodies, _ := ic.owner.GetAttribute(ATTR_GENERIC_BODIES).([]func())
+		ic.owner.SetAttribute(ATTR_GENERIC_BODIES, append(bodies, f))
+	} else if len(ic.pending) > 0 {
+		ic.bodies = append(ic.bodies, f)
+	} else {
+		f()
+	}
+}
+
+// flushBodies preprocesses the bodies that were waiting for pending types.
+func (ic *instContext) flushBodies() {
+	for len(ic.pending) == 0 && len(ic.bodies) > 0 {
+		f := ic.bodies[0]
+		ic.bodies = ic.bodies[1:]
+		ic.preprocessBody(f)
+	}
+}
+
+// beginPredefine marks pn as being predefined, so that the bodies of the
+// instances it creates get preprocessed by endPredefine. Returns false if pn
+// is already being predefined.
+func beginPredefine(pn *PackageNode) bool {
+	if pn.GetAttribute(ATTR_PREDEFINING) == true {
+		return false
+	}
+	pn.SetAttribute(ATTR_PREDEFINING, true)
+	return true
+}
+
+// endPredefine preprocesses the bodies of the instances created while pn was
+// being predefined.
+func endPredefine(pn *PackageNode) {
+	pn.DelAttribute(ATTR_PREDEFINING)
+	bodies, _ := pn.GetAttribute(ATTR_GENERIC_BODIES).([]func())
+	pn.DelAttribute(ATTR_GENERIC_BODIES)
+	for _, f := range bodies {
+		f()
+	}
+}
+
+// isGenericDecl returns true if n is the declaration of a generic function,
+// of a generic type, or of a method of a generic type.
+// Instances are not generic declarations.
+func isGenericDecl(n Node) bool {
+	switch d := n.(type) {
+	case *FuncDecl:
+		if d.HasAttribute(ATTR_GENERIC_INST) {
+			return false
+		}
+		if len(d.TypeParams) > 0 {
+			return true
+		}
+		_, indices := genericRecv(d)
+		return indices != nil
+	case *TypeDecl:
+		return len(d.TypeParams) > 0
+	default:
+		return false
+	}
+}
+
+// genericRecv returns the name of the generic type of the receiver of
+// method fd along with its type parameters, e.g. List and [T] for
+// func (l *List[T]) Len() int. Returns nil indices if fd is not a method of
+// a generic type.
+func genericRecv(fd *FuncDecl) (Name, []Expr) {
+	if !fd.IsMethod {
+		return "", nil
+	}
+	rt := fd.Recv.Type
+	if sx, ok := rt.(*StarExpr); ok {
+		rt = sx.X
+	}
+	switch rt := rt.(type) {
+	case *IndexExpr:
+		if nx, ok := rt.X.(*NameExpr); ok {
+			return nx.Name, []Expr{rt.Index}
+		}
+	case *IndexListExpr:
+		if nx, ok := rt.X.(*NameExpr); ok {
+			return nx.Name, rt.Indices
+		}
+	}
+	return "", nil
+}
+
+// predefineGeneric predefines the name of a generic declaration with a
+// *templateType. Methods of generic types are instantiated along with their
+// type, and are not predefined.
+func predefineGeneric(store Store, last BlockNode, d Decl) {
+	fn := fileOfSafe(last)
+	pn, ok := skipFile(last).(*PackageNode)
+	if fn == nil || !ok {
+		panic(fmt.Sprintf("generic declaration %s must be at the package level", d.GetDeclNames()))
+	}
+	tt := &templateType{Decl: d, File: fn}
+	switch d := d.(type) {
+	case *TypeDecl:
+		if d.IsAlias {
+			panic(fmt.Sprintf("generic type %s cannot be an alias", d.Name))
+		}
+		pn.Define2(true, d.Name, tt, TypedValue{}, NameSource{&d.NameExpr, d, NSTypeDecl, -1})
+		d.Path = last.GetPathForName(store, d.Name)
+	case *FuncDecl:
+		if d.IsMethod {
+			return
+		}
+		if d.Body == nil {
+			panic(fmt.Sprintf("generic function %s must have a body", d.Name))
+		}
+		pn.Define2(false, d.Name, tt, TypedValue{}, NameSource{&d.NameExpr, d, NSFuncDecl, -1})
+	}
+}
+
+// genericRef marks x, a reference to the generic declaration of tt, to be
+// instantiated by its parent expression.
+func genericRef(x Expr, tt *templateType, ftype TransField) Expr {
+	_, isFunc := tt.Decl.(*FuncDecl)
+	switch ftype {
+	case TRANS_INDEX_X, TRANS_INDEXLIST_X:
+	case TRANS_CALL_FUNC:
+		if !isFunc {
+			panic(fmt.Sprintf("cannot use generic type %s without instantiation", genericName(x)))
+		}
+	default:
+		if isFunc {
+			panic(fmt.Sprintf("cannot use generic function %s without instantiation", genericName(x)))
+		}
+		panic(fmt.Sprintf("cannot use generic type %s without instantiation", genericName(x)))
+	}
+	x.SetAttribute(ATTR_GENERIC, tt)
+	return x
+}
+
+// genericName returns the name of x, a reference to a generic declaration,
+// as written in the source.
+func genericName(x Expr) string {
+	switch x := x.(type) {
+	case *NameExpr:
+		return string(x.Name)
+	case *SelectorExpr:
+		if nx, ok := x.X.(*NameExpr); ok {
+			return string(nx.Name) + "." + string(x.Sel)
+		}
+		return string(x.Sel)
+	case *IndexExpr:
+		return genericName(x.X)
+	case *IndexListExpr:
+		return genericName(x.X)
+	default:
+		return x.String()
+	}
+}
+
+// instantiateIndex instantiates the generic declaration referred to by x with
+// the type arguments of n, an *IndexExpr or *IndexListExpr, and returns the
+// expression to replace n with. If not all type arguments of a generic
+// function are given, n is returned as is for the enclosing call to infer
+// the rest.
+func instantiateIndex(store Store, last BlockNode, n Expr, x Expr, indices []Expr, ftype TransField) Expr {
+	tt := x.GetAttribute(ATTR_GENERIC).(*templateType)
+	targs := make([]Type, len(indices))
+	for i, ix := range indices {
+		targs[i] = evalStaticType(store, last, ix)
+	}
+	switch d := tt.Decl.(type) {
+	case *TypeDecl:
+		if len(targs) != len(d.TypeParams) {
+			panic(fmt.Sprintf("got %d type arguments but %s has %d type parameters",
+				len(targs), genericName(x), len(d.TypeParams)))
+		}
+		dt := instantiateType(store, last, tt, targs)
+		n.SetAttribute(ATTR_TYPE_VALUE, dt)
+		return toConstTypeExpr(last, n, dt)
+	case *FuncDecl:
+		if len(targs) > len(d.TypeParams) {
+			panic(fmt.Sprintf("got %d type arguments but %s has %d type parameters",
+				len(targs), genericName(x), len(d.TypeParams)))
+		}
+		if len(targs) < len(d.TypeParams) {
+			if ftype != TRANS_CALL_FUNC {
+				panic(fmt.Sprintf("cannot use generic function %s without instantiation", genericName(x)))
+			}
+			n.SetAttribute(ATTR_GENERIC, tt)
+			return n
+		}
+		return instantiateFunc(store, last, n, tt, targs)
+	default:
+		panic("should not happen")
+	}
+}
+
+// instantiateCall instantiates the generic function called by cx, inferring
+// the type arguments that are not given from the types of the arguments.
+func instantiateCall(store Store, last BlockNode, cx *CallExpr) {
+	tt := cx.Func.GetAttribute(ATTR_GENERIC).(*templateType)
+	fd := tt.Decl.(*FuncDecl)
+	inf := &inferrer{
+		index: make(map[Name]int, len(fd.TypeParams)),
+		targs: make([]Type, len(fd.TypeParams)),
+	}
+	for i, tp := range fd.TypeParams {
+		inf.index[tp.Name] = i
+	}
+	var explicit []Expr
+	switch fx := cx.Func.(type) {
+	case *IndexExpr:
+		explicit = []Expr{fx.Index}
+	case *IndexListExpr:
+		explicit = fx.Indices
+	}
+	for i, ix := range explicit {
+		inf.targs[i] = evalStaticType(store, last, ix)
+	}
+	// Collect the types of the arguments.
+	var ats []Type
+	if len(cx.Args) == 1 {
+		if tt, ok := evalStaticTypeOf(store, last, cx.Args[0]).(*tupleType); ok {
+			ats = tt.Elts
+		}
+	}
+	if ats == nil {
+		ats = make([]Type, len(cx.Args))
+		for i, arg := range cx.Args {
+			ats[i] = evalStaticTypeOf(store, last, arg)
+		}
+	}
+	// Match them with the type expressions of the parameters.
+	params := fd.Type.Params
+	pxs := make([]Expr, len(ats))
+	for i := range ats {
+		switch {
+		case i < len(params)-1:
+			pxs[i] = params[i].Type
+		case len(params) == 0:
+			// checked by the call.
+		default:
+			px := params[len(params)-1].Type
+			if sx, ok := px.(*SliceTypeExpr); ok && sx.Vrd && !cx.Varg {
+				px = sx.Elt
+			} else if i >= len(params) {
+				continue // checked by the call.
+			}
+			pxs[i] = px
+		}
+	}
+	// Typed arguments first, then untyped constants with their default
+	// type, like Go.
+	for i, at := range ats {
+		if pxs[i] != nil && at != nil && !isUntyped(at) {
+			inf.unify(pxs[i], at)
+		}
+	}
+	for i, at := range ats {
+		if pxs[i] != nil && at != nil && isUntyped(at) {
+			inf.unify(pxs[i], defaultTypeOf(at))
+		}
+	}
+	// Infer the remaining type arguments from core type constraints,
+	// e.g. E from S ~[]E.
+	for progress := true; progress; {
+		progress = false
+		for i, tp := range fd.TypeParams {
+			if inf.targs[i] == nil {
+				continue
+			}
+			switch tp.Type.(type) {
+			case *NameExpr, *SelectorExpr, *InterfaceTypeExpr, *IndexExpr, *IndexListExpr:
+				// not a core type.
+			default:
+				n := inf.bound()
+				inf.unify(tp.Type, baseOf(inf.targs[i]))
+				progress = progress || inf.bound() > n
+			}
+		}
+	}
+	for i, targ := range inf.targs {
+		if targ == nil {
+			panic(fmt.Sprintf("in call to %s, cannot infer %s",
+				genericName(cx.Func), fd.TypeParams[i].Name))
+		}
+	}
+	cx.Func = instantiateFunc(store, last, cx.Func, tt, inf.targs)
+}
+
+// inferrer infers the type arguments of a generic function from the types of
+// its arguments.
+type inferrer struct {
+	index map[Name]int // type parameter indices
+	targs []Type       // inferred type arguments
+}
+
+// bound returns the number of type arguments inferred so far.
+func (inf *inferrer) bound() (n int) {
+	for _, targ := range inf.targs {
+		if targ != nil {
+			n++
+		}
+	}
+	return
+}
+
+// unify matches the type expression x of the template with type t, binding
+// the type parameters it refers to. Mismatches are not reported here, but
+// when the arguments of the call are checked against the instance.
+func (inf *inferrer) unify(x Expr, t Type) {
+	if t == nil {
+		return
+	}
+	switch x := x.(type) {
+	case *NameExpr:
+		if i, ok := inf.index[x.Name]; ok && inf.targs[i] == nil {
+			inf.targs[i] = t
+		}
+	case *FieldTypeExpr:
+		inf.unify(x.Type, t)
+	case *StarExpr:
+		if pt, ok := baseOf(t).(*PointerType); ok {
+			inf.unify(x.X, pt.Elt)
+		}
+	case *SliceTypeExpr:
+		if st, ok := baseOf(t).(*SliceType); ok {
+			inf.unify(x.Elt, st.Elt)
+		}
+	case *ArrayTypeExpr:
+		if at, ok := baseOf(t).(*ArrayType); ok {
+			inf.unify(x.Elt, at.Elt)
+		}
+	case *MapTypeExpr:
+		if mt, ok := baseOf(t).(*MapType); ok {
+			inf.unify(x.Key, mt.Key)
+			inf.unify(x.Value, mt.Value)
+		}
+	case *ChanTypeExpr:
+		if ct, ok := baseOf(t).(*ChanType); ok {
+			inf.unify(x.Value, ct.Elt)
+		}
+	case *FuncTypeExpr:
+		if ft, ok := baseOf(t).(*FuncType); ok &&
+			len(ft.Params) == len(x.Params) &&
+			len(ft.Results) == len(x.Results) {
+			for i := range x.Params {
+				inf.unify(x.Params[i].Type, ft.Params[i].Type)
+			}
+			for i := range x.Results {
+				inf.unify(x.Results[i].Type, ft.Results[i].Type)
+			}
+		}
+	case *IndexExpr:
+		inf.unifyTypeArgs([]Expr{x.Index}, t)
+	case *IndexListExpr:
+		inf.unifyTypeArgs(x.Indices, t)
+	}
+}
+
+func (inf *inferrer) unifyTypeArgs(indices []Expr, t Type) {
+	if dt, ok := t.(*DeclaredType); ok && len(dt.TypeArgs) == len(indices) {
+		for i, ix := range indices {
+			inf.unify(ix, dt.TypeArgs[i])
+		}
+	}
+}
+
+// instantiateFunc returns the *ConstExpr of the instance of the generic
+// function of tt with type arguments targs.
+func instantiateFunc(store Store, last BlockNode, source Expr, tt *templateType, targs []Type) *ConstExpr {
+	fd := tt.Decl.(*FuncDecl)
+	ic := instContextOf(last)
+	names := make([]Name, len(fd.TypeParams))
+	for i := range fd.TypeParams {
+		names[i] = fd.TypeParams[i].Name
+	}
+	ifd, body := instantiateFuncDecl(store, ic, tt.File, fd, names, targs)
+	if body != nil {
+		ic.preprocessBody(func() {
+			checkTypeArgs(store, ifd, fd.TypeParams, targs)
+			body()
+		})
+	}
+	fv := ifd.GetAttribute(ATTR_GENERIC_FUNC).(*FuncValue)
+	cx := toConstExpr(source, TypedValue{T: fv.Type, V: fv})
+	setConstAttrs(cx)
+	return cx
+}
+
+// instantiateFuncDecl returns the instance of fd, a generic function or a
+// method of a generic type declared in file fn, with the type parameters
+// names bound to targs. If the instance is new, its signature is
+// preprocessed, and the returned body function preprocesses the rest.
+func instantiateFuncDecl(store Store, ic *instContext, fn *FileNode, fd *FuncDecl, names []Name, targs []Type) (ifd *FuncDecl, body func()) {
+	pn := packageOf(fn)
+	inst := typeArgsString(targs)
+	loc := fd.GetLocation()
+	loc.Inst = inst
+	if bn := store.GetBlockNodeSafe(loc); bn != nil {
+		return bn.(*FuncDecl), nil
+	}
+	ifd = copyWithSpans(fd).(*FuncDecl)
+	ifd.TypeParams = nil
+	if !fd.IsMethod {
+		ifd.Name = Name(fmt.Sprintf("%s[%s]", fd.Name, inst))
+	}
+	ifd.SetAttribute(ATTR_GENERIC_INST, targs)
+	ifd.SetAttribute(ATTR_GENERIC_CONTEXT, ic)
+	ifd.SetAttribute(ATTR_PREDEFINED, true)
+	setInstLocations(pn.PkgPath, fn.FileName, inst, ifd)
+	initStaticBlocks(store, fn, ifd)
+	defineTypeParams(ifd, names, targs)
+	// Preprocess the signature like tryPredefine.
+	var fv *FuncValue
+	if ifd.IsMethod {
+		ifd.Recv = *Preprocess(store, ifd, &ifd.Recv).(*FieldTypeExpr)
+		ifd.Type = *Preprocess(store, ifd, &ifd.Type).(*FuncTypeExpr)
+		rft := evalStaticType(store, ifd, &ifd.Recv).(FieldType)
+		ft := evalStaticType(store, ifd, &ifd.Type).(*FuncType)
+		fv = &FuncValue{
+			Type:     ft.UnboundType(rft),
+			IsMethod: true,
+			Source:   ifd,
+			Name:     ifd.Name,
+			Parent:   nil, // set lazily
+			FileName: fn.FileName,
+			PkgPath:  pn.PkgPath,
+			Crossing: ft.IsCrossing(),
+			body:     ifd.Body,
+		}
+	} else {
+		ifd.Type = *Preprocess(store, ifd, &ifd.Type).(*FuncTypeExpr)
+		ft := evalStaticType(store, ifd, &ifd.Type).(*FuncType)
+		fv = &FuncValue{
+			Type:     ft,
+			IsMethod: false,
+			Source:   ifd,
+			Name:     ifd.Name,
+			Parent:   nil, // set lazily
+			FileName: fn.FileName,
+			PkgPath:  pn.PkgPath,
+			Crossing: ft.IsCrossing(),
+			body:     ifd.Body,
+		}
+	}
+	// Like the template, the instance belongs to the declaring
+	// package's realm.
+	fv.ObjectInfo.SetPkgID(PkgIDFromPkgPath(pn.PkgPath))
+	ifd.SetAttribute(ATTR_GENERIC_FUNC, fv)
+	store.SetBlockNode(ifd)
+	body = func() {
+		Preprocess(store, fn, ifd)
+		fv.UpdateBodyFromSource()
+		Transcribe(ifd, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+			if bn, ok := n.(BlockNode); ok && stage == TRANS_ENTER {
+				store.SetBlockNode(bn)
+			}
+			return n, TRANS_CONTINUE
+		})
+	}
+	return ifd, body
+}
+
+// instantiateType returns the instance of the generic type of tt with type
+// arguments targs.
+func instantiateType(store Store, last BlockNode, tt *templateType, targs []Type) *DeclaredType {
+	td := tt.Decl.(*TypeDecl)
+	fn := tt.File
+	pn := packageOf(fn)
+	inst := typeArgsString(targs)
+	name := Name(fmt.Sprintf("%s[%s]", td.Name, inst))
+	tid := DeclaredTypeID(pn.PkgPath, Location{}, name)
+	ic := instContextOf(last)
+	if dt, ok := ic.pending[tid]; ok {
+		// recursive reference.
+		return dt
+	}
+	mfns, mds := genericMethodsOf(tt)
+	var bodies []func()
+	if t := store.GetTypeSafe(tid); t != nil {
+		// Already instantiated, possibly by a previous run: make
+		// sure the instances of its methods exist.
+		dt := t.(*DeclaredType)
+		ic.pending[tid] = dt
+		for i, md := range mds {
+			_, body := instantiateMethod(store, ic, mfns[i], md, targs)
+			if body != nil {
+				bodies = append(bodies, body)
+			}
+		}
+		delete(ic.pending, tid)
+		for _, body := range bodies {
+			ic.preprocessBody(body)
+		}
+		ic.flushBodies()
+		return dt
+	}
+	dt := &DeclaredType{
+		PkgPath:  pn.PkgPath,
+		Name:     name,
+		Base:     placeholderTypeOf(td.Type),
+		TypeArgs: targs,
+	}
+	ic.pending[tid] = dt
+	// Evaluate the base type in a block with the type parameters.
+	tb := &BlockStmt{}
+	tb.SetSpan(td.GetSpan())
+	tb.InitStaticBlock(tb, fn)
+	tb.SetLocation(Location{PkgPath: pn.PkgPath, File: fn.FileName, Span: td.GetSpan(), Inst: inst})
+	tb.SetAttribute(ATTR_GENERIC_CONTEXT, ic)
+	names := make([]Name, len(td.TypeParams))
+	for i := range td.TypeParams {
+		names[i] = td.TypeParams[i].Name
+	}
+	defineTypeParams(tb, names, targs)
+	bx := Preprocess(store, tb, copyWithSpans(td.Type)).(Expr)
+	bt := evalStaticType(store, tb, bx)
+	if _, ok := bt.(*InterfaceType); ok && len(mds) > 0 {
+		panic(fmt.Sprintf("invalid receiver type %s (base type is interface type)", td.Name))
+	}
+	dt.Base = baseOf(bt)
+	for i, md := range mds {
+		ifd, body := instantiateMethod(store, ic, mfns[i], md, targs)
+		dt.DefineMethod(ifd.GetAttribute(ATTR_GENERIC_FUNC).(*FuncValue))
+		if body != nil {
+			bodies = append(bodies, body)
+		}
+	}
+	dt.Seal()
+	store.SetType(dt)
+	delete(ic.pending, tid)
+	ic.preprocessBody(func() {
+		checkTypeArgs(store, tb, td.TypeParams, targs)
+	})
+	for _, body := range bodies {
+		ic.preprocessBody(body)
+	}
+	ic.flushBodies()
+	return dt
+}
+
+// instantiateMethod returns the instance of md, a method of a generic type
+// declared in file fn, for the type arguments targs.
+func instantiateMethod(store Store, ic *instContext, fn *FileNode, md *FuncDecl, targs []Type) (*FuncDecl, func()) {
+	_, indices := genericRecv(md)
+	if len(indices) != len(targs) {
+		panic(fmt.Sprintf("got %d type parameters in receiver of method %s but want %d",
+			len(indices), md.Name, len(targs)))
+	}
+	names := make([]Name, len(indices))
+	for i, ix := range indices {
+		nx, ok := ix.(*NameExpr)
+		if !ok {
+			panic(fmt.Sprintf("receiver type parameter %s of method %s must be an identifier",
+				ix, md.Name))
+		}
+		names[i] = nx.Name
+	}
+	return instantiateFuncDecl(store, ic, fn, md, names, targs)
+}
+
+// genericMethodsOf returns the methods of the generic type of tt, with the
+// files they are declared in.
+func genericMethodsOf(tt *templateType) (fns []*FileNode, mds []*FuncDecl) {
+	name := tt.Decl.(*TypeDecl).Name
+	files := []*FileNode{tt.File}
+	if fset := packageOf(tt.File).FileSet; fset != nil {
+		files = fset.Files
+	}
+	for _, fn := range files {
+		for _, d := range fn.Decls {
+			if fd, ok := d.(*FuncDecl); ok {
+				if rn, indices := genericRecv(fd); indices != nil && rn == name {
+					fns = append(fns, fn)
+					mds = append(mds, fd)
+				}
+			}
+		}
+	}
+	return
+}
+
+// defineTypeParams defines the type parameters names as the constant types
+// targs in bn.
+func defineTypeParams(bn BlockNode, names []Name, targs []Type) {
+	for i, name := range names {
+		bn.Define2(true, name, targs[i], asValue(targs[i]),
+			NameSource{&NameExpr{Name: name}, bn, NSTypeParam, i})
+	}
+}
+
+// checkTypeArgs checks that targs satisfy the constraints of tparams, which
+// are evaluated in bn where the type parameters are defined.
+func checkTypeArgs(store Store, bn BlockNode, tparams []FieldTypeExpr, targs []Type) {
+	for i := range tparams {
+		cx := Preprocess(store, bn, copyWithSpans(tparams[i].Type)).(Expr)
+		ct := evalStaticType(store, bn, cx)
+		if it, ok := baseOf(ct).(*InterfaceType); ok {
+			if err := it.VerifyImplementedBy(targs[i]); err != nil {
+				panic(fmt.Sprintf("%s does not satisfy %s: %v",
+					targs[i].String(), ct.String(), err))
+			}
+		} else if baseOf(targs[i]).TypeID() != baseOf(ct).TypeID() {
+			panic(fmt.Sprintf("%s does not satisfy ~%s",
+				targs[i].String(), ct.String()))
+		}
+	}
+}
+
+// findUndefinedGeneric returns the first name that the declaration of the
+// generic type or function x refers to, outside of function bodies, and that
+// is not yet predefined, so that it can be predefined before x gets
+// instantiated. Only generic declarations of the package of last are
+// considered, as imported packages are already predefined.
+func findUndefinedGeneric(store Store, last BlockNode, x Expr, defining map[Name]struct{}) Name {
+	nx, ok := x.(*NameExpr)
+	if !ok {
+		return ""
+	}
+	tt, ok := last.GetStaticTypeOf(store, nx.Name).(*templateType)
+	if !ok {
+		return ""
+	}
+	pn := packageOf(tt.File)
+	skip := map[Name]struct{}{}
+	var xs []Expr
+	switch d := tt.Decl.(type) {
+	case *TypeDecl:
+		for _, tp := range d.TypeParams {
+			skip[tp.Name] = struct{}{}
+			xs = append(xs, tp.Type)
+		}
+		xs = append(xs, d.Type)
+		_, mds := genericMethodsOf(tt)
+		for _, md := range mds {
+			_, indices := genericRecv(md)
+			for _, ix := range indices {
+				if nx, ok := ix.(*NameExpr); ok {
+					skip[nx.Name] = struct{}{}
+				}
+			}
+			xs = append(xs, &md.Type)
+		}
+	case *FuncDecl:
+		for _, tp := range d.TypeParams {
+			skip[tp.Name] = struct{}{}
+			xs = append(xs, tp.Type)
+		}
+		xs = append(xs, &d.Type)
+	}
+	var un Name
+	for _, x := range xs {
+		Transcribe(x, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+			if un != "" {
+				return n, TRANS_EXIT
+			}
+			if nx, ok := n.(*NameExpr); ok && stage == TRANS_ENTER {
+				if _, ok := skip[nx.Name]; ok {
+					return n, TRANS_CONTINUE
+				}
+				if _, ok := defining[nx.Name]; ok {
+					return n, TRANS_CONTINUE
+				}
+				if idx, ok := pn.GetLocalIndex(nx.Name); ok && pn.Types[idx] == nil {
+					un = nx.Name
+					return n, TRANS_EXIT
+				}
+			}
+			return n, TRANS_CONTINUE
+		})
+		if un != "" {
+			return un
+		}
+	}
+	return ""
+}
+
+// placeholderTypeOf returns an empty type of the kind of type expression x,
+// to be the base of an instantiated type while its base is being evaluated.
+func placeholderTypeOf(x Expr) Type {
+	switch x.(type) {
+	case *FuncTypeExpr:
+		return &FuncType{}
+	case *ArrayTypeExpr:
+		return &ArrayType{}
+	case *SliceTypeExpr:
+		return &SliceType{}
+	case *InterfaceTypeExpr:
+		return &InterfaceType{}
+	case *MapTypeExpr:
+		return &MapType{}
+	case *ChanTypeExpr:
+		return &ChanType{}
+	case *StarExpr:
+		return &PointerType{}
+	default:
+		return &StructType{}
+	}
+}
+
+// typeArgsString returns the string of type arguments targs, as used in the
+// names of instances.
+func typeArgsString(targs []Type) string {
+	ss := make([]string, len(targs))
+	for i, targ := range targs {
+		ss[i] = targ.TypeID().String()
+	}
+	s := strings.Join(ss, ",")
+	if len(s) > maxGenericInstLen {
+		panic(fmt.Sprintf("type arguments of generic instance exceed %d bytes: %s...",
+			maxGenericInstLen, s[:64]))
+	}
+	return s
+}
+
+// copyWithSpans is like n.Copy(), but also copies the spans of all nodes,
+// for the locations and error messages of instances.
+func copyWithSpans(n Node) Node {
+	var spans []Span
+	Transcribe(n, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage == TRANS_ENTER {
+			spans = append(spans, n.GetSpan())
+		}
+		return n, TRANS_CONTINUE
+	})
+	c := n.Copy()
+	i := 0
+	Transcribe(c, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage == TRANS_ENTER {
+			if i >= len(spans) {
+				panic("should not happen")
+			}
+			n.SetSpan(spans[i])
+			i++
+		}
+		return n, TRANS_CONTINUE
+	})
+	if i != len(spans) {
+		panic("should not happen")
+	}
+	return c
+}
+
+// setInstLocations sets the locations of the block nodes of instance n.
+func setInstLocations(pkgPath string, fileName string, inst string, n Node) {
+	Transcribe(n, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if bn, ok := n.(BlockNode); ok && stage == TRANS_ENTER {
+			bn.SetLocation(Location{
+				PkgPath: pkgPath,
+				File:    fileName,
+				Span:    bn.GetSpan(),
+				Inst:    inst,
+			})
+		}
+		return n, TRANS_CONTINUE
+	})
+}
@@ -268,14 +268,10 @@ func setSpanFromLeftChild(fs *token.FileSet, gon ast.Node, n Node, leftChild Nod
 }
 
 // setSpanFromRightChild bypasses the rightward End() recursion of
-// End-recursive AST types (StarExpr, UnaryExpr, ArrayType, MapType —
-// stdlib defines their End() as <rightField>.End()). Pos is taken
-// from gon.Pos() (O(1) for these four types: Star / OpPos / Lbrack /
-// Map), End from the translated right child's Span.
-//
-// ChanType is also End-recursive in stdlib but is rejected at parse
-// time by Go2Gno (panicWithPos("channels are not permitted")), so
-// the chain cannot be constructed; no gate for it.
+// End-recursive AST types (StarExpr, UnaryExpr, ArrayType, MapType,
+// ChanType — stdlib defines their End() as <rightField>.End()). Pos is taken
+// from gon.Pos() (O(1) for these five types: Star / OpPos / Lbrack /
+// Map / Begin), End from the translated right child's Span.
 //
 // CALLER must gate on the recursing field being the same Go AST type
 // as `gon`.
@@ -512,10 +508,26 @@ func Go2Gno(fs *token.FileSet, gon ast.Node, fileComments []*ast.CommentGroup) (
 		}
 	case *ast.InterfaceType:
 		return &InterfaceTypeExpr{
-			Methods: toFieldsFromList(fs, gon.Methods),
+			Methods: toInterfaceElems(fs, gon.Methods),
 		}
 	case *ast.ChanType:
-		panicWithPos("channels are not permitted")
+		var dir ChanDir
+		switch gon.Dir {
+		case ast.SEND:
+			dir = SEND
+		case ast.RECV:
+			dir = RECV
+		default:
+			dir = BOTH
+		}
+		cx := &ChanTypeExpr{
+			Dir:   dir,
+			Value: toExpr(fs, gon.Value),
+		}
+		if _, chained := gon.Value.(*ast.ChanType); chained {
+			setSpanFromRightChild(fs, gon, cx, cx.Value)
+		}
+		return cx
 	case *ast.FuncType:
 		return &FuncTypeExpr{
 			Params:  toFieldsFromList(fs, gon.Params),
@@ -668,11 +680,12 @@ func Go2Gno(fs *token.FileSet, gon ast.Node, fileComments []*ast.CommentGroup) (
 			body = Go2Gno(fs, gon.Body, fileComments).(*BlockStmt).Body
 		}
 		fd := &FuncDecl{
-			IsMethod: isMethod,
-			Recv:     recv,
-			NameExpr: NameExpr{Name: name},
-			Type:     *type_,
-			Body:     body,
+			IsMethod:   isMethod,
+			Recv:       recv,
+			NameExpr:   NameExpr{Name: name},
+			TypeParams: toTypeParams(fs, gon.Type.TypeParams),
+			Type:       *type_,
+			Body:       body,
 		}
 		if gon.Body != nil && strings.HasPrefix(gon.Name.Name, "Example") && fileComments != nil {
 			output, unordered, hasOutput := exampleOutput(gon.Body, fileComments)
@@ -702,16 +715,25 @@ func Go2Gno(fs *token.FileSet, gon ast.Node, fileComments []*ast.CommentGroup) (
 	case *ast.EmptyStmt:
 		return &EmptyStmt{}
 	case *ast.IndexListExpr:
-		if len(gon.Indices) > 1 {
-			panicWithPos("invalid operation: more than one index")
+		ix := &IndexListExpr{
+			X:       toExpr(fs, gon.X),
+			Indices: toExprs(fs, gon.Indices),
 		}
-		panicWithPos("invalid operation: indexList is not permitted in Gno")
+		return ix
 	case *ast.GoStmt:
-		panicWithPos("goroutines are not permitted")
+		cx := toExpr(fs, gon.Call).(*CallExpr)
+		return &GoStmt{
+			Call: *cx,
+		}
 	case *ast.SendStmt:
-		panicWithPos("send statements are not permitted")
+		return &SendStmt{
+			Chan:  toExpr(fs, gon.Chan),
+			Value: toExpr(fs, gon.Value),
+		}
 	case *ast.SelectStmt:
-		panicWithPos("select statements are not permitted")
+		return &SelectStmt{
+			Cases: toSelectCases(fs, gon.Body.List),
+		}
 	default:
 		panicWithPos("unknown Go type %v: %s\n",
 			reflect.TypeOf(gon),
@@ -884,9 +906,10 @@ func toDecls(fs *token.FileSet, gd *ast.GenDecl) (ds Decls) {
 			tipe := toExpr(fs, s.Type)
 			alias := s.Assign != 0
 			td := &TypeDecl{
-				NameExpr: NameExpr{Name: name},
-				Type:     tipe,
-				IsAlias:  alias,
+				NameExpr:   NameExpr{Name: name},
+				TypeParams: toTypeParams(fs, s.TypeParams),
+				Type:       tipe,
+				IsAlias:    alias,
 			}
 			setSpan(fs, s, td)
 			ds = append(ds, td)
@@ -1017,6 +1040,59 @@ func toFields(fs *token.FileSet, fields ...*ast.Field) (ftxs []FieldTypeExpr) {
 	return
 }
 
+// toTypeParams converts a type parameter list. Gno instantiates generic
+// declarations for each distinct list of type arguments, so constraints are
+// only used to check method sets and core types (~T); other type set terms
+// (unions and comparable) are left to the Go type checker and dropped here.
+func toTypeParams(fs *token.FileSet, fl *ast.FieldList) (ftxs []FieldTypeExpr) {
+	if fl == nil {
+		return nil
+	}
+	ftxs = toFields(fs, fl.List...)
+	for i := range ftxs {
+		ftxs[i].Type = toConstraint(ftxs[i].Type)
+	}
+	return
+}
+
+func toConstraint(x Expr) Expr {
+	switch cx := x.(type) {
+	case *UnaryExpr:
+		// ~T, the core type.
+		return cx.X
+	case *BinaryExpr:
+		// T1 | T2.
+		return &InterfaceTypeExpr{}
+	case *NameExpr:
+		if cx.Name == "comparable" {
+			return &InterfaceTypeExpr{}
+		}
+	}
+	return x
+}
+
+// toInterfaceElems converts the method list of an interface type, dropping
+// the type set elements that may only appear in constraints.
+func toInterfaceElems(fs *token.FileSet, fl *ast.FieldList) (ftxs []FieldTypeExpr) {
+	if fl == nil {
+		return nil
+	}
+	for _, f := range fl.List {
+		if len(f.Names) == 0 {
+			switch ft := f.Type.(type) {
+			case *ast.BinaryExpr, *ast.UnaryExpr:
+				continue
+			case *ast.Ident:
+				if ft.Name == "comparable" {
+					continue
+				}
+			}
+		}
+		ftxs = append(ftxs, toFields(fs, f)...)
+	}
+	return
+}
+
 func toKeyValueExprs(fs *token.FileSet, elts []ast.Expr) (kvxs KeyValueExprs) {
 	kvxs = make([]KeyValueExpr, len(elts))
 	for i, x := range elts {
@@ -1054,6 +1130,27 @@ func toClauses(fs *token.FileSet, csz []ast.Stmt) []SwitchClauseStmt {
 	return res
 }
 
+func toSelectCases(fs *token.FileSet, csz []ast.Stmt) []SelectCaseStmt {
+	res := make([]SelectCaseStmt, 0, len(csz))
+	sawDefault := false
+	for _, cs := range csz {
+		cc := cs.(*ast.CommClause)
+		if cc.Comm == nil {
+			if sawDefault {
+				panic("multiple defaults in select")
+			}
+			sawDefault = true
+		}
+		scs := SelectCaseStmt{
+			Comm: toStmt(fs, cc.Comm),
+			Body: toStmts(fs, cc.Body),
+		}
+		setSpan(fs, cc, &scs)
+		res = append(res, scs)
+	}
+	return res
+}
+
 func toSwitchClauseStmt(fs *token.FileSet, cc *ast.CaseClause) SwitchClauseStmt {
 	scs := SwitchClauseStmt{
 		Cases: toExprs(fs, cc.List),
@@ -193,9 +193,13 @@ func TestSpan(t *testing.T) {
 			3, 7, 3, 19,
 		},
 
-		// ── *ast.ChanType — n/a on this branch: rejected at parse
-		// time via `panicWithPos("channels are not permitted")`,
-		// so a ChanType chain cannot be constructed by Go2Gno.
+		// ── *ast.ChanType (End-recursive) ───────────────────────────
+		// Gate: gon.Value is *ast.ChanType.
+		{
+			"chantype/chain",
+			"package main\n\nvar x chan chan int\n",
+			3, 7, 3, 20,
+		},
 
 		// ── *ast.MapType (End-recursive) ────────────────────────────
 		// Gate: gon.Value is *ast.MapType.
@@ -51,6 +51,10 @@ func go2GnoType(rt reflect.Type) Type {
 		return Float32Type
 	case reflect.Float64:
 		return Float64Type
+	case reflect.Complex64:
+		return Complex64Type
+	case reflect.Complex128:
+		return Complex128Type
 	case reflect.Array:
 		return &ArrayType{
 			Elt: go2GnoType(rt.Elem()),
@@ -114,6 +118,14 @@ func Go2GnoValue(alloc *Allocator, store Store, rv reflect.Value) (tv TypedValue
 		tv.SetFloat32(softfloat.F64to32(math.Float64bits(rv.Float())))
 	case reflect.Float64:
 		tv.SetFloat64(math.Float64bits(rv.Float()))
+	case reflect.Complex64:
+		c := rv.Complex()
+		tv.SetComplex64(
+			softfloat.F64to32(math.Float64bits(real(c))),
+			softfloat.F64to32(math.Float64bits(imag(c))))
+	case reflect.Complex128:
+		c := rv.Complex()
+		tv.SetComplex128(math.Float64bits(real(c)), math.Float64bits(imag(c)))
 	case reflect.Array:
 		rvl := rv.Len()
 		if rv.Type().Elem().Kind() == reflect.Uint8 {
@@ -186,10 +198,16 @@ func gno2GoType(t Type) reflect.Type {
 			return reflect.TypeFor[float32]()
 		case Float64Type:
 			return reflect.TypeFor[float64]()
+		case Complex64Type:
+			return reflect.TypeFor[complex64]()
+		case Complex128Type:
+			return reflect.TypeFor[complex128]()
 		case UntypedBigintType:
 			panic("not yet implemented")
 		case UntypedBigdecType:
 			panic("not yet implemented")
+		case UntypedBigcomplexType:
+			panic("not yet implemented")
 		default:
 			panic("should not happen")
 		}
@@ -284,6 +302,13 @@ func Gno2GoValue(tv *TypedValue, rv reflect.Value) (ret reflect.Value) {
 			rv.SetFloat(math.Float64frombits(softfloat.F32to64(tv.GetFloat32())))
 		case Float64Type:
 			rv.SetFloat(math.Float64frombits(tv.GetFloat64()))
+		case Complex64Type:
+			re, im := tv.GetComplex64()
+			rv.SetComplex(complex(
+				math.Float64frombits(softfloat.F32to64(re)),
+				math.Float64frombits(softfloat.F32to64(im))))
+		case Complex128Type:
+			rv.SetComplex(complex128Of(tv.GetComplex128()))
 		default:
 			panic(fmt.Sprintf(
 				"unexpected type %s",
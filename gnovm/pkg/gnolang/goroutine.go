@@ -0,0 +1,349 @@
+package gnolang
+
+// ----------------------------------------
+// Goroutines
+//
+// The machine runs goroutines one at a time. Control only changes hands
+// when the running goroutine blocks on a channel operation (send, receive,
+// select, or range over a channel) or exits, and runnable goroutines are
+// resumed in FIFO order. Scheduling is thus cooperative and fully
+// deterministic: every validator executes the same interleaving, and
+// charges the same gas.
+//
+// The main goroutine is whichever goroutine was running when the first go
+// statement executed. Like a Go program returning from main, once the main
+// goroutine halts any remaining goroutines are abandoned. When every
+// goroutine is blocked, the machine panics with a deadlock error.
+
+// Goroutine holds the execution state of a goroutine while it is not
+// running. The state of the running goroutine lives on the Machine.
+type Goroutine struct {
+	ID int64
+
+	ops        []Op
+	values     []TypedValue
+	exprs      []Expr
+	stmts      []Stmt
+	blocks     []*Block
+	frames     []Frame
+	pkg        *PackageValue
+	realm      *Realm
+	inView     bool
+	exception  *Exception
+	numResults int
+	lastline   int
+
+	// Set while blocked on a channel operation, and until the blocking
+	// op resumes (see takeWait).
+	wait *waitState
+}
+
+// scheduler tracks the goroutines of a machine.
+type scheduler struct {
+	cur    *Goroutine   // running goroutine; nil until the first go statement
+	main   *Goroutine   // goroutine that was running at the first go statement
+	runq   []*Goroutine // runnable goroutines, FIFO
+	all    []*Goroutine // live goroutines, in creation order
+	nextID int64
+}
+
+// waitState records a goroutine blocked on one or more channel operations
+// (more than one for a select), and the outcome of the operation that
+// completed it.
+type waitState struct {
+	g     *Goroutine
+	items []*waitItem // one per channel operation
+
+	done   bool
+	index  int        // index of the completed operation
+	value  TypedValue // received value
+	ok     bool       // false if received because the channel was closed
+	closed bool       // true if a send was aborted because the channel was closed
+}
+
+// waitItem is an entry in a channel's send or receive queue.
+type waitItem struct {
+	ws    *waitState
+	ch    *ChanValue
+	index int        // select case index; zero otherwise
+	value TypedValue // value to send; unused for receives
+}
+
+const deadlockError = "fatal error: all goroutines are asleep - deadlock!"
+
+// curGoroutine returns the running goroutine, creating the main goroutine
+// on first use.
+func (m *Machine) curGoroutine() *Goroutine {
+	if m.sched.cur == nil {
+		m.sched.nextID++
+		g := &Goroutine{ID: m.sched.nextID}
+		m.sched.cur = g
+		m.sched.main = g
+		m.sched.all = append(m.sched.all, g)
+	}
+	return m.sched.cur
+}
+
+// startGoroutine creates a runnable goroutine which calls cx, with the
+// function value and arguments already evaluated in vals.
+func (m *Machine) startGoroutine(cx *CallExpr, vals []TypedValue) *Goroutine {
+	m.curGoroutine()
+	m.sched.nextID++
+	g := &Goroutine{
+		ID: m.sched.nextID,
+		// OpGoexit stays at the bottom of the stack; when reached, the
+		// function has returned and the goroutine is done.
+		ops:    []Op{OpGoexit, OpPrecall},
+		values: vals,
+		exprs:  []Expr{cx},
+		// Not used by the call itself, which gets its own block, but
+		// keeps m.LastBlock() valid for the goroutine's whole lifetime.
+		blocks:   []*Block{m.LastBlock()},
+		pkg:      m.Package,
+		realm:    m.Realm,
+		inView:   m.InView,
+		lastline: m.Lastline,
+	}
+	m.sched.all = append(m.sched.all, g)
+	m.sched.runq = append(m.sched.runq, g)
+	return g
+}
+
+func (m *Machine) saveGoroutine(g *Goroutine) {
+	g.ops = m.Ops
+	g.values = m.Values
+	g.exprs = m.Exprs
+	g.stmts = m.Stmts
+	g.blocks = m.Blocks
+	g.frames = m.Frames
+	g.pkg = m.Package
+	g.realm = m.Realm
+	g.inView = m.InView
+	g.exception = m.Exception
+	g.numResults = m.NumResults
+	g.lastline = m.Lastline
+}
+
+func (m *Machine) loadGoroutine(g *Goroutine) {
+	m.Ops = g.ops
+	m.Values = g.values
+	m.Exprs = g.exprs
+	m.Stmts = g.stmts
+	m.Blocks = g.blocks
+	m.Frames = g.frames
+	m.Package = g.pkg
+	m.Realm = g.realm
+	m.InView = g.inView
+	m.Exception = g.exception
+	m.NumResults = g.numResults
+	m.Lastline = g.lastline
+	// The machine owns the stacks now.
+	*g = Goroutine{ID: g.ID, wait: g.wait}
+	m.sched.cur = g
+}
+
+// schedule suspends the running goroutine and resumes the next runnable
+// one. The running goroutine must either be blocked or have exited.
+func (m *Machine) schedule() {
+	m.incrCPU(OpCPUSchedule)
+	if len(m.sched.runq) == 0 {
+		panic(deadlockError)
+	}
+	next := m.sched.runq[0]
+	m.sched.runq[0] = nil
+	m.sched.runq = m.sched.runq[1:]
+	if cur := m.sched.cur; cur != nil {
+		m.saveGoroutine(cur)
+	}
+	m.loadGoroutine(next)
+}
+
+// goexit terminates the running goroutine, which must not be the main
+// goroutine, and resumes the next runnable one.
+func (m *Machine) goexit() {
+	g := m.sched.cur
+	if g == nil || g == m.sched.main {
+		panic("should not happen")
+	}
+	for i, og := range m.sched.all {
+		if og == g {
+			m.sched.all = append(m.sched.all[:i], m.sched.all[i+1:]...)
+			break
+		}
+	}
+	m.sched.cur = nil
+	m.schedule()
+}
+
+// block parks the running goroutine until ws is completed by another
+// goroutine, and resumes the next runnable one. Before blocking, the
+// caller must re-push its own op, which then finishes via takeWait once
+// the goroutine is resumed.
+func (m *Machine) block(ws *waitState) {
+	g := m.curGoroutine()
+	ws.g = g
+	g.wait = ws
+	m.schedule()
+}
+
+// takeWait returns the completed wait state of the running goroutine if it
+// was just resumed from a blocking channel operation, or nil otherwise.
+func (m *Machine) takeWait() *waitState {
+	g := m.sched.cur
+	if g == nil || g.wait == nil {
+		return nil
+	}
+	ws := g.wait
+	if !ws.done {
+		panic("should not happen")
+	}
+	g.wait = nil
+	return ws
+}
+
+// wake completes the wait state of wi, which must already be removed from
+// its channel's queue, and makes its goroutine runnable.
+func (m *Machine) wake(wi *waitItem, value TypedValue, ok bool) {
+	ws := wi.ws
+	ws.done = true
+	ws.index = wi.index
+	ws.value = value
+	ws.ok = ok
+	// Withdraw the goroutine's other operations, if in a select.
+	for _, other := range ws.items {
+		if other != wi {
+			other.ch.dequeue(other)
+		}
+	}
+	m.sched.runq = append(m.sched.runq, ws.g)
+}
+
+// resetGoroutines restores the main goroutine's stacks onto the machine,
+// discarding all other goroutines.
+func (m *Machine) resetGoroutines() {
+	if main := m.sched.main; main != nil && m.sched.cur != main {
+		m.loadGoroutine(main)
+	}
+	m.sched = scheduler{}
+}
+
+func (g *Goroutine) visit(alloc *Allocator, vis Visitor) (stop bool) {
+	for _, block := range g.blocks {
+		if block == nil {
+			continue
+		}
+		if stop = vis(block); stop {
+			return
+		}
+	}
+	for i := range g.frames {
+		if stop = g.frames[i].Visit(alloc, vis); stop {
+			return
+		}
+	}
+	for e := g.exception; e != nil; e = e.Previous {
+		if stop = e.Visit(alloc, vis); stop {
+			return
+		}
+	}
+	return
+}
+
+// ----------------------------------------
+// Channel operations
+
+func popWaitItem(q *[]*waitItem) *waitItem {
+	if len(*q) == 0 {
+		return nil
+	}
+	wi := (*q)[0]
+	(*q)[0] = nil
+	*q = (*q)[1:]
+	return wi
+}
+
+func removeWaitItem(q []*waitItem, wi *waitItem) []*waitItem {
+	for i, qi := range q {
+		if qi == wi {
+			return append(q[:i], q[i+1:]...)
+		}
+	}
+	return q
+}
+
+func (cv *ChanValue) dequeue(wi *waitItem) {
+	cv.recvq = removeWaitItem(cv.recvq, wi)
+	cv.sendq = removeWaitItem(cv.sendq, wi)
+}
+
+// trySend sends v on cv if that can be done without blocking, handing it
+// directly to a blocked receiver if there is one.
+// The caller must check that cv is not closed.
+func (m *Machine) trySend(cv *ChanValue, v TypedValue) bool {
+	if wi := popWaitItem(&cv.recvq); wi != nil {
+		m.wake(wi, v, true)
+		return true
+	}
+	if len(cv.Buffer) < cv.Cap {
+		cv.Buffer = append(cv.Buffer, v)
+		return true
+	}
+	return false
+}
+
+// tryRecv receives from cv if that can be done without blocking. If cv is
+// closed and drained, it returns ok == false, and the caller is
+// responsible for producing the zero value.
+func (m *Machine) tryRecv(cv *ChanValue) (v TypedValue, ok bool, done bool) {
+	if n := len(cv.Buffer); n > 0 {
+		v = cv.Buffer[0]
+		copy(cv.Buffer, cv.Buffer[1:])
+		cv.Buffer[n-1] = TypedValue{}
+		cv.Buffer = cv.Buffer[:n-1]
+		// Room was made for a blocked sender.
+		if wi := popWaitItem(&cv.sendq); wi != nil {
+			cv.Buffer = append(cv.Buffer, wi.value)
+			m.wake(wi, TypedValue{}, false)
+		}
+		return v, true, true
+	}
+	if wi := popWaitItem(&cv.sendq); wi != nil {
+		m.wake(wi, TypedValue{}, false)
+		return wi.value, true, true
+	}
+	if cv.Closed {
+		return TypedValue{}, false, true
+	}
+	return TypedValue{}, false, false
+}
+
+// closeChan closes cv, waking all blocked receivers (with the zero value)
+// and all blocked senders (which then panic).
+func (m *Machine) closeChan(cv *ChanValue) {
+	if cv == nil {
+		m.Panic(typedRuntimeError("close of nil channel"))
+	}
+	if cv.Closed {
+		m.Panic(typedRuntimeError("close of closed channel"))
+	}
+	cv.Closed = true
+	for wi := popWaitItem(&cv.recvq); wi != nil; wi = popWaitItem(&cv.recvq) {
+		m.wake(wi, TypedValue{}, false)
+	}
+	for wi := popWaitItem(&cv.sendq); wi != nil; wi = popWaitItem(&cv.sendq) {
+		wi.ws.closed = true
+		m.wake(wi, TypedValue{}, false)
+	}
+}
+
+func (ws *waitState) enqueueSend(cv *ChanValue, index int, v TypedValue) {
+	wi := &waitItem{ws: ws, ch: cv, index: index, value: v}
+	ws.items = append(ws.items, wi)
+	cv.sendq = append(cv.sendq, wi)
+}
+
+func (ws *waitState) enqueueRecv(cv *ChanValue, index int) {
+	wi := &waitItem{ws: ws, ch: cv, index: index}
+	ws.items = append(ws.items, wi)
+	cv.recvq = append(cv.recvq, wi)
+}
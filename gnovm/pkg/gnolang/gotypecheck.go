@@ -151,6 +151,10 @@ type TypeCheckOptions struct {
 	// After TypeCheckMemPackage returns, it contains the file position
 	// information from the parsed package.
 	Fset *token.FileSet
+
+	// AllowConcurrency permits goroutines, channels and select statements.
+	// Off-chain tooling enables it; on-chain it is gated by a VM param.
+	AllowConcurrency bool
 }
 
 // TypeCheckMemPackage performs type validation and checking on the given
@@ -175,18 +179,19 @@ func TypeCheckMemPackage(mpkg *std.MemPackage, opts TypeCheckOptions) (
 		cache:     map[string]*gnoImporterResult{},
 		permCache: opts.Cache,
 		fset:      opts.Fset,
+		allowConc: opts.AllowConcurrency,
 		cfg: &types.Config{
 			// Pin the accepted Go language version. Left empty, go/types gates
 			// syntax at whatever version the validator binary was built with,
 			// so the accept/reject verdict (and its error text) becomes a
 			// function of the build, not the package — a consensus fork. This
 			// is a syntax-acceptance floor only, NOT Gno's runtime semantics:
-			// Gno matches no single Go version (no generics, like <go1.18, yet
-			// go1.22 per-iteration loopvars, implemented in the interpreter
+			// Gno matches no single Go version (go1.18 generics, yet go1.22
+			// per-iteration loopvars, implemented in the interpreter
 			// regardless of this value). go1.18 is the minimum the injected
-			// .gnobuiltins shim needs (it uses any/type params) and rejects
-			// features Gno can't run (min/max, range-over-int/func) here rather
-			// than downstream.
+			// .gnobuiltins shim and generic packages need (any/type params)
+			// and rejects features Gno can't run (min/max, range-over-int/func)
+			// here rather than downstream.
 			GoVersion: "go1.18",
 			Error: func(err error) {
 				gimp.Error(err)
@@ -220,6 +225,7 @@ type gnoImporter struct {
 	cache     map[string]*gnoImporterResult
 	permCache TypeCheckCache
 	fset      *token.FileSet // if non-nil, used for Go parsing instead of creating a new one.
+	allowConc bool           // if false, reject goroutines, channels and select.
 	cfg       *types.Config
 	errors    []error  // there may be many for a single import
 	stack     []string // stack of pkgpaths for cyclic import detection
@@ -400,6 +406,82 @@ func prepareGoGno0p9(f *ast.File) (err error) {
 	return err
 }
 
+// checkNoConcurrency returns an error for each goroutine, channel type and
+// select statement in f.
+func checkNoConcurrency(fset *token.FileSet, f *ast.File) (errs []error) {
+	ast.Inspect(f, func(n ast.Node) bool {
+		var msg string
+		switch n.(type) {
+		case *ast.GoStmt:
+			msg = "goroutines are not permitted"
+		case *ast.ChanType:
+			msg = "channels are not permitted"
+		case *ast.SelectStmt:
+			msg = "select statements are not permitted"
+		default:
+			return true
+		}
+		errs = append(errs, types.Error{Fset: fset, Pos: n.Pos(), Msg: msg})
+		return true
+	})
+	return
+}
+
+// checkNoRealmChanVars returns an error for each package-level variable of
+// the realm package pkg whose type can hold a channel: realm variables are
+// persisted, while channels only live for the duration of a transaction.
+func checkNoRealmChanVars(fset *token.FileSet, pkg *types.Package) (errs []error) {
+	scope := pkg.Scope()
+	for _, name := range scope.Names() {
+		v, ok := scope.Lookup(name).(*types.Var)
+		if !ok || !holdsChan(v.Type(), nil) {
+			continue
+		}
+		errs = append(errs, types.Error{
+			Fset: fset,
+			Pos:  v.Pos(),
+			Msg: fmt.Sprintf("cannot declare realm variable %s of type %s: "+
+				"channels cannot be persisted", name, v.Type()),
+		})
+	}
+	return
+}
+
+// holdsChan returns true if a value of type t can hold a channel, other than
+// through an interface or a function.
+func holdsChan(t types.Type, seen map[*types.Named]bool) bool {
+	switch t := t.(type) {
+	case *types.Chan:
+		return true
+	case *types.Named:
+		if seen[t] {
+			return false
+		}
+		if seen == nil {
+			seen = map[*types.Named]bool{}
+		}
+		seen[t] = true
+		return holdsChan(t.Underlying(), seen)
+	case *types.Pointer:
+		return holdsChan(t.Elem(), seen)
+	case *types.Array:
+		return holdsChan(t.Elem(), seen)
+	case *types.Slice:
+		return holdsChan(t.Elem(), seen)
+	case *types.Map:
+		return holdsChan(t.Key(), seen) || holdsChan(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if holdsChan(t.Field(i).Type(), seen) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
 // Assumes that the code is Gno 0.9.
 // If not, first use `gno lint` to transpile the code.
 // Returns parsed *types.Package, *token.FileSet, []*ast.File.
@@ -450,6 +532,17 @@ func (gimp *gnoImporter) typeCheckMemPackage(mpkg *std.MemPackage, wtests *bool)
 		}
 	}
 
+	// STEP 3: Reject concurrency unless allowed.
+	if !gimp.allowConc {
+		var cerrs []error
+		for _, gof := range allgofs {
+			cerrs = append(cerrs, checkNoConcurrency(gofset, gof)...)
+		}
+		if len(cerrs) > 0 {
+			return nil, multierr.Combine(cerrs...)
+		}
+	}
+
 	// STEP 3: Add and Parse .gnobuiltins.go file.
 	file := makeGnoBuiltins(mpkg.Name, gnoVersion)
 	const parseOpts = parser.ParseComments |
@@ -484,6 +577,12 @@ func (gimp *gnoImporter) typeCheckMemPackage(mpkg *std.MemPackage, wtests *bool)
 		errs = multierr.Combine(gimp.errors[numErrs:]...)
 		return
 	}
+	// STEP 4: Reject realm variables which can hold channels.
+	if IsRealmPath(mpkg.Path) {
+		if cerrs := checkNoRealmChanVars(gofset, pkg); len(cerrs) > 0 {
+			return nil, multierr.Combine(cerrs...)
+		}
+	}
 	if wtests != nil && !*wtests {
 		errs = multierr.Combine(gimp.errors[numErrs:]...)
 		if errs != nil {
@@ -42,9 +42,13 @@ type Machine struct {
 	GCCycle       int64         // number of "gc" cycles
 	Stage         Stage         // pre for static eval, add for package init, run otherwise
 	ReviveEnabled bool          // true if revive() enabled (only in testing mode for now)
+	InView        bool          // true while running a read-only view; see EnterView
 	Lastline      int           // the line the VM is currently executing
+	sched         scheduler     // goroutines; see goroutine.go
 
 	Debugger Debugger
+	Coverage *Coverage // if set, records executed statements; see coverage.go
+	Profiler *Profiler // if set, records gas and allocations; see profiler.go
 
 	// Configuration
 	Output   io.Writer
@@ -55,6 +59,9 @@ type Machine struct {
 	// bounded printer (see bounded_strings.go). True on validator-
 	// side Machines; false for filetests, REPL, etc.
 	BoundedPanicRender bool
+	// DisallowConcurrency rejects goroutines and channels at run time;
+	// see MachineOptions.DisallowConcurrency.
+	DisallowConcurrency bool
 }
 
 // NewMachine initializes a new gno virtual machine, acting as a shorthand
@@ -79,6 +86,8 @@ type MachineOptions struct {
 	// Active package of the given machine; must be set before execution.
 	PkgPath       string
 	Debug         bool
+	Coverage      *Coverage // records executed statements, for gno test -cover
+	Profiler      *Profiler // records gas and allocations; see Machine.SetProfiler
 	Input         io.Reader // used for default debugger input only
 	Output        io.Writer // default os.Stdout
 	Store         Store     // default NewStore(Alloc, nil, nil)
@@ -96,6 +105,13 @@ type MachineOptions struct {
 	// validator-side Machine; default false preserves the existing
 	// verbose render for filetests, REPL, and other trusted contexts.
 	BoundedPanicRender bool
+	// DisallowConcurrency, when true, makes starting a goroutine or making a
+	// channel panic. Type-checking rejects them already unless
+	// TypeCheckOptions.AllowConcurrency is set, but code checked while
+	// they were allowed may still run after they no longer are. Every
+	// other channel operation needs a channel, or blocks forever on a nil
+	// one, so these two are enough.
+	DisallowConcurrency bool
 }
 
 const (
@@ -200,8 +216,11 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	mm.Debugger.enabled = opts.Debug
 	mm.Debugger.in = opts.Input
 	mm.Debugger.out = output
+	mm.Coverage = opts.Coverage
 	mm.ReviveEnabled = opts.ReviveEnabled
 	mm.BoundedPanicRender = opts.BoundedPanicRender
+	mm.DisallowConcurrency = opts.DisallowConcurrency
+	mm.SetProfiler(opts.Profiler)
 	// Maybe get/set package and realm.
 	if !opts.SkipPackage && opts.PkgPath != "" {
 		pv := (*PackageValue)(nil)
@@ -226,6 +245,14 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 // and m should not be used after this call. Only Machines initialized with this
 // package's constructors should be released.
 func (m *Machine) Release() {
+	// the stacks below must be the main goroutine's
+	m.resetGoroutines()
+
+	if m.Profiler != nil {
+		m.Alloc.SetProfiler(nil)
+		m.Profiler.detach(m)
+	}
+
 	// here we zero in the values for the next user
 	ops := m.Ops[:0:startingOpsCap]
 	values := m.Values[:0:startingValuesCap]
@@ -323,9 +350,16 @@ func assertBorrowedRealm(pkgPath string, r *Realm) {
 // Upon restart, preprocess all MemPackage and save blocknodes.
 // This is a temporary measure until we optimize/make-lazy.
 //
+// Packages are preprocessed in index order, each only once and after the
+// stored packages it imports: a path is indexed again when its code is
+// replaced (a private realm redeploy or a realm upgrade), and the new code
+// may import packages indexed after the original.
+//
 // NOTE: package paths not beginning with gno.land will be allowed to override,
 // to support cases of stdlibs processed through [RunMemPackagesWithOverrides].
 func (m *Machine) PreprocessAllFilesAndSaveBlockNodes() {
+	var paths []string
+	mpkgs := make(map[string]*std.MemPackage)
 	ch := m.Store.IterMemPackage()
 	for mpkg := range ch {
 		if mpkg == nil {
@@ -339,30 +373,62 @@ func (m *Machine) PreprocessAllFilesAndSaveBlockNodes() {
 			// stores.
 			continue
 		}
-		mpkg = MPFProd.FilterMemPackage(mpkg)
-		fset := m.ParseMemPackage(mpkg)
-		pn := NewPackageNode(Name(mpkg.Name), mpkg.Path, fset)
-		m.Store.SetBlockNode(pn)
-		PredefineFileSet(m.Store, pn, fset)
-		for _, fn := range fset.Files {
-			// Save Types to m.Store (while preprocessing).
-			fn = Preprocess(m.Store, pn, fn).(*FileNode)
-			// Save BlockNodes to m.Store.
-			SaveBlockNodes(m.Store, fn)
+		if _, exists := mpkgs[mpkg.Path]; exists {
+			continue
+		}
+		paths = append(paths, mpkg.Path)
+		mpkgs[mpkg.Path] = MPFProd.FilterMemPackage(mpkg)
+	}
+	done := make(map[string]struct{}, len(paths))
+	var preprocess func(path string)
+	preprocess = func(path string) {
+		if _, ok := done[path]; ok {
+			return
 		}
-		// Normally, the fileset would be added onto the
-		// package node only after runFiles(), but we cannot
-		// run files upon restart (only preprocess them).
-		// So, add them here instead.
-		// TODO: is this right?
-		if pn.FileSet == nil {
-			pn.FileSet = fset
+		done[path] = struct{}{}
+		if IsRealmPath(path) && !isPackageStored(m.Store, path) {
+			// An archived realm: nothing may run its code anymore.
+			return
 		}
-		// pn.FileSet != nil happens for non-realm file tests.
-		// TODO ensure the files are the same.
+		mpkg := mpkgs[path]
+		fset := m.ParseMemPackage(mpkg)
+		for _, dep := range fset.importPaths() {
+			if _, ok := mpkgs[dep]; ok {
+				preprocess(dep)
+			}
+		}
+		m.preprocessFileSet(mpkg, fset)
+	}
+	for _, path := range paths {
+		preprocess(path)
 	}
 }
 
+// preprocessFileSet preprocesses the parsed files of a stored package
+// and saves its block nodes, without running anything.
+func (m *Machine) preprocessFileSet(mpkg *std.MemPackage, fset *FileSet) *PackageNode {
+	pn := NewPackageNode(Name(mpkg.Name), mpkg.Path, fset)
+	m.Store.SetBlockNode(pn)
+	PredefineFileSet(m.Store, pn, fset)
+	for _, fn := range fset.Files {
+		// Save Types to m.Store (while preprocessing).
+		fn = Preprocess(m.Store, pn, fn).(*FileNode)
+		// Save BlockNodes to m.Store.
+		SaveBlockNodes(m.Store, fn)
+	}
+	// Normally, the fileset would be added onto the
+	// package node only after runFiles(), but we cannot
+	// run files upon restart (only preprocess them).
+	// So, add them here instead.
+	// TODO: is this right?
+	if pn.FileSet == nil {
+		pn.FileSet = fset
+	}
+	// pn.FileSet != nil happens for non-realm file tests.
+	// TODO ensure the files are the same.
+	return pn
+}
+
 //----------------------------------------
 // top level Run* methods.
 
@@ -694,6 +760,14 @@ func (h *initHeap) Pop() any {
 // Returns the updated typed values of package.
 // m.Package must match fns's package path.
 func (m *Machine) runFileDecls(withOverrides bool, fns ...*FileNode) []TypedValue {
+	return m.runFileDeclsWith(withOverrides, nil, fns...)
+}
+
+// Like runFileDecls, but if prepared is not nil it is called once the new
+// package values are in place, before any declaration is run. The names it
+// returns are considered initialized already, and the var declarations
+// declaring them are not run.
+func (m *Machine) runFileDeclsWith(withOverrides bool, prepared func(updates []TypedValue) []Name, fns ...*FileNode) []TypedValue {
 	// Files' package names must match the machine's active one.
 	// if there is one.
 	for _, fn := range fns {
@@ -758,6 +832,11 @@ func (m *Machine) runFileDecls(withOverrides bool, fns ...*FileNode) []TypedValu
 
 	// Get new values across all files in package.
 	updates := pn.PrepareNewValues(m.Alloc, pv)
+	if prepared != nil {
+		for _, name := range prepared(updates) {
+			fdeclared[name] = struct{}{}
+		}
+	}
 
 	// To initialize package variables, Go's spec says the following:
 	//    Within a package, package-level variable initialization proceeds
@@ -781,6 +860,15 @@ func (m *Machine) runFileDecls(withOverrides bool, fns ...*FileNode) []TypedValu
 			if _, ok := decl.(*FuncDecl); ok {
 				continue
 			}
+			if isGenericDecl(decl) {
+				// instantiated during preprocessing.
+				continue
+			}
+			if vd, ok := decl.(*ValueDecl); ok && !vd.Const &&
+				len(vd.GetDeclNames()) > 0 && isDeclared(vd, fdeclared) {
+				// initialized by prepared.
+				continue
+			}
 			pending = append(pending, decl)
 			declFiles = append(declFiles, fn)
 		}
@@ -1213,6 +1301,7 @@ const (
 	OpEnterCrossing       Op = 0x05 // before OpCall of a crossing function
 	OpCall                Op = 0x06 // call(Frame.Func, [...])
 	OpCallNativeBody      Op = 0x07 // call body is native
+	OpGoexit              Op = 0x08 // end of goroutine
 	OpDefer               Op = 0x0A // defer call(X, [...])
 	OpCallDeferNativeBody Op = 0x0B // call body is native
 	OpGo                  Op = 0x0C // go call(X, [...])
@@ -1233,30 +1322,31 @@ const (
 	OpReturnToBlock       Op = 0x1D // copy results to block (before defer) XXX rename to OpCopyResultsToBlock
 
 	/* Unary & binary operators */
-	OpUpos  Op = 0x20 // + (unary)
-	OpUneg  Op = 0x21 // - (unary)
-	OpUnot  Op = 0x22 // ! (unary)
-	OpUxor  Op = 0x23 // ^ (unary)
-	OpUrecv Op = 0x25 // <- (unary) // TODO make expr
-	OpLor   Op = 0x26 // ||
-	OpLand  Op = 0x27 // &&
-	OpEql   Op = 0x28 // ==
-	OpNeq   Op = 0x29 // !=
-	OpLss   Op = 0x2A // <
-	OpLeq   Op = 0x2B // <=
-	OpGtr   Op = 0x2C // >
-	OpGeq   Op = 0x2D // >=
-	OpAdd   Op = 0x2E // +
-	OpSub   Op = 0x2F // -
-	OpBor   Op = 0x30 // |
-	OpXor   Op = 0x31 // ^
-	OpMul   Op = 0x32 // *
-	OpQuo   Op = 0x33 // /
-	OpRem   Op = 0x34 // %
-	OpShl   Op = 0x35 // <<
-	OpShr   Op = 0x36 // >>
-	OpBand  Op = 0x37 // &
-	OpBandn Op = 0x38 // &^
+	OpUpos   Op = 0x20 // + (unary)
+	OpUneg   Op = 0x21 // - (unary)
+	OpUnot   Op = 0x22 // ! (unary)
+	OpUxor   Op = 0x23 // ^ (unary)
+	OpUrecv2 Op = 0x24 // (_, ok :=) <- (unary)
+	OpUrecv  Op = 0x25 // <- (unary) // TODO make expr
+	OpLor    Op = 0x26 // ||
+	OpLand   Op = 0x27 // &&
+	OpEql    Op = 0x28 // ==
+	OpNeq    Op = 0x29 // !=
+	OpLss    Op = 0x2A // <
+	OpLeq    Op = 0x2B // <=
+	OpGtr    Op = 0x2C // >
+	OpGeq    Op = 0x2D // >=
+	OpAdd    Op = 0x2E // +
+	OpSub    Op = 0x2F // -
+	OpBor    Op = 0x30 // |
+	OpXor    Op = 0x31 // ^
+	OpMul    Op = 0x32 // *
+	OpQuo    Op = 0x33 // /
+	OpRem    Op = 0x34 // %
+	OpShl    Op = 0x35 // <<
+	OpShr    Op = 0x36 // >>
+	OpBand   Op = 0x37 // &
+	OpBandn  Op = 0x38 // &^
 
 	/* Other expression operators */
 	OpEval         Op = 0x40 // eval next expression
@@ -1306,6 +1396,7 @@ const (
 	OpDefine      Op = 0x8C // X... := Y...
 	OpInc         Op = 0x8D // X++
 	OpDec         Op = 0x8E // X--
+	OpSend        Op = 0x8F // X <- Y
 
 	/* Decl operators */
 	OpValueDecl Op = 0x90 // var/const ...
@@ -1320,6 +1411,7 @@ const (
 	OpRangeIterMap      Op = 0xD5
 	OpRangeIterArrayPtr Op = 0xD6
 	OpReturnCallDefers  Op = 0xD7 // XXX rename to OpCallDefers
+	OpRangeIterChan     Op = 0xD8
 	OpVoid              Op = 0xFF // For profiling simple operation
 )
 
@@ -1378,21 +1470,39 @@ func bigdecDigits(bdv BigdecValue) int64 {
 
 // incrCPUBigDec charges per-100-digit CPU gas for BigDec binary ops.
 func (m *Machine) incrCPUBigDec(lv, rv *TypedValue, slopePer100 int64) {
-	if lv.T == UntypedBigdecType {
+	switch lv.T {
+	case UntypedBigdecType:
 		lb := bigdecDigits(lv.GetBigDec())
 		rb := bigdecDigits(rv.GetBigDec())
 		m.incrCPU(max(lb, rb) * slopePer100 / 100)
+	case UntypedBigcomplexType:
+		// Two bigdec ops, one per part.
+		lb := bigcomplexDigits(lv.GetBigComplex())
+		rb := bigcomplexDigits(rv.GetBigComplex())
+		m.incrCPU(2 * max(lb, rb) * slopePer100 / 100)
 	}
 }
 
+// bigcomplexDigits estimates the decimal digit count of the larger part of
+// a BigcomplexValue.
+func bigcomplexDigits(bcv BigcomplexValue) int64 {
+	return max(bigdecDigits(bcv.Real), bigdecDigits(bcv.Imag))
+}
+
 // incrCPUBigDecQuad charges quadratic CPU gas for BigDec Mul/Quo.
 // gas = (digits/10)^2 * slope / 10. overflow.Mulp keeps the compute
 // safe if maxAllocTx is ever raised.
 func (m *Machine) incrCPUBigDecQuad(lv, rv *TypedValue, slope int64) {
-	if lv.T == UntypedBigdecType {
+	switch lv.T {
+	case UntypedBigdecType:
 		lb := bigdecDigits(lv.GetBigDec()) / 10
 		rb := bigdecDigits(rv.GetBigDec()) / 10
 		m.incrCPU(overflow.Mulp(overflow.Mulp(lb, rb), slope) / 10)
+	case UntypedBigcomplexType:
+		// Complex Mul/Quo run about six bigdec Mul/Quo.
+		lb := bigcomplexDigits(lv.GetBigComplex()) / 10
+		rb := bigcomplexDigits(rv.GetBigComplex()) / 10
+		m.incrCPU(overflow.Mulp(overflow.Mulp(overflow.Mulp(lb, rb), slope), 6) / 10)
 	}
 }
 
@@ -1453,8 +1563,10 @@ const (
 	OpCPUSubRealmSlope       = 15201 // realm.Sub: per 1024 bytes of synthesized pkgpath (slope)
 	OpCPUDefer               = 71
 	OpCPUCallDeferNativeBody = 172 // XXX arbitrary, not properly benchmarked
-	OpCPUGo                  = 1   // XXX not yet implemented
-	OpCPUSelect              = 1   // XXX not yet implemented
+	OpCPUGo                  = 360 // ratio-scaled; see cmd/calibrate/chan_op_bench_output.txt
+	OpCPUGoexit              = 8   // ratio-scaled; plus OpCPUSchedule, charged by the switch
+	OpCPUSchedule            = 147 // per goroutine switch, ratio-scaled
+	OpCPUSelect              = 229 // ratio-scaled; per case evaluated, and base of the choice; per-case added in handler
 	OpCPUSwitchClause        = 87
 	OpCPUSwitchClauseCase    = 109 // max(match=109, miss=106)
 	OpCPUTypeSwitch          = 280 // parameterized; base from fit (280.5); per-clause added in handler
@@ -1475,7 +1587,8 @@ const (
 	OpCPUUneg      = 69
 	OpCPUUnot      = 70
 	OpCPUUxor      = 69
-	OpCPUUrecv     = 1 // XXX not yet implemented
+	OpCPUUrecv     = 144 // max(buffer=103, blocked sender=144), ratio-scaled
+	OpCPUUrecv2    = 153 // max(buffer=109, blocked sender=153), ratio-scaled
 	OpCPULor       = 83
 	OpCPULand      = 86 // benchmark: true=69, false=66; 86 includes dispatch overhead not isolated by benchops
 	OpCPUEql       = 93 // max(int=85, float64=93); parameterized cases added in handler
@@ -1495,11 +1608,16 @@ const (
 	OpCPUMulFloat  = 142 // float64 mul (142.1 ns)
 	OpCPUQuoInt    = 138 // int quo (137.7 ns)
 	OpCPUQuoFloat  = 234 // float64 quo (234.1 ns)
-	OpCPURem       = 142
-	OpCPUShl       = 80
-	OpCPUShr       = 79
-	OpCPUBand      = 71
-	OpCPUBandn     = 71
+	// XXX complex ops are estimated from the float64 ops they run, not yet benchmarked.
+	OpCPUAddComplex = 2 * OpCPUAddFloat
+	OpCPUSubComplex = 2 * OpCPUSubFloat
+	OpCPUMulComplex = 4*OpCPUMulFloat + 2*OpCPUAddFloat
+	OpCPUQuoComplex = 3*OpCPUQuoFloat + 3*OpCPUMulFloat + 3*OpCPUAddFloat
+	OpCPURem        = 142
+	OpCPUShl        = 80
+	OpCPUShr        = 79
+	OpCPUBand       = 71
+	OpCPUBandn      = 71
 
 	/* Other expression operators */
 	OpCPUEval                = 82  // parameterized for NameExpr; base from fit (81.7)
@@ -1556,6 +1674,7 @@ const (
 	OpCPUIncFloat    = 188 // float64 inc (187.6 ns)
 	OpCPUDecInt      = 81  // int dec (80.9 ns)
 	OpCPUDecFloat    = 189 // float64 dec (189.3 ns)
+	OpCPUSend        = 168 // max(buffer=97, blocked receiver=168), ratio-scaled
 
 	/* Decl operators */
 	OpCPUValueDecl = 197
@@ -1570,6 +1689,7 @@ const (
 	OpCPURangeIterMap      = 73  // flat (called once per entry)
 	OpCPURangeIterArrayPtr = 239
 	OpCPUReturnCallDefers  = 724 // base from fit; per-defer charging happens via sticky-op re-dispatch
+	OpCPURangeIterChan     = 73  // XXX arbitrary, not yet benchmarked; flat (called once per value)
 
 	// Per-N slope constants for parameterized ops.
 	// Each value is the CPU gas cost per unit of the parameter N.
@@ -1587,6 +1707,7 @@ const (
 	OpCPUSlopeForLoopHeap     = 97  // per heap var copied (fit: 96.5)
 	OpCPUSlopeRangeIterArray  = 15  // per element (fit: 14.7)
 	OpCPUSlopeTypeSwitchCase  = 254 // per clause concrete (fit: 253.9)
+	OpCPUSlopeSelectCase      = 29  // per select case, ratio-scaled
 	OpCPUSlopeTypeAssertIface = 349 // per interface method (fit: 348.9)
 	OpCPUSlopeConvertStrRunes = 23  // per char string→runes (fit: 23.4)
 	OpCPUSlopeConvertRunesStr = 8   // per rune runes→string (fit: 8.1)
@@ -1672,6 +1793,15 @@ const (
 	// from BenchmarkComputeMapKey_Bytes); the per-call constant absorbs
 	// the constant-overhead portion that dominates at small N.
 	OpCPUSlopeComputeMapKeyByte = 4 // per byte/10
+
+	// encoding/json (values_json.go) charges OpCPUJSONValue for every value
+	// it encodes or decodes, and OpCPUSlopeJSONByte for every byte it writes
+	// or reads, so that the cost of the walk, the map key sort and the
+	// string escaping follows the size of the JSON text. Both are the max of
+	// the encoder and decoder fits, which decoding dominates; see
+	// cmd/calibrate/json_bench_output.txt.
+	OpCPUJSONValue     = 1019 // per value (draft, Intel Xeon)
+	OpCPUSlopeJSONByte = 156  // per byte/10 (draft, Intel Xeon)
 )
 
 //----------------------------------------
@@ -1774,9 +1904,14 @@ func (m *Machine) runOnce() (caught *Exception) {
 			// Per-native gas charged inside doOpCallDeferNativeBody.
 			m.doOpCallDeferNativeBody()
 		case OpGo:
-			panic("goroutines are not yet supported")
+			m.incrCPU(OpCPUGo)
+			m.doOpGo()
+		case OpGoexit:
+			m.incrCPU(OpCPUGoexit)
+			m.doOpGoexit()
 		case OpSelect:
-			panic("select is not yet supported")
+			m.incrCPU(OpCPUSelect)
+			m.doOpSelect()
 		case OpSwitchClause:
 			m.incrCPU(OpCPUSwitchClause)
 			m.doOpSwitchClause()
@@ -1815,7 +1950,11 @@ func (m *Machine) runOnce() (caught *Exception) {
 			m.incrCPU(OpCPUUxor)
 			m.doOpUxor()
 		case OpUrecv:
-			panic("channel type is not yet supported")
+			m.incrCPU(OpCPUUrecv)
+			m.doOpUrecv()
+		case OpUrecv2:
+			m.incrCPU(OpCPUUrecv2)
+			m.doOpUrecv2()
 		/* Binary operators */
 		case OpLor:
 			m.incrCPU(OpCPULor)
@@ -1937,7 +2076,8 @@ func (m *Machine) runOnce() (caught *Exception) {
 			m.incrCPU(OpCPUSliceType)
 			m.doOpSliceType()
 		case OpChanType:
-			panic("channel type is not yet supported")
+			m.incrCPU(OpCPUChanType)
+			m.doOpChanType()
 		case OpFuncType:
 			m.incrCPU(OpCPUFuncType)
 			m.doOpFuncType()
@@ -1994,6 +2134,9 @@ func (m *Machine) runOnce() (caught *Exception) {
 			m.doOpInc()
 		case OpDec:
 			m.doOpDec()
+		case OpSend:
+			m.incrCPU(OpCPUSend)
+			m.doOpSend()
 		/* Decl operators */
 		case OpValueDecl:
 			m.incrCPU(OpCPUValueDecl)
@@ -2020,6 +2163,9 @@ func (m *Machine) runOnce() (caught *Exception) {
 		case OpRangeIterMap:
 			m.incrCPU(OpCPURangeIterMap)
 			m.doOpExec(op)
+		case OpRangeIterChan:
+			m.incrCPU(OpCPURangeIterChan)
+			m.doOpExec(op)
 		case OpReturnCallDefers:
 			m.incrCPU(OpCPUReturnCallDefers)
 			m.doOpReturnCallDefers()
@@ -2301,6 +2447,7 @@ func (m *Machine) PushFrameCall(cx *CallExpr, fv *FuncValue, recv TypedValue, is
 		IsVarg:        cx.Varg,
 		LastPackage:   m.Package,
 		LastRealm:     m.Realm,
+		LastInView:    m.InView,
 		WithCross:     withCross,
 		DidCrossing:   false,
 		Defers:        nil,
@@ -2547,6 +2694,7 @@ func (m *Machine) PopFrameAndReturn() {
 	m.Values = m.Values[:fr.NumValues+numRes]
 	m.Package = fr.LastPackage
 	m.setRealm(fr.LastRealm)
+	m.InView = fr.LastInView
 	if m.Exception != nil {
 		// Inner defer exceptions replace the outer defer
 		// ones.  You can still reach the previous exceptions
@@ -2727,7 +2875,7 @@ func panicIllegalPointerLHS(lx Expr) {
 func (m *Machine) PopAsPointer(lx Expr) PointerValue {
 	pv, ro := m.PopAsPointer2(lx)
 	if ro {
-		m.Panic(typedString(readonlyAccessPanic(lx)))
+		m.Panic(typedString(m.readonlyAccessPanic(lx)))
 	}
 	return pv
 }
@@ -2737,11 +2885,28 @@ func (m *Machine) PopAsPointer(lx Expr) PointerValue {
 // owned by a realm different from the one currently executing. Going
 // through a method or crossing function re-enters via PushFrameCall, whose
 // implicit borrow-realm switch (or hard cross-call) lines m.Realm up with
-// the target's owner.
-func readonlyAccessPanic(x Expr) string {
+// the target's owner. In a read-only view no call can help, hence the
+// different message.
+func (m *Machine) readonlyAccessPanic(x Expr) string {
+	if m.InView {
+		return "cannot modify object in a read-only view: " + x.String()
+	}
 	return "cannot directly modify readonly tainted object (use a method or crossing function): " + x.String()
 }
 
+// EnterView puts the machine in a read-only view until the caller of the
+// running native function returns; see chain/runtime.View. In a view, no
+// real object of any realm may be modified, whatever the borrow rules say;
+// new objects may be allocated, but not attached to real ones. The view
+// ends when the frame that entered it is popped, since every call frame
+// restores the view mode of its caller (like it restores m.Realm).
+func (m *Machine) EnterView() {
+	m.InView = true
+	// The native's own frame is the last one: keep the view when it
+	// returns, so that the view only ends with its caller.
+	m.LastFrame().LastInView = true
+}
+
 // IsReadonly is the write-guard form, used at every mutation site
 // (PopAsPointer2, uverse append/copy/delete, map assign). Returns false if
 // m.Realm is nil (single user mode). Otherwise returns true iff:
@@ -2765,6 +2930,13 @@ func (m *Machine) IsReadonly(tv *TypedValue) bool {
 }
 
 func (m *Machine) isReadonly(tv *TypedValue, ownPkgID PkgID) bool {
+	//  in a read-only view, any package or real object is readonly
+	if m.InView {
+		if rv, ok := tv.V.(RefValue); ok && rv.PkgPath != "" {
+			return true
+		}
+		return tv.IsReadonlyBy(PkgID{}, ownPkgID)
+	}
 	//  m.Realm is nil → single user mode, nothing is readonly
 	if m.Realm == nil {
 		return false
@@ -2784,9 +2956,10 @@ func (m *Machine) isReadonly(tv *TypedValue, ownPkgID PkgID) bool {
 
 // isExternalRealm returns true if base is a real Object belonging to
 // a different realm than m.Realm. Used for NameExpr cross-realm checks
-// where we have a Base (Block) rather than a TypedValue.
+// where we have a Base (Block) rather than a TypedValue. In a read-only
+// view, every real base counts as external.
 func (m *Machine) isExternalRealm(base Value) bool {
-	if m.Realm == nil {
+	if m.Realm == nil && !m.InView {
 		return false
 	}
 	obj, ok := base.(Object)
@@ -2797,6 +2970,15 @@ func (m *Machine) isExternalRealm(base Value) bool {
 	if oid.IsZero() {
 		return false // transient (local var, unreal block)
 	}
+	// In a read-only view, no borrow rule grants write access: a real HIV
+	// (e.g. captured by a closure persisted in a realm) is readonly too,
+	// while an unreal one is not, whatever its alloc-site stamp.
+	if m.InView {
+		if !obj.GetIsReal() {
+			return false
+		}
+		return m.Package == nil || !m.Package.PkgID.IsStdlibPkg() || oid.PkgID != m.Package.PkgID
+	}
 	// HIVs are exempt from the external-realm gate at the NameExpr
 	// write path. Two paths reach here:
 	//   - Real HIV in foreign realm: PushFrameCall's borrow rule #3 already
@@ -2858,7 +3040,7 @@ func (m *Machine) resolvePointer(lx Expr, lhsOperands []TypedValue) (pv PointerV
 			ro = m.IsReadonly(xv)
 			if ro {
 				// Ensure we always panic, without expecting the caller to do it.
-				m.Panic(typedString(readonlyAccessPanic(lx)))
+				m.Panic(typedString(m.readonlyAccessPanic(lx)))
 			}
 			pv = xv.GetPointerAtIndex(m, m.Realm, m.Alloc, m.Store, iv)
 		} else {
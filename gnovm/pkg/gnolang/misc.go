@@ -82,7 +82,7 @@ func word2UnaryOp(w Word) Op {
 	case BAND:
 		panic("unexpected unary operation & - use RefExpr instead")
 	case ARROW:
-		panic("channel type is not yet supported")
+		return OpUrecv
 	default:
 		panic("unexpected unary operation")
 	}
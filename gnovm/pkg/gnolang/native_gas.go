@@ -1,6 +1,10 @@
 package gnolang
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/overflow"
+)
 
 // Per-native gas charging. See gnovm/cmd/calibrate/gen_native_table.py and
 // gnovm/cmd/calibrate/native_gas_formulas.md for the calibration pipeline.
@@ -28,7 +32,7 @@ const (
 
 // NativeGasInfo is the per-function gas descriptor.
 //
-// Pre-call charge:  Base + Slope*N1/1024 + Slope2*N2/1024
+// Pre-call charge:  Base + Slope*N1/1024 + Slope2*N2/1024 + ProdSlope*P1*P2/1024
 //
 //	(read off the call block before nativeBody)
 //
@@ -41,6 +45,9 @@ const (
 // op_gas_formulas.md). Typical use: Slope on len(slice) for per-element
 // loop overhead, Slope2 on SizeSliceTotalBytes for per-byte marshal cost
 // in []string params (chain.emit, chain/params.SetStrings, etc.).
+// ProdSlope charges on the product of two parameter sizes, for natives
+// whose work is quadratic in their operands (math/big multiplication,
+// division, GCD).
 //
 // Bases are calibrated end-to-end through the dispatcher (Gno↔Go reflect
 // + X_ work + return push). The /1024 mirrors machine.go:incrCPUBigInt's
@@ -57,6 +64,13 @@ type NativeGasInfo struct {
 	Slope2Idx  int8
 	Slope2Kind NativeGasSize
 
+	// Optional pre-call slope on the product of two parameter sizes, both
+	// read with ProdKind. Zero ProdSlope = unused.
+	ProdSlope int64 // per 1024 units of P1*P2
+	ProdIdx   int8
+	ProdIdx2  int8
+	ProdKind  NativeGasSize
+
 	// Optional post-call charge. Zero PostBase + zero PostSlope +
 	// zero PostSlope2 = no post-charge (skipped via the gi-nil
 	// shortcut returned by chargeNativeGas). PostSlopeIdx is the
@@ -105,7 +119,7 @@ func RegisterNativeGas(pkgPath string, name Name, info *NativeGasInfo) {
 //     historical OpCPUCallNativeBody flat. Variable-cost ones like print
 //     also self-charge (see uversePrint). TODO: extend the calibration
 //     table to cover uverse natives too.
-//   - Calibrated stdlibs charge Base + Slope*N1/1024 + Slope2*N2/1024.
+//   - Calibrated stdlibs charge the pre-call formula on NativeGasInfo.
 //   - Stdlibs with no calibrated entry panic when a real GasMeter is
 //     attached. This forces every new native to come with a benchmark.
 //     Test/no-meter Machines silently fall through (no charge).
@@ -138,6 +152,11 @@ func (m *Machine) chargeNativeGas(fv *FuncValue) *NativeGasInfo {
 	if gi.Slope2 != 0 {
 		cost += gi.Slope2 * m.nativeSizeFromBlock(gi.Slope2Kind, gi.Slope2Idx) / 1024
 	}
+	if gi.ProdSlope != 0 {
+		p := overflow.Mulp(m.nativeSizeFromBlock(gi.ProdKind, gi.ProdIdx),
+			m.nativeSizeFromBlock(gi.ProdKind, gi.ProdIdx2))
+		cost = overflow.Addp(cost, overflow.Mulp(gi.ProdSlope, p)/1024)
+	}
 	m.incrCPU(cost)
 	if !gi.hasPost() {
 		return nil
@@ -102,6 +102,34 @@ func TestChargeNativeGas_LinearScalesWithInput(t *testing.T) {
 	}
 }
 
+func TestChargeNativeGas_ProductOfTwoParams(t *testing.T) {
+	// Mimic math/big intMul: the product term charges len(x)*len(y), so
+	// doubling both operands quadruples it.
+	cleanup := registerTestNative(t, &NativeGasInfo{
+		Base: 10, SlopeIdx: -1, SlopeKind: SizeFlat,
+		ProdSlope: 2048, ProdIdx: 0, ProdIdx2: 2, ProdKind: SizeLenBytes,
+	})
+	defer cleanup()
+
+	cases := []struct {
+		x, y int
+		want int64
+	}{
+		{0, 1024, 10},              // an empty operand costs nothing extra
+		{32, 32, 10 + 2*32*32},     // 1024 units → +2*1024/1024 per unit
+		{64, 64, 10 + 2*64*64},     // doubling both operands quadruples it
+		{4096, 16, 10 + 2*4096*16}, // unequal operands
+		{16, 4096, 10 + 2*16*4096}, // symmetric
+	}
+	for _, c := range cases {
+		m := stubMachine([]int{c.x, 0, c.y})
+		_ = m.chargeNativeGas(&FuncValue{NativePkg: testNativePkg, NativeName: testNativeFn})
+		if m.Cycles != c.want {
+			t.Errorf("x=%d y=%d: got %d cycles, want %d", c.x, c.y, m.Cycles, c.want)
+		}
+	}
+}
+
 func TestChargeNativeGas_PostCallReturnLen(t *testing.T) {
 	// Mimic bankerGetCoins: pre=flat 100, post=20*N/1024 on the return at
 	// stack offset 2 (a slice with length 1024 → +20 cost).
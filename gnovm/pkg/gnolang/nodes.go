@@ -145,6 +145,13 @@ const (
 	ATTR_REF_ELEM_TYPE         GnoAttribute = "ATTR_REF_ELEM_TYPE"    // static element type of &x, set on the RefExpr node during preprocessing.
 	// For top level declarations, a map[Name]struct{} of other dependencies
 	ATTR_DECL_DEPS GnoAttribute = "ATTR_DECL_DEPS"
+	// Generics (see generics.go).
+	ATTR_GENERIC         GnoAttribute = "ATTR_GENERIC"         // *templateType of a not yet instantiated expr.
+	ATTR_GENERIC_INST    GnoAttribute = "ATTR_GENERIC_INST"    // []Type type arguments of an instance *FuncDecl.
+	ATTR_GENERIC_FUNC    GnoAttribute = "ATTR_GENERIC_FUNC"    // *FuncValue of an instance *FuncDecl.
+	ATTR_GENERIC_CONTEXT GnoAttribute = "ATTR_GENERIC_CONTEXT" // *instContext of an instance block node.
+	ATTR_GENERIC_BODIES  GnoAttribute = "ATTR_GENERIC_BODIES"  // []func() instance bodies to preprocess.
+	ATTR_PREDEFINING     GnoAttribute = "ATTR_PREDEFINING"     // package node is predefining its declarations.
 )
 
 // Embedded in each Node.
@@ -229,6 +236,7 @@ func (*BasicLitExpr) assertNode()      {}
 func (*BinaryExpr) assertNode()        {}
 func (*CallExpr) assertNode()          {}
 func (*IndexExpr) assertNode()         {}
+func (*IndexListExpr) assertNode()     {}
 func (*SelectorExpr) assertNode()      {}
 func (*SliceExpr) assertNode()         {}
 func (*StarExpr) assertNode()          {}
@@ -281,6 +289,7 @@ var (
 	_ Node = &BinaryExpr{}
 	_ Node = &CallExpr{}
 	_ Node = &IndexExpr{}
+	_ Node = &IndexListExpr{}
 	_ Node = &SelectorExpr{}
 	_ Node = &SliceExpr{}
 	_ Node = &StarExpr{}
@@ -345,6 +354,7 @@ func (*BasicLitExpr) assertExpr()     {}
 func (*BinaryExpr) assertExpr()       {}
 func (*CallExpr) assertExpr()         {}
 func (*IndexExpr) assertExpr()        {}
+func (*IndexListExpr) assertExpr()    {}
 func (*SelectorExpr) assertExpr()     {}
 func (*SliceExpr) assertExpr()        {}
 func (*StarExpr) assertExpr()         {}
@@ -362,6 +372,7 @@ var (
 	_ Expr = &BinaryExpr{}
 	_ Expr = &CallExpr{}
 	_ Expr = &IndexExpr{}
+	_ Expr = &IndexListExpr{}
 	_ Expr = &SelectorExpr{}
 	_ Expr = &SliceExpr{}
 	_ Expr = &StarExpr{}
@@ -464,6 +475,14 @@ type IndexExpr struct { // X[Index]
 	HasOK bool // if true, is form: `value, ok := <X>[<Key>]
 }
 
+// Instantiation of a generic function or type with more than one type
+// argument. X[Index] with a single type argument is an *IndexExpr.
+type IndexListExpr struct { // X[Indices...]
+	Attributes
+	X       Expr  // generic function or type
+	Indices Exprs // type arguments
+}
+
 type SelectorExpr struct { // X.Sel
 	Attributes
 	X    Expr      // expression
@@ -505,8 +524,9 @@ type TypeAssertExpr struct { // X.(Type)
 // (referencing) are represented with RefExpr nodes.
 type UnaryExpr struct { // (Op X)
 	Attributes
-	X  Expr // operand
-	Op Word // operator
+	X     Expr // operand
+	Op    Word // operator
+	HasOK bool // if true, is form: `value, ok := <-<X>`
 }
 
 // MyType{<key>:<value>} struct, array, slice, and map
@@ -921,6 +941,7 @@ type RangeStmt struct {
 	IsMap      bool // if X is map type
 	IsString   bool // if X is string type
 	IsArrayPtr bool // if X is array-pointer type
+	IsChan     bool // if X is chan type
 }
 
 type ReturnStmt struct {
@@ -1091,10 +1112,11 @@ type FuncDecl struct {
 	Attributes
 	StaticBlock
 	NameExpr
-	IsMethod bool
-	Recv     FieldTypeExpr // receiver (if method); or empty (if function)
-	Type     FuncTypeExpr  // function signature: parameters and results
-	Body                   // function body; or empty for external (non-Go) function
+	IsMethod   bool
+	Recv       FieldTypeExpr  // receiver (if method); or empty (if function)
+	TypeParams FieldTypeExprs // type parameters (if generic function)
+	Type       FuncTypeExpr   // function signature: parameters and results
+	Body                      // function body; or empty for external (non-Go) function
 
 	unboundType *FuncTypeExpr // memoized
 }
@@ -1180,8 +1202,9 @@ func (x *ValueDecl) GetDeclNames() []Name {
 type TypeDecl struct {
 	Attributes
 	NameExpr
-	Type    Expr // Name, SelectorExpr, StarExpr, or XxxTypes
-	IsAlias bool // type alias since Go 1.9
+	TypeParams FieldTypeExprs // type parameters (if generic type)
+	Type       Expr           // Name, SelectorExpr, StarExpr, or XxxTypes
+	IsAlias    bool           // type alias since Go 1.9
 }
 
 func (x *TypeDecl) GetDeclNames() []Name {
@@ -1298,6 +1321,20 @@ func (fs *FileSet) FileNames() []string {
 	return res
 }
 
+// Returns the sorted, de-duplicated paths imported by the files of fs.
+func (fs *FileSet) importPaths() []string {
+	var res []string
+	for _, fn := range fs.Files {
+		for _, decl := range fn.Decls {
+			if id, ok := decl.(*ImportDecl); ok {
+				res = append(res, id.PkgPath)
+			}
+		}
+	}
+	slices.Sort(res)
+	return slices.Compact(res)
+}
+
 // ----------------------------------------
 // FileNode, & PackageNode
 
@@ -1710,6 +1747,7 @@ const (
 	NSFuncParam    // func(<name>...) (indexed)
 	NSFuncResult   // func()<name>... (indexed)
 	NSTypeSwitch   // switch <name> := _.(type)
+	NSTypeParam    // func _[<name> _]() or type _[<name> _] _ (indexed)
 )
 
 type oldValue struct {
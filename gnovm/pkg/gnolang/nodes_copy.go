@@ -53,6 +53,13 @@ func (x *IndexExpr) Copy() Node {
 	}
 }
 
+func (x *IndexListExpr) Copy() Node {
+	return &IndexListExpr{
+		X:       x.X.Copy().(Expr),
+		Indices: copyExprs(x.Indices),
+	}
+}
+
 func (x *SelectorExpr) Copy() Node {
 	return &SelectorExpr{
 		X:   x.X.Copy().(Expr),
@@ -275,7 +282,7 @@ func (x *SelectStmt) Copy() Node {
 
 func (x *SelectCaseStmt) Copy() Node {
 	return &SelectCaseStmt{
-		Comm: x.Comm.Copy().(Stmt),
+		Comm: copyStmt(x.Comm),
 		Body: copyStmts(x.Body),
 	}
 }
@@ -305,10 +312,11 @@ func (x *SwitchClauseStmt) Copy() Node {
 
 func (x *FuncDecl) Copy() Node {
 	funcDecl := &FuncDecl{
-		NameExpr: *(x.NameExpr.Copy().(*NameExpr)),
-		IsMethod: x.IsMethod,
-		Type:     *(x.Type.Copy().(*FuncTypeExpr)),
-		Body:     copyStmts(x.Body),
+		NameExpr:   *(x.NameExpr.Copy().(*NameExpr)),
+		IsMethod:   x.IsMethod,
+		TypeParams: copyFTs(x.TypeParams),
+		Type:       *(x.Type.Copy().(*FuncTypeExpr)),
+		Body:       copyStmts(x.Body),
 	}
 	if x.IsMethod {
 		funcDecl.Recv = *(x.Recv.Copy().(*FieldTypeExpr))
@@ -334,9 +342,10 @@ func (x *ValueDecl) Copy() Node {
 
 func (x *TypeDecl) Copy() Node {
 	return &TypeDecl{
-		NameExpr: *(x.NameExpr.Copy().(*NameExpr)),
-		Type:     x.Type.Copy().(Expr),
-		IsAlias:  x.IsAlias,
+		NameExpr:   *(x.NameExpr.Copy().(*NameExpr)),
+		TypeParams: copyFTs(x.TypeParams),
+		Type:       x.Type.Copy().(Expr),
+		IsAlias:    x.IsAlias,
 	}
 }
 
@@ -374,6 +383,10 @@ func copyExpr(x Expr) Expr {
 }
 
 func copyExprs(xs []Expr) []Expr {
+	if xs == nil {
+		// e.g. *ValueDecl.Values, nil if no values.
+		return nil
+	}
 	res := make([]Expr, len(xs))
 	for i, x := range xs {
 		res[i] = x.Copy().(Expr)
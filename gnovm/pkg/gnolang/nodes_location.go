@@ -274,6 +274,7 @@ type Location struct {
 	PkgPath string
 	File    string
 	Span
+	Inst string `json:",omitempty"` // type arguments of the enclosing generic instantiation, if any
 }
 
 // Convenience with no modifications.
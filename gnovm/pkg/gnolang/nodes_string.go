@@ -137,6 +137,10 @@ func (x IndexExpr) String() string {
 	return fmt.Sprintf("%s[%s]", x.X, x.Index)
 }
 
+func (x IndexListExpr) String() string {
+	return fmt.Sprintf("%s[%s]", x.X, x.Indices.String())
+}
+
 func (x SelectorExpr) String() string {
 	return fmt.Sprintf("%s.%s", x.X, x.Sel)
 }
@@ -231,9 +235,9 @@ func (x InterfaceTypeExpr) String() string {
 func (x ChanTypeExpr) String() string {
 	switch x.Dir {
 	case SEND:
-		return fmt.Sprintf("<-chan %s", x.Value)
-	case RECV:
 		return fmt.Sprintf("chan<- %s", x.Value)
+	case RECV:
+		return fmt.Sprintf("<-chan %s", x.Value)
 	case SEND | RECV:
 		return fmt.Sprintf("chan %s", x.Value)
 	default:
@@ -421,8 +425,12 @@ func (x FuncDecl) String() string {
 	if x.IsMethod {
 		recv = "(" + x.Recv.String() + ") "
 	}
-	return fmt.Sprintf("func %s%s%s { %s }",
-		recv, x.Name, x.Type.String()[4:], x.Body.String())
+	tparams := ""
+	if len(x.TypeParams) > 0 {
+		tparams = "[" + x.TypeParams.String() + "]"
+	}
+	return fmt.Sprintf("func %s%s%s%s { %s }",
+		recv, x.Name, tparams, x.Type.String()[4:], x.Body.String())
 }
 
 func (x ImportDecl) String() string {
@@ -453,6 +461,9 @@ func (x TypeDecl) String() string {
 	if x.IsAlias {
 		return fmt.Sprintf("type %s = %s", x.Name, x.Type.String())
 	}
+	if len(x.TypeParams) > 0 {
+		return fmt.Sprintf("type %s[%s] %s", x.Name, x.TypeParams.String(), x.Type.String())
+	}
 	return fmt.Sprintf("type %s %s", x.Name, x.Type.String())
 }
 
@@ -70,7 +70,7 @@ func (m *Machine) doOpAssign() {
 		sz := numStackValuesForPointer(lx)
 		lv, ro := m.resolvePointer(lx, lhsOperands[offset:offset+sz])
 		if ro {
-			m.Panic(typedString(readonlyAccessPanic(lx)))
+			m.Panic(typedString(m.readonlyAccessPanic(lx)))
 		}
 		offset += sz
 		if m.Stage != StagePre && isUntyped(rvs[i].T) && rvs[i].T.Kind() != BoolKind {
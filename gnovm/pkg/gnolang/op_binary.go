@@ -223,6 +223,8 @@ func (m *Machine) doOpAdd() {
 		m.incrCPU(OpCPUAddString)
 	case Float32Kind, Float64Kind:
 		m.incrCPU(OpCPUAddFloat)
+	case Complex64Kind, Complex128Kind:
+		m.incrCPU(OpCPUAddComplex)
 	default:
 		m.incrCPU(OpCPUAddInt)
 	}
@@ -249,6 +251,8 @@ func (m *Machine) doOpSub() {
 	switch lv.T.Kind() {
 	case Float32Kind, Float64Kind:
 		m.incrCPU(OpCPUSubFloat)
+	case Complex64Kind, Complex128Kind:
+		m.incrCPU(OpCPUSubComplex)
 	default:
 		m.incrCPU(OpCPUSubInt)
 	}
@@ -306,6 +310,8 @@ func (m *Machine) doOpMul() {
 	switch lv.T.Kind() {
 	case Float32Kind, Float64Kind:
 		m.incrCPU(OpCPUMulFloat)
+	case Complex64Kind, Complex128Kind:
+		m.incrCPU(OpCPUMulComplex)
 	default:
 		m.incrCPU(OpCPUMulInt)
 	}
@@ -331,6 +337,8 @@ func (m *Machine) doOpQuo() {
 	switch lv.T.Kind() {
 	case Float32Kind, Float64Kind:
 		m.incrCPU(OpCPUQuoFloat)
+	case Complex64Kind, Complex128Kind:
+		m.incrCPU(OpCPUQuoComplex)
 	default:
 		m.incrCPU(OpCPUQuoInt)
 	}
@@ -513,6 +521,18 @@ func isEql(m *Machine, lv, rv *TypedValue, viaIface bool) bool {
 		return lb.Cmp(rb) == 0
 	case BigdecKind:
 		return bigdecCmp(lv.V.(BigdecValue), rv.V.(BigdecValue)) == 0
+	case Complex64Kind:
+		lr, li := lv.GetComplex64()
+		rr, ri := rv.GetComplex64()
+		return softfloat.Feq32(lr, rr) && softfloat.Feq32(li, ri)
+	case Complex128Kind:
+		lr, li := lv.GetComplex128()
+		rr, ri := rv.GetComplex128()
+		return softfloat.Feq64(lr, rr) && softfloat.Feq64(li, ri)
+	case BigcomplexKind:
+		lc := lv.GetBigComplex()
+		rc := rv.GetBigComplex()
+		return bigdecCmp(lc.Real, rc.Real) == 0 && bigdecCmp(lc.Imag, rc.Imag) == 0
 	case ArrayKind:
 		la := lv.V.(*ArrayValue)
 		ra := rv.V.(*ArrayValue)
@@ -582,6 +602,9 @@ func isEql(m *Machine, lv, rv *TypedValue, viaIface bool) bool {
 			return false
 		}
 		return true
+	case ChanKind:
+		// Channels are equal if created by the same call to make.
+		return lv.V == rv.V
 	case MapKind, SliceKind, FuncKind:
 		// Uncomparable kinds. A via-interface comparison of these is caught by
 		// the comparability check above before reaching here, so the only way
@@ -874,6 +897,143 @@ func parseBigdecLiteral(s, kind string) BigdecValue {
 	return BigdecValue{V: r}
 }
 
+// bigcomplexAdd, bigcomplexSub, bigcomplexMul and bigcomplexQuo implement
+// exact untyped complex constant arithmetic on top of the bigdec helpers.
+// bigcomplexQuo must not be called with a zero divisor.
+func bigcomplexAdd(lc, rc BigcomplexValue) BigcomplexValue {
+	return BigcomplexValue{
+		Real: bigdecAdd(lc.Real, rc.Real),
+		Imag: bigdecAdd(lc.Imag, rc.Imag),
+	}
+}
+
+func bigcomplexSub(lc, rc BigcomplexValue) BigcomplexValue {
+	return BigcomplexValue{
+		Real: bigdecSub(lc.Real, rc.Real),
+		Imag: bigdecSub(lc.Imag, rc.Imag),
+	}
+}
+
+func bigcomplexMul(lc, rc BigcomplexValue) BigcomplexValue {
+	// (a+bi)(c+di) = (ac-bd) + (ad+bc)i
+	a, b, c, d := lc.Real, lc.Imag, rc.Real, rc.Imag
+	return BigcomplexValue{
+		Real: bigdecSub(bigdecMul(a, c), bigdecMul(b, d)),
+		Imag: bigdecAdd(bigdecMul(a, d), bigdecMul(b, c)),
+	}
+}
+
+func bigcomplexQuo(lc, rc BigcomplexValue) BigcomplexValue {
+	// (a+bi)/(c+di) = ((ac+bd) + (bc-ad)i) / (c²+d²)
+	a, b, c, d := lc.Real, lc.Imag, rc.Real, rc.Imag
+	denom := bigdecAdd(bigdecMul(c, c), bigdecMul(d, d))
+	return BigcomplexValue{
+		Real: bigdecQuo(bigdecAdd(bigdecMul(a, c), bigdecMul(b, d)), denom),
+		Imag: bigdecQuo(bigdecSub(bigdecMul(b, c), bigdecMul(a, d)), denom),
+	}
+}
+
+// Typed complex arithmetic works on the IEEE 754 bits of the parts, and
+// like the float cases rounds every operation through softfloat. It
+// follows what Go compiles to on amd64 (never fusing *+): complex64 is
+// widened to complex128 for multiplication and division, and division is
+// runtime.complex128div.
+
+const (
+	float64SignBit = 1 << 63
+	float64Inf     = 0x7FF0000000000000
+	float64One     = 0x3FF0000000000000
+)
+
+func complex128Mul(ar, ai, br, bi uint64) (re, im uint64) {
+	re = softfloat.Fsub64(softfloat.Fmul64(ar, br), softfloat.Fmul64(ai, bi))
+	im = softfloat.Fadd64(softfloat.Fmul64(ar, bi), softfloat.Fmul64(ai, br))
+	return
+}
+
+func complex64Mul(ar, ai, br, bi uint32) (re, im uint32) {
+	re64, im64 := complex128Mul(
+		softfloat.F32to64(ar), softfloat.F32to64(ai),
+		softfloat.F32to64(br), softfloat.F32to64(bi))
+	return softfloat.F64to32(re64), softfloat.F64to32(im64)
+}
+
+func complex64Quo(nr, ni, mr, mi uint32) (re, im uint32) {
+	re64, im64 := complex128Quo(
+		softfloat.F32to64(nr), softfloat.F32to64(ni),
+		softfloat.F32to64(mr), softfloat.F32to64(mi))
+	return softfloat.F64to32(re64), softfloat.F64to32(im64)
+}
+
+// complex128Quo returns n/m, using Smith's algorithm for robust complex
+// division, then correcting a NaN result to infinities and zeros where
+// applicable, as in C99 (ISO/IEC 9899:1999 - G.5.1).
+func complex128Quo(nr, ni, mr, mi uint64) (e, f uint64) {
+	add, sub, mul, div := softfloat.Fadd64, softfloat.Fsub64, softfloat.Fmul64, softfloat.Fdiv64
+	if softfloat.Fge64(mr&^float64SignBit, mi&^float64SignBit) {
+		ratio := div(mi, mr)
+		denom := add(mr, mul(ratio, mi))
+		e = div(add(nr, mul(ni, ratio)), denom)
+		f = div(sub(ni, mul(nr, ratio)), denom)
+	} else {
+		ratio := div(mr, mi)
+		denom := add(mi, mul(ratio, mr))
+		e = div(add(mul(nr, ratio), ni), denom)
+		f = div(sub(mul(ni, ratio), nr), denom)
+	}
+	if !float64IsNaN(e) || !float64IsNaN(f) {
+		return e, f
+	}
+	a, b, c, d := nr, ni, mr, mi
+	switch {
+	case isZeroFloat64(c) && isZeroFloat64(d) && (!float64IsNaN(a) || !float64IsNaN(b)):
+		inf := float64Copysign(float64Inf, c)
+		e = mul(inf, a)
+		f = mul(inf, b)
+	case (float64IsInf(a) || float64IsInf(b)) && float64IsFinite(c) && float64IsFinite(d):
+		a = float64Copysign(float64FromBool(float64IsInf(a)), a)
+		b = float64Copysign(float64FromBool(float64IsInf(b)), b)
+		e = mul(float64Inf, add(mul(a, c), mul(b, d)))
+		f = mul(float64Inf, sub(mul(b, c), mul(a, d)))
+	case (float64IsInf(c) || float64IsInf(d)) && float64IsFinite(a) && float64IsFinite(b):
+		c = float64Copysign(float64FromBool(float64IsInf(c)), c)
+		d = float64Copysign(float64FromBool(float64IsInf(d)), d)
+		e = mul(0, add(mul(a, c), mul(b, d)))
+		f = mul(0, sub(mul(b, c), mul(a, d)))
+	}
+	return e, f
+}
+
+func float64IsNaN(u uint64) bool {
+	_, _, _, _, nan := softfloat.Funpack64(u)
+	return nan
+}
+
+func float64IsInf(u uint64) bool {
+	_, _, _, inf, _ := softfloat.Funpack64(u)
+	return inf
+}
+
+func float64IsFinite(u uint64) bool {
+	_, _, _, inf, nan := softfloat.Funpack64(u)
+	return !inf && !nan
+}
+
+func isZeroFloat64(u uint64) bool {
+	return u&^float64SignBit == 0
+}
+
+func float64Copysign(u, sign uint64) uint64 {
+	return u&^float64SignBit | sign&float64SignBit
+}
+
+func float64FromBool(b bool) uint64 {
+	if b {
+		return float64One
+	}
+	return 0
+}
+
 // for doOpAdd and doOpAddAssign.
 func addAssign(alloc *Allocator, lv, rv *TypedValue) {
 	// set the result in lv.
@@ -915,6 +1075,16 @@ func addAssign(alloc *Allocator, lv, rv *TypedValue) {
 		lv.V = BigintValue{V: lb}
 	case UntypedBigdecType:
 		lv.V = bigdecAdd(lv.GetBigDec(), rv.GetBigDec())
+	case Complex64Type:
+		lr, li := lv.GetComplex64()
+		rr, ri := rv.GetComplex64()
+		lv.SetComplex64(softfloat.Fadd32(lr, rr), softfloat.Fadd32(li, ri))
+	case Complex128Type:
+		lr, li := lv.GetComplex128()
+		rr, ri := rv.GetComplex128()
+		lv.SetComplex128(softfloat.Fadd64(lr, rr), softfloat.Fadd64(li, ri))
+	case UntypedBigcomplexType:
+		lv.V = bigcomplexAdd(lv.GetBigComplex(), rv.GetBigComplex())
 	default:
 		panic(fmt.Sprintf(
 			"operators + and += not defined for %s",
@@ -962,6 +1132,16 @@ func subAssign(lv, rv *TypedValue) {
 		lv.V = BigintValue{V: lb}
 	case UntypedBigdecType:
 		lv.V = bigdecSub(lv.GetBigDec(), rv.GetBigDec())
+	case Complex64Type:
+		lr, li := lv.GetComplex64()
+		rr, ri := rv.GetComplex64()
+		lv.SetComplex64(softfloat.Fsub32(lr, rr), softfloat.Fsub32(li, ri))
+	case Complex128Type:
+		lr, li := lv.GetComplex128()
+		rr, ri := rv.GetComplex128()
+		lv.SetComplex128(softfloat.Fsub64(lr, rr), softfloat.Fsub64(li, ri))
+	case UntypedBigcomplexType:
+		lv.V = bigcomplexSub(lv.GetBigComplex(), rv.GetBigComplex())
 	default:
 		panic(fmt.Sprintf(
 			"operators - and -= not defined for %s",
@@ -1009,6 +1189,16 @@ func mulAssign(lv, rv *TypedValue) {
 		lv.V = BigintValue{V: lb}
 	case UntypedBigdecType:
 		lv.V = bigdecMul(lv.GetBigDec(), rv.GetBigDec())
+	case Complex64Type:
+		lr, li := lv.GetComplex64()
+		rr, ri := rv.GetComplex64()
+		lv.SetComplex64(complex64Mul(lr, li, rr, ri))
+	case Complex128Type:
+		lr, li := lv.GetComplex128()
+		rr, ri := rv.GetComplex128()
+		lv.SetComplex128(complex128Mul(lr, li, rr, ri))
+	case UntypedBigcomplexType:
+		lv.V = bigcomplexMul(lv.GetBigComplex(), rv.GetBigComplex())
 	default:
 		panic(fmt.Sprintf(
 			"operators * and *= not defined for %s",
@@ -1100,6 +1290,20 @@ func quoAssign(lv, rv *TypedValue) *Exception {
 			return expt
 		}
 		lv.V = bigdecQuo(lv.GetBigDec(), rb)
+	case Complex64Type:
+		lr, li := lv.GetComplex64()
+		rr, ri := rv.GetComplex64()
+		lv.SetComplex64(complex64Quo(lr, li, rr, ri))
+	case Complex128Type:
+		lr, li := lv.GetComplex128()
+		rr, ri := rv.GetComplex128()
+		lv.SetComplex128(complex128Quo(lr, li, rr, ri))
+	case UntypedBigcomplexType:
+		rc := rv.GetBigComplex()
+		if rc.IsZero() {
+			return expt
+		}
+		lv.V = bigcomplexQuo(lv.GetBigComplex(), rc)
 	default:
 		panic(fmt.Sprintf(
 			"operators / and /= not defined for %s",
@@ -0,0 +1,316 @@
+package gnolang
+
+import (
+	"fmt"
+)
+
+// NOTE: channel operations which cannot complete immediately park the
+// running goroutine (see goroutine.go). Before doing so, the op handler
+// re-pushes itself (and its expression or statement, which stay on the
+// stacks), so that once resumed it runs again and picks up the outcome of
+// the operation via m.takeWait().
+
+func (m *Machine) doOpGo() {
+	if m.DisallowConcurrency {
+		panic("goroutines are not permitted")
+	}
+	gs := m.PopStmt().(*GoStmt)
+	cx := &gs.Call
+	ftv := m.PeekValue(cx.NumArgs + 1)
+	switch fv := ftv.V.(type) {
+	case nil:
+		m.PopValues(cx.NumArgs + 1)
+		m.pushPanic(typedRuntimeError("go of nil func value"))
+		return
+	case *FuncValue:
+		if fv.IsCrossing() {
+			m.PopValues(cx.NumArgs + 1)
+			m.pushPanic(typedString(fmt.Sprintf(
+				"cannot start goroutine with crossing function %s", fv.Name)))
+			return
+		}
+	case *BoundMethodValue:
+		if fv.IsCrossing() {
+			m.PopValues(cx.NumArgs + 1)
+			m.pushPanic(typedString(fmt.Sprintf(
+				"cannot start goroutine with crossing method %s", fv.Func.Name)))
+			return
+		}
+	}
+	if cx.IsWithCross() {
+		m.PopValues(cx.NumArgs + 1)
+		m.pushPanic(typedString("cannot start goroutine with cross call"))
+		return
+	}
+	// The function value and arguments are evaluated in the calling
+	// goroutine; the call itself happens in the new one.
+	vals := make([]TypedValue, cx.NumArgs+1)
+	m.PopCopyValues(vals)
+	m.startGoroutine(cx, vals)
+}
+
+func (m *Machine) doOpGoexit() {
+	m.goexit()
+}
+
+func (m *Machine) doOpSend() {
+	xv := m.PeekValue(1) // value to send
+	cv := m.PeekValue(2) // channel
+	if ws := m.takeWait(); ws != nil {
+		m.PopValues(2)
+		if ws.closed {
+			m.Panic(typedRuntimeError("send on closed channel"))
+		}
+		return
+	}
+	var ws *waitState
+	if cv.V == nil {
+		// sending on a nil channel blocks forever.
+		ws = &waitState{}
+	} else {
+		ch := cv.V.(*ChanValue)
+		if ch.Closed {
+			m.PopValues(2)
+			m.Panic(typedRuntimeError("send on closed channel"))
+		}
+		v := xv.Copy(m.Alloc)
+		if !m.trySend(ch, v) {
+			ws = &waitState{}
+			ws.enqueueSend(ch, 0, v)
+		}
+	}
+	if ws != nil {
+		m.PushOp(OpSend)
+		m.block(ws)
+		return
+	}
+	m.PopValues(2)
+}
+
+func (m *Machine) doOpUrecv() {
+	ux := m.PopExpr().(*UnaryExpr)
+	xv := m.PeekValue(1) // channel; re-used for the result
+	v, _, ws := m.recv(xv)
+	if ws != nil {
+		m.PushOp(OpUrecv)
+		m.PushExpr(ux)
+		m.block(ws)
+		return
+	}
+	*xv = v
+}
+
+func (m *Machine) doOpUrecv2() {
+	ux := m.PopExpr().(*UnaryExpr)
+	xv := m.PeekValue(1) // channel; re-used for the result
+	v, ok, ws := m.recv(xv)
+	if ws != nil {
+		m.PushOp(OpUrecv2)
+		m.PushExpr(ux)
+		m.block(ws)
+		return
+	}
+	*xv = v
+	m.PushValue(untypedBool(ok))
+}
+
+// recv receives a value from the channel xv. If the running goroutine was
+// just resumed from a blocked receive, it returns the received value.
+// If the receive cannot complete without blocking, it returns the wait state
+// on which the caller must block.
+func (m *Machine) recv(xv *TypedValue) (v TypedValue, ok bool, ws *waitState) {
+	if ws = m.takeWait(); ws != nil {
+		v, ok = ws.value, ws.ok
+	} else if xv.V == nil {
+		// receiving from a nil channel blocks forever.
+		return v, ok, &waitState{}
+	} else {
+		ch := xv.V.(*ChanValue)
+		var done bool
+		if v, ok, done = m.tryRecv(ch); !done {
+			ws = &waitState{}
+			ws.enqueueRecv(ch, 0)
+			return v, ok, ws
+		}
+	}
+	if !ok {
+		v = defaultTypedValue(m.Alloc, baseOf(xv.T).(*ChanType).Elt)
+	}
+	return v, ok, nil
+}
+
+// selectRecvExpr returns the receive expression of a select receive case,
+// which is of the form `<-ch`, `x = <-ch` or `x, ok := <-ch`.
+func selectRecvExpr(comm Stmt) *UnaryExpr {
+	switch cs := comm.(type) {
+	case *ExprStmt:
+		return cs.X.(*UnaryExpr)
+	case *AssignStmt:
+		return cs.Rhs[0].(*UnaryExpr)
+	default:
+		panic(fmt.Sprintf("unexpected select case %v", comm))
+	}
+}
+
+// doOpSelect first evaluates the operands of each communication clause one
+// by one in source order, then picks the first ready case, or the default
+// case, or blocks until one of the cases becomes ready.
+//
+// The values stack holds (from the frame's NumValues):
+// the index of the next case to evaluate, followed by for each evaluated
+// case its block, channel, and for send cases the value to send.
+func (m *Machine) doOpSelect() {
+	ss := m.PeekStmt1().(*SelectStmt)
+	fr := m.LastFrame()
+	// Evaluate the operands of the next case, in the case's block: the
+	// preprocessor resolves names in the communication clause relative to
+	// it.
+	nciv := &m.Values[fr.NumValues] // next case index (reuse)
+	idx := int(nciv.GetInt())
+	for idx < len(ss.Cases) && ss.Cases[idx].Comm == nil {
+		idx++
+	}
+	if idx < len(ss.Cases) {
+		nciv.SetInt(int64(idx + 1))
+		sc := &ss.Cases[idx]
+		b := m.Alloc.NewBlock(sc, m.LastBlock())
+		m.PushValue(TypedValue{V: b})
+		m.PushOp(OpSelect)
+		m.PushOp(OpPopBlock)
+		m.PushBlock(b)
+		if cs, ok := sc.Comm.(*SendStmt); ok {
+			// evaluate value
+			m.PushExpr(cs.Value)
+			m.PushOp(OpEval)
+			// evaluate chan
+			m.PushExpr(cs.Chan)
+			m.PushOp(OpEval)
+		} else {
+			// evaluate chan
+			m.PushExpr(selectRecvExpr(sc.Comm).X)
+			m.PushOp(OpEval)
+		}
+		return
+	}
+
+	// All operands are evaluated; pick a case.
+	m.incrCPU(OpCPUSlopeSelectCase * int64(len(ss.Cases)))
+	vals := m.Values[fr.NumValues+1:]
+	blocks := make([]*Block, len(ss.Cases))
+	chans := make([]*TypedValue, len(ss.Cases))
+	sends := make([]*TypedValue, len(ss.Cases))
+	dflt := -1
+	for i, j := 0, 0; i < len(ss.Cases); i++ {
+		switch ss.Cases[i].Comm.(type) {
+		case nil:
+			dflt = i
+		case *SendStmt:
+			blocks[i] = vals[j].V.(*Block)
+			chans[i] = &vals[j+1]
+			sends[i] = &vals[j+2]
+			j += 3
+		default:
+			blocks[i] = vals[j].V.(*Block)
+			chans[i] = &vals[j+1]
+			j += 2
+		}
+	}
+	chosen := -1
+	var rv TypedValue // received value
+	var rok bool      // received ok
+	if ws := m.takeWait(); ws != nil {
+		if ws.closed {
+			m.Panic(typedRuntimeError("send on closed channel"))
+		}
+		chosen, rv, rok = ws.index, ws.value, ws.ok
+	} else {
+		for i := range ss.Cases {
+			if chans[i] == nil || chans[i].V == nil {
+				// default case, or nil channel (never ready).
+				continue
+			}
+			ch := chans[i].V.(*ChanValue)
+			if sends[i] != nil {
+				if ch.Closed {
+					m.Panic(typedRuntimeError("send on closed channel"))
+				}
+				if m.trySend(ch, sends[i].Copy(m.Alloc)) {
+					chosen = i
+					break
+				}
+			} else if v, ok, done := m.tryRecv(ch); done {
+				chosen, rv, rok = i, v, ok
+				break
+			}
+		}
+		if chosen < 0 {
+			chosen = dflt
+		}
+		if chosen < 0 {
+			// Block on all cases until one is ready.
+			ws := &waitState{}
+			for i := range ss.Cases {
+				if chans[i] == nil || chans[i].V == nil {
+					continue
+				}
+				ch := chans[i].V.(*ChanValue)
+				if sends[i] != nil {
+					ws.enqueueSend(ch, i, sends[i].Copy(m.Alloc))
+				} else {
+					ws.enqueueRecv(ch, i)
+				}
+			}
+			m.PushOp(OpSelect)
+			m.block(ws)
+			return
+		}
+	}
+	sc := &ss.Cases[chosen]
+	if chans[chosen] != nil && sends[chosen] == nil && !rok {
+		rv = defaultTypedValue(m.Alloc, baseOf(chans[chosen].T).(*ChanType).Elt)
+	}
+	b := blocks[chosen]
+	if b == nil {
+		b = m.Alloc.NewBlock(sc, m.LastBlock())
+	}
+	m.PopValues(len(m.Values) - fr.NumValues)
+	m.PopStmt() // pop select stmt
+	m.PushBlock(b)
+	m.PushOp(OpPopBlock)
+	// exec case body
+	if len(sc.Body) != 0 {
+		b.bodyStmt = bodyStmt{
+			Body:          sc.Body,
+			BodyLen:       len(sc.Body),
+			NextBodyIndex: -2,
+		}
+		m.PushOp(OpBody)
+		m.PushStmt(b.GetBodyStmt())
+	}
+	// assign the received value (and ok), if any.
+	if as, ok := sc.Comm.(*AssignStmt); ok {
+		rvs := []TypedValue{rv, untypedBool(rok)}[:len(as.Lhs)]
+		switch as.Op {
+		case DEFINE:
+			m.PushOp(OpDefine)
+			m.PushStmt(as)
+			for _, rv := range rvs {
+				m.PushValue(rv)
+			}
+		case ASSIGN:
+			m.PushOp(OpAssign)
+			m.PushStmt(as)
+			// evaluate rhs
+			for i := len(rvs) - 1; 0 <= i; i-- {
+				m.PushExpr(&ConstExpr{TypedValue: rvs[i]})
+				m.PushOp(OpEval)
+			}
+			// evaluate lhs
+			for i := len(as.Lhs) - 1; 0 <= i; i-- {
+				m.PushForPointer(as.Lhs[i])
+			}
+		default:
+			panic("should not happen")
+		}
+	}
+}
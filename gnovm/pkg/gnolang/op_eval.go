@@ -103,12 +103,17 @@ func (m *Machine) doOpEval() {
 				panic(fmt.Sprintf("unexpected decimal/float format %s", x.Value))
 			}
 		case IMAG:
-			// NOTE: this is a syntax and grammar problem, not an
-			// AST one.  Imaginaries should get evaluated as a
-			// type like any other.  See
-			// github.com/Quasilyte/go-complex-nums-emulation
-			// and github.com/golang/go/issues/19921
-			panic("imaginaries are not supported")
+			x.Value = strings.ReplaceAll(x.Value, blankIdentifier, "")
+			// The part before the "i" may be any integer or float
+			// literal; big.ParseFloat handles every base prefix, and
+			// a leading 0 is decimal as the spec requires.
+			m.PushValue(TypedValue{
+				T: UntypedBigcomplexType,
+				V: BigcomplexValue{
+					Real: BigdecValue{V: new(big.Rat)},
+					Imag: parseBigdecLiteral(strings.TrimSuffix(x.Value, "i"), "imaginary"),
+				},
+			})
 		case CHAR:
 			// Matching character literal parsing in go/constant.MakeFromLiteral.
 			val := x.Value
@@ -205,6 +210,9 @@ func (m *Machine) doOpEval() {
 		m.PushForPointer(x.X)
 	case *UnaryExpr:
 		op := word2UnaryOp(x.Op)
+		if x.HasOK {
+			op = OpUrecv2
+		}
 		m.PushOp(op)
 		// evaluate x
 		m.PushExpr(x.X)
@@ -278,6 +286,11 @@ func (m *Machine) doOpEval() {
 			m.PushExpr(&x.Params[i])
 			m.PushOp(OpEval)
 		}
+	case *ChanTypeExpr:
+		m.PushOp(OpChanType)
+		// evaluate elem type
+		m.PushExpr(x.Value)
+		m.PushOp(OpEval) // OpEvalType?
 	case *MapTypeExpr:
 		m.PopExpr()
 		m.PushOp(OpMapType)
@@ -38,8 +38,11 @@ SwitchStmt -> +block
     OpSwitchClauseCase
   OpTypeSwitch
 
-SelectStmt ->
-  OpSelectCase +block
+RangeStmt (chan) ->
+  OpRangeIterChan +block
+
+SelectStmt -> +block (per case)
+  OpSelect
 
 */
 
@@ -468,12 +471,82 @@ func (m *Machine) doOpExec(op Op) {
 				panic("should not happen")
 			}
 		}
+	case OpRangeIterChan:
+		bs := s.(*bodyStmt)
+		xv := m.PeekValue(1)
+		switch bs.NextBodyIndex {
+		case -2: // init.
+			bs.NumOps = len(m.Ops)
+			bs.NumValues = len(m.Values)
+			bs.NumExprs = len(m.Exprs)
+			bs.NumStmts = len(m.Stmts)
+			bs.NextBodyIndex++
+			fallthrough
+		case -1: // receive and assign next element.
+			v, ok, ws := m.recv(xv)
+			if ws != nil {
+				// NOTE: this op is sticky, so it runs again
+				// once the goroutine is resumed.
+				m.block(ws)
+				return
+			}
+			if !ok {
+				// channel closed and drained; done with range.
+				m.PopFrameAndReset()
+				return
+			}
+			if bs.Key != nil {
+				switch bs.Op {
+				case ASSIGN:
+					m.PopAsPointer(bs.Key).Assign2(m, m.Alloc, m.Store, m.Realm, v, false)
+				case DEFINE:
+					knx := bs.Key.(*NameExpr)
+					ptr := m.LastBlock().GetPointerToMaybeHeapDefine(m.Store, knx)
+					ptr.TV.Assign(m.Alloc, v, false)
+				default:
+					panic("should not happen")
+				}
+			}
+			bs.NextBodyIndex++
+			fallthrough
+		default:
+			if bs.NextBodyIndex < bs.BodyLen {
+				next := bs.Body[bs.NextBodyIndex]
+				bs.NextBodyIndex++
+				// continue onto exec stmt.
+				bs.Active = next
+				s = next // switch on bs.Active
+				goto EXEC_SWITCH
+			} else if bs.NextBodyIndex == bs.BodyLen {
+				// set up next assign if needed.
+				switch bs.Op {
+				case ASSIGN:
+					if bs.Key != nil {
+						m.PushForPointer(bs.Key)
+					}
+				case DEFINE:
+					// do nothing
+				case ILLEGAL:
+					// do nothing, no assignment
+				default:
+					panic("should not happen")
+				}
+				bs.NextBodyIndex = -1
+				bs.Active = nil
+				return // redo doOpExec:*bodyStmt
+			} else {
+				panic("should not happen")
+			}
+		}
 	}
 
 EXEC_SWITCH:
 	if debug {
 		debug.Printf("EXEC: %v\n", s)
 	}
+	if m.Coverage != nil {
+		m.Coverage.hit(m, s)
+	}
 	switch cs := s.(type) {
 	case *AssignStmt:
 		switch cs.Op {
@@ -639,6 +712,8 @@ EXEC_SWITCH:
 			m.PushOp(OpRangeIterString)
 		} else if cs.IsArrayPtr {
 			m.PushOp(OpRangeIterArrayPtr)
+		} else if cs.IsChan {
+			m.PushOp(OpRangeIterChan)
 		} else {
 			m.PushOp(OpRangeIter)
 		}
@@ -671,7 +746,7 @@ EXEC_SWITCH:
 			for {
 				fr := m.LastFrame()
 				switch fr.Source.(type) {
-				case *ForStmt, *RangeStmt, *SwitchStmt:
+				case *ForStmt, *RangeStmt, *SwitchStmt, *SelectStmt:
 					if cs.Label != "" && cs.Label != fr.Label {
 						m.PopFrame()
 					} else {
@@ -771,6 +846,32 @@ EXEC_SWITCH:
 		// evaluate func
 		m.PushExpr(cs.Call.Func)
 		m.PushOp(OpEval)
+	case *GoStmt:
+		m.PushOp(OpGo)
+		// evaluate args
+		args := cs.Call.Args
+		for i := len(args) - 1; 0 <= i; i-- {
+			m.PushExpr(args[i])
+			m.PushOp(OpEval)
+		}
+		// evaluate func
+		m.PushExpr(cs.Call.Func)
+		m.PushOp(OpEval)
+	case *SendStmt:
+		m.PopStmt()
+		m.PushOp(OpSend)
+		// evaluate value
+		m.PushExpr(cs.Value)
+		m.PushOp(OpEval)
+		// evaluate chan
+		m.PushExpr(cs.Chan)
+		m.PushOp(OpEval)
+	case *SelectStmt:
+		m.PushFrameBasic(cs)
+		m.PushOp(OpPopFrameAndReset)
+		m.PushOp(OpSelect)
+		// push next case index 0
+		m.PushValue(typedInt(0))
 	case *SwitchStmt:
 		m.PushFrameBasic(cs)
 		m.PushOp(OpPopFrameAndReset)
@@ -820,6 +921,9 @@ func (m *Machine) doOpIfCond() {
 	// Test cond and run Body or Else.
 	cond := m.PopValue()
 	if cond.GetBool() {
+		if m.Coverage != nil {
+			m.Coverage.branch(m, is, 0)
+		}
 		if len(is.Then.Body) != 0 {
 			// expand block size
 			b.ExpandWith(m.Alloc, &is.Then)
@@ -833,6 +937,9 @@ func (m *Machine) doOpIfCond() {
 			m.PushStmt(b.GetBodyStmt())
 		}
 	} else {
+		if m.Coverage != nil {
+			m.Coverage.branch(m, is, 1)
+		}
 		if len(is.Else.Body) != 0 {
 			// expand block size
 			b.ExpandWith(m.Alloc, &is.Else)
@@ -897,6 +1004,13 @@ matchLoop:
 	if matchedIdx < 0 {
 		matchedIdx = defaultIdx
 	}
+	if m.Coverage != nil {
+		if matchedIdx < 0 {
+			m.Coverage.branch(m, ss, len(ss.Clauses))
+		} else {
+			m.Coverage.branch(m, ss, matchedIdx)
+		}
+	}
 	if matchedIdx < 0 {
 		return // no clause matched and no default
 	}
@@ -951,6 +1065,13 @@ func (m *Machine) doOpSwitchClause() {
 		}
 		m.PopStmt()    // pop switch stmt
 		m.PopValues(3) // pop switch tag value, clause case index, clause index
+		if m.Coverage != nil {
+			if defaultIdx < 0 {
+				m.Coverage.branch(m, ss, len(ss.Clauses))
+			} else {
+				m.Coverage.branch(m, ss, defaultIdx)
+			}
+		}
 		if defaultIdx >= 0 {
 			cl := &ss.Clauses[defaultIdx]
 			b := m.LastBlock()
@@ -998,6 +1119,9 @@ func (m *Machine) doOpSwitchClauseCase() {
 		m.PopValues(3)                  // pop switch tag value, clause case index, clause index
 		// expand block size
 		clidx := cliv.GetInt()
+		if m.Coverage != nil {
+			m.Coverage.branch(m, ss, int(clidx))
+		}
 		cl := &ss.Clauses[clidx]
 		b := m.LastBlock()
 		b.ExpandWith(m.Alloc, cl)
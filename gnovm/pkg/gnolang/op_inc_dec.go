@@ -16,7 +16,7 @@ func (m *Machine) doOpInc() {
 
 	// Gas based on operand type.
 	switch lv.T.Kind() {
-	case Float32Kind, Float64Kind:
+	case Float32Kind, Float64Kind, Complex64Kind, Complex128Kind:
 		m.incrCPU(OpCPUIncFloat)
 	default:
 		m.incrCPU(OpCPUIncInt)
@@ -75,6 +75,18 @@ func (m *Machine) doOpInc() {
 		lv.V = BigintValue{V: lb}
 	case UntypedBigdecType:
 		lv.V = bigdecAdd(lv.GetBigDec(), BigdecValue{V: new(big.Rat).SetInt64(1)})
+	case Complex64Type:
+		re, im := lv.GetComplex64()
+		lv.SetComplex64(softfloat.Fadd32(re, softfloat.Fintto32(1)), im)
+	case Complex128Type:
+		re, im := lv.GetComplex128()
+		lv.SetComplex128(softfloat.Fadd64(re, softfloat.Fintto64(1)), im)
+	case UntypedBigcomplexType:
+		bcv := lv.GetBigComplex()
+		lv.V = BigcomplexValue{
+			Real: bigdecAdd(bcv.Real, BigdecValue{V: new(big.Rat).SetInt64(1)}),
+			Imag: bcv.Imag,
+		}
 	default:
 		panic(fmt.Sprintf("unexpected type %s in inc/dec operation", lv.T))
 	}
@@ -94,7 +106,7 @@ func (m *Machine) doOpDec() {
 
 	// Gas based on operand type.
 	switch lv.T.Kind() {
-	case Float32Kind, Float64Kind:
+	case Float32Kind, Float64Kind, Complex64Kind, Complex128Kind:
 		m.incrCPU(OpCPUDecFloat)
 	default:
 		m.incrCPU(OpCPUDecInt)
@@ -149,6 +161,18 @@ func (m *Machine) doOpDec() {
 		lv.V = BigintValue{V: lb}
 	case UntypedBigdecType:
 		lv.V = bigdecSub(lv.GetBigDec(), BigdecValue{V: new(big.Rat).SetInt64(1)})
+	case Complex64Type:
+		re, im := lv.GetComplex64()
+		lv.SetComplex64(softfloat.Fsub32(re, softfloat.Fintto32(1)), im)
+	case Complex128Type:
+		re, im := lv.GetComplex128()
+		lv.SetComplex128(softfloat.Fsub64(re, softfloat.Fintto64(1)), im)
+	case UntypedBigcomplexType:
+		bcv := lv.GetBigComplex()
+		lv.V = BigcomplexValue{
+			Real: bigdecSub(bcv.Real, BigdecValue{V: new(big.Rat).SetInt64(1)}),
+			Imag: bcv.Imag,
+		}
 	default:
 		panic(fmt.Sprintf("unexpected type %s in inc/dec operation", lv.T))
 	}
@@ -70,6 +70,19 @@ func (m *Machine) doOpSliceType() {
 	}
 }
 
+func (m *Machine) doOpChanType() {
+	x := m.PopExpr().(*ChanTypeExpr)
+	tv := m.PeekValue(1) // re-use as result.
+	t := &ChanType{
+		Dir: x.Dir,
+		Elt: tv.GetType(),
+	}
+	*tv = TypedValue{
+		T: gTypeType,
+		V: toTypeValue(t),
+	}
+}
+
 func (m *Machine) doOpFuncType() {
 	x := m.PopExpr().(*FuncTypeExpr)
 	m.incrCPU(OpCPUSlopeFuncType * int64(len(x.Params)+len(x.Results)))
@@ -448,6 +461,12 @@ func (m *Machine) doOpStaticTypeOf() {
 			m.PushOp(OpEval)
 		}
 	case *UnaryExpr:
+		if x.Op == ARROW {
+			// The static type of <-ch is the element type.
+			xt := m.staticTypeOfX(x.X)
+			m.PushValue(asValue(xt.Elem()))
+			return
+		}
 		m.PushExpr(x.X)
 		m.PushOp(OpStaticTypeOf)
 	case *CompositeLitExpr:
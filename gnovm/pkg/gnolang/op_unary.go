@@ -61,6 +61,19 @@ func (m *Machine) doOpUneg() {
 		} else {
 			xv.V = BigdecValue{V: new(big.Rat).Neg(bdv.V)}
 		}
+	case Complex64Type:
+		re, im := xv.GetComplex64()
+		xv.SetComplex64(softfloat.Fneg32(re), softfloat.Fneg32(im))
+	case Complex128Type:
+		re, im := xv.GetComplex128()
+		xv.SetComplex128(softfloat.Fneg64(re), softfloat.Fneg64(im))
+	case UntypedBigcomplexType:
+		bcv := xv.V.(BigcomplexValue)
+		zero := BigdecValue{V: new(big.Rat)}
+		xv.V = BigcomplexValue{
+			Real: bigdecSub(zero, bcv.Real),
+			Imag: bigdecSub(zero, bcv.Imag),
+		}
 	case nil:
 		// NOTE: for now only BigintValue is possible.
 		biv := xv.V.(BigintValue)
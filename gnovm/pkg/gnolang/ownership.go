@@ -446,7 +446,8 @@ func (tv *TypedValue) GetFirstObject(store Store) Object {
 // the package may always read/copy regardless of rid — e.g. stdlib or a /p/
 // library reading its own immutable tables while running under a caller's
 // realm (those callables don't borrow, so m.Realm is the caller's, not the
-// library's). Pass a zero PkgID to disable this exemption.
+// library's). Pass a zero PkgID to disable this exemption. A zero rid
+// makes every real object readonly instead, as in a read-only view.
 //
 // This is different from GetFirstObject in two significant ways:
 //  1. IsReadonlyBy does not go through RefValues; for this reason, it
@@ -530,6 +531,11 @@ func (tv *TypedValue) IsReadonlyBy(rid, ownPkgID PkgID) bool {
 	if tvoid.IsZero() {
 		return false
 	}
+	// read-only view: every real object is readonly, but new ones are not
+	// (whatever their alloc-site stamp) until they are persisted.
+	if rid.IsZero() {
+		return tvoid.IsFinalized() && tvoid.PkgID != ownPkgID
+	}
 	// tv is an object residing in external realm — unless it is the
 	// executing package's own package-level data (stamped ownPkgID),
 	// which the package may always read/copy regardless of m.Realm.
@@ -16,6 +16,8 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	StringValue(""),
 	BigintValue{},
 	BigdecValue{},
+	Complex128Value{},
+	BigcomplexValue{},
 	// DataByteValue{}
 	PointerValue{},
 	&ArrayValue{},
@@ -55,6 +57,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	BinaryExpr{},
 	CallExpr{},
 	IndexExpr{},
+	IndexListExpr{},
 	SelectorExpr{},
 	SliceExpr{},
 	StarExpr{},
@@ -20,6 +20,8 @@ func init() {
 	amino.RegisterGenproto2Type(reflect.TypeOf((*StringValue)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*BigintValue)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*BigdecValue)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*Complex128Value)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*BigcomplexValue)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*PointerValue)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ArrayValue)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*SliceValue)(nil)).Elem())
@@ -48,6 +50,7 @@ func init() {
 	amino.RegisterGenproto2Type(reflect.TypeOf((*BinaryExpr)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*CallExpr)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*IndexExpr)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*IndexListExpr)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*SelectorExpr)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*SliceExpr)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*StarExpr)(nil)).Elem())
@@ -411,6 +414,202 @@ func (goo *BigdecValue) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth i
 	return goo.UnmarshalAmino(repr)
 }
 
+func (goo Complex128Value) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	if goo.Imag != 0 {
+		{
+			before := offset
+			offset = amino.PrependUvarint(buf, offset, uint64(goo.Imag))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Real != 0 {
+		{
+			before := offset
+			offset = amino.PrependUvarint(buf, offset, uint64(goo.Real))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	return offset, err
+}
+
+func (goo Complex128Value) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	if goo.Real != 0 {
+		s += 1 + amino.UvarintSize(uint64(goo.Real))
+	}
+	if goo.Imag != 0 {
+		s += 1 + amino.UvarintSize(uint64(goo.Imag))
+	}
+	return s, nil
+}
+
+func (goo *Complex128Value) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = Complex128Value{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeUvarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Real = uint64(v)
+		case 2:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeUvarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Imag = uint64(v)
+		default:
+			return fmt.Errorf("unknown field number %d for Complex128Value", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo BigcomplexValue) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	{
+		repr, err := goo.Imag.MarshalAmino()
+		if err != nil {
+			return offset, err
+		}
+		if repr != "" {
+			{
+				before := offset
+				offset = amino.PrependString(buf, offset, string(repr))
+				valueLen := before - offset
+				if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+					offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+				} else {
+					offset = before
+				}
+			}
+		}
+	}
+	{
+		repr, err := goo.Real.MarshalAmino()
+		if err != nil {
+			return offset, err
+		}
+		if repr != "" {
+			{
+				before := offset
+				offset = amino.PrependString(buf, offset, string(repr))
+				valueLen := before - offset
+				if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+					offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+				} else {
+					offset = before
+				}
+			}
+		}
+	}
+	return offset, err
+}
+
+func (goo BigcomplexValue) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		repr, err := goo.Real.MarshalAmino()
+		if err != nil {
+			return 0, err
+		}
+		if repr != "" {
+			s += 1 + amino.UvarintSize(uint64(len(repr))) + len(repr)
+		}
+	}
+	{
+		repr, err := goo.Imag.MarshalAmino()
+		if err != nil {
+			return 0, err
+		}
+		if repr != "" {
+			s += 1 + amino.UvarintSize(uint64(len(repr))) + len(repr)
+		}
+	}
+	return s, nil
+}
+
+func (goo *BigcomplexValue) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = BigcomplexValue{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			var repr string
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			repr = string(v)
+			if err := goo.Real.UnmarshalAmino(repr); err != nil {
+				return err
+			}
+		case 2:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			var repr string
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			repr = string(v)
+			if err := goo.Imag.UnmarshalAmino(repr); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown field number %d for BigcomplexValue", fnum)
+		}
+	}
+	return nil
+}
+
 func (goo PointerValue) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
 	if goo.Index != 0 {
@@ -3430,6 +3629,18 @@ func (goo *ValuePath) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int
 
 func (goo Location) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
+	if goo.Inst != "" {
+		{
+			before := offset
+			offset = amino.PrependString(buf, offset, string(goo.Inst))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
 	{
 		before := offset
 		offset, err = goo.Span.MarshalBinary2(cdc, buf, offset)
@@ -3488,6 +3699,9 @@ func (goo Location) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
+	if goo.Inst != "" {
+		s += 1 + amino.UvarintSize(uint64(len(goo.Inst))) + len(goo.Inst)
+	}
 	return s, nil
 }
 
@@ -3538,6 +3752,16 @@ func (goo *Location) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int)
 			if err := goo.Span.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
 				return err
 			}
+		case 4:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Inst = string(v)
 		default:
 			return fmt.Errorf("unknown field number %d for Location", fnum)
 		}
@@ -4463,18 +4687,193 @@ func (goo *IndexExpr) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int
 					return err
 				}
 			}
-		case 4:
-			if typ3 != amino.Typ3Varint {
-				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
-			}
-			v, n, err := amino.DecodeBool(bz)
-			if err != nil {
-				return err
-			}
-			bz = bz[n:]
-			goo.HasOK = bool(v)
+		case 4:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeBool(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.HasOK = bool(v)
+		default:
+			return fmt.Errorf("unknown field number %d for IndexExpr", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo IndexListExpr) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	for i := len(goo.Indices) - 1; i >= 0; i-- {
+		elem := goo.Indices[i]
+		if elem != nil {
+			before := offset
+			offset, err = cdc.MarshalAnyBinary2(elem, buf, offset)
+			if err != nil {
+				return offset, err
+			}
+			anyLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
+		} else {
+			offset = amino.PrependByte(buf, offset, 0x00)
+		}
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+	}
+	if goo.X != nil {
+		if goo.X != nil {
+			before := offset
+			offset, err = cdc.MarshalAnyBinary2(goo.X, buf, offset)
+			if err != nil {
+				return offset, err
+			}
+			anyLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+		}
+	}
+	{
+		before := offset
+		offset, err = goo.Attributes.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo IndexListExpr) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.Attributes.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	if goo.X != nil {
+		if goo.X != nil {
+			cs, err := cdc.SizeAnyBinary2(goo.X)
+			if err != nil {
+				return 0, err
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	for _, elem := range goo.Indices {
+		if elem != nil {
+			cs, err := cdc.SizeAnyBinary2(elem)
+			if err != nil {
+				return 0, err
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		} else {
+			s += 1 + 1
+		}
+	}
+	return s, nil
+}
+
+func (goo *IndexListExpr) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = IndexListExpr{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := goo.Attributes.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		case 2:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(fbz) > 0 {
+				if err := cdc.UnmarshalAnyBinary2(fbz, &goo.X, anyDepth); err != nil {
+					return err
+				}
+			}
+		case 3:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(fbz) > 0 {
+				var ev Expr
+				if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
+					return err
+				}
+				goo.Indices = append(goo.Indices, ev)
+			} else {
+				goo.Indices = append(goo.Indices, nil)
+			}
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 3 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if len(fbz) > 0 {
+					var ev Expr
+					if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
+						return err
+					}
+					goo.Indices = append(goo.Indices, ev)
+				} else {
+					goo.Indices = append(goo.Indices, nil)
+				}
+			}
 		default:
-			return fmt.Errorf("unknown field number %d for IndexExpr", fnum)
+			return fmt.Errorf("unknown field number %d for IndexListExpr", fnum)
 		}
 	}
 	return nil
@@ -5217,6 +5616,18 @@ func (goo *TypeAssertExpr) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDept
 
 func (goo UnaryExpr) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
+	if goo.HasOK {
+		{
+			before := offset
+			offset = amino.PrependBool(buf, offset, bool(goo.HasOK))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
 	if goo.Op != 0 {
 		{
 			before := offset
@@ -5281,6 +5692,9 @@ func (goo UnaryExpr) SizeBinary2(cdc *amino.Codec) (int, error) {
 	if goo.Op != 0 {
 		s += 1 + amino.VarintSize(int64(goo.Op))
 	}
+	if goo.HasOK {
+		s += 1 + 1
+	}
 	return s, nil
 }
 
@@ -5335,6 +5749,16 @@ func (goo *UnaryExpr) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int
 			}
 			bz = bz[n:]
 			goo.Op = Word(v)
+		case 4:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeBool(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.HasOK = bool(v)
 		default:
 			return fmt.Errorf("unknown field number %d for UnaryExpr", fnum)
 		}
@@ -9245,6 +9669,18 @@ func (goo *IncDecStmt) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth in
 
 func (goo RangeStmt) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
+	if goo.IsChan {
+		{
+			before := offset
+			offset = amino.PrependBool(buf, offset, bool(goo.IsChan))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 11, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
 	if goo.IsArrayPtr {
 		{
 			before := offset
@@ -9445,6 +9881,9 @@ func (goo RangeStmt) SizeBinary2(cdc *amino.Codec) (int, error) {
 	if goo.IsArrayPtr {
 		s += 1 + 1
 	}
+	if goo.IsChan {
+		s += 1 + 1
+	}
 	return s, nil
 }
 
@@ -9616,6 +10055,16 @@ func (goo *RangeStmt) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int
 			}
 			bz = bz[n:]
 			goo.IsArrayPtr = bool(v)
+		case 11:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 11: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeBool(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.IsChan = bool(v)
 		default:
 			return fmt.Errorf("unknown field number %d for RangeStmt", fnum)
 		}
@@ -11545,7 +11994,7 @@ func (goo FuncDecl) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (in
 		} else {
 			offset = amino.PrependByte(buf, offset, 0x00)
 		}
-		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 7, amino.Typ3ByteLength)
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 8, amino.Typ3ByteLength)
 	}
 	{
 		before := offset
@@ -11556,11 +12005,22 @@ func (goo FuncDecl) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (in
 		dataLen := before - offset
 		if dataLen > 0 {
 			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 6, amino.Typ3ByteLength)
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 7, amino.Typ3ByteLength)
 		} else {
 			offset = before
 		}
 	}
+	for i := len(goo.TypeParams) - 1; i >= 0; i-- {
+		elem := goo.TypeParams[i]
+		before := offset
+		offset, err = elem.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 6, amino.Typ3ByteLength)
+	}
 	{
 		before := offset
 		offset, err = goo.Recv.MarshalBinary2(cdc, buf, offset)
@@ -11673,6 +12133,13 @@ func (goo FuncDecl) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
+	for _, elem := range goo.TypeParams {
+		cs, err := elem.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		s += 1 + amino.UvarintSize(uint64(cs)) + cs
+	}
 	{
 		cs, err := goo.Type.SizeBinary2(cdc)
 		if err != nil {
@@ -11773,14 +12240,41 @@ func (goo *FuncDecl) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int)
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 6: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
+			var ev FieldTypeExpr
 			fbz, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			if err := goo.Type.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
 				return err
 			}
+			goo.TypeParams = append(goo.TypeParams, ev)
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 6 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 6: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				var ev FieldTypeExpr
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
+				}
+				goo.TypeParams = append(goo.TypeParams, ev)
+			}
 		case 7:
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 7: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
@@ -11790,6 +12284,18 @@ func (goo *FuncDecl) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int)
 				return err
 			}
 			bz = bz[n:]
+			if err := goo.Type.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		case 8:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 8: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
 			if len(fbz) > 0 {
 				var ev Stmt
 				if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
@@ -11806,11 +12312,11 @@ func (goo *FuncDecl) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int)
 				if err != nil {
 					return err
 				}
-				if nextFnum != 7 {
+				if nextFnum != 8 {
 					break
 				}
 				if nextTyp3 != amino.Typ3ByteLength {
-					return fmt.Errorf("field 7: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+					return fmt.Errorf("field 8: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
 				}
 				bz = bz[n:]
 				fbz, n, err := amino.DecodeByteSlice(bz)
@@ -12227,7 +12733,7 @@ func (goo TypeDecl) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (in
 			offset = amino.PrependBool(buf, offset, bool(goo.IsAlias))
 			valueLen := before - offset
 			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3Varint)
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 5, amino.Typ3Varint)
 			} else {
 				offset = before
 			}
@@ -12242,8 +12748,19 @@ func (goo TypeDecl) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (in
 			}
 			anyLen := before - offset
 			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
-			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
+		}
+	}
+	for i := len(goo.TypeParams) - 1; i >= 0; i-- {
+		elem := goo.TypeParams[i]
+		before := offset
+		offset, err = elem.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
 		}
+		dataLen := before - offset
+		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
 	}
 	{
 		before := offset
@@ -12296,6 +12813,13 @@ func (goo TypeDecl) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
+	for _, elem := range goo.TypeParams {
+		cs, err := elem.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		s += 1 + amino.UvarintSize(uint64(cs)) + cs
+	}
 	if goo.Type != nil {
 		if goo.Type != nil {
 			cs, err := cdc.SizeAnyBinary2(goo.Type)
@@ -12354,6 +12878,45 @@ func (goo *TypeDecl) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int)
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
+			var ev FieldTypeExpr
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+			goo.TypeParams = append(goo.TypeParams, ev)
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 3 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				var ev FieldTypeExpr
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
+				}
+				goo.TypeParams = append(goo.TypeParams, ev)
+			}
+		case 4:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
 			fbz, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
@@ -12364,9 +12927,9 @@ func (goo *TypeDecl) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int)
 					return err
 				}
 			}
-		case 4:
+		case 5:
 			if typ3 != amino.Typ3Varint {
-				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+				return fmt.Errorf("field 5: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
 			}
 			v, n, err := amino.DecodeBool(bz)
 			if err != nil {
@@ -14888,6 +15451,21 @@ func (goo *TypeType) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int)
 
 func (goo DeclaredType) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
+	for i := len(goo.TypeArgs) - 1; i >= 0; i-- {
+		elem := goo.TypeArgs[i]
+		if elem != nil {
+			before := offset
+			offset, err = cdc.MarshalAnyBinary2(elem, buf, offset)
+			if err != nil {
+				return offset, err
+			}
+			anyLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
+		} else {
+			offset = amino.PrependByte(buf, offset, 0x00)
+		}
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 6, amino.Typ3ByteLength)
+	}
 	for i := len(goo.Methods) - 1; i >= 0; i-- {
 		elem := goo.Methods[i]
 		before := offset
@@ -14985,6 +15563,17 @@ func (goo DeclaredType) SizeBinary2(cdc *amino.Codec) (int, error) {
 		}
 		s += 1 + amino.UvarintSize(uint64(cs)) + cs
 	}
+	for _, elem := range goo.TypeArgs {
+		if elem != nil {
+			cs, err := cdc.SizeAnyBinary2(elem)
+			if err != nil {
+				return 0, err
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		} else {
+			s += 1 + 1
+		}
+	}
 	return s, nil
 }
 
@@ -15088,6 +15677,53 @@ func (goo *DeclaredType) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 				}
 				goo.Methods = append(goo.Methods, ev)
 			}
+		case 6:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 6: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(fbz) > 0 {
+				var ev Type
+				if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
+					return err
+				}
+				goo.TypeArgs = append(goo.TypeArgs, ev)
+			} else {
+				goo.TypeArgs = append(goo.TypeArgs, nil)
+			}
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 6 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 6: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if len(fbz) > 0 {
+					var ev Type
+					if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
+						return err
+					}
+					goo.TypeArgs = append(goo.TypeArgs, ev)
+				} else {
+					goo.TypeArgs = append(goo.TypeArgs, nil)
+				}
+			}
 		default:
 			return fmt.Errorf("unknown field number %d for DeclaredType", fnum)
 		}
@@ -42,6 +42,8 @@ func PredefineFileSet(store Store, pn *PackageNode, fset *FileSet) {
 		setNodeLocations(pn.PkgPath, fn.FileName, fn)
 		initStaticBlocks(store, pn, fn)
 	}
+	// Bodies of generic instances are preprocessed at the end.
+	predefining := beginPredefine(pn)
 	// NOTE: much of what follows is duplicated for a single *FileNode
 	// in the main Preprocess translation function.  Keep synced.
 
@@ -175,6 +177,9 @@ func PredefineFileSet(store Store, pn *PackageNode, fset *FileSet) {
 			}
 		}
 	}
+	if predefining {
+		endPredefine(pn)
+	}
 }
 
 func initStaticBlocks(store Store, ctx BlockNode, nn Node) {
@@ -250,6 +255,10 @@ func initStaticBlocks1(store Store, ctx BlockNode, nn Node) {
 
 		switch stage {
 		case TRANS_ENTER:
+			if isGenericDecl(n) {
+				// templates are initialized per instance.
+				return n, TRANS_SKIP
+			}
 			// FuncLit/FuncDecl push at ENTER (not BLOCK) so param/result/recv
 			// masking takes effect before the FuncTypeExpr children are walked.
 			switch n := n.(type) {
@@ -455,8 +464,16 @@ func initStaticBlocks2(store Store, ctx BlockNode, nn Node) {
 				nx := &n.NameExpr
 				nx.Type = NameExprTypeDefine
 				last2.Reserve(true, nx, n, NSTypeDecl, -1)
+				if isGenericDecl(n) {
+					// templates are initialized per instance.
+					return n, TRANS_SKIP
+				}
 			case *FuncDecl:
 				if n.IsMethod {
+					if isGenericDecl(n) {
+						// instantiated with the receiver type.
+						return n, TRANS_SKIP
+					}
 					if n.Recv.Name == "" || n.Recv.Name == blankIdentifier {
 						// create a hidden var with leading dot.
 						// NOTE: document somewhere.
@@ -477,10 +494,18 @@ func initStaticBlocks2(store Store, ctx BlockNode, nn Node) {
 						dname := Name(fmt.Sprintf("._%d", idx))
 						n.Name = dname
 					}
+					if n.HasAttribute(ATTR_GENERIC_INST) {
+						// instances are not declared.
+						break
+					}
 					nx := &n.NameExpr
 					nx.Type = NameExprTypeDefine
 					pkg.Reserve(false, nx, n, NSFuncDecl, -1)
 					pkg.UnassignableNames = append(pkg.UnassignableNames, n.Name)
+					if isGenericDecl(n) {
+						// templates are initialized per instance.
+						return n, TRANS_SKIP
+					}
 				}
 			case *FuncTypeExpr:
 				for i := range n.Params {
@@ -789,6 +814,13 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 				// but for testing convenience we allow
 				// importing directly onto the package.
 				// Uverse requires this.
+				if isGenericDecl(n) {
+					// templates are preprocessed per instance.
+					if n.GetAttribute(ATTR_PREDEFINED) != true {
+						predefineRecursively(store, last, n.(Decl))
+					}
+					return n, TRANS_SKIP
+				}
 				if n.GetAttribute(ATTR_PREDEFINED) == true {
 					// skip declarations already predefined
 					// (e.g. through recursion for a dependent)
@@ -895,9 +927,18 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					xt = xt.Elem()
 					n.IsArrayPtr = true
 				case ArrayKind, SliceKind:
+				case ChanKind:
+					if baseOf(xt).(*ChanType).Dir == SEND {
+						panic(fmt.Sprintf(
+							"invalid operation: range %s receive from send-only channel", n.X))
+					}
+					if n.Value != nil {
+						panic("range over channel permits only one iteration variable")
+					}
+					n.IsChan = true
 				default:
 					panic(fmt.Sprintf(
-						"range iteration requires map, string, array, slice, or pointer to array; got %s",
+						"range iteration requires map, string, array, slice, chan, or pointer to array; got %s",
 						xt.Kind().String(),
 					))
 				}
@@ -914,6 +955,12 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 							vn := n.Value.(*NameExpr).Name
 							last.Define(vn, anyValue(vt))
 						}
+					} else if xt.Kind() == ChanKind {
+						if n.Key != nil {
+							et := xt.Elem()
+							kn := n.Key.(*NameExpr).Name
+							last.Define(kn, anyValue(et))
+						}
 					} else if xt.Kind() == StringKind {
 						if n.Key != nil {
 							it := IntType
@@ -1069,6 +1116,13 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					}
 				}
 
+			// TRANS_BLOCK -----------------------
+			case *SelectCaseStmt:
+				// NOTE: unlike *SwitchStmt, *SelectStmt has no
+				// faux block, as it has no .Init; names defined by
+				// the receive statement live in the case block.
+				pushInitBlock(n, &last, &stack)
+
 			// TRANS_BLOCK -----------------------
 			case *FuncDecl:
 				// retrieve cached function type.
@@ -1121,6 +1175,8 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					// declarations.  (this must happen
 					// after pushInitBlock above, otherwise
 					// it would happen @ *FileNode:ENTER)
+					pn := packageOf(n)
+					predefining := beginPredefine(pn)
 
 					// Predefine all import decls.
 					for i := range n.Decls {
@@ -1188,6 +1244,11 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 							n.Decls[i] = d
 						}
 					}
+					// Preprocess the bodies of generic
+					// instances now that all is predefined.
+					if predefining {
+						endPredefine(pn)
+					}
 				}
 
 			// TRANS_BLOCK -----------------------
@@ -1357,6 +1418,10 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 						}
 						return cx, TRANS_CONTINUE
 					}
+					// Generic func or type, instantiated by the parent.
+					if tt, ok := last.GetStaticTypeOfAt(store, n.Path).(*templateType); ok {
+						return genericRef(n, tt, ftype), TRANS_CONTINUE
+					}
 					// Is const decl or type decl. Not (import) packages.
 					if last.GetIsConst(store, n.Name) {
 						// n.Name may refer to either
@@ -1538,6 +1603,10 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 				}
 			// TRANS_LEAVE -----------------------
 			case *CallExpr:
+				// Generic func, possibly partially instantiated.
+				if n.Func.HasAttribute(ATTR_GENERIC) {
+					instantiateCall(store, last, n)
+				}
 				// Func type evaluation.
 				nft := evalStaticTypeOf(store, last, n.Func)
 				switch bnft := baseOf(nft).(type) {
@@ -1570,7 +1639,11 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 						// and the error is handled here.
 						// Out of bounds errors are usually handled during evalConst().
 						if isWhole(ct) {
-							if bd, ok := arg0.TypedValue.V.(BigdecValue); ok {
+							bd, ok := arg0.TypedValue.V.(BigdecValue)
+							if bc, isBc := arg0.TypedValue.V.(BigcomplexValue); isBc && bc.Imag.IsZero() {
+								bd, ok = bc.Real, true
+							}
+							if ok {
 								if !bd.IsInt() {
 									panic(fmt.Sprintf(
 										"cannot convert %s to integer type",
@@ -1591,9 +1664,22 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 							// the float target, so it must not take the
 							// default-type (int) path below.
 							switch at.Kind() {
-							case BigintKind, BigdecKind, Float32Kind, Float64Kind:
+							case BigintKind, BigdecKind, BigcomplexKind, Float32Kind, Float64Kind:
+								convertConst(store, last, n, arg0, ct)
+								constConverted = true
+							}
+						} else if isComplex(ct) {
+							// Like floats above; a typed non-complex
+							// constant is rejected by ConvertTo.
+							switch at.Kind() {
+							case BigintKind, BigdecKind, BigcomplexKind, Complex64Kind, Complex128Kind:
 								convertConst(store, last, n, arg0, ct)
 								constConverted = true
+							case Int32Kind:
+								if isUntyped(at) { // rune
+									convertConst(store, last, n, arg0, ct)
+									constConverted = true
+								}
 							}
 						} else if ct.Kind() == SliceKind {
 							switch ct.Elem().Kind() {
@@ -1685,6 +1771,10 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					}
 					if isNumeric(ctBase) {
 						if isNumeric(atBase) {
+							if isComplex(ctBase) != isComplex(atBase) && !isUntyped(atBase) {
+								panic(fmt.Sprintf("cannot convert %v to %v",
+									at, ct))
+							}
 							// The conversion is legal, set the target type.
 							n.SetAttribute(ATTR_TYPEOF_VALUE, ct)
 							return n, TRANS_CONTINUE
@@ -1857,10 +1947,14 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 										tt, len(n.Args)))
 								}
 							case *ChanType:
-								panic("channel type is not yet supported")
+								if len(n.Args) > 2 {
+									panic(fmt.Sprintf(
+										"invalid operation: make(%s) expects 1 or 2 arguments; found %d",
+										tt, len(n.Args)))
+								}
 							default:
 								panic(fmt.Sprintf(
-									"invalid argument: cannot make %s; type must be slice, map", tt))
+									"invalid argument: cannot make %s; type must be slice, map, or channel", tt))
 							}
 
 							// Reject negative constant size arguments (len, cap, hint).
@@ -1927,6 +2021,116 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 								}
 							}
 
+							return n, TRANS_CONTINUE
+						case "complex", "real", "imag":
+							// Like make(), complex/real/imag are declared with
+							// permissive any params and results; here the call
+							// is given a concrete signature, and constant
+							// arguments are folded into a constant result.
+							if n.Varg {
+								panic(fmt.Sprintf(
+									"invalid operation: invalid use of ... with built-in %s", fv.Name))
+							}
+							numParams := 1
+							if fv.Name == "complex" {
+								numParams = 2
+							}
+							if len(n.Args) < numParams {
+								panic(fmt.Sprintf(
+									"not enough arguments for %s() (expected %d, found %d)",
+									fv.Name, numParams, len(n.Args)))
+							} else if len(n.Args) > numParams {
+								panic(fmt.Sprintf(
+									"too many arguments for %s() (expected %d, found %d)",
+									fv.Name, numParams, len(n.Args)))
+							}
+							ats := make([]Type, len(n.Args))
+							allConst, allUntyped := true, true
+							for i, arg := range n.Args {
+								ats[i] = evalStaticTypeOf(store, last, arg)
+								if !isConst(arg) {
+									allConst = false
+								}
+								if !isUntyped(ats[i]) {
+									allUntyped = false
+								}
+							}
+							// Untyped constant arguments produce an untyped
+							// constant result.
+							if allConst && allUntyped {
+								return evalConstComplexBuiltin(n, fv.Name), TRANS_CONTINUE
+							}
+
+							// Determine the concrete param and result types.
+							var pt, rt Type
+							if fv.Name == "complex" {
+								switch {
+								case isUntyped(ats[0]) && isUntyped(ats[1]):
+									pt = Float64Type
+								case isUntyped(ats[0]):
+									pt = ats[1]
+								case isUntyped(ats[1]):
+									pt = ats[0]
+								case ats[0].TypeID() != ats[1].TypeID():
+									panic(fmt.Sprintf(
+										"invalid operation: complex(%v, %v) (mismatched types %v and %v)",
+										n.Args[0], n.Args[1], ats[0], ats[1]))
+								default:
+									pt = ats[0]
+								}
+								switch pt.Kind() {
+								case Float32Kind:
+									rt = Complex64Type
+								case Float64Kind:
+									rt = Complex128Type
+								default:
+									panic(fmt.Sprintf(
+										"invalid operation: complex(%v, %v) (arguments have type %v, expected floating-point)",
+										n.Args[0], n.Args[1], pt))
+								}
+							} else {
+								pt = ats[0]
+								if isUntyped(pt) {
+									pt = Complex128Type
+								}
+								switch pt.Kind() {
+								case Complex64Kind:
+									rt = Float32Type
+								case Complex128Kind:
+									rt = Float64Type
+								default:
+									panic(fmt.Sprintf(
+										"invalid argument: %v (variable of type %v) not a complex number",
+										n.Args[0], pt))
+								}
+							}
+							params := make([]FieldType, len(n.Args))
+							for i := range params {
+								params[i] = FieldType{Name: bnft.Params[i].Name, Type: pt}
+							}
+							sft := &FuncType{
+								Params:  params,
+								Results: []FieldType{{Type: rt}},
+							}
+
+							// Update func attributes with specified types.
+							n.Func.SetAttribute(ATTR_TYPEOF_VALUE, sft)
+							cx := n.Func.(*ConstExpr)
+							fv2 := cx.V.(*FuncValue).Copy(store.GetAllocator())
+							fv2.Type = sft
+							cx.T = sft
+							cx.V = fv2
+							n.SetAttribute(ATTR_TYPEOF_VALUE, &tupleType{Elts: []Type{rt}})
+							for i := range n.Args {
+								checkOrConvertType(store, last, n, &n.Args[i], pt)
+							}
+							n.NumArgs = len(n.Args)
+
+							// Typed constant arguments produce a typed
+							// constant result.
+							if allConst {
+								return evalConst(store, last, n), TRANS_CONTINUE
+							}
 							return n, TRANS_CONTINUE
 						case "cross":
 							// cross(rlm) — the explicit cross-call form.
@@ -2270,6 +2474,9 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 
 			// TRANS_LEAVE -----------------------
 			case *IndexExpr:
+				if n.X.HasAttribute(ATTR_GENERIC) {
+					return instantiateIndex(store, last, n, n.X, []Expr{n.Index}, ftype), TRANS_CONTINUE
+				}
 				dt := evalStaticTypeOf(store, last, n.X)
 				if dt.Kind() == PointerKind {
 					// if a is a pointer to an array,
@@ -2293,6 +2500,13 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 						dt.String()))
 				}
 
+			// TRANS_LEAVE -----------------------
+			case *IndexListExpr:
+				if !n.X.HasAttribute(ATTR_GENERIC) {
+					panic("invalid operation: more than one index")
+				}
+				return instantiateIndex(store, last, n, n.X, n.Indices, ftype), TRANS_CONTINUE
+
 			// TRANS_LEAVE -----------------------
 			case *SliceExpr:
 				// Replace const L/H/M with int *ConstExpr,
@@ -2636,6 +2850,10 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					n.Path = pn.GetPathForName(store, n.Sel)
 					// Produce const expr if typed or untyped const.
 					tt := pn.GetStaticTypeOfAt(store, n.Path)
+					if gt, ok := tt.(*templateType); ok {
+						// Generic func or type, instantiated by the parent.
+						return genericRef(n, gt, ftype), TRANS_CONTINUE
+					}
 					if isUntyped(tt) || pn.GetIsConstAt(store, n.Path) {
 						cx := evalConst(store, last, n)
 						return cx, TRANS_CONTINUE
@@ -2712,6 +2930,10 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 			case *MapTypeExpr:
 				evalStaticType(store, last, n)
 
+			// TRANS_LEAVE -----------------------
+			case *ChanTypeExpr:
+				evalStaticType(store, last, n)
+
 			// TRANS_LEAVE -----------------------
 			case *StructTypeExpr:
 				evalStaticType(store, last, n)
@@ -2915,6 +3137,20 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 			case *IncDecStmt:
 				n.AssertCompatible(store, last)
 
+			// TRANS_LEAVE -----------------------
+			case *SendStmt:
+				ct, ok := baseOf(evalStaticTypeOf(store, last, n.Chan)).(*ChanType)
+				if !ok {
+					panic(fmt.Sprintf(
+						"invalid operation: cannot send to non-channel %s", n.Chan))
+				}
+				if ct.Dir == RECV {
+					panic(fmt.Sprintf(
+						"invalid operation: cannot send to receive-only channel %s", n.Chan))
+				}
+				// Value consts become element type *ConstExprs.
+				checkOrConvertType(store, last, n, &n.Value, ct.Elt)
+
 			// TRANS_LEAVE -----------------------
 			case *ForStmt:
 				// Cond consts become bool *ConstExprs.
@@ -3086,6 +3322,8 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					*dstT = *(tmp.(*InterfaceType))
 				case *MapType:
 					*dstT = *(tmp.(*MapType))
+				case *ChanType:
+					*dstT = *(tmp.(*ChanType))
 				case *StructType:
 					*dstT = *(tmp.(*StructType))
 				case *DeclaredType:
@@ -3273,10 +3511,12 @@ func parseAssignFromExprList(
 // - var a, b, c T = f()
 // - var a, b = n.(T)
 // - var a, b = n[i], where n is a map
+// - var a, b = <-ch
 // Assign:
 // - a, b, c := f()
 // - a, b := n.(T)
 // - a, b := n[i], where n is a map
+// - a, b := <-ch
 func parseMultipleAssignFromOneExpr(
 	store Store,
 	bn BlockNode,
@@ -3315,6 +3555,19 @@ func parseMultipleAssignFromOneExpr(
 		}
 		tuple = &tupleType{Elts: []Type{mt.Value, BoolType}}
 		expr.HasOK = true
+	case *UnaryExpr:
+		// Channel receive case:
+		// var a, b = <-ch
+		// a, b := <-ch
+		if expr.Op != ARROW {
+			panic(fmt.Sprintf("unexpected unary expression %s", expr))
+		}
+		ct, ok := baseOf(evalStaticTypeOf(store, bn, expr.X)).(*ChanType)
+		if !ok {
+			panic(fmt.Sprintf("invalid receive expression on %s", expr.X))
+		}
+		tuple = &tupleType{Elts: []Type{ct.Elt, BoolType}}
+		expr.HasOK = true
 	default:
 		panic(fmt.Sprintf("unexpected value expression type %T", expr))
 	}
@@ -3535,6 +3788,13 @@ func codaHeapDefinesByUse(ctx BlockNode, bn BlockNode) {
 		}
 
 		switch stage {
+		// ----------------------------------------
+		case TRANS_ENTER:
+			if isGenericDecl(n) {
+				// templates are not preprocessed.
+				return n, TRANS_SKIP
+			}
+
 		// ----------------------------------------
 		case TRANS_BLOCK:
 
@@ -3791,6 +4051,10 @@ func codaHeapUsesDemoteDefines(ctx BlockNode, bn BlockNode) {
 
 		// ----------------------------------------
 		case TRANS_ENTER:
+			if isGenericDecl(n) {
+				// templates are not preprocessed.
+				return n, TRANS_SKIP
+			}
 			switch n := n.(type) {
 			// type switch is a special cast that varName is
 			// defined in case clauses.
@@ -3931,6 +4195,10 @@ func codaPackageSelectors(bn BlockNode) {
 	_ = Transcribe(bn, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
 		switch stage {
 		case TRANS_ENTER:
+			if isGenericDecl(n) {
+				// templates are not preprocessed.
+				return n, TRANS_SKIP
+			}
 			switch n := n.(type) {
 			case *NameExpr:
 				// Replace a package name with RefValue{PkgPath}
@@ -3974,6 +4242,8 @@ func codaPackageSelectors(bn BlockNode) {
 	})
 }
 
+// isSwitchLabel returns true if label is the label of an enclosing switch or
+// select statement.
 func isSwitchLabel(ns []Node, label Name) bool {
 	for {
 		swch := lastSwitch(ns)
@@ -3988,6 +4258,14 @@ func isSwitchLabel(ns []Node, label Name) bool {
 		ns = ns[:len(ns)-1]
 	}
 
+	for _, n := range ns {
+		if sel, ok := n.(*SelectStmt); ok {
+			if sel.GetLabel() == label && label != "" {
+				return true
+			}
+		}
+	}
+
 	return false
 }
 
@@ -4436,6 +4714,24 @@ func evalConst(store Store, last BlockNode, x Expr) *ConstExpr {
 				if cv.GetFloat64() == softfloat.NegZero64 {
 					cv.SetFloat64(0)
 				}
+			case Complex64Kind:
+				re, im := cv.GetComplex64()
+				if re == softfloat.NegZero32 {
+					re = 0
+				}
+				if im == softfloat.NegZero32 {
+					im = 0
+				}
+				cv.SetComplex64(re, im)
+			case Complex128Kind:
+				re, im := cv.GetComplex128()
+				if re == softfloat.NegZero64 {
+					re = 0
+				}
+				if im == softfloat.NegZero64 {
+					im = 0
+				}
+				cv.SetComplex128(re, im)
 			}
 		}
 		cx = &ConstExpr{
@@ -4611,6 +4907,8 @@ func findBreakableNode(last BlockNode, store Store) {
 			return
 		case *SwitchClauseStmt:
 			return
+		case *SelectCaseStmt:
+			return
 		}
 
 		last = last.GetParentNode(store)
@@ -4695,7 +4993,7 @@ func findGotoLabel(last BlockNode, label Name) (
 					"cannot find GOTO label %q within current function",
 					label))
 			}
-		case *ForStmt, *RangeStmt, *SwitchClauseStmt:
+		case *ForStmt, *RangeStmt, *SwitchClauseStmt, *SelectCaseStmt:
 			body := cbn.GetBody()
 			_, bodyIdx = body.GetLabeledStmt(label)
 			if bodyIdx != -1 {
@@ -4706,7 +5004,7 @@ func findGotoLabel(last BlockNode, label Name) (
 				frameDepth += 1
 				blockDepth = 0 // reset
 			}
-		case *IfCaseStmt, *SelectCaseStmt, *BlockStmt:
+		case *IfCaseStmt, *BlockStmt:
 			body := cbn.GetBody()
 			_, bodyIdx = body.GetLabeledStmt(label)
 			if bodyIdx != -1 {
@@ -4755,6 +5053,46 @@ func anyValue(t Type) TypedValue {
 	}
 }
 
+// evalConstComplexBuiltin folds a call to complex, real or imag whose
+// arguments are all untyped constants into an untyped constant.
+func evalConstComplexBuiltin(n *CallExpr, name Name) *ConstExpr {
+	bcvs := make([]BigcomplexValue, len(n.Args))
+	for i, arg := range n.Args {
+		tv := arg.(*ConstExpr).TypedValue
+		if !isNumeric(tv.T) {
+			panic(fmt.Sprintf(
+				"invalid argument: %v (untyped %v constant) not a number",
+				arg, tv.T))
+		}
+		ConvertUntypedTo(&tv, UntypedBigcomplexType)
+		bcvs[i] = tv.V.(BigcomplexValue)
+	}
+	var tv TypedValue
+	switch name {
+	case "complex":
+		for i, bcv := range bcvs {
+			if !bcv.Imag.IsZero() {
+				panic(fmt.Sprintf(
+					"cannot use %v (untyped complex constant) as float value in argument to complex (truncated)",
+					n.Args[i]))
+			}
+		}
+		tv = TypedValue{
+			T: UntypedBigcomplexType,
+			V: BigcomplexValue{Real: bcvs[0].Real, Imag: bcvs[1].Real},
+		}
+	case "real":
+		tv = TypedValue{T: UntypedBigdecType, V: bcvs[0].Real}
+	case "imag":
+		tv = TypedValue{T: UntypedBigdecType, V: bcvs[0].Imag}
+	default:
+		panic("should not happen")
+	}
+	cx := toConstExpr(n, tv)
+	setConstAttrs(cx)
+	return cx
+}
+
 func isConst(x Expr) bool {
 	_, ok := x.(*ConstExpr)
 	return ok
@@ -5183,6 +5521,8 @@ func findUndefinedAny(store Store, last BlockNode, x Expr, stack []Name, definin
 		return findUndefinedT(store, last, cx.Elt, stack, defining, isalias, direct)
 	case *SliceTypeExpr:
 		return findUndefinedT(store, last, cx.Elt, stack, defining, isalias, astype && isalias)
+	case *ChanTypeExpr:
+		return findUndefinedT(store, last, cx.Value, stack, defining, isalias, astype && isalias)
 	case *InterfaceTypeExpr:
 		for i := range cx.Methods {
 			method := &cx.Methods[i]
@@ -5248,6 +5588,23 @@ func findUndefinedAny(store Store, last BlockNode, x Expr, stack []Name, definin
 		if un != "" {
 			return
 		}
+		if un = findUndefinedGeneric(store, last, cx.X, defining); un != "" {
+			return un, false
+		}
+	case *IndexListExpr:
+		un, directR = findUndefinedV(store, last, cx.X, stack, defining, direct, nil)
+		if un != "" {
+			return
+		}
+		for _, ix := range cx.Indices {
+			un, directR = findUndefinedV(store, last, ix, stack, defining, direct, nil)
+			if un != "" {
+				return
+			}
+		}
+		if un = findUndefinedGeneric(store, last, cx.X, defining); un != "" {
+			return un, false
+		}
 	case *constTypeExpr:
 		return
 	case *ConstExpr:
@@ -5410,6 +5767,10 @@ func predefineRecursively2(store Store, last BlockNode, d Decl, stack []Name, de
 			break // predefine successfully performed.
 		}
 	}
+	if isGenericDecl(d) {
+		// templates are preprocessed per instance.
+		return false
+	}
 	switch cd := d.(type) {
 	case *FuncDecl:
 		// We cannot Preprocess the body of *FuncDecl as it may
@@ -5539,6 +5900,10 @@ func tryPredefine(store Store, pkg *PackageNode, last BlockNode, d Decl, stack [
 		if d.Name == blankIdentifier {
 			return
 		}
+		if isGenericDecl(d) {
+			predefineGeneric(store, last, d)
+			return
+		}
 		// before looking for dependencies, predefine empty type.
 		last2 := skipFile(last)
 		if !isLocallyDefined(last2, d.Name) {
@@ -5555,6 +5920,8 @@ func tryPredefine(store Store, pkg *PackageNode, last BlockNode, d Decl, stack [
 				t = &InterfaceType{}
 			case *MapTypeExpr:
 				t = &MapType{}
+			case *ChanTypeExpr:
+				t = &ChanType{}
 			case *StructTypeExpr:
 				t = &StructType{}
 			case *StarExpr:
@@ -5587,6 +5954,15 @@ func tryPredefine(store Store, pkg *PackageNode, last BlockNode, d Decl, stack [
 					tv := Uverse().GetValueAt(nil, path)
 					t = tv.GetType()
 				}
+			case *IndexExpr, *IndexListExpr:
+				// instance of a generic type.
+				un, directR = findUndefinedT(store, last, tx, stack, defining, false, false)
+				if un != "" {
+					untype = true
+					return
+				}
+				d.Type = Preprocess(store, last, tx).(Expr)
+				t = evalStaticType(store, last, d.Type)
 			case *SelectorExpr:
 				// get package value.
 				un, directR = findUndefinedV(store, last, tx.X, stack, defining, false, nil)
@@ -5638,6 +6014,10 @@ func tryPredefine(store Store, pkg *PackageNode, last BlockNode, d Decl, stack [
 		}
 		// END *TypeDecl
 	case *FuncDecl:
+		if isGenericDecl(d) {
+			predefineGeneric(store, last, d)
+			return
+		}
 		un, directR = findUndefinedT(store, last, &d.Type, stack, defining, false, false)
 		if un != "" {
 			untype = true
@@ -6004,6 +6384,9 @@ func codaInitOrderDeps(pn *PackageNode, fn *FileNode) {
 		default:
 			continue
 		}
+		if isGenericDecl(decl) {
+			continue
+		}
 
 		var deps map[Name]struct{}
 		addDep := func(name Name) {
@@ -6154,6 +6537,17 @@ func resolveDeclDep(name Name, pn *PackageNode) Decl {
 	return pn.NameSources[li].Origin.(Decl)
 }
 
+// isDeclared reports whether all names declared by d are already in
+// fdeclared, meaning d has already been initialized.
+func isDeclared(d *ValueDecl, fdeclared map[Name]struct{}) bool {
+	for _, n := range d.GetDeclNames() {
+		if _, ok := fdeclared[n]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
 // resolveEffectiveDeps computes, for every Decl reachable from the given
 // declarations, the set of *ValueDecl dependencies obtained by collapsing
 // FuncDecl edges (FuncDecls are transparent pass-throughs).
@@ -6168,17 +6562,6 @@ func resolveEffectiveDeps(decls []Decl, pn *PackageNode, fdeclared map[Name]stru
 	cache := map[Decl][]*ValueDecl{} // fully resolved
 	onStack := map[Decl]bool{}       // grey: currently in DFS path
 
-	// inFDeclared reports whether all names declared by d are already in
-	// fdeclared, meaning d has already been initialized.
-	inFDeclared := func(d *ValueDecl) bool {
-		for _, n := range d.GetDeclNames() {
-			if _, ok := fdeclared[n]; !ok {
-				return false
-			}
-		}
-		return true
-	}
-
 	var walk func(d Decl, path []Name) []*ValueDecl
 	walk = func(d Decl, path []Name) []*ValueDecl {
 		if res, ok := cache[d]; ok {
@@ -6220,7 +6603,7 @@ func resolveEffectiveDeps(decls []Decl, pn *PackageNode, fdeclared map[Name]stru
 					panic(bld.String())
 				}
 				// Skip already-initialized decls entirely.
-				if len(fdeclared) > 0 && inFDeclared(dep) {
+				if len(fdeclared) > 0 && isDeclared(dep, fdeclared) {
 					continue
 				}
 				if !slices.Contains(result, dep) {
@@ -6369,6 +6752,10 @@ func SaveBlockNodes(store Store, fn *FileNode) {
 		if stage != TRANS_ENTER {
 			return n, TRANS_CONTINUE
 		}
+		// instances are saved when instantiated.
+		if isGenericDecl(n) {
+			return n, TRANS_SKIP
+		}
 		// save node to store if blocknode.
 		if bn, ok := n.(BlockNode); ok {
 			// Location must exist already.
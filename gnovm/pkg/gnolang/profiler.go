@@ -0,0 +1,486 @@
+package gnolang
+
+import (
+	"bytes"
+	"cmp"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/gnolang/gno/tm2/pkg/store"
+	stypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ----------------------------------------
+// Profiler
+//
+// A Profiler attributes the gas and the allocations of a machine to the Gno
+// call stack being executed: when Machine.Profiler is set, the gas meter of
+// the machine is wrapped (see Profiler.GasMeter), and every charge, as well
+// as every allocation of the machine's allocator, is recorded against the
+// functions and lines of the frames of the machine at that time. Gas charged
+// by a store is attributed the same way, when the store was given a gas
+// meter wrapped by the profiler.
+//
+// Charges are recorded before being forwarded to the underlying gas meter,
+// so that the charge running out of gas is part of the profile: profiling a
+// failing transaction shows which function burned its gas.
+//
+// Profiles are written in the pprof format, so that they can be inspected
+// with `go tool pprof`.
+
+// Sample types of the profiles, one of which is selected by default by
+// [Profiler.WriteProfile]; all of them are part of the profile.
+const (
+	ProfileGas        = "gas"         // all gas.
+	ProfileCPU        = "cpu"         // gas for CPU cycles.
+	ProfileStore      = "store"       // gas for store reads and writes.
+	ProfileAlloc      = "alloc"       // gas for allocations.
+	ProfileAllocSpace = "alloc_space" // bytes allocated.
+)
+
+// Values recorded by a Profiler, in the order of profSampleTypes.
+const (
+	profGas = iota
+	profCPUGas
+	profStoreGas
+	profAllocGas
+	profAllocBytes
+	numProfValues
+)
+
+var profSampleTypes = [numProfValues]struct{ typ, unit string }{
+	{ProfileGas, "gas"},
+	{ProfileCPU, "gas"},
+	{ProfileStore, "gas"},
+	{ProfileAlloc, "gas"},
+	{ProfileAllocSpace, "bytes"},
+}
+
+// profStoreGasDescs are the descriptors of the gas charged for store reads
+// and writes; gas with any other descriptor but GasAllocDesc is CPU gas.
+var profStoreGasDescs = map[string]bool{
+	stypes.GasIterNextCostFlatDesc: true,
+	stypes.GasValuePerByteDesc:     true,
+	stypes.GasWritePerByteDesc:     true,
+	stypes.GasReadPerByteDesc:      true,
+	stypes.GasWriteCostFlatDesc:    true,
+	stypes.GasReadCostFlatDesc:     true,
+	stypes.GasHasDesc:              true,
+	stypes.GasDeleteDesc:           true,
+	"DepthReadFlat":                true,
+	"DepthSet":                     true,
+	"DepthDelete":                  true,
+	GasAminoDecodeDesc:             true,
+	GasAminoEncodeDesc:             true,
+}
+
+// Profiler records the gas and allocations of the machines it is set on.
+// A Profiler is not safe for concurrent use; use one per machine or per
+// sequence of machines, and Merge them afterwards.
+type Profiler struct {
+	m     *Machine // machine being profiled, if any.
+	root  profNode
+	funcs map[*FuncValue]profLoc // see funcLoc.
+}
+
+// profLoc is a line of a function, in a call stack.
+type profLoc struct {
+	Func    string
+	PkgPath string
+	File    string
+	Line    int
+}
+
+type profNode struct {
+	values   [numProfValues]int64
+	children map[profLoc]*profNode
+}
+
+// NewProfiler returns an empty Profiler.
+func NewProfiler() *Profiler {
+	return &Profiler{
+		funcs: make(map[*FuncValue]profLoc),
+	}
+}
+
+// SetProfiler makes p record the gas and allocations of m, until m is
+// released; p may be nil. The gas meters of m and of its allocator are
+// wrapped by p, and m is given an infinite gas meter if it had none.
+func (m *Machine) SetProfiler(p *Profiler) {
+	m.Profiler = p
+	if p == nil {
+		return
+	}
+	p.m = m
+	if m.GasMeter == nil {
+		m.GasMeter = store.NewInfiniteGasMeter()
+	}
+	gm := p.GasMeter(m.GasMeter)
+	if m.Alloc.GetGasMeter() == m.GasMeter {
+		m.Alloc.SetGasMeter(gm)
+	}
+	m.Alloc.SetProfiler(p)
+	m.GasMeter = gm
+}
+
+// detach stops attributing charges to the stack of m, once it is released.
+func (p *Profiler) detach(m *Machine) {
+	if p.m == m {
+		p.m = nil
+	}
+}
+
+// GasMeter returns a gas meter recording the gas charged to base in p.
+// Charges are attributed to the stack of the last machine p was set on,
+// which makes it suitable for the gas meter of the store of the machine.
+func (p *Profiler) GasMeter(base store.GasMeter) store.GasMeter {
+	if gm, ok := base.(profGasMeter); ok && gm.p == p {
+		return base
+	}
+	return profGasMeter{GasMeter: base, p: p}
+}
+
+type profGasMeter struct {
+	store.GasMeter
+	p *Profiler
+}
+
+func (gm profGasMeter) ConsumeGas(amount store.Gas, descriptor string) {
+	kind := profCPUGas
+	if descriptor == GasAllocDesc {
+		kind = profAllocGas
+	} else if profStoreGasDescs[descriptor] {
+		kind = profStoreGas
+	}
+	n := gm.p.node()
+	n.values[profGas] += amount
+	n.values[kind] += amount
+	gm.GasMeter.ConsumeGas(amount, descriptor)
+}
+
+func (p *Profiler) recordAlloc(size int64) {
+	p.node().values[profAllocBytes] += size
+}
+
+// node returns the node of the current stack of the profiled machine.
+func (p *Profiler) node() *profNode {
+	n := &p.root
+	m := p.m
+	if m == nil {
+		// Outside of the execution of a machine, e.g. when loading a
+		// package before running it.
+		return n.child(profLoc{Func: "(vm)"})
+	}
+
+	// Walk the call frames from the outermost one: the line of each
+	// function is that of the call to the next.
+	var loc profLoc
+	fx, fs := 0, 0
+	for i := range m.Frames {
+		fr := &m.Frames[i]
+		if fr.Func == nil {
+			continue
+		}
+		if loc.Func != "" {
+			if fr.Source != nil {
+				loc.Line = fr.Source.GetLine()
+			}
+			n = n.child(loc)
+		}
+		loc = p.funcLoc(fr)
+		fx, fs = fr.NumExprs, fr.NumStmts
+	}
+	if loc.Func == "" {
+		// Code run outside of any function, like the initialization
+		// of package variables.
+		loc.Func = "(vm)"
+		if m.Package != nil {
+			loc.Func = m.Package.PkgPath
+			loc.PkgPath = m.Package.PkgPath
+		}
+	}
+
+	// The line of the innermost function is that of the last expression or
+	// statement it pushed, if any: those below belong to its callers. It is
+	// otherwise the line of its declaration, when entering or leaving it.
+	for i := len(m.Exprs) - 1; i >= fx; i-- {
+		if l := m.Exprs[i].GetLine(); l > 0 {
+			loc.Line = l
+			return n.child(loc)
+		}
+	}
+	if len(m.Stmts) > fs {
+		if s := m.PeekStmt1(); s != nil && s.GetLine() > 0 {
+			loc.Line = s.GetLine()
+		}
+	}
+	return n.child(loc)
+}
+
+func (n *profNode) child(loc profLoc) *profNode {
+	c := n.children[loc]
+	if c == nil {
+		if n.children == nil {
+			n.children = make(map[profLoc]*profNode)
+		}
+		c = &profNode{}
+		n.children[loc] = c
+	}
+	return c
+}
+
+// funcLoc returns the location of the function of the call frame fr, at the
+// line where it is declared. Its name is qualified with its package path and,
+// for methods, its receiver type.
+func (p *Profiler) funcLoc(fr *Frame) profLoc {
+	fv := fr.Func
+	if loc, ok := p.funcs[fv]; ok {
+		return loc
+	}
+	loc := profLoc{PkgPath: fv.PkgPath, File: fv.FileName}
+	if fv.Source != nil {
+		src := fv.Source.GetLocation()
+		loc.Line = src.Line
+		if loc.File == "" {
+			// Function literals have no file name.
+			loc.File = src.File
+		}
+	}
+	switch {
+	case fv.IsMethod:
+		// Like Go: pkg.T.Method or pkg.(*T).Method.
+		rt := fv.Type.(*FuncType).Params[0].Type
+		recv := rt.String()
+		if pt, ok := rt.(*PointerType); ok {
+			if dt, ok := pt.Elt.(*DeclaredType); ok {
+				recv = "(*" + string(dt.Name) + ")"
+			}
+		} else if dt, ok := rt.(*DeclaredType); ok {
+			recv = string(dt.Name)
+		}
+		loc.Func = fmt.Sprintf("%s.%s.%s", fv.PkgPath, recv, fv.Name)
+	case fv.Name == "":
+		// Function literals are named after their position, as they
+		// don't know the function they are declared in.
+		loc.Func = fmt.Sprintf("%s.func@%d", fv.PkgPath, loc.Line)
+	default:
+		loc.Func = fmt.Sprintf("%s.%s", fv.PkgPath, fv.Name)
+	}
+	p.funcs[fv] = loc
+	return loc
+}
+
+// Total returns the total value of the given sample type, one of ProfileGas,
+// ProfileCPU, ProfileStore, ProfileAlloc and ProfileAllocSpace.
+func (p *Profiler) Total(sampleType string) int64 {
+	i, err := profSampleIndex(sampleType)
+	if err != nil {
+		panic(err)
+	}
+	var total int64
+	var walk func(n *profNode)
+	walk = func(n *profNode) {
+		total += n.values[i]
+		for _, c := range n.children {
+			walk(c)
+		}
+	}
+	walk(&p.root)
+	return total
+}
+
+// Merge adds the values recorded by other to p.
+func (p *Profiler) Merge(other *Profiler) {
+	var merge func(dst, src *profNode)
+	merge = func(dst, src *profNode) {
+		for i, v := range src.values {
+			dst.values[i] += v
+		}
+		for loc, c := range src.children {
+			merge(dst.child(loc), c)
+		}
+	}
+	merge(&p.root, &other.root)
+}
+
+// CheckProfileSampleType returns an error if sampleType is not one of the
+// sample types of the profiles, such as ProfileGas.
+func CheckProfileSampleType(sampleType string) error {
+	_, err := profSampleIndex(sampleType)
+	return err
+}
+
+func profSampleIndex(sampleType string) (int, error) {
+	for i, st := range profSampleTypes {
+		if st.typ == sampleType {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("invalid profile sample type %q", sampleType)
+}
+
+// WriteProfile writes the profile recorded by p to w, in the gzipped protocol
+// buffer format of pprof, with sampleType as the default sample type. The
+// file names of the functions are given by filePath, which defaults to
+// joining their package path and file name.
+func (p *Profiler) WriteProfile(w io.Writer, sampleType string, filePath func(pkgPath, file string) string) error {
+	si, err := profSampleIndex(sampleType)
+	if err != nil {
+		return err
+	}
+	if filePath == nil {
+		filePath = func(pkgPath, file string) string {
+			return pkgPath + "/" + file
+		}
+	}
+	e := profEncoder{
+		filePath: filePath,
+		strIdx:   map[string]int{"": 0},
+		strs:     []string{""},
+		locs:     map[profLoc]uint64{},
+		funcs:    map[profLoc]uint64{},
+	}
+	for _, st := range profSampleTypes {
+		e.valueType(1, st.typ, st.unit) // sample_type
+	}
+	e.walk(&p.root, nil)
+	e.buf = append(e.buf, e.fns...)
+	e.buf = append(e.buf, e.locBuf...)
+	for _, s := range e.strs {
+		e.buf = protowire.AppendTag(e.buf, 6, protowire.BytesType) // string_table
+		e.buf = protowire.AppendString(e.buf, s)
+	}
+	e.valueType(11, ProfileGas, "gas") // period_type
+	e.buf = protowire.AppendTag(e.buf, 12, protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, 1) // period
+	e.buf = protowire.AppendTag(e.buf, 14, protowire.VarintType)
+	e.buf = protowire.AppendVarint(e.buf, uint64(e.str(profSampleTypes[si].typ))) // default_sample_type
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	if _, err := zw.Write(e.buf); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	_, err = w.Write(gz.Bytes())
+	return err
+}
+
+// profEncoder encodes a profile.proto message; see
+// https://github.com/google/pprof/blob/main/proto/profile.proto.
+type profEncoder struct {
+	filePath func(pkgPath, file string) string
+
+	buf    []byte // samples, and then the whole profile.
+	fns    []byte // functions.
+	locBuf []byte // locations.
+	strIdx map[string]int
+	strs   []string // string table.
+	locs   map[profLoc]uint64
+	funcs  map[profLoc]uint64 // by function, without line.
+}
+
+func (e *profEncoder) str(s string) int {
+	i, ok := e.strIdx[s]
+	if !ok {
+		i = len(e.strs)
+		e.strs = append(e.strs, s)
+		e.strIdx[s] = i
+	}
+	return i
+}
+
+func (e *profEncoder) valueType(field protowire.Number, typ, unit string) {
+	var msg []byte
+	msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, uint64(e.str(typ)))
+	msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, uint64(e.str(unit)))
+	e.buf = protowire.AppendTag(e.buf, field, protowire.BytesType)
+	e.buf = protowire.AppendBytes(e.buf, msg)
+}
+
+// walk encodes a sample for each node with values, in a deterministic order;
+// stack holds the location ids of the callers of n, outermost first.
+func (e *profEncoder) walk(n *profNode, stack []uint64) {
+	if n.values != [numProfValues]int64{} {
+		var ids, values []byte
+		for i := len(stack) - 1; i >= 0; i-- {
+			ids = protowire.AppendVarint(ids, stack[i])
+		}
+		for _, v := range n.values {
+			values = protowire.AppendVarint(values, uint64(v))
+		}
+		var msg []byte
+		msg = protowire.AppendTag(msg, 1, protowire.BytesType)
+		msg = protowire.AppendBytes(msg, ids)
+		msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+		msg = protowire.AppendBytes(msg, values)
+		e.buf = protowire.AppendTag(e.buf, 2, protowire.BytesType) // sample
+		e.buf = protowire.AppendBytes(e.buf, msg)
+	}
+	locs := make([]profLoc, 0, len(n.children))
+	for loc := range n.children {
+		locs = append(locs, loc)
+	}
+	slices.SortFunc(locs, func(a, b profLoc) int {
+		return cmp.Or(
+			cmp.Compare(a.Func, b.Func),
+			cmp.Compare(a.PkgPath, b.PkgPath),
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Line, b.Line),
+		)
+	})
+	for _, loc := range locs {
+		e.walk(n.children[loc], append(stack, e.location(loc)))
+	}
+}
+
+// location returns the id of the location of loc, encoding it and its
+// function if needed.
+func (e *profEncoder) location(loc profLoc) uint64 {
+	if id, ok := e.locs[loc]; ok {
+		return id
+	}
+	fn := loc
+	fn.Line = 0
+	fid, ok := e.funcs[fn]
+	if !ok {
+		fid = uint64(len(e.funcs) + 1)
+		e.funcs[fn] = fid
+		var msg []byte
+		msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, fid)
+		msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(e.str(fn.Func))) // name
+		msg = protowire.AppendTag(msg, 3, protowire.VarintType)
+		msg = protowire.AppendVarint(msg, uint64(e.str(fn.Func))) // system_name
+		if fn.File != "" {
+			msg = protowire.AppendTag(msg, 4, protowire.VarintType)
+			msg = protowire.AppendVarint(msg, uint64(e.str(e.filePath(fn.PkgPath, fn.File)))) // filename
+		}
+		e.fns = protowire.AppendTag(e.fns, 5, protowire.BytesType)
+		e.fns = protowire.AppendBytes(e.fns, msg)
+	}
+
+	id := uint64(len(e.locs) + 1)
+	e.locs[loc] = id
+	var line []byte
+	line = protowire.AppendTag(line, 1, protowire.VarintType)
+	line = protowire.AppendVarint(line, fid)
+	line = protowire.AppendTag(line, 2, protowire.VarintType)
+	line = protowire.AppendVarint(line, uint64(loc.Line))
+	var msg []byte
+	msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+	msg = protowire.AppendVarint(msg, id)
+	msg = protowire.AppendTag(msg, 4, protowire.BytesType)
+	msg = protowire.AppendBytes(msg, line)
+	e.locBuf = protowire.AppendTag(e.locBuf, 4, protowire.BytesType)
+	e.locBuf = protowire.AppendBytes(e.locBuf, msg)
+	return id
+}
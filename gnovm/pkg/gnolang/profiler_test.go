@@ -0,0 +1,183 @@
+package gnolang
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	stypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const profilerTestBody = `package prof
+
+type T struct{ n int }
+
+func (t *T) Burn(n int) {
+	for i := 0; i < n; i++ {
+		t.n += i
+	}
+}
+
+func Alloc() []byte {
+	return make([]byte, 1000)
+}
+
+func Run() {
+	t := &T{}
+	t.Burn(100)
+	Alloc()
+	func() {
+		t.Burn(10)
+	}()
+}
+`
+
+func runProfilerTest(t *testing.T, p *Profiler, gasMeter stypes.GasMeter, x Expr) {
+	t.Helper()
+
+	db := memdb.NewMemDB()
+	baseStore := dbadapter.StoreConstructor(db, stypes.StoreOptions{})
+	iavlStore := iavl.StoreConstructor(db, stypes.StoreOptions{})
+	store := NewStore(nil, baseStore, iavlStore)
+	mpkg := &std.MemPackage{
+		Type:  MPUserProd,
+		Name:  "prof",
+		Path:  "gno.land/p/demo/prof",
+		Files: []*std.MemFile{{Name: "prof.gno", Body: profilerTestBody}},
+	}
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath:  mpkg.Path,
+		Store:    store,
+		GasMeter: gasMeter,
+		Profiler: p,
+	})
+	defer m.Release()
+	m.RunMemPackage(mpkg, true)
+	m.Eval(x)
+}
+
+// profilerSelf returns the values recorded by p for each line of each
+// function, excluding their callees.
+func profilerSelf(p *Profiler) map[profLoc][numProfValues]int64 {
+	self := map[profLoc][numProfValues]int64{}
+	var walk func(loc profLoc, n *profNode)
+	walk = func(loc profLoc, n *profNode) {
+		v := self[loc]
+		for i := range v {
+			v[i] += n.values[i]
+		}
+		self[loc] = v
+		for loc, c := range n.children {
+			walk(loc, c)
+		}
+	}
+	walk(profLoc{}, &p.root)
+	return self
+}
+
+func TestProfiler(t *testing.T) {
+	p := NewProfiler()
+	runProfilerTest(t, p, nil, Call(X("Run")))
+
+	self := profilerSelf(p)
+	loc := func(fn string, line int) profLoc {
+		return profLoc{Func: "gno.land/p/demo/prof." + fn, PkgPath: "gno.land/p/demo/prof", File: "prof.gno", Line: line}
+	}
+	burn := self[loc("(*T).Burn", 7)]
+	assert.Greater(t, burn[profCPUGas], int64(0))
+	assert.Equal(t, burn[profGas], burn[profCPUGas]+burn[profStoreGas]+burn[profAllocGas])
+	// Entering and leaving a function is attributed to its declaration.
+	assert.Greater(t, self[loc("(*T).Burn", 5)][profGas], int64(0))
+
+	// The make call allocating the slice is a child of Alloc, itself called
+	// by Run at line 18.
+	alloc := p.root.children[loc("Run", 18)].children[loc("Alloc", 12)]
+	require.NotNil(t, alloc)
+	mk := alloc.children[profLoc{Func: ".uverse.make", PkgPath: ".uverse"}]
+	require.NotNil(t, mk)
+	assert.Greater(t, mk.values[profAllocBytes], int64(1000))
+
+	// The closure declared and called at line 19 calls Burn at line 20.
+	closure := p.root.children[loc("Run", 19)].children[loc("func@19", 20)]
+	require.NotNil(t, closure)
+	require.Contains(t, closure.children, loc("(*T).Burn", 7))
+
+	assert.Equal(t, p.Total(ProfileGas), p.Total(ProfileCPU)+p.Total(ProfileStore)+p.Total(ProfileAlloc))
+
+	// Merging doubles the values.
+	total := p.Total(ProfileAllocSpace)
+	p.Merge(p)
+	assert.Equal(t, 2*total, p.Total(ProfileAllocSpace))
+}
+
+func TestProfiler_OutOfGas(t *testing.T) {
+	// The charge running out of gas is recorded.
+	p := NewProfiler()
+	gasMeter := stypes.NewGasMeter(100_000)
+	assert.Panics(t, func() {
+		runProfilerTest(t, p, gasMeter, Call(X("Run")))
+	})
+	assert.True(t, gasMeter.IsOutOfGas())
+	assert.Equal(t, gasMeter.GasConsumed(), p.Total(ProfileGas))
+	assert.Contains(t, profilerSelf(p), profLoc{
+		Func: "gno.land/p/demo/prof.(*T).Burn", PkgPath: "gno.land/p/demo/prof", File: "prof.gno", Line: 7,
+	})
+}
+
+func TestProfiler_WriteProfile(t *testing.T) {
+	p := NewProfiler()
+	runProfilerTest(t, p, nil, Call(X("Alloc")))
+
+	var buf bytes.Buffer
+	require.NoError(t, p.WriteProfile(&buf, ProfileAllocSpace, nil))
+	zr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+
+	// Check the fields of the profile.
+	var strs []string
+	counts := map[protowire.Number]int{}
+	var defaultSampleType uint64
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		require.GreaterOrEqual(t, n, 0)
+		data = data[n:]
+		counts[num]++
+		switch typ {
+		case protowire.BytesType:
+			v, n := protowire.ConsumeBytes(data)
+			require.GreaterOrEqual(t, n, 0)
+			if num == 6 {
+				strs = append(strs, string(v))
+			}
+			data = data[n:]
+		case protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			require.GreaterOrEqual(t, n, 0)
+			if num == 14 {
+				defaultSampleType = v
+			}
+			data = data[n:]
+		default:
+			t.Fatalf("unexpected wire type %v", typ)
+		}
+	}
+	assert.Equal(t, 5, counts[1], "sample types")
+	assert.Positive(t, counts[2], "samples")
+	assert.Positive(t, counts[4], "locations")
+	assert.Positive(t, counts[5], "functions")
+	require.NotEmpty(t, strs)
+	assert.Equal(t, "", strs[0])
+	assert.Contains(t, strs, "gno.land/p/demo/prof.Alloc")
+	assert.Contains(t, strs, "gno.land/p/demo/prof/prof.gno")
+	assert.Equal(t, ProfileAllocSpace, strs[defaultSampleType])
+}
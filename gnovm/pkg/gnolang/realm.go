@@ -252,6 +252,16 @@ func (rlm *Realm) String() string {
 	}
 }
 
+// ChanPersistError is raised when a channel value is reachable from realm
+// state as the realm is finalized. Channels only live for the duration of a
+// transaction; realm variables whose type can hold one are rejected when
+// type-checking, but a channel may still be stored in an interface value.
+type ChanPersistError struct{}
+
+func (ChanPersistError) Error() string {
+	return "cannot persist channel values"
+}
+
 //----------------------------------------
 // ownership hooks
 
@@ -272,6 +282,13 @@ func (rlm *Realm) String() string {
 //   - doOpIndex (read path): passes nilRealm, so DidUpdate is a no-op.
 //   - debugger: passes nilRealm (read-only), so DidUpdate is a no-op.
 func (rlm *Realm) DidUpdate(m *Machine, po, xo, co Object) {
+	// Read-only view gate: whichever realm is active, no real object may
+	// be modified in a view (see Machine.EnterView). Stdlib self-mutation
+	// is exempt for the same reason as below: it is never persisted.
+	if m != nil && m.InView && po != nil && po.GetIsReal() &&
+		!po.GetObjectID().PkgID.IsStdlibPkg() {
+		panic("invariant violation: DidUpdate called in a read-only view without prior readonly check")
+	}
 	if rlm == nil {
 		// /p/-immutability gate: in StageRun, reject mutations to real
 		// /p/-stamped objects. m.Realm becomes nil when a method is
@@ -1277,7 +1294,7 @@ func (rlm *Realm) assertTypeIsPublic(store Store, t Type, visited map[TypeID]str
 		}
 	case FieldType:
 		rlm.assertTypeIsPublic(store, tt.Type, visited)
-	case *SliceType, *ArrayType, *PointerType:
+	case *SliceType, *ArrayType, *PointerType, *ChanType:
 		rlm.assertTypeIsPublic(store, tt.Elem(), visited)
 	case *tupleType:
 		for _, et := range tt.Elts {
@@ -1298,6 +1315,9 @@ func (rlm *Realm) assertTypeIsPublic(store Store, t Type, visited map[TypeID]str
 		}
 	case *DeclaredType:
 		rlm.assertTypeIsPublic(store, tt.Base, visited)
+		for _, targ := range tt.TypeArgs {
+			rlm.assertTypeIsPublic(store, targ, visited)
+		}
 		for _, method := range tt.Methods {
 			rlm.assertTypeIsPublic(store, method.T, visited)
 			if mv, ok := method.V.(*FuncValue); ok {
@@ -1347,6 +1367,10 @@ func getChildObjects(val Value, more []Value) []Value {
 		return more
 	case BigdecValue:
 		return more
+	case Complex128Value:
+		return more
+	case BigcomplexValue:
+		return more
 	case DataByteValue:
 		panic("cannot get children from data byte objects")
 	case PointerValue:
@@ -1417,6 +1441,8 @@ func getChildObjects(val Value, more []Value) []Value {
 	case *HeapItemValue:
 		more = getSelfOrChildObjects(cv.Value.V, more)
 		return more
+	case *ChanValue:
+		panic(ChanPersistError{})
 	default:
 		panic(fmt.Sprintf(
 			"unexpected type %v",
@@ -1486,6 +1512,17 @@ func copyMethods(methods []TypedValue) []TypedValue {
 	return res
 }
 
+func copyTypeArgsWithRefs(targs []Type) []Type {
+	if len(targs) == 0 {
+		return nil
+	}
+	res := make([]Type, len(targs))
+	for i, targ := range targs {
+		res[i] = refOrCopyType(targ)
+	}
+	return res
+}
+
 func refOrCopyType(typ Type) Type {
 	if dt, ok := typ.(*DeclaredType); ok {
 		return RefType{ID: dt.TypeID()}
@@ -1560,6 +1597,11 @@ func copyTypeWithRefs(typ Type) Type {
 			Key:   refOrCopyType(ct.Key),
 			Value: refOrCopyType(ct.Value),
 		}
+	case *ChanType:
+		return &ChanType{
+			Dir: ct.Dir,
+			Elt: refOrCopyType(ct.Elt),
+		}
 	case *InterfaceType:
 		return &InterfaceType{
 			PkgPath: ct.PkgPath,
@@ -1576,10 +1618,11 @@ func copyTypeWithRefs(typ Type) Type {
 		// before calling copyTypeWithRefs. Uverse types are preloaded in
 		// cacheTypes, so SetType's early-return always fires for them.
 		dt := &DeclaredType{
-			PkgPath: ct.PkgPath,
-			Name:    ct.Name,
-			Base:    copyTypeWithRefs(ct.Base),
-			Methods: copyMethods(ct.Methods),
+			PkgPath:  ct.PkgPath,
+			Name:     ct.Name,
+			Base:     copyTypeWithRefs(ct.Base),
+			Methods:  copyMethods(ct.Methods),
+			TypeArgs: copyTypeArgsWithRefs(ct.TypeArgs),
 		}
 		return dt
 	case *PackageType:
@@ -1623,6 +1666,10 @@ func copyValueWithRefs(val Value) Value {
 		return cv
 	case BigdecValue:
 		return cv
+	case Complex128Value:
+		return cv
+	case BigcomplexValue:
+		return cv
 	case DataByteValue:
 		// DataByteValue is a view into an ArrayValue.Data,
 		// it is copied with its parent array.
@@ -1780,6 +1827,8 @@ func copyValueWithRefs(val Value) Value {
 			Value:      refOrCopyValue(cv.Value),
 		}
 		return hiv
+	case *ChanValue:
+		panic(ChanPersistError{})
 	default:
 		panic(fmt.Sprintf(
 			"unexpected type %v",
@@ -1827,6 +1876,9 @@ func fillType(store Store, typ Type) Type {
 		ct.Key = fillType(store, ct.Key)
 		ct.Value = fillType(store, ct.Value)
 		return ct
+	case *ChanType:
+		ct.Elt = fillType(store, ct.Elt)
+		return ct
 	case *InterfaceType:
 		for i, mthd := range ct.Methods {
 			ct.Methods[i].Type = fillType(store, mthd.Type)
@@ -1853,6 +1905,9 @@ func fillType(store Store, typ Type) Type {
 		} else {
 			ct2.sealed = true
 			ct2.Base = fillType(store, ct2.Base)
+			for i, targ := range ct2.TypeArgs {
+				ct2.TypeArgs[i] = fillType(store, targ)
+			}
 			for i, method := range ct2.Methods {
 				ct2.Methods[i].T = fillType(store, method.T)
 				mv := ct2.Methods[i].V.(*FuncValue)
@@ -1896,6 +1951,10 @@ func fillTypesOfValue(store Store, val Value) Value {
 		return cv
 	case BigdecValue: // do nothing
 		return cv
+	case Complex128Value: // do nothing
+		return cv
+	case BigcomplexValue: // do nothing
+		return cv
 	case DataByteValue: // do nothing
 		return cv
 	case PointerValue:
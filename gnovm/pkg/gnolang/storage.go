@@ -0,0 +1,222 @@
+package gnolang
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+)
+
+// ----------------------------------------
+// Object storage
+//
+// The storage deposit of a realm pays for the bytes of its persisted objects.
+// RealmObjectsStorage walks the objects of a realm from its package value,
+// breadth first, so that each object is reported with the shortest path from
+// a package variable reaching it, e.g. "items[2]" or "tree.root.left".
+// Objects of other realms, and objects no longer reachable, are not reported.
+
+// ObjectStorage is the storage used by a persisted object of a realm.
+type ObjectStorage struct {
+	ObjectID ObjectID `json:"objectid"`
+	Kind     string   `json:"kind"`           // e.g. "StructValue", "Block".
+	Type     string   `json:"type,omitempty"` // if known, e.g. "gno.land/r/demo/foo.Item".
+	Size     int64    `json:"size"`           // in bytes, as stored.
+	RefCount int      `json:"refcount"`
+	Path     string   `json:"path"` // from a package variable, or e.g. "(package block)".
+}
+
+// RealmObjectsStorage returns the storage used by the objects of the realm at
+// pkgPath, reachable from its package value, by decreasing size. It returns
+// nil if the realm has no package value in store.
+func RealmObjectsStorage(store Store, pkgPath string) []ObjectStorage {
+	pv, ok := store.GetObjectSafe(ObjectIDFromPkgPath(pkgPath)).(*PackageValue)
+	if !ok {
+		return nil
+	}
+	w := &objectsWalker{
+		store: store,
+		pid:   PkgIDFromPkgPath(pkgPath),
+		seen:  make(map[ObjectID]bool),
+	}
+	w.push(pv, nil, "(package)", "")
+	for len(w.queue) > 0 {
+		item := w.queue[0]
+		w.queue = w.queue[1:]
+		w.visit(item)
+	}
+	sort.SliceStable(w.objs, func(i, j int) bool {
+		if w.objs[i].Size != w.objs[j].Size {
+			return w.objs[i].Size > w.objs[j].Size
+		}
+		return w.objs[i].ObjectID.String() < w.objs[j].ObjectID.String()
+	})
+	return w.objs
+}
+
+// WriteObjectsStorage writes objs as a table, followed by their total size.
+func WriteObjectsStorage(w io.Writer, objs []ObjectStorage) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "SIZE\tREFS\tOBJECT\tKIND\tTYPE\tPATH")
+	var total int64
+	for _, obj := range objs {
+		fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\n",
+			obj.Size, obj.RefCount, obj.ObjectID, obj.Kind, obj.Type, obj.Path)
+		total += obj.Size
+	}
+	fmt.Fprintf(tw, "%d\t\t\t\t\t(total of %d objects)\n", total, len(objs))
+	return tw.Flush()
+}
+
+type objectsWalker struct {
+	store Store
+	pid   PkgID
+	seen  map[ObjectID]bool
+	queue []objectsWalkerItem
+	objs  []ObjectStorage
+}
+
+type objectsWalkerItem struct {
+	oo     Object
+	t      Type   // type of oo, if known.
+	path   string // path of oo.
+	prefix string // prefix of the paths of the values of oo.
+}
+
+// push queues the object, or RefValue, v if it is a persisted object of the
+// realm not yet seen.
+func (w *objectsWalker) push(v Value, t Type, path, prefix string) {
+	var oo Object
+	switch cv := v.(type) {
+	case RefValue:
+		if cv.PkgPath != "" || cv.ObjectID.PkgID != w.pid || w.seen[cv.ObjectID] {
+			return // another package, or already seen.
+		}
+		if oo = w.store.GetObjectSafe(cv.ObjectID); oo == nil {
+			return
+		}
+	case Object:
+		oo = cv
+	default:
+		return
+	}
+	oid := oo.GetObjectID()
+	if oid.IsZero() || oid.PkgID != w.pid || w.seen[oid] {
+		return
+	}
+	w.seen[oid] = true
+	w.queue = append(w.queue, objectsWalkerItem{oo: oo, t: t, path: path, prefix: prefix})
+}
+
+// value queues the objects referred to by tv, itself at path.
+func (w *objectsWalker) value(tv TypedValue, path string) {
+	switch cv := tv.V.(type) {
+	case PointerValue:
+		// The type of the base is not that of the pointer.
+		w.push(cv.Base, nil, path, path)
+	case *SliceValue:
+		w.push(cv.Base, nil, path, path)
+	default:
+		w.push(tv.V, tv.T, path, path)
+	}
+}
+
+func (w *objectsWalker) visit(item objectsWalkerItem) {
+	oo := item.oo
+	oi := oo.GetObjectInfo()
+	obj := ObjectStorage{
+		ObjectID: oi.ID,
+		Kind:     reflect.TypeOf(oo).Elem().Name(),
+		Size:     oi.LastObjectSize,
+		RefCount: oi.RefCount,
+		Path:     item.path,
+	}
+	t := item.t
+	if hiv, ok := oo.(*HeapItemValue); ok {
+		t = hiv.Value.T // rather than heapItemType.
+	}
+	if t != nil {
+		obj.Type = t.String()
+	}
+	w.objs = append(w.objs, obj)
+
+	switch cv := oo.(type) {
+	case *PackageValue:
+		w.push(cv.Block, nil, "(package block)", "")
+		for i, fb := range cv.FBlocks {
+			w.push(fb, nil, fmt.Sprintf("(file block %s)", cv.FNames[i]), "")
+		}
+	case *Block:
+		names := w.blockNames(cv)
+		for i, tv := range cv.Values {
+			name := fmt.Sprintf("(%d)", i)
+			if i < len(names) && names[i] != "" && names[i] != blankIdentifier {
+				name = string(names[i])
+			}
+			w.value(tv, joinObjectPath(item.prefix, name))
+		}
+		w.push(cv.Parent, nil, joinObjectPath(item.prefix, "(parent block)"), item.prefix)
+	case *HeapItemValue:
+		w.value(cv.Value, item.path)
+	case *ArrayValue:
+		for i, tv := range cv.List {
+			w.value(tv, fmt.Sprintf("%s[%d]", item.prefix, i))
+		}
+	case *StructValue:
+		st, _ := baseOf(item.t).(*StructType)
+		for i, tv := range cv.Fields {
+			name := fmt.Sprintf("(%d)", i)
+			if st != nil && i < len(st.Fields) {
+				name = string(st.Fields[i].Name)
+			}
+			w.value(tv, joinObjectPath(item.prefix, name))
+		}
+	case *MapValue:
+		for cur := cv.List.Head; cur != nil; cur = cur.Next {
+			path := fmt.Sprintf("%s[%s]", item.prefix, mapKeyPath(cur.Key))
+			w.value(cur.Key, joinObjectPath(path, "(key)"))
+			w.value(cur.Value, path)
+		}
+	case *FuncValue:
+		w.push(cv.Parent, nil, joinObjectPath(item.prefix, "(parent block)"), item.prefix)
+		for i, tv := range cv.Captures {
+			w.value(tv, joinObjectPath(item.prefix, fmt.Sprintf("(capture %d)", i)))
+		}
+	case *BoundMethodValue:
+		w.value(cv.Receiver, joinObjectPath(item.prefix, "(receiver)"))
+	}
+}
+
+// blockNames returns the names of the values of b, if its source is in store.
+func (w *objectsWalker) blockNames(b *Block) []Name {
+	source := b.Source
+	if rn, ok := source.(RefNode); ok {
+		source = w.store.GetBlockNodeSafe(rn.GetLocation())
+	}
+	if source == nil {
+		return nil
+	}
+	return source.GetStaticBlock().Names
+}
+
+func joinObjectPath(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "." + name
+}
+
+// mapKeyPath returns the path element of a map value for key.
+func mapKeyPath(key TypedValue) string {
+	pt, ok := baseOf(key.T).(PrimitiveType)
+	switch {
+	case !ok:
+		return "..."
+	case pt.Kind() == StringKind:
+		return strconv.Quote(key.GetString())
+	default:
+		return key.ProtectedSprint(newSeenValues(), false)
+	}
+}
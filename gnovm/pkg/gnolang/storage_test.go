@@ -0,0 +1,105 @@
+package gnolang
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	storetypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRealmObjectsStorage(t *testing.T) {
+	baseStore := dbadapter.StoreConstructor(memdb.NewMemDB(), storetypes.StoreOptions{})
+	iavlStore := dbadapter.StoreConstructor(memdb.NewMemDB(), storetypes.StoreOptions{})
+	st := NewStore(NewAllocator(math.MaxInt64), baseStore, iavlStore)
+
+	const pkgPath = "gno.land/r/test/objects"
+	txSt := st.BeginTransaction(nil, nil, nil, nil)
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath: pkgPath,
+		Store:   txSt,
+		Output:  io.Discard,
+	})
+	m.RunMemPackage(&std.MemPackage{
+		Type: MPUserProd,
+		Name: "objects",
+		Path: pkgPath,
+		Files: []*std.MemFile{
+			{Name: "gnomod.toml", Body: GenGnoModLatest(pkgPath)},
+			{Name: "objects.gno", Body: `package objects
+
+type Node struct {
+	Left, Right *Node
+}
+
+var (
+	root  = &Node{Left: &Node{}}
+	byKey = map[string]*Node{"left": root.Left}
+	list  = []int{1, 2, 3}
+)
+`},
+		},
+	}, true)
+	txSt.Write()
+
+	objs := RealmObjectsStorage(st, pkgPath)
+	require.NotEmpty(t, objs)
+
+	paths := make(map[string]ObjectStorage)
+	var total int64
+	for i, obj := range objs {
+		if i > 0 {
+			assert.LessOrEqual(t, obj.Size, objs[i-1].Size, "objects are sorted by decreasing size")
+		}
+		assert.Equal(t, PkgIDFromPkgPath(pkgPath), obj.ObjectID.PkgID)
+		assert.Positive(t, obj.Size, obj.Path)
+		total += obj.Size
+		if obj.Kind != "HeapItemValue" {
+			paths[obj.Path] = obj
+		}
+	}
+	assert.Len(t, objs, 12)
+	assert.Equal(t, "PackageValue", paths["(package)"].Kind)
+	assert.Equal(t, "Block", paths["(package block)"].Kind)
+	assert.Equal(t, "Block", paths["(file block objects.gno)"].Kind)
+	assert.Equal(t, "StructValue", paths["root"].Kind)
+	assert.Equal(t, "gno.land/r/test/objects.Node", paths["root"].Type)
+	assert.Equal(t, "MapValue", paths["byKey"].Kind)
+	assert.Equal(t, "map[string]*gno.land/r/test/objects.Node", paths["byKey"].Type)
+	assert.Equal(t, "ArrayValue", paths["list"].Kind)
+
+	// The left node is shared by root and byKey, but reported once.
+	var left []ObjectStorage
+	for _, obj := range objs {
+		if obj.Path == "root.Left" || obj.Path == `byKey["left"]` {
+			left = append(left, obj)
+		}
+	}
+	require.Len(t, left, 2) // its heap item, and its struct value.
+	assert.Equal(t, left[0].Path, left[1].Path)
+	for _, obj := range left {
+		if obj.Kind == "HeapItemValue" {
+			assert.Equal(t, 2, obj.RefCount)
+		}
+	}
+
+	var sb strings.Builder
+	require.NoError(t, WriteObjectsStorage(&sb, objs))
+	lines := strings.Split(strings.TrimSpace(sb.String()), "\n")
+	assert.Len(t, lines, len(objs)+2)
+	assert.Regexp(t, `^SIZE +REFS +OBJECT +KIND +TYPE +PATH$`, lines[0])
+	assert.Regexp(t, fmt.Sprintf(`^%d +\(total of %d objects\)$`, total, len(objs)), lines[len(lines)-1])
+}
+
+func TestRealmObjectsStorage_NotFound(t *testing.T) {
+	baseStore := dbadapter.StoreConstructor(memdb.NewMemDB(), storetypes.StoreOptions{})
+	st := NewStore(NewAllocator(math.MaxInt64), baseStore, baseStore)
+	assert.Nil(t, RealmObjectsStorage(st, "gno.land/r/test/missing"))
+}
@@ -59,15 +59,20 @@ type Store interface {
 	SetObject(Object) int64 // returns size difference of the object
 	GetStagingPackage() *PackageValue
 	SetStagingPackage(pv *PackageValue)
-	DelObject(Object) int64 // returns size difference of the object
+	DelObject(Object) int64                     // returns size difference of the object
+	DelPackageObjects(pkgPath string) int64     // returns the size of the deleted objects
+	HasEscapedObjects(pkgPath string) bool      // but its package and file blocks
+	FuncValueObjects(pkgPath string) []ObjectID // stored func values running its code
 	GetType(tid TypeID) Type
 	GetTypeSafe(tid TypeID) Type
 	SetCacheType(Type)
 	SetType(Type)
+	ReplaceType(Type) // overwrites a type saved by SetType (realm upgrades)
 	GetPackageNode(pkgPath string) *PackageNode
 	GetBlockNode(Location) BlockNode
 	GetBlockNodeSafe(Location) BlockNode
 	SetBlockNode(BlockNode)
+	DelBlockNode(Location)
 	RealmStorageDiffs() StorageDiffs // returns storage changes per realm within the message
 
 	// UNSTABLE
@@ -117,6 +122,7 @@ const (
 	GasComputeMapKeyDesc = "ComputeMapKey"
 	GasAminoDecodeDesc   = "AminoDecodePerByte"
 	GasAminoEncodeDesc   = "AminoEncodePerByte"
+	GasAllocDesc         = "memory allocation"
 )
 
 // GasConfig defines amino compute gas costs for GnoVM stores.
@@ -703,6 +709,11 @@ func (ds *defaultStore) SetObject(oo Object) int64 {
 		size = len(hashbz)
 		oo.GetObjectInfo().LastObjectSize = int64(size)
 	}
+	// index the function values running realm code, for upgrades.
+	// Charged like the object write: it is state the realm grows.
+	if pkgPath := funcValuePkgPath(oo); pkgPath != "" && oo.GetIsNewReal() && ds.baseStore != nil {
+		ds.baseStore.Set(ds.gctx, []byte(backendFuncValueKey(pkgPath, oid)), []byte(oid.String()))
+	}
 	// save object to cache.
 	if debug {
 		if !oid.IsFinalized() {
@@ -768,6 +779,9 @@ func (ds *defaultStore) DelObject(oo Object) int64 {
 	if ds.baseStore != nil {
 		key := backendObjectKey(oid)
 		ds.baseStore.Delete(ds.gctx, []byte(key))
+		if pkgPath := funcValuePkgPath(oo); pkgPath != "" {
+			ds.baseStore.Delete(ds.gctx, []byte(backendFuncValueKey(pkgPath, oid)))
+		}
 	}
 	// delete escaped hash from iavl.
 	if oo.GetIsEscaped() && ds.iavlStore != nil {
@@ -784,6 +798,116 @@ func (ds *defaultStore) DelObject(oo Object) int64 {
 	return size
 }
 
+// DelPackageObjects deletes all the objects of the package at pkgPath, its
+// package value included, from the cache and the backend, and the escaped
+// hashes of its package and file blocks from the iavl store. The realm record
+// is kept. It returns the size of the deleted objects, as accounted by
+// SetObject.
+// CONTRACT: no other object of the package is escaped; see HasEscapedObjects.
+func (ds *defaultStore) DelPackageObjects(pkgPath string) (size int64) {
+	pid := PkgIDFromPkgPath(pkgPath)
+	for oid := range ds.cacheObjects {
+		if oid.PkgID == pid {
+			delete(ds.cacheObjects, oid)
+		}
+	}
+	delete(ds.cacheRealms, pid)
+	// Collect the keys first: the store may not be written while iterated.
+	var keys [][]byte
+	iter := store.PrefixIterator(ds.gctx, ds.baseStore, []byte(backendPackageObjectsPrefix(pid)))
+	for ; iter.Valid(); iter.Next() {
+		if strings.HasSuffix(string(iter.Key()), "#realm") {
+			continue
+		}
+		keys = append(keys, slices.Clone(iter.Key()))
+		size += int64(len(iter.Value()))
+	}
+	iter.Close()
+	for _, key := range keys {
+		ds.baseStore.Delete(ds.gctx, key)
+	}
+	if ds.iavlStore != nil {
+		keys = keys[:0]
+		iter = store.PrefixIterator(ds.gctx, ds.iavlStore, []byte(escapedPackageObjectsPrefix(pid)))
+		for ; iter.Valid(); iter.Next() {
+			keys = append(keys, slices.Clone(iter.Key()))
+		}
+		iter.Close()
+		for _, key := range keys {
+			ds.iavlStore.Delete(ds.gctx, key)
+		}
+	}
+	return size
+}
+
+// FuncValueObjects returns the ids of the stored function values and bound
+// methods running the code of the realm at pkgPath, whichever realm they
+// belong to.
+func (ds *defaultStore) FuncValueObjects(pkgPath string) (oids []ObjectID) {
+	if ds.baseStore == nil {
+		return nil
+	}
+	prefix := backendFuncValuePrefix(pkgPath)
+	iter := store.PrefixIterator(ds.gctx, ds.baseStore, []byte(prefix))
+	for ; iter.Valid(); iter.Next() {
+		var oid ObjectID
+		if err := oid.UnmarshalAmino(string(iter.Value())); err != nil {
+			panic(fmt.Sprintf("invalid function value index %q: %v", iter.Key(), err))
+		}
+		oids = append(oids, oid)
+	}
+	iter.Close()
+	// DelPackageObjects leaves the keys of the archived objects.
+	return slices.DeleteFunc(oids, func(oid ObjectID) bool {
+		return !ds.baseStore.Has(ds.gctx, []byte(backendObjectKey(oid)))
+	})
+}
+
+// funcValuePkgPath returns the path of the realm whose code oo runs, if oo is
+// a function value or a bound method declared in a realm, or "".
+func funcValuePkgPath(oo Object) string {
+	var pkgPath string
+	switch cv := oo.(type) {
+	case *FuncValue:
+		pkgPath = cv.PkgPath
+	case *BoundMethodValue:
+		if cv.Func != nil {
+			pkgPath = cv.Func.PkgPath
+		}
+	}
+	if !IsRealmPath(pkgPath) {
+		return ""
+	}
+	return pkgPath
+}
+
+// HasEscapedObjects returns whether an object of the package at pkgPath, but
+// its package and file blocks, is escaped. Escaped objects have more than one
+// reference, possibly from other realms.
+func (ds *defaultStore) HasEscapedObjects(pkgPath string) bool {
+	if ds.iavlStore == nil {
+		return false
+	}
+	pv, ok := ds.GetObjectSafe(ObjectIDFromPkgPath(pkgPath)).(*PackageValue)
+	if !ok {
+		return false
+	}
+	// The blocks are either loaded objects or RefValues.
+	blocks := make(map[string]bool, 1+len(pv.FBlocks))
+	for _, bv := range append([]Value{pv.Block}, pv.FBlocks...) {
+		blocks[bv.(interface{ GetObjectID() ObjectID }).GetObjectID().String()] = true
+	}
+	pid := PkgIDFromPkgPath(pkgPath)
+	iter := store.PrefixIterator(ds.gctx, ds.iavlStore, []byte(escapedPackageObjectsPrefix(pid)))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if !blocks[string(iter.Key())] {
+			return true
+		}
+	}
+	return false
+}
+
 // NOTE: not used quite yet.
 // NOTE: The implementation matches that of GetObject() in anticipation of what
 // the persistent type system might work like.
@@ -884,6 +1008,14 @@ func (ds *defaultStore) SetType(tt Type) {
 	ds.cacheTypes[tid] = tt
 }
 
+// ReplaceType is like SetType, but overwrites any type already saved
+// under the same TypeID, both in the cache and in the backend. It is
+// used when a realm upgrade redeclares the realm's types.
+func (ds *defaultStore) ReplaceType(tt Type) {
+	delete(ds.cacheTypes, tt.TypeID())
+	ds.SetType(tt)
+}
+
 // Convenience
 func (ds *defaultStore) GetPackageNode(pkgPath string) *PackageNode {
 	return ds.GetBlockNode(PackageNodeLocation(pkgPath)).(*PackageNode)
@@ -945,6 +1077,12 @@ func (ds *defaultStore) SetBlockNode(bn BlockNode) {
 	// XXX
 }
 
+// DelBlockNode removes the node at loc from the cache. Like SetBlockNode,
+// it does not touch the backend, which does not store nodes.
+func (ds *defaultStore) DelBlockNode(loc Location) {
+	ds.cacheNodes.Delete(loc)
+}
+
 func (ds *defaultStore) NumMemPackages() int64 {
 	ctrkey := []byte(backendPackageIndexCtrKey())
 	ctrbz := ds.baseStore.Get(ds.gctx, ctrkey)
@@ -1448,6 +1586,28 @@ func backendRealmKey(oid ObjectID) string {
 	return "oid:" + oid.String() + "#realm"
 }
 
+// backendPackageObjectsPrefix is the prefix of the backend keys of the
+// objects of pid, and of its realm record.
+func backendPackageObjectsPrefix(pid PkgID) string {
+	return "oid:" + escapedPackageObjectsPrefix(pid)
+}
+
+// escapedPackageObjectsPrefix is the prefix of the iavl keys of the hashes of
+// the escaped objects of pid.
+func escapedPackageObjectsPrefix(pid PkgID) string {
+	return hex.EncodeToString(pid.Hashlet[:]) + ":"
+}
+
+// backendFuncValueKey indexes the function value oid running the code of the
+// realm at pkgPath; ':' cannot appear in a package path.
+func backendFuncValueKey(pkgPath string, oid ObjectID) string {
+	return backendFuncValuePrefix(pkgPath) + oid.String()
+}
+
+func backendFuncValuePrefix(pkgPath string) string {
+	return "fnv:" + pkgPath + ":"
+}
+
 func backendTypeKey(tid TypeID) string {
 	return "tid:" + tid.String()
 }
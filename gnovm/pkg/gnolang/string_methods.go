@@ -23,27 +23,30 @@ func _() {
 	_ = x[Uint64Kind-12]
 	_ = x[Float32Kind-13]
 	_ = x[Float64Kind-14]
-	_ = x[BigintKind-15]
-	_ = x[BigdecKind-16]
-	_ = x[ArrayKind-17]
-	_ = x[SliceKind-18]
-	_ = x[PointerKind-19]
-	_ = x[StructKind-20]
-	_ = x[PackageKind-21]
-	_ = x[InterfaceKind-22]
-	_ = x[ChanKind-23]
-	_ = x[FuncKind-24]
-	_ = x[MapKind-25]
-	_ = x[TypeKind-26]
-	_ = x[BlockKind-27]
-	_ = x[HeapItemKind-28]
-	_ = x[TupleKind-29]
-	_ = x[RefTypeKind-30]
+	_ = x[Complex64Kind-15]
+	_ = x[Complex128Kind-16]
+	_ = x[BigintKind-17]
+	_ = x[BigdecKind-18]
+	_ = x[BigcomplexKind-19]
+	_ = x[ArrayKind-20]
+	_ = x[SliceKind-21]
+	_ = x[PointerKind-22]
+	_ = x[StructKind-23]
+	_ = x[PackageKind-24]
+	_ = x[InterfaceKind-25]
+	_ = x[ChanKind-26]
+	_ = x[FuncKind-27]
+	_ = x[MapKind-28]
+	_ = x[TypeKind-29]
+	_ = x[BlockKind-30]
+	_ = x[HeapItemKind-31]
+	_ = x[TupleKind-32]
+	_ = x[RefTypeKind-33]
 }
 
-const _Kind_name = "InvalidKindBoolKindStringKindIntKindInt8KindInt16KindInt32KindInt64KindUintKindUint8KindUint16KindUint32KindUint64KindFloat32KindFloat64KindBigintKindBigdecKindArrayKindSliceKindPointerKindStructKindPackageKindInterfaceKindChanKindFuncKindMapKindTypeKindBlockKindHeapItemKindTupleKindRefTypeKind"
+const _Kind_name = "InvalidKindBoolKindStringKindIntKindInt8KindInt16KindInt32KindInt64KindUintKindUint8KindUint16KindUint32KindUint64KindFloat32KindFloat64KindComplex64KindComplex128KindBigintKindBigdecKindBigcomplexKindArrayKindSliceKindPointerKindStructKindPackageKindInterfaceKindChanKindFuncKindMapKindTypeKindBlockKindHeapItemKindTupleKindRefTypeKind"
 
-var _Kind_index = [...]uint16{0, 11, 19, 29, 36, 44, 53, 62, 71, 79, 88, 98, 108, 118, 129, 140, 150, 160, 169, 178, 189, 199, 210, 223, 231, 239, 246, 254, 263, 275, 284, 295}
+var _Kind_index = [...]uint16{0, 11, 19, 29, 36, 44, 53, 62, 71, 79, 88, 98, 108, 118, 129, 140, 153, 167, 177, 187, 201, 210, 219, 230, 240, 251, 264, 272, 280, 287, 295, 304, 316, 325, 336}
 
 func (i Kind) String() string {
 	idx := int(i) - 0
@@ -64,6 +67,7 @@ func _() {
 	_ = x[OpEnterCrossing-5]
 	_ = x[OpCall-6]
 	_ = x[OpCallNativeBody-7]
+	_ = x[OpGoexit-8]
 	_ = x[OpDefer-10]
 	_ = x[OpCallDeferNativeBody-11]
 	_ = x[OpGo-12]
@@ -86,6 +90,7 @@ func _() {
 	_ = x[OpUneg-33]
 	_ = x[OpUnot-34]
 	_ = x[OpUxor-35]
+	_ = x[OpUrecv2-36]
 	_ = x[OpUrecv-37]
 	_ = x[OpLor-38]
 	_ = x[OpLand-39]
@@ -149,6 +154,7 @@ func _() {
 	_ = x[OpDefine-140]
 	_ = x[OpInc-141]
 	_ = x[OpDec-142]
+	_ = x[OpSend-143]
 	_ = x[OpValueDecl-144]
 	_ = x[OpTypeDecl-145]
 	_ = x[OpSticky-208]
@@ -159,10 +165,11 @@ func _() {
 	_ = x[OpRangeIterMap-213]
 	_ = x[OpRangeIterArrayPtr-214]
 	_ = x[OpReturnCallDefers-215]
+	_ = x[OpRangeIterChan-216]
 	_ = x[OpVoid-255]
 }
 
-const _Op_name = "OpInvalidOpHaltOpNoopOpExecOpPrecallOpEnterCrossingOpCallOpCallNativeBodyOpDeferOpCallDeferNativeBodyOpGoOpSelectOpSwitchClauseOpSwitchClauseCaseOpTypeSwitchOpIfCondOpPopValueOpPopResultsOpPopBlockOpPopFrameAndResetOpPanic1OpPanic2OpReturnOpReturnAfterCopyOpReturnFromBlockOpReturnToBlockOpUposOpUnegOpUnotOpUxorOpUrecvOpLorOpLandOpEqlOpNeqOpLssOpLeqOpGtrOpGeqOpAddOpSubOpBorOpXorOpMulOpQuoOpRemOpShlOpShrOpBandOpBandnOpEvalOpBinary1OpIndex1OpIndex2OpSelectorOpSliceOpStarOpRefOpTypeAssert1OpTypeAssert2OpStaticTypeOfOpCompositeLitOpArrayLitOpSliceLitOpSliceLit2OpMapLitOpStructLitOpFuncLitOpConvertOpFieldTypeOpArrayTypeOpSliceTypeOpPointerTypeOpInterfaceTypeOpChanTypeOpFuncTypeOpMapTypeOpStructTypeOpAssignOpAddAssignOpSubAssignOpMulAssignOpQuoAssignOpRemAssignOpBandAssignOpBandnAssignOpBorAssignOpXorAssignOpShlAssignOpShrAssignOpDefineOpIncOpDecOpValueDeclOpTypeDeclOpStickyOpBodyOpForLoopOpRangeIterOpRangeIterStringOpRangeIterMapOpRangeIterArrayPtrOpReturnCallDefersOpVoid"
+const _Op_name = "OpInvalidOpHaltOpNoopOpExecOpPrecallOpEnterCrossingOpCallOpCallNativeBodyOpGoexitOpDeferOpCallDeferNativeBodyOpGoOpSelectOpSwitchClauseOpSwitchClauseCaseOpTypeSwitchOpIfCondOpPopValueOpPopResultsOpPopBlockOpPopFrameAndResetOpPanic1OpPanic2OpReturnOpReturnAfterCopyOpReturnFromBlockOpReturnToBlockOpUposOpUnegOpUnotOpUxorOpUrecv2OpUrecvOpLorOpLandOpEqlOpNeqOpLssOpLeqOpGtrOpGeqOpAddOpSubOpBorOpXorOpMulOpQuoOpRemOpShlOpShrOpBandOpBandnOpEvalOpBinary1OpIndex1OpIndex2OpSelectorOpSliceOpStarOpRefOpTypeAssert1OpTypeAssert2OpStaticTypeOfOpCompositeLitOpArrayLitOpSliceLitOpSliceLit2OpMapLitOpStructLitOpFuncLitOpConvertOpFieldTypeOpArrayTypeOpSliceTypeOpPointerTypeOpInterfaceTypeOpChanTypeOpFuncTypeOpMapTypeOpStructTypeOpAssignOpAddAssignOpSubAssignOpMulAssignOpQuoAssignOpRemAssignOpBandAssignOpBandnAssignOpBorAssignOpXorAssignOpShlAssignOpShrAssignOpDefineOpIncOpDecOpSendOpValueDeclOpTypeDeclOpStickyOpBodyOpForLoopOpRangeIterOpRangeIterStringOpRangeIterMapOpRangeIterArrayPtrOpReturnCallDefersOpRangeIterChanOpVoid"
 
 var _Op_map = map[Op]string{
 	0:   _Op_name[0:9],
@@ -173,102 +180,106 @@ var _Op_map = map[Op]string{
 	5:   _Op_name[36:51],
 	6:   _Op_name[51:57],
 	7:   _Op_name[57:73],
-	10:  _Op_name[73:80],
-	11:  _Op_name[80:101],
-	12:  _Op_name[101:105],
-	13:  _Op_name[105:113],
-	14:  _Op_name[113:127],
-	15:  _Op_name[127:145],
-	16:  _Op_name[145:157],
-	17:  _Op_name[157:165],
-	18:  _Op_name[165:175],
-	19:  _Op_name[175:187],
-	20:  _Op_name[187:197],
-	21:  _Op_name[197:215],
-	22:  _Op_name[215:223],
-	23:  _Op_name[223:231],
-	26:  _Op_name[231:239],
-	27:  _Op_name[239:256],
-	28:  _Op_name[256:273],
-	29:  _Op_name[273:288],
-	32:  _Op_name[288:294],
-	33:  _Op_name[294:300],
-	34:  _Op_name[300:306],
-	35:  _Op_name[306:312],
-	37:  _Op_name[312:319],
-	38:  _Op_name[319:324],
-	39:  _Op_name[324:330],
-	40:  _Op_name[330:335],
-	41:  _Op_name[335:340],
-	42:  _Op_name[340:345],
-	43:  _Op_name[345:350],
-	44:  _Op_name[350:355],
-	45:  _Op_name[355:360],
-	46:  _Op_name[360:365],
-	47:  _Op_name[365:370],
-	48:  _Op_name[370:375],
-	49:  _Op_name[375:380],
-	50:  _Op_name[380:385],
-	51:  _Op_name[385:390],
-	52:  _Op_name[390:395],
-	53:  _Op_name[395:400],
-	54:  _Op_name[400:405],
-	55:  _Op_name[405:411],
-	56:  _Op_name[411:418],
-	64:  _Op_name[418:424],
-	65:  _Op_name[424:433],
-	66:  _Op_name[433:441],
-	67:  _Op_name[441:449],
-	68:  _Op_name[449:459],
-	69:  _Op_name[459:466],
-	70:  _Op_name[466:472],
-	71:  _Op_name[472:477],
-	72:  _Op_name[477:490],
-	73:  _Op_name[490:503],
-	74:  _Op_name[503:517],
-	75:  _Op_name[517:531],
-	76:  _Op_name[531:541],
-	77:  _Op_name[541:551],
-	78:  _Op_name[551:562],
-	79:  _Op_name[562:570],
-	80:  _Op_name[570:581],
-	81:  _Op_name[581:590],
-	82:  _Op_name[590:599],
-	112: _Op_name[599:610],
-	113: _Op_name[610:621],
-	114: _Op_name[621:632],
-	115: _Op_name[632:645],
-	116: _Op_name[645:660],
-	117: _Op_name[660:670],
-	118: _Op_name[670:680],
-	119: _Op_name[680:689],
-	120: _Op_name[689:701],
-	128: _Op_name[701:709],
-	129: _Op_name[709:720],
-	130: _Op_name[720:731],
-	131: _Op_name[731:742],
-	132: _Op_name[742:753],
-	133: _Op_name[753:764],
-	134: _Op_name[764:776],
-	135: _Op_name[776:789],
-	136: _Op_name[789:800],
-	137: _Op_name[800:811],
-	138: _Op_name[811:822],
-	139: _Op_name[822:833],
-	140: _Op_name[833:841],
-	141: _Op_name[841:846],
-	142: _Op_name[846:851],
-	144: _Op_name[851:862],
-	145: _Op_name[862:872],
-	208: _Op_name[872:880],
-	209: _Op_name[880:886],
-	210: _Op_name[886:895],
-	211: _Op_name[895:906],
-	212: _Op_name[906:923],
-	213: _Op_name[923:937],
-	214: _Op_name[937:956],
-	215: _Op_name[956:974],
-	255: _Op_name[974:980],
+	8:   _Op_name[73:81],
+	10:  _Op_name[81:88],
+	11:  _Op_name[88:109],
+	12:  _Op_name[109:113],
+	13:  _Op_name[113:121],
+	14:  _Op_name[121:135],
+	15:  _Op_name[135:153],
+	16:  _Op_name[153:165],
+	17:  _Op_name[165:173],
+	18:  _Op_name[173:183],
+	19:  _Op_name[183:195],
+	20:  _Op_name[195:205],
+	21:  _Op_name[205:223],
+	22:  _Op_name[223:231],
+	23:  _Op_name[231:239],
+	26:  _Op_name[239:247],
+	27:  _Op_name[247:264],
+	28:  _Op_name[264:281],
+	29:  _Op_name[281:296],
+	32:  _Op_name[296:302],
+	33:  _Op_name[302:308],
+	34:  _Op_name[308:314],
+	35:  _Op_name[314:320],
+	36:  _Op_name[320:328],
+	37:  _Op_name[328:335],
+	38:  _Op_name[335:340],
+	39:  _Op_name[340:346],
+	40:  _Op_name[346:351],
+	41:  _Op_name[351:356],
+	42:  _Op_name[356:361],
+	43:  _Op_name[361:366],
+	44:  _Op_name[366:371],
+	45:  _Op_name[371:376],
+	46:  _Op_name[376:381],
+	47:  _Op_name[381:386],
+	48:  _Op_name[386:391],
+	49:  _Op_name[391:396],
+	50:  _Op_name[396:401],
+	51:  _Op_name[401:406],
+	52:  _Op_name[406:411],
+	53:  _Op_name[411:416],
+	54:  _Op_name[416:421],
+	55:  _Op_name[421:427],
+	56:  _Op_name[427:434],
+	64:  _Op_name[434:440],
+	65:  _Op_name[440:449],
+	66:  _Op_name[449:457],
+	67:  _Op_name[457:465],
+	68:  _Op_name[465:475],
+	69:  _Op_name[475:482],
+	70:  _Op_name[482:488],
+	71:  _Op_name[488:493],
+	72:  _Op_name[493:506],
+	73:  _Op_name[506:519],
+	74:  _Op_name[519:533],
+	75:  _Op_name[533:547],
+	76:  _Op_name[547:557],
+	77:  _Op_name[557:567],
+	78:  _Op_name[567:578],
+	79:  _Op_name[578:586],
+	80:  _Op_name[586:597],
+	81:  _Op_name[597:606],
+	82:  _Op_name[606:615],
+	112: _Op_name[615:626],
+	113: _Op_name[626:637],
+	114: _Op_name[637:648],
+	115: _Op_name[648:661],
+	116: _Op_name[661:676],
+	117: _Op_name[676:686],
+	118: _Op_name[686:696],
+	119: _Op_name[696:705],
+	120: _Op_name[705:717],
+	128: _Op_name[717:725],
+	129: _Op_name[725:736],
+	130: _Op_name[736:747],
+	131: _Op_name[747:758],
+	132: _Op_name[758:769],
+	133: _Op_name[769:780],
+	134: _Op_name[780:792],
+	135: _Op_name[792:805],
+	136: _Op_name[805:816],
+	137: _Op_name[816:827],
+	138: _Op_name[827:838],
+	139: _Op_name[838:849],
+	140: _Op_name[849:857],
+	141: _Op_name[857:862],
+	142: _Op_name[862:867],
+	143: _Op_name[867:873],
+	144: _Op_name[873:884],
+	145: _Op_name[884:894],
+	208: _Op_name[894:902],
+	209: _Op_name[902:908],
+	210: _Op_name[908:917],
+	211: _Op_name[917:928],
+	212: _Op_name[928:945],
+	213: _Op_name[945:959],
+	214: _Op_name[959:978],
+	215: _Op_name[978:996],
+	216: _Op_name[996:1011],
+	255: _Op_name[1011:1017],
 }
 
 func (i Op) String() string {
@@ -308,83 +319,85 @@ func _() {
 	_ = x[TRANS_CALL_ARG-4]
 	_ = x[TRANS_INDEX_X-5]
 	_ = x[TRANS_INDEX_INDEX-6]
-	_ = x[TRANS_SELECTOR_X-7]
-	_ = x[TRANS_SLICE_X-8]
-	_ = x[TRANS_SLICE_LOW-9]
-	_ = x[TRANS_SLICE_HIGH-10]
-	_ = x[TRANS_SLICE_MAX-11]
-	_ = x[TRANS_STAR_X-12]
-	_ = x[TRANS_REF_X-13]
-	_ = x[TRANS_TYPEASSERT_X-14]
-	_ = x[TRANS_TYPEASSERT_TYPE-15]
-	_ = x[TRANS_UNARY_X-16]
-	_ = x[TRANS_COMPOSITE_TYPE-17]
-	_ = x[TRANS_COMPOSITE_KEY-18]
-	_ = x[TRANS_COMPOSITE_VALUE-19]
-	_ = x[TRANS_FUNCLIT_TYPE-20]
-	_ = x[TRANS_FUNCLIT_HEAP_CAPTURE-21]
-	_ = x[TRANS_FUNCLIT_BODY-22]
-	_ = x[TRANS_FIELDTYPE_NAME-23]
-	_ = x[TRANS_FIELDTYPE_TYPE-24]
-	_ = x[TRANS_FIELDTYPE_TAG-25]
-	_ = x[TRANS_ARRAYTYPE_LEN-26]
-	_ = x[TRANS_ARRAYTYPE_ELT-27]
-	_ = x[TRANS_SLICETYPE_ELT-28]
-	_ = x[TRANS_INTERFACETYPE_METHOD-29]
-	_ = x[TRANS_CHANTYPE_VALUE-30]
-	_ = x[TRANS_FUNCTYPE_PARAM-31]
-	_ = x[TRANS_FUNCTYPE_RESULT-32]
-	_ = x[TRANS_MAPTYPE_KEY-33]
-	_ = x[TRANS_MAPTYPE_VALUE-34]
-	_ = x[TRANS_STRUCTTYPE_FIELD-35]
-	_ = x[TRANS_ASSIGN_LHS-36]
-	_ = x[TRANS_ASSIGN_RHS-37]
-	_ = x[TRANS_BLOCK_BODY-38]
-	_ = x[TRANS_DECL_BODY-39]
-	_ = x[TRANS_DEFER_CALL-40]
-	_ = x[TRANS_EXPR_X-41]
-	_ = x[TRANS_FOR_INIT-42]
-	_ = x[TRANS_FOR_COND-43]
-	_ = x[TRANS_FOR_POST-44]
-	_ = x[TRANS_FOR_BODY-45]
-	_ = x[TRANS_GO_CALL-46]
-	_ = x[TRANS_IF_INIT-47]
-	_ = x[TRANS_IF_COND-48]
-	_ = x[TRANS_IF_BODY-49]
-	_ = x[TRANS_IF_ELSE-50]
-	_ = x[TRANS_IF_CASE_BODY-51]
-	_ = x[TRANS_INCDEC_X-52]
-	_ = x[TRANS_RANGE_X-53]
-	_ = x[TRANS_RANGE_KEY-54]
-	_ = x[TRANS_RANGE_VALUE-55]
-	_ = x[TRANS_RANGE_BODY-56]
-	_ = x[TRANS_RETURN_RESULT-57]
-	_ = x[TRANS_SELECT_CASE-58]
-	_ = x[TRANS_SELECTCASE_COMM-59]
-	_ = x[TRANS_SELECTCASE_BODY-60]
-	_ = x[TRANS_SEND_CHAN-61]
-	_ = x[TRANS_SEND_VALUE-62]
-	_ = x[TRANS_SWITCH_INIT-63]
-	_ = x[TRANS_SWITCH_X-64]
-	_ = x[TRANS_SWITCH_CASE-65]
-	_ = x[TRANS_SWITCHCASE_CASE-66]
-	_ = x[TRANS_SWITCHCASE_BODY-67]
-	_ = x[TRANS_FUNC_RECV-68]
-	_ = x[TRANS_FUNC_TYPE-69]
-	_ = x[TRANS_FUNC_BODY-70]
-	_ = x[TRANS_IMPORT_PATH-71]
-	_ = x[TRANS_CONST_TYPE-72]
-	_ = x[TRANS_CONST_VALUE-73]
-	_ = x[TRANS_VAR_NAME-74]
-	_ = x[TRANS_VAR_TYPE-75]
-	_ = x[TRANS_VAR_VALUE-76]
-	_ = x[TRANS_TYPE_TYPE-77]
-	_ = x[TRANS_FILE_BODY-78]
+	_ = x[TRANS_INDEXLIST_X-7]
+	_ = x[TRANS_INDEXLIST_INDEX-8]
+	_ = x[TRANS_SELECTOR_X-9]
+	_ = x[TRANS_SLICE_X-10]
+	_ = x[TRANS_SLICE_LOW-11]
+	_ = x[TRANS_SLICE_HIGH-12]
+	_ = x[TRANS_SLICE_MAX-13]
+	_ = x[TRANS_STAR_X-14]
+	_ = x[TRANS_REF_X-15]
+	_ = x[TRANS_TYPEASSERT_X-16]
+	_ = x[TRANS_TYPEASSERT_TYPE-17]
+	_ = x[TRANS_UNARY_X-18]
+	_ = x[TRANS_COMPOSITE_TYPE-19]
+	_ = x[TRANS_COMPOSITE_KEY-20]
+	_ = x[TRANS_COMPOSITE_VALUE-21]
+	_ = x[TRANS_FUNCLIT_TYPE-22]
+	_ = x[TRANS_FUNCLIT_HEAP_CAPTURE-23]
+	_ = x[TRANS_FUNCLIT_BODY-24]
+	_ = x[TRANS_FIELDTYPE_NAME-25]
+	_ = x[TRANS_FIELDTYPE_TYPE-26]
+	_ = x[TRANS_FIELDTYPE_TAG-27]
+	_ = x[TRANS_ARRAYTYPE_LEN-28]
+	_ = x[TRANS_ARRAYTYPE_ELT-29]
+	_ = x[TRANS_SLICETYPE_ELT-30]
+	_ = x[TRANS_INTERFACETYPE_METHOD-31]
+	_ = x[TRANS_CHANTYPE_VALUE-32]
+	_ = x[TRANS_FUNCTYPE_PARAM-33]
+	_ = x[TRANS_FUNCTYPE_RESULT-34]
+	_ = x[TRANS_MAPTYPE_KEY-35]
+	_ = x[TRANS_MAPTYPE_VALUE-36]
+	_ = x[TRANS_STRUCTTYPE_FIELD-37]
+	_ = x[TRANS_ASSIGN_LHS-38]
+	_ = x[TRANS_ASSIGN_RHS-39]
+	_ = x[TRANS_BLOCK_BODY-40]
+	_ = x[TRANS_DECL_BODY-41]
+	_ = x[TRANS_DEFER_CALL-42]
+	_ = x[TRANS_EXPR_X-43]
+	_ = x[TRANS_FOR_INIT-44]
+	_ = x[TRANS_FOR_COND-45]
+	_ = x[TRANS_FOR_POST-46]
+	_ = x[TRANS_FOR_BODY-47]
+	_ = x[TRANS_GO_CALL-48]
+	_ = x[TRANS_IF_INIT-49]
+	_ = x[TRANS_IF_COND-50]
+	_ = x[TRANS_IF_BODY-51]
+	_ = x[TRANS_IF_ELSE-52]
+	_ = x[TRANS_IF_CASE_BODY-53]
+	_ = x[TRANS_INCDEC_X-54]
+	_ = x[TRANS_RANGE_X-55]
+	_ = x[TRANS_RANGE_KEY-56]
+	_ = x[TRANS_RANGE_VALUE-57]
+	_ = x[TRANS_RANGE_BODY-58]
+	_ = x[TRANS_RETURN_RESULT-59]
+	_ = x[TRANS_SELECT_CASE-60]
+	_ = x[TRANS_SELECTCASE_COMM-61]
+	_ = x[TRANS_SELECTCASE_BODY-62]
+	_ = x[TRANS_SEND_CHAN-63]
+	_ = x[TRANS_SEND_VALUE-64]
+	_ = x[TRANS_SWITCH_INIT-65]
+	_ = x[TRANS_SWITCH_X-66]
+	_ = x[TRANS_SWITCH_CASE-67]
+	_ = x[TRANS_SWITCHCASE_CASE-68]
+	_ = x[TRANS_SWITCHCASE_BODY-69]
+	_ = x[TRANS_FUNC_RECV-70]
+	_ = x[TRANS_FUNC_TYPE-71]
+	_ = x[TRANS_FUNC_BODY-72]
+	_ = x[TRANS_IMPORT_PATH-73]
+	_ = x[TRANS_CONST_TYPE-74]
+	_ = x[TRANS_CONST_VALUE-75]
+	_ = x[TRANS_VAR_NAME-76]
+	_ = x[TRANS_VAR_TYPE-77]
+	_ = x[TRANS_VAR_VALUE-78]
+	_ = x[TRANS_TYPE_TYPE-79]
+	_ = x[TRANS_FILE_BODY-80]
 }
 
-const _TransField_name = "TRANS_ROOTTRANS_BINARY_LEFTTRANS_BINARY_RIGHTTRANS_CALL_FUNCTRANS_CALL_ARGTRANS_INDEX_XTRANS_INDEX_INDEXTRANS_SELECTOR_XTRANS_SLICE_XTRANS_SLICE_LOWTRANS_SLICE_HIGHTRANS_SLICE_MAXTRANS_STAR_XTRANS_REF_XTRANS_TYPEASSERT_XTRANS_TYPEASSERT_TYPETRANS_UNARY_XTRANS_COMPOSITE_TYPETRANS_COMPOSITE_KEYTRANS_COMPOSITE_VALUETRANS_FUNCLIT_TYPETRANS_FUNCLIT_HEAP_CAPTURETRANS_FUNCLIT_BODYTRANS_FIELDTYPE_NAMETRANS_FIELDTYPE_TYPETRANS_FIELDTYPE_TAGTRANS_ARRAYTYPE_LENTRANS_ARRAYTYPE_ELTTRANS_SLICETYPE_ELTTRANS_INTERFACETYPE_METHODTRANS_CHANTYPE_VALUETRANS_FUNCTYPE_PARAMTRANS_FUNCTYPE_RESULTTRANS_MAPTYPE_KEYTRANS_MAPTYPE_VALUETRANS_STRUCTTYPE_FIELDTRANS_ASSIGN_LHSTRANS_ASSIGN_RHSTRANS_BLOCK_BODYTRANS_DECL_BODYTRANS_DEFER_CALLTRANS_EXPR_XTRANS_FOR_INITTRANS_FOR_CONDTRANS_FOR_POSTTRANS_FOR_BODYTRANS_GO_CALLTRANS_IF_INITTRANS_IF_CONDTRANS_IF_BODYTRANS_IF_ELSETRANS_IF_CASE_BODYTRANS_INCDEC_XTRANS_RANGE_XTRANS_RANGE_KEYTRANS_RANGE_VALUETRANS_RANGE_BODYTRANS_RETURN_RESULTTRANS_SELECT_CASETRANS_SELECTCASE_COMMTRANS_SELECTCASE_BODYTRANS_SEND_CHANTRANS_SEND_VALUETRANS_SWITCH_INITTRANS_SWITCH_XTRANS_SWITCH_CASETRANS_SWITCHCASE_CASETRANS_SWITCHCASE_BODYTRANS_FUNC_RECVTRANS_FUNC_TYPETRANS_FUNC_BODYTRANS_IMPORT_PATHTRANS_CONST_TYPETRANS_CONST_VALUETRANS_VAR_NAMETRANS_VAR_TYPETRANS_VAR_VALUETRANS_TYPE_TYPETRANS_FILE_BODY"
+const _TransField_name = "TRANS_ROOTTRANS_BINARY_LEFTTRANS_BINARY_RIGHTTRANS_CALL_FUNCTRANS_CALL_ARGTRANS_INDEX_XTRANS_INDEX_INDEXTRANS_INDEXLIST_XTRANS_INDEXLIST_INDEXTRANS_SELECTOR_XTRANS_SLICE_XTRANS_SLICE_LOWTRANS_SLICE_HIGHTRANS_SLICE_MAXTRANS_STAR_XTRANS_REF_XTRANS_TYPEASSERT_XTRANS_TYPEASSERT_TYPETRANS_UNARY_XTRANS_COMPOSITE_TYPETRANS_COMPOSITE_KEYTRANS_COMPOSITE_VALUETRANS_FUNCLIT_TYPETRANS_FUNCLIT_HEAP_CAPTURETRANS_FUNCLIT_BODYTRANS_FIELDTYPE_NAMETRANS_FIELDTYPE_TYPETRANS_FIELDTYPE_TAGTRANS_ARRAYTYPE_LENTRANS_ARRAYTYPE_ELTTRANS_SLICETYPE_ELTTRANS_INTERFACETYPE_METHODTRANS_CHANTYPE_VALUETRANS_FUNCTYPE_PARAMTRANS_FUNCTYPE_RESULTTRANS_MAPTYPE_KEYTRANS_MAPTYPE_VALUETRANS_STRUCTTYPE_FIELDTRANS_ASSIGN_LHSTRANS_ASSIGN_RHSTRANS_BLOCK_BODYTRANS_DECL_BODYTRANS_DEFER_CALLTRANS_EXPR_XTRANS_FOR_INITTRANS_FOR_CONDTRANS_FOR_POSTTRANS_FOR_BODYTRANS_GO_CALLTRANS_IF_INITTRANS_IF_CONDTRANS_IF_BODYTRANS_IF_ELSETRANS_IF_CASE_BODYTRANS_INCDEC_XTRANS_RANGE_XTRANS_RANGE_KEYTRANS_RANGE_VALUETRANS_RANGE_BODYTRANS_RETURN_RESULTTRANS_SELECT_CASETRANS_SELECTCASE_COMMTRANS_SELECTCASE_BODYTRANS_SEND_CHANTRANS_SEND_VALUETRANS_SWITCH_INITTRANS_SWITCH_XTRANS_SWITCH_CASETRANS_SWITCHCASE_CASETRANS_SWITCHCASE_BODYTRANS_FUNC_RECVTRANS_FUNC_TYPETRANS_FUNC_BODYTRANS_IMPORT_PATHTRANS_CONST_TYPETRANS_CONST_VALUETRANS_VAR_NAMETRANS_VAR_TYPETRANS_VAR_VALUETRANS_TYPE_TYPETRANS_FILE_BODY"
 
-var _TransField_index = [...]uint16{0, 10, 27, 45, 60, 74, 87, 104, 120, 133, 148, 164, 179, 191, 202, 220, 241, 254, 274, 293, 314, 332, 358, 376, 396, 416, 435, 454, 473, 492, 518, 538, 558, 579, 596, 615, 637, 653, 669, 685, 700, 716, 728, 742, 756, 770, 784, 797, 810, 823, 836, 849, 867, 881, 894, 909, 926, 942, 961, 978, 999, 1020, 1035, 1051, 1068, 1082, 1099, 1120, 1141, 1156, 1171, 1186, 1203, 1219, 1236, 1250, 1264, 1279, 1294, 1309}
+var _TransField_index = [...]uint16{0, 10, 27, 45, 60, 74, 87, 104, 121, 142, 158, 171, 186, 202, 217, 229, 240, 258, 279, 292, 312, 331, 352, 370, 396, 414, 434, 454, 473, 492, 511, 530, 556, 576, 596, 617, 634, 653, 675, 691, 707, 723, 738, 754, 766, 780, 794, 808, 822, 835, 848, 861, 874, 887, 905, 919, 932, 947, 964, 980, 999, 1016, 1037, 1058, 1073, 1089, 1106, 1120, 1137, 1158, 1179, 1194, 1209, 1224, 1241, 1257, 1274, 1288, 1302, 1317, 1332, 1347}
 
 func (i TransField) String() string {
 	idx := int(i) - 0
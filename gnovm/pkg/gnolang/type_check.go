@@ -32,10 +32,11 @@ var (
 	}
 	// TODO: star, addressable
 	unaryChecker = map[Word]func(t Type) bool{
-		ADD: isNumeric,
-		SUB: isNumeric,
-		XOR: isWhole,
-		NOT: isBoolean,
+		ADD:   isNumeric,
+		SUB:   isNumeric,
+		XOR:   isWhole,
+		NOT:   isBoolean,
+		ARROW: isRecvChan,
 	}
 	IncDecStmtChecker = map[Word]func(t Type) bool{
 		INC: isNumeric,
@@ -65,10 +66,12 @@ const (
 	IsString
 	IsBigInt
 	IsBigDec
+	IsComplex
+	IsBigComplex
 
-	IsNumeric = IsInteger | IsFloat | IsBigInt | IsBigDec
+	IsNumeric = IsInteger | IsFloat | IsBigInt | IsBigDec | IsComplex | IsBigComplex
 	IsWhole   = IsInteger | IsBigInt
-	IsOrdered = IsNumeric | IsString
+	IsOrdered = IsInteger | IsFloat | IsBigInt | IsBigDec | IsString
 )
 
 func (pt PrimitiveType) category() category {
@@ -85,6 +88,10 @@ func (pt PrimitiveType) category() category {
 		return IsBigInt
 	case BigdecKind:
 		return IsBigDec
+	case Complex64Kind, Complex128Kind:
+		return IsComplex
+	case BigcomplexKind:
+		return IsBigComplex
 	default:
 		panic(fmt.Sprintf("unexpected primitive type %v", pt))
 	}
@@ -108,6 +115,16 @@ func isBoolean(t Type) bool {
 	}
 }
 
+// isRecvChan returns true if values can be received from t.
+func isRecvChan(t Type) bool {
+	switch t := baseOf(t).(type) {
+	case *ChanType:
+		return t.Dir != SEND
+	default:
+		return false
+	}
+}
+
 // rune can be numeric and string
 func isNumeric(t Type) bool {
 	switch t := baseOf(t).(type) {
@@ -145,6 +162,16 @@ func isWhole(t Type) bool {
 	}
 }
 
+// isComplex returns true for typed and untyped complex types.
+func isComplex(t Type) bool {
+	switch t := baseOf(t).(type) {
+	case PrimitiveType:
+		return t.category()&(IsComplex|IsBigComplex) != 0
+	default:
+		return false
+	}
+}
+
 func isIntegerKind(k Kind) bool {
 	switch k {
 	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind,
@@ -159,7 +186,7 @@ func isIntegerKind(k Kind) bool {
 
 func mayBeNil(t Type) bool {
 	switch baseOf(t).(type) {
-	case *SliceType, *FuncType, *MapType, *InterfaceType, *PointerType: //  we don't have unsafePointer
+	case *SliceType, *FuncType, *MapType, *ChanType, *InterfaceType, *PointerType: //  we don't have unsafePointer
 		return true
 	default:
 		return false
@@ -227,7 +254,7 @@ Main:
 			if cx, ok := currExpr.Func.(*ConstExpr); ok {
 				if fv, ok := cx.V.(*FuncValue); ok {
 					if fv.PkgPath == uversePkgPath {
-						// TODO: should support min, max, real, imag
+						// TODO: should support min, max
 						switch fv.Name {
 						case "len":
 							at := evalStaticTypeOf(store, last, currExpr.Args[0])
@@ -362,6 +389,13 @@ func checkValDefineMismatch(n Node) {
 				panic(fmt.Sprintf("assignment mismatch: %d variable(s) but %d value(s)", numNames, numValues))
 			}
 			return
+		case *UnaryExpr:
+			if values[0].(*UnaryExpr).Op == ARROW {
+				if numNames != 2 {
+					panic(fmt.Sprintf("assignment mismatch: %d variable(s) but %d value(s)", numNames, numValues))
+				}
+				return
+			}
 		case *IndexExpr:
 			if numNames != 2 {
 				panic(fmt.Sprintf("assignment mismatch: %d variable(s) but %d value(s)", numNames, numValues))
@@ -521,18 +555,34 @@ func checkAssignableTo(n Node, xt, dt Type) (err error) {
 			switch dt.Kind() {
 			case IntKind, Int8Kind, Int16Kind, Int32Kind,
 				Int64Kind, UintKind, Uint8Kind, Uint16Kind,
-				Uint32Kind, Uint64Kind, BigdecKind, Float32Kind, Float64Kind:
+				Uint32Kind, Uint64Kind, BigdecKind, Float32Kind, Float64Kind,
+				BigcomplexKind, Complex64Kind, Complex128Kind:
 				return nil // ok
 			default:
 				return errors.New(
 					"cannot use untyped Bigdec as %s",
 					dt.Kind())
 			}
+		// Like bigdec above, a loose check: the imaginary part must be
+		// zero for non-complex kinds, which ConvertUntypedTo asserts.
+		case UntypedBigcomplexType:
+			switch dt.Kind() {
+			case IntKind, Int8Kind, Int16Kind, Int32Kind,
+				Int64Kind, UintKind, Uint8Kind, Uint16Kind,
+				Uint32Kind, Uint64Kind, BigdecKind, Float32Kind, Float64Kind,
+				BigcomplexKind, Complex64Kind, Complex128Kind:
+				return nil // ok
+			default:
+				return errors.New(
+					"cannot use untyped Bigcomplex as %s",
+					dt.Kind())
+			}
 		case UntypedBigintType:
 			switch dt.Kind() {
 			case IntKind, Int8Kind, Int16Kind, Int32Kind,
 				Int64Kind, UintKind, Uint8Kind, Uint16Kind,
-				Uint32Kind, Uint64Kind, BigintKind, BigdecKind, Float32Kind, Float64Kind: // see 0d0
+				Uint32Kind, Uint64Kind, BigintKind, BigdecKind, Float32Kind, Float64Kind, // see 0d0
+				BigcomplexKind, Complex64Kind, Complex128Kind:
 				return nil // ok
 			default:
 				return errors.New(
@@ -543,7 +593,8 @@ func checkAssignableTo(n Node, xt, dt Type) (err error) {
 			switch dt.Kind() {
 			case IntKind, Int8Kind, Int16Kind, Int32Kind,
 				Int64Kind, UintKind, Uint8Kind, Uint16Kind,
-				Uint32Kind, Uint64Kind, BigintKind, BigdecKind, Float32Kind, Float64Kind:
+				Uint32Kind, Uint64Kind, BigintKind, BigdecKind, Float32Kind, Float64Kind,
+				BigcomplexKind, Complex64Kind, Complex128Kind:
 				return nil // ok
 			default:
 				return errors.New(
@@ -606,6 +657,25 @@ func checkAssignableTo(n Node, xt, dt Type) (err error) {
 			}
 			return nil
 		}
+	case *ChanType:
+		if ct, ok := xt.(*ChanType); ok {
+			// a bidirectional channel is assignable to a
+			// directional channel of the same element type.
+			if ct.Dir != cdt.Dir && ct.Dir != BOTH {
+				return errors.New(
+					"cannot use %s as %s",
+					ct.String(),
+					cdt.String())
+			}
+			err := checkSame(ct.Elt, cdt.Elt, "")
+			if err != nil {
+				return errors.New(
+					"cannot use %s as %s",
+					ct.String(),
+					cdt.String())
+			}
+			return nil
+		}
 	case *InterfaceType:
 		panic("should not happen")
 	case *DeclaredType:
@@ -866,6 +936,8 @@ func (x *RangeStmt) AssertCompatible(store Store, last BlockNode) {
 			mustAssignableTo(x, cxt.Key, kt)
 		case *SliceType, *ArrayType:
 			mustAssignableTo(x, IntType, kt)
+		case *ChanType:
+			mustAssignableTo(x, cxt.Elt, kt)
 		case PrimitiveType:
 			if cxt.Kind() == StringKind {
 				mustAssignableTo(x, IntType, kt)
@@ -959,6 +1031,22 @@ func (x *AssignStmt) AssertCompatible(store Store, last BlockNode) {
 					}
 				}
 				cx.HasOK = true
+			case *UnaryExpr: // must be a receive when len(Lhs) > len(Rhs)
+				if len(x.Lhs) != 2 || cx.Op != ARROW {
+					panic("should not happen")
+				}
+				if x.Op == ASSIGN {
+					if lt := evalAssignLhsType(store, last, x.Lhs[0]); lt != nil {
+						ct := baseOf(evalStaticTypeOf(store, last, cx.X)).(*ChanType)
+						mustAssignableTo(x, ct.Elt, lt)
+					}
+					if dt := evalAssignLhsType(store, last, x.Lhs[1]); dt != nil {
+						if dt.Kind() != BoolKind { // typed, not bool
+							panic(fmt.Sprintf("want bool type got %v", dt))
+						}
+					}
+				}
+				cx.HasOK = true
 			default:
 				panic(fmt.Sprintf("RHS should not be %v when len(Lhs) > len(Rhs)", cx))
 			}
@@ -1218,10 +1306,8 @@ func isNilIdentifier(x Expr) bool {
 // This is used for map key validation and other comparability checks.
 func isComparable(dt Type) bool {
 	switch cdt := baseOf(dt).(type) {
-	case PrimitiveType, *PointerType, *InterfaceType:
+	case PrimitiveType, *PointerType, *InterfaceType, *ChanType:
 		return true
-	case *ChanType:
-		panic("channel type is not yet supported")
 	case *ArrayType:
 		return isComparable(cdt.Elt)
 	case *StructType:
@@ -80,6 +80,7 @@ func (*PackageType) assertType()   {}
 func (*ChanType) assertType()      {}
 func (blockType) assertType()      {}
 func (heapItemType) assertType()   {}
+func (*templateType) assertType()  {}
 func (*tupleType) assertType()     {}
 func (RefType) assertType()        {}
 
@@ -99,6 +100,7 @@ func (*PackageType) IsImmutable() bool     { return false }
 func (*ChanType) IsImmutable() bool        { return true }
 func (blockType) IsImmutable() bool        { return false }
 func (heapItemType) IsImmutable() bool     { return false }
+func (*templateType) IsImmutable() bool    { panic("should not happen") }
 func (*tupleType) IsImmutable() bool       { panic("should not happen") }
 func (RefType) IsImmutable() bool          { panic("should not happen") }
 
@@ -129,6 +131,9 @@ const (
 	Float64Type
 	UntypedBigintType
 	UntypedBigdecType
+	Complex64Type
+	Complex128Type
+	UntypedBigcomplexType
 	// UintptrType
 )
 
@@ -171,16 +176,22 @@ func (pt PrimitiveType) Specificity() int {
 		return 0
 	case Float64Type:
 		return 0
-	case UntypedBigdecType:
+	case Complex64Type:
+		return 0
+	case Complex128Type:
+		return 0
+	case UntypedBigcomplexType:
 		return 1
-	case UntypedStringType:
+	case UntypedBigdecType:
 		return 2
+	case UntypedStringType:
+		return 3
 	case UntypedBigintType:
-		return 2
-	case UntypedRuneType:
 		return 3
-	case UntypedBoolType:
+	case UntypedRuneType:
 		return 4
+	case UntypedBoolType:
+		return 5
 	default:
 		panic(fmt.Sprintf("unexpected primitive type %v", pt))
 	}
@@ -222,6 +233,12 @@ func (pt PrimitiveType) Kind() Kind {
 		return BigintKind
 	case UntypedBigdecType:
 		return BigdecKind
+	case Complex64Type:
+		return Complex64Kind
+	case Complex128Type:
+		return Complex128Kind
+	case UntypedBigcomplexType:
+		return BigcomplexKind
 	default:
 		panic(fmt.Sprintf("unexpected primitive type %v", pt))
 	}
@@ -272,6 +289,12 @@ func (pt PrimitiveType) TypeID() TypeID {
 		return typeid("<untyped> bigint")
 	case UntypedBigdecType:
 		return typeid("<untyped> bigdec")
+	case Complex64Type:
+		return typeid("complex64")
+	case Complex128Type:
+		return typeid("complex128")
+	case UntypedBigcomplexType:
+		return typeid("<untyped> bigcomplex")
 	default:
 		panic(fmt.Sprintf("unexpected primitive type %v", pt))
 	}
@@ -321,6 +344,12 @@ func (pt PrimitiveType) String() string {
 		return string("<untyped> bigint")
 	case UntypedBigdecType:
 		return string("<untyped> bigdec")
+	case Complex64Type:
+		return string("complex64")
+	case Complex128Type:
+		return string("complex128")
+	case UntypedBigcomplexType:
+		return string("<untyped> bigcomplex")
 	default:
 		panic(fmt.Sprintf("unexpected primitive type %d", pt))
 	}
@@ -1077,9 +1106,9 @@ func (ct *ChanType) TypeID() TypeID {
 		case SEND | RECV:
 			ct.typeid = typeidf("chan{%s}", ct.Elt.TypeID().String())
 		case SEND:
-			ct.typeid = typeidf("<-chan{%s}", ct.Elt.TypeID().String())
-		case RECV:
 			ct.typeid = typeidf("chan<-{%s}", ct.Elt.TypeID().String())
+		case RECV:
+			ct.typeid = typeidf("<-chan{%s}", ct.Elt.TypeID().String())
 		default:
 			panic("should not happen")
 		}
@@ -1092,9 +1121,9 @@ func (ct *ChanType) String() string {
 	case SEND | RECV:
 		return "chan " + ct.Elt.String()
 	case SEND:
-		return "<-chan " + ct.Elt.String()
-	case RECV:
 		return "chan<- " + ct.Elt.String()
+	case RECV:
+		return "<-chan " + ct.Elt.String()
 	default:
 		panic("should not happen")
 	}
@@ -1432,6 +1461,7 @@ type DeclaredType struct {
 	ParentLoc Location     // for disambiguation
 	Base      Type         // not a DeclaredType
 	Methods   []TypedValue // {T:*FuncType,V:*FuncValue}...
+	TypeArgs  []Type       `json:",omitempty"` // if instantiated from a generic type
 
 	typeid TypeID
 	sealed bool // for ensuring correctness with recursive types.
@@ -1962,6 +1992,8 @@ func ParseDeclaredTypeID(tid string) (pkgPath string, loc string, name string, o
 func DeclaredTypeID(pkgPath string, loc Location, name Name) TypeID {
 	if loc.IsZero() { // package/file decl
 		return typeidf("%s.%s", pkgPath, name)
+	} else if loc.Inst != "" { // local decl of a generic instance
+		return typeidf("%s[%s[%s]].%s", pkgPath, loc.String(), loc.Inst, name)
 	} else {
 		return typeidf("%s[%s].%s", pkgPath, loc.String(), name)
 	}
@@ -2223,6 +2255,42 @@ func (bt heapItemType) IsNamed() bool {
 	panic("heapItemType has no property called named")
 }
 
+// ----------------------------------------
+// templateType
+
+// templateType is the static type of the name of a generic function or
+// generic type declaration. It only exists during preprocessing: every use
+// of the name must be instantiated (see generics.go), and the runtime slot
+// of the name stays empty.
+type templateType struct {
+	Decl Decl      // *FuncDecl or *TypeDecl with .TypeParams
+	File *FileNode // file of declaration
+}
+
+func (tt *templateType) Kind() Kind {
+	return InvalidKind
+}
+
+func (tt *templateType) TypeID() TypeID {
+	panic("templateType has no type id")
+}
+
+func (tt *templateType) String() string {
+	return fmt.Sprintf("generic %s", tt.Decl.GetDeclNames()[0])
+}
+
+func (tt *templateType) Elem() Type {
+	panic("templateType has no elem type")
+}
+
+func (tt *templateType) GetPkgPath() string {
+	return packageOf(tt.File).PkgPath
+}
+
+func (tt *templateType) IsNamed() bool {
+	panic("templateType has no property called named")
+}
+
 // ----------------------------------------
 // tupleType
 
@@ -2331,8 +2399,11 @@ const (
 	Uint64Kind
 	Float32Kind
 	Float64Kind
-	BigintKind // not in go.
-	BigdecKind // not in go.
+	Complex64Kind
+	Complex128Kind
+	BigintKind     // not in go.
+	BigdecKind     // not in go.
+	BigcomplexKind // not in go.
 	// UintptrKind
 	ArrayKind
 	SliceKind
@@ -2390,6 +2461,12 @@ func KindOf(t Type) Kind {
 			return BigintKind
 		case UntypedBigdecType:
 			return BigdecKind
+		case Complex64Type:
+			return Complex64Kind
+		case Complex128Type:
+			return Complex128Kind
+		case UntypedBigcomplexType:
+			return BigcomplexKind
 		default:
 			panic(fmt.Sprintf("unexpected primitive type %s", t.String()))
 		}
@@ -2495,7 +2572,7 @@ func debugAssertEqualityTypes(lt, rt Type) {
 
 func isUntyped(t Type) bool {
 	switch t {
-	case UntypedBoolType, UntypedRuneType, UntypedBigintType, UntypedBigdecType, UntypedStringType:
+	case UntypedBoolType, UntypedRuneType, UntypedBigintType, UntypedBigdecType, UntypedBigcomplexType, UntypedStringType:
 		return true
 	default:
 		return false
@@ -2523,6 +2600,8 @@ func defaultTypeOf(t Type) Type {
 		return IntType
 	case UntypedBigdecType:
 		return Float64Type
+	case UntypedBigcomplexType:
+		return Complex128Type
 	case UntypedStringType:
 		return StringType
 	default:
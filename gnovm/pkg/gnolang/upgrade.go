@@ -0,0 +1,319 @@
+package gnolang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// UpgradeMemPackage replaces the code of the realm stored at mpkg.Path with
+// the files of mpkg. The realm keeps its package value, and with it its
+// address and object store: package-level variables already declared keep
+// their values, new ones are initialized, and init functions are not run
+// again.
+//
+// It panics if the new code cannot be run on the persisted state of the
+// realm (see checkUpgrade). Stored realms importing the upgraded one,
+// directly or not, are preprocessed again against the new code. Once the
+// realm is migrated, the caller must check with CheckUpgradeFuncValues that
+// no function value still runs the old code.
+//
+// mpkg must be of a storable type; the caller must validate it.
+func (m *Machine) UpgradeMemPackage(mpkg *std.MemPackage) (*PackageNode, *PackageValue) {
+	mptype := mpkg.Type.(MemPackageType)
+	if !mptype.IsStorable() {
+		panic(fmt.Sprintf("mempackage type must be storable, but got %v", mptype))
+	}
+	mpkg.Sort()
+	files := m.ParseMemPackageAsType(mpkg, mptype.AsRunnable())
+
+	pv := m.Store.GetPackage(mpkg.Path, false)
+	if pv == nil || !pv.IsRealm() {
+		panic(fmt.Sprintf("cannot upgrade %s: not an existing realm", mpkg.Path))
+	}
+	if pv.PkgName != Name(mpkg.Name) {
+		panic(fmt.Sprintf("cannot upgrade %s: package name changed from %s to %s",
+			mpkg.Path, pv.PkgName, mpkg.Name))
+	}
+	rlm := pv.GetRealm()
+	pb := pv.GetBlock(m.Store)
+	opn := pb.GetSource(m.Store).(*PackageNode)
+	oldVals := pb.Values
+	oldFBlocks := make([]*Block, len(pv.FNames))
+	for i, fname := range pv.FNames {
+		oldFBlocks[i] = pv.GetFileBlock(m.Store, fname)
+	}
+
+	// Replace the old nodes with the new ones, and run the new
+	// declarations on a fresh package block. The variables kept from the
+	// old code get their values back before any declaration is run, so
+	// that new variables may be initialized from them.
+	deleteBlockNodes(m.Store, opn)
+	pn := NewPackageNode(Name(mpkg.Name), mpkg.Path, &FileSet{})
+	m.Store.SetBlockNode(pn)
+	pb.Source = pn
+	pb.Values = nil
+	pv.FNames = nil
+	pv.FBlocks = nil
+	pv.fBlocksMap = nil
+	m.SetActivePackage(pv)
+	m.runFileDeclsWith(false, func([]TypedValue) (kept []Name) {
+		checkUpgrade(opn, pn)
+		for oi, name := range opn.Names {
+			if opn.NameSources[oi].Type != NSValueDecl || opn.getLocalIsConst(name) {
+				continue
+			}
+			ni, _ := pn.GetLocalIndex(name)
+			pb.Values[ni] = oldVals[oi]
+			fillValueTV(m.Store, &pb.Values[ni])
+			kept = append(kept, name)
+		}
+		return kept
+	}, files.Files...)
+	pv.deriveFBlocksMap(m.Store)
+
+	// Account for the package values and file blocks that changed.
+	for ni, name := range pn.Names {
+		var xo Object
+		if oi, ok := opn.GetLocalIndex(name); ok && int(oi) < len(oldVals) {
+			xo = packageSlotObject(m.Store, &oldVals[oi])
+		}
+		co := packageSlotObject(m.Store, &pb.Values[ni])
+		if xo != co {
+			rlm.DidUpdate(m, pb, xo, co)
+		}
+	}
+	for oi, name := range opn.Names {
+		if _, ok := pn.GetLocalIndex(name); !ok && int(oi) < len(oldVals) {
+			if xo := packageSlotObject(m.Store, &oldVals[oi]); xo != nil {
+				rlm.DidUpdate(m, pb, xo, nil)
+			}
+		}
+	}
+	for _, fb := range oldFBlocks {
+		rlm.DidUpdate(m, pv, fb, nil)
+	}
+	for _, fname := range pv.FNames {
+		rlm.DidUpdate(m, pv, nil, pv.GetFileBlock(m.Store, fname))
+	}
+	rlm.MarkDirty(pb)
+	rlm.FinalizeRealmTransaction(m.Store)
+	m.Store.SetPackageRealm(rlm)
+
+	// Overwrite the saved declared types, whose methods may have changed.
+	for _, tv := range pb.Values {
+		if tvv, ok := tv.V.(TypeValue); ok {
+			if dt, ok := tvv.Type.(*DeclaredType); ok && dt.PkgPath == pv.PkgPath {
+				m.Store.ReplaceType(dt)
+			}
+		}
+	}
+	// The index now lists the path again, so that the new code is
+	// preprocessed after its imports upon restart.
+	m.Store.DeleteMemPackage(mpkg.Path)
+	m.Store.AddMemPackage(mpkg, mptype)
+	m.repreprocessImporters(mpkg.Path)
+	return pn, pv
+}
+
+// checkUpgrade panics if npn, the new code of a realm, cannot replace opn:
+//   - package-level variables may not be removed, nor change type;
+//   - declared types may not be removed, nor change their underlying type;
+//   - methods may not be removed, nor change signature;
+//   - generic declarations are not supported.
+//
+// Constants, functions and new declarations are free to change, as the
+// packages importing the realm are preprocessed again; function values of
+// the old code may not be persisted, see CheckUpgradeFuncValues.
+func checkUpgrade(opn, npn *PackageNode) {
+	fail := func(format string, args ...any) {
+		panic(fmt.Sprintf("cannot upgrade %s: %s", opn.PkgPath, fmt.Sprintf(format, args...)))
+	}
+	for _, fset := range []*FileSet{opn.FileSet, npn.FileSet} {
+		for _, fn := range fset.Files {
+			for _, decl := range fn.Decls {
+				if isGenericDecl(decl) {
+					fail("generic declarations are not supported")
+				}
+			}
+		}
+	}
+	for oi, name := range opn.Names {
+		otype := opn.NameSources[oi].Type
+		if otype != NSValueDecl && otype != NSTypeDecl {
+			continue
+		}
+		if otype == NSValueDecl && opn.getLocalIsConst(name) {
+			continue
+		}
+		ni, ok := npn.GetLocalIndex(name)
+		if !ok || npn.NameSources[ni].Type != otype ||
+			(otype == NSValueDecl && npn.getLocalIsConst(name)) {
+			if otype == NSTypeDecl {
+				fail("type %s was removed", name)
+			}
+			fail("variable %s was removed", name)
+		}
+		if otype == NSValueDecl {
+			ot, nt := opn.Types[oi], npn.Types[ni]
+			if ot.TypeID() != nt.TypeID() {
+				fail("variable %s changed type from %s to %s", name, ot.String(), nt.String())
+			}
+			if opn.HeapItems[oi] != npn.HeapItems[ni] {
+				fail("variable %s changed storage", name)
+			}
+			continue
+		}
+		ot, nt := opn.Values[oi].GetType(), npn.Values[ni].GetType()
+		if ot.TypeID() != nt.TypeID() {
+			fail("type %s changed from %s to %s", name, ot.String(), nt.String())
+		}
+		odt, ok := ot.(*DeclaredType)
+		if !ok || odt.PkgPath != opn.PkgPath {
+			continue // alias.
+		}
+		ndt := nt.(*DeclaredType)
+		if odt.Base.TypeID() != ndt.Base.TypeID() {
+			fail("type %s changed underlying type from %s to %s",
+				name, odt.Base.String(), ndt.Base.String())
+		}
+		for _, om := range odt.Methods {
+			mname := om.V.(*FuncValue).Name
+			idx, ok := ndt.lookupMethod(mname)
+			if !ok {
+				fail("method %s.%s was removed", name, mname)
+			}
+			if nm := ndt.Methods[idx]; om.T.TypeID() != nm.T.TypeID() {
+				fail("method %s.%s changed type from %s to %s",
+					name, mname, om.T.String(), nm.T.String())
+			}
+		}
+	}
+}
+
+// CheckUpgradeFuncValues returns an error if a function value or bound method
+// running the code of the realm at pkgPath before its upgrade is persisted,
+// in any realm. since is the time of the realm before the upgrade: the
+// objects it created after are of the new code.
+//
+// Such values refer to block nodes of the old code, which were deleted or
+// replaced, and to the layout of its package block: calling them would run
+// the wrong code, if any.
+func CheckUpgradeFuncValues(store Store, pkgPath string, since uint64) error {
+	pid := PkgIDFromPkgPath(pkgPath)
+	for _, oid := range store.FuncValueObjects(pkgPath) {
+		if oid.PkgID == pid && oid.NewTime > since {
+			continue
+		}
+		return fmt.Errorf("cannot upgrade %s: function value %s of the old code is persisted", pkgPath, oid)
+	}
+	return nil
+}
+
+// packageSlotObject returns the object held by a package block slot, if any.
+func packageSlotObject(store Store, tv *TypedValue) Object {
+	if hiv, ok := tv.V.(*HeapItemValue); ok {
+		return hiv
+	}
+	return tv.GetFirstObject(store)
+}
+
+// deleteBlockNodes removes from store the nodes saved for the files of pn
+// by SaveBlockNodes.
+func deleteBlockNodes(store Store, pn *PackageNode) {
+	for _, fn := range pn.FileSet.Files {
+		Transcribe(fn, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+			if stage != TRANS_ENTER {
+				return n, TRANS_CONTINUE
+			}
+			if isGenericDecl(n) {
+				return n, TRANS_SKIP
+			}
+			if bn, ok := n.(BlockNode); ok {
+				store.DelBlockNode(bn.GetLocation())
+			}
+			return n, TRANS_CONTINUE
+		})
+	}
+}
+
+// FindImporters returns the sorted paths of the stored realms importing
+// pkgPath directly, archived realms excluded.
+func FindImporters(store Store, pkgPath string) (importers []string) {
+	paths, imports := realmImports(store, pkgPath)
+	for _, path := range paths {
+		if slices.Contains(imports[path], pkgPath) && isPackageStored(store, path) {
+			importers = append(importers, path)
+		}
+	}
+	return importers
+}
+
+// isPackageStored returns whether the package value of pkgPath is in the
+// store; it is not for archived realms, whose objects were all deleted.
+func isPackageStored(store Store, pkgPath string) bool {
+	return store.GetObjectSafe(ObjectIDFromPkgPath(pkgPath)) != nil
+}
+
+// realmImports returns the sorted paths of the stored realms in the domain of
+// pkgPath, but pkgPath, with the paths imported by each.
+func realmImports(store Store, pkgPath string) (paths []string, imports map[string][]string) {
+	domain, _, _ := strings.Cut(pkgPath, "/")
+	imports = make(map[string][]string)
+	for path := range store.FindPathsByPrefix(domain + "/r/") {
+		if path == pkgPath {
+			continue
+		}
+		pn, ok := store.GetBlockNodeSafe(PackageNodeLocation(path)).(*PackageNode)
+		if !ok || pn.FileSet == nil {
+			continue
+		}
+		paths = append(paths, path)
+		imports[path] = pn.FileSet.importPaths()
+	}
+	return paths, imports
+}
+
+// repreprocessImporters preprocesses again the stored realms importing
+// pkgPath, directly or not, so that their nodes refer to its new code.
+// As on restart, imports are preprocessed before their importers.
+func (m *Machine) repreprocessImporters(pkgPath string) {
+	paths, imports := realmImports(m.Store, pkgPath)
+	affected := map[string]bool{pkgPath: true}
+	for changed := true; changed; {
+		changed = false
+		for _, path := range paths {
+			if affected[path] {
+				continue
+			}
+			for _, dep := range imports[path] {
+				if affected[dep] {
+					affected[path] = true
+					changed = true
+					break
+				}
+			}
+		}
+	}
+	done := map[string]bool{pkgPath: true}
+	var preprocess func(path string)
+	preprocess = func(path string) {
+		if done[path] || !affected[path] {
+			return
+		}
+		done[path] = true
+		if !isPackageStored(m.Store, path) {
+			return
+		}
+		for _, dep := range imports[path] {
+			preprocess(dep)
+		}
+		mpkg := MPFProd.FilterMemPackage(m.Store.GetMemPackage(path))
+		m.preprocessFileSet(mpkg, m.ParseMemPackage(mpkg))
+	}
+	for _, path := range paths {
+		preprocess(path)
+	}
+}
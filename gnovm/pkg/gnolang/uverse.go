@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"strings"
 
 	bm "github.com/gnolang/gno/gnovm/pkg/benchops"
@@ -764,6 +765,8 @@ func makeUverseNode() {
 	def("byte", asValue(Uint8Type))
 	def("float32", asValue(Float32Type))
 	def("float64", asValue(Float64Type))
+	def("complex64", asValue(Complex64Type))
+	def("complex128", asValue(Complex128Type))
 	def("int", asValue(IntType))
 	def("int8", asValue(Int8Type))
 	def("int16", asValue(Int16Type))
@@ -1099,6 +1102,50 @@ func makeUverseNode() {
 			m.PushValue(res0)
 		},
 	)
+	defNative("close",
+		Flds( // params
+			"c", AnyT(),
+		),
+		nil, // results
+		func(m *Machine) {
+			arg0 := m.LastBlock().GetParams1(m.Store)
+			if _, ok := baseOf(arg0.TV.T).(*ChanType); !ok {
+				panic(fmt.Sprintf(
+					"invalid operation: non-chan argument %v to close",
+					arg0.TV.T))
+			}
+			cv, _ := arg0.TV.V.(*ChanValue)
+			m.closeChan(cv)
+		},
+	)
+	// NOTE: complex, real and imag are specialized at preprocess time
+	// (see the CallExpr special cases), which folds constant arguments and
+	// gives each call a concrete signature; the native bodies below only
+	// see float32/float64 or complex64/complex128 arguments.
+	defNative("complex",
+		Flds( // params
+			"r", AnyT(),
+			"i", AnyT(),
+		),
+		Flds( // results
+			"", AnyT(),
+		),
+		func(m *Machine) {
+			arg0, arg1 := m.LastBlock().GetParams2(m.Store)
+			res0 := TypedValue{}
+			switch arg0.TV.T.Kind() {
+			case Float32Kind:
+				res0.T = Complex64Type
+				res0.SetComplex64(arg0.TV.GetFloat32(), arg1.TV.GetFloat32())
+			case Float64Kind:
+				res0.T = Complex128Type
+				res0.SetComplex128(arg0.TV.GetFloat64(), arg1.TV.GetFloat64())
+			default:
+				panic("should not happen")
+			}
+			m.PushValue(res0)
+		},
+	)
 	defNative("copy",
 		Flds( // params
 			"dst", GenT("X", nil),
@@ -1271,6 +1318,18 @@ func makeUverseNode() {
 			}
 		},
 	)
+	defNative("imag",
+		Flds( // params
+			"c", AnyT(),
+		),
+		Flds( // results
+			"", AnyT(),
+		),
+		func(m *Machine) {
+			arg0 := m.LastBlock().GetParams1(m.Store)
+			m.PushValue(complexPart(arg0.TV, false))
+		},
+	)
 	defNative("len",
 		Flds( // params
 			"x", AnyT(),
@@ -1423,6 +1482,31 @@ func makeUverseNode() {
 				default:
 					panic("make() of map type takes 1 or 2 arguments")
 				}
+			case *ChanType:
+				if m.DisallowConcurrency {
+					panic("channels are not permitted")
+				}
+				switch vargsl {
+				case 0:
+					m.PushValue(TypedValue{
+						T: tt,
+						V: m.Alloc.NewChan(0),
+					})
+					return
+				case 1:
+					sv := vargs.TV.GetPointerAtIndexInt(m, m.Store, 0).Deref()
+					si := sv.ConvertGetInt()
+					if si < 0 || si > math.MaxInt32 {
+						m.Panic(typedRuntimeError("makechan: size out of range"))
+					}
+					m.PushValue(TypedValue{
+						T: tt,
+						V: m.Alloc.NewChan(int(si)),
+					})
+					return
+				default:
+					panic("make() of chan type takes 1 or 2 arguments")
+				}
 			default:
 				panic(fmt.Sprintf(
 					"cannot make type %s kind %v",
@@ -1532,6 +1616,18 @@ func makeUverseNode() {
 			}
 		},
 	)
+	defNative("real",
+		Flds( // params
+			"c", AnyT(),
+		),
+		Flds( // results
+			"", AnyT(),
+		),
+		func(m *Machine) {
+			arg0 := m.LastBlock().GetParams1(m.Store)
+			m.PushValue(complexPart(arg0.TV, true))
+		},
+	)
 
 	//----------------------------------------
 	// Gno2 types
@@ -2043,6 +2139,33 @@ func copyListToRunes(dst []rune, tvs []TypedValue) {
 	}
 }
 
+// complexPart returns the real (or imaginary) part of a complex64 or
+// complex128 value as a float32 or float64 respectively.
+func complexPart(tv *TypedValue, isReal bool) TypedValue {
+	res := TypedValue{}
+	switch tv.T.Kind() {
+	case Complex64Kind:
+		re, im := tv.GetComplex64()
+		res.T = Float32Type
+		if isReal {
+			res.SetFloat32(re)
+		} else {
+			res.SetFloat32(im)
+		}
+	case Complex128Kind:
+		re, im := tv.GetComplex128()
+		res.T = Float64Type
+		if isReal {
+			res.SetFloat64(re)
+		} else {
+			res.SetFloat64(im)
+		}
+	default:
+		panic("should not happen")
+	}
+	return res
+}
+
 func consumeGas(m *Machine, amount types.Gas) {
 	if m.GasMeter != nil {
 		m.GasMeter.ConsumeGas(amount, "CPUCycles")
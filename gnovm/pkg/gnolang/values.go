@@ -3,6 +3,7 @@ package gnolang
 import (
 	"encoding/binary"
 	"fmt"
+	"math"
 	"math/big"
 	"reflect"
 	"strings"
@@ -40,6 +41,8 @@ type Value interface {
 func (StringValue) assertValue()       {}
 func (BigintValue) assertValue()       {}
 func (BigdecValue) assertValue()       {}
+func (Complex128Value) assertValue()   {}
+func (BigcomplexValue) assertValue()   {}
 func (DataByteValue) assertValue()     {}
 func (PointerValue) assertValue()      {}
 func (*ArrayValue) assertValue()       {}
@@ -47,6 +50,7 @@ func (*SliceValue) assertValue()       {}
 func (*StructValue) assertValue()      {}
 func (*FuncValue) assertValue()        {}
 func (*MapValue) assertValue()         {}
+func (*ChanValue) assertValue()        {}
 func (*BoundMethodValue) assertValue() {}
 func (TypeValue) assertValue()         {}
 func (*PackageValue) assertValue()     {}
@@ -63,6 +67,8 @@ var (
 	_ Value = StringValue("")
 	_ Value = BigintValue{}
 	_ Value = BigdecValue{}
+	_ Value = Complex128Value{}
+	_ Value = BigcomplexValue{}
 	_ Value = DataByteValue{}
 	_ Value = PointerValue{}
 	_ Value = &ArrayValue{}
@@ -236,6 +242,32 @@ func (bdv BigdecValue) Copy(alloc *Allocator) BigdecValue {
 	return BigdecValue{V: new(big.Rat).Set(bdv.V)}
 }
 
+// ----------------------------------------
+// Complex128Value
+
+// Complex128Value holds the IEEE 754 bits of the real and imaginary parts
+// of a complex128. Unlike the other fixed size primitive types, complex128
+// does not fit in TypedValue.N, so it is held in TypedValue.V; a nil V is
+// the zero value. (complex64 does fit, and is held in N.)
+type Complex128Value struct {
+	Real uint64
+	Imag uint64
+}
+
+// ----------------------------------------
+// BigcomplexValue
+
+// BigcomplexValue holds an untyped complex constant.
+type BigcomplexValue struct {
+	Real BigdecValue
+	Imag BigdecValue
+}
+
+// IsZero reports whether both parts are exactly zero.
+func (bcv BigcomplexValue) IsZero() bool {
+	return bcv.Real.IsZero() && bcv.Imag.IsZero()
+}
+
 // ----------------------------------------
 // DataByteValue
 
@@ -1065,6 +1097,31 @@ func (mv *MapValue) DeleteForKey(m *Machine, store Store, key *TypedValue) (dele
 	return nil
 }
 
+// ----------------------------------------
+// ChanValue
+
+// ChanValue is a channel. Unlike maps and slices, channels are not
+// objects: they only live for the duration of a machine run, and any
+// attempt to persist one in a realm panics.
+type ChanValue struct {
+	Buffer []TypedValue // buffered values, oldest first
+	Cap    int          // buffer capacity; zero if unbuffered
+	Closed bool
+
+	recvq []*waitItem // goroutines blocked receiving, FIFO
+	sendq []*waitItem // goroutines blocked sending, FIFO
+
+	lastGCCycle int64 // breaks cycles during GC; see GCVisitorFn
+}
+
+func (cv *ChanValue) GetLength() int {
+	return len(cv.Buffer)
+}
+
+func (cv *ChanValue) GetCapacity() int {
+	return cv.Cap
+}
+
 // ----------------------------------------
 // TypeValue
 
@@ -1382,6 +1439,32 @@ func (tv *TypedValue) MapKeyBytes(bz []byte) (bz2 []byte, isNaN bool) {
 			return bz, true
 		}
 		return binary.LittleEndian.AppendUint64(bz, u64), false
+	case Complex64Type:
+		re, im := tv.GetComplex64()
+		for _, u32 := range [2]uint32{re, im} {
+			if u32 == 0 || u32 == (1<<31) { // 0 or -0 normalized to 0
+				bz = binary.LittleEndian.AppendUint32(bz, 0)
+				continue
+			}
+			if _, _, _, _, isNaN = softfloat.Funpack32(u32); isNaN {
+				return bz, true
+			}
+			bz = binary.LittleEndian.AppendUint32(bz, u32)
+		}
+		return bz, false
+	case Complex128Type:
+		re, im := tv.GetComplex128()
+		for _, u64 := range [2]uint64{re, im} {
+			if u64 == 0 || u64 == (1<<63) { // 0 or -0 normalized to 0
+				bz = binary.LittleEndian.AppendUint64(bz, 0)
+				continue
+			}
+			if _, _, _, _, isNaN = softfloat.Funpack64(u64); isNaN {
+				return bz, true
+			}
+			bz = binary.LittleEndian.AppendUint64(bz, u64)
+		}
+		return bz, false
 	default:
 		panic(fmt.Sprintf(
 			"unexpected primitive value type: %s",
@@ -1764,6 +1847,101 @@ func (tv *TypedValue) GetBigDec() BigdecValue {
 	return tv.V.(BigdecValue)
 }
 
+// SetComplex64 sets the IEEE 754 bits of the real and imaginary parts,
+// held in the low and high halves of N respectively.
+func (tv *TypedValue) SetComplex64(re, im uint32) {
+	if debug {
+		if tv.T.Kind() != Complex64Kind {
+			panic(fmt.Sprintf(
+				"TypedValue.SetComplex64() on type %s",
+				tv.T.String()))
+		}
+	}
+	*(*uint64)(unsafe.Pointer(&tv.N)) = uint64(re) | uint64(im)<<32
+}
+
+func (tv *TypedValue) GetComplex64() (re, im uint32) {
+	if debug {
+		if tv.T != nil && tv.T.Kind() != Complex64Kind {
+			panic(fmt.Sprintf(
+				"TypedValue.GetComplex64() on type %s",
+				tv.T.String()))
+		}
+	}
+	u64 := *(*uint64)(unsafe.Pointer(&tv.N))
+	return uint32(u64), uint32(u64 >> 32)
+}
+
+// SetComplex128 sets the IEEE 754 bits of the real and imaginary parts.
+// Zero is held as a nil V, so that it encodes the same as the zero value.
+func (tv *TypedValue) SetComplex128(re, im uint64) {
+	if debug {
+		if tv.T.Kind() != Complex128Kind {
+			panic(fmt.Sprintf(
+				"TypedValue.SetComplex128() on type %s",
+				tv.T.String()))
+		}
+	}
+	if re == 0 && im == 0 {
+		tv.V = nil
+	} else {
+		tv.V = Complex128Value{Real: re, Imag: im}
+	}
+}
+
+func (tv *TypedValue) GetComplex128() (re, im uint64) {
+	if debug {
+		if tv.T != nil && tv.T.Kind() != Complex128Kind {
+			panic(fmt.Sprintf(
+				"TypedValue.GetComplex128() on type %s",
+				tv.T.String()))
+		}
+	}
+	if tv.V == nil {
+		return 0, 0
+	}
+	cv := tv.V.(Complex128Value)
+	return cv.Real, cv.Imag
+}
+
+func (tv *TypedValue) GetBigComplex() BigcomplexValue {
+	if debug {
+		if tv.T != nil && tv.T.Kind() != BigcomplexKind {
+			panic(fmt.Sprintf(
+				"TypedValue.GetBigComplex() on type %s",
+				tv.T.String()))
+		}
+	}
+	return tv.V.(BigcomplexValue)
+}
+
+// complex64Of and complex128Of build a Go complex from the IEEE 754 bits of
+// its parts. They are for printing and native interop only; arithmetic must
+// go through softfloat to stay deterministic.
+func complex64Of(re, im uint32) complex64 {
+	return complex(math.Float32frombits(re), math.Float32frombits(im))
+}
+
+func complex128Of(re, im uint64) complex128 {
+	return complex(math.Float64frombits(re), math.Float64frombits(im))
+}
+
+// isZeroComplex reports whether the complex tv equals zero (-0 included).
+func isZeroComplex(tv *TypedValue) bool {
+	switch tv.T.Kind() {
+	case Complex64Kind:
+		re, im := tv.GetComplex64()
+		return re&^(1<<31) == 0 && im&^(1<<31) == 0
+	case Complex128Kind:
+		re, im := tv.GetComplex128()
+		return re&^(1<<63) == 0 && im&^(1<<63) == 0
+	case BigcomplexKind:
+		return tv.GetBigComplex().IsZero()
+	default:
+		panic("type should be complex")
+	}
+}
+
 // Sign returns the sign of the given numeric tv.
 func (tv *TypedValue) Sign() int {
 	if tv.T == nil {
@@ -1801,6 +1979,12 @@ func (tv *TypedValue) Sign() int {
 	case BigdecKind:
 		v := tv.GetBigDec()
 		return v.Sign()
+	case Complex64Kind, Complex128Kind, BigcomplexKind:
+		// Complex numbers are unordered; only report whether tv is zero.
+		if isZeroComplex(tv) {
+			return 0
+		}
+		return 1
 	default:
 		panic("type should be numeric")
 	}
@@ -1880,6 +2064,14 @@ func (tv *TypedValue) ComputeMapKey(m *Machine, store Store, omitType bool) (key
 				bz = append(bz, ptrBytes[:]...)
 			}
 		}
+	case *ChanType:
+		// channels are keyed by identity.
+		var ptr uintptr
+		if tv.V != nil {
+			ptr = uintptr(unsafe.Pointer(tv.V.(*ChanValue)))
+		}
+		ptrBytes := uintptrToBytes(&ptr)
+		bz = append(bz, ptrBytes[:]...)
 	case *ArrayType:
 		av := tv.V.(*ArrayValue)
 		al := av.GetLength()
@@ -1931,7 +2123,7 @@ func (tv *TypedValue) ComputeMapKey(m *Machine, store Store, omitType bool) (key
 		bz = append(bz, '}')
 	default:
 		// Defensive fallback: the isComparable gate above already stops
-		// every uncomparable type (slices, maps, funcs, chans, ...) before
+		// every uncomparable type (slices, maps, funcs, ...) before
 		// the switch, so this is unreachable in practice.
 		panic(&Exception{Value: typedRuntimeError(
 			"runtime error: hash of unhashable type " + tv.T.String())})
@@ -2481,6 +2673,8 @@ func (tv *TypedValue) GetLength() int {
 			return 0
 		case *MapType:
 			return 0
+		case *ChanType:
+			return 0
 		case *PointerType:
 			if at, ok := bt.Elt.(*ArrayType); ok {
 				return at.Len
@@ -2501,6 +2695,8 @@ func (tv *TypedValue) GetLength() int {
 		return cv.GetLength()
 	case *MapValue:
 		return cv.GetLength()
+	case *ChanValue:
+		return cv.GetLength()
 	case PointerValue:
 		if av, ok := cv.TV.V.(*ArrayValue); ok {
 			return av.GetLength()
@@ -2521,6 +2717,8 @@ func (tv *TypedValue) GetCapacity() int {
 			return bt.Len
 		case *SliceType:
 			return 0
+		case *ChanType:
+			return 0
 		case *PointerType:
 			if at, ok := bt.Elt.(*ArrayType); ok {
 				return at.Len
@@ -2535,6 +2733,8 @@ func (tv *TypedValue) GetCapacity() int {
 		return cv.GetCapacity()
 	case *SliceValue:
 		return cv.GetCapacity()
+	case *ChanValue:
+		return cv.GetCapacity()
 	case PointerValue:
 		if av, ok := cv.TV.V.(*ArrayValue); ok {
 			return av.GetCapacity()
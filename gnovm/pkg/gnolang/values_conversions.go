@@ -55,7 +55,8 @@ func ConvertTo(alloc *Allocator, store Store, tv *TypedValue, t Type, isConst bo
 	// special case for undefined/nil source
 	if tv.IsUndefined() {
 		switch t.Kind() {
-		case BoolKind, StringKind, IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind, UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind, Float32Kind, Float64Kind, BigintKind, BigdecKind:
+		case BoolKind, StringKind, IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind, UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind, Float32Kind, Float64Kind, BigintKind, BigdecKind,
+			Complex64Kind, Complex128Kind, BigcomplexKind:
 			panic(fmt.Sprintf("cannot convert %v to %v", tv, t))
 		}
 		tv.T = t
@@ -1033,6 +1034,45 @@ func ConvertTo(alloc *Allocator, store Store, tv *TypedValue, t Type, isConst bo
 				"cannot convert %s to %s",
 				tvk.String(), k.String()))
 		}
+	case Complex64Kind:
+		switch k {
+		case Complex128Kind:
+			re, im := tv.GetComplex64()
+			tv.T = t
+			tv.ClearNum()
+			tv.SetComplex128(softfloat.F32to64(re), softfloat.F32to64(im))
+		default:
+			panic(fmt.Sprintf(
+				"cannot convert %s to %s",
+				tvk.String(), k.String()))
+		}
+	case Complex128Kind:
+		switch k {
+		case Complex64Kind:
+			re64, im64 := tv.GetComplex128()
+			re, im := softfloat.F64to32(re64), softfloat.F64to32(im64)
+			validate(Complex128Kind, Complex64Kind, func() bool {
+				// As for float64 to float32: may round, must not overflow.
+				return re&^softfloat.NegZero32 != softfloat.Inf32 &&
+					im&^softfloat.NegZero32 != softfloat.Inf32
+			})
+
+			if isConst {
+				if re == softfloat.NegZero32 {
+					re = 0
+				}
+				if im == softfloat.NegZero32 {
+					im = 0
+				}
+			}
+			tv.T = t
+			tv.V = nil
+			tv.SetComplex64(re, im)
+		default:
+			panic(fmt.Sprintf(
+				"cannot convert %s to %s",
+				tvk.String(), k.String()))
+		}
 	case StringKind:
 		bt := baseOf(t)
 		switch cbt := bt.(type) {
@@ -1171,6 +1211,8 @@ func ConvertUntypedTo(tv *TypedValue, t Type) {
 		ConvertUntypedBigintTo(tv, tv.V.(BigintValue), t)
 	case UntypedBigdecType:
 		ConvertUntypedBigdecTo(tv, tv.V.(BigdecValue), t)
+	case UntypedBigcomplexType:
+		ConvertUntypedBigcomplexTo(tv, tv.V.(BigcomplexValue), t)
 	case UntypedStringType:
 		if t.Kind() == StringKind {
 			tv.T = t
@@ -1193,6 +1235,7 @@ func ConvertUntypedRuneTo(dst *TypedValue, t Type) {
 	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
 	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
 	case StringKind, BigintKind, BigdecKind:
+	case Complex64Kind, Complex128Kind, BigcomplexKind:
 	default:
 		panic(fmt.Sprintf(
 			"cannot convert untyped rune type to %s",
@@ -1267,6 +1310,9 @@ func ConvertUntypedRuneTo(dst *TypedValue, t Type) {
 	case BigdecKind:
 		dst.ClearNum()
 		dst.V = BigdecValue{V: new(big.Rat).SetInt64(int64(sv))}
+	case Complex64Kind, Complex128Kind, BigcomplexKind:
+		re := BigdecValue{V: new(big.Rat).SetInt64(int64(sv))}
+		ConvertUntypedBigcomplexTo(dst, newBigcomplexFromReal(re), t)
 	default:
 		panic(fmt.Sprintf("unexpected target %v", k))
 	}
@@ -1341,6 +1387,10 @@ func ConvertUntypedBigintTo(dst *TypedValue, biv BigintValue, t Type) {
 		dst.T = t
 		dst.V = BigdecValue{V: new(big.Rat).SetInt(bi)}
 		return // done
+	case Complex64Kind, Complex128Kind, BigcomplexKind:
+		re := BigdecValue{V: new(big.Rat).SetInt(bi)}
+		ConvertUntypedBigcomplexTo(dst, newBigcomplexFromReal(re), t)
+		return // done
 	default:
 		panic(fmt.Sprintf(
 			"cannot convert untyped bigint type to %s",
@@ -1470,6 +1520,9 @@ func ConvertUntypedBigdecTo(dst *TypedValue, bdv BigdecValue, t Type) {
 		}
 		dst.SetFloat64(math.Float64bits(posZero(f64)))
 		return
+	case Complex64Kind, Complex128Kind, BigcomplexKind:
+		ConvertUntypedBigcomplexTo(dst, newBigcomplexFromReal(bdv), t)
+		return
 	default:
 		panic(fmt.Sprintf(
 			"cannot convert untyped bigdec type to %s",
@@ -1477,6 +1530,52 @@ func ConvertUntypedBigdecTo(dst *TypedValue, bdv BigdecValue, t Type) {
 	}
 }
 
+// newBigcomplexFromReal returns re as an untyped complex with a zero
+// imaginary part.
+func newBigcomplexFromReal(re BigdecValue) BigcomplexValue {
+	return BigcomplexValue{Real: re, Imag: BigdecValue{V: new(big.Rat)}}
+}
+
+func ConvertUntypedBigcomplexTo(dst *TypedValue, bcv BigcomplexValue, t Type) {
+	k := t.Kind()
+	switch k {
+	case BigcomplexKind:
+		dst.T = t
+		dst.V = bcv
+		return
+	case InterfaceKind:
+		t = Complex128Type // default
+		fallthrough
+	case Complex128Kind:
+		re, im := bigdecToFloat64(bcv.Real), bigdecToFloat64(bcv.Imag)
+		if math.IsInf(re, 0) || math.IsInf(im, 0) {
+			panic("cannot convert untyped bigcomplex to complex128 -- too close to +-Inf")
+		}
+		dst.T = t
+		dst.ClearNum()
+		dst.SetComplex128(math.Float64bits(posZero(re)), math.Float64bits(posZero(im)))
+		return
+	case Complex64Kind:
+		re, im := bigdecToFloat32(bcv.Real), bigdecToFloat32(bcv.Imag)
+		if math.IsInf(float64(re), 0) || math.IsInf(float64(im), 0) {
+			panic("cannot convert untyped bigcomplex to complex64 -- too close to +-Inf")
+		}
+		dst.T = t
+		dst.V = nil
+		dst.SetComplex64(math.Float32bits(posZero(re)), math.Float32bits(posZero(im)))
+		return
+	default:
+		// Constants of complex kind convert to other numeric kinds
+		// when their imaginary part is zero.
+		if !bcv.Imag.IsZero() {
+			panic(fmt.Sprintf(
+				"cannot convert untyped bigcomplex to %s -- %s has a nonzero imaginary part",
+				k.String(), bcv.String()))
+		}
+		ConvertUntypedBigdecTo(dst, bcv.Real, t)
+	}
+}
+
 // bigdecToFloat64 renders bdv as the nearest float64, handling both Rat and
 // Float representations. big.Rat.Float64 and big.Float.Float64 are both
 // deterministic (they build the IEEE 754 bit pattern via math.Ldexp / direct
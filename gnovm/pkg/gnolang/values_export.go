@@ -165,6 +165,10 @@ func exportCopyValue(val Value, seen map[Object]int) Value {
 		return cv
 	case BigdecValue:
 		return cv
+	case Complex128Value:
+		return cv
+	case BigcomplexValue:
+		return cv
 	case DataByteValue:
 		panic("cannot copy data byte value")
 	case PointerValue:
@@ -380,6 +384,12 @@ func exportCopyTypeWithRefs(typ Type, seen map[Object]int) Type {
 			Base:    exportCopyTypeWithRefs(ct.Base, seen),
 			Methods: exportCopyMethods(ct.Methods, seen),
 		}
+		if len(ct.TypeArgs) > 0 {
+			dt.TypeArgs = make([]Type, len(ct.TypeArgs))
+			for i, targ := range ct.TypeArgs {
+				dt.TypeArgs[i] = exportRefOrCopyType(targ, seen)
+			}
+		}
 		return dt
 	case *PackageType:
 		return &PackageType{}
@@ -2,8 +2,9 @@ package gnolang
 
 import "fmt"
 
-// StringValue, BigintValue and BigdecValue are leaf constants that hold no
-// references, so DeepFill returns them unchanged (the identity case).
+// StringValue, BigintValue, BigdecValue, Complex128Value and BigcomplexValue
+// are leaf constants that hold no references, so DeepFill returns them
+// unchanged (the identity case).
 
 func (sv StringValue) DeepFill(store Store) Value {
 	return sv
@@ -17,6 +18,14 @@ func (bdv BigdecValue) DeepFill(store Store) Value {
 	return bdv
 }
 
+func (cv Complex128Value) DeepFill(store Store) Value {
+	return cv
+}
+
+func (bcv BigcomplexValue) DeepFill(store Store) Value {
+	return bcv
+}
+
 func (dbv DataByteValue) DeepFill(store Store) Value {
 	dbv.Base.DeepFill(store)
 	return dbv
@@ -61,6 +70,14 @@ func (sv *StructValue) DeepFill(store Store) Value {
 	return sv
 }
 
+func (cv *ChanValue) DeepFill(store Store) Value {
+	for i := range cv.Buffer {
+		tv := &cv.Buffer[i]
+		tv.DeepFill(store)
+	}
+	return cv
+}
+
 // XXX implement these too
 func (fv *FuncValue) DeepFill(store Store) Value         { panic("not yet implemented") }
 func (mv *MapValue) DeepFill(store Store) Value          { panic("not yet implemented") }
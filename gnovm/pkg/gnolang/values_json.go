@@ -0,0 +1,1514 @@
+package gnolang
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// JSON encoding and decoding of Gno values, backing the encoding/json
+// stdlib. The rules follow Go's encoding/json, driven by the Gno type of
+// each value instead of reflection: exported struct fields (including those
+// promoted from embedded structs) honor `json:"name,omitempty,string"` tags,
+// map keys are sorted, []byte is base64 and pointer cycles are reported.
+// Methods such as MarshalJSON are not consulted.
+//
+// Every value visited and every byte produced or consumed is charged CPU
+// gas, so the cost of encoding a value follows the size of its output even
+// when it aliases the same object many times.
+
+// JSONErrorKind selects the encoding/json error type a *JSONError stands for.
+type JSONErrorKind int
+
+const (
+	JSONSyntaxError JSONErrorKind = iota + 1
+	JSONUnmarshalTypeError
+	JSONUnsupportedTypeError
+	JSONUnsupportedValueError
+	JSONInvalidUnmarshalError
+	JSONPlainError // errors.New(Msg)
+)
+
+// JSONError is returned by EncodeJSON, DecodeJSON and IndentJSON. Only the
+// fields the matching encoding/json error type has are set.
+type JSONError struct {
+	Kind   JSONErrorKind
+	Msg    string // JSONSyntaxError, JSONPlainError
+	Value  string // JSONUnmarshalTypeError (e.g. "number 1.5"), JSONUnsupportedValueError
+	Type   string // Gno type, if any
+	Struct string // JSONUnmarshalTypeError: name of the struct holding Field
+	Field  string // JSONUnmarshalTypeError: dotted path to the field
+	Offset int64  // JSONSyntaxError, JSONUnmarshalTypeError
+}
+
+func (e *JSONError) Error() string {
+	switch e.Kind {
+	case JSONUnmarshalTypeError:
+		if e.Struct != "" || e.Field != "" {
+			return "json: cannot unmarshal " + e.Value + " into Go struct field " +
+				e.Struct + "." + e.Field + " of type " + e.Type
+		}
+		return "json: cannot unmarshal " + e.Value + " into Go value of type " + e.Type
+	case JSONUnsupportedTypeError:
+		return "json: unsupported type: " + e.Type
+	case JSONUnsupportedValueError:
+		return "json: unsupported value: " + e.Value
+	case JSONInvalidUnmarshalError:
+		switch {
+		case e.Type == "":
+			return "json: Unmarshal(nil)"
+		case !strings.HasPrefix(e.Type, "*"):
+			return "json: Unmarshal(non-pointer " + e.Type + ")"
+		default:
+			return "json: Unmarshal(nil " + e.Type + ")"
+		}
+	default:
+		return e.Msg
+	}
+}
+
+const (
+	// jsonStartDetectingCyclesAfter mirrors Go's encoding/json: below this
+	// many nested pointers, maps and slices, cycles are not looked for.
+	jsonStartDetectingCyclesAfter = 1000
+	// jsonMaxDepth bounds the nesting of encoded values, like the limit
+	// Go's scanner puts on decoded ones.
+	jsonMaxDepth = 10000
+)
+
+// Generic values produced when decoding into an empty interface.
+var (
+	jsonAnyType    = &InterfaceType{}
+	jsonObjectType = &MapType{Key: StringType, Value: jsonAnyType}
+	jsonArrayType  = &SliceType{Elt: jsonAnyType}
+)
+
+// ----------------------------------------
+// Encoding
+
+type jsonEncoder struct {
+	m          *Machine
+	buf        []byte
+	charged    int
+	escapeHTML bool
+	depth      int
+	ptrLevel   int
+	ptrSeen    map[any]struct{}
+	fields     jsonFieldCache
+}
+
+// EncodeJSON returns the JSON encoding of tv, as Go's json.Marshal would
+// encode the equivalent Go value. escapeHTML escapes <, > and & in strings.
+func (m *Machine) EncodeJSON(tv TypedValue, escapeHTML bool) (data []byte, err error) {
+	e := &jsonEncoder{m: m, escapeHTML: escapeHTML}
+	defer func() {
+		if r := recover(); r != nil {
+			je, ok := r.(*JSONError)
+			if !ok {
+				panic(r)
+			}
+			data, err = nil, je
+		}
+	}()
+	e.encode(tv, false)
+	e.charge()
+	return e.buf, nil
+}
+
+// charge charges the value about to be encoded and the bytes written since
+// the last call.
+func (e *jsonEncoder) charge() {
+	n := int64(len(e.buf) - e.charged)
+	e.charged = len(e.buf)
+	e.m.incrCPU(OpCPUJSONValue + n*OpCPUSlopeJSONByte/10)
+}
+
+func (e *jsonEncoder) enter(key any, t Type) {
+	e.ptrLevel++
+	if e.ptrLevel > jsonStartDetectingCyclesAfter {
+		if e.ptrSeen == nil {
+			e.ptrSeen = make(map[any]struct{})
+		}
+		if _, ok := e.ptrSeen[key]; ok {
+			panic(&JSONError{Kind: JSONUnsupportedValueError, Value: "encountered a cycle via " + t.String()})
+		}
+		e.ptrSeen[key] = struct{}{}
+	}
+}
+
+func (e *jsonEncoder) leave(key any) {
+	if e.ptrLevel > jsonStartDetectingCyclesAfter {
+		delete(e.ptrSeen, key)
+	}
+	e.ptrLevel--
+}
+
+func (e *jsonEncoder) encode(tv TypedValue, quoted bool) {
+	e.charge()
+	e.depth++
+	if e.depth > jsonMaxDepth {
+		panic(&JSONError{Kind: JSONUnsupportedValueError, Value: "exceeded max depth"})
+	}
+	defer func() { e.depth-- }()
+
+	fillValueTV(e.m.Store, &tv)
+	if tv.T == nil {
+		e.buf = append(e.buf, "null"...)
+		return
+	}
+	if dt, ok := tv.T.(*DeclaredType); ok && dt.PkgPath == "encoding/json" {
+		switch dt.Name {
+		case "RawMessage":
+			e.encodeRawMessage(tv)
+			return
+		case "Number":
+			e.encodeNumber(tv, quoted)
+			return
+		}
+	}
+	switch bt := baseOf(tv.T).(type) {
+	case PrimitiveType:
+		e.encodePrimitive(tv, quoted)
+	case *ArrayType:
+		av := tv.V.(*ArrayValue)
+		e.buf = append(e.buf, '[')
+		for i := range bt.Len {
+			if i > 0 {
+				e.buf = append(e.buf, ',')
+			}
+			if av.Data != nil {
+				etv := TypedValue{T: bt.Elt}
+				etv.SetUint8(av.Data[i])
+				e.encode(etv, false)
+			} else {
+				e.encode(av.List[i], false)
+			}
+		}
+		e.buf = append(e.buf, ']')
+	case *SliceType:
+		if tv.V == nil {
+			e.buf = append(e.buf, "null"...)
+			return
+		}
+		sv := tv.V.(*SliceValue)
+		av := sv.GetBase(e.m.Store)
+		if bt.Elt.Kind() == Uint8Kind {
+			var data []byte
+			if av.Data != nil {
+				data = av.Data[sv.Offset : sv.Offset+sv.Length]
+			} else {
+				data = make([]byte, sv.Length)
+				for i := range data {
+					data[i] = av.List[sv.Offset+i].GetUint8()
+				}
+			}
+			e.buf = append(e.buf, '"')
+			e.buf = base64.StdEncoding.AppendEncode(e.buf, data)
+			e.buf = append(e.buf, '"')
+			return
+		}
+		key := struct {
+			av          *ArrayValue
+			offset, len int
+		}{av, sv.Offset, sv.Length}
+		e.enter(key, tv.T)
+		e.buf = append(e.buf, '[')
+		for i := range sv.Length {
+			if i > 0 {
+				e.buf = append(e.buf, ',')
+			}
+			e.encode(av.List[sv.Offset+i], false)
+		}
+		e.buf = append(e.buf, ']')
+		e.leave(key)
+	case *MapType:
+		if tv.V == nil {
+			e.buf = append(e.buf, "null"...)
+			return
+		}
+		if _, ok := jsonMapKey(TypedValue{T: bt.Key}); !ok {
+			panic(&JSONError{Kind: JSONUnsupportedTypeError, Type: tv.T.String()})
+		}
+		mv := tv.V.(*MapValue)
+		type kv struct {
+			key string
+			val TypedValue
+		}
+		kvs := make([]kv, 0, mv.List.Size)
+		for item := mv.List.Head; item != nil; item = item.Next {
+			key, _ := jsonMapKey(item.Key)
+			kvs = append(kvs, kv{key, item.Value})
+		}
+		slices.SortFunc(kvs, func(a, b kv) int { return strings.Compare(a.key, b.key) })
+		e.enter(mv, tv.T)
+		e.buf = append(e.buf, '{')
+		for i, kv := range kvs {
+			if i > 0 {
+				e.buf = append(e.buf, ',')
+			}
+			e.buf = appendJSONString(e.buf, kv.key, e.escapeHTML)
+			e.buf = append(e.buf, ':')
+			e.encode(kv.val, false)
+		}
+		e.buf = append(e.buf, '}')
+		e.leave(mv)
+	case *StructType:
+		sv := tv.V.(*StructValue)
+		e.buf = append(e.buf, '{')
+		first := true
+	FieldLoop:
+		for _, f := range e.fields.get(bt) {
+			fsv := sv
+			for _, i := range f.index[:len(f.index)-1] {
+				ftv := fsv.Fields[i]
+				fillValueTV(e.m.Store, &ftv)
+				if ftv.T.Kind() == PointerKind {
+					if ftv.V == nil {
+						continue FieldLoop
+					}
+					ftv = ftv.V.(PointerValue).Deref()
+					fillValueTV(e.m.Store, &ftv)
+				}
+				fsv = ftv.V.(*StructValue)
+			}
+			ftv := fsv.Fields[f.index[len(f.index)-1]]
+			fillValueTV(e.m.Store, &ftv)
+			if f.omitEmpty && jsonIsEmpty(ftv) {
+				continue
+			}
+			if !first {
+				e.buf = append(e.buf, ',')
+			}
+			first = false
+			e.buf = appendJSONString(e.buf, f.name, e.escapeHTML)
+			e.buf = append(e.buf, ':')
+			e.encode(ftv, f.quoted)
+		}
+		e.buf = append(e.buf, '}')
+	case *PointerType:
+		if tv.V == nil {
+			e.buf = append(e.buf, "null"...)
+			return
+		}
+		pv := tv.V.(PointerValue)
+		e.enter(pv.TV, tv.T)
+		e.encode(pv.Deref(), quoted)
+		e.leave(pv.TV)
+	default:
+		panic(&JSONError{Kind: JSONUnsupportedTypeError, Type: tv.T.String()})
+	}
+}
+
+func (e *jsonEncoder) encodePrimitive(tv TypedValue, quoted bool) {
+	start := len(e.buf)
+	switch k := tv.T.Kind(); k {
+	case BoolKind:
+		e.buf = strconv.AppendBool(e.buf, tv.GetBool())
+	case StringKind:
+		if quoted {
+			s := appendJSONString(nil, tv.GetString(), e.escapeHTML)
+			e.buf = appendJSONString(e.buf, string(s), false)
+			return
+		}
+		e.buf = appendJSONString(e.buf, tv.GetString(), e.escapeHTML)
+		return
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		e.buf = strconv.AppendInt(e.buf, jsonGetInt(&tv), 10)
+	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		e.buf = strconv.AppendUint(e.buf, jsonGetUint(&tv), 10)
+	case Float32Kind:
+		e.appendFloat(float64(math.Float32frombits(tv.GetFloat32())), 32)
+	case Float64Kind:
+		e.appendFloat(math.Float64frombits(tv.GetFloat64()), 64)
+	default:
+		panic(&JSONError{Kind: JSONUnsupportedTypeError, Type: tv.T.String()})
+	}
+	if quoted {
+		e.buf = append(e.buf, 0)
+		copy(e.buf[start+1:], e.buf[start:])
+		e.buf[start] = '"'
+		e.buf = append(e.buf, '"')
+	}
+}
+
+// appendFloat formats f like Go's encoding/json: ES6 number formatting,
+// with exponents only for very large and very small magnitudes.
+func (e *jsonEncoder) appendFloat(f float64, bits int) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		panic(&JSONError{Kind: JSONUnsupportedValueError, Value: strconv.FormatFloat(f, 'g', -1, bits)})
+	}
+	abs := math.Abs(f)
+	fmt := byte('f')
+	if abs != 0 {
+		if bits == 64 && (abs < 1e-6 || abs >= 1e21) ||
+			bits == 32 && (float32(abs) < 1e-6 || float32(abs) >= 1e21) {
+			fmt = 'e'
+		}
+	}
+	e.buf = strconv.AppendFloat(e.buf, f, fmt, -1, bits)
+	if fmt == 'e' {
+		// clean up e-09 to e-9
+		n := len(e.buf)
+		if n >= 4 && e.buf[n-4] == 'e' && e.buf[n-3] == '-' && e.buf[n-2] == '0' {
+			e.buf[n-2] = e.buf[n-1]
+			e.buf = e.buf[:n-1]
+		}
+	}
+}
+
+func (e *jsonEncoder) encodeRawMessage(tv TypedValue) {
+	if tv.V == nil {
+		e.buf = append(e.buf, "null"...)
+		return
+	}
+	sv := tv.V.(*SliceValue)
+	av := sv.GetBase(e.m.Store)
+	raw := av.Data[sv.Offset : sv.Offset+sv.Length]
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, raw); err != nil {
+		panic(&JSONError{
+			Kind: JSONPlainError,
+			Msg:  "json: error calling MarshalJSON for type " + tv.T.String() + ": " + err.Error(),
+		})
+	}
+	e.buf = append(e.buf, buf.Bytes()...)
+}
+
+func (e *jsonEncoder) encodeNumber(tv TypedValue, quoted bool) {
+	s := tv.GetString()
+	if s == "" {
+		s = "0"
+	}
+	if !jsonIsValidNumber(s) {
+		panic(&JSONError{Kind: JSONPlainError, Msg: "json: invalid number literal " + strconv.Quote(s)})
+	}
+	if quoted {
+		e.buf = append(e.buf, '"')
+	}
+	e.buf = append(e.buf, s...)
+	if quoted {
+		e.buf = append(e.buf, '"')
+	}
+}
+
+// jsonMapKey returns the object key encoding a map key, which must be a
+// string or an integer.
+func jsonMapKey(tv TypedValue) (string, bool) {
+	switch tv.T.Kind() {
+	case StringKind:
+		return tv.GetString(), true
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		return strconv.FormatInt(jsonGetInt(&tv), 10), true
+	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		return strconv.FormatUint(jsonGetUint(&tv), 10), true
+	default:
+		return "", false
+	}
+}
+
+// jsonIsEmpty reports whether a field tagged omitempty is left out.
+func jsonIsEmpty(tv TypedValue) bool {
+	if tv.T == nil {
+		return true
+	}
+	switch bt := baseOf(tv.T).(type) {
+	case PrimitiveType:
+		switch bt.Kind() {
+		case BoolKind:
+			return !tv.GetBool()
+		case StringKind:
+			return tv.GetString() == ""
+		case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+			return jsonGetInt(&tv) == 0
+		case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+			return jsonGetUint(&tv) == 0
+		case Float32Kind:
+			return math.Float32frombits(tv.GetFloat32()) == 0
+		case Float64Kind:
+			return math.Float64frombits(tv.GetFloat64()) == 0
+		}
+	case *ArrayType:
+		return bt.Len == 0
+	case *SliceType, *MapType:
+		return tv.V == nil || tv.GetLength() == 0
+	case *PointerType, *FuncType, *ChanType, *InterfaceType:
+		return tv.V == nil
+	}
+	return false
+}
+
+const jsonHex = "0123456789abcdef"
+
+// appendJSONString appends s as a JSON string, like Go's encoding/json:
+// invalid UTF-8 becomes U+FFFD and U+2028, U+2029 are always escaped.
+func appendJSONString(dst []byte, s string, escapeHTML bool) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			if b >= 0x20 && b != '"' && b != '\\' &&
+				(!escapeHTML || b != '<' && b != '>' && b != '&') {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch b {
+			case '\\', '"':
+				dst = append(dst, '\\', b)
+			case '\b':
+				dst = append(dst, '\\', 'b')
+			case '\f':
+				dst = append(dst, '\\', 'f')
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', jsonHex[b>>4], jsonHex[b&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, "\ufffd"...)
+			i += size
+			start = i
+			continue
+		}
+		if r == '\u2028' || r == '\u2029' {
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', jsonHex[r&0xF])
+			i += size
+			start = i
+			continue
+		}
+		i += size
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
+
+// jsonIsValidNumber reports whether s is a JSON number literal.
+func jsonIsValidNumber(s string) bool {
+	if s == "" {
+		return false
+	}
+	if s[0] == '-' {
+		s = s[1:]
+		if s == "" {
+			return false
+		}
+	}
+	switch {
+	case s[0] == '0':
+		s = s[1:]
+	case '1' <= s[0] && s[0] <= '9':
+		s = s[1:]
+		for len(s) > 0 && '0' <= s[0] && s[0] <= '9' {
+			s = s[1:]
+		}
+	default:
+		return false
+	}
+	if len(s) >= 2 && s[0] == '.' && '0' <= s[1] && s[1] <= '9' {
+		s = s[2:]
+		for len(s) > 0 && '0' <= s[0] && s[0] <= '9' {
+			s = s[1:]
+		}
+	}
+	if len(s) >= 2 && (s[0] == 'e' || s[0] == 'E') {
+		s = s[1:]
+		if s[0] == '+' || s[0] == '-' {
+			s = s[1:]
+			if s == "" {
+				return false
+			}
+		}
+		for len(s) > 0 && '0' <= s[0] && s[0] <= '9' {
+			s = s[1:]
+		}
+	}
+	return s == ""
+}
+
+func jsonGetInt(tv *TypedValue) int64 {
+	switch tv.T.Kind() {
+	case IntKind:
+		return tv.GetInt()
+	case Int8Kind:
+		return int64(tv.GetInt8())
+	case Int16Kind:
+		return int64(tv.GetInt16())
+	case Int32Kind:
+		return int64(tv.GetInt32())
+	default:
+		return tv.GetInt64()
+	}
+}
+
+func jsonGetUint(tv *TypedValue) uint64 {
+	switch tv.T.Kind() {
+	case UintKind:
+		return tv.GetUint()
+	case Uint8Kind:
+		return uint64(tv.GetUint8())
+	case Uint16Kind:
+		return uint64(tv.GetUint16())
+	case Uint32Kind:
+		return uint64(tv.GetUint32())
+	default:
+		return tv.GetUint64()
+	}
+}
+
+// ----------------------------------------
+// Struct fields
+
+type jsonField struct {
+	name      string
+	tagged    bool
+	index     []int // field indices from the outer struct, through embedded ones
+	typ       Type
+	omitEmpty bool
+	quoted    bool
+}
+
+type jsonFieldCache map[*StructType][]jsonField
+
+func (c *jsonFieldCache) get(st *StructType) []jsonField {
+	if fields, ok := (*c)[st]; ok {
+		return fields
+	}
+	if *c == nil {
+		*c = make(jsonFieldCache)
+	}
+	fields := jsonTypeFields(st)
+	(*c)[st] = fields
+	return fields
+}
+
+// jsonTypeFields returns the fields JSON should recognize for st, applying
+// Go's rules for embedded structs: shallower fields hide deeper ones, a
+// tagged field beats an untagged one at the same depth, and otherwise
+// conflicting names are dropped.
+func jsonTypeFields(st *StructType) []jsonField {
+	type queued struct {
+		st    *StructType
+		index []int
+	}
+	var fields []jsonField
+	current := []queued{}
+	next := []queued{{st: st}}
+	var count, nextCount map[*StructType]int
+	visited := map[*StructType]bool{}
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[*StructType]int{}
+
+		for _, q := range current {
+			if visited[q.st] {
+				continue
+			}
+			visited[q.st] = true
+
+			for i, sf := range q.st.Fields {
+				ft := sf.Type
+				if pt, ok := ft.(*PointerType); ok {
+					ft = pt.Elt
+				}
+				if sf.Embedded {
+					if !isUpper(string(sf.Name)) && ft.Kind() != StructKind {
+						// Ignore embedded fields of unexported non-struct types.
+						continue
+					}
+					// Exported fields of unexported embedded structs are
+					// still promoted.
+				} else if !isUpper(string(sf.Name)) {
+					continue
+				}
+				tag := reflect.StructTag(sf.Tag).Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, opts, _ := strings.Cut(tag, ",")
+				if !jsonIsValidTag(name) {
+					name = ""
+				}
+				index := make([]int, len(q.index)+1)
+				copy(index, q.index)
+				index[len(q.index)] = i
+
+				if name != "" || !sf.Embedded || ft.Kind() != StructKind {
+					quoted := false
+					if jsonHasOption(opts, "string") {
+						switch ft.Kind() {
+						case BoolKind, StringKind,
+							IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind,
+							UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind,
+							Float32Kind, Float64Kind:
+							quoted = true
+						}
+					}
+					f := jsonField{
+						name:      name,
+						tagged:    name != "",
+						index:     index,
+						typ:       sf.Type,
+						omitEmpty: jsonHasOption(opts, "omitempty"),
+						quoted:    quoted,
+					}
+					if f.name == "" {
+						f.name = string(sf.Name)
+					}
+					fields = append(fields, f)
+					if count[q.st] > 1 {
+						// If there were multiple instances, add a second,
+						// so that the annihilation code will see a
+						// duplicate. It only cares about the distinction
+						// between 1 and 2, so don't bother generating
+						// any more copies.
+						fields = append(fields, fields[len(fields)-1])
+					}
+					continue
+				}
+
+				// Record new anonymous struct to explore in next round.
+				est := baseOf(ft).(*StructType)
+				nextCount[est]++
+				if nextCount[est] == 1 {
+					next = append(next, queued{st: est, index: index})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(fields, func(a, b jsonField) int {
+		if c := strings.Compare(a.name, b.name); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(len(a.index), len(b.index)); c != 0 {
+			return c
+		}
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+			return +1
+		}
+		return slices.Compare(a.index, b.index)
+	})
+
+	// Delete all fields that are hidden by the Go rules for embedded
+	// fields, except that fields with JSON tags are promoted.
+	out := fields[:0]
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		fi := fields[i]
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].name != fi.name {
+				break
+			}
+		}
+		if advance == 1 {
+			out = append(out, fi)
+			continue
+		}
+		// The fields are sorted in increasing index-length order, then by
+		// presence of tag. If the first two have the same depth and
+		// taggedness, none of them dominates.
+		if len(fi.index) == len(fields[i+1].index) && fi.tagged == fields[i+1].tagged {
+			continue
+		}
+		out = append(out, fi)
+	}
+	fields = out
+	slices.SortFunc(fields, func(a, b jsonField) int {
+		return slices.Compare(a.index, b.index)
+	})
+	return fields
+}
+
+func jsonIsValidTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", c):
+			// Backslash and quote chars are reserved, but
+			// otherwise any punctuation chars are allowed
+			// in a tag name.
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+func jsonHasOption(opts, name string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ----------------------------------------
+// Decoding
+
+type jsonDecoder struct {
+	m       *Machine
+	data    []byte
+	off     int
+	charged int
+	err     *JSONError // first type error; decoding goes on after it
+	fields  jsonFieldCache
+	errCtx  struct {
+		strct  string
+		fields []string
+	}
+}
+
+// DecodeJSON decodes data into the value ptr points to, as Go's
+// json.Unmarshal would. The decoded value is built apart and then assigned
+// through ptr, so a syntax error leaves it untouched; fields absent from
+// data keep their values, while maps, slices and pointers reached while
+// decoding are replaced by new ones rather than written through.
+func (m *Machine) DecodeJSON(data []byte, ptr TypedValue) error {
+	if ptr.T == nil {
+		return &JSONError{Kind: JSONInvalidUnmarshalError}
+	}
+	pt, ok := baseOf(ptr.T).(*PointerType)
+	if !ok || ptr.V == nil {
+		return &JSONError{Kind: JSONInvalidUnmarshalError, Type: ptr.T.String()}
+	}
+	if err := jsonCheckValid(data); err != nil {
+		return err
+	}
+	if m.IsReadonly(&ptr) {
+		m.Panic(typedString("cannot unmarshal into readonly tainted value"))
+	}
+	fillValueTV(m.Store, &ptr)
+	pv := ptr.V.(PointerValue)
+
+	d := &jsonDecoder{m: m, data: data}
+	if st, ok := baseOf(pt.Elt).(*StructType); ok {
+		d.errCtx.strct = jsonStructName(pt.Elt, st)
+	}
+	v := d.decode(pt.Elt, m.jsonCopy(pv.Deref()))
+	d.charge()
+	pv.Assign2(m, m.Alloc, m.Store, m.Realm, v, false)
+	if d.err != nil {
+		return d.err
+	}
+	return nil
+}
+
+func jsonCheckValid(data []byte) *JSONError {
+	if json.Valid(data) {
+		return nil
+	}
+	var raw json.RawMessage
+	err := json.Unmarshal(data, &raw)
+	serr, ok := err.(*json.SyntaxError)
+	if !ok {
+		return &JSONError{Kind: JSONPlainError, Msg: err.Error()}
+	}
+	return &JSONError{Kind: JSONSyntaxError, Msg: serr.Error(), Offset: serr.Offset}
+}
+
+// jsonStructName is the name Go's UnmarshalTypeError gives a struct type.
+func jsonStructName(t Type, st *StructType) string {
+	if dt, ok := t.(*DeclaredType); ok {
+		return string(dt.Name)
+	}
+	return ""
+}
+
+// jsonCopy returns a copy of tv which the decoder may update in place:
+// struct and array values are copied recursively, loading stored objects,
+// while pointers, slices, maps and the like are shared.
+func (m *Machine) jsonCopy(tv TypedValue) TypedValue {
+	fillValueTV(m.Store, &tv)
+	switch cv := tv.V.(type) {
+	case *StructValue:
+		m.incrCPU(OpCPUSlopeCopyElement * int64(len(cv.Fields)))
+		fields := m.Alloc.NewStructFields(len(cv.Fields))
+		for i := range cv.Fields {
+			fields[i] = m.jsonCopy(cv.Fields[i])
+		}
+		return TypedValue{T: tv.T, V: m.Alloc.NewStruct(tv.T, fields)}
+	case *ArrayValue:
+		if cv.Data != nil {
+			m.incrCPU(OpCPUSlopeCopyPrimitive * int64(len(cv.Data)))
+			return TypedValue{T: tv.T, V: m.Alloc.NewArrayFromData(tv.T, cv.Data)}
+		}
+		m.incrCPU(OpCPUSlopeCopyElement * int64(len(cv.List)))
+		av := m.Alloc.NewListArray(tv.T, len(cv.List))
+		for i := range cv.List {
+			av.List[i] = m.jsonCopy(cv.List[i])
+		}
+		return TypedValue{T: tv.T, V: av}
+	}
+	return tv
+}
+
+// charge charges the value about to be decoded and the input consumed since
+// the last call.
+func (d *jsonDecoder) charge() {
+	n := int64(d.off - d.charged)
+	d.charged = d.off
+	d.m.incrCPU(OpCPUJSONValue + n*OpCPUSlopeJSONByte/10)
+}
+
+func (d *jsonDecoder) typeError(what string, t Type) {
+	if d.err == nil {
+		d.err = &JSONError{
+			Kind:   JSONUnmarshalTypeError,
+			Value:  what,
+			Type:   t.String(),
+			Offset: int64(d.off),
+			Struct: d.errCtx.strct,
+			Field:  strings.Join(d.errCtx.fields, "."),
+		}
+		if d.err.Field == "" {
+			d.err.Struct = ""
+		}
+	}
+}
+
+func (d *jsonDecoder) saveError(msg string) {
+	if d.err == nil {
+		d.err = &JSONError{Kind: JSONPlainError, Msg: msg}
+	}
+}
+
+// The scanning methods below run on input already checked by
+// jsonCheckValid.
+
+func (d *jsonDecoder) peek() byte {
+	for {
+		switch c := d.data[d.off]; c {
+		case ' ', '\t', '\r', '\n':
+			d.off++
+		default:
+			return c
+		}
+	}
+}
+
+// consume skips c, and the spaces before it, if it comes next.
+func (d *jsonDecoder) consume(c byte) bool {
+	if d.off < len(d.data) && d.peek() == c {
+		d.off++
+		return true
+	}
+	return false
+}
+
+func (d *jsonDecoder) readString() string {
+	d.peek()
+	start := d.off
+	d.off++ // opening quote
+	plain := true
+	for {
+		switch c := d.data[d.off]; {
+		case c == '\\':
+			plain = false
+			d.off += 2
+			continue
+		case c == '"':
+			d.off++
+		case c >= utf8.RuneSelf:
+			plain = false
+			d.off++
+			continue
+		default:
+			d.off++
+			continue
+		}
+		break
+	}
+	raw := d.data[start:d.off]
+	if plain || utf8.Valid(raw) && bytes.IndexByte(raw, '\\') < 0 {
+		return string(raw[1 : len(raw)-1])
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		panic("unexpected invalid JSON string")
+	}
+	return s
+}
+
+func (d *jsonDecoder) readLiteral() string {
+	d.peek()
+	start := d.off
+	for d.off < len(d.data) {
+		c := d.data[d.off]
+		if c != '-' && c != '+' && c != '.' && !('0' <= c && c <= '9') && !('a' <= c && c <= 'z') && !('A' <= c && c <= 'Z') {
+			break
+		}
+		d.off++
+	}
+	return string(d.data[start:d.off])
+}
+
+func (d *jsonDecoder) skipValue() {
+	switch d.peek() {
+	case '{', '[':
+		depth := 0
+		for {
+			switch c := d.data[d.off]; c {
+			case '"':
+				d.readString()
+				continue
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+			d.off++
+			if depth == 0 {
+				return
+			}
+		}
+	case '"':
+		d.readString()
+	default:
+		d.readLiteral()
+	}
+}
+
+// zero returns a new zero value of t.
+func (d *jsonDecoder) zero(t Type) TypedValue {
+	return defaultTypedValue(d.m.Alloc, t)
+}
+
+// decode decodes the next JSON value into a value of type t. cur is the
+// current value, which decode may update in place (see jsonCopy); values
+// of a different kind from the JSON one are left as they were.
+func (d *jsonDecoder) decode(t Type, cur TypedValue) TypedValue {
+	d.charge()
+	fillValueTV(d.m.Store, &cur)
+	c := d.peek()
+	if c == 'n' {
+		d.readLiteral()
+		switch baseOf(t).(type) {
+		case *InterfaceType:
+			return TypedValue{}
+		case *PointerType, *MapType, *SliceType:
+			return TypedValue{T: t}
+		}
+		return cur
+	}
+	if dt, ok := t.(*DeclaredType); ok && dt.PkgPath == "encoding/json" {
+		switch dt.Name {
+		case "RawMessage":
+			start := d.off
+			d.skipValue()
+			data := make([]byte, d.off-start)
+			copy(data, d.data[start:d.off])
+			return TypedValue{T: t, V: d.m.Alloc.NewSliceFromData(data)}
+		case "Number":
+			var s string
+			if c == '"' {
+				s = d.readString()
+				if !jsonIsValidNumber(s) {
+					d.saveError("json: invalid number literal, trying to unmarshal " + strconv.Quote(`"`+s+`"`) + " into Number")
+					return cur
+				}
+			} else if c == '-' || '0' <= c && c <= '9' {
+				s = d.readLiteral()
+			} else {
+				break
+			}
+			tv := TypedValue{T: t}
+			tv.SetString(d.m.Alloc.NewString(s))
+			return tv
+		}
+	}
+
+	switch bt := baseOf(t).(type) {
+	case *PointerType:
+		var elem TypedValue
+		if cur.V != nil {
+			elem = d.m.jsonCopy(cur.V.(PointerValue).Deref())
+		} else {
+			elem = d.zero(bt.Elt)
+		}
+		elem = d.decode(bt.Elt, elem)
+		d.m.Alloc.checkConstructionTime(bt.Elt)
+		d.m.Alloc.AllocatePointer()
+		hi := d.m.Alloc.NewHeapItem(bt.Elt, elem)
+		return TypedValue{T: t, V: PointerValue{TV: &hi.Value, Base: hi}}
+	case *InterfaceType:
+		if !bt.IsEmptyInterface() {
+			d.typeError(jsonValueKind(c), t)
+			d.skipValue()
+			return cur
+		}
+		if cur.T != nil && cur.T.Kind() == PointerKind && cur.V != nil {
+			// Like Go, decode into the value a non-nil pointer refers to.
+			return d.decode(cur.T, cur)
+		}
+		return d.decodeAny()
+	}
+
+	switch c {
+	case '{':
+		return d.object(t, cur)
+	case '[':
+		return d.array(t, cur)
+	case '"':
+		return d.str(t, cur)
+	case 't', 'f':
+		lit := d.readLiteral()
+		if t.Kind() != BoolKind {
+			d.typeError("bool", t)
+			return cur
+		}
+		tv := TypedValue{T: t}
+		tv.SetBool(lit == "true")
+		return tv
+	default:
+		return d.number(t, cur, d.readLiteral())
+	}
+}
+
+// jsonValueKind describes a JSON value by its first byte, the way
+// UnmarshalTypeError does.
+func jsonValueKind(c byte) string {
+	switch c {
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case '"':
+		return "string"
+	case 't', 'f':
+		return "bool"
+	default:
+		return "number"
+	}
+}
+
+func (d *jsonDecoder) object(t Type, cur TypedValue) TypedValue {
+	switch bt := baseOf(t).(type) {
+	case *StructType:
+		sv := cur.V.(*StructValue)
+		fields := d.fields.get(bt)
+		outer := d.errCtx
+		d.errCtx.strct = jsonStructName(t, bt)
+		d.consume('{')
+		for !d.consume('}') {
+			key := d.readString()
+			d.consume(':')
+			if f := jsonLookupField(fields, key); f != nil {
+				d.errCtx.fields = append(d.errCtx.fields, f.name)
+				d.field(bt, sv, f)
+				d.errCtx.fields = d.errCtx.fields[:len(d.errCtx.fields)-1]
+			} else {
+				d.skipValue()
+			}
+			d.consume(',')
+		}
+		d.errCtx = outer
+		return cur
+	case *MapType:
+		switch bt.Key.Kind() {
+		case StringKind,
+			IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind,
+			UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		default:
+			d.typeError("object", t)
+			d.skipValue()
+			return cur
+		}
+		d.m.Alloc.checkConstructionTime(t)
+		mv := d.m.Alloc.NewMap(t)
+		if cur.V != nil {
+			// Like Go, keep the entries already in the map.
+			for item := cur.V.(*MapValue).List.Head; item != nil; item = item.Next {
+				ptr := mv.GetPointerForKey(d.m, d.m.Alloc, d.m.Store, item.Key)
+				*ptr.TV = d.m.jsonCopy(item.Value)
+			}
+		}
+		d.consume('{')
+		for !d.consume('}') {
+			key := d.readString()
+			d.consume(':')
+			ktv, ok := d.mapKey(bt.Key, key)
+			if !ok {
+				d.skipValue()
+				d.consume(',')
+				continue
+			}
+			v := d.decode(bt.Value, d.zero(bt.Value))
+			ptr := mv.GetPointerForKey(d.m, d.m.Alloc, d.m.Store, ktv)
+			*ptr.TV = v
+			d.consume(',')
+		}
+		return TypedValue{T: t, V: mv}
+	default:
+		d.typeError("object", t)
+		d.skipValue()
+		return cur
+	}
+}
+
+func (d *jsonDecoder) mapKey(kt Type, key string) (TypedValue, bool) {
+	tv := TypedValue{T: kt}
+	switch kt.Kind() {
+	case StringKind:
+		tv.SetString(d.m.Alloc.NewString(key))
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		n, err := strconv.ParseInt(key, 10, jsonBitSize(kt))
+		if err != nil {
+			d.typeError("number "+key, kt)
+			return tv, false
+		}
+		jsonSetInt(&tv, n)
+	default:
+		n, err := strconv.ParseUint(key, 10, jsonBitSize(kt))
+		if err != nil {
+			d.typeError("number "+key, kt)
+			return tv, false
+		}
+		jsonSetUint(&tv, n)
+	}
+	return tv, true
+}
+
+// jsonLookupField finds the field for key, preferring an exact match over
+// a case-insensitive one.
+func jsonLookupField(fields []jsonField, key string) *jsonField {
+	for i := range fields {
+		if fields[i].name == key {
+			return &fields[i]
+		}
+	}
+	for i := range fields {
+		if strings.EqualFold(fields[i].name, key) {
+			return &fields[i]
+		}
+	}
+	return nil
+}
+
+// field decodes the next value into field f of sv, a value of type st,
+// allocating the embedded struct pointers on the way to it.
+func (d *jsonDecoder) field(st *StructType, sv *StructValue, f *jsonField) {
+	for _, i := range f.index[:len(f.index)-1] {
+		ftv := &sv.Fields[i]
+		fillValueTV(d.m.Store, ftv)
+		pt, ok := baseOf(st.Fields[i].Type).(*PointerType)
+		if !ok {
+			st = baseOf(st.Fields[i].Type).(*StructType)
+			sv = ftv.V.(*StructValue)
+			continue
+		}
+		var elem TypedValue
+		if ftv.V != nil {
+			elem = d.m.jsonCopy(ftv.V.(PointerValue).Deref())
+		} else {
+			if !isUpper(string(st.Fields[i].Name)) {
+				d.saveError("json: cannot set embedded pointer to unexported struct: " + pt.Elt.String())
+				d.skipValue()
+				return
+			}
+			elem = d.zero(pt.Elt)
+		}
+		d.m.Alloc.checkConstructionTime(pt.Elt)
+		d.m.Alloc.AllocatePointer()
+		hi := d.m.Alloc.NewHeapItem(pt.Elt, elem)
+		*ftv = TypedValue{T: ftv.T, V: PointerValue{TV: &hi.Value, Base: hi}}
+		st = baseOf(pt.Elt).(*StructType)
+		sv = elem.V.(*StructValue)
+	}
+	ftv := &sv.Fields[f.index[len(f.index)-1]]
+	if f.quoted {
+		*ftv = d.quoted(f.typ, *ftv)
+	} else {
+		*ftv = d.decode(f.typ, *ftv)
+	}
+}
+
+// quoted decodes a value encoded with the ",string" option: a JSON string
+// holding the literal.
+func (d *jsonDecoder) quoted(t Type, cur TypedValue) TypedValue {
+	switch d.peek() {
+	case 'n':
+		return d.decode(t, cur)
+	case '"':
+	default:
+		d.saveError("json: invalid use of ,string struct tag, trying to unmarshal unquoted value into " + t.String())
+		d.skipValue()
+		return cur
+	}
+	s := d.readString()
+	inner := []byte(s)
+	if len(inner) == 0 || !json.Valid(inner) || strings.IndexByte("\"tfn-0123456789", inner[0]) < 0 {
+		d.saveError("json: invalid use of ,string struct tag, trying to unmarshal " + strconv.Quote(s) + " into " + t.String())
+		return cur
+	}
+	sub := &jsonDecoder{m: d.m, data: inner, fields: d.fields, errCtx: d.errCtx}
+	tv := sub.decode(t, cur)
+	if sub.err != nil && d.err == nil {
+		d.err = sub.err
+	}
+	return tv
+}
+
+func (d *jsonDecoder) array(t Type, cur TypedValue) TypedValue {
+	switch bt := baseOf(t).(type) {
+	case *ArrayType:
+		av := cur.V.(*ArrayValue)
+		d.consume('[')
+		i := 0
+		for ; !d.consume(']'); i++ {
+			switch {
+			case i >= bt.Len:
+				d.skipValue()
+			case av.Data != nil:
+				etv := TypedValue{T: bt.Elt}
+				etv.SetUint8(av.Data[i])
+				etv = d.decode(bt.Elt, etv)
+				av.Data[i] = etv.GetUint8()
+			default:
+				av.List[i] = d.decode(bt.Elt, av.List[i])
+			}
+			d.consume(',')
+		}
+		for ; i < bt.Len; i++ {
+			if av.Data != nil {
+				av.Data[i] = 0
+			} else {
+				av.List[i] = d.zero(bt.Elt)
+			}
+		}
+		return cur
+	case *SliceType:
+		list := []TypedValue{}
+		d.consume('[')
+		for !d.consume(']') {
+			list = append(list, d.decode(bt.Elt, d.zero(bt.Elt)))
+			d.consume(',')
+		}
+		d.m.Alloc.checkConstructionTime(t)
+		if bt.Elt.Kind() == Uint8Kind {
+			data := make([]byte, len(list))
+			for i := range list {
+				data[i] = list[i].GetUint8()
+			}
+			return TypedValue{T: t, V: d.m.Alloc.NewSliceFromData(data)}
+		}
+		return TypedValue{T: t, V: d.m.Alloc.NewSliceFromList(slices.Clip(list))}
+	default:
+		d.typeError("array", t)
+		d.skipValue()
+		return cur
+	}
+}
+
+func (d *jsonDecoder) str(t Type, cur TypedValue) TypedValue {
+	s := d.readString()
+	switch bt := baseOf(t).(type) {
+	case PrimitiveType:
+		if bt.Kind() == StringKind {
+			tv := TypedValue{T: t}
+			tv.SetString(d.m.Alloc.NewString(s))
+			return tv
+		}
+	case *SliceType:
+		if bt.Elt.Kind() == Uint8Kind {
+			data, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				d.saveError(err.Error())
+				return cur
+			}
+			d.m.Alloc.checkConstructionTime(t)
+			return TypedValue{T: t, V: d.m.Alloc.NewSliceFromData(slices.Clip(data))}
+		}
+	}
+	d.typeError("string", t)
+	return cur
+}
+
+func (d *jsonDecoder) number(t Type, cur TypedValue, lit string) TypedValue {
+	tv := TypedValue{T: t}
+	switch k := t.Kind(); k {
+	case IntKind, Int8Kind, Int16Kind, Int32Kind, Int64Kind:
+		n, err := strconv.ParseInt(lit, 10, jsonBitSize(t))
+		if err != nil {
+			d.typeError("number "+lit, t)
+			return cur
+		}
+		jsonSetInt(&tv, n)
+	case UintKind, Uint8Kind, Uint16Kind, Uint32Kind, Uint64Kind:
+		n, err := strconv.ParseUint(lit, 10, jsonBitSize(t))
+		if err != nil {
+			d.typeError("number "+lit, t)
+			return cur
+		}
+		jsonSetUint(&tv, n)
+	case Float32Kind:
+		f, err := strconv.ParseFloat(lit, 32)
+		if err != nil {
+			d.typeError("number "+lit, t)
+			return cur
+		}
+		tv.SetFloat32(math.Float32bits(float32(f)))
+	case Float64Kind:
+		f, err := strconv.ParseFloat(lit, 64)
+		if err != nil {
+			d.typeError("number "+lit, t)
+			return cur
+		}
+		tv.SetFloat64(math.Float64bits(f))
+	default:
+		d.typeError("number", t)
+		return cur
+	}
+	return tv
+}
+
+// decodeAny decodes the next value the way Go decodes into an empty
+// interface: map[string]any, []any, float64, string, bool or nil.
+func (d *jsonDecoder) decodeAny() TypedValue {
+	switch c := d.peek(); c {
+	case '{':
+		mv := d.m.Alloc.NewMap(jsonObjectType)
+		d.consume('{')
+		for !d.consume('}') {
+			key := TypedValue{T: StringType}
+			key.SetString(d.m.Alloc.NewString(d.readString()))
+			d.consume(':')
+			v := d.decode(jsonAnyType, TypedValue{})
+			ptr := mv.GetPointerForKey(d.m, d.m.Alloc, d.m.Store, key)
+			*ptr.TV = v
+			d.consume(',')
+		}
+		return TypedValue{T: jsonObjectType, V: mv}
+	case '[':
+		return d.array(jsonArrayType, TypedValue{})
+	case '"':
+		return d.str(StringType, TypedValue{})
+	case 't', 'f':
+		return d.decode(BoolType, TypedValue{})
+	default:
+		lit := d.readLiteral()
+		return d.number(Float64Type, TypedValue{}, lit)
+	}
+}
+
+func jsonBitSize(t Type) int {
+	switch t.Kind() {
+	case Int8Kind, Uint8Kind:
+		return 8
+	case Int16Kind, Uint16Kind:
+		return 16
+	case Int32Kind, Uint32Kind:
+		return 32
+	default:
+		return 64
+	}
+}
+
+func jsonSetInt(tv *TypedValue, n int64) {
+	switch tv.T.Kind() {
+	case IntKind:
+		tv.SetInt(n)
+	case Int8Kind:
+		tv.SetInt8(int8(n))
+	case Int16Kind:
+		tv.SetInt16(int16(n))
+	case Int32Kind:
+		tv.SetInt32(int32(n))
+	default:
+		tv.SetInt64(n)
+	}
+}
+
+func jsonSetUint(tv *TypedValue, n uint64) {
+	switch tv.T.Kind() {
+	case UintKind:
+		tv.SetUint(n)
+	case Uint8Kind:
+		tv.SetUint8(uint8(n))
+	case Uint16Kind:
+		tv.SetUint16(uint16(n))
+	case Uint32Kind:
+		tv.SetUint32(uint32(n))
+	default:
+		tv.SetUint64(n)
+	}
+}
+
+// ----------------------------------------
+// Indentation
+
+// IndentJSON is json.Indent, charged before it runs for the largest output
+// src could indent to, since a long indent repeated at every nesting level
+// can make it much longer than src.
+func (m *Machine) IndentJSON(src []byte, prefix, indent string) ([]byte, error) {
+	m.incrCPU(jsonIndentSize(src, len(prefix), len(indent)) * OpCPUSlopeJSONByte / 10)
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, src, prefix, indent); err != nil {
+		if serr, ok := err.(*json.SyntaxError); ok {
+			return nil, &JSONError{Kind: JSONSyntaxError, Msg: serr.Error(), Offset: serr.Offset}
+		}
+		return nil, &JSONError{Kind: JSONPlainError, Msg: err.Error()}
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonIndentSize bounds the length of src indented with the given prefix
+// and indent lengths: each delimiter outside strings may start a new line.
+func jsonIndentSize(src []byte, prefix, indent int) int64 {
+	size := int64(len(src))
+	depth := int64(0)
+	inString := false
+	for i := 0; i < len(src); i++ {
+		c := src[i]
+		if inString {
+			switch c {
+			case '\\':
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '{', '[':
+			depth++
+			size += 2 + int64(prefix) + depth*int64(indent)
+		case '}', ']':
+			depth = max(depth-1, 0)
+			size += 1 + int64(prefix) + depth*int64(indent)
+		case ',':
+			size += 1 + int64(prefix) + depth*int64(indent)
+		case ':':
+			size++
+		}
+	}
+	return size
+}
@@ -0,0 +1,77 @@
+package gnolang
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// appendJSONString must produce exactly what encoding/json produces.
+func TestAppendJSONString(t *testing.T) {
+	cases := []string{
+		"",
+		"plain",
+		"quote\" backslash\\ slash/",
+		"\x00\x01\x1f\x7f\t\n\r\b\f",
+		"<script>&amp;</script>",
+		"h\u00e9llo \u4e16\u754c \U0001F600",
+		"\u2028\u2029",
+		"bad \xff utf8 \xc3",
+	}
+	for _, s := range cases {
+		for _, escapeHTML := range []bool{true, false} {
+			var buf bytes.Buffer
+			enc := json.NewEncoder(&buf)
+			enc.SetEscapeHTML(escapeHTML)
+			require.NoError(t, enc.Encode(s))
+			want := bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+
+			got := appendJSONString(nil, s, escapeHTML)
+			assert.Equal(t, string(want), string(got), "%q escapeHTML=%v", s, escapeHTML)
+		}
+	}
+}
+
+func TestJSONIsValidNumber(t *testing.T) {
+	cases := map[string]bool{
+		"0":       true,
+		"-0":      true,
+		"12.5":    true,
+		"1e10":    true,
+		"-1.5E-3": true,
+		"":        false,
+		"-":       false,
+		"01":      false,
+		"1.":      false,
+		".5":      false,
+		"1e":      false,
+		"+1":      false,
+		"0x10":    false,
+	}
+	for s, want := range cases {
+		assert.Equal(t, want, jsonIsValidNumber(s), "%q", s)
+	}
+}
+
+// jsonIndentSize is charged before json.Indent runs, so it must never be
+// smaller than the output.
+func TestJSONIndentSize(t *testing.T) {
+	cases := []string{
+		`{}`,
+		`[]`,
+		`{"a":[1,2,{"b":"c,d:{["}],"e":{}}`,
+		`[[[[[[[[[[1]]]]]]]]]]`,
+		`  {"a" : "x\"y" , "b":[ ] }  `,
+	}
+	for _, src := range cases {
+		for _, ind := range [][2]string{{"", ""}, {"", "\t"}, {">>", "    "}} {
+			var buf bytes.Buffer
+			require.NoError(t, json.Indent(&buf, []byte(src), ind[0], ind[1]))
+			size := jsonIndentSize([]byte(src), len(ind[0]), len(ind[1]))
+			assert.GreaterOrEqual(t, size, int64(buf.Len()), "%s prefix=%q indent=%q", src, ind[0], ind[1])
+		}
+	}
+}
@@ -65,6 +65,18 @@ func (biv BigintValue) String() string {
 	return biv.V.String()
 }
 
+func (bcv BigcomplexValue) String() string {
+	im := bcv.Imag.String()
+	if !strings.HasPrefix(im, "-") {
+		im = "+" + im
+	}
+	return "(" + bcv.Real.String() + im + "i)"
+}
+
+func (cv Complex128Value) String() string {
+	return strconv.FormatComplex(complex128Of(cv.Real, cv.Imag), 'g', -1, 128)
+}
+
 func (bdv BigdecValue) String() string {
 	if bdv.F != nil {
 		return bdv.F.Text('g', -1)
@@ -181,6 +193,10 @@ func (mv *MapValue) ProtectedString(seen *seenValues) string {
 	return protectedStringOf(mv, seen)
 }
 
+func (cv *ChanValue) String() string {
+	return fmt.Sprintf("chan{len:%d,cap:%d}", len(cv.Buffer), cv.Cap)
+}
+
 func (tv TypeValue) String() string {
 	return fmt.Sprintf("typeval{%s}",
 		tv.Type.String())
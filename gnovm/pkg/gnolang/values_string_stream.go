@@ -220,6 +220,11 @@ func (mw *meteredWriter) WriteFloat(f float64, bitSize int) {
 	mw.n = len(strconv.AppendFloat(mw.buf[:mw.n], f, 'g', -1, bitSize))
 }
 
+// WriteComplex writes c in strconv 'g' format, e.g. "(1+2i)".
+func (mw *meteredWriter) WriteComplex(c complex128, bitSize int) {
+	mw.WriteString(strconv.FormatComplex(c, 'g', -1, bitSize))
+}
+
 // WriteQuote writes the Go-quoted form of s (fmt %q-equivalent), matching
 // ProtectedString's strconv.Quote post-step.
 //
@@ -308,10 +313,16 @@ func writeProtectedSprint(w *meteredWriter, tv TypedValue, seen *seenValues, con
 			w.WriteFloat(float64(math.Float32frombits(tv.GetFloat32())), 32)
 		case Float64Type:
 			w.WriteFloat(math.Float64frombits(tv.GetFloat64()), 64)
+		case Complex64Type:
+			w.WriteComplex(complex128(complex64Of(tv.GetComplex64())), 64)
+		case Complex128Type:
+			w.WriteComplex(complex128Of(tv.GetComplex128()), 128)
 		case UntypedBigintType:
 			w.WriteString(tv.V.(BigintValue).V.String())
 		case UntypedBigdecType:
 			w.WriteString(tv.V.(BigdecValue).String())
+		case UntypedBigcomplexType:
+			w.WriteString(tv.V.(BigcomplexValue).String())
 		default:
 			panic("should not happen")
 		}
@@ -343,8 +354,6 @@ func writeProtectedSprint(w *meteredWriter, tv TypedValue, seen *seenValues, con
 		panic("should not happen")
 	case *PackageType:
 		w.WriteString(tv.V.(*PackageValue).String())
-	case *ChanType:
-		panic("not yet implemented")
 	case *TypeType:
 		w.WriteString(tv.V.(TypeValue).String())
 	default:
@@ -617,6 +626,10 @@ func (tv TypedValue) WriteProtected(w *meteredWriter, seen *seenValues) {
 			w.WriteFloat(float64(math.Float32frombits(tv.GetFloat32())), 32)
 		case Float64Type:
 			w.WriteFloat(math.Float64frombits(tv.GetFloat64()), 64)
+		case Complex64Type:
+			w.WriteComplex(complex128(complex64Of(tv.GetComplex64())), 64)
+		case Complex128Type:
+			w.WriteComplex(complex128Of(tv.GetComplex128()), 128)
 		default:
 			// Complex types that require recursion protection.
 			w.WriteString(nilStr)
@@ -38,6 +38,11 @@ type File struct {
 	// If this value is set, the module cannot be added to the chain.
 	Replace []Replace `toml:"replace,omitempty" json:"replace,omitempty"`
 
+	// Upgrade is the upgrade section of the gnomod.toml file.
+	// If it names an authority, the realm can be upgraded on chain by that
+	// address. Otherwise, the realm is immutable once added.
+	Upgrade Upgrade `toml:"upgrade,omitempty" json:"upgrade,omitempty"`
+
 	// AddPkg is the addpkg section of the gnomod.toml file.
 	// It is filled by the vmkeeper when a module is added.
 	// It is not intended to be used offchain.
@@ -53,6 +58,11 @@ type AddPkg struct {
 	// XXX: Consider things like IsUsingBanker or other security-awareness flags
 }
 
+type Upgrade struct {
+	// Authority is the address allowed to upgrade the realm.
+	Authority string `toml:"authority,omitempty" json:"authority,omitempty"`
+}
+
 type Replace struct {
 	// Old is the old module path of the dependency, i.e.,
 	// `gno.land/r/path/to/module`.
@@ -165,3 +175,8 @@ func (f *File) Sanitize() {
 func (f *File) HasReplaces() bool {
 	return len(f.Replace) > 0
 }
+
+// IsUpgradable returns true if the module declares an upgrade authority.
+func (f *File) IsUpgradable() bool {
+	return f.Upgrade.Authority != ""
+}
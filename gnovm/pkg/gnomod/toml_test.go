@@ -70,6 +70,19 @@ func TestMarshalTomlHelper(t *testing.T) {
 			}(),
 			expected: "module = \"gno.land/r/test\"\ngno = \"0.9\"\n\n[addpkg]\n  creator = \"addr1\"\n  height = 42\n",
 		},
+		{
+			name: "upgradable",
+			file: func() *File {
+				file := File{}
+				file.Module = "gno.land/r/test"
+				file.Gno = "0.9"
+				file.Upgrade.Authority = "addr2"
+				file.AddPkg.Creator = "addr1"
+				file.AddPkg.Height = 42
+				return &file
+			}(),
+			expected: "module = \"gno.land/r/test\"\ngno = \"0.9\"\n\n[upgrade]\n  authority = \"addr2\"\n\n[addpkg]\n  creator = \"addr1\"\n  height = 42\n",
+		},
 		{
 			name: "full",
 			file: func() *File {
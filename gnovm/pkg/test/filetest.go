@@ -33,14 +33,16 @@ func (opts *TestOptions) RunFiletest(fname string, source []byte, tgs gno.Store)
 	opts.outWriter.w = opts.Output
 	opts.outWriter.errW = opts.Error
 	tcheck := true // Go type-check filetests in test/files.
-	return opts.runFiletest(fname, source, tgs, tcheck)
+	return opts.runFiletest(fname, "", source, tgs, tcheck)
 }
 
 // tcheck: only set to false pkg/test.Test(), since `gno test`
 // (cmd/gno/test.go) already type-checked the whole package.
 // Go type-checking in filetests is only available for gnovm internal filetests
 // in test/files.
-func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store, tcheck bool) (string, types.Gas, gno.StorageDiffs, error) {
+// dir is the directory of the filetest, used by the debugger to find its
+// source; it may be empty.
+func (opts *TestOptions) runFiletest(fname, dir string, source []byte, tgs gno.Store, tcheck bool) (string, types.Gas, gno.StorageDiffs, error) {
 	dirs, err := ParseDirectives(bytes.NewReader(source))
 	if err != nil {
 		return "", 0, nil, fmt.Errorf("error parsing directives: %w", err)
@@ -75,7 +77,11 @@ func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store,
 	if dirs.First(DirectiveRealm) != nil {
 		opslog = new(bytes.Buffer)
 	}
-	gasMeter := store.NewInfiniteGasMeter()
+	var gasMeter store.GasMeter = store.NewInfiniteGasMeter()
+	if opts.Profiler != nil {
+		// Wrap the gas meter of the store too, to profile its gas.
+		gasMeter = opts.Profiler.GasMeter(gasMeter)
+	}
 	// Create machine for execution and run test
 	tcw := opts.BaseStore.CacheWrap()
 	m := gno.NewMachineWithOptions(gno.MachineOptions{
@@ -86,14 +92,25 @@ func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store,
 		GasMeter:      gasMeter,
 		Debug:         opts.Debug,
 		ReviveEnabled: true,
+		Coverage:      opts.Coverage,
+		Profiler:      opts.Profiler,
 	})
 	defer m.Release()
+	var pkgFile func(string) string
+	if dir != "" {
+		// The file is renamed when run, see runTest.
+		pkgFile = func(string) string { return filepath.Join(dir, fname) }
+	}
+	opts.enableDebugger(m, pkgPath, pkgFile)
 
 	// RUN THE FILETEST /////////////////////////////////////
 	result := opts.runTest(m, pkgPath, fname, source, opslog, tcheck)
 
 	// Collect storage diffs after test execution.
 	storageDiffs := m.Store.RealmStorageDiffs()
+	if opts.StorageObjects {
+		printStorageObjects(opts.Error, m.Store, storageDiffs)
+	}
 
 	// updated tells whether the directives have been updated, and as such
 	// a new generated filetest should be returned.
@@ -428,10 +445,11 @@ func (opts *TestOptions) runTest(m *gno.Machine, pkgPath, fname string, content
 				// mimicing the loading behavior with on-chain.
 				// (if using m.Store, the realm package will
 				// be preloaded during typecheck)
-				Getter:     opts.TestStore,
-				TestGetter: m.Store,
-				Mode:       gno.TCLatestRelaxed,
-				Cache:      opts.tcCache,
+				Getter:           opts.TestStore,
+				TestGetter:       m.Store,
+				Mode:             gno.TCLatestRelaxed,
+				Cache:            opts.tcCache,
+				AllowConcurrency: true,
 			}); err != nil {
 				tcError = fmt.Sprintf("%v", err.Error())
 			}
@@ -468,10 +486,11 @@ func (opts *TestOptions) runTest(m *gno.Machine, pkgPath, fname string, content
 		// Validate Gno syntax and type check.
 		if tcheck {
 			if _, err := gno.TypeCheckMemPackage(mpkg, gno.TypeCheckOptions{
-				Getter:     m.Store,
-				TestGetter: m.Store,
-				Mode:       gno.TCLatestRelaxed,
-				Cache:      opts.tcCache,
+				Getter:           m.Store,
+				TestGetter:       m.Store,
+				Mode:             gno.TCLatestRelaxed,
+				Cache:            opts.tcCache,
+				AllowConcurrency: true,
 			}); err != nil {
 				tcError = fmt.Sprintf("%v", err.Error())
 			}
@@ -0,0 +1,447 @@
+package test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// Fuzz tests are run in two steps. testing.RunFuzz runs the body of the fuzz
+// test, which adds seeds to its corpus and registers its fuzz target, and
+// returns both. Gno has no reflection, so the fuzz target, which takes typed
+// arguments, is then called from here: for each input, an expression calling
+// the target with the input's values is evaluated through
+// testing.RunFuzzInput.
+//
+// When fuzzing, inputs are generated by mutating those of the corpus, and
+// are added to the corpus if they execute statements of the tested package
+// a number of times not seen before, as recorded by a [gno.Coverage] set on
+// the machine. The first failing input is minimized, and written to the
+// testdata/fuzz directory of the package, which is read as part of the
+// corpus by later runs.
+
+const (
+	// fuzzStatusInterval is the interval between fuzzing status lines.
+	fuzzStatusInterval = 3 * time.Second
+	// fuzzMinimizeTime bounds the time spent minimizing a failing input.
+	fuzzMinimizeTime = time.Minute
+)
+
+// fuzzZeroValues holds the zero value of each type supported as an
+// argument of a fuzz target, by name of the type.
+var fuzzZeroValues = map[string]any{
+	"[]uint8": []byte{},
+	"string":  "",
+	"bool":    false,
+	"int":     int(0),
+	"int8":    int8(0),
+	"int16":   int16(0),
+	"int32":   int32(0),
+	"int64":   int64(0),
+	"uint":    uint(0),
+	"uint8":   uint8(0),
+	"uint16":  uint16(0),
+	"uint32":  uint32(0),
+	"uint64":  uint64(0),
+	"float32": float32(0),
+	"float64": float64(0),
+}
+
+// fuzzTarget is the fuzz target registered by a fuzz test with F.Fuzz.
+type fuzzTarget struct {
+	name     string         // name of the fuzz test.
+	fn       gno.TypedValue // the fuzz target.
+	types    []string       // types of the fuzzed arguments, after the *T.
+	runInput gno.TypedValue // testing.RunFuzzInput.
+	m        *gno.Machine
+	testing  gno.Expr // the testing package.
+}
+
+func newFuzzTarget(m *gno.Machine, testingcx gno.Expr, name string, fn gno.TypedValue) (*fuzzTarget, error) {
+	ft, ok := fn.T.(*gno.FuncType)
+	if !ok {
+		return nil, fmt.Errorf("fuzz target must be a function, got %s", fn.T.String())
+	}
+	if len(ft.Params) < 2 || ft.Params[0].Type.String() != "*testing.T" {
+		return nil, errors.New("fuzz target must receive at least two arguments, where the first argument is a *T")
+	}
+	if len(ft.Results) != 0 {
+		return nil, errors.New("fuzz target must not return a value")
+	}
+	types := make([]string, 0, len(ft.Params)-1)
+	for _, p := range ft.Params[1:] {
+		typ := p.Type.String()
+		if _, ok := fuzzZeroValues[typ]; !ok {
+			return nil, fmt.Errorf("unsupported type for fuzzing %s", typ)
+		}
+		types = append(types, typ)
+	}
+	runInputX := gno.Sel(testingcx, "RunFuzzInput")
+	return &fuzzTarget{
+		name:     name,
+		fn:       fn,
+		types:    types,
+		runInput: m.Eval(runInputX)[0],
+		m:        m,
+		testing:  testingcx,
+	}, nil
+}
+
+// check returns an error if the values of e don't match the arguments of the
+// fuzz target.
+func (ft *fuzzTarget) check(e fuzzEntry) error {
+	types := make([]string, len(e.values))
+	for i, v := range e.values {
+		types[i] = fmt.Sprintf("%T", v)
+	}
+	if !slices.Equal(types, ft.types) {
+		return fmt.Errorf("mismatched types in corpus entry: %v, want %v", types, ft.types)
+	}
+	return nil
+}
+
+// zeroValues returns the zero values of the arguments of the fuzz target.
+func (ft *fuzzTarget) zeroValues() []any {
+	values := make([]any, len(ft.types))
+	for i, typ := range ft.types {
+		values[i] = fuzzZeroValues[typ]
+	}
+	return values
+}
+
+// run calls the fuzz target with values, as the test named name, and
+// returns whether it failed and its output.
+func (ft *fuzzTarget) run(name string, verbose bool, values []any) (failed bool, output string) {
+	m := ft.m
+	args := make([]any, 0, len(values)+1)
+	args = append(args, gno.Nx("t"))
+	for i, v := range values {
+		var tv gno.TypedValue
+		if b, ok := v.([]byte); ok {
+			// Faster than Go2GnoValue, which makes a list of values.
+			tv.T = &gno.SliceType{Elt: gno.Uint8Type}
+			tv.V = m.Alloc.NewSliceFromData(slices.Clone(b))
+		} else {
+			tv = gno.Go2GnoValue(m.Alloc, m.Store, reflect.ValueOf(v))
+		}
+		args = append(args, gno.NewConstExpr(gno.Nx("arg"+strconv.Itoa(i)), tv))
+	}
+	// func(t *testing.T) { target(t, args...) }
+	call := gno.Fn(
+		gno.Flds("t", gno.Ptr(gno.Sel(ft.testing, "T"))),
+		nil,
+		gno.Ss(&gno.ExprStmt{
+			X: gno.Call(gno.NewConstExpr(gno.Nx(ft.name+".target"), ft.fn), args...),
+		}),
+	)
+	eval := m.Eval(gno.Call(
+		gno.NewConstExpr(gno.Sel(ft.testing, "RunFuzzInput"), ft.runInput),
+		gno.Str(name),
+		gno.Nx(strconv.FormatBool(verbose)),
+		call,
+	))
+	return eval[0].GetBool(), eval[1].GetString()
+}
+
+// runFuzzTest runs the fuzz test name on m: its body, then its fuzz target
+// with each entry of its corpus, made of its seeds and of the files in the
+// testdata/fuzz/<name> directory of the package in fsDir. If fuzzCov is not
+// nil, the fuzz target is then fuzzed, using fuzzCov for coverage feedback.
+func (opts *TestOptions) runFuzzTest(m *gno.Machine, testingcx gno.Expr, name, fsDir string, fuzzCov *gno.Coverage) error {
+	startedAt := time.Now()
+
+	runFuzzX := gno.Sel(testingcx, "RunFuzz")
+	runFuzz := m.Eval(runFuzzX)[0]
+	runFuzzCX := gno.NewConstExpr(runFuzzX, runFuzz)
+
+	eval := m.Eval(gno.Call(
+		runFuzzCX, // Call testing.RunFuzz
+		gno.Nx(strconv.FormatBool(opts.Verbose)),
+		&gno.CompositeLitExpr{
+			Type: gno.Sel(testingcx, "InternalFuzzTarget"),
+			Elts: gno.KeyValueExprs{
+				{Key: gno.X("Name"), Value: gno.Str(name)},
+				{Key: gno.X("F"), Value: gno.Nx(name)},
+			},
+		},
+	))
+
+	var rep report
+	if err := json.Unmarshal([]byte(eval[0].GetString()), &rep); err != nil {
+		fmt.Fprintf(opts.Error, "--- FAIL: %s [internal gno testing error]", name)
+		return err
+	}
+	if rep.Failed {
+		// Already printed by testing.RunFuzz.
+		return fmt.Errorf("failed: %q", name)
+	}
+	if rep.Skipped {
+		return nil
+	}
+	if eval[1].T == nil {
+		// F.Fuzz was not called: there is nothing else to run.
+		if opts.Verbose {
+			fmt.Fprintf(opts.Error, "--- PASS: %s (%s)\n", name, fmtDuration(time.Since(startedAt)))
+		}
+		return nil
+	}
+
+	fail := func(err error) error {
+		fmt.Fprintf(opts.Error, "--- FAIL: %s (%s)\n", name, fmtDuration(time.Since(startedAt)))
+		fmt.Fprintln(opts.Error, err.Error())
+		return fmt.Errorf("failed: %q", name)
+	}
+	target, err := newFuzzTarget(m, testingcx, name, eval[1])
+	if err != nil {
+		return fail(err)
+	}
+	var corpus []fuzzEntry
+	for i, values := range fuzzSeeds(eval[2]) {
+		corpus = append(corpus, fuzzEntry{name: "seed#" + strconv.Itoa(i), values: values})
+	}
+	files, err := readFuzzCorpus(fsDir, name)
+	if err != nil {
+		return fail(err)
+	}
+	corpus = append(corpus, files...)
+	for _, e := range corpus {
+		if err := target.check(e); err != nil {
+			return fail(fmt.Errorf("%s/%s: %w", name, e.name, err))
+		}
+	}
+
+	if fuzzCov != nil {
+		return opts.fuzz(target, corpus, fsDir, fuzzCov, startedAt)
+	}
+
+	filter := splitRegexp(opts.RunFlag)
+	var failures strings.Builder
+	for _, e := range corpus {
+		ename := name + "/" + e.name
+		if !shouldRun(filter, ename) {
+			continue
+		}
+		start := time.Now()
+		failed, output := target.run(ename, opts.Verbose, e.values)
+		if failed {
+			// If verbose, the failure is already printed.
+			fmt.Fprintf(&failures, "--- FAIL: %s (%s)\n%s", ename, fmtDuration(time.Since(start)), output)
+		}
+	}
+	dstr := fmtDuration(time.Since(startedAt))
+	if failures.Len() > 0 {
+		fmt.Fprintf(opts.Error, "--- FAIL: %s (%s)\n", name, dstr)
+		if !opts.Verbose {
+			fmt.Fprint(opts.Error, failures.String())
+		}
+		return fmt.Errorf("failed: %q", name)
+	}
+	if opts.Verbose {
+		fmt.Fprintf(opts.Error, "--- PASS: %s (%s)\n", name, dstr)
+	}
+	return nil
+}
+
+// fuzz fuzzes target, starting from corpus, until an input fails or the
+// fuzzing time set in opts is elapsed.
+func (opts *TestOptions) fuzz(target *fuzzTarget, corpus []fuzzEntry, fsDir string, cov *gno.Coverage, startedAt time.Time) error {
+	start := time.Now()
+	status := func(format string, args ...any) {
+		elapsed := time.Since(start).Round(time.Second)
+		fmt.Fprintf(opts.Error, "fuzz: elapsed: %s, "+format+"\n", append([]any{elapsed}, args...)...)
+	}
+
+	// seen records, for each block, the hit count buckets reached so far.
+	blocks := cov.Blocks()
+	seen := make([]uint8, len(blocks))
+	run := func(values []any) (failed bool, output string) {
+		cov.Reset()
+		return target.run(target.name, false, values)
+	}
+	// newCoverage reports whether the last input reached a new hit count
+	// bucket for any block, and records it.
+	newCoverage := func() (found bool) {
+		for i, cb := range blocks {
+			if bucket := fuzzCountBucket(cb.Count); seen[i]&bucket == 0 && bucket != 0 {
+				seen[i] |= bucket
+				found = true
+			}
+		}
+		return found
+	}
+
+	if len(corpus) == 0 {
+		corpus = []fuzzEntry{{name: "zero", values: target.zeroValues()}}
+	}
+	status("gathering baseline coverage: 0/%d completed", len(corpus))
+	for _, e := range corpus {
+		if failed, output := run(e.values); failed {
+			fmt.Fprintf(opts.Error, "--- FAIL: %s (%s)\n%s", target.name, fmtDuration(time.Since(startedAt)), output)
+			fmt.Fprintf(opts.Error, "failure while testing seed corpus entry: %s/%s\n", target.name, e.name)
+			return fmt.Errorf("failed: %q", target.name)
+		}
+		newCoverage()
+	}
+	status("gathering baseline coverage: %d/%d completed, now fuzzing", len(corpus), len(corpus))
+
+	baseline := len(corpus)
+	execs := 0
+	stats := func() {
+		rate := float64(execs) / time.Since(start).Seconds()
+		status("execs: %d (%.0f/sec), new interesting: %d (total: %d)",
+			execs, rate, len(corpus)-baseline, len(corpus))
+	}
+	mut := newFuzzMutator(uint64(time.Now().UnixNano()))
+	lastStatus := time.Now()
+	for opts.FuzzN <= 0 || execs < opts.FuzzN {
+		if opts.FuzzTime > 0 && time.Since(start) >= opts.FuzzTime {
+			break
+		}
+		if time.Since(lastStatus) >= fuzzStatusInterval {
+			stats()
+			lastStatus = time.Now()
+		}
+
+		values := mut.mutate(corpus[mut.r.IntN(len(corpus))].values, corpus)
+		failed, _ := run(values)
+		execs++
+		if failed {
+			stats()
+			return opts.fuzzFailure(target, values, run, fsDir, startedAt)
+		}
+		if newCoverage() {
+			corpus = append(corpus, fuzzEntry{name: "new#" + strconv.Itoa(execs), values: values})
+		}
+	}
+	stats()
+	if opts.Verbose {
+		fmt.Fprintf(opts.Error, "--- PASS: %s (%s)\n", target.name, fmtDuration(time.Since(startedAt)))
+	}
+	return nil
+}
+
+// fuzzFailure minimizes values, a failing input of target, writes it to the
+// corpus of target and reports the failure.
+func (opts *TestOptions) fuzzFailure(
+	target *fuzzTarget,
+	values []any,
+	run func([]any) (bool, string),
+	fsDir string,
+	startedAt time.Time,
+) error {
+	fmt.Fprintln(opts.Error, "fuzz: minimizing failing input")
+	deadline := time.Now().Add(fuzzMinimizeTime)
+	stop := func() bool { return time.Now().After(deadline) }
+	for i, v := range values {
+		// Like Go, only []byte and string values are minimized.
+		var b []byte
+		switch v := v.(type) {
+		case []byte:
+			b = v
+		case string:
+			b = []byte(v)
+		default:
+			continue
+		}
+		with := func(b []byte) []any {
+			vs := slices.Clone(values)
+			if _, ok := v.(string); ok {
+				vs[i] = string(b)
+			} else {
+				vs[i] = b
+			}
+			return vs
+		}
+		b = minimizeBytes(b, func(b []byte) bool {
+			failed, _ := run(with(b))
+			return failed
+		}, stop)
+		values = with(b)
+	}
+	_, output := run(values)
+
+	fmt.Fprintf(opts.Error, "--- FAIL: %s (%s)\n%s\n", target.name, fmtDuration(time.Since(startedAt)), output)
+	fname, err := writeFuzzCorpusFile(fsDir, target.name, values)
+	if err != nil {
+		fmt.Fprintf(opts.Error, "failed to write failing input: %v\n", err)
+	} else {
+		fmt.Fprintf(opts.Error, "Failing input written to %s\n", filepath.Join("testdata", "fuzz", target.name, fname))
+		fmt.Fprintf(opts.Error, "To re-run:\ngno test -run=%s/%s\n", target.name, fname)
+	}
+	return fmt.Errorf("failed: %q", target.name)
+}
+
+// fuzzCountBucket returns the bucket of a hit count, as a bit: like in AFL,
+// the number of times a block is executed only matters by order of
+// magnitude.
+func fuzzCountBucket(n int64) uint8 {
+	switch {
+	case n == 0:
+		return 0
+	case n == 1:
+		return 1 << 0
+	case n == 2:
+		return 1 << 1
+	case n == 3:
+		return 1 << 2
+	case n < 8:
+		return 1 << 3
+	case n < 16:
+		return 1 << 4
+	case n < 32:
+		return 1 << 5
+	case n < 128:
+		return 1 << 6
+	default:
+		return 1 << 7
+	}
+}
+
+// fuzzSeeds converts the seeds returned by testing.RunFuzz, of type [][]any,
+// to Go values.
+func fuzzSeeds(tv gno.TypedValue) [][]any {
+	var seeds [][]any
+	for _, etv := range sliceElems(tv) {
+		values := []any{}
+		for _, vtv := range sliceElems(etv) {
+			values = append(values, gno.Gno2GoValue(&vtv, reflect.Value{}).Interface())
+		}
+		seeds = append(seeds, values)
+	}
+	return seeds
+}
+
+func sliceElems(tv gno.TypedValue) []gno.TypedValue {
+	if tv.V == nil {
+		return nil
+	}
+	sv := tv.V.(*gno.SliceValue)
+	return sv.GetBase(nil).List[sv.Offset : sv.Offset+sv.Length]
+}
+
+// loadFuzzFuncs returns the names of the fuzz tests in tfiles, which take a
+// single *testing.F parameter.
+func loadFuzzFuncs(tfiles *gno.FileSet) (rt []string) {
+	for _, tf := range tfiles.Files {
+		for _, d := range tf.Decls {
+			if fd, ok := d.(*gno.FuncDecl); ok {
+				if fd.IsMethod || len(fd.Type.Params) != 1 || len(fd.Type.Results) != 0 {
+					continue
+				}
+				fname := string(fd.Name)
+				if strings.HasPrefix(fname, "Fuzz") {
+					rt = append(rt, fname)
+				}
+			}
+		}
+	}
+	return
+}
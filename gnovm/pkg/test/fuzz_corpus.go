@@ -0,0 +1,326 @@
+package test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Fuzz corpus files use the format of Go's fuzzing corpus, so that they can
+// be read and written by the same tools: a header line, followed by one line
+// per argument, each written as a Go conversion of a literal:
+//
+//	go test fuzz v1
+//	[]byte("hello")
+//	int(42)
+
+const fuzzCorpusHeader = "go test fuzz v1"
+
+// fuzzEntry is an entry of the corpus of a fuzz test: the arguments of one
+// call to its fuzz target, as Go values.
+type fuzzEntry struct {
+	name   string // name of the entry: seed#N, or its file name.
+	values []any
+}
+
+// marshalFuzzEntry encodes values in the format of fuzz corpus files.
+func marshalFuzzEntry(values []any) []byte {
+	var b bytes.Buffer
+	b.WriteString(fuzzCorpusHeader + "\n")
+	for _, v := range values {
+		switch v := v.(type) {
+		case []byte:
+			fmt.Fprintf(&b, "[]byte(%q)\n", v)
+		case string:
+			fmt.Fprintf(&b, "string(%q)\n", v)
+		case uint8:
+			fmt.Fprintf(&b, "byte(%q)\n", v)
+		case int32:
+			if utf8.ValidRune(v) {
+				fmt.Fprintf(&b, "rune(%q)\n", v)
+			} else {
+				fmt.Fprintf(&b, "int32(%d)\n", v)
+			}
+		case float32:
+			if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+				fmt.Fprintf(&b, "math.Float32frombits(0x%x)\n", math.Float32bits(v))
+			} else {
+				fmt.Fprintf(&b, "float32(%v)\n", v)
+			}
+		case float64:
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				fmt.Fprintf(&b, "math.Float64frombits(0x%x)\n", math.Float64bits(v))
+			} else {
+				fmt.Fprintf(&b, "float64(%v)\n", v)
+			}
+		case bool, int, int8, int16, int64, uint, uint16, uint32, uint64:
+			fmt.Fprintf(&b, "%T(%v)\n", v, v)
+		default:
+			panic(fmt.Sprintf("unsupported fuzz value type %T", v))
+		}
+	}
+	return b.Bytes()
+}
+
+// unmarshalFuzzEntry decodes the contents of a fuzz corpus file.
+func unmarshalFuzzEntry(data []byte) ([]any, error) {
+	lines := bytes.Split(data, []byte("\n"))
+	if len(lines) == 0 || string(bytes.TrimSpace(lines[0])) != fuzzCorpusHeader {
+		return nil, errors.New("missing or unrecognized header")
+	}
+	var values []any
+	for i, line := range lines[1:] {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		v, err := parseFuzzValue(string(line))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+2, err)
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return nil, errors.New("no values")
+	}
+	return values, nil
+}
+
+// parseFuzzValue parses a line of a fuzz corpus file, such as `int(42)`.
+func parseFuzzValue(line string) (any, error) {
+	expr, err := parser.ParseExpr(line)
+	if err != nil {
+		return nil, err
+	}
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) != 1 || call.Ellipsis != token.NoPos {
+		return nil, errors.New("expected a call with a single argument")
+	}
+
+	var typ string
+	switch fn := call.Fun.(type) {
+	case *ast.Ident:
+		typ = fn.Name
+	case *ast.ArrayType:
+		if elt, ok := fn.Elt.(*ast.Ident); ok && fn.Len == nil &&
+			(elt.Name == "byte" || elt.Name == "uint8") {
+			typ = "[]byte"
+		}
+	case *ast.SelectorExpr:
+		if pkg, ok := fn.X.(*ast.Ident); ok && pkg.Name == "math" {
+			typ = "math." + fn.Sel.Name
+		}
+	}
+
+	lit, neg, err := fuzzLiteral(call.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	if neg {
+		lit.Value = "-" + lit.Value
+	}
+
+	switch typ {
+	case "[]byte", "string":
+		if lit.Kind != token.STRING {
+			return nil, fmt.Errorf("%s: expected a string literal", typ)
+		}
+		s, err := strconv.Unquote(lit.Value)
+		if err != nil {
+			return nil, err
+		}
+		if typ == "[]byte" {
+			return []byte(s), nil
+		}
+		return s, nil
+	case "bool":
+		if lit.Kind != token.IDENT || (lit.Value != "true" && lit.Value != "false") {
+			return nil, errors.New("bool: expected true or false")
+		}
+		return lit.Value == "true", nil
+	case "float32", "float64":
+		bits := 64
+		if typ == "float32" {
+			bits = 32
+		}
+		var f float64
+		switch lit.Kind {
+		case token.INT, token.FLOAT, token.IDENT:
+			// Identifiers are non-finite numbers, such as +Inf.
+			f, err = strconv.ParseFloat(lit.Value, bits)
+		default:
+			err = fmt.Errorf("%s: expected a number", typ)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if typ == "float32" {
+			return float32(f), nil
+		}
+		return f, nil
+	case "math.Float32frombits", "math.Float64frombits":
+		bits := 64
+		if typ == "math.Float32frombits" {
+			bits = 32
+		}
+		if lit.Kind != token.INT {
+			return nil, fmt.Errorf("%s: expected an integer", typ)
+		}
+		u, err := strconv.ParseUint(lit.Value, 0, bits)
+		if err != nil {
+			return nil, err
+		}
+		if bits == 32 {
+			return math.Float32frombits(uint32(u)), nil
+		}
+		return math.Float64frombits(u), nil
+	}
+
+	// Integer types, written either as numbers or as characters.
+	var signed bool
+	var bits int
+	switch typ {
+	case "int", "int64":
+		signed, bits = true, 64
+	case "int8":
+		signed, bits = true, 8
+	case "int16":
+		signed, bits = true, 16
+	case "int32", "rune":
+		signed, bits = true, 32
+	case "uint", "uint64":
+		bits = 64
+	case "uint8", "byte":
+		bits = 8
+	case "uint16":
+		bits = 16
+	case "uint32":
+		bits = 32
+	default:
+		return nil, fmt.Errorf("unsupported type %q", typ)
+	}
+	var n int64
+	var u uint64
+	switch lit.Kind {
+	case token.CHAR:
+		r, _, tail, err := strconv.UnquoteChar(lit.Value[1:len(lit.Value)-1], '\'')
+		if err != nil || tail != "" {
+			return nil, fmt.Errorf("invalid character literal %s", lit.Value)
+		}
+		n, u = int64(r), uint64(r)
+		if signed && n>>(bits-1) != 0 || !signed && u>>bits != 0 {
+			return nil, fmt.Errorf("character %s overflows %s", lit.Value, typ)
+		}
+	case token.INT:
+		if signed {
+			n, err = strconv.ParseInt(lit.Value, 0, bits)
+		} else {
+			u, err = strconv.ParseUint(lit.Value, 0, bits)
+		}
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%s: expected an integer", typ)
+	}
+	switch typ {
+	case "int":
+		return int(n), nil
+	case "int8":
+		return int8(n), nil
+	case "int16":
+		return int16(n), nil
+	case "int32", "rune":
+		return int32(n), nil
+	case "int64":
+		return n, nil
+	case "uint":
+		return uint(u), nil
+	case "uint8", "byte":
+		return uint8(u), nil
+	case "uint16":
+		return uint16(u), nil
+	case "uint32":
+		return uint32(u), nil
+	default: // uint64
+		return u, nil
+	}
+}
+
+// fuzzLiteral returns the literal x, possibly preceded by a sign. Identifiers
+// (true, false, NaN, Inf) are returned as literals of kind token.IDENT.
+func fuzzLiteral(x ast.Expr) (lit *ast.BasicLit, neg bool, err error) {
+	if u, ok := x.(*ast.UnaryExpr); ok && (u.Op == token.SUB || u.Op == token.ADD) {
+		lit, _, err = fuzzLiteral(u.X)
+		if err == nil && (lit.Kind == token.STRING || lit.Kind == token.CHAR) {
+			err = errors.New("unexpected sign")
+		}
+		return lit, u.Op == token.SUB, err
+	}
+	switch x := x.(type) {
+	case *ast.BasicLit:
+		return &ast.BasicLit{Kind: x.Kind, Value: x.Value}, false, nil
+	case *ast.Ident:
+		return &ast.BasicLit{Kind: token.IDENT, Value: x.Name}, false, nil
+	default:
+		return nil, false, errors.New("expected a literal")
+	}
+}
+
+// fuzzCorpusDir returns the directory of the corpus of the fuzz test name of
+// the package in dir.
+func fuzzCorpusDir(dir, name string) string {
+	return filepath.Join(dir, "testdata", "fuzz", name)
+}
+
+// readFuzzCorpus reads the corpus files of the fuzz test name of the package
+// in dir. A missing directory is an empty corpus.
+func readFuzzCorpus(dir, name string) ([]fuzzEntry, error) {
+	cdir := fuzzCorpusDir(dir, name)
+	files, err := os.ReadDir(cdir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []fuzzEntry
+	for _, file := range files {
+		if file.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(cdir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		values, err := unmarshalFuzzEntry(data)
+		if err != nil {
+			return nil, fmt.Errorf("malformed fuzz corpus entry %s: %w", filepath.Join(cdir, file.Name()), err)
+		}
+		entries = append(entries, fuzzEntry{name: file.Name(), values: values})
+	}
+	return entries, nil
+}
+
+// writeFuzzCorpusFile writes values to the corpus of the fuzz test name of the
+// package in dir, in a file named after the hash of its contents, and
+// returns the file name.
+func writeFuzzCorpusFile(dir, name string, values []any) (string, error) {
+	data := marshalFuzzEntry(values)
+	sum := sha256.Sum256(data)
+	fname := fmt.Sprintf("%x", sum[:8])
+	cdir := fuzzCorpusDir(dir, name)
+	if err := os.MkdirAll(cdir, 0o755); err != nil {
+		return "", err
+	}
+	return fname, os.WriteFile(filepath.Join(cdir, fname), data, 0o644)
+}
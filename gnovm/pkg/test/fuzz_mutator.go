@@ -0,0 +1,245 @@
+package test
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+)
+
+// fuzzMaxLen is the maximum length of the []byte and string values generated
+// by mutations. Inputs read from the corpus may be longer.
+const fuzzMaxLen = 1 << 12
+
+// fuzzMutator generates new inputs of a fuzz target, by applying random
+// mutations to the inputs of its corpus.
+type fuzzMutator struct {
+	r *rand.Rand
+}
+
+func newFuzzMutator(seed uint64) *fuzzMutator {
+	return &fuzzMutator{r: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15))}
+}
+
+// mutate returns a copy of values with one of them mutated. corpus provides
+// values which can be spliced into []byte and string values.
+func (fm *fuzzMutator) mutate(values []any, corpus []fuzzEntry) []any {
+	values = slices.Clone(values)
+	i := fm.r.IntN(len(values))
+	// Apply a few mutations at once, so that mutations which are only
+	// interesting together can be found.
+	for n := 1 + fm.r.IntN(3); n > 0; n-- {
+		values[i] = fm.mutateValue(values[i], i, corpus)
+	}
+	return values
+}
+
+func (fm *fuzzMutator) mutateValue(v any, i int, corpus []fuzzEntry) any {
+	switch v := v.(type) {
+	case []byte:
+		return fm.mutateBytes(slices.Clone(v), fm.spliceSource(i, corpus))
+	case string:
+		return string(fm.mutateBytes([]byte(v), fm.spliceSource(i, corpus)))
+	case bool:
+		return !v
+	case int:
+		return int(fm.mutateInt(int64(v), 64))
+	case int8:
+		return int8(fm.mutateInt(int64(v), 8))
+	case int16:
+		return int16(fm.mutateInt(int64(v), 16))
+	case int32:
+		return int32(fm.mutateInt(int64(v), 32))
+	case int64:
+		return fm.mutateInt(v, 64)
+	case uint:
+		return uint(fm.mutateUint(uint64(v), 64))
+	case uint8:
+		return uint8(fm.mutateUint(uint64(v), 8))
+	case uint16:
+		return uint16(fm.mutateUint(uint64(v), 16))
+	case uint32:
+		return uint32(fm.mutateUint(uint64(v), 32))
+	case uint64:
+		return fm.mutateUint(v, 64)
+	case float32:
+		return float32(fm.mutateFloat(float64(v)))
+	case float64:
+		return fm.mutateFloat(v)
+	default:
+		panic("unsupported fuzz value type")
+	}
+}
+
+// spliceSource returns the value of the i-th argument of a random corpus
+// entry, as bytes, or nil if it is not a []byte or string.
+func (fm *fuzzMutator) spliceSource(i int, corpus []fuzzEntry) []byte {
+	if len(corpus) == 0 {
+		return nil
+	}
+	values := corpus[fm.r.IntN(len(corpus))].values
+	switch v := values[i].(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	default:
+		return nil
+	}
+}
+
+// Values which often trigger edge cases, tried when mutating numbers.
+var (
+	fuzzInterestingInts   = []int64{0, 1, -1, 2, 7, 8, 16, 32, 64, 100, 127, 128, 255, 256, 1000, 1024, 4096, 32767, 65535}
+	fuzzInterestingBytes  = []byte{0, 1, 0x7f, 0x80, 0xff, ' ', '\n', '"', '\'', '\\', '0', 'a', 'Z', '%', '/'}
+	fuzzInterestingFloats = []float64{0, math.Copysign(0, -1), 1, -1, 0.5, math.MaxFloat64, math.SmallestNonzeroFloat64, math.Inf(1), math.Inf(-1), math.NaN()}
+)
+
+// mutateInt mutates v, an integer of the given size in bits; the result is
+// truncated by the caller.
+func (fm *fuzzMutator) mutateInt(v int64, bits int) int64 {
+	switch fm.r.IntN(5) {
+	case 0:
+		return v + 1 + fm.r.Int64N(16)
+	case 1:
+		return v - 1 - fm.r.Int64N(16)
+	case 2:
+		return v ^ 1<<fm.r.IntN(bits)
+	case 3:
+		n := fuzzInterestingInts[fm.r.IntN(len(fuzzInterestingInts))]
+		if fm.r.IntN(2) == 0 {
+			n = -n
+		}
+		return n
+	default:
+		// The minimum or maximum value of the type.
+		lim := int64(1)<<(bits-1) - 1
+		if fm.r.IntN(2) == 0 {
+			return -lim - 1
+		}
+		return lim
+	}
+}
+
+// mutateUint mutates v, an unsigned integer of the given size in bits; the
+// result is truncated by the caller.
+func (fm *fuzzMutator) mutateUint(v uint64, bits int) uint64 {
+	switch fm.r.IntN(5) {
+	case 0:
+		return v + 1 + fm.r.Uint64N(16)
+	case 1:
+		return v - 1 - fm.r.Uint64N(16)
+	case 2:
+		return v ^ 1<<fm.r.IntN(bits)
+	case 3:
+		return uint64(fuzzInterestingInts[fm.r.IntN(len(fuzzInterestingInts))])
+	default:
+		if fm.r.IntN(2) == 0 {
+			return 0
+		}
+		return math.MaxUint64 >> (64 - bits)
+	}
+}
+
+func (fm *fuzzMutator) mutateFloat(v float64) float64 {
+	switch fm.r.IntN(5) {
+	case 0:
+		return v + float64(fm.r.IntN(32)-16)
+	case 1:
+		return v * (fm.r.Float64()*4 - 2)
+	case 2:
+		return -v
+	case 3:
+		return fuzzInterestingFloats[fm.r.IntN(len(fuzzInterestingFloats))]
+	default:
+		return math.Float64frombits(math.Float64bits(v) ^ 1<<fm.r.IntN(64))
+	}
+}
+
+// mutateBytes mutates b in place when possible, and returns the result.
+// splice, if not nil, is another value whose bytes can be copied into b.
+func (fm *fuzzMutator) mutateBytes(b []byte, splice []byte) []byte {
+	if len(b) == 0 {
+		// Only insertions apply to an empty value.
+		return fm.insertBytes(b)
+	}
+	switch fm.r.IntN(9) {
+	case 0:
+		return fm.insertBytes(b)
+	case 1:
+		// Remove a range.
+		pos := fm.r.IntN(len(b))
+		n := 1 + fm.r.IntN(min(len(b)-pos, 8))
+		return append(b[:pos], b[pos+n:]...)
+	case 2:
+		// Duplicate a range.
+		pos := fm.r.IntN(len(b))
+		n := 1 + fm.r.IntN(min(len(b)-pos, 8))
+		if len(b)+n > fuzzMaxLen {
+			return b
+		}
+		return slices.Insert(b, pos, slices.Clone(b[pos:pos+n])...)
+	case 3:
+		// Flip a bit.
+		pos := fm.r.IntN(len(b))
+		b[pos] ^= 1 << fm.r.IntN(8)
+	case 4:
+		b[fm.r.IntN(len(b))] = byte(fm.r.IntN(256))
+	case 5:
+		b[fm.r.IntN(len(b))] = fuzzInterestingBytes[fm.r.IntN(len(fuzzInterestingBytes))]
+	case 6:
+		// Swap two bytes.
+		i, j := fm.r.IntN(len(b)), fm.r.IntN(len(b))
+		b[i], b[j] = b[j], b[i]
+	case 7:
+		// Add to a byte, as if it were a small number.
+		b[fm.r.IntN(len(b))] += byte(fm.r.IntN(32) - 16)
+	default:
+		// Overwrite a range with a range of another value.
+		if len(splice) == 0 {
+			return fm.insertBytes(b)
+		}
+		src := fm.r.IntN(len(splice))
+		dst := fm.r.IntN(len(b))
+		copy(b[dst:], splice[src:min(len(splice), src+1+fm.r.IntN(16))])
+	}
+	return b
+}
+
+// insertBytes inserts a few random bytes at a random position of b.
+func (fm *fuzzMutator) insertBytes(b []byte) []byte {
+	n := 1 + fm.r.IntN(4)
+	if len(b)+n > fuzzMaxLen {
+		return b
+	}
+	ins := make([]byte, n)
+	for i := range ins {
+		if fm.r.IntN(2) == 0 {
+			// Mostly printable characters, which parsers care about.
+			ins[i] = byte(' ' + fm.r.IntN('~'-' '+1))
+		} else {
+			ins[i] = byte(fm.r.IntN(256))
+		}
+	}
+	return slices.Insert(b, fm.r.IntN(len(b)+1), ins...)
+}
+
+// minimizeBytes returns the smallest value derived from b by removing bytes,
+// for which fails returns true. It stops early, returning the smallest value
+// found so far, when stop returns true.
+func minimizeBytes(b []byte, fails func([]byte) bool, stop func() bool) []byte {
+	// Remove ranges of decreasing size, down to single bytes.
+	for n := len(b); n > 0; n /= 2 {
+		for pos := 0; pos+n <= len(b); {
+			if stop() {
+				return b
+			}
+			candidate := append(b[:pos:pos], b[pos+n:]...)
+			if fails(candidate) {
+				b = candidate
+			} else {
+				pos += n
+			}
+		}
+	}
+	return b
+}
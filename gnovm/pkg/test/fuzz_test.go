@@ -0,0 +1,94 @@
+package test
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFuzzEntry_Roundtrip(t *testing.T) {
+	values := []any{
+		[]byte("hello\x00\xff"), "wor\"ld", true, false,
+		int(-42), int8(math.MinInt8), int16(7), int32('é'), int32(-1), int64(math.MaxInt64),
+		uint(3), uint8('a'), uint8(0xff), uint16(9), uint32(10), uint64(math.MaxUint64),
+		float32(1.5), float64(-0.25), math.Inf(1), float32(math.Inf(-1)),
+	}
+	data := marshalFuzzEntry(values)
+	got, err := unmarshalFuzzEntry(data)
+	if err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+	if !reflect.DeepEqual(got, values) {
+		t.Fatalf("roundtrip mismatch:\ngot  %#v\nwant %#v", got, values)
+	}
+
+	// NaN is not equal to itself: check it separately.
+	got, err = unmarshalFuzzEntry(marshalFuzzEntry([]any{math.NaN()}))
+	if err != nil || !math.IsNaN(got[0].(float64)) {
+		t.Fatalf("NaN roundtrip: %v, %v", got, err)
+	}
+}
+
+func TestFuzzEntry_Unmarshal(t *testing.T) {
+	got, err := unmarshalFuzzEntry([]byte("go test fuzz v1\nstring(\"a\")\n\nint(-3)\nbyte('x')\nrune('\\n')\nfloat64(2)\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []any{"a", int(-3), uint8('x'), int32('\n'), float64(2)}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	for _, data := range []string{
+		"",
+		"go test fuzz v2\nint(1)\n",
+		"go test fuzz v1\n",
+		"go test fuzz v1\nint8(300)\n",
+		"go test fuzz v1\nstring(-\"a\")\n",
+		"go test fuzz v1\n[]int(\"a\")\n",
+		"go test fuzz v1\nbool(1)\n",
+		"go test fuzz v1\nint(1, 2)\n",
+	} {
+		if _, err := unmarshalFuzzEntry([]byte(data)); err == nil {
+			t.Errorf("unmarshal %q: expected an error", data)
+		}
+	}
+}
+
+func TestFuzzMutator_PreservesTypes(t *testing.T) {
+	fm := newFuzzMutator(1)
+	values := []any{[]byte("ab"), "cd", true, int8(1), uint16(2), float32(3), int(4)}
+	corpus := []fuzzEntry{{name: "seed#0", values: values}}
+	for range 1000 {
+		got := fm.mutate(values, corpus)
+		for i := range values {
+			if reflect.TypeOf(got[i]) != reflect.TypeOf(values[i]) {
+				t.Fatalf("value %d: got type %T, want %T", i, got[i], values[i])
+			}
+		}
+		if s := got[0].([]byte); len(s) > fuzzMaxLen {
+			t.Fatalf("value too long: %d", len(s))
+		}
+	}
+	// The original values must not be modified.
+	if !bytes.Equal(values[0].([]byte), []byte("ab")) {
+		t.Fatalf("mutate modified its input: %q", values[0])
+	}
+}
+
+func TestMinimizeBytes(t *testing.T) {
+	fails := func(b []byte) bool {
+		return bytes.Contains(b, []byte("FU")) && bytes.Count(b, []byte("x")) >= 2
+	}
+	got := minimizeBytes([]byte("aaxbbbFUccccxdd"), fails, func() bool { return false })
+	if len(got) != 4 || !fails(got) {
+		t.Fatalf("got %q, want a failing input of 4 bytes", got)
+	}
+
+	// stop interrupts the minimization, keeping the input unchanged.
+	in := []byte("aaxFUx")
+	if got := minimizeBytes(in, fails, func() bool { return true }); !bytes.Equal(got, in) {
+		t.Fatalf("got %q, want %q", got, in)
+	}
+}
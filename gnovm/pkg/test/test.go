@@ -65,6 +65,7 @@ func Context(caller crypto.Bech32Address, pkgPath string, send std.Coins) *runti
 		OriginSendSpent: new(std.Coins),
 		Banker:          banker,
 		Params:          newTestParams(),
+		Scheduler:       newTestScheduler(),
 		EventLogger:     sdk.NewEventLogger(),
 	}
 	return &runtime.TestExecContext{
@@ -218,6 +219,36 @@ func (tp *testParams) GetStrings(key string, ptr *[]string) bool {
 	return true
 }
 
+// ----------------------------------------
+// testScheduler
+//
+// In-memory backing for chain/scheduler. Calls are recorded but never
+// run, and no deposit is taken: the test runner has no block lifecycle
+// and no gas price. Ids start at 1 and are never reused, like the keeper's.
+
+type testScheduler struct {
+	next  uint64
+	calls map[uint64]string // id -> scheduling realm
+}
+
+func newTestScheduler() *testScheduler {
+	return &testScheduler{calls: map[uint64]string{}}
+}
+
+func (ts *testScheduler) Schedule(pkgPath string, height int64, fn string, args []string, gasLimit int64) (uint64, error) {
+	ts.next++
+	ts.calls[ts.next] = pkgPath
+	return ts.next, nil
+}
+
+func (ts *testScheduler) Cancel(pkgPath string, id uint64) bool {
+	if ts.calls[id] != pkgPath {
+		return false
+	}
+	delete(ts.calls, id)
+	return true
+}
+
 // ----------------------------------------
 // main test function
 
@@ -234,6 +265,9 @@ type TestOptions struct {
 	Error io.Writer
 	// Debug enables the interactive debugger on gno tests.
 	Debug bool
+	// If set, gno tests and filetests are debugged by the client of this
+	// Debug Adapter Protocol session.
+	DebugAdapter *gno.DebugAdapter
 
 	// Not set by NewTestOptions:
 
@@ -250,6 +284,28 @@ type TestOptions struct {
 	Metrics bool
 	// Uses Error to print the events emitted.
 	Events bool
+	// Uses Error to print the objects of the realms changed by each test.
+	StorageObjects bool
+	// If set, records the statements of the tested package executed by
+	// its tests and filetests.
+	Coverage *gno.Coverage
+	// If set, records the gas and allocations of the tests, benchmarks and
+	// filetests of the tested package, and of its initialization.
+	Profiler *gno.Profiler
+	// Regular expression selecting the benchmarks to run; benchmarks are
+	// not run if empty.
+	BenchFlag string
+	// Minimum duration of each benchmark; ignored if BenchN > 0.
+	BenchTime time.Duration
+	// Exact number of iterations of each benchmark, if > 0.
+	BenchN int
+	// Regular expression selecting the fuzz test to fuzz, which must match
+	// at most one fuzz test of the package; no fuzzing is done if empty.
+	FuzzFlag string
+	// Maximum duration of fuzzing; unlimited if zero.
+	FuzzTime time.Duration
+	// Maximum number of inputs generated when fuzzing, if > 0.
+	FuzzN int
 
 	filetestBuffer bytes.Buffer
 	outWriter      proxyWriter
@@ -331,6 +387,10 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 
 	var errs error
 
+	if opts.Coverage != nil {
+		opts.Coverage.AddMemPackage(mpkg)
+	}
+
 	// Create a common tcw/tgs for both the `pkg` tests as well as the
 	// `pkg_test` tests. This allows us to "export" symbols from the pkg
 	// tests and import them from the `pkg_test` tests.
@@ -360,6 +420,8 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 		// new packages by default, which we don't want.  Instead we
 		// will run the mempackage ourselves in the next line.
 		SkipPackage: true,
+		Coverage:    opts.Coverage,
+		Profiler:    opts.Profiler,
 	})
 	// Filter out xxx_test *_test.gno and *_filetest.gno and run.
 	// If testing with only filetests, there will be no files.
@@ -379,11 +441,32 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 	// not necessarily integration tests, it's just for our internal reference.)
 	tset, itset, itfiles, ftfiles := parseMemPackageTests(mpkg)
 
+	// Coverage feedback for fuzzing, on the statements of the package.
+	var fuzzCov *gno.Coverage
+	if opts.FuzzFlag != "" {
+		filter := splitRegexp(opts.FuzzFlag)
+		var matches []string
+		for _, name := range append(loadFuzzFuncs(tset), loadFuzzFuncs(itset)...) {
+			if shouldRun(filter, name) {
+				matches = append(matches, name)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			fmt.Fprintln(opts.Error, "testing: warning: no fuzz tests to fuzz")
+		case 1:
+			fuzzCov = gno.NewCoverage(gno.CoverModeCount)
+			fuzzCov.AddMemPackage(mpkg)
+		default:
+			return fmt.Errorf("will not fuzz, -fuzz matches more than one fuzz test: %v", matches)
+		}
+	}
+
 	// Testing with *_test.gno
 	if len(tset.Files)+len(itset.Files) > 0 {
 		// Run test files in pkg.
 		if len(tset.Files) > 0 {
-			err := opts.runTestFiles(mpkg, tset, tgs)
+			err := opts.runTestFiles(mpkg, tset, tgs, fsDir, fuzzCov)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -404,7 +487,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 				Files: itfiles,
 			}
 
-			err := opts.runTestFiles(itmpkg, itset, tgs)
+			err := opts.runTestFiles(itmpkg, itset, tgs, fsDir, fuzzCov)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -432,7 +515,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 			// We can not use shared tx gno store (tgs) between _filetest.gno since we need to
 			// isolate the state between them
 			changed, gas, storageDiffs, err := opts.runFiletest(
-				testFileName, []byte(testFile.Body), opts.TestStore, tcheck)
+				testFileName, filepath.Dir(testFilePath), []byte(testFile.Body), opts.TestStore, tcheck)
 			if changed != "" {
 				// Note: changed always == "" if opts.Sync == false.
 				err = os.WriteFile(testFilePath, []byte(changed), 0o644)
@@ -467,33 +550,61 @@ func filetestPath(fsDir, testFileName string) string {
 	return filepath.Join(fsDir, testFileName)
 }
 
-// enableDebugger attaches the interactive debugger to m. Source files are
-// resolved relative to the gno root, then the bundled stdlibs, then the
-// examples tree.
-func (opts *TestOptions) enableDebugger(m *gno.Machine) {
-	fileContent := func(ppath, name string) string {
-		p := filepath.Join(opts.RootDir, ppath, name)
-		b, err := os.ReadFile(p)
-		if err != nil {
-			p = filepath.Join(opts.RootDir, "gnovm", "stdlibs", ppath, name)
-			b, err = os.ReadFile(p)
-		}
-		if err != nil {
-			p = filepath.Join(opts.RootDir, "examples", ppath, name)
-			b, _ = os.ReadFile(p)
+// enableDebugger attaches the debugger to m, if enabled: the interactive
+// debugger, or the DAP client of opts.DebugAdapter. pkgFile, if not nil,
+// returns the path of the file name of the tested package pkgPath.
+func (opts *TestOptions) enableDebugger(m *gno.Machine, pkgPath string, pkgFile func(name string) string) {
+	switch {
+	case opts.DebugAdapter != nil:
+		m.Debugger.EnableDAP(opts.DebugAdapter, func(ppath, name string) string {
+			if pkgFile != nil && (ppath == pkgPath || ppath == pkgPath+"_test") {
+				return pkgFile(name)
+			}
+			return SourcePath(opts.RootDir, ppath, name)
+		})
+	case opts.Debug:
+		m.Debugger.Enable(os.Stdin, os.Stdout, func(ppath, name string) string {
+			b, _ := os.ReadFile(SourcePath(opts.RootDir, ppath, name))
+			return string(b)
+		})
+	}
+}
+
+// SourcePath returns the path of the source file name of the package
+// pkgPath, for debuggers: name itself if it is the path of an existing file,
+// else the file of the package in the gno root, the bundled stdlibs (or
+// their testing versions) or the examples tree. It returns "" if not found.
+func SourcePath(rootDir, pkgPath, name string) string {
+	candidates := []string{
+		name,
+		filepath.Join(rootDir, pkgPath, name),
+		filepath.Join(rootDir, "gnovm", "stdlibs", pkgPath, name),
+		filepath.Join(rootDir, "gnovm", "tests", "stdlibs", pkgPath, name),
+		filepath.Join(rootDir, "examples", pkgPath, name),
+	}
+	for _, p := range candidates {
+		if s, err := os.Stat(p); err == nil && !s.IsDir() {
+			if abs, err := filepath.Abs(p); err == nil {
+				return abs
+			}
+			return p
 		}
-		return string(b)
 	}
-	m.Debugger.Enable(os.Stdin, os.Stdout, fileContent)
+	return ""
 }
 
 // Runs *_test.go tests.
 // Not the same as pkg/test/filetest runFiletests()
 // which runs *_filetest.go tests.
+// fsDir is the directory of the package, where the corpus of fuzz tests is
+// found; fuzzCov is only set when fuzzing the fuzz test matched by
+// opts.FuzzFlag.
 func (opts *TestOptions) runTestFiles(
 	mpkg *std.MemPackage,
 	files *gno.FileSet,
 	tgs gno.TransactionStore,
+	fsDir string,
+	fuzzCov *gno.Coverage,
 ) (errs error) {
 	var m *gno.Machine
 	defer func() {
@@ -523,6 +634,8 @@ func (opts *TestOptions) runTestFiles(
 	// Check if we already have the package - it may have been eagerly loaded.
 	m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug, nil)
 	m.Alloc = alloc
+	m.Coverage = opts.Coverage
+	m.SetProfiler(opts.Profiler)
 	if tgs.GetMemPackage(mpkg.Path) == nil {
 		m.RunMemPackage(mpkg, false)
 	} else {
@@ -548,6 +661,8 @@ func (opts *TestOptions) runTestFiles(
 		// - Wrap here.
 		m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug, store.NewInfiniteGasMeter())
 		m.Alloc = alloc.Reset()
+		m.Coverage = opts.Coverage
+		m.SetProfiler(opts.Profiler)
 		m.SetActivePackage(pv)
 
 		testfv := m.Eval(gno.Nx(tf.Name))[0].GetFunc()
@@ -609,9 +724,7 @@ func (opts *TestOptions) runTestFiles(
 		}
 		runTestCX := gno.NewConstExpr(runTestX, runTest)
 
-		if opts.Debug {
-			opts.enableDebugger(m)
-		}
+		opts.enableDebugger(m, mpkg.Path, func(name string) string { return filepath.Join(fsDir, name) })
 
 		eval := m.Eval(gno.Call(
 			runTestCX,                                     // Call testing.RunTest
@@ -643,6 +756,9 @@ func (opts *TestOptions) runTestFiles(
 				fmt.Fprintf(opts.Error, "EVENTS: %s\n", string(res))
 			}
 		}
+		if opts.StorageObjects {
+			printStorageObjects(opts.Error, m.Store, m.Store.RealmStorageDiffs())
+		}
 
 		ret := eval[0].GetString()
 		if ret == "" {
@@ -706,15 +822,15 @@ func (opts *TestOptions) runTestFiles(
 
 		m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug, store.NewInfiniteGasMeter())
 		m.Alloc = alloc.Reset()
+		m.Coverage = opts.Coverage
+		m.SetProfiler(opts.Profiler)
 		m.SetActivePackage(pv)
 
 		runExampleTestX := gno.Sel(testingcx, "RunExampleTest")
 		runExampleTest := m.Eval(runExampleTestX)[0]
 		runExampleTestCX := gno.NewConstExpr(runExampleTestX, runExampleTest)
 
-		if opts.Debug {
-			opts.enableDebugger(m)
-		}
+		opts.enableDebugger(m, mpkg.Path, func(name string) string { return filepath.Join(fsDir, name) })
 
 		startedAt := time.Now()
 		if opts.Verbose {
@@ -761,6 +877,81 @@ func (opts *TestOptions) runTestFiles(
 		}
 	}
 
+	fuzzFilter := splitRegexp(opts.FuzzFlag)
+	for _, name := range loadFuzzFuncs(files) {
+		fuzzing := fuzzCov != nil && shouldRun(fuzzFilter, name)
+		if !fuzzing && !shouldRun(filter, name) {
+			continue
+		}
+		m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug, store.NewInfiniteGasMeter())
+		m.Alloc = alloc.Reset()
+		m.Coverage = opts.Coverage
+		m.SetActivePackage(pv)
+
+		opts.enableDebugger(m, mpkg.Path, func(name string) string { return filepath.Join(fsDir, name) })
+
+		var cov *gno.Coverage
+		if fuzzing {
+			// The coverage of the package is not recorded while
+			// fuzzing, as fuzzCov is reset for each input; nor is
+			// its profile, which would only measure the fuzzer.
+			m.Coverage, cov = fuzzCov, fuzzCov
+		} else {
+			m.SetProfiler(opts.Profiler)
+		}
+		if err := opts.runFuzzTest(m, testingcx, name, fsDir, cov); err != nil {
+			errs = multierr.Append(errs, err)
+			if opts.FailfastFlag {
+				return errs
+			}
+		}
+	}
+
+	if opts.BenchFlag == "" {
+		return errs
+	}
+	for _, name := range loadBenchmarkFuncs(files) {
+		m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug, store.NewInfiniteGasMeter())
+		m.Alloc = alloc.Reset()
+		m.Coverage = opts.Coverage
+		m.SetProfiler(opts.Profiler)
+		m.SetActivePackage(pv)
+
+		runBenchmarkX := gno.Sel(testingcx, "RunBenchmark")
+		runBenchmark := m.Eval(runBenchmarkX)[0]
+		runBenchmarkCX := gno.NewConstExpr(runBenchmarkX, runBenchmark)
+
+		opts.enableDebugger(m, mpkg.Path, func(name string) string { return filepath.Join(fsDir, name) })
+
+		eval := m.Eval(gno.Call(
+			runBenchmarkCX, // Call testing.RunBenchmark
+			gno.Str(opts.BenchFlag),
+			gno.Nx(strconv.FormatBool(opts.Verbose)),
+			gno.Num(strconv.FormatInt(int64(opts.BenchTime), 10)),
+			gno.Num(strconv.Itoa(opts.BenchN)),
+			&gno.CompositeLitExpr{
+				Type: gno.Sel(testingcx, "InternalBenchmark"),
+				Elts: gno.KeyValueExprs{
+					{Key: gno.X("Name"), Value: gno.Str(name)},
+					{Key: gno.X("F"), Value: gno.Nx(name)},
+				},
+			},
+		))
+
+		var rep report
+		if err := json.Unmarshal([]byte(eval[0].GetString()), &rep); err != nil {
+			errs = multierr.Append(errs, err)
+			fmt.Fprintf(opts.Error, "--- FAIL: %s [internal gno testing error]", name)
+			continue
+		}
+		if rep.Failed {
+			errs = multierr.Append(errs, fmt.Errorf("failed: %q", name))
+			if opts.FailfastFlag {
+				return errs
+			}
+		}
+	}
+
 	return errs
 }
 
@@ -798,6 +989,25 @@ func loadTestFuncs(pkgName string, tfiles *gno.FileSet) (rt []testFunc) {
 	return
 }
 
+// loadBenchmarkFuncs returns the names of the benchmark functions in tfiles,
+// which take a single *testing.B parameter.
+func loadBenchmarkFuncs(tfiles *gno.FileSet) (rt []string) {
+	for _, tf := range tfiles.Files {
+		for _, d := range tf.Decls {
+			if fd, ok := d.(*gno.FuncDecl); ok {
+				if fd.IsMethod || len(fd.Type.Params) != 1 || len(fd.Type.Results) != 0 {
+					continue
+				}
+				fname := string(fd.Name)
+				if strings.HasPrefix(fname, "Benchmark") {
+					rt = append(rt, fname)
+				}
+			}
+		}
+	}
+	return
+}
+
 func loadExampleTestFuncs(tfiles *gno.FileSet) (rt []*gno.FuncDecl) {
 	for _, tf := range tfiles.Files {
 		for _, d := range tf.Decls {
@@ -899,6 +1109,26 @@ func fmtDuration(d time.Duration) string {
 	return fmt.Sprintf("%.2fs", d.Seconds())
 }
 
+// printStorageObjects prints to w the storage used by the objects of each
+// realm of diffs, by package path.
+func printStorageObjects(w io.Writer, store gno.Store, diffs gno.StorageDiffs) {
+	paths := make([]string, 0, len(diffs))
+	for path := range diffs {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		objs := gno.RealmObjectsStorage(store, path)
+		if objs == nil {
+			continue
+		}
+		fmt.Fprintf(w, "STORAGE OBJECTS: %s\n", path)
+		if err := gno.WriteObjectsStorage(w, objs); err != nil {
+			panic(err)
+		}
+	}
+}
+
 // fmtStorageDiffs formats storage diffs for display in test output.
 // Returns an empty string when there are no non-zero diffs, otherwise returns
 // a string like ", storage: gno.land/r/example:+31b gno.land/r/other:+42b".
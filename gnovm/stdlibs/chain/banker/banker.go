@@ -45,6 +45,7 @@ func X_bankerGetCoin(m *gno.Machine, bt uint8, addr string, denom string) int64
 
 func X_bankerSendCoins(m *gno.Machine, bt uint8, fromS, toS string, denoms []string, amounts []int64) {
 	// bt != BankerTypeReadonly (checked in gno)
+	execctx.AssertNotInView(m, "send coins")
 
 	ctx := execctx.GetContext(m)
 	amt := CompactCoins(denoms, amounts)
@@ -76,10 +77,12 @@ func X_bankerTotalCoin(m *gno.Machine, bt uint8, denom string) int64 {
 }
 
 func X_bankerIssueCoin(m *gno.Machine, bt uint8, addr string, denom string, amount int64) {
+	execctx.AssertNotInView(m, "issue coins")
 	execctx.GetContext(m).Banker.IssueCoin(crypto.Bech32Address(addr), denom, amount)
 }
 
 func X_bankerRemoveCoin(m *gno.Machine, bt uint8, addr string, denom string, amount int64) {
+	execctx.AssertNotInView(m, "remove coins")
 	execctx.GetContext(m).Banker.RemoveCoin(crypto.Bech32Address(addr), denom, amount)
 }
 
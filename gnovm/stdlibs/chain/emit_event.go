@@ -8,6 +8,7 @@ import (
 
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/stdlibs/internal/execctx"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -98,9 +99,37 @@ type Event struct {
 
 func (e Event) AssertABCIEvent() {}
 
+// IndexedAttributes returns the type of the event, and its attributes with
+// Indexed set.
+func (e Event) IndexedAttributes() (string, []abci.EventAttribute) {
+	var attrs []abci.EventAttribute
+	for _, attr := range e.Attributes {
+		if attr.Indexed {
+			attrs = append(attrs, abci.EventAttribute{Key: attr.Key, Value: attr.Value})
+		}
+	}
+	return e.Type, attrs
+}
+
+// EventAttributes returns the type of the event, the path of the package
+// which emitted it, and all its attributes.
+func (e Event) EventAttributes() (string, string, []abci.EventAttribute) {
+	attrs := make([]abci.EventAttribute, 0, len(e.Attributes))
+	for _, attr := range e.Attributes {
+		attrs = append(attrs, abci.EventAttribute{Key: attr.Key, Value: attr.Value})
+	}
+	return e.Type, e.PkgPath, attrs
+}
+
+// EventAttribute is a key/value pair of an Event. Type is only set for
+// events emitted with EmitTyped, and gives the encoding of Value (see
+// DecodeAttrValue). Indexed asks the node's event store to index the
+// attribute, so that events can be searched by its value.
 type EventAttribute struct {
-	Key   string `json:"key"`
-	Value string `json:"value"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Indexed bool   `json:"indexed,omitempty"`
 }
 
 // StorageDepositEvent is emitted when a storage deposit fee is locked.
@@ -123,3 +152,22 @@ type StorageUnlockEvent struct {
 }
 
 func (e StorageUnlockEvent) AssertABCIEvent() {}
+
+// ScheduledCallEvent is emitted by the chain for every call it runs that
+// was scheduled with chain/scheduler.
+type ScheduledCallEvent struct {
+	ID      uint64 `json:"id"`
+	PkgPath string `json:"pkg_path"`
+	Func    string `json:"func"`
+	// Height is the height the call was scheduled for; the call may run
+	// at a later one.
+	Height  int64    `json:"height"`
+	GasUsed int64    `json:"gas_used"`
+	Fee     std.Coin `json:"fee"`
+	Refund  std.Coin `json:"refund"`
+	// Error is empty if the call succeeded; its state changes are
+	// discarded otherwise.
+	Error string `json:"error"`
+}
+
+func (e ScheduledCallEvent) AssertABCIEvent() {}
@@ -0,0 +1,155 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/internal/execctx"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Types of the attributes of an event emitted with EmitTyped. The
+// attributes of an event emitted with Emit have no type, and are strings.
+const (
+	AttrTypeString  = "string"
+	AttrTypeInt     = "int"     // base 10 int64
+	AttrTypeUint    = "uint"    // base 10 uint64
+	AttrTypeBool    = "bool"    // "true" or "false"
+	AttrTypeAddress = "address" // bech32
+	AttrTypeCoins   = "coins"   // as std.ParseCoins
+	AttrTypeObject  = "object"  // JSON array of {"key","type","value"}
+	AttrTypeList    = "list"    // JSON array of {"type","value"}
+)
+
+// MaxEventAttrDepth caps the nesting of object and list attributes.
+const MaxEventAttrDepth = 8
+
+// nestedAttr is the JSON encoding of an object field or a list item. The
+// encoding is produced by chain.ObjectAttr and chain.ListAttr; Key is only
+// set for object fields.
+type nestedAttr struct {
+	Key   *string `json:"key,omitempty"`
+	Type  string  `json:"type"`
+	Value string  `json:"value"`
+}
+
+// Decode returns the typed value of the attribute; see DecodeAttrValue.
+func (a EventAttribute) Decode() (any, error) {
+	return DecodeAttrValue(a.Type, a.Value)
+}
+
+// DecodeAttrValue decodes the value of an event attribute of type typ. It
+// returns a string for an untyped or string attribute, an int64, uint64,
+// bool, crypto.Address, std.Coins, a map[string]any of the decoded fields of
+// an object or a []any of the decoded items of a list.
+func DecodeAttrValue(typ, value string) (any, error) {
+	return decodeAttrValue(typ, value, 0)
+}
+
+func decodeAttrValue(typ, value string, depth int) (any, error) {
+	switch typ {
+	case "", AttrTypeString:
+		return value, nil
+	case AttrTypeInt:
+		return strconv.ParseInt(value, 10, 64)
+	case AttrTypeUint:
+		return strconv.ParseUint(value, 10, 64)
+	case AttrTypeBool:
+		return strconv.ParseBool(value)
+	case AttrTypeAddress:
+		return crypto.AddressFromBech32(value)
+	case AttrTypeCoins:
+		return std.ParseCoins(value)
+	case AttrTypeObject, AttrTypeList:
+		if depth >= MaxEventAttrDepth {
+			return nil, errors.New("attribute nested too deeply")
+		}
+		dec := json.NewDecoder(bytes.NewReader([]byte(value)))
+		dec.DisallowUnknownFields()
+		var items []nestedAttr
+		if err := dec.Decode(&items); err != nil {
+			return nil, fmt.Errorf("invalid %s attribute: %w", typ, err)
+		}
+		if dec.More() {
+			return nil, fmt.Errorf("invalid %s attribute: trailing data", typ)
+		}
+		if typ == AttrTypeList {
+			list := make([]any, len(items))
+			for i, item := range items {
+				if item.Key != nil {
+					return nil, errors.New("list item has a key")
+				}
+				v, err := decodeAttrValue(item.Type, item.Value, depth+1)
+				if err != nil {
+					return nil, err
+				}
+				list[i] = v
+			}
+			return list, nil
+		}
+		obj := make(map[string]any, len(items))
+		for _, item := range items {
+			if item.Key == nil {
+				return nil, errors.New("object field has no key")
+			}
+			if _, dup := obj[*item.Key]; dup {
+				return nil, fmt.Errorf("duplicate object field %q", *item.Key)
+			}
+			v, err := decodeAttrValue(item.Type, item.Value, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			obj[*item.Key] = v
+		}
+		return obj, nil
+	default:
+		return nil, fmt.Errorf("unknown attribute type %q", typ)
+	}
+}
+
+func X_emitTyped(m *gno.Machine, typ string, keys, types, values []string, indexed []bool) {
+	if len(keys) > MaxEventPairs {
+		m.PanicString("event has too many attributes")
+	}
+	if len(typ) > MaxEventAttrLen {
+		m.PanicString("event type is too long")
+	}
+	if len(types) != len(keys) || len(values) != len(keys) || len(indexed) != len(keys) {
+		m.PanicString("mismatched event attribute slices")
+	}
+	eventAttrs := make([]EventAttribute, len(keys))
+	for i, key := range keys {
+		if len(key) > MaxEventAttrLen {
+			m.PanicString("event attribute key is too long")
+		}
+		if len(values[i]) > MaxEventAttrLen {
+			m.PanicString("event attribute value is too long")
+		}
+		// EmitTyped always sets a type: an empty one would make the
+		// attribute indistinguishable from one emitted with Emit.
+		if types[i] == "" {
+			m.PanicString("event attribute " + strconv.Quote(key) + " has no type")
+		}
+		if _, err := DecodeAttrValue(types[i], values[i]); err != nil {
+			m.PanicString("invalid event attribute " + strconv.Quote(key) + ": " + err.Error())
+		}
+		eventAttrs[i] = EventAttribute{
+			Key:     key,
+			Value:   values[i],
+			Type:    types[i],
+			Indexed: indexed[i],
+		}
+	}
+
+	ctx := execctx.GetContext(m)
+	ctx.EventLogger.EmitEvent(Event{
+		Type:       typ,
+		Attributes: eventAttrs,
+		PkgPath:    currentPkgPath(m),
+	})
+}
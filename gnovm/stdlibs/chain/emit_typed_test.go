@@ -0,0 +1,202 @@
+package chain
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/internal/execctx"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeAttrValue(t *testing.T) {
+	t.Parallel()
+	addr := crypto.AddressFromPreimage([]byte("addr"))
+	tests := []struct {
+		name     string
+		typ      string
+		value    string
+		expected any
+		errMsg   string
+	}{
+		{name: "Untyped", typ: "", value: "x", expected: "x"},
+		{name: "String", typ: AttrTypeString, value: "x", expected: "x"},
+		{name: "Int", typ: AttrTypeInt, value: "-42", expected: int64(-42)},
+		{name: "Uint", typ: AttrTypeUint, value: "18446744073709551615", expected: uint64(18446744073709551615)},
+		{name: "Bool", typ: AttrTypeBool, value: "true", expected: true},
+		{name: "Address", typ: AttrTypeAddress, value: addr.String(), expected: addr},
+		{name: "Coins", typ: AttrTypeCoins, value: "10foo,5ugnot", expected: std.NewCoins(std.NewCoin("foo", 10), std.NewCoin("ugnot", 5))},
+		{
+			name:  "Object",
+			typ:   AttrTypeObject,
+			value: `[{"key":"a","type":"int","value":"1"},{"key":"b","type":"list","value":"[{\"type\":\"bool\",\"value\":\"false\"}]"}]`,
+			expected: map[string]any{
+				"a": int64(1),
+				"b": []any{false},
+			},
+		},
+		{name: "EmptyList", typ: AttrTypeList, value: `[]`, expected: []any{}},
+		{name: "BadInt", typ: AttrTypeInt, value: "1.5", errMsg: "invalid syntax"},
+		{name: "BadAddress", typ: AttrTypeAddress, value: "g1xyz", errMsg: "invalid bech32"},
+		{name: "UnknownType", typ: "float", value: "1", errMsg: `unknown attribute type "float"`},
+		{name: "ListItemKey", typ: AttrTypeList, value: `[{"key":"a","type":"int","value":"1"}]`, errMsg: "list item has a key"},
+		{name: "ObjectFieldNoKey", typ: AttrTypeObject, value: `[{"type":"int","value":"1"}]`, errMsg: "object field has no key"},
+		{name: "DuplicateField", typ: AttrTypeObject, value: `[{"key":"a","type":"int","value":"1"},{"key":"a","type":"int","value":"2"}]`, errMsg: `duplicate object field "a"`},
+		{name: "UnknownField", typ: AttrTypeList, value: `[{"type":"int","value":"1","x":1}]`, errMsg: "unknown field"},
+		{name: "TrailingData", typ: AttrTypeList, value: `[] []`, errMsg: "trailing data"},
+		{name: "BadNestedValue", typ: AttrTypeList, value: `[{"type":"uint","value":"-1"}]`, errMsg: "invalid syntax"},
+		{name: "TooDeep", typ: AttrTypeList, value: nestedList(MaxEventAttrDepth + 1), errMsg: "attribute nested too deeply"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			v, err := DecodeAttrValue(tt.typ, tt.value)
+			if tt.errMsg != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, v)
+		})
+	}
+
+	_, err := DecodeAttrValue(AttrTypeList, nestedList(MaxEventAttrDepth))
+	assert.NoError(t, err)
+}
+
+// nestedList returns a list attribute value with depth levels of lists.
+func nestedList(depth int) string {
+	v := `[]`
+	for i := 1; i < depth; i++ {
+		b, _ := json.Marshal([]nestedAttr{{Type: AttrTypeList, Value: v}})
+		v = string(b)
+	}
+	return v
+}
+
+func TestEmitTyped(t *testing.T) {
+	m := gno.NewMachine(pkgPath, nil)
+	pushFuncFrame(m, "main")
+	pushFuncFrame(m, "EmitTyped")
+
+	tests := []struct {
+		name     string
+		keys     []string
+		types    []string
+		values   []string
+		indexed  []bool
+		expected []EventAttribute
+		panicMsg string
+	}{
+		{
+			name:    "Valid",
+			keys:    []string{"amount", "sender"},
+			types:   []string{AttrTypeInt, AttrTypeString},
+			values:  []string{"7", "alice"},
+			indexed: []bool{false, true},
+			expected: []EventAttribute{
+				{Key: "amount", Value: "7", Type: AttrTypeInt},
+				{Key: "sender", Value: "alice", Type: AttrTypeString, Indexed: true},
+			},
+		},
+		{
+			name:     "MissingType",
+			keys:     []string{"k"},
+			types:    []string{""},
+			values:   []string{"v"},
+			indexed:  []bool{false},
+			panicMsg: `event attribute "k" has no type`,
+		},
+		{
+			name:     "InvalidValue",
+			keys:     []string{"k"},
+			types:    []string{AttrTypeBool},
+			values:   []string{"yes"},
+			indexed:  []bool{false},
+			panicMsg: `invalid event attribute "k"`,
+		},
+		{
+			name:     "MismatchedSlices",
+			keys:     []string{"k"},
+			types:    []string{AttrTypeString},
+			values:   []string{"v"},
+			indexed:  nil,
+			panicMsg: "mismatched event attribute slices",
+		},
+		{
+			name:     "ValueTooLong",
+			keys:     []string{"k"},
+			types:    []string{AttrTypeString},
+			values:   []string{strings.Repeat("v", MaxEventAttrLen+1)},
+			indexed:  []bool{false},
+			panicMsg: "event attribute value is too long",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			elgs := sdk.NewEventLogger()
+			m.Context = execctx.ExecContext{EventLogger: elgs}
+
+			if tt.panicMsg != "" {
+				func() {
+					defer func() {
+						ex, ok := recover().(*gno.Exception)
+						require.True(t, ok, "expected a gno exception")
+						assert.Contains(t, ex.Value.Sprint(m), tt.panicMsg)
+					}()
+					X_emitTyped(m, "test", tt.keys, tt.types, tt.values, tt.indexed)
+				}()
+				assert.Empty(t, elgs.Events())
+				return
+			}
+			X_emitTyped(m, "test", tt.keys, tt.types, tt.values, tt.indexed)
+			assert.Equal(t, []sdk.Event{Event{
+				Type:       "test",
+				PkgPath:    pkgPath,
+				Attributes: tt.expected,
+			}}, elgs.Events())
+		})
+	}
+}
+
+func TestEvent_IndexedAttributes(t *testing.T) {
+	ev := Event{
+		Type: "Transfer",
+		Attributes: []EventAttribute{
+			{Key: "from", Value: "alice", Type: AttrTypeString, Indexed: true},
+			{Key: "amount", Value: "7", Type: AttrTypeInt},
+			{Key: "to", Value: "bob", Type: AttrTypeString, Indexed: true},
+		},
+	}
+	typ, attrs := ev.IndexedAttributes()
+	assert.Equal(t, "Transfer", typ)
+	assert.Equal(t, []abci.EventAttribute{
+		{Key: "from", Value: "alice"},
+		{Key: "to", Value: "bob"},
+	}, attrs)
+}
+
+func TestEvent_EventAttributes(t *testing.T) {
+	ev := Event{
+		Type: "Transfer",
+		Attributes: []EventAttribute{
+			{Key: "from", Value: "alice", Type: AttrTypeString, Indexed: true},
+			{Key: "amount", Value: "7", Type: AttrTypeInt},
+		},
+		PkgPath: "gno.land/r/demo/foo",
+	}
+	typ, pkgPath, attrs := ev.EventAttributes()
+	assert.Equal(t, "Transfer", typ)
+	assert.Equal(t, "gno.land/r/demo/foo", pkgPath)
+	assert.Equal(t, []abci.EventAttribute{
+		{Key: "from", Value: "alice"},
+		{Key: "amount", Value: "7"},
+	}, attrs)
+}
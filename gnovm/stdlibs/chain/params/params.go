@@ -13,41 +13,123 @@ import (
 // TODO rename to SetRealmParam*().
 
 func SetString(m *gno.Machine, key, val string) {
-	pk := pkey(m, key)
+	pk := setPkey(m, key)
 	execctx.GetContext(m).Params.SetString(pk, val)
 }
 
 func SetBool(m *gno.Machine, key string, val bool) {
-	pk := pkey(m, key)
+	pk := setPkey(m, key)
 	execctx.GetContext(m).Params.SetBool(pk, val)
 }
 
 func SetInt64(m *gno.Machine, key string, val int64) {
-	pk := pkey(m, key)
+	pk := setPkey(m, key)
 	execctx.GetContext(m).Params.SetInt64(pk, val)
 }
 
 func SetUint64(m *gno.Machine, key string, val uint64) {
-	pk := pkey(m, key)
+	pk := setPkey(m, key)
 	execctx.GetContext(m).Params.SetUint64(pk, val)
 }
 
 func SetBytes(m *gno.Machine, key string, val []byte) {
-	pk := pkey(m, key)
+	pk := setPkey(m, key)
 	execctx.GetContext(m).Params.SetBytes(pk, val)
 }
 
 func SetStrings(m *gno.Machine, key string, val []string) {
-	pk := pkey(m, key)
+	pk := setPkey(m, key)
 	execctx.GetContext(m).Params.SetStrings(pk, val)
 }
 
 func UpdateParamStrings(m *gno.Machine, key string, val []string, add bool) {
-	pk := pkey(m, key)
+	pk := setPkey(m, key)
 	execctx.GetContext(m).Params.UpdateStrings(pk, val, add)
 }
 
+func GetString(m *gno.Machine, key string) (string, bool) {
+	var out string
+	ok := execctx.GetContext(m).Params.GetString(pkey(m, key), &out)
+	return out, ok
+}
+
+func GetBool(m *gno.Machine, key string) (bool, bool) {
+	var out bool
+	ok := execctx.GetContext(m).Params.GetBool(pkey(m, key), &out)
+	return out, ok
+}
+
+func GetInt64(m *gno.Machine, key string) (int64, bool) {
+	var out int64
+	ok := execctx.GetContext(m).Params.GetInt64(pkey(m, key), &out)
+	return out, ok
+}
+
+func GetUint64(m *gno.Machine, key string) (uint64, bool) {
+	var out uint64
+	ok := execctx.GetContext(m).Params.GetUint64(pkey(m, key), &out)
+	return out, ok
+}
+
+func GetBytes(m *gno.Machine, key string) ([]byte, bool) {
+	var out []byte
+	ok := execctx.GetContext(m).Params.GetBytes(pkey(m, key), &out)
+	return out, ok
+}
+
+func GetStrings(m *gno.Machine, key string) ([]string, bool) {
+	var out []string
+	ok := execctx.GetContext(m).Params.GetStrings(pkey(m, key), &out)
+	return out, ok
+}
+
+// GetModule*() read any parameter; unlike the setters they are not
+// limited to the "vm:<realm>:" prefix, since params are public state
+// (they can all be queried over RPC).
+
+func GetModuleString(m *gno.Machine, module, submodule, name string) (string, bool) {
+	var out string
+	ok := execctx.GetContext(m).Params.GetString(mkey(m, module, submodule, name), &out)
+	return out, ok
+}
+
+func GetModuleBool(m *gno.Machine, module, submodule, name string) (bool, bool) {
+	var out bool
+	ok := execctx.GetContext(m).Params.GetBool(mkey(m, module, submodule, name), &out)
+	return out, ok
+}
+
+func GetModuleInt64(m *gno.Machine, module, submodule, name string) (int64, bool) {
+	var out int64
+	ok := execctx.GetContext(m).Params.GetInt64(mkey(m, module, submodule, name), &out)
+	return out, ok
+}
+
+func GetModuleUint64(m *gno.Machine, module, submodule, name string) (uint64, bool) {
+	var out uint64
+	ok := execctx.GetContext(m).Params.GetUint64(mkey(m, module, submodule, name), &out)
+	return out, ok
+}
+
+func GetModuleBytes(m *gno.Machine, module, submodule, name string) ([]byte, bool) {
+	var out []byte
+	ok := execctx.GetContext(m).Params.GetBytes(mkey(m, module, submodule, name), &out)
+	return out, ok
+}
+
+func GetModuleStrings(m *gno.Machine, module, submodule, name string) ([]string, bool) {
+	var out []string
+	ok := execctx.GetContext(m).Params.GetStrings(mkey(m, module, submodule, name), &out)
+	return out, ok
+}
+
 // NOTE: further validation must happen by implementor of ParamsInterface.
+// setPkey is pkey for the setters, which cannot run in a read-only view.
+func setPkey(m *gno.Machine, key string) string {
+	execctx.AssertNotInView(m, "set params")
+	return pkey(m, key)
+}
+
 func pkey(m *gno.Machine, key string) string {
 	if len(key) == 0 {
 		m.PanicString("empty param key")
@@ -58,3 +140,18 @@ func pkey(m *gno.Machine, key string) string {
 	_, rlmPath := execctx.CurrentRealm(m)
 	return fmt.Sprintf("vm:%s:%s", rlmPath, key)
 }
+
+// mkey builds the full "<module>:<submodule>:<name>" key read by the
+// GetModule* functions. None of the parts may be empty or contain ":",
+// so that a key cannot reach outside of the module it names.
+func mkey(m *gno.Machine, module, submodule, name string) string {
+	for _, part := range [...]string{module, submodule, name} {
+		if len(part) == 0 {
+			m.PanicString("empty param key part: " + module + ":" + submodule + ":" + name)
+		}
+		if strings.Contains(part, ":") {
+			m.PanicString("invalid param key part: " + part)
+		}
+	}
+	return module + ":" + submodule + ":" + name
+}
@@ -64,3 +64,11 @@ func typedString(s string) gno.TypedValue {
 	tv.SetString(gno.StringValue(s))
 	return tv
 }
+
+func X_enterView(m *gno.Machine) {
+	m.EnterView()
+}
+
+func IsView(m *gno.Machine) bool {
+	return m.InView
+}
@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/internal/execctx"
+)
+
+func X_schedule(m *gno.Machine, pkgPath string, height int64, fn string, gasLimit int64, args []string) (uint64, string) {
+	execctx.AssertNotInView(m, "schedule calls")
+	id, err := scheduler(m).Schedule(pkgPath, height, fn, args, gasLimit)
+	if err != nil {
+		return 0, err.Error()
+	}
+	return id, ""
+}
+
+func X_cancel(m *gno.Machine, pkgPath string, id uint64) bool {
+	execctx.AssertNotInView(m, "cancel scheduled calls")
+	return scheduler(m).Cancel(pkgPath, id)
+}
+
+func scheduler(m *gno.Machine) execctx.SchedulerInterface {
+	s := execctx.GetContext(m).Scheduler
+	if s == nil {
+		m.PanicString("scheduled calls are not supported in this context")
+	}
+	return s
+}
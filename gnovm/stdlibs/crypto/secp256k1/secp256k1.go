@@ -0,0 +1,64 @@
+package secp256k1
+
+import (
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/secp256k1"
+)
+
+// X_verify uses the tm2 key type itself, so that realms accept exactly the
+// signatures gnokey produces and the ante handler accepts.
+func X_verify(publicKey, message, signature []byte) bool {
+	if len(publicKey) != secp256k1.PubKeySecp256k1Size {
+		return false
+	}
+	var pub secp256k1.PubKeySecp256k1
+	copy(pub[:], publicKey)
+	return pub.VerifyBytes(message, signature)
+}
+
+// X_pubKeyAddress returns ("", errStr) on error; the gno wrapper turns it
+// into an error.
+func X_pubKeyAddress(publicKey []byte) (addr string, errStr string) {
+	if len(publicKey) != secp256k1.PubKeySecp256k1Size {
+		return "", "secp256k1: invalid public key length"
+	}
+	if _, err := btcec.ParsePubKey(publicKey); err != nil {
+		return "", "secp256k1: invalid public key: " + err.Error()
+	}
+	var pub secp256k1.PubKeySecp256k1
+	copy(pub[:], publicKey)
+	return pub.Address().String(), ""
+}
+
+// X_recoverPubKey returns (nil, errStr) on error; the gno wrapper turns it
+// into an error.
+func X_recoverPubKey(message, signature []byte) (pub []byte, errStr string) {
+	if len(signature) != 65 {
+		return nil, "secp256k1: invalid signature length"
+	}
+	v := signature[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return nil, "secp256k1: invalid recovery id"
+	}
+	// Reject malleable signatures, as Verify does.
+	var s btcec.ModNScalar
+	if s.SetByteSlice(signature[32:64]) || s.IsOverHalfOrder() {
+		return nil, "secp256k1: invalid signature"
+	}
+	// RecoverCompact takes the signature as V || R || S, where V is 27 plus
+	// the recovery id, plus 4 to ask for a compressed key.
+	compact := make([]byte, 65)
+	compact[0] = 27 + 4 + v
+	copy(compact[1:], signature[:64])
+	key, _, err := ecdsa.RecoverCompact(compact, crypto.Sha256(message))
+	if err != nil {
+		return nil, "secp256k1: " + err.Error()
+	}
+	return key.SerializeCompressed(), ""
+}
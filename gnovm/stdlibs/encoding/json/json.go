@@ -0,0 +1,57 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// The natives return errors as the fields of a *gno.JSONError, which
+// newError in errors.gno turns back into the matching error type. kind is
+// zero when there is no error.
+//
+// Returned slices are clipped to their length: a native slice result keeps
+// its capacity, and append in Gno expects the spare capacity to be
+// addressable.
+
+func X_marshal(m *gno.Machine, v gno.TypedValue, escapeHTML bool) (data []byte, kind int, msg, value, typ string) {
+	data, err := m.EncodeJSON(v, escapeHTML)
+	if err != nil {
+		je := err.(*gno.JSONError)
+		return nil, int(je.Kind), je.Msg, je.Value, je.Type
+	}
+	return data[:len(data):len(data)], 0, "", "", ""
+}
+
+func X_unmarshal(m *gno.Machine, data []byte, v gno.TypedValue) (kind int, msg, value, typ, strct, field string, offset int64) {
+	err := m.DecodeJSON(data, v)
+	if err != nil {
+		je := err.(*gno.JSONError)
+		return int(je.Kind), je.Msg, je.Value, je.Type, je.Struct, je.Field, je.Offset
+	}
+	return 0, "", "", "", "", "", 0
+}
+
+func X_indentJSON(m *gno.Machine, src []byte, prefix, indent string) (dst []byte, msg string, offset int64) {
+	dst, err := m.IndentJSON(src, prefix, indent)
+	if err != nil {
+		je := err.(*gno.JSONError)
+		return nil, je.Msg, je.Offset
+	}
+	return dst[:len(dst):len(dst)], "", 0
+}
+
+func X_compact(src []byte) (dst []byte, msg string, offset int64) {
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, src); err != nil {
+		serr := err.(*json.SyntaxError)
+		return nil, serr.Error(), serr.Offset
+	}
+	dst = buf.Bytes()
+	return dst[:len(dst):len(dst)], "", 0
+}
+
+func X_valid(data []byte) bool {
+	return json.Valid(data)
+}
@@ -37,6 +37,18 @@ type ParamsInterface interface {
 	GetStrings(key string, ptr *[]string) bool
 }
 
+// SchedulerInterface registers calls that the chain runs on behalf of a
+// realm at a later block height. Implementations validate the call against
+// chain state (the function must exist and be crossing) and escrow the
+// realm's prepaid gas deposit; they return an error rather than panic for
+// anything the calling realm got wrong.
+type SchedulerInterface interface {
+	Schedule(pkgPath string, height int64, fn string, args []string, gasLimit int64) (id uint64, err error)
+	// Cancel removes a pending call of the realm at pkgPath and refunds
+	// its deposit. It returns false if there is no such call.
+	Cancel(pkgPath string, id uint64) bool
+}
+
 type ExecContext struct {
 	ChainID         string
 	ChainDomain     string
@@ -48,6 +60,7 @@ type ExecContext struct {
 	OriginSendSpent *std.Coins // mutable
 	Banker          BankerInterface
 	Params          ParamsInterface
+	Scheduler       SchedulerInterface // nil if the chain cannot schedule calls
 	EventLogger     *sdk.EventLogger
 	SessionAccount  std.DelegatedAccount // nil for master-key txs
 }
@@ -89,3 +102,12 @@ func init() {
 		return ""
 	}
 }
+
+// AssertNotInView panics if m runs in a read-only view (see
+// chain/runtime.View): natives which modify chain state call it first, as
+// the VM only guards the objects of realms.
+func AssertNotInView(m *gno.Machine, action string) {
+	if m.InView {
+		m.PanicString("cannot " + action + " in a read-only view")
+	}
+}
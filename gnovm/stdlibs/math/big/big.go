@@ -0,0 +1,228 @@
+package big
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Ints cross the native boundary as a sign and a big-endian magnitude
+// (see the Int type in big.gno); Rats as a signed numerator magnitude and
+// a denominator magnitude, where an empty denominator means 1.
+
+func toInt(neg bool, abs []byte) *big.Int {
+	z := new(big.Int).SetBytes(abs)
+	if neg {
+		z.Neg(z)
+	}
+	return z
+}
+
+func fromInt(z *big.Int) (bool, []byte) {
+	return z.Sign() < 0, z.Bytes()
+}
+
+func toRat(neg bool, a, b []byte) *big.Rat {
+	num := toInt(neg, a)
+	if len(b) == 0 {
+		return new(big.Rat).SetInt(num)
+	}
+	return new(big.Rat).SetFrac(num, new(big.Int).SetBytes(b))
+}
+
+func fromRat(x *big.Rat) (bool, []byte, []byte) {
+	var b []byte
+	if !x.IsInt() {
+		b = x.Denom().Bytes()
+	}
+	return x.Sign() < 0, x.Num().Bytes(), b
+}
+
+func X_intAdd(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Add(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intSub(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Sub(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intMul(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Mul(toInt(xneg, x), toInt(yneg, y)))
+}
+
+// The division natives expect a non-zero y; the callers in big.gno panic
+// with "division by zero" first, as Go does.
+
+func X_intQuo(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Quo(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intRem(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Rem(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intDiv(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Div(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intMod(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Mod(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intAnd(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).And(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intAndNot(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).AndNot(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intOr(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Or(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intXor(xneg bool, x []byte, yneg bool, y []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Xor(toInt(xneg, x), toInt(yneg, y)))
+}
+
+func X_intNot(xneg bool, x []byte) (bool, []byte) {
+	return fromInt(new(big.Int).Not(toInt(xneg, x)))
+}
+
+func X_intLsh(xneg bool, x []byte, n uint) (bool, []byte) {
+	return fromInt(new(big.Int).Lsh(toInt(xneg, x), n))
+}
+
+func X_intRsh(xneg bool, x []byte, n uint) (bool, []byte) {
+	return fromInt(new(big.Int).Rsh(toInt(xneg, x), n))
+}
+
+func X_intBit(xneg bool, x []byte, i int) uint {
+	return toInt(xneg, x).Bit(i)
+}
+
+func X_intSetBit(xneg bool, x []byte, i int, b uint) (bool, []byte) {
+	return fromInt(new(big.Int).SetBit(toInt(xneg, x), i, b))
+}
+
+func X_intCmpAbs(x, y []byte) int {
+	return new(big.Int).SetBytes(x).Cmp(new(big.Int).SetBytes(y))
+}
+
+// X_intExp returns ok == false where big.Int.Exp returns nil: a negative
+// y whose inverse modulo m does not exist.
+func X_intExp(xneg bool, x []byte, yneg bool, y []byte, mneg bool, m []byte) (neg bool, z []byte, ok bool) {
+	r := new(big.Int).Exp(toInt(xneg, x), toInt(yneg, y), toInt(mneg, m))
+	if r == nil {
+		return false, nil, false
+	}
+	neg, z = fromInt(r)
+	return neg, z, true
+}
+
+func X_intSqrt(x []byte) []byte {
+	return new(big.Int).Sqrt(new(big.Int).SetBytes(x)).Bytes()
+}
+
+// X_intGCD returns the gcd d of a and b along with the cofactors x and y
+// such that d = a*x + b*y.
+func X_intGCD(aneg bool, a []byte, bneg bool, b []byte) (d []byte, xneg bool, x []byte, yneg bool, y []byte) {
+	var bx, by big.Int
+	bd := new(big.Int).GCD(&bx, &by, toInt(aneg, a), toInt(bneg, b))
+	xneg, x = fromInt(&bx)
+	yneg, y = fromInt(&by)
+	return bd.Bytes(), xneg, x, yneg, y
+}
+
+func X_intModInverse(gneg bool, g []byte, nneg bool, n []byte) (neg bool, z []byte, ok bool) {
+	r := new(big.Int).ModInverse(toInt(gneg, g), toInt(nneg, n))
+	if r == nil {
+		return false, nil, false
+	}
+	neg, z = fromInt(r)
+	return neg, z, true
+}
+
+// X_intProbablyPrime is deterministic: the Miller-Rabin bases big.Int
+// picks are seeded from x itself.
+func X_intProbablyPrime(x []byte, n int) bool {
+	return new(big.Int).SetBytes(x).ProbablyPrime(n)
+}
+
+func X_intText(xneg bool, x []byte, base int) string {
+	return toInt(xneg, x).Text(base)
+}
+
+func X_intSetString(s string, base int) (neg bool, z []byte, ok bool) {
+	r, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return false, nil, false
+	}
+	neg, z = fromInt(r)
+	return neg, z, true
+}
+
+// X_ratNorm reduces the fraction a/b to lowest terms; b must be non-zero.
+func X_ratNorm(neg bool, a, b []byte) (bool, []byte, []byte) {
+	return fromRat(toRat(neg, a, b))
+}
+
+// maxBitLen mirrors MaxBitLen in big.gno.
+const maxBitLen = 1 << 20
+
+func X_ratSetString(s string) (neg bool, a, b []byte, ok bool) {
+	if ratExponentTooLarge(s) {
+		return false, nil, nil, false
+	}
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return false, nil, nil, false
+	}
+	neg, a, b = fromRat(r)
+	return neg, a, b, true
+}
+
+func X_ratSetFloat64(f float64) (neg bool, a, b []byte, ok bool) {
+	r := new(big.Rat).SetFloat64(f)
+	if r == nil {
+		return false, nil, nil, false
+	}
+	neg, a, b = fromRat(r)
+	return neg, a, b, true
+}
+
+func X_ratFloat64(neg bool, a, b []byte) (float64, bool) {
+	return toRat(neg, a, b).Float64()
+}
+
+// ratExponentTooLarge reports whether s carries an exponent that could
+// make the value exceed maxBitLen bits. big.Rat.SetString accepts decimal
+// exponents up to 1e6 and binary ones up to 1e7, and computes the result
+// before returning it, so such inputs are rejected upfront instead.
+func ratExponentTooLarge(s string) bool {
+	if strings.IndexByte(s, '/') >= 0 {
+		return false // fractions take no exponent
+	}
+	exps := "eEpP"
+	if m := strings.TrimLeft(s, "+-"); strings.HasPrefix(m, "0x") || strings.HasPrefix(m, "0X") {
+		exps = "pP" // e and E are hexadecimal digits
+	}
+	i := strings.LastIndexAny(s, exps)
+	if i < 0 {
+		return false
+	}
+	exp, err := strconv.ParseInt(strings.ReplaceAll(s[i+1:], "_", ""), 10, 64)
+	if err != nil {
+		// Malformed exponents are rejected by SetString; out of range ones
+		// are larger than it accepts anyway.
+		return errors.Is(err, strconv.ErrRange)
+	}
+	if exp < 0 {
+		exp = -exp
+	}
+	if s[i] == 'e' || s[i] == 'E' {
+		exp = exp * 10 / 3 // log2(10) < 10/3
+	}
+	return exp > maxBitLen
+}
@@ -49,6 +49,13 @@ type nativeGasEntry struct {
 	Slope2Idx  int8
 	Slope2Kind gno.NativeGasSize
 
+	// Optional pre-call slope on the product of two parameter sizes, for
+	// natives whose work is quadratic in their operands.
+	ProdSlope int64
+	ProdIdx   int8
+	ProdIdx2  int8
+	ProdKind  gno.NativeGasSize
+
 	// Post-call charge (zero = none). PostSlopeIdx is the stack offset
 	// from top (1 = last-pushed return); PostSlopeKind must be a
 	// SizeReturn* kind (or SizeSliceTotalBytes for slice-of-string).
@@ -76,13 +83,12 @@ type nativeGasEntry struct {
 // today, so the table stays single-slope; the schema fields support
 // future natives that genuinely scale on both dimensions.
 //
-// 66 entries — exhaustive coverage of gnovm/stdlibs/generated.go.
-// The trailing 10 IBC-crypto entries (crypto/bn254, crypto/cometbls,
-// crypto/keccak256, crypto/merkle, crypto/modexp) are draft fits measured
-// on Intel Xeon Silver 4114; the chain/markdown rows and the rest are on
-// Apple M2. The whole table must be regenerated on the reference Xeon 8168
-// before any consensus-relevant deployment; the IBC rows are flagged
-// "draft" in their trailing comment to make that obvious.
+// 119 entries — exhaustive coverage of gnovm/stdlibs/generated.go.
+//
+// Rows under a "--- pkg (draft, machine) ---" marker are draft fits
+// measured on that machine rather than the M2, and are also flagged
+// "draft" in their trailing comment. The whole table must be regenerated
+// on the reference Xeon 8168 before any consensus-relevant deployment.
 var calibratedNativeGas = []nativeGasEntry{
 	{Pkg: "crypto/sha256", Fn: "sum256", Base: 226, Slope: 8906, SlopeIdx: 0, SlopeKind: SizeLenBytes},                                                         // fit base=226.3ns slope=8.6969ns/N (=8906/1024) R²=1.000
 	{Pkg: "crypto/ed25519", Fn: "verify", Base: 56534, Slope: 8975, SlopeIdx: 1, SlopeKind: SizeLenBytes},                                                      // fit base=56534.0ns slope=8.7645ns/N (=8975/1024) R²=0.991
@@ -131,6 +137,20 @@ var calibratedNativeGas = []nativeGasEntry{
 	{Pkg: "sys/params", Fn: "setSysParamStrings", Base: 341, Slope: 27034, SlopeIdx: 3, SlopeKind: SizeLenSlice},                                                 // fit base=341.0ns slope=26.4006ns/N (=27034/1024) R²=0.997
 	{Pkg: "sys/params", Fn: "updateSysParamStrings", Base: 413, Slope: 26861, SlopeIdx: 3, SlopeKind: SizeLenSlice},                                              // fit base=413.4ns slope=26.2318ns/N (=26861/1024) R²=0.998
 	{Pkg: "sys/params", Fn: "getSysParamStrings", Base: 349, SlopeIdx: -1, SlopeKind: SizeFlat, PostSlope: 23215, PostSlopeIdx: 2, PostSlopeKind: SizeReturnLen}, // post-call: base=348.9ns + 22.6713ns/N (=23215/1024) R²=0.999
+	// chain/params getters — same keeper reads as the sys/params getters,
+	// without the realm gate.
+	{Pkg: "chain/params", Fn: "GetString", Base: 363, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                         // mirrors sys/params.getSysParamString
+	{Pkg: "chain/params", Fn: "GetBool", Base: 236, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                           // mirrors sys/params.getSysParamBool
+	{Pkg: "chain/params", Fn: "GetInt64", Base: 323, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                          // mirrors sys/params.getSysParamInt64
+	{Pkg: "chain/params", Fn: "GetUint64", Base: 309, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                         // mirrors sys/params.getSysParamUint64
+	{Pkg: "chain/params", Fn: "GetBytes", Base: 416, SlopeIdx: -1, SlopeKind: SizeFlat, PostSlope: 10584, PostSlopeIdx: 2, PostSlopeKind: SizeReturnLen},         // mirrors sys/params.getSysParamBytes
+	{Pkg: "chain/params", Fn: "GetStrings", Base: 349, SlopeIdx: -1, SlopeKind: SizeFlat, PostSlope: 23215, PostSlopeIdx: 2, PostSlopeKind: SizeReturnLen},       // mirrors sys/params.getSysParamStrings
+	{Pkg: "chain/params", Fn: "GetModuleString", Base: 363, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                   // mirrors sys/params.getSysParamString
+	{Pkg: "chain/params", Fn: "GetModuleBool", Base: 236, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                     // mirrors sys/params.getSysParamBool
+	{Pkg: "chain/params", Fn: "GetModuleInt64", Base: 323, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                    // mirrors sys/params.getSysParamInt64
+	{Pkg: "chain/params", Fn: "GetModuleUint64", Base: 309, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                   // mirrors sys/params.getSysParamUint64
+	{Pkg: "chain/params", Fn: "GetModuleBytes", Base: 416, SlopeIdx: -1, SlopeKind: SizeFlat, PostSlope: 10584, PostSlopeIdx: 2, PostSlopeKind: SizeReturnLen},   // mirrors sys/params.getSysParamBytes
+	{Pkg: "chain/params", Fn: "GetModuleStrings", Base: 349, SlopeIdx: -1, SlopeKind: SizeFlat, PostSlope: 23215, PostSlopeIdx: 2, PostSlopeKind: SizeReturnLen}, // mirrors sys/params.getSysParamStrings
 	// chain/markdown — calibrated from gnovm/cmd/calibrate (M2 ARM64 baseline,
 	// same as the other rows above). Same Xeon 8168 re-calibration caveat
 	// applies; values are stable across re-runs (R² ≥ 0.994 for all eight).
@@ -161,6 +181,80 @@ var calibratedNativeGas = []nativeGasEntry{
 	// smaller inputs / undercharges very large inputs. Re-check before
 	// allowing >256-byte modulus in production realms.
 	{Pkg: "crypto/modexp", Fn: "modExp", Base: 58000, Slope: 24647680, SlopeIdx: 2, SlopeKind: SizeLenBytes}, // draft, calibrated against N=256-byte modulus (cubic underlying, see comment)
+	// --- math/big (draft, Intel Xeon) ---
+	// Two-operand rows are benched with operands of equal size and the fitted
+	// slope is split between Slope and Slope2. Mul, the divisions, GCD,
+	// ModInverse and ratNorm are superlinear; their linear fit over 8..512-byte
+	// operands is topped up with ProdSlope on len(x)*len(y), the largest
+	// leftover of the BigIntProd benches (1 KiB up to MaxBitLen-sized operands,
+	// see calibrate/bigint_prod_bench_output.txt). ModInverse needs none. Sqrt
+	// is superlinear too; re-check it before relying on it for multi-kilobyte
+	// values.
+	{Pkg: "math/big", Fn: "intAdd", Base: 3069, Slope: 53183, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 53183, Slope2Idx: 3, Slope2Kind: SizeLenBytes},                                                                   // draft fit base=3069ns slope=103.9ns/N on both operands, split evenly
+	{Pkg: "math/big", Fn: "intSub", Base: 3091, Slope: 63294, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 63294, Slope2Idx: 3, Slope2Kind: SizeLenBytes},                                                                   // draft fit base=3091ns slope=123.6ns/N, split evenly
+	{Pkg: "math/big", Fn: "intMul", Base: 3682, Slope: 89165, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 89165, Slope2Idx: 3, Slope2Kind: SizeLenBytes, ProdSlope: 1, ProdIdx: 1, ProdIdx2: 3, ProdKind: SizeLenBytes},    // draft fit base=3682ns slope=174.2ns/N on 8..512 bytes, split evenly; product: max leftover 0.75ns per 1024 N² (at 16 KiB), rounded up
+	{Pkg: "math/big", Fn: "intQuo", Base: 2455, Slope: 60601, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 60601, Slope2Idx: 3, Slope2Kind: SizeLenBytes, ProdSlope: 1, ProdIdx: 1, ProdIdx2: 3, ProdKind: SizeLenBytes},    // draft fit base=2455ns slope=88.8ns/N with len(y)=N/2, charged 2/3 on each operand; product: max leftover 0.90ns per 1024 N² (at 32 KiB), rounded up
+	{Pkg: "math/big", Fn: "intRem", Base: 2073, Slope: 54607, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 54607, Slope2Idx: 3, Slope2Kind: SizeLenBytes, ProdSlope: 4, ProdIdx: 1, ProdIdx2: 3, ProdKind: SizeLenBytes},    // draft fit base=2073ns slope=80.0ns/N with len(y)=N/2, charged 2/3 on each operand; product: max leftover 3.09ns per 1024 N² (at 8 KiB), rounded up
+	{Pkg: "math/big", Fn: "intDiv", Base: 2538, Slope: 61018, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 61018, Slope2Idx: 3, Slope2Kind: SizeLenBytes, ProdSlope: 2, ProdIdx: 1, ProdIdx2: 3, ProdKind: SizeLenBytes},    // draft fit base=2538ns slope=89.4ns/N with len(y)=N/2, charged 2/3 on each operand; product: max leftover 1.13ns per 1024 N² (at 8 KiB), rounded up
+	{Pkg: "math/big", Fn: "intMod", Base: 2640, Slope: 48107, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 48107, Slope2Idx: 3, Slope2Kind: SizeLenBytes, ProdSlope: 6, ProdIdx: 1, ProdIdx2: 3, ProdKind: SizeLenBytes},    // draft fit base=2640ns slope=70.5ns/N with len(y)=N/2, charged 2/3 on each operand; product: max leftover 5.96ns per 1024 N² (at 8 KiB), rounded up
+	{Pkg: "math/big", Fn: "intAnd", Base: 2884, Slope: 50885, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 50885, Slope2Idx: 3, Slope2Kind: SizeLenBytes},                                                                   // draft fit base=2884ns slope=99.4ns/N, split evenly
+	{Pkg: "math/big", Fn: "intAndNot", Base: 2729, Slope: 48881, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 48881, Slope2Idx: 3, Slope2Kind: SizeLenBytes},                                                                // draft fit base=2729ns slope=95.5ns/N, split evenly
+	{Pkg: "math/big", Fn: "intOr", Base: 2366, Slope: 58106, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 58106, Slope2Idx: 3, Slope2Kind: SizeLenBytes},                                                                    // draft fit base=2366ns slope=113.5ns/N, split evenly
+	{Pkg: "math/big", Fn: "intXor", Base: 2894, Slope: 71524, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 71524, Slope2Idx: 3, Slope2Kind: SizeLenBytes},                                                                   // draft fit base=2894ns slope=139.7ns/N, split evenly
+	{Pkg: "math/big", Fn: "intNot", Base: 2127, Slope: 98783, SlopeIdx: 1, SlopeKind: SizeLenBytes},                                                                                                                          // draft fit base=2127ns slope=96.5ns/N
+	{Pkg: "math/big", Fn: "intLsh", Base: 3095, SlopeIdx: -1, SlopeKind: SizeFlat, PostSlope: 98329, PostSlopeIdx: 1, PostSlopeKind: SizeReturnLen},                                                                          // draft post-call: fit base=3095ns slope=96.0ns/N on the result
+	{Pkg: "math/big", Fn: "intRsh", Base: 2399, Slope: 95038, SlopeIdx: 1, SlopeKind: SizeLenBytes},                                                                                                                          // draft fit base=2399ns slope=92.8ns/N
+	{Pkg: "math/big", Fn: "intBit", Base: 880, Slope: 31086, SlopeIdx: 1, SlopeKind: SizeLenBytes},                                                                                                                           // draft fit base=880ns slope=30.4ns/N
+	{Pkg: "math/big", Fn: "intSetBit", Base: 3397, SlopeIdx: -1, SlopeKind: SizeFlat, PostSlope: 88677, PostSlopeIdx: 1, PostSlopeKind: SizeReturnLen},                                                                       // draft post-call: fit base=3397ns slope=86.6ns/N on the result
+	{Pkg: "math/big", Fn: "intCmpAbs", Base: 1181, Slope: 30986, SlopeIdx: 0, SlopeKind: SizeLenBytes, Slope2: 30986, Slope2Idx: 1, Slope2Kind: SizeLenBytes},                                                                // draft fit base=1181ns slope=60.5ns/N, split evenly
+	{Pkg: "math/big", Fn: "intSqrt", Base: 2452, Slope: 164160, SlopeIdx: 0, SlopeKind: SizeLenBytes},                                                                                                                        // draft fit base=2452ns slope=160.3ns/N on 8..512 bytes
+	{Pkg: "math/big", Fn: "intText", Base: 1559, Slope: 84877, SlopeIdx: 1, SlopeKind: SizeLenBytes},                                                                                                                         // draft fit base=1559ns slope=82.9ns/N (base 10)
+	{Pkg: "math/big", Fn: "intSetString", Base: 2095, Slope: 44284, SlopeIdx: 0, SlopeKind: SizeLenString},                                                                                                                   // draft fit base=2095ns slope=43.2ns/N (base 10)
+	{Pkg: "math/big", Fn: "intGCD", Base: 5623, Slope: 171611, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 171611, Slope2Idx: 3, Slope2Kind: SizeLenBytes, ProdSlope: 26, ProdIdx: 1, ProdIdx2: 3, ProdKind: SizeLenBytes}, // draft fit base=5623ns slope=335.2ns/N on 8..512 bytes, split evenly; product: max leftover 25.1ns per 1024 N² (at 1 KiB), rounded up
+	{Pkg: "math/big", Fn: "intModInverse", Base: 3801, Slope: 122412, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 122412, Slope2Idx: 3, Slope2Kind: SizeLenBytes},                                                          // draft fit base=3801ns slope=239.1ns/N on 8..512 bytes, split evenly; no product term, the linear charge covers up to 128 KiB
+	{Pkg: "math/big", Fn: "ratNorm", Base: 4382, Slope: 122667, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 122667, Slope2Idx: 2, Slope2Kind: SizeLenBytes, ProdSlope: 3, ProdIdx: 1, ProdIdx2: 2, ProdKind: SizeLenBytes}, // draft fit base=4382ns slope=239.6ns/N, split evenly; product: max leftover 2.07ns per 1024 N² (at 64 KiB), rounded up
+	{Pkg: "math/big", Fn: "ratFloat64", Base: 2586, Slope: 71665, SlopeIdx: 1, SlopeKind: SizeLenBytes, Slope2: 71665, Slope2Idx: 2, Slope2Kind: SizeLenBytes},                                                               // draft fit base=2586ns slope=140.0ns/N, split evenly
+	{Pkg: "math/big", Fn: "ratSetFloat64", Base: 3355, SlopeIdx: -1, SlopeKind: SizeFlat},                                                                                                                                    // draft flat, median 3355ns
+	// ratSetString is charged on its input and on the numerator and denominator
+	// it returns, which an exponent can make much longer than the input.
+	{Pkg: "math/big", Fn: "ratSetString", Base: 3597, Slope: 44284, SlopeIdx: 0, SlopeKind: SizeLenString, PostSlope: 139400, PostSlopeIdx: 3, PostSlopeKind: SizeReturnLen, PostSlope2: 139400, PostSlope2Idx: 2, PostSlope2Kind: SizeReturnLen}, // draft, post-call slope calibrated at the largest exponent big.go accepts (1.5e262144, 14.8ms)
+	// Like crypto/modexp, intExp with a modulus costs O(len(y)·len(m)²), and
+	// m is at most 256 bytes (MaxModBitLen). Slope is fit so the charge
+	// matches measured cost with a 256-byte y and m (~6.06ms), which
+	// overcharges smaller moduli; Slope2 only covers converting m. Without a
+	// modulus, the last squaring dominates and the post-call slope is
+	// calibrated on a MaxBitLen result (~21.3ms).
+	{Pkg: "math/big", Fn: "intExp", Base: 10105, Slope: 24216432, SlopeIdx: 3, SlopeKind: SizeLenBytes, Slope2: 98783, Slope2Idx: 5, Slope2Kind: SizeLenBytes, PostSlope: 166100, PostSlopeIdx: 2, PostSlopeKind: SizeReturnLen}, // draft (cubic underlying, see comment)
+	// intProbablyPrime runs at most 20 Miller-Rabin rounds (maxPrimeRounds in
+	// int.gno) on at most 256 bytes; the slope is fit so the charge matches a
+	// 256-byte prime at 20 rounds (~176ms).
+	{Pkg: "math/big", Fn: "intProbablyPrime", Base: 126290, Slope: 703812192, SlopeIdx: 0, SlopeKind: SizeLenBytes}, // draft (cubic underlying, see comment)
+	// --- crypto/secp256k1 (draft, Intel Xeon) ---
+	{Pkg: "crypto/secp256k1", Fn: "verify", Base: 258183, Slope: 20151, SlopeIdx: 1, SlopeKind: SizeLenBytes},        // draft fit base=258183ns slope=19.68ns/N (=20151/1024) R²=0.974 on 64..16384 bytes
+	{Pkg: "crypto/secp256k1", Fn: "pubKeyAddress", Base: 21690, SlopeIdx: -1, SlopeKind: SizeFlat},                   // draft, median 21690ns (point decompression dominates)
+	{Pkg: "crypto/secp256k1", Fn: "recoverPubKey", Base: 282298, Slope: 18907, SlopeIdx: 0, SlopeKind: SizeLenBytes}, // draft fit base=282298ns slope=18.46ns/N (=18907/1024) R²=0.962 on 64..16384 bytes
+	// --- encoding/json (draft, Intel Xeon) ---
+	// marshal, unmarshal and indentJSON charge the machine per value and per
+	// byte as they go (gno.OpCPUJSONValue, gno.OpCPUSlopeJSONByte); their
+	// rows cover the call, benched as marshal on a bool, and the conversion
+	// of their []byte arguments and results, at the slope fit on valid.
+	{Pkg: "encoding/json", Fn: "marshal", Base: 1338, SlopeIdx: -1, SlopeKind: SizeFlat, PostSlope: 30770, PostSlopeIdx: 5, PostSlopeKind: SizeReturnLen},                     // draft, median 1338ns + valid's slope on the result
+	{Pkg: "encoding/json", Fn: "unmarshal", Base: 1338, Slope: 30770, SlopeIdx: 0, SlopeKind: SizeLenBytes},                                                                   // draft, median 1338ns + valid's slope on data
+	{Pkg: "encoding/json", Fn: "indentJSON", Base: 1338, Slope: 30770, SlopeIdx: 0, SlopeKind: SizeLenBytes, PostSlope: 30770, PostSlopeIdx: 3, PostSlopeKind: SizeReturnLen}, // draft, median 1338ns + valid's slope on src and the result
+	{Pkg: "encoding/json", Fn: "compact", Base: 1535, Slope: 70731, SlopeIdx: 0, SlopeKind: SizeLenBytes},                                                                     // draft fit slope=69.07ns/N (=70731/1024) R²=0.998 on 64..16384 bytes, base from N=64
+	{Pkg: "encoding/json", Fn: "valid", Base: 463, Slope: 30770, SlopeIdx: 0, SlopeKind: SizeLenBytes},                                                                        // draft fit slope=30.05ns/N (=30770/1024) R²=1.000 on 64..16384 bytes, base from N=64
+	// --- chain/scheduler (draft, Intel Xeon) ---
+	// The keeper's store writes and deposit transfer are metered by the
+	// store; these rows are the native call overhead only.
+	{Pkg: "chain/scheduler", Fn: "schedule", Base: 901, Slope: 92762, SlopeIdx: 4, SlopeKind: SizeLenSlice}, // draft fit base=900.6ns slope=90.5881ns/N (=92762/1024) R²=1.000
+	{Pkg: "chain/scheduler", Fn: "cancel", Base: 245, SlopeIdx: -1, SlopeKind: SizeFlat},                    // draft, median 245.2ns
+	// --- chain emitTyped (draft, Intel Xeon) ---
+	// Dominated by decoding each value to validate it: list and object
+	// values go through encoding/json, hence the large per-attribute slope.
+	{Pkg: "chain", Fn: "emitTyped", Base: 508, Slope: 3187958, SlopeIdx: 1, SlopeKind: SizeLenSlice, Slope2: 7571, Slope2Idx: 3, Slope2Kind: SizeSliceTotalBytes}, // draft fit slope=3113.24ns/attr (=3187958/1024) slope2=7.394ns/byte (=7571/1024) R²=0.997, base from the 1_1 point
+	// --- chain/runtime views (draft, Intel Xeon) ---
+	{Pkg: "chain/runtime", Fn: "IsView", Base: 44, SlopeIdx: -1, SlopeKind: SizeFlat},   // draft, median 43.7ns
+	{Pkg: "chain/runtime", Fn: "enterView", Base: 5, SlopeIdx: -1, SlopeKind: SizeFlat}, // draft, median 5.3ns
 }
 
 func init() {
@@ -173,6 +267,10 @@ func init() {
 			Slope2:         e.Slope2,
 			Slope2Idx:      e.Slope2Idx,
 			Slope2Kind:     e.Slope2Kind,
+			ProdSlope:      e.ProdSlope,
+			ProdIdx:        e.ProdIdx,
+			ProdIdx2:       e.ProdIdx2,
+			ProdKind:       e.ProdKind,
 			PostBase:       e.PostBase,
 			PostSlope:      e.PostSlope,
 			PostSlopeIdx:   e.PostSlopeIdx,
@@ -18,8 +18,9 @@ type (
 	// interface. This can be obtained by embedding [ExecContext].
 	ExecContexter = execctx.ExecContexter
 
-	BankerInterface = execctx.BankerInterface
-	ParamsInterface = execctx.ParamsInterface
+	BankerInterface    = execctx.BankerInterface
+	ParamsInterface    = execctx.ParamsInterface
+	SchedulerInterface = execctx.SchedulerInterface
 )
 
 // GetContext returns the execution context.
@@ -9,42 +9,49 @@ import (
 
 func X_setSysParamString(m *gno.Machine, module, submodule, name, val string) {
 	assertSysParamsRealm(m)
+	execctx.AssertNotInView(m, "set params")
 	pk := prmkey(module, submodule, name)
 	execctx.GetContext(m).Params.SetString(pk, val)
 }
 
 func X_setSysParamBool(m *gno.Machine, module, submodule, name string, val bool) {
 	assertSysParamsRealm(m)
+	execctx.AssertNotInView(m, "set params")
 	pk := prmkey(module, submodule, name)
 	execctx.GetContext(m).Params.SetBool(pk, val)
 }
 
 func X_setSysParamInt64(m *gno.Machine, module, submodule, name string, val int64) {
 	assertSysParamsRealm(m)
+	execctx.AssertNotInView(m, "set params")
 	pk := prmkey(module, submodule, name)
 	execctx.GetContext(m).Params.SetInt64(pk, val)
 }
 
 func X_setSysParamUint64(m *gno.Machine, module, submodule, name string, val uint64) {
 	assertSysParamsRealm(m)
+	execctx.AssertNotInView(m, "set params")
 	pk := prmkey(module, submodule, name)
 	execctx.GetContext(m).Params.SetUint64(pk, val)
 }
 
 func X_setSysParamBytes(m *gno.Machine, module, submodule, name string, val []byte) {
 	assertSysParamsRealm(m)
+	execctx.AssertNotInView(m, "set params")
 	pk := prmkey(module, submodule, name)
 	execctx.GetContext(m).Params.SetBytes(pk, val)
 }
 
 func X_setSysParamStrings(m *gno.Machine, module, submodule, name string, val []string) {
 	assertSysParamsRealm(m)
+	execctx.AssertNotInView(m, "set params")
 	pk := prmkey(module, submodule, name)
 	execctx.GetContext(m).Params.SetStrings(pk, val)
 }
 
 func X_updateSysParamStrings(m *gno.Machine, module, submodule, name string, val []string, add bool) {
 	assertSysParamsRealm(m)
+	execctx.AssertNotInView(m, "set params")
 	pk := prmkey(module, submodule, name)
 	execctx.GetContext(m).Params.UpdateStrings(pk, val, add)
 }
@@ -67,6 +67,10 @@ func X_valueOfInternal(v gnolang.TypedValue) (
 		kind, bytes = "float32", uint64(v.GetFloat32())
 	case gnolang.Float64Kind:
 		kind, bytes = "float64", v.GetFloat64()
+	case gnolang.Complex64Kind:
+		kind = "complex64"
+	case gnolang.Complex128Kind:
+		kind = "complex128"
 	case gnolang.ArrayKind:
 		kind, xlen = "array", v.GetLength()
 	case gnolang.SliceKind:
@@ -191,6 +195,20 @@ func compareKeys(ki, kj gnolang.TypedValue) bool {
 		return math.Float32frombits(ki.GetFloat32()) < math.Float32frombits(kj.GetFloat32())
 	case gnolang.Float64Kind:
 		return math.Float64frombits(ki.GetFloat64()) < math.Float64frombits(kj.GetFloat64())
+	case gnolang.Complex64Kind:
+		ri, ii := ki.GetComplex64()
+		rj, ij := kj.GetComplex64()
+		if ri != rj {
+			return math.Float32frombits(ri) < math.Float32frombits(rj)
+		}
+		return math.Float32frombits(ii) < math.Float32frombits(ij)
+	case gnolang.Complex128Kind:
+		ri, ii := ki.GetComplex128()
+		rj, ij := kj.GetComplex128()
+		if ri != rj {
+			return math.Float64frombits(ri) < math.Float64frombits(rj)
+		}
+		return math.Float64frombits(ii) < math.Float64frombits(ij)
 	case gnolang.StringKind:
 		return ki.GetString() < kj.GetString()
 	case gnolang.IntKind,
@@ -40,7 +40,8 @@ var testNativeFns = [][2]string{
 	{"runtime", "GC"},
 	{"runtime", "MemStats"},
 
-	// testing — context / assertions / regex.
+	// testing — context / assertions / regex / benchmarks.
+	{"testing", "benchMetrics"},
 	{"testing", "getContext"},
 	{"testing", "isRealm"},
 	{"testing", "makeRealm"},
@@ -26,3 +26,11 @@ func X_recoverWithStacktrace(m *gnolang.Machine) (gnolang.TypedValue, string) {
 	}
 	return exception.Value, exception.Stacktrace.String()
 }
+
+func X_benchMetrics(m *gnolang.Machine) (int64, int64, int64) {
+	var gas int64
+	if m.GasMeter != nil {
+		gas = m.GasMeter.GasConsumed()
+	}
+	return m.Cycles, gas, m.Alloc.Allocated()
+}
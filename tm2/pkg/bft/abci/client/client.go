@@ -29,6 +29,10 @@ type Client interface {
 	InitChainAsync(abci.RequestInitChain) *ReqRes
 	BeginBlockAsync(abci.RequestBeginBlock) *ReqRes
 	EndBlockAsync(abci.RequestEndBlock) *ReqRes
+	ListSnapshotsAsync(abci.RequestListSnapshots) *ReqRes
+	OfferSnapshotAsync(abci.RequestOfferSnapshot) *ReqRes
+	LoadSnapshotChunkAsync(abci.RequestLoadSnapshotChunk) *ReqRes
+	ApplySnapshotChunkAsync(abci.RequestApplySnapshotChunk) *ReqRes
 
 	FlushSync() error
 	EchoSync(msg string) (abci.ResponseEcho, error)
@@ -41,6 +45,10 @@ type Client interface {
 	InitChainSync(abci.RequestInitChain) (abci.ResponseInitChain, error)
 	BeginBlockSync(abci.RequestBeginBlock) (abci.ResponseBeginBlock, error)
 	EndBlockSync(abci.RequestEndBlock) (abci.ResponseEndBlock, error)
+	ListSnapshotsSync(abci.RequestListSnapshots) (abci.ResponseListSnapshots, error)
+	OfferSnapshotSync(abci.RequestOfferSnapshot) (abci.ResponseOfferSnapshot, error)
+	LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk) (abci.ResponseLoadSnapshotChunk, error)
+	ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk) (abci.ResponseApplySnapshotChunk, error)
 }
 
 // ----------------------------------------
@@ -149,6 +149,22 @@ func (app *PersistentKVStoreApplication) EndBlock(req abci.RequestEndBlock) abci
 	return abci.ResponseEndBlock{ValidatorUpdates: app.ValSetChanges}
 }
 
+func (app *PersistentKVStoreApplication) ListSnapshots(req abci.RequestListSnapshots) abci.ResponseListSnapshots {
+	return app.app.ListSnapshots(req)
+}
+
+func (app *PersistentKVStoreApplication) OfferSnapshot(req abci.RequestOfferSnapshot) abci.ResponseOfferSnapshot {
+	return app.app.OfferSnapshot(req)
+}
+
+func (app *PersistentKVStoreApplication) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) abci.ResponseLoadSnapshotChunk {
+	return app.app.LoadSnapshotChunk(req)
+}
+
+func (app *PersistentKVStoreApplication) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) abci.ResponseApplySnapshotChunk {
+	return app.app.ApplySnapshotChunk(req)
+}
+
 // ---------------------------------------------
 // update validators
 
@@ -37,6 +37,10 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 		RequestDeliverTx{},
 		RequestEndBlock{},
 		RequestCommit{},
+		RequestListSnapshots{},
+		RequestOfferSnapshot{},
+		RequestLoadSnapshotChunk{},
+		RequestApplySnapshotChunk{},
 
 		// response types
 		ResponseBase{},
@@ -52,6 +56,10 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 		ResponseDeliverTx{},
 		ResponseEndBlock{},
 		ResponseCommit{},
+		ResponseListSnapshots{},
+		ResponseOfferSnapshot{},
+		ResponseLoadSnapshotChunk{},
+		ResponseApplySnapshotChunk{},
 
 		// error types
 		StringError(""),
@@ -60,6 +68,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 		ConsensusParams{},
 		BlockParams{},
 		ValidatorParams{},
+		Snapshot{},
 		ValidatorUpdate{},
 		LastCommitInfo{},
 		VoteInfo{},
@@ -31,6 +31,10 @@ func init() {
 	amino.RegisterGenproto2Type(reflect.TypeOf((*RequestDeliverTx)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*RequestEndBlock)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*RequestCommit)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*RequestListSnapshots)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*RequestOfferSnapshot)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*RequestLoadSnapshotChunk)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*RequestApplySnapshotChunk)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseBase)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseException)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseEcho)(nil)).Elem())
@@ -44,10 +48,15 @@ func init() {
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseDeliverTx)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseEndBlock)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseCommit)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseListSnapshots)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseOfferSnapshot)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseLoadSnapshotChunk)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*ResponseApplySnapshotChunk)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*StringError)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ConsensusParams)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*BlockParams)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ValidatorParams)(nil)).Elem())
+	amino.RegisterGenproto2Type(reflect.TypeOf((*Snapshot)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*ValidatorUpdate)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*LastCommitInfo)(nil)).Elem())
 	amino.RegisterGenproto2Type(reflect.TypeOf((*VoteInfo)(nil)).Elem())
@@ -1426,110 +1435,144 @@ func (goo *RequestCommit) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 	return nil
 }
 
-func (goo ResponseBase) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo RequestListSnapshots) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
-	if goo.Info != "" {
-		{
-			before := offset
-			offset = amino.PrependString(buf, offset, string(goo.Info))
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 5, amino.Typ3ByteLength)
-			} else {
-				offset = before
-			}
+	{
+		before := offset
+		offset, err = goo.RequestBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
 		}
 	}
-	if goo.Log != "" {
-		{
-			before := offset
-			offset = amino.PrependString(buf, offset, string(goo.Log))
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
-			} else {
-				offset = before
-			}
+	return offset, err
+}
+
+func (goo RequestListSnapshots) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.RequestBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	for i := len(goo.Events) - 1; i >= 0; i-- {
-		elem := goo.Events[i]
-		if elem != nil {
-			before := offset
-			offset, err = cdc.MarshalAnyBinary2(elem, buf, offset)
+	return s, nil
+}
+
+func (goo *RequestListSnapshots) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = RequestListSnapshots{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
-				return offset, err
+				return err
 			}
-			anyLen := before - offset
-			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
-		} else {
-			offset = amino.PrependByte(buf, offset, 0x00)
+			bz = bz[n:]
+			if err := goo.RequestBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown field number %d for RequestListSnapshots", fnum)
 		}
-		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
 	}
-	if len(goo.Data) != 0 {
+	return nil
+}
+
+func (goo RequestOfferSnapshot) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	if len(goo.AppHash) != 0 {
 		{
 			before := offset
-			offset = amino.PrependByteSlice(buf, offset, goo.Data)
+			offset = amino.PrependByteSlice(buf, offset, goo.AppHash)
 			valueLen := before - offset
 			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
 			} else {
 				offset = before
 			}
 		}
 	}
-	if goo.Error != nil {
-		if goo.Error != nil {
+	if goo.Snapshot != nil {
+		{
 			before := offset
-			offset, err = cdc.MarshalAnyBinary2(goo.Error, buf, offset)
+			offset, err = (*goo.Snapshot).MarshalBinary2(cdc, buf, offset)
 			if err != nil {
 				return offset, err
 			}
-			anyLen := before - offset
-			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
+			dataLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+		}
+	}
+	{
+		before := offset
+		offset, err = goo.RequestBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
 			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
 		}
 	}
 	return offset, err
 }
 
-func (goo ResponseBase) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo RequestOfferSnapshot) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
-	if goo.Error != nil {
-		if goo.Error != nil {
-			cs, err := cdc.SizeAnyBinary2(goo.Error)
-			if err != nil {
-				return 0, err
-			}
+	{
+		cs, err := goo.RequestBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	if len(goo.Data) != 0 {
-		s += 1 + amino.ByteSliceSize(goo.Data)
-	}
-	for _, elem := range goo.Events {
-		if elem != nil {
-			cs, err := cdc.SizeAnyBinary2(elem)
+	if goo.Snapshot != nil {
+		{
+			cs, err := (*goo.Snapshot).SizeBinary2(cdc)
 			if err != nil {
 				return 0, err
 			}
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
-		} else {
-			s += 1 + 1
 		}
 	}
-	if goo.Log != "" {
-		s += 1 + amino.UvarintSize(uint64(len(goo.Log))) + len(goo.Log)
-	}
-	if goo.Info != "" {
-		s += 1 + amino.UvarintSize(uint64(len(goo.Info))) + len(goo.Info)
+	if len(goo.AppHash) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.AppHash)
 	}
 	return s, nil
 }
 
-func (goo *ResponseBase) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseBase{}
+func (goo *RequestOfferSnapshot) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = RequestOfferSnapshot{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -1552,43 +1595,1164 @@ func (goo *ResponseBase) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 				return err
 			}
 			bz = bz[n:]
-			if len(fbz) > 0 {
-				if err := cdc.UnmarshalAnyBinary2(fbz, &goo.Error, anyDepth); err != nil {
-					return err
-				}
+			if err := goo.RequestBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
 			}
 		case 2:
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
+			{
+				var pv Snapshot
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if err := pv.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
+				}
+				goo.Snapshot = &pv
+			}
+		case 3:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
 			v, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
 			if len(v) == 0 {
-				goo.Data = nil
+				goo.AppHash = nil
 			} else {
-				goo.Data = v
+				goo.AppHash = v
 			}
-		case 3:
+		default:
+			return fmt.Errorf("unknown field number %d for RequestOfferSnapshot", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo RequestLoadSnapshotChunk) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	if goo.Chunk != 0 {
+		{
+			before := offset
+			offset = amino.PrependUvarint(buf, offset, uint64(goo.Chunk))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Format != 0 {
+		{
+			before := offset
+			offset = amino.PrependUvarint(buf, offset, uint64(goo.Format))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Height != 0 {
+		{
+			before := offset
+			offset = amino.PrependVarint(buf, offset, int64(goo.Height))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	{
+		before := offset
+		offset, err = goo.RequestBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo RequestLoadSnapshotChunk) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.RequestBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	if goo.Height != 0 {
+		s += 1 + amino.VarintSize(int64(goo.Height))
+	}
+	if goo.Format != 0 {
+		s += 1 + amino.UvarintSize(uint64(goo.Format))
+	}
+	if goo.Chunk != 0 {
+		s += 1 + amino.UvarintSize(uint64(goo.Chunk))
+	}
+	return s, nil
+}
+
+func (goo *RequestLoadSnapshotChunk) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = RequestLoadSnapshotChunk{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
 			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
 			fbz, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			if len(fbz) > 0 {
-				var ev Event
+			if err := goo.RequestBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		case 2:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeVarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Height = int64(v)
+		case 3:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeUvarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Format = uint32(v)
+		case 4:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeUvarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Chunk = uint32(v)
+		default:
+			return fmt.Errorf("unknown field number %d for RequestLoadSnapshotChunk", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo RequestApplySnapshotChunk) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	if goo.Sender != "" {
+		{
+			before := offset
+			offset = amino.PrependString(buf, offset, string(goo.Sender))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if len(goo.Chunk) != 0 {
+		{
+			before := offset
+			offset = amino.PrependByteSlice(buf, offset, goo.Chunk)
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Index != 0 {
+		{
+			before := offset
+			offset = amino.PrependUvarint(buf, offset, uint64(goo.Index))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	{
+		before := offset
+		offset, err = goo.RequestBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo RequestApplySnapshotChunk) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.RequestBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	if goo.Index != 0 {
+		s += 1 + amino.UvarintSize(uint64(goo.Index))
+	}
+	if len(goo.Chunk) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.Chunk)
+	}
+	if goo.Sender != "" {
+		s += 1 + amino.UvarintSize(uint64(len(goo.Sender))) + len(goo.Sender)
+	}
+	return s, nil
+}
+
+func (goo *RequestApplySnapshotChunk) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = RequestApplySnapshotChunk{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := goo.RequestBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		case 2:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeUvarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Index = uint32(v)
+		case 3:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(v) == 0 {
+				goo.Chunk = nil
+			} else {
+				goo.Chunk = v
+			}
+		case 4:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Sender = string(v)
+		default:
+			return fmt.Errorf("unknown field number %d for RequestApplySnapshotChunk", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo ResponseBase) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	if goo.Info != "" {
+		{
+			before := offset
+			offset = amino.PrependString(buf, offset, string(goo.Info))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 5, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Log != "" {
+		{
+			before := offset
+			offset = amino.PrependString(buf, offset, string(goo.Log))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	for i := len(goo.Events) - 1; i >= 0; i-- {
+		elem := goo.Events[i]
+		if elem != nil {
+			before := offset
+			offset, err = cdc.MarshalAnyBinary2(elem, buf, offset)
+			if err != nil {
+				return offset, err
+			}
+			anyLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
+		} else {
+			offset = amino.PrependByte(buf, offset, 0x00)
+		}
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+	}
+	if len(goo.Data) != 0 {
+		{
+			before := offset
+			offset = amino.PrependByteSlice(buf, offset, goo.Data)
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Error != nil {
+		if goo.Error != nil {
+			before := offset
+			offset, err = cdc.MarshalAnyBinary2(goo.Error, buf, offset)
+			if err != nil {
+				return offset, err
+			}
+			anyLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		}
+	}
+	return offset, err
+}
+
+func (goo ResponseBase) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	if goo.Error != nil {
+		if goo.Error != nil {
+			cs, err := cdc.SizeAnyBinary2(goo.Error)
+			if err != nil {
+				return 0, err
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	if len(goo.Data) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.Data)
+	}
+	for _, elem := range goo.Events {
+		if elem != nil {
+			cs, err := cdc.SizeAnyBinary2(elem)
+			if err != nil {
+				return 0, err
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		} else {
+			s += 1 + 1
+		}
+	}
+	if goo.Log != "" {
+		s += 1 + amino.UvarintSize(uint64(len(goo.Log))) + len(goo.Log)
+	}
+	if goo.Info != "" {
+		s += 1 + amino.UvarintSize(uint64(len(goo.Info))) + len(goo.Info)
+	}
+	return s, nil
+}
+
+func (goo *ResponseBase) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseBase{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(fbz) > 0 {
+				if err := cdc.UnmarshalAnyBinary2(fbz, &goo.Error, anyDepth); err != nil {
+					return err
+				}
+			}
+		case 2:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(v) == 0 {
+				goo.Data = nil
+			} else {
+				goo.Data = v
+			}
+		case 3:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(fbz) > 0 {
+				var ev Event
 				if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
 					return err
 				}
-				goo.Events = append(goo.Events, ev)
-			} else {
-				goo.Events = append(goo.Events, nil)
+				goo.Events = append(goo.Events, ev)
+			} else {
+				goo.Events = append(goo.Events, nil)
+			}
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 3 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if len(fbz) > 0 {
+					var ev Event
+					if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
+						return err
+					}
+					goo.Events = append(goo.Events, ev)
+				} else {
+					goo.Events = append(goo.Events, nil)
+				}
+			}
+		case 4:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Log = string(v)
+		case 5:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 5: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Info = string(v)
+		default:
+			return fmt.Errorf("unknown field number %d for ResponseBase", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo ResponseException) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	{
+		before := offset
+		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo ResponseException) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.ResponseBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	return s, nil
+}
+
+func (goo *ResponseException) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseException{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown field number %d for ResponseException", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo ResponseEcho) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	if goo.Message != "" {
+		{
+			before := offset
+			offset = amino.PrependString(buf, offset, string(goo.Message))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	{
+		before := offset
+		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo ResponseEcho) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.ResponseBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	if goo.Message != "" {
+		s += 1 + amino.UvarintSize(uint64(len(goo.Message))) + len(goo.Message)
+	}
+	return s, nil
+}
+
+func (goo *ResponseEcho) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseEcho{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		case 2:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Message = string(v)
+		default:
+			return fmt.Errorf("unknown field number %d for ResponseEcho", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo ResponseFlush) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	{
+		before := offset
+		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo ResponseFlush) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.ResponseBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	return s, nil
+}
+
+func (goo *ResponseFlush) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseFlush{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown field number %d for ResponseFlush", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo ResponseInfo) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	if len(goo.LastBlockAppHash) != 0 {
+		{
+			before := offset
+			offset = amino.PrependByteSlice(buf, offset, goo.LastBlockAppHash)
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 5, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.LastBlockHeight != 0 {
+		{
+			before := offset
+			offset = amino.PrependVarint(buf, offset, int64(goo.LastBlockHeight))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.AppVersion != "" {
+		{
+			before := offset
+			offset = amino.PrependString(buf, offset, string(goo.AppVersion))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.ABCIVersion != "" {
+		{
+			before := offset
+			offset = amino.PrependString(buf, offset, string(goo.ABCIVersion))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	{
+		before := offset
+		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo ResponseInfo) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.ResponseBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	if goo.ABCIVersion != "" {
+		s += 1 + amino.UvarintSize(uint64(len(goo.ABCIVersion))) + len(goo.ABCIVersion)
+	}
+	if goo.AppVersion != "" {
+		s += 1 + amino.UvarintSize(uint64(len(goo.AppVersion))) + len(goo.AppVersion)
+	}
+	if goo.LastBlockHeight != 0 {
+		s += 1 + amino.VarintSize(int64(goo.LastBlockHeight))
+	}
+	if len(goo.LastBlockAppHash) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.LastBlockAppHash)
+	}
+	return s, nil
+}
+
+func (goo *ResponseInfo) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseInfo{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		case 2:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.ABCIVersion = string(v)
+		case 3:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.AppVersion = string(v)
+		case 4:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeVarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.LastBlockHeight = int64(v)
+		case 5:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 5: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(v) == 0 {
+				goo.LastBlockAppHash = nil
+			} else {
+				goo.LastBlockAppHash = v
+			}
+		default:
+			return fmt.Errorf("unknown field number %d for ResponseInfo", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo ResponseSetOption) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	{
+		before := offset
+		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo ResponseSetOption) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.ResponseBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	return s, nil
+}
+
+func (goo *ResponseSetOption) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseSetOption{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown field number %d for ResponseSetOption", fnum)
+		}
+	}
+	return nil
+}
+
+func (goo ResponseInitChain) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	for i := len(goo.TxResponses) - 1; i >= 0; i-- {
+		elem := goo.TxResponses[i]
+		before := offset
+		offset, err = elem.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
+	}
+	for i := len(goo.Validators) - 1; i >= 0; i-- {
+		elem := goo.Validators[i]
+		before := offset
+		offset, err = elem.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+	}
+	if goo.ConsensusParams != nil {
+		{
+			before := offset
+			offset, err = (*goo.ConsensusParams).MarshalBinary2(cdc, buf, offset)
+			if err != nil {
+				return offset, err
+			}
+			dataLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+		}
+	}
+	{
+		before := offset
+		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
+		}
+		dataLen := before - offset
+		if dataLen > 0 {
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
+		} else {
+			offset = before
+		}
+	}
+	return offset, err
+}
+
+func (goo ResponseInitChain) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	{
+		cs, err := goo.ResponseBase.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		if cs > 0 {
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	if goo.ConsensusParams != nil {
+		{
+			cs, err := (*goo.ConsensusParams).SizeBinary2(cdc)
+			if err != nil {
+				return 0, err
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	for _, elem := range goo.Validators {
+		cs, err := elem.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		s += 1 + amino.UvarintSize(uint64(cs)) + cs
+	}
+	for _, elem := range goo.TxResponses {
+		cs, err := elem.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		s += 1 + amino.UvarintSize(uint64(cs)) + cs
+	}
+	return s, nil
+}
+
+func (goo *ResponseInitChain) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseInitChain{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
+			}
+		case 2:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			{
+				var pv ConsensusParams
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if err := pv.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
+				}
+				goo.ConsensusParams = &pv
+			}
+		case 3:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			var ev ValidatorUpdate
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
 			}
+			goo.Validators = append(goo.Validators, ev)
 			for len(bz) > 0 {
 				var nextFnum uint32
 				var nextTyp3 amino.Typ3
@@ -1603,121 +2767,105 @@ func (goo *ResponseBase) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 					return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
 				}
 				bz = bz[n:]
+				var ev ValidatorUpdate
 				fbz, n, err := amino.DecodeByteSlice(bz)
 				if err != nil {
 					return err
 				}
 				bz = bz[n:]
-				if len(fbz) > 0 {
-					var ev Event
-					if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
-						return err
-					}
-					goo.Events = append(goo.Events, ev)
-				} else {
-					goo.Events = append(goo.Events, nil)
+				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
 				}
+				goo.Validators = append(goo.Validators, ev)
 			}
 		case 4:
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
-			v, n, err := amino.DecodeString(bz)
+			var ev ResponseDeliverTx
+			fbz, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			goo.Log = string(v)
-		case 5:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 5: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
-			}
-			v, n, err := amino.DecodeString(bz)
-			if err != nil {
+			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
 				return err
 			}
-			bz = bz[n:]
-			goo.Info = string(v)
+			goo.TxResponses = append(goo.TxResponses, ev)
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 4 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				var ev ResponseDeliverTx
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
+				}
+				goo.TxResponses = append(goo.TxResponses, ev)
+			}
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseBase", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseInitChain", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseException) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseQuery) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
-	{
-		before := offset
-		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
-		if err != nil {
-			return offset, err
-		}
-		dataLen := before - offset
-		if dataLen > 0 {
-			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
-		} else {
-			offset = before
-		}
-	}
-	return offset, err
-}
-
-func (goo ResponseException) SizeBinary2(cdc *amino.Codec) (int, error) {
-	var s int
-	{
-		cs, err := goo.ResponseBase.SizeBinary2(cdc)
-		if err != nil {
-			return 0, err
-		}
-		if cs > 0 {
-			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+	if goo.Height != 0 {
+		{
+			before := offset
+			offset = amino.PrependVarint(buf, offset, int64(goo.Height))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 5, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
 		}
 	}
-	return s, nil
-}
-
-func (goo *ResponseException) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseException{}
-	var lastFieldNum uint32
-	for len(bz) > 0 {
-		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
-		_ = typ3
-		if err != nil {
-			return err
-		}
-		if fnum <= lastFieldNum {
-			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
-		}
-		lastFieldNum = fnum
-		bz = bz[n:]
-		switch fnum {
-		case 1:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
-			}
-			fbz, n, err := amino.DecodeByteSlice(bz)
+	if goo.Proof != nil {
+		{
+			before := offset
+			offset, err = (*goo.Proof).MarshalBinary2(cdc, buf, offset)
 			if err != nil {
-				return err
+				return offset, err
 			}
-			bz = bz[n:]
-			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-				return err
+			dataLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
+		}
+	}
+	if len(goo.Value) != 0 {
+		{
+			before := offset
+			offset = amino.PrependByteSlice(buf, offset, goo.Value)
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+			} else {
+				offset = before
 			}
-		default:
-			return fmt.Errorf("unknown field number %d for ResponseException", fnum)
 		}
 	}
-	return nil
-}
-
-func (goo ResponseEcho) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
-	var err error
-	if goo.Message != "" {
+	if len(goo.Key) != 0 {
 		{
 			before := offset
-			offset = amino.PrependString(buf, offset, string(goo.Message))
+			offset = amino.PrependByteSlice(buf, offset, goo.Key)
 			valueLen := before - offset
 			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
 				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
@@ -1743,7 +2891,7 @@ func (goo ResponseEcho) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int)
 	return offset, err
 }
 
-func (goo ResponseEcho) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseQuery) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -1754,14 +2902,29 @@ func (goo ResponseEcho) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	if goo.Message != "" {
-		s += 1 + amino.UvarintSize(uint64(len(goo.Message))) + len(goo.Message)
+	if len(goo.Key) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.Key)
+	}
+	if len(goo.Value) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.Value)
+	}
+	if goo.Proof != nil {
+		{
+			cs, err := (*goo.Proof).SizeBinary2(cdc)
+			if err != nil {
+				return 0, err
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	if goo.Height != 0 {
+		s += 1 + amino.VarintSize(int64(goo.Height))
 	}
 	return s, nil
 }
 
-func (goo *ResponseEcho) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseEcho{}
+func (goo *ResponseQuery) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseQuery{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -1791,20 +2954,64 @@ func (goo *ResponseEcho) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
-			v, n, err := amino.DecodeString(bz)
+			v, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			goo.Message = string(v)
+			if len(v) == 0 {
+				goo.Key = nil
+			} else {
+				goo.Key = v
+			}
+		case 3:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(v) == 0 {
+				goo.Value = nil
+			} else {
+				goo.Value = v
+			}
+		case 4:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			{
+				var pv merkle.Proof
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if err := pv.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
+				}
+				goo.Proof = &pv
+			}
+		case 5:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 5: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeVarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Height = int64(v)
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseEcho", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseQuery", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseFlush) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseBeginBlock) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
 	{
 		before := offset
@@ -1823,7 +3030,7 @@ func (goo ResponseFlush) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int
 	return offset, err
 }
 
-func (goo ResponseFlush) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseBeginBlock) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -1837,8 +3044,8 @@ func (goo ResponseFlush) SizeBinary2(cdc *amino.Codec) (int, error) {
 	return s, nil
 }
 
-func (goo *ResponseFlush) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseFlush{}
+func (goo *ResponseBeginBlock) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseBeginBlock{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -1865,18 +3072,30 @@ func (goo *ResponseFlush) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 				return err
 			}
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseFlush", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseBeginBlock", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseInfo) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseCheckTx) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
-	if len(goo.LastBlockAppHash) != 0 {
+	if goo.Sequence != 0 {
 		{
 			before := offset
-			offset = amino.PrependByteSlice(buf, offset, goo.LastBlockAppHash)
+			offset = amino.PrependUvarint(buf, offset, uint64(goo.Sequence))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 6, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Sender != "" {
+		{
+			before := offset
+			offset = amino.PrependString(buf, offset, string(goo.Sender))
 			valueLen := before - offset
 			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
 				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 5, amino.Typ3ByteLength)
@@ -1885,10 +3104,10 @@ func (goo ResponseInfo) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int)
 			}
 		}
 	}
-	if goo.LastBlockHeight != 0 {
+	if goo.Priority != 0 {
 		{
 			before := offset
-			offset = amino.PrependVarint(buf, offset, int64(goo.LastBlockHeight))
+			offset = amino.PrependVarint(buf, offset, int64(goo.Priority))
 			valueLen := before - offset
 			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
 				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3Varint)
@@ -1897,25 +3116,25 @@ func (goo ResponseInfo) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int)
 			}
 		}
 	}
-	if goo.AppVersion != "" {
+	if goo.GasUsed != 0 {
 		{
 			before := offset
-			offset = amino.PrependString(buf, offset, string(goo.AppVersion))
+			offset = amino.PrependVarint(buf, offset, int64(goo.GasUsed))
 			valueLen := before - offset
 			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3Varint)
 			} else {
 				offset = before
 			}
 		}
 	}
-	if goo.ABCIVersion != "" {
+	if goo.GasWanted != 0 {
 		{
 			before := offset
-			offset = amino.PrependString(buf, offset, string(goo.ABCIVersion))
+			offset = amino.PrependVarint(buf, offset, int64(goo.GasWanted))
 			valueLen := before - offset
 			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3Varint)
 			} else {
 				offset = before
 			}
@@ -1938,7 +3157,7 @@ func (goo ResponseInfo) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int)
 	return offset, err
 }
 
-func (goo ResponseInfo) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseCheckTx) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -1949,23 +3168,26 @@ func (goo ResponseInfo) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	if goo.ABCIVersion != "" {
-		s += 1 + amino.UvarintSize(uint64(len(goo.ABCIVersion))) + len(goo.ABCIVersion)
+	if goo.GasWanted != 0 {
+		s += 1 + amino.VarintSize(int64(goo.GasWanted))
 	}
-	if goo.AppVersion != "" {
-		s += 1 + amino.UvarintSize(uint64(len(goo.AppVersion))) + len(goo.AppVersion)
+	if goo.GasUsed != 0 {
+		s += 1 + amino.VarintSize(int64(goo.GasUsed))
 	}
-	if goo.LastBlockHeight != 0 {
-		s += 1 + amino.VarintSize(int64(goo.LastBlockHeight))
+	if goo.Priority != 0 {
+		s += 1 + amino.VarintSize(int64(goo.Priority))
 	}
-	if len(goo.LastBlockAppHash) != 0 {
-		s += 1 + amino.ByteSliceSize(goo.LastBlockAppHash)
+	if goo.Sender != "" {
+		s += 1 + amino.UvarintSize(uint64(len(goo.Sender))) + len(goo.Sender)
+	}
+	if goo.Sequence != 0 {
+		s += 1 + amino.UvarintSize(uint64(goo.Sequence))
 	}
 	return s, nil
 }
 
-func (goo *ResponseInfo) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseInfo{}
+func (goo *ResponseCheckTx) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseCheckTx{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -1992,25 +3214,25 @@ func (goo *ResponseInfo) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 				return err
 			}
 		case 2:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
 			}
-			v, n, err := amino.DecodeString(bz)
+			v, n, err := amino.DecodeVarint(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			goo.ABCIVersion = string(v)
+			goo.GasWanted = int64(v)
 		case 3:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
 			}
-			v, n, err := amino.DecodeString(bz)
+			v, n, err := amino.DecodeVarint(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			goo.AppVersion = string(v)
+			goo.GasUsed = int64(v)
 		case 4:
 			if typ3 != amino.Typ3Varint {
 				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
@@ -2020,129 +3242,58 @@ func (goo *ResponseInfo) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 				return err
 			}
 			bz = bz[n:]
-			goo.LastBlockHeight = int64(v)
+			goo.Priority = int64(v)
 		case 5:
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 5: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
-			v, n, err := amino.DecodeByteSlice(bz)
+			v, n, err := amino.DecodeString(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			if len(v) == 0 {
-				goo.LastBlockAppHash = nil
-			} else {
-				goo.LastBlockAppHash = v
-			}
-		default:
-			return fmt.Errorf("unknown field number %d for ResponseInfo", fnum)
-		}
-	}
-	return nil
-}
-
-func (goo ResponseSetOption) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
-	var err error
-	{
-		before := offset
-		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
-		if err != nil {
-			return offset, err
-		}
-		dataLen := before - offset
-		if dataLen > 0 {
-			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3ByteLength)
-		} else {
-			offset = before
-		}
-	}
-	return offset, err
-}
-
-func (goo ResponseSetOption) SizeBinary2(cdc *amino.Codec) (int, error) {
-	var s int
-	{
-		cs, err := goo.ResponseBase.SizeBinary2(cdc)
-		if err != nil {
-			return 0, err
-		}
-		if cs > 0 {
-			s += 1 + amino.UvarintSize(uint64(cs)) + cs
-		}
-	}
-	return s, nil
-}
-
-func (goo *ResponseSetOption) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseSetOption{}
-	var lastFieldNum uint32
-	for len(bz) > 0 {
-		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
-		_ = typ3
-		if err != nil {
-			return err
-		}
-		if fnum <= lastFieldNum {
-			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
-		}
-		lastFieldNum = fnum
-		bz = bz[n:]
-		switch fnum {
-		case 1:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			goo.Sender = string(v)
+		case 6:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 6: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
 			}
-			fbz, n, err := amino.DecodeByteSlice(bz)
+			v, n, err := amino.DecodeUvarint(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-				return err
-			}
+			goo.Sequence = uint64(v)
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseSetOption", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseCheckTx", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseInitChain) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseDeliverTx) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
-	for i := len(goo.TxResponses) - 1; i >= 0; i-- {
-		elem := goo.TxResponses[i]
-		before := offset
-		offset, err = elem.MarshalBinary2(cdc, buf, offset)
-		if err != nil {
-			return offset, err
-		}
-		dataLen := before - offset
-		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
-	}
-	for i := len(goo.Validators) - 1; i >= 0; i-- {
-		elem := goo.Validators[i]
-		before := offset
-		offset, err = elem.MarshalBinary2(cdc, buf, offset)
-		if err != nil {
-			return offset, err
+	if goo.GasUsed != 0 {
+		{
+			before := offset
+			offset = amino.PrependVarint(buf, offset, int64(goo.GasUsed))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
 		}
-		dataLen := before - offset
-		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
 	}
-	if goo.ConsensusParams != nil {
+	if goo.GasWanted != 0 {
 		{
 			before := offset
-			offset, err = (*goo.ConsensusParams).MarshalBinary2(cdc, buf, offset)
-			if err != nil {
-				return offset, err
+			offset = amino.PrependVarint(buf, offset, int64(goo.GasWanted))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3Varint)
+			} else {
+				offset = before
 			}
-			dataLen := before - offset
-			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
 		}
 	}
 	{
@@ -2162,7 +3313,7 @@ func (goo ResponseInitChain) MarshalBinary2(cdc *amino.Codec, buf []byte, offset
 	return offset, err
 }
 
-func (goo ResponseInitChain) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseDeliverTx) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -2173,34 +3324,17 @@ func (goo ResponseInitChain) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	if goo.ConsensusParams != nil {
-		{
-			cs, err := (*goo.ConsensusParams).SizeBinary2(cdc)
-			if err != nil {
-				return 0, err
-			}
-			s += 1 + amino.UvarintSize(uint64(cs)) + cs
-		}
-	}
-	for _, elem := range goo.Validators {
-		cs, err := elem.SizeBinary2(cdc)
-		if err != nil {
-			return 0, err
-		}
-		s += 1 + amino.UvarintSize(uint64(cs)) + cs
+	if goo.GasWanted != 0 {
+		s += 1 + amino.VarintSize(int64(goo.GasWanted))
 	}
-	for _, elem := range goo.TxResponses {
-		cs, err := elem.SizeBinary2(cdc)
-		if err != nil {
-			return 0, err
-		}
-		s += 1 + amino.UvarintSize(uint64(cs)) + cs
+	if goo.GasUsed != 0 {
+		s += 1 + amino.VarintSize(int64(goo.GasUsed))
 	}
 	return s, nil
 }
 
-func (goo *ResponseInitChain) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseInitChain{}
+func (goo *ResponseDeliverTx) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseDeliverTx{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -2227,155 +3361,71 @@ func (goo *ResponseInitChain) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyD
 				return err
 			}
 		case 2:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
-			}
-			{
-				var pv ConsensusParams
-				fbz, n, err := amino.DecodeByteSlice(bz)
-				if err != nil {
-					return err
-				}
-				bz = bz[n:]
-				if err := pv.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-					return err
-				}
-				goo.ConsensusParams = &pv
-			}
-		case 3:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
 			}
-			var ev ValidatorUpdate
-			fbz, n, err := amino.DecodeByteSlice(bz)
+			v, n, err := amino.DecodeVarint(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-				return err
-			}
-			goo.Validators = append(goo.Validators, ev)
-			for len(bz) > 0 {
-				var nextFnum uint32
-				var nextTyp3 amino.Typ3
-				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
-				if err != nil {
-					return err
-				}
-				if nextFnum != 3 {
-					break
-				}
-				if nextTyp3 != amino.Typ3ByteLength {
-					return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
-				}
-				bz = bz[n:]
-				var ev ValidatorUpdate
-				fbz, n, err := amino.DecodeByteSlice(bz)
-				if err != nil {
-					return err
-				}
-				bz = bz[n:]
-				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-					return err
-				}
-				goo.Validators = append(goo.Validators, ev)
-			}
-		case 4:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
-			}
-			var ev ResponseDeliverTx
-			fbz, n, err := amino.DecodeByteSlice(bz)
+			goo.GasWanted = int64(v)
+		case 3:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeVarint(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-				return err
-			}
-			goo.TxResponses = append(goo.TxResponses, ev)
-			for len(bz) > 0 {
-				var nextFnum uint32
-				var nextTyp3 amino.Typ3
-				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
-				if err != nil {
-					return err
-				}
-				if nextFnum != 4 {
-					break
-				}
-				if nextTyp3 != amino.Typ3ByteLength {
-					return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
-				}
-				bz = bz[n:]
-				var ev ResponseDeliverTx
-				fbz, n, err := amino.DecodeByteSlice(bz)
-				if err != nil {
-					return err
-				}
-				bz = bz[n:]
-				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-					return err
-				}
-				goo.TxResponses = append(goo.TxResponses, ev)
-			}
+			goo.GasUsed = int64(v)
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseInitChain", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseDeliverTx", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseQuery) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseEndBlock) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
-	if goo.Height != 0 {
-		{
+	for i := len(goo.Events) - 1; i >= 0; i-- {
+		elem := goo.Events[i]
+		if elem != nil {
 			before := offset
-			offset = amino.PrependVarint(buf, offset, int64(goo.Height))
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 5, amino.Typ3Varint)
-			} else {
-				offset = before
+			offset, err = cdc.MarshalAnyBinary2(elem, buf, offset)
+			if err != nil {
+				return offset, err
 			}
+			anyLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
+		} else {
+			offset = amino.PrependByte(buf, offset, 0x00)
 		}
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
 	}
-	if goo.Proof != nil {
+	if goo.ConsensusParams != nil {
 		{
 			before := offset
-			offset, err = (*goo.Proof).MarshalBinary2(cdc, buf, offset)
+			offset, err = (*goo.ConsensusParams).MarshalBinary2(cdc, buf, offset)
 			if err != nil {
 				return offset, err
 			}
 			dataLen := before - offset
 			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
-		}
-	}
-	if len(goo.Value) != 0 {
-		{
-			before := offset
-			offset = amino.PrependByteSlice(buf, offset, goo.Value)
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
-			} else {
-				offset = before
-			}
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
 		}
 	}
-	if len(goo.Key) != 0 {
-		{
-			before := offset
-			offset = amino.PrependByteSlice(buf, offset, goo.Key)
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
-			} else {
-				offset = before
-			}
+	for i := len(goo.ValidatorUpdates) - 1; i >= 0; i-- {
+		elem := goo.ValidatorUpdates[i]
+		before := offset
+		offset, err = elem.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
 		}
+		dataLen := before - offset
+		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
 	}
 	{
 		before := offset
@@ -2394,7 +3444,7 @@ func (goo ResponseQuery) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int
 	return offset, err
 }
 
-func (goo ResponseQuery) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseEndBlock) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -2405,29 +3455,38 @@ func (goo ResponseQuery) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	if len(goo.Key) != 0 {
-		s += 1 + amino.ByteSliceSize(goo.Key)
-	}
-	if len(goo.Value) != 0 {
-		s += 1 + amino.ByteSliceSize(goo.Value)
+	for _, elem := range goo.ValidatorUpdates {
+		cs, err := elem.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		s += 1 + amino.UvarintSize(uint64(cs)) + cs
 	}
-	if goo.Proof != nil {
+	if goo.ConsensusParams != nil {
 		{
-			cs, err := (*goo.Proof).SizeBinary2(cdc)
+			cs, err := (*goo.ConsensusParams).SizeBinary2(cdc)
 			if err != nil {
 				return 0, err
 			}
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	if goo.Height != 0 {
-		s += 1 + amino.VarintSize(int64(goo.Height))
+	for _, elem := range goo.Events {
+		if elem != nil {
+			cs, err := cdc.SizeAnyBinary2(elem)
+			if err != nil {
+				return 0, err
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		} else {
+			s += 1 + 1
+		}
 	}
 	return s, nil
 }
 
-func (goo *ResponseQuery) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseQuery{}
+func (goo *ResponseEndBlock) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseEndBlock{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -2457,36 +3516,47 @@ func (goo *ResponseQuery) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
-			v, n, err := amino.DecodeByteSlice(bz)
+			var ev ValidatorUpdate
+			fbz, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			if len(v) == 0 {
-				goo.Key = nil
-			} else {
-				goo.Key = v
-			}
-		case 3:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
-			}
-			v, n, err := amino.DecodeByteSlice(bz)
-			if err != nil {
+			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
 				return err
 			}
-			bz = bz[n:]
-			if len(v) == 0 {
-				goo.Value = nil
-			} else {
-				goo.Value = v
+			goo.ValidatorUpdates = append(goo.ValidatorUpdates, ev)
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 2 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				var ev ValidatorUpdate
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
+				}
+				goo.ValidatorUpdates = append(goo.ValidatorUpdates, ev)
 			}
-		case 4:
+		case 3:
 			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
 			{
-				var pv merkle.Proof
+				var pv ConsensusParams
 				fbz, n, err := amino.DecodeByteSlice(bz)
 				if err != nil {
 					return err
@@ -2495,26 +3565,63 @@ func (goo *ResponseQuery) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth
 				if err := pv.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
 					return err
 				}
-				goo.Proof = &pv
+				goo.ConsensusParams = &pv
 			}
-		case 5:
-			if typ3 != amino.Typ3Varint {
-				return fmt.Errorf("field 5: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+		case 4:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
-			v, n, err := amino.DecodeVarint(bz)
+			fbz, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			goo.Height = int64(v)
+			if len(fbz) > 0 {
+				var ev Event
+				if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
+					return err
+				}
+				goo.Events = append(goo.Events, ev)
+			} else {
+				goo.Events = append(goo.Events, nil)
+			}
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 4 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if len(fbz) > 0 {
+					var ev Event
+					if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
+						return err
+					}
+					goo.Events = append(goo.Events, ev)
+				} else {
+					goo.Events = append(goo.Events, nil)
+				}
+			}
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseQuery", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseEndBlock", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseBeginBlock) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseCommit) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
 	{
 		before := offset
@@ -2533,7 +3640,7 @@ func (goo ResponseBeginBlock) MarshalBinary2(cdc *amino.Codec, buf []byte, offse
 	return offset, err
 }
 
-func (goo ResponseBeginBlock) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseCommit) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -2547,8 +3654,8 @@ func (goo ResponseBeginBlock) SizeBinary2(cdc *amino.Codec) (int, error) {
 	return s, nil
 }
 
-func (goo *ResponseBeginBlock) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseBeginBlock{}
+func (goo *ResponseCommit) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseCommit{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -2571,41 +3678,28 @@ func (goo *ResponseBeginBlock) UnmarshalBinary2(cdc *amino.Codec, bz []byte, any
 				return err
 			}
 			bz = bz[n:]
-			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-				return err
-			}
-		default:
-			return fmt.Errorf("unknown field number %d for ResponseBeginBlock", fnum)
-		}
-	}
-	return nil
-}
-
-func (goo ResponseCheckTx) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
-	var err error
-	if goo.GasUsed != 0 {
-		{
-			before := offset
-			offset = amino.PrependVarint(buf, offset, int64(goo.GasUsed))
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3Varint)
-			} else {
-				offset = before
+			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+				return err
 			}
+		default:
+			return fmt.Errorf("unknown field number %d for ResponseCommit", fnum)
 		}
 	}
-	if goo.GasWanted != 0 {
-		{
-			before := offset
-			offset = amino.PrependVarint(buf, offset, int64(goo.GasWanted))
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3Varint)
-			} else {
-				offset = before
-			}
+	return nil
+}
+
+func (goo ResponseListSnapshots) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	for i := len(goo.Snapshots) - 1; i >= 0; i-- {
+		elem := goo.Snapshots[i]
+		before := offset
+		offset, err = elem.MarshalBinary2(cdc, buf, offset)
+		if err != nil {
+			return offset, err
 		}
+		dataLen := before - offset
+		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
 	}
 	{
 		before := offset
@@ -2624,7 +3718,7 @@ func (goo ResponseCheckTx) MarshalBinary2(cdc *amino.Codec, buf []byte, offset i
 	return offset, err
 }
 
-func (goo ResponseCheckTx) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseListSnapshots) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -2635,17 +3729,18 @@ func (goo ResponseCheckTx) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	if goo.GasWanted != 0 {
-		s += 1 + amino.VarintSize(int64(goo.GasWanted))
-	}
-	if goo.GasUsed != 0 {
-		s += 1 + amino.VarintSize(int64(goo.GasUsed))
+	for _, elem := range goo.Snapshots {
+		cs, err := elem.SizeBinary2(cdc)
+		if err != nil {
+			return 0, err
+		}
+		s += 1 + amino.UvarintSize(uint64(cs)) + cs
 	}
 	return s, nil
 }
 
-func (goo *ResponseCheckTx) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseCheckTx{}
+func (goo *ResponseListSnapshots) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseListSnapshots{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -2672,58 +3767,53 @@ func (goo *ResponseCheckTx) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDep
 				return err
 			}
 		case 2:
-			if typ3 != amino.Typ3Varint {
-				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
-			v, n, err := amino.DecodeVarint(bz)
+			var ev Snapshot
+			fbz, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			goo.GasWanted = int64(v)
-		case 3:
-			if typ3 != amino.Typ3Varint {
-				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
-			}
-			v, n, err := amino.DecodeVarint(bz)
-			if err != nil {
+			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
 				return err
 			}
-			bz = bz[n:]
-			goo.GasUsed = int64(v)
+			goo.Snapshots = append(goo.Snapshots, ev)
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 2 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				var ev Snapshot
+				fbz, n, err := amino.DecodeByteSlice(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
+					return err
+				}
+				goo.Snapshots = append(goo.Snapshots, ev)
+			}
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseCheckTx", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseListSnapshots", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseDeliverTx) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseOfferSnapshot) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
-	if goo.GasUsed != 0 {
-		{
-			before := offset
-			offset = amino.PrependVarint(buf, offset, int64(goo.GasUsed))
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3Varint)
-			} else {
-				offset = before
-			}
-		}
-	}
-	if goo.GasWanted != 0 {
-		{
-			before := offset
-			offset = amino.PrependVarint(buf, offset, int64(goo.GasWanted))
-			valueLen := before - offset
-			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
-				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3Varint)
-			} else {
-				offset = before
-			}
-		}
-	}
 	{
 		before := offset
 		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
@@ -2741,7 +3831,7 @@ func (goo ResponseDeliverTx) MarshalBinary2(cdc *amino.Codec, buf []byte, offset
 	return offset, err
 }
 
-func (goo ResponseDeliverTx) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseOfferSnapshot) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -2752,17 +3842,11 @@ func (goo ResponseDeliverTx) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	if goo.GasWanted != 0 {
-		s += 1 + amino.VarintSize(int64(goo.GasWanted))
-	}
-	if goo.GasUsed != 0 {
-		s += 1 + amino.VarintSize(int64(goo.GasUsed))
-	}
 	return s, nil
 }
 
-func (goo *ResponseDeliverTx) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseDeliverTx{}
+func (goo *ResponseOfferSnapshot) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseOfferSnapshot{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -2788,72 +3872,26 @@ func (goo *ResponseDeliverTx) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyD
 			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
 				return err
 			}
-		case 2:
-			if typ3 != amino.Typ3Varint {
-				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
-			}
-			v, n, err := amino.DecodeVarint(bz)
-			if err != nil {
-				return err
-			}
-			bz = bz[n:]
-			goo.GasWanted = int64(v)
-		case 3:
-			if typ3 != amino.Typ3Varint {
-				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
-			}
-			v, n, err := amino.DecodeVarint(bz)
-			if err != nil {
-				return err
-			}
-			bz = bz[n:]
-			goo.GasUsed = int64(v)
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseDeliverTx", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseOfferSnapshot", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseEndBlock) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseLoadSnapshotChunk) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
-	for i := len(goo.Events) - 1; i >= 0; i-- {
-		elem := goo.Events[i]
-		if elem != nil {
-			before := offset
-			offset, err = cdc.MarshalAnyBinary2(elem, buf, offset)
-			if err != nil {
-				return offset, err
-			}
-			anyLen := before - offset
-			offset = amino.PrependUvarint(buf, offset, uint64(anyLen))
-		} else {
-			offset = amino.PrependByte(buf, offset, 0x00)
-		}
-		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
-	}
-	if goo.ConsensusParams != nil {
+	if len(goo.Chunk) != 0 {
 		{
 			before := offset
-			offset, err = (*goo.ConsensusParams).MarshalBinary2(cdc, buf, offset)
-			if err != nil {
-				return offset, err
+			offset = amino.PrependByteSlice(buf, offset, goo.Chunk)
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+			} else {
+				offset = before
 			}
-			dataLen := before - offset
-			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
-		}
-	}
-	for i := len(goo.ValidatorUpdates) - 1; i >= 0; i-- {
-		elem := goo.ValidatorUpdates[i]
-		before := offset
-		offset, err = elem.MarshalBinary2(cdc, buf, offset)
-		if err != nil {
-			return offset, err
 		}
-		dataLen := before - offset
-		offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
-		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
 	}
 	{
 		before := offset
@@ -2872,7 +3910,7 @@ func (goo ResponseEndBlock) MarshalBinary2(cdc *amino.Codec, buf []byte, offset
 	return offset, err
 }
 
-func (goo ResponseEndBlock) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseLoadSnapshotChunk) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -2883,38 +3921,14 @@ func (goo ResponseEndBlock) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
-	for _, elem := range goo.ValidatorUpdates {
-		cs, err := elem.SizeBinary2(cdc)
-		if err != nil {
-			return 0, err
-		}
-		s += 1 + amino.UvarintSize(uint64(cs)) + cs
-	}
-	if goo.ConsensusParams != nil {
-		{
-			cs, err := (*goo.ConsensusParams).SizeBinary2(cdc)
-			if err != nil {
-				return 0, err
-			}
-			s += 1 + amino.UvarintSize(uint64(cs)) + cs
-		}
-	}
-	for _, elem := range goo.Events {
-		if elem != nil {
-			cs, err := cdc.SizeAnyBinary2(elem)
-			if err != nil {
-				return 0, err
-			}
-			s += 1 + amino.UvarintSize(uint64(cs)) + cs
-		} else {
-			s += 1 + 1
-		}
+	if len(goo.Chunk) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.Chunk)
 	}
 	return s, nil
 }
 
-func (goo *ResponseEndBlock) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseEndBlock{}
+func (goo *ResponseLoadSnapshotChunk) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseLoadSnapshotChunk{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -2944,113 +3958,46 @@ func (goo *ResponseEndBlock) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDe
 			if typ3 != amino.Typ3ByteLength {
 				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
 			}
-			var ev ValidatorUpdate
-			fbz, n, err := amino.DecodeByteSlice(bz)
-			if err != nil {
-				return err
-			}
-			bz = bz[n:]
-			if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-				return err
-			}
-			goo.ValidatorUpdates = append(goo.ValidatorUpdates, ev)
-			for len(bz) > 0 {
-				var nextFnum uint32
-				var nextTyp3 amino.Typ3
-				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
-				if err != nil {
-					return err
-				}
-				if nextFnum != 2 {
-					break
-				}
-				if nextTyp3 != amino.Typ3ByteLength {
-					return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
-				}
-				bz = bz[n:]
-				var ev ValidatorUpdate
-				fbz, n, err := amino.DecodeByteSlice(bz)
-				if err != nil {
-					return err
-				}
-				bz = bz[n:]
-				if err := ev.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-					return err
-				}
-				goo.ValidatorUpdates = append(goo.ValidatorUpdates, ev)
-			}
-		case 3:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
-			}
-			{
-				var pv ConsensusParams
-				fbz, n, err := amino.DecodeByteSlice(bz)
-				if err != nil {
-					return err
-				}
-				bz = bz[n:]
-				if err := pv.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
-					return err
-				}
-				goo.ConsensusParams = &pv
-			}
-		case 4:
-			if typ3 != amino.Typ3ByteLength {
-				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
-			}
-			fbz, n, err := amino.DecodeByteSlice(bz)
+			v, n, err := amino.DecodeByteSlice(bz)
 			if err != nil {
 				return err
 			}
 			bz = bz[n:]
-			if len(fbz) > 0 {
-				var ev Event
-				if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
-					return err
-				}
-				goo.Events = append(goo.Events, ev)
+			if len(v) == 0 {
+				goo.Chunk = nil
 			} else {
-				goo.Events = append(goo.Events, nil)
-			}
-			for len(bz) > 0 {
-				var nextFnum uint32
-				var nextTyp3 amino.Typ3
-				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
-				if err != nil {
-					return err
-				}
-				if nextFnum != 4 {
-					break
-				}
-				if nextTyp3 != amino.Typ3ByteLength {
-					return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
-				}
-				bz = bz[n:]
-				fbz, n, err := amino.DecodeByteSlice(bz)
-				if err != nil {
-					return err
-				}
-				bz = bz[n:]
-				if len(fbz) > 0 {
-					var ev Event
-					if err := cdc.UnmarshalAnyBinary2(fbz, &ev, anyDepth); err != nil {
-						return err
-					}
-					goo.Events = append(goo.Events, ev)
-				} else {
-					goo.Events = append(goo.Events, nil)
-				}
+				goo.Chunk = v
 			}
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseEndBlock", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseLoadSnapshotChunk", fnum)
 		}
 	}
 	return nil
 }
 
-func (goo ResponseCommit) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+func (goo ResponseApplySnapshotChunk) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
+	for i := len(goo.RejectSenders) - 1; i >= 0; i-- {
+		elem := goo.RejectSenders[i]
+		if elem != "" {
+			offset = amino.PrependString(buf, offset, string(elem))
+		} else {
+			offset = amino.PrependByte(buf, offset, 0x00)
+		}
+		offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3ByteLength)
+	}
+	if len(goo.RefetchChunks) != 0 {
+		{
+			before := offset
+			for i := len(goo.RefetchChunks) - 1; i >= 0; i-- {
+				e := goo.RefetchChunks[i]
+				offset = amino.PrependUvarint(buf, offset, uint64(e))
+			}
+			dataLen := before - offset
+			offset = amino.PrependUvarint(buf, offset, uint64(dataLen))
+			offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3ByteLength)
+		}
+	}
 	{
 		before := offset
 		offset, err = goo.ResponseBase.MarshalBinary2(cdc, buf, offset)
@@ -3068,7 +4015,7 @@ func (goo ResponseCommit) MarshalBinary2(cdc *amino.Codec, buf []byte, offset in
 	return offset, err
 }
 
-func (goo ResponseCommit) SizeBinary2(cdc *amino.Codec) (int, error) {
+func (goo ResponseApplySnapshotChunk) SizeBinary2(cdc *amino.Codec) (int, error) {
 	var s int
 	{
 		cs, err := goo.ResponseBase.SizeBinary2(cdc)
@@ -3079,11 +4026,24 @@ func (goo ResponseCommit) SizeBinary2(cdc *amino.Codec) (int, error) {
 			s += 1 + amino.UvarintSize(uint64(cs)) + cs
 		}
 	}
+	if len(goo.RefetchChunks) != 0 {
+		{
+			var cs int
+			for _, e := range goo.RefetchChunks {
+				cs += amino.UvarintSize(uint64(e))
+			}
+			s += 1 + amino.UvarintSize(uint64(cs)) + cs
+		}
+	}
+	for _, elem := range goo.RejectSenders {
+		vs := amino.UvarintSize(uint64(len(elem))) + len(elem)
+		s += 1 + vs
+	}
 	return s, nil
 }
 
-func (goo *ResponseCommit) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
-	*goo = ResponseCommit{}
+func (goo *ResponseApplySnapshotChunk) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = ResponseApplySnapshotChunk{}
 	var lastFieldNum uint32
 	for len(bz) > 0 {
 		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
@@ -3109,8 +4069,62 @@ func (goo *ResponseCommit) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDept
 			if err := goo.ResponseBase.UnmarshalBinary2(cdc, fbz, anyDepth); err != nil {
 				return err
 			}
+		case 2:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			fbz, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			for len(fbz) > 0 {
+				var ev uint32
+				v, n, err := amino.DecodeUvarint(fbz)
+				if err != nil {
+					return err
+				}
+				fbz = fbz[n:]
+				ev = uint32(v)
+				goo.RefetchChunks = append(goo.RefetchChunks, ev)
+			}
+		case 3:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			var ev string
+			v, n, err := amino.DecodeString(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			ev = string(v)
+			goo.RejectSenders = append(goo.RejectSenders, ev)
+			for len(bz) > 0 {
+				var nextFnum uint32
+				var nextTyp3 amino.Typ3
+				nextFnum, nextTyp3, n, err = amino.DecodeFieldNumberAndTyp3(bz)
+				if err != nil {
+					return err
+				}
+				if nextFnum != 3 {
+					break
+				}
+				if nextTyp3 != amino.Typ3ByteLength {
+					return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3ByteLength, nextTyp3)
+				}
+				bz = bz[n:]
+				var ev string
+				v, n, err := amino.DecodeString(bz)
+				if err != nil {
+					return err
+				}
+				bz = bz[n:]
+				ev = string(v)
+				goo.RejectSenders = append(goo.RejectSenders, ev)
+			}
 		default:
-			return fmt.Errorf("unknown field number %d for ResponseCommit", fnum)
+			return fmt.Errorf("unknown field number %d for ResponseApplySnapshotChunk", fnum)
 		}
 	}
 	return nil
@@ -3509,6 +4523,171 @@ func (goo *ValidatorParams) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDep
 	return nil
 }
 
+func (goo Snapshot) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
+	var err error
+	if len(goo.Metadata) != 0 {
+		{
+			before := offset
+			offset = amino.PrependByteSlice(buf, offset, goo.Metadata)
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 5, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if len(goo.Hash) != 0 {
+		{
+			before := offset
+			offset = amino.PrependByteSlice(buf, offset, goo.Hash)
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 4, amino.Typ3ByteLength)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Chunks != 0 {
+		{
+			before := offset
+			offset = amino.PrependUvarint(buf, offset, uint64(goo.Chunks))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 3, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Format != 0 {
+		{
+			before := offset
+			offset = amino.PrependUvarint(buf, offset, uint64(goo.Format))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 2, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	if goo.Height != 0 {
+		{
+			before := offset
+			offset = amino.PrependVarint(buf, offset, int64(goo.Height))
+			valueLen := before - offset
+			if valueLen > 1 || (valueLen == 1 && buf[offset] != 0x00) {
+				offset = amino.PrependFieldNumberAndTyp3(buf, offset, 1, amino.Typ3Varint)
+			} else {
+				offset = before
+			}
+		}
+	}
+	return offset, err
+}
+
+func (goo Snapshot) SizeBinary2(cdc *amino.Codec) (int, error) {
+	var s int
+	if goo.Height != 0 {
+		s += 1 + amino.VarintSize(int64(goo.Height))
+	}
+	if goo.Format != 0 {
+		s += 1 + amino.UvarintSize(uint64(goo.Format))
+	}
+	if goo.Chunks != 0 {
+		s += 1 + amino.UvarintSize(uint64(goo.Chunks))
+	}
+	if len(goo.Hash) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.Hash)
+	}
+	if len(goo.Metadata) != 0 {
+		s += 1 + amino.ByteSliceSize(goo.Metadata)
+	}
+	return s, nil
+}
+
+func (goo *Snapshot) UnmarshalBinary2(cdc *amino.Codec, bz []byte, anyDepth int) error {
+	*goo = Snapshot{}
+	var lastFieldNum uint32
+	for len(bz) > 0 {
+		fnum, typ3, n, err := amino.DecodeFieldNumberAndTyp3(bz)
+		_ = typ3
+		if err != nil {
+			return err
+		}
+		if fnum <= lastFieldNum {
+			return fmt.Errorf("encountered fieldNum: %v, but we have already seen fnum: %v", fnum, lastFieldNum)
+		}
+		lastFieldNum = fnum
+		bz = bz[n:]
+		switch fnum {
+		case 1:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 1: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeVarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Height = int64(v)
+		case 2:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 2: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeUvarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Format = uint32(v)
+		case 3:
+			if typ3 != amino.Typ3Varint {
+				return fmt.Errorf("field 3: expected typ3 %v, got %v", amino.Typ3Varint, typ3)
+			}
+			v, n, err := amino.DecodeUvarint(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			goo.Chunks = uint32(v)
+		case 4:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 4: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(v) == 0 {
+				goo.Hash = nil
+			} else {
+				goo.Hash = v
+			}
+		case 5:
+			if typ3 != amino.Typ3ByteLength {
+				return fmt.Errorf("field 5: expected typ3 %v, got %v", amino.Typ3ByteLength, typ3)
+			}
+			v, n, err := amino.DecodeByteSlice(bz)
+			if err != nil {
+				return err
+			}
+			bz = bz[n:]
+			if len(v) == 0 {
+				goo.Metadata = nil
+			} else {
+				goo.Metadata = v
+			}
+		default:
+			return fmt.Errorf("unknown field number %d for Snapshot", fnum)
+		}
+	}
+	return nil
+}
+
 func (goo ValidatorUpdate) MarshalBinary2(cdc *amino.Codec, buf []byte, offset int) (int, error) {
 	var err error
 	if goo.Power != 0 {
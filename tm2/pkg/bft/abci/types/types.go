@@ -95,6 +95,34 @@ type RequestCommit struct {
 	RequestBase
 }
 
+type RequestListSnapshots struct {
+	RequestBase
+}
+
+// RequestOfferSnapshot offers a snapshot to restore, along with the app hash
+// trusted for its height (the one of the header at the next height).
+type RequestOfferSnapshot struct {
+	RequestBase
+	Snapshot *Snapshot
+	AppHash  []byte
+}
+
+type RequestLoadSnapshotChunk struct {
+	RequestBase
+	Height int64
+	Format uint32
+	Chunk  uint32
+}
+
+// RequestApplySnapshotChunk applies the chunk at Index of the snapshot
+// accepted by OfferSnapshot, which was received from the peer Sender.
+type RequestApplySnapshotChunk struct {
+	RequestBase
+	Index  uint32
+	Chunk  []byte
+	Sender string
+}
+
 // ----------------------------------------
 // Response types
 
@@ -182,6 +210,11 @@ type ResponseCheckTx struct {
 	ResponseBase
 	GasWanted int64 // nondeterministic
 	GasUsed   int64
+
+	// Ordering of the tx in the prioritized mempool.
+	Priority int64  // higher first
+	Sender   string // if set, the txs of the sender are ordered by Sequence
+	Sequence uint64 // a tx with the Sequence of a pending tx replaces it
 }
 
 type ResponseDeliverTx struct {
@@ -201,6 +234,30 @@ type ResponseCommit struct {
 	ResponseBase
 }
 
+type ResponseListSnapshots struct {
+	ResponseBase
+	Snapshots []Snapshot
+}
+
+// ResponseOfferSnapshot accepts the snapshot offered, unless Error is set.
+type ResponseOfferSnapshot struct {
+	ResponseBase
+}
+
+type ResponseLoadSnapshotChunk struct {
+	ResponseBase
+	Chunk []byte
+}
+
+// ResponseApplySnapshotChunk asks to fetch RefetchChunks again, and to stop
+// fetching from RejectSenders. An Error without any chunk to refetch rejects
+// the snapshot.
+type ResponseApplySnapshotChunk struct {
+	ResponseBase
+	RefetchChunks []uint32
+	RejectSenders []string
+}
+
 // ----------------------------------------
 // Interface types
 
@@ -242,6 +299,32 @@ func (err EventString) Event() string {
 	return string(err)
 }
 
+// IndexedEvent is an Event with attributes to index, so that the transactions
+// which emitted it can be searched by their values.
+type IndexedEvent interface {
+	Event
+
+	// IndexedAttributes returns the type of the event, and its attributes
+	// to index.
+	IndexedAttributes() (string, []EventAttribute)
+}
+
+// AttributedEvent is an Event with a type and attributes, emitted by a
+// package of the application, so that subscriptions can filter on them.
+type AttributedEvent interface {
+	Event
+
+	// EventAttributes returns the type of the event, the path of the
+	// package which emitted it, and all its attributes.
+	EventAttributes() (string, string, []EventAttribute)
+}
+
+// EventAttribute is an attribute of an IndexedEvent or AttributedEvent.
+type EventAttribute struct {
+	Key   string
+	Value string
+}
+
 // ----------------------------------------
 // Misc
 
@@ -263,6 +346,17 @@ type ValidatorParams struct {
 	PubKeyTypeURLs []string
 }
 
+// Snapshot is a snapshot of the application state at Height, in the format
+// Format of the application, split in Chunks chunks. Hash identifies the
+// snapshot, and Metadata is opaque to the consensus engine.
+type Snapshot struct {
+	Height   int64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+}
+
 type ValidatorUpdate struct {
 	Address crypto.Address
 	PubKey  crypto.PubKey
@@ -15,11 +15,13 @@ type AppConns interface {
 	Mempool() Mempool
 	Consensus() Consensus
 	Query() Query
+	Snapshot() Snapshot
 }
 
-// ClientCreator creates ABCI clients for the three Tendermint connections.
+// ClientCreator creates ABCI clients for the Tendermint connections.
 type ClientCreator interface {
-	// NewABCIClient returns a client for mutating connections (consensus, mempool).
+	// NewABCIClient returns a client for mutating connections (consensus,
+	// mempool, snapshot).
 	NewABCIClient() (abcicli.Client, error)
 	// NewReadOnlyABCIClient returns a client for the query connection.
 	// It uses an independent mutex so query calls never block consensus.
@@ -33,7 +35,7 @@ func NewAppConns(clientCreator ClientCreator) AppConns {
 //-----------------------------
 // multi implements AppConns
 
-// a multi is made of a few appConns (mempool, consensus, query)
+// a multi is made of a few appConns (mempool, consensus, query, snapshot)
 // and manages their underlying abci clients
 // TODO: on app restart, clients must reboot together
 type multi struct {
@@ -42,6 +44,7 @@ type multi struct {
 	mempoolConn   *mempool
 	consensusConn *consensus
 	queryConn     *query
+	snapshotConn  *snapshot
 
 	clientCreator ClientCreator
 }
@@ -70,6 +73,11 @@ func (app *multi) Query() Query {
 	return app.queryConn
 }
 
+// Returns the snapshot Connection
+func (app *multi) Snapshot() Snapshot {
+	return app.snapshotConn
+}
+
 func (app *multi) OnStart() error {
 	// query connection — uses an independent mutex so queries never block consensus
 	querycli, err := app.clientCreator.NewReadOnlyABCIClient()
@@ -104,5 +112,17 @@ func (app *multi) OnStart() error {
 	}
 	app.consensusConn = NewConsensus(concli)
 
+	// snapshot connection — it restores the application state, so it shares
+	// the mutex of the mutating connections
+	snapcli, err := app.clientCreator.NewABCIClient()
+	if err != nil {
+		return errors.Wrap(err, "Error creating ABCI client (snapshot connection)")
+	}
+	snapcli.SetLogger(app.Logger.With("module", "abci-client", "connection", "snapshot"))
+	if err := snapcli.Start(); err != nil {
+		return errors.Wrap(err, "Error starting ABCI client (snapshot connection)")
+	}
+	app.snapshotConn = NewSnapshot(snapcli)
+
 	return nil
 }
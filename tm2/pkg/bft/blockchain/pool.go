@@ -151,6 +151,15 @@ func (pool *BlockPool) removeTimedoutPeers() {
 	}
 }
 
+// SetHeight sets the height of the next block to sync. It must be called
+// before the pool is started.
+func (pool *BlockPool) SetHeight(height int64) {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	pool.height = height
+}
+
 // GetStatus returns pool's height, numPending requests and the number of
 // requesters.
 func (pool *BlockPool) GetStatus() (height int64, numPending int32, lenRequesters int) {
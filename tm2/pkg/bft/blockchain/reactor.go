@@ -138,6 +138,21 @@ func (bcR *BlockchainReactor) OnStart() error {
 	return nil
 }
 
+// SwitchToFastSync is called by the state sync reactor once it restored the
+// state of the application: it starts fast syncing the blocks after the
+// height of state.
+func (bcR *BlockchainReactor) SwitchToFastSync(state sm.State) error {
+	bcR.fastSync = true
+	bcR.initialState = state
+	bcR.pool.SetHeight(state.LastBlockHeight + 1)
+
+	if err := bcR.pool.Start(); err != nil {
+		return err
+	}
+	go bcR.poolRoutine()
+	return nil
+}
+
 // OnStop implements cmn.Service.
 func (bcR *BlockchainReactor) OnStop() {
 	bcR.pool.Stop()
@@ -0,0 +1,309 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultTrustingPeriod is the default trusting period of the trust
+	// options, which should be significantly shorter than the unbonding period
+	// of the chain.
+	DefaultTrustingPeriod = 168 * time.Hour
+
+	// DefaultMaxClockDrift is the default maximum duration a header time can
+	// be ahead of the local clock.
+	DefaultMaxClockDrift = 10 * time.Second
+)
+
+// TrustOptions are the options to trust the first light block of a Client,
+// which must be obtained out of band, from a trusted source.
+type TrustOptions struct {
+	// Period is how long a validator set stays trusted after the time of its
+	// header.
+	Period time.Duration
+
+	// Height and Hash identify the trusted header.
+	Height int64
+	Hash   []byte
+}
+
+// ValidateBasic checks the trust options are well-formed.
+func (opts TrustOptions) ValidateBasic() error {
+	if opts.Period <= 0 {
+		return fmt.Errorf("%w: non-positive trusting period %v", ErrInvalidTrustOptions, opts.Period)
+	}
+	if opts.Height <= 0 {
+		return fmt.Errorf("%w: non-positive height %d", ErrInvalidTrustOptions, opts.Height)
+	}
+	if len(opts.Hash) == 0 {
+		return fmt.Errorf("%w: empty hash", ErrInvalidTrustOptions)
+	}
+	return nil
+}
+
+type Option func(c *Client)
+
+// WithSequentialVerification makes the Client verify every light block up to
+// the requested height, instead of skipping them when it can.
+func WithSequentialVerification() Option {
+	return func(c *Client) {
+		c.sequential = true
+	}
+}
+
+// WithMaxClockDrift sets the maximum duration a header time can be ahead of
+// the local clock.
+func WithMaxClockDrift(drift time.Duration) Option {
+	return func(c *Client) {
+		c.maxClockDrift = drift
+	}
+}
+
+// Client is a light client, verifying the light blocks of a chain from a
+// Provider, and saving the verified light blocks in a Store.
+type Client struct {
+	chainID        string
+	trustingPeriod time.Duration
+	maxClockDrift  time.Duration
+	sequential     bool
+
+	provider Provider
+	store    Store
+
+	mtx sync.Mutex // serializes the verifications
+}
+
+// NewClient returns a light client of the chain, trusting the light block of
+// the trust options. It is fetched from the provider, unless the store
+// already has it.
+func NewClient(
+	ctx context.Context,
+	chainID string,
+	trustOptions TrustOptions,
+	provider Provider,
+	store Store,
+	opts ...Option,
+) (*Client, error) {
+	if err := trustOptions.ValidateBasic(); err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		chainID:        chainID,
+		trustingPeriod: trustOptions.Period,
+		maxClockDrift:  DefaultMaxClockDrift,
+		provider:       provider,
+		store:          store,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.initializeTrust(ctx, trustOptions); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// initializeTrust saves the trusted light block of the trust options, after
+// checking it is the one of the options and that its validators signed it.
+func (c *Client) initializeTrust(ctx context.Context, opts TrustOptions) error {
+	if lb, err := c.store.LightBlock(opts.Height); err == nil && bytes.Equal(lb.Hash(), opts.Hash) {
+		return nil
+	}
+
+	lb, err := c.provider.LightBlock(ctx, opts.Height)
+	if err != nil {
+		return err
+	}
+	if err := lb.ValidateBasic(c.chainID); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	if !bytes.Equal(lb.Hash(), opts.Hash) {
+		return fmt.Errorf("%w: header hash %X does not match the trusted hash %X",
+			ErrInvalidTrustOptions, lb.Hash(), opts.Hash)
+	}
+	if err := lb.ValidatorSet.VerifyCommit(c.chainID, lb.Commit.BlockID, lb.Height, lb.Commit); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+
+	return c.store.SaveLightBlock(lb)
+}
+
+// ChainID returns the ID of the chain of the client.
+func (c *Client) ChainID() string {
+	return c.chainID
+}
+
+// TrustedLightBlock returns the verified light block at the given height, or
+// the latest one if height is 0, without verifying any new light block. It
+// returns ErrLightBlockNotFound if no light block was verified at that height.
+func (c *Client) TrustedLightBlock(height int64) (*LightBlock, error) {
+	if height == 0 {
+		return c.store.LightBlockBefore(math.MaxInt64)
+	}
+	return c.store.LightBlock(height)
+}
+
+// Update verifies the latest light block of the provider, and returns it, or
+// the latest trusted light block if the provider has no newer one.
+func (c *Client) Update(ctx context.Context, now time.Time) (*LightBlock, error) {
+	latest, err := c.provider.LightBlock(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	trusted, err := c.store.LightBlockBefore(math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+	if latest.Height <= trusted.Height {
+		return trusted, nil
+	}
+
+	if err := c.verifyForwards(ctx, trusted, latest, now); err != nil {
+		return nil, err
+	}
+	return latest, nil
+}
+
+// VerifyLightBlockAtHeight returns the light block at the given height,
+// verifying it first if it was not verified yet: forwards from the closest
+// trusted light block below it, or backwards from the closest trusted light
+// block above it.
+func (c *Client) VerifyLightBlockAtHeight(ctx context.Context, height int64, now time.Time) (*LightBlock, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("non-positive height %d", height)
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if lb, err := c.store.LightBlock(height); err == nil {
+		return lb, nil
+	} else if !errors.Is(err, ErrLightBlockNotFound) {
+		return nil, err
+	}
+
+	if trusted, err := c.store.LightBlockBefore(height); err == nil {
+		untrusted, err := c.provider.LightBlock(ctx, height)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.verifyForwards(ctx, trusted, untrusted, now); err != nil {
+			return nil, err
+		}
+		return untrusted, nil
+	} else if !errors.Is(err, ErrLightBlockNotFound) {
+		return nil, err
+	}
+
+	trusted, err := c.store.LightBlockAfter(height)
+	if err != nil {
+		return nil, err
+	}
+	return c.verifyBackwards(ctx, trusted, height, now)
+}
+
+// verifyForwards verifies the untrusted light block from the trusted one
+// below it, and saves the light blocks it verified.
+func (c *Client) verifyForwards(ctx context.Context, trusted, untrusted *LightBlock, now time.Time) error {
+	if c.sequential {
+		return c.verifySequential(ctx, trusted, untrusted, now)
+	}
+	return c.verifySkipping(ctx, trusted, untrusted, now)
+}
+
+// verifySequential verifies each light block after the trusted one, up to the
+// untrusted one.
+func (c *Client) verifySequential(ctx context.Context, trusted, untrusted *LightBlock, now time.Time) error {
+	for height := trusted.Height + 1; height <= untrusted.Height; height++ {
+		lb := untrusted
+		if height < untrusted.Height {
+			var err error
+			if lb, err = c.provider.LightBlock(ctx, height); err != nil {
+				return err
+			}
+		}
+
+		if err := VerifyAdjacent(trusted, lb, c.trustingPeriod, now, c.maxClockDrift); err != nil {
+			return fmt.Errorf("unable to verify light block at height %d, %w", height, err)
+		}
+		if err := c.store.SaveLightBlock(lb); err != nil {
+			return err
+		}
+		trusted = lb
+	}
+	return nil
+}
+
+// verifySkipping verifies the untrusted light block directly from the trusted
+// one, and bisects the heights in between while the validator set changed too
+// much for that.
+func (c *Client) verifySkipping(ctx context.Context, trusted, untrusted *LightBlock, now time.Time) error {
+	// pending are the light blocks left to verify, the next one last.
+	pending := []*LightBlock{untrusted}
+	for len(pending) > 0 {
+		lb := pending[len(pending)-1]
+
+		var err error
+		if lb.Height == trusted.Height+1 {
+			err = VerifyAdjacent(trusted, lb, c.trustingPeriod, now, c.maxClockDrift)
+		} else {
+			err = VerifyNonAdjacent(trusted, lb, c.trustingPeriod, now, c.maxClockDrift)
+		}
+
+		switch {
+		case err == nil:
+			if err := c.store.SaveLightBlock(lb); err != nil {
+				return err
+			}
+			trusted = lb
+			pending = pending[:len(pending)-1]
+		case errors.Is(err, ErrNewValSetCantBeTrusted):
+			pivot, err := c.provider.LightBlock(ctx, trusted.Height+(lb.Height-trusted.Height)/2)
+			if err != nil {
+				return err
+			}
+			pending = append(pending, pivot)
+		default:
+			return fmt.Errorf("unable to verify light block at height %d, %w", lb.Height, err)
+		}
+	}
+	return nil
+}
+
+// verifyBackwards verifies the light blocks before the trusted one, down to
+// the given height, through the hash chain of their headers, and saves the
+// one at that height.
+func (c *Client) verifyBackwards(ctx context.Context, trusted *LightBlock, height int64, now time.Time) (*LightBlock, error) {
+	if IsExpired(trusted.SignedHeader, c.trustingPeriod, now) {
+		return nil, fmt.Errorf("%w: header at height %d expired at %v",
+			ErrOldHeaderExpired, trusted.Height, trusted.Time.Add(c.trustingPeriod))
+	}
+
+	for trusted.Height > height {
+		lb, err := c.provider.LightBlock(ctx, trusted.Height-1)
+		if err != nil {
+			return nil, err
+		}
+		if err := VerifyBackwards(trusted, lb); err != nil {
+			return nil, fmt.Errorf("unable to verify light block at height %d, %w", lb.Height, err)
+		}
+		trusted = lb
+	}
+
+	if err := c.store.SaveLightBlock(trusted); err != nil {
+		return nil, err
+	}
+	return trusted, nil
+}
@@ -0,0 +1,188 @@
+package light
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, repeatValSet(newTestValSet(4), 4), nil)
+
+	t.Run("trusted", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewDBStore(memdb.NewMemDB())
+		c, err := NewClient(context.Background(), testChainID, chain.trustOptions(2), &mockProvider{chain: chain}, store)
+		require.NoError(t, err)
+
+		lb, err := c.TrustedLightBlock(0)
+		require.NoError(t, err)
+		assert.Equal(t, chain.lightBlocks[2].Hash(), lb.Hash())
+
+		// The trusted light block is not fetched again.
+		provider := &mockProvider{chain: chain}
+		_, err = NewClient(context.Background(), testChainID, chain.trustOptions(2), provider, store)
+		require.NoError(t, err)
+		assert.Empty(t, provider.requests)
+	})
+
+	t.Run("invalid trust options", func(t *testing.T) {
+		t.Parallel()
+
+		opts := chain.trustOptions(2)
+		opts.Period = 0
+		_, err := NewClient(context.Background(), testChainID, opts, &mockProvider{chain: chain}, NewDBStore(memdb.NewMemDB()))
+		assert.ErrorIs(t, err, ErrInvalidTrustOptions)
+
+		opts = chain.trustOptions(2)
+		opts.Hash = chain.lightBlocks[3].Hash()
+		_, err = NewClient(context.Background(), testChainID, opts, &mockProvider{chain: chain}, NewDBStore(memdb.NewMemDB()))
+		assert.ErrorIs(t, err, ErrInvalidTrustOptions)
+	})
+
+	t.Run("other chain", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewClient(context.Background(), "other-chain", chain.trustOptions(2), &mockProvider{chain: chain}, NewDBStore(memdb.NewMemDB()))
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+}
+
+// newValSetsChain returns a chain of 10 heights whose validator set changes
+// entirely at heights 4 and 8.
+func newValSetsChain(t *testing.T) *testChain {
+	t.Helper()
+
+	valSets := repeatValSet(newTestValSet(4), 3)
+	valSets = append(valSets, repeatValSet(newTestValSet(4), 4)...)
+	valSets = append(valSets, repeatValSet(newTestValSet(4), 4)...)
+	return newTestChain(t, valSets, nil)
+}
+
+func TestClient_VerifyLightBlockAtHeight(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		opts     []Option
+		requests []int64
+	}{
+		{
+			name: "skipping",
+			// 10 fails from 1, so does 5, then 3 passes; 5 fails from 3, 4
+			// passes as it is adjacent, then 5 passes from 4; 10 fails from
+			// 5, 7 passes; 10 fails from 7, 8 passes as it is adjacent, then
+			// 10 passes from 8.
+			requests: []int64{10, 5, 3, 4, 7, 8},
+		},
+		{
+			name:     "sequential",
+			opts:     []Option{WithSequentialVerification()},
+			requests: []int64{10, 2, 3, 4, 5, 6, 7, 8, 9},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			chain := newValSetsChain(t)
+			provider := &mockProvider{chain: chain}
+			store := NewDBStore(memdb.NewMemDB())
+
+			c, err := NewClient(context.Background(), testChainID, chain.trustOptions(1), provider, store, testCase.opts...)
+			require.NoError(t, err)
+			provider.requests = nil
+
+			lb, err := c.VerifyLightBlockAtHeight(context.Background(), 10, chain.now())
+			require.NoError(t, err)
+			assert.Equal(t, chain.lightBlocks[10].Hash(), lb.Hash())
+			assert.Equal(t, testCase.requests, provider.requests)
+
+			// The verified light blocks are saved, and not fetched again.
+			for _, height := range testCase.requests {
+				_, err := c.TrustedLightBlock(height)
+				assert.NoError(t, err)
+			}
+			provider.requests = nil
+			_, err = c.VerifyLightBlockAtHeight(context.Background(), 7, chain.now())
+			require.NoError(t, err)
+			assert.Empty(t, provider.requests)
+		})
+	}
+}
+
+func TestClient_VerifyLightBlockAtHeight_Backwards(t *testing.T) {
+	t.Parallel()
+
+	chain := newValSetsChain(t)
+	provider := &mockProvider{chain: chain}
+
+	c, err := NewClient(context.Background(), testChainID, chain.trustOptions(8), provider, NewDBStore(memdb.NewMemDB()))
+	require.NoError(t, err)
+	provider.requests = nil
+
+	lb, err := c.VerifyLightBlockAtHeight(context.Background(), 5, chain.now())
+	require.NoError(t, err)
+	assert.Equal(t, chain.lightBlocks[5].Hash(), lb.Hash())
+	assert.Equal(t, []int64{7, 6, 5}, provider.requests)
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := c.VerifyLightBlockAtHeight(context.Background(), 2, chain.now().Add(DefaultTrustingPeriod))
+		assert.ErrorIs(t, err, ErrOldHeaderExpired)
+	})
+}
+
+func TestClient_Update(t *testing.T) {
+	t.Parallel()
+
+	chain := newValSetsChain(t)
+	provider := &mockProvider{chain: chain}
+
+	c, err := NewClient(context.Background(), testChainID, chain.trustOptions(1), provider, NewDBStore(memdb.NewMemDB()))
+	require.NoError(t, err)
+
+	lb, err := c.Update(context.Background(), chain.now())
+	require.NoError(t, err)
+	assert.Equal(t, chain.lightBlocks[chain.height].Hash(), lb.Hash())
+
+	// Without a new light block, the latest trusted one is returned.
+	lb, err = c.Update(context.Background(), chain.now())
+	require.NoError(t, err)
+	assert.Equal(t, chain.lightBlocks[chain.height].Hash(), lb.Hash())
+}
+
+func TestClient_Fork(t *testing.T) {
+	t.Parallel()
+
+	chain := newValSetsChain(t)
+
+	// The provider serves a fork of the chain after height 3, signed by other
+	// validators.
+	fork := newValSetsChain(t)
+	for height := int64(1); height <= 3; height++ {
+		fork.lightBlocks[height] = chain.lightBlocks[height]
+	}
+
+	for _, opts := range [][]Option{nil, {WithSequentialVerification()}} {
+		c, err := NewClient(context.Background(), testChainID, chain.trustOptions(1), &mockProvider{chain: fork}, NewDBStore(memdb.NewMemDB()), opts...)
+		require.NoError(t, err)
+
+		_, err = c.VerifyLightBlockAtHeight(context.Background(), 3, chain.now())
+		require.NoError(t, err)
+
+		_, err = c.VerifyLightBlockAtHeight(context.Background(), 10, chain.now())
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+
+		_, err = c.TrustedLightBlock(10)
+		assert.ErrorIs(t, err, ErrLightBlockNotFound)
+	}
+}
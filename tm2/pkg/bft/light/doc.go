@@ -0,0 +1,25 @@
+// Package light implements a light client: it verifies the signed headers of
+// a chain and the transitions of its validator set from a trusted height,
+// without executing its blocks, so that the data served by an untrusted node
+// can be checked against the verified headers.
+//
+// The trust in a chain is bootstrapped from a header obtained out of band, from
+// a trusted source, and identified by its height and hash (TrustOptions). The
+// light blocks after it are then verified either sequentially, each being
+// signed by the validator set the previous one committed to, or by skipping: a
+// light block is trusted at once if more than 2/3 of the voting power of the
+// latest trusted validator set signed it, and light blocks in between are
+// verified first (bisection) when the validator set changed too much for that.
+// The light blocks before the trusted ones are verified backwards, through
+// the hash chain of their headers.
+//
+// A trusted validator set stays trusted for the trusting period after the
+// time of its header: it should be significantly shorter than the unbonding
+// period of the chain, for the validators to still be accountable for what
+// they signed.
+//
+// Proxy builds on a Client to wrap an RPC client of an untrusted node, and
+// verifies its responses: headers, validator sets, blocks, and the merkle
+// proofs of the store queries (/.store/<store>/key) against the app hash of
+// the verified headers.
+package light
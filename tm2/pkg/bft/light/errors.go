@@ -0,0 +1,14 @@
+package light
+
+import "errors"
+
+var (
+	ErrOldHeaderExpired        = errors.New("trusted header has expired")
+	ErrNewValSetCantBeTrusted  = errors.New("not enough of the trusted validators signed the new header")
+	ErrInvalidHeader           = errors.New("invalid header")
+	ErrLightBlockNotFound      = errors.New("light block not found")
+	ErrUnverifiableQuery       = errors.New("query cannot be verified")
+	ErrInvalidTrustOptions     = errors.New("invalid trust options")
+	ErrValidatorsHashMismatch  = errors.New("validator set does not match the validators hash of the header")
+	ErrValidatorAddressInvalid = errors.New("validator address does not match its public key")
+)
@@ -0,0 +1,179 @@
+package light
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = "test-chain"
+
+// genesisTime is the time of the first block of the test chains, whose
+// blocks are a minute apart. It is recent, as the proxy verifies the headers
+// at the current time.
+var genesisTime = time.Now().UTC().Truncate(time.Second).Add(-time.Hour)
+
+// testValSet is a validator set along with the signers of its validators.
+type testValSet struct {
+	vals  *types.ValidatorSet
+	privs []types.PrivValidator
+}
+
+func newTestValSet(numValidators int) testValSet {
+	vals, privs := types.RandValidatorSet(numValidators, 10)
+	return testValSet{vals: vals, privs: privs}
+}
+
+// testChain is a chain of blocks signed by their validator sets.
+type testChain struct {
+	blocks      map[int64]*types.Block
+	lightBlocks map[int64]*LightBlock
+	height      int64
+}
+
+// newTestChain returns a chain with a block at each height of valSets, but
+// the last one, which is the set of next validators of the last block. Each
+// block has the app hash returned by appHash for its height, or none if nil.
+func newTestChain(t *testing.T, valSets []testValSet, appHash func(height int64) []byte) *testChain {
+	t.Helper()
+
+	chain := &testChain{
+		blocks:      make(map[int64]*types.Block),
+		lightBlocks: make(map[int64]*LightBlock),
+	}
+
+	var (
+		lastBlockID types.BlockID
+		lastCommit  = types.NewCommit(types.BlockID{}, nil)
+	)
+	for i := range len(valSets) - 1 {
+		height := int64(i + 1)
+		vs := valSets[i]
+
+		block := types.MakeBlock(height, nil, lastCommit)
+		var hash []byte
+		if appHash != nil {
+			hash = appHash(height)
+		}
+		block.Header.Populate(
+			testChainID, genesisTime.Add(time.Duration(height)*time.Minute), lastBlockID, 0,
+			"", vs.vals.Hash(), valSets[i+1].vals.Hash(), nil, hash, nil, vs.vals.Validators[0].Address,
+		)
+
+		blockID := types.BlockID{Hash: block.Hash()}
+		voteSet := types.NewVoteSet(testChainID, height, 0, types.PrecommitType, vs.vals)
+		commit, err := types.MakeCommit(blockID, height, 0, voteSet, vs.privs)
+		require.NoError(t, err)
+
+		chain.blocks[height] = block
+		chain.lightBlocks[height] = &LightBlock{
+			SignedHeader: &types.SignedHeader{Header: &block.Header, Commit: commit},
+			ValidatorSet: vs.vals,
+		}
+		chain.height = height
+
+		lastBlockID, lastCommit = blockID, commit
+	}
+
+	return chain
+}
+
+// repeatValSet returns the given validator set n times.
+func repeatValSet(vs testValSet, n int) []testValSet {
+	valSets := make([]testValSet, n)
+	for i := range valSets {
+		valSets[i] = vs
+	}
+	return valSets
+}
+
+// trustOptions returns the trust options of the chain at height.
+func (c *testChain) trustOptions(height int64) TrustOptions {
+	return TrustOptions{
+		Period: DefaultTrustingPeriod,
+		Height: height,
+		Hash:   c.lightBlocks[height].Hash(),
+	}
+}
+
+// now returns a time shortly after the last block of the chain.
+func (c *testChain) now() time.Time {
+	return c.lightBlocks[c.height].Time.Add(time.Second)
+}
+
+// mockProvider is a Provider of the light blocks of a test chain, which
+// records the heights it was asked for.
+type mockProvider struct {
+	chain    *testChain
+	requests []int64
+}
+
+func (p *mockProvider) LightBlock(_ context.Context, height int64) (*LightBlock, error) {
+	p.requests = append(p.requests, height)
+	if height == 0 {
+		height = p.chain.height
+	}
+	lb, ok := p.chain.lightBlocks[height]
+	if !ok {
+		return nil, fmt.Errorf("no light block at height %d", height)
+	}
+	return lb, nil
+}
+
+// mockClient is an RPC client of a test chain, whose queries are answered by
+// queryFn.
+type mockClient struct {
+	rpcclient.Client
+
+	chain   *testChain
+	queryFn func(path string, data []byte, opts rpcclient.ABCIQueryOptions) *ctypes.ResultABCIQuery
+}
+
+func (c *mockClient) heightOf(height *int64) int64 {
+	if height == nil {
+		return c.chain.height
+	}
+	return *height
+}
+
+func (c *mockClient) ABCIQueryWithOptions(
+	_ context.Context,
+	path string,
+	data []byte,
+	opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	return c.queryFn(path, data, opts), nil
+}
+
+func (c *mockClient) Block(_ context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	block, ok := c.chain.blocks[c.heightOf(height)]
+	if !ok {
+		return nil, fmt.Errorf("no block at height %d", c.heightOf(height))
+	}
+	return &ctypes.ResultBlock{
+		BlockMeta: types.NewBlockMeta(block, block.MakePartSet(types.BlockPartSizeBytes)),
+		Block:     block,
+	}, nil
+}
+
+func (c *mockClient) Commit(_ context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	lb, ok := c.chain.lightBlocks[c.heightOf(height)]
+	if !ok {
+		return nil, fmt.Errorf("no commit at height %d", c.heightOf(height))
+	}
+	return &ctypes.ResultCommit{SignedHeader: *lb.SignedHeader, CanonicalCommit: true}, nil
+}
+
+func (c *mockClient) Validators(_ context.Context, height *int64) (*ctypes.ResultValidators, error) {
+	lb, ok := c.chain.lightBlocks[c.heightOf(height)]
+	if !ok {
+		return nil, fmt.Errorf("no validators at height %d", c.heightOf(height))
+	}
+	return &ctypes.ResultValidators{BlockHeight: lb.Height, Validators: lb.ValidatorSet.Validators}, nil
+}
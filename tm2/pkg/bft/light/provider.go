@@ -0,0 +1,61 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// Provider provides the light blocks of a chain. Providers are not trusted:
+// the light blocks they return are verified by the Client.
+type Provider interface {
+	// LightBlock returns the light block at the given height, or the latest
+	// one if height is 0.
+	LightBlock(ctx context.Context, height int64) (*LightBlock, error)
+}
+
+var _ Provider = (*rpcProvider)(nil)
+
+// rpcProvider provides the light blocks of the node of an RPC client, from
+// its commit and validators routes.
+type rpcProvider struct {
+	client rpcclient.SignClient
+}
+
+// NewRPCProvider returns a Provider fetching the light blocks from the node
+// of the given RPC client.
+func NewRPCProvider(client rpcclient.SignClient) Provider {
+	return &rpcProvider{client: client}
+}
+
+func (p *rpcProvider) LightBlock(ctx context.Context, height int64) (*LightBlock, error) {
+	var heightPtr *int64
+	if height != 0 {
+		heightPtr = &height
+	}
+
+	commit, err := p.client.Commit(ctx, heightPtr)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the commit at height %d, %w", height, err)
+	}
+	sh := commit.SignedHeader
+	if sh.Header == nil || sh.Commit == nil {
+		return nil, errors.New("node returned an empty signed header")
+	}
+	if height != 0 && sh.Height != height {
+		return nil, fmt.Errorf("node returned the signed header at height %d, expected %d", sh.Height, height)
+	}
+
+	vals, err := p.client.Validators(ctx, &sh.Height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the validators at height %d, %w", sh.Height, err)
+	}
+
+	return &LightBlock{
+		SignedHeader: &sh,
+		ValidatorSet: &types.ValidatorSet{Validators: vals.Validators},
+	}, nil
+}
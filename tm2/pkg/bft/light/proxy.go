@@ -0,0 +1,203 @@
+package light
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/merkle"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
+)
+
+var _ rpcclient.Client = (*Proxy)(nil)
+
+// Proxy is an RPC client of an untrusted node, which verifies its responses
+// with a light client:
+//
+//   - ABCIQuery and ABCIQueryWithOptions only serve the store queries
+//     (/.store/<store>/key), with their merkle proof verified against the app
+//     hash of the verified header which follows the height of the query;
+//   - Block checks the block is the one of the verified header;
+//   - Commit checks the header is verified, and that its commit is signed by
+//     its validators;
+//   - Validators returns the verified validator set.
+//
+// The other methods are forwarded to the node, unverified.
+type Proxy struct {
+	rpcclient.Client
+
+	light *Client
+	prt   *merkle.ProofRuntime
+}
+
+// NewProxy returns a Proxy verifying the responses of the node of client with
+// light, whose provider is usually NewRPCProvider(client).
+func NewProxy(client rpcclient.Client, light *Client) *Proxy {
+	return &Proxy{
+		Client: client,
+		light:  light,
+		prt:    rootmulti.DefaultProofRuntime(),
+	}
+}
+
+func (p *Proxy) ABCIQuery(ctx context.Context, path string, data []byte) (*ctypes.ResultABCIQuery, error) {
+	return p.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+// ABCIQueryWithOptions queries the store at the given height, or the latest
+// one whose app hash is verified if 0, and verifies the proof of the result.
+// opts.Prove is ignored, as proofs are always requested. Error responses of
+// the application are returned as they are.
+func (p *Proxy) ABCIQueryWithOptions(
+	ctx context.Context,
+	path string,
+	data []byte,
+	opts rpcclient.ABCIQueryOptions,
+) (*ctypes.ResultABCIQuery, error) {
+	storeName, ok := parseStoreKeyQuery(path)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not a store key query (/.store/<store>/key)", ErrUnverifiableQuery, path)
+	}
+
+	// The app hash of a header is the one of the state after the previous
+	// block: the state at the latest height is not verifiable yet.
+	if opts.Height == 0 {
+		latest, err := p.light.Update(ctx, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		opts.Height = latest.Height - 1
+	}
+	opts.Prove = true
+
+	res, err := p.Client.ABCIQueryWithOptions(ctx, path, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	resp := res.Response
+	if resp.Error != nil {
+		return res, nil
+	}
+	if resp.Height != opts.Height {
+		return nil, fmt.Errorf("%w: node answered at height %d, expected %d", ErrUnverifiableQuery, resp.Height, opts.Height)
+	}
+	if resp.Proof == nil || len(resp.Proof.Ops) == 0 {
+		return nil, fmt.Errorf("%w: node returned no proof", ErrUnverifiableQuery)
+	}
+
+	lb, err := p.light.VerifyLightBlockAtHeight(ctx, resp.Height+1, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	keyPath := merkle.KeyPath{}.
+		AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+		AppendKey(data, merkle.KeyEncodingHex).
+		String()
+	if len(resp.Value) == 0 {
+		err = p.prt.VerifyAbsence(resp.Proof, lb.AppHash, keyPath)
+	} else {
+		err = p.prt.VerifyValue(resp.Proof, lb.AppHash, keyPath, resp.Value)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify the proof of %s at height %d, %w", keyPath, resp.Height, err)
+	}
+
+	return res, nil
+}
+
+// Block returns the block at the given height, or the latest verified one if
+// nil, once checked against the verified header.
+func (p *Proxy) Block(ctx context.Context, height *int64) (*ctypes.ResultBlock, error) {
+	lb, err := p.verifiedLightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.Client.Block(ctx, &lb.Height)
+	if err != nil {
+		return nil, err
+	}
+	if res.Block == nil || res.BlockMeta == nil {
+		return nil, fmt.Errorf("node returned an empty block at height %d", lb.Height)
+	}
+	if err := res.Block.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	if !bytes.Equal(res.Block.Hash(), lb.Hash()) {
+		return nil, fmt.Errorf("%w: block hash %X does not match the verified header %X",
+			ErrInvalidHeader, res.Block.Hash(), lb.Hash())
+	}
+	if !bytes.Equal(res.BlockMeta.BlockID.Hash, lb.Hash()) {
+		return nil, fmt.Errorf("%w: block ID %X does not match the verified header %X",
+			ErrInvalidHeader, res.BlockMeta.BlockID.Hash, lb.Hash())
+	}
+
+	return res, nil
+}
+
+// Commit returns the signed header at the given height, or the latest
+// verified one if nil, once checked against the verified header.
+func (p *Proxy) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
+	lb, err := p.verifiedLightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.Client.Commit(ctx, &lb.Height)
+	if err != nil {
+		return nil, err
+	}
+	if err := res.SignedHeader.ValidateBasic(p.light.ChainID()); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	if !bytes.Equal(res.Hash(), lb.Hash()) {
+		return nil, fmt.Errorf("%w: header hash %X does not match the verified header %X",
+			ErrInvalidHeader, res.Hash(), lb.Hash())
+	}
+	if err := lb.ValidatorSet.VerifyCommit(p.light.ChainID(), res.Commit.BlockID, lb.Height, res.Commit); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+
+	return res, nil
+}
+
+// Validators returns the validator set of the verified header at the given
+// height, or of the latest verified one if nil.
+//
+// NOTE: Unlike for a node, the latest validator set is the one of the latest
+// verified header, not the one of the next height.
+func (p *Proxy) Validators(ctx context.Context, height *int64) (*ctypes.ResultValidators, error) {
+	lb, err := p.verifiedLightBlock(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultValidators{
+		BlockHeight: lb.Height,
+		Validators:  lb.ValidatorSet.Validators,
+	}, nil
+}
+
+// verifiedLightBlock returns the light block at the given height, or the
+// latest one if nil, verifying it first if needed.
+func (p *Proxy) verifiedLightBlock(ctx context.Context, height *int64) (*LightBlock, error) {
+	if height == nil || *height == 0 {
+		return p.light.Update(ctx, time.Now())
+	}
+	return p.light.VerifyLightBlockAtHeight(ctx, *height, time.Now())
+}
+
+// parseStoreKeyQuery returns the store of a store key query path, of the form
+// /.store/<store>/key.
+func parseStoreKeyQuery(path string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	if len(parts) != 3 || parts[0] != ".store" || parts[1] == "" || parts[2] != "key" {
+		return "", false
+	}
+	return parts[1], true
+}
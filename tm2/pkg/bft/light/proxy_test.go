@@ -0,0 +1,146 @@
+package light
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"testing"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestProxy returns a proxy of a test chain whose app state is a
+// multistore with a "main" store, where "key" is set to "value<height>" at
+// each height.
+func newTestProxy(t *testing.T) (*Proxy, *mockClient) {
+	t.Helper()
+
+	ms := rootmulti.NewMultiStore(memdb.NewMemDB())
+	ms.SetStoreOptions(types.StoreOptions{PruningOptions: types.PruneNothing})
+	mainKey := types.NewStoreKey("main")
+	ms.MountStoreWithDB(mainKey, iavl.StoreConstructor, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+
+	// The app hash of a header is the one of the state after the previous
+	// block.
+	const numHeights = 5
+	appHashes := make(map[int64][]byte)
+	for height := int64(1); height < numHeights; height++ {
+		ms.GetStore(mainKey).Set(nil, []byte("key"), []byte("value"+string(rune('0'+height))))
+		appHashes[height+1] = ms.Commit().Hash
+	}
+
+	chain := newTestChain(t, repeatValSet(newTestValSet(4), numHeights+1), func(height int64) []byte {
+		return appHashes[height]
+	})
+	client := &mockClient{
+		chain: chain,
+		queryFn: func(path string, data []byte, opts rpcclient.ABCIQueryOptions) *ctypes.ResultABCIQuery {
+			res := ms.Query(abci.RequestQuery{
+				Path:   strings.TrimPrefix(path, "/.store"),
+				Data:   data,
+				Height: opts.Height,
+				Prove:  opts.Prove,
+			})
+			return &ctypes.ResultABCIQuery{Response: res}
+		},
+	}
+
+	light, err := NewClient(context.Background(), testChainID, chain.trustOptions(1), NewRPCProvider(client), NewDBStore(memdb.NewMemDB()))
+	require.NoError(t, err)
+
+	return NewProxy(client, light), client
+}
+
+func TestProxy_ABCIQuery(t *testing.T) {
+	t.Parallel()
+
+	proxy, client := newTestProxy(t)
+
+	// The latest height whose app hash is verified is the one before the
+	// latest header.
+	res, err := proxy.ABCIQuery(context.Background(), "/.store/main/key", []byte("key"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), res.Response.Height)
+	assert.Equal(t, []byte("value4"), res.Response.Value)
+
+	res, err = proxy.ABCIQueryWithOptions(context.Background(), "/.store/main/key", []byte("key"), rpcclient.ABCIQueryOptions{Height: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []byte("value2"), res.Response.Value)
+
+	// Absent keys are proven absent.
+	res, err = proxy.ABCIQuery(context.Background(), "/.store/main/key", []byte("absent"))
+	require.NoError(t, err)
+	assert.Nil(t, res.Response.Value)
+
+	t.Run("unverifiable", func(t *testing.T) {
+		t.Parallel()
+
+		for _, path := range []string{"vm/qrender", "/.store/main/subspace", "/.app/simulate"} {
+			_, err := proxy.ABCIQuery(context.Background(), path, []byte("key"))
+			assert.ErrorIs(t, err, ErrUnverifiableQuery, path)
+		}
+	})
+
+	t.Run("forged", func(t *testing.T) {
+		t.Parallel()
+
+		forging := &mockClient{
+			chain: client.chain,
+			queryFn: func(path string, data []byte, opts rpcclient.ABCIQueryOptions) *ctypes.ResultABCIQuery {
+				res := client.queryFn(path, data, opts)
+				res.Response.Value = []byte("forged")
+				return res
+			},
+		}
+		forgingProxy := NewProxy(forging, proxy.light)
+
+		_, err := forgingProxy.ABCIQuery(context.Background(), "/.store/main/key", []byte("key"))
+		assert.ErrorContains(t, err, "unable to verify the proof")
+
+		// Another value is not proven by the proof of an absence either.
+		_, err = forgingProxy.ABCIQuery(context.Background(), "/.store/main/key", []byte("absent"))
+		assert.ErrorContains(t, err, "unable to verify the proof")
+	})
+}
+
+func TestProxy_SignClient(t *testing.T) {
+	t.Parallel()
+
+	proxy, client := newTestProxy(t)
+	chain := client.chain
+	height := int64(3)
+
+	block, err := proxy.Block(context.Background(), &height)
+	require.NoError(t, err)
+	assert.Equal(t, chain.lightBlocks[3].Hash(), block.Block.Hash())
+
+	commit, err := proxy.Commit(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, chain.lightBlocks[chain.height].Hash(), commit.Hash())
+
+	vals, err := proxy.Validators(context.Background(), &height)
+	require.NoError(t, err)
+	assert.Equal(t, height, vals.BlockHeight)
+	assert.Equal(t, chain.lightBlocks[3].ValidatorSet.Validators, vals.Validators)
+
+	t.Run("forged block", func(t *testing.T) {
+		t.Parallel()
+
+		// A node serving the block at height 2 for height 3.
+		blocks := maps.Clone(chain.blocks)
+		blocks[3] = chain.blocks[2]
+		forgingProxy := NewProxy(&mockClient{chain: &testChain{blocks: blocks}}, proxy.light)
+
+		_, err := forgingProxy.Block(context.Background(), &height)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+}
@@ -0,0 +1,142 @@
+package light
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+// Store persists the light blocks verified by a Client.
+type Store interface {
+	// SaveLightBlock saves the verified light block.
+	SaveLightBlock(lb *LightBlock) error
+
+	// LightBlock returns the light block at the given height, or
+	// ErrLightBlockNotFound.
+	LightBlock(height int64) (*LightBlock, error)
+
+	// LightBlockBefore returns the light block with the greatest height lower
+	// than or equal to the given height, or ErrLightBlockNotFound.
+	LightBlockBefore(height int64) (*LightBlock, error)
+
+	// LightBlockAfter returns the light block with the lowest height greater
+	// than or equal to the given height, or ErrLightBlockNotFound.
+	LightBlockAfter(height int64) (*LightBlock, error)
+}
+
+var _ Store = (*dbStore)(nil)
+
+// dbStore is a Store persisting the light blocks in a database, indexed by
+// height.
+type dbStore struct {
+	mtx sync.Mutex
+	db  dbm.DB
+}
+
+// NewDBStore returns a Store persisting the light blocks in db.
+func NewDBStore(db dbm.DB) Store {
+	return &dbStore{db: db}
+}
+
+var (
+	lightBlockPrefix    = []byte("lb/")
+	lightBlockPrefixEnd = []byte("lb0") // end of the lightBlockPrefix range
+)
+
+// lightBlockKey returns the key of the light block at height, ordered by
+// height.
+func lightBlockKey(height int64) []byte {
+	key := make([]byte, len(lightBlockPrefix)+8)
+	copy(key, lightBlockPrefix)
+	binary.BigEndian.PutUint64(key[len(lightBlockPrefix):], uint64(height))
+	return key
+}
+
+func (s *dbStore) SaveLightBlock(lb *LightBlock) error {
+	if lb.SignedHeader == nil || lb.Height <= 0 {
+		return fmt.Errorf("invalid light block")
+	}
+
+	bz, err := amino.Marshal(lb)
+	if err != nil {
+		return fmt.Errorf("unable to marshal light block, %w", err)
+	}
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	return s.db.SetSync(lightBlockKey(lb.Height), bz)
+}
+
+func (s *dbStore) LightBlock(height int64) (*LightBlock, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	bz, err := s.db.Get(lightBlockKey(height))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("%w: height %d", ErrLightBlockNotFound, height)
+	}
+	return decodeLightBlock(bz)
+}
+
+func (s *dbStore) LightBlockBefore(height int64) (*LightBlock, error) {
+	if height <= 0 {
+		return nil, fmt.Errorf("%w: before height %d", ErrLightBlockNotFound, height)
+	}
+	end := lightBlockPrefixEnd
+	if height < math.MaxInt64 {
+		end = lightBlockKey(height + 1)
+	}
+	return s.first(true, lightBlockKey(1), end, height)
+}
+
+func (s *dbStore) LightBlockAfter(height int64) (*LightBlock, error) {
+	if height <= 0 {
+		height = 1
+	}
+	return s.first(false, lightBlockKey(height), lightBlockPrefixEnd, height)
+}
+
+// first returns the first light block of the [start, end) key range, in
+// reverse order if reverse is set.
+func (s *dbStore) first(reverse bool, start, end []byte, height int64) (*LightBlock, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	var (
+		itr dbm.Iterator
+		err error
+	)
+	if reverse {
+		itr, err = s.db.ReverseIterator(start, end)
+	} else {
+		itr, err = s.db.Iterator(start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	if !itr.Valid() {
+		if err := itr.Error(); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: around height %d", ErrLightBlockNotFound, height)
+	}
+	return decodeLightBlock(itr.Value())
+}
+
+func decodeLightBlock(bz []byte) (*LightBlock, error) {
+	lb := new(LightBlock)
+	if err := amino.Unmarshal(bz, lb); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal light block, %w", err)
+	}
+	return lb, nil
+}
@@ -0,0 +1,59 @@
+package light
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBStore(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, repeatValSet(newTestValSet(4), 10), nil)
+	store := NewDBStore(memdb.NewMemDB())
+
+	_, err := store.LightBlockBefore(9)
+	assert.ErrorIs(t, err, ErrLightBlockNotFound)
+
+	for _, height := range []int64{3, 5, 7} {
+		require.NoError(t, store.SaveLightBlock(chain.lightBlocks[height]))
+	}
+
+	lb, err := store.LightBlock(5)
+	require.NoError(t, err)
+	assert.Equal(t, chain.lightBlocks[5].Hash(), lb.Hash())
+	assert.Equal(t, chain.lightBlocks[5].ValidatorSet.Hash(), lb.ValidatorSet.Hash())
+
+	_, err = store.LightBlock(4)
+	assert.ErrorIs(t, err, ErrLightBlockNotFound)
+
+	testTable := []struct {
+		height int64
+		before int64 // 0 if none
+		after  int64 // 0 if none
+	}{
+		{1, 0, 3},
+		{3, 3, 3},
+		{4, 3, 5},
+		{7, 7, 7},
+		{8, 7, 0},
+	}
+
+	for _, testCase := range testTable {
+		lb, err := store.LightBlockBefore(testCase.height)
+		if testCase.before == 0 {
+			assert.ErrorIs(t, err, ErrLightBlockNotFound)
+		} else if assert.NoError(t, err) {
+			assert.Equal(t, testCase.before, lb.Height)
+		}
+
+		lb, err = store.LightBlockAfter(testCase.height)
+		if testCase.after == 0 {
+			assert.ErrorIs(t, err, ErrLightBlockNotFound)
+		} else if assert.NoError(t, err) {
+			assert.Equal(t, testCase.after, lb.Height)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package light
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// LightBlock is a signed header along with the validator set which signed
+// it.
+type LightBlock struct {
+	*types.SignedHeader `json:"signed_header"`
+	ValidatorSet        *types.ValidatorSet `json:"validator_set"`
+}
+
+// ValidateBasic checks that the light block is consistent: that the commit
+// is for the header, and that the validator set is the one of the header.
+//
+// NOTE: This does not check the signatures of the commit, see the Verify
+// functions.
+func (lb *LightBlock) ValidateBasic(chainID string) error {
+	if lb.SignedHeader == nil {
+		return errors.New("missing signed header")
+	}
+	if lb.ValidatorSet.IsNilOrEmpty() {
+		return errors.New("missing validator set")
+	}
+	if err := lb.SignedHeader.ValidateBasic(chainID); err != nil {
+		return err
+	}
+
+	// The validators hash does not commit to the addresses, which are used to
+	// match the precommits of the commits verified with a trusted set.
+	for _, val := range lb.ValidatorSet.Validators {
+		if val == nil || val.PubKey == nil {
+			return errors.New("missing validator public key")
+		}
+		if val.Address != val.PubKey.Address() {
+			return fmt.Errorf("%w: %s", ErrValidatorAddressInvalid, val.Address)
+		}
+	}
+	if !bytes.Equal(lb.ValidatorSet.Hash(), lb.ValidatorsHash) {
+		return fmt.Errorf("%w: %X vs %X", ErrValidatorsHashMismatch, lb.ValidatorSet.Hash(), lb.ValidatorsHash)
+	}
+	return nil
+}
+
+// VerifyAdjacent verifies the untrusted light block at the height following
+// the one of the trusted light block: it must be signed by more than 2/3 of
+// the validators the trusted header committed to as next validators.
+//
+// The trusted header must not have expired at now, given the trusting period,
+// and the untrusted header must not be ahead of now by more than
+// maxClockDrift.
+func VerifyAdjacent(
+	trusted, untrusted *LightBlock,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+) error {
+	if untrusted.Height != trusted.Height+1 {
+		return fmt.Errorf("%w: headers must be adjacent in height, got %d and %d",
+			ErrInvalidHeader, trusted.Height, untrusted.Height)
+	}
+	if err := verifyNewHeader(trusted, untrusted, trustingPeriod, now, maxClockDrift); err != nil {
+		return err
+	}
+
+	if !bytes.Equal(untrusted.LastBlockID.Hash, trusted.Hash()) {
+		return fmt.Errorf("%w: last block ID %X does not match the trusted header %X",
+			ErrInvalidHeader, untrusted.LastBlockID.Hash, trusted.Hash())
+	}
+	if !bytes.Equal(untrusted.ValidatorsHash, trusted.NextValidatorsHash) {
+		return fmt.Errorf("%w: validators hash %X does not match the next validators hash %X of the trusted header",
+			ErrInvalidHeader, untrusted.ValidatorsHash, trusted.NextValidatorsHash)
+	}
+
+	return untrusted.ValidatorSet.VerifyCommit(
+		trusted.ChainID, untrusted.Commit.BlockID, untrusted.Height, untrusted.Commit,
+	)
+}
+
+// VerifyNonAdjacent verifies the untrusted light block at a height after the
+// one following the trusted light block: it must be signed by more than 2/3
+// of its validator set, and more than 2/3 of the voting power of the trusted
+// validator set (see types.ValidatorSet.VerifyFutureCommit). If the latter
+// does not hold, the error is ErrNewValSetCantBeTrusted, and a light block in
+// between should be verified first.
+//
+// The trusted header must not have expired at now, given the trusting period,
+// and the untrusted header must not be ahead of now by more than
+// maxClockDrift.
+func VerifyNonAdjacent(
+	trusted, untrusted *LightBlock,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+) error {
+	if untrusted.Height <= trusted.Height+1 {
+		return fmt.Errorf("%w: headers must not be adjacent in height, got %d and %d",
+			ErrInvalidHeader, trusted.Height, untrusted.Height)
+	}
+	if err := verifyNewHeader(trusted, untrusted, trustingPeriod, now, maxClockDrift); err != nil {
+		return err
+	}
+
+	// Verify the commit with the new set first, so that an error of the old
+	// set below is a lack of trust rather than an invalid commit.
+	chainID := trusted.ChainID
+	blockID := untrusted.Commit.BlockID
+	if err := untrusted.ValidatorSet.VerifyCommit(chainID, blockID, untrusted.Height, untrusted.Commit); err != nil {
+		return err
+	}
+
+	err := trusted.ValidatorSet.VerifyFutureCommit(
+		untrusted.ValidatorSet, chainID, blockID, untrusted.Height, untrusted.Commit,
+	)
+	if types.IsErrTooMuchChange(err) {
+		return fmt.Errorf("%w: %w", ErrNewValSetCantBeTrusted, err)
+	}
+	return err
+}
+
+// VerifyBackwards verifies the untrusted light block at the height preceding
+// the one of the trusted light block, through the last block ID of the
+// trusted header.
+func VerifyBackwards(trusted, untrusted *LightBlock) error {
+	if untrusted.Height != trusted.Height-1 {
+		return fmt.Errorf("%w: headers must be adjacent in height, got %d and %d",
+			ErrInvalidHeader, untrusted.Height, trusted.Height)
+	}
+	if err := untrusted.ValidateBasic(trusted.ChainID); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	if !untrusted.Time.Before(trusted.Time) {
+		return fmt.Errorf("%w: header time %v is not before the trusted header time %v",
+			ErrInvalidHeader, untrusted.Time, trusted.Time)
+	}
+	if !bytes.Equal(untrusted.Hash(), trusted.LastBlockID.Hash) {
+		return fmt.Errorf("%w: header hash %X does not match the last block ID %X of the trusted header",
+			ErrInvalidHeader, untrusted.Hash(), trusted.LastBlockID.Hash)
+	}
+	return nil
+}
+
+// verifyNewHeader checks the untrusted light block against the trusted one,
+// except for the signatures of its commit.
+func verifyNewHeader(
+	trusted, untrusted *LightBlock,
+	trustingPeriod time.Duration,
+	now time.Time,
+	maxClockDrift time.Duration,
+) error {
+	if IsExpired(trusted.SignedHeader, trustingPeriod, now) {
+		return fmt.Errorf("%w: header at height %d expired at %v",
+			ErrOldHeaderExpired, trusted.Height, trusted.Time.Add(trustingPeriod))
+	}
+	if err := untrusted.ValidateBasic(trusted.ChainID); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidHeader, err)
+	}
+	if !untrusted.Time.After(trusted.Time) {
+		return fmt.Errorf("%w: header time %v is not after the trusted header time %v",
+			ErrInvalidHeader, untrusted.Time, trusted.Time)
+	}
+	if untrusted.Time.After(now.Add(maxClockDrift)) {
+		return fmt.Errorf("%w: header time %v is ahead of now %v by more than %v",
+			ErrInvalidHeader, untrusted.Time, now, maxClockDrift)
+	}
+	return nil
+}
+
+// IsExpired returns whether the header is no longer trusted at now, given the
+// trusting period.
+func IsExpired(h *types.SignedHeader, trustingPeriod time.Duration, now time.Time) bool {
+	return !h.Time.Add(trustingPeriod).After(now)
+}
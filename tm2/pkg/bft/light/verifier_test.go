@@ -0,0 +1,141 @@
+package light
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLightBlock_ValidateBasic(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, repeatValSet(newTestValSet(4), 3), nil)
+	lb := chain.lightBlocks[1]
+
+	require.NoError(t, lb.ValidateBasic(testChainID))
+	assert.Error(t, lb.ValidateBasic("other-chain"))
+
+	t.Run("other validator set", func(t *testing.T) {
+		t.Parallel()
+
+		other := &LightBlock{SignedHeader: lb.SignedHeader, ValidatorSet: newTestValSet(4).vals}
+		assert.ErrorIs(t, other.ValidateBasic(testChainID), ErrValidatorsHashMismatch)
+	})
+
+	t.Run("forged address", func(t *testing.T) {
+		t.Parallel()
+
+		vals := lb.ValidatorSet.Copy()
+		vals.Validators[0].Address = crypto.AddressFromPreimage([]byte("forged"))
+		forged := &LightBlock{SignedHeader: lb.SignedHeader, ValidatorSet: vals}
+		assert.ErrorIs(t, forged.ValidateBasic(testChainID), ErrValidatorAddressInvalid)
+	})
+}
+
+func TestVerifyAdjacent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		valsA = newTestValSet(4)
+		valsB = newTestValSet(4)
+	)
+	// The validator set changes from A to B at height 3.
+	chain := newTestChain(t, append(repeatValSet(valsA, 2), repeatValSet(valsB, 2)...), nil)
+	now := chain.now()
+
+	for height := int64(1); height < chain.height; height++ {
+		trusted, untrusted := chain.lightBlocks[height], chain.lightBlocks[height+1]
+		assert.NoError(t, VerifyAdjacent(trusted, untrusted, DefaultTrustingPeriod, now, DefaultMaxClockDrift))
+	}
+
+	trusted, untrusted := chain.lightBlocks[1], chain.lightBlocks[2]
+
+	t.Run("not adjacent", func(t *testing.T) {
+		t.Parallel()
+
+		err := VerifyAdjacent(trusted, chain.lightBlocks[3], DefaultTrustingPeriod, now, DefaultMaxClockDrift)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		t.Parallel()
+
+		err := VerifyAdjacent(trusted, untrusted, time.Minute, now, DefaultMaxClockDrift)
+		assert.ErrorIs(t, err, ErrOldHeaderExpired)
+	})
+
+	t.Run("from the future", func(t *testing.T) {
+		t.Parallel()
+
+		err := VerifyAdjacent(trusted, untrusted, DefaultTrustingPeriod, trusted.Time, DefaultMaxClockDrift)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+
+	t.Run("other validators", func(t *testing.T) {
+		t.Parallel()
+
+		// A fork of the chain signed by other validators than the next ones.
+		fork := newTestChain(t, append(repeatValSet(valsA, 1), repeatValSet(valsB, 2)...), nil)
+		err := VerifyAdjacent(trusted, fork.lightBlocks[2], DefaultTrustingPeriod, now, DefaultMaxClockDrift)
+		assert.ErrorIs(t, err, ErrInvalidHeader)
+	})
+}
+
+func TestVerifyNonAdjacent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		valsA = newTestValSet(4)
+		valsB = newTestValSet(4)
+	)
+	// The validator set changes from A to B at height 4.
+	chain := newTestChain(t, append(repeatValSet(valsA, 3), repeatValSet(valsB, 4)...), nil)
+	now := chain.now()
+
+	assert.NoError(t, VerifyNonAdjacent(
+		chain.lightBlocks[1], chain.lightBlocks[3], DefaultTrustingPeriod, now, DefaultMaxClockDrift,
+	))
+	assert.NoError(t, VerifyNonAdjacent(
+		chain.lightBlocks[4], chain.lightBlocks[6], DefaultTrustingPeriod, now, DefaultMaxClockDrift,
+	))
+
+	// The validators of height 3 committed to B, but did not sign height 5.
+	err := VerifyNonAdjacent(chain.lightBlocks[3], chain.lightBlocks[5], DefaultTrustingPeriod, now, DefaultMaxClockDrift)
+	assert.ErrorIs(t, err, ErrNewValSetCantBeTrusted)
+
+	err = VerifyNonAdjacent(chain.lightBlocks[1], chain.lightBlocks[2], DefaultTrustingPeriod, now, DefaultMaxClockDrift)
+	assert.ErrorIs(t, err, ErrInvalidHeader)
+
+	t.Run("invalid commit", func(t *testing.T) {
+		t.Parallel()
+
+		// The commit of height 3, with the validator set of height 5.
+		forged := &LightBlock{
+			SignedHeader: &types.SignedHeader{
+				Header: chain.lightBlocks[3].Header,
+				Commit: chain.lightBlocks[3].Commit,
+			},
+			ValidatorSet: valsB.vals,
+		}
+		err := VerifyNonAdjacent(chain.lightBlocks[1], forged, DefaultTrustingPeriod, now, DefaultMaxClockDrift)
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, ErrNewValSetCantBeTrusted)
+	})
+}
+
+func TestVerifyBackwards(t *testing.T) {
+	t.Parallel()
+
+	chain := newTestChain(t, repeatValSet(newTestValSet(4), 4), nil)
+
+	assert.NoError(t, VerifyBackwards(chain.lightBlocks[3], chain.lightBlocks[2]))
+	assert.ErrorIs(t, VerifyBackwards(chain.lightBlocks[3], chain.lightBlocks[1]), ErrInvalidHeader)
+
+	// A fork of the chain, whose header 2 is not the one of the chain.
+	fork := newTestChain(t, repeatValSet(newTestValSet(4), 4), nil)
+	assert.ErrorIs(t, VerifyBackwards(chain.lightBlocks[3], fork.lightBlocks[2]), ErrInvalidHeader)
+}
@@ -69,7 +69,7 @@ type CListMempool struct {
 	logger *slog.Logger
 }
 
-var _ Mempool = &CListMempool{}
+var _ GossipMempool = &CListMempool{}
 
 // CListMempoolOption sets an optional parameter on the mempool.
 type CListMempoolOption func(*CListMempool)
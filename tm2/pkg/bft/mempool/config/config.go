@@ -5,9 +5,17 @@ import "github.com/gnolang/gno/tm2/pkg/errors"
 // -----------------------------------------------------------------------------
 // MempoolConfig
 
+const (
+	// FIFOType is the mempool keeping the txs in their arrival order.
+	FIFOType = "fifo"
+	// PriorityType is the mempool ordering the txs by priority (gas price).
+	PriorityType = "priority"
+)
+
 // MempoolConfig defines the configuration options for the Tendermint mempool
 type MempoolConfig struct {
 	RootDir            string `json:"home" toml:"home"`
+	Type               string `json:"type" toml:"type" comment:"Mempool implementation: \"fifo\" keeps the txs in their arrival order, \"priority\" orders\n them by gas price, lets a tx replace a pending tx of the same sender and sequence\n by paying more, and evicts the cheapest txs when full"`
 	Recheck            bool   `json:"recheck" toml:"recheck"`
 	Broadcast          bool   `json:"broadcast" toml:"broadcast"`
 	WalPath            string `json:"wal_dir" toml:"wal_dir"`
@@ -19,6 +27,7 @@ type MempoolConfig struct {
 // DefaultMempoolConfig returns a default configuration for the Tendermint mempool
 func DefaultMempoolConfig() *MempoolConfig {
 	return &MempoolConfig{
+		Type:      FIFOType,
 		Recheck:   true,
 		Broadcast: true,
 		WalPath:   "",
@@ -50,6 +59,9 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
+	if cfg.Type != FIFOType && cfg.Type != PriorityType {
+		return errors.New("type must be %q or %q", FIFOType, PriorityType)
+	}
 	if cfg.Size < 0 {
 		return errors.New("size can't be negative")
 	}
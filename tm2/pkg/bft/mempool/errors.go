@@ -34,3 +34,16 @@ func (e MempoolIsFullError) Error() string {
 		e.numTxs, e.maxTxs,
 		e.txsBytes, e.maxTxsBytes)
 }
+
+// TxReplacementError means a tx has the sender and sequence of a pending tx in
+// the prioritized mempool, without a higher priority to replace it.
+type TxReplacementError struct {
+	priority        int64
+	pendingPriority int64
+}
+
+func (e TxReplacementError) Error() string {
+	return fmt.Sprintf(
+		"tx priority %d must be higher than the priority %d of the pending tx with the same sender and sequence",
+		e.priority, e.pendingPriority)
+}
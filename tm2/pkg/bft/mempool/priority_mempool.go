@@ -0,0 +1,864 @@
+package mempool
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	auto "github.com/gnolang/gno/tm2/pkg/autofile"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/clist"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/gnolang/gno/tm2/pkg/telemetry"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/metrics"
+)
+
+// --------------------------------------------------------------------------------
+
+// PriorityMempool is an in-memory pool for transactions before they are
+// proposed in a consensus round, like the CListMempool, but which orders them
+// by priority instead of by arrival. The priority of a tx, its sender and its
+// sequence are set by the application on CheckTx (see abci.ResponseCheckTx):
+//
+//   - txs are reaped by decreasing priority, and the txs of a sender by
+//     increasing sequence, without gaps;
+//   - a tx with the sender and sequence of a pending tx replaces it, if it
+//     has a higher priority;
+//   - when the mempool is full, the txs with the lowest priority are evicted
+//     for a tx with a higher priority.
+//
+// The txs are also kept in a concurrent list in their arrival order, which is
+// used to gossip them.
+type PriorityMempool struct {
+	config *cfg.MempoolConfig
+
+	mtx          sync.Mutex
+	proxyAppConn appconn.Mempool
+	preCheck     PreCheckFunc
+	height       int64 // the last block Update()'d to
+	maxTxBytes   int64
+
+	// The txs, updated by the abci responses.
+	txsMtx   sync.RWMutex
+	txs      *clist.CList // concurrent linked-list of good txs, to gossip
+	txsMap   map[[sha256.Size]byte]*priorityTx
+	senders  map[string][]*priorityTx // txs of each sender, by sequence
+	txsBytes int64                    // total size of mempool, in bytes
+	nextTx   uint64                   // arrival order of the next tx
+
+	// Txs being rechecked, in the order of the recheck requests.
+	// Like the recheck cursor of the CListMempool, it is mutated in serial
+	// by the abci responses.
+	recheckTxs []*priorityTx
+	rechecking int32 // for re-checking filtered txs on Update()
+
+	// notify listeners (ie. consensus) when txs are available
+	notifiedTxsAvailable bool
+	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
+
+	// Keep a cache of already-seen txs.
+	// This reduces the pressure on the proxyApp.
+	cache txCache
+
+	// A log of mempool txs
+	wal *auto.AutoFile
+
+	logger *slog.Logger
+}
+
+var _ GossipMempool = &PriorityMempool{}
+
+// PriorityMempoolOption sets an optional parameter on the mempool.
+type PriorityMempoolOption func(*PriorityMempool)
+
+// NewPriorityMempool returns a new prioritized mempool with the given
+// configuration and connection to an application.
+func NewPriorityMempool(
+	config *cfg.MempoolConfig,
+	proxyAppConn appconn.Mempool,
+	height int64,
+	maxTxBytes int64,
+	options ...PriorityMempoolOption,
+) *PriorityMempool {
+	if maxTxBytes <= 0 {
+		panic("maxTxBytes must be positive")
+	}
+	mempool := &PriorityMempool{
+		config:       config,
+		proxyAppConn: proxyAppConn,
+		txs:          clist.New(),
+		txsMap:       make(map[[sha256.Size]byte]*priorityTx),
+		senders:      make(map[string][]*priorityTx),
+		height:       height,
+		maxTxBytes:   maxTxBytes,
+		logger:       log.NewNoopLogger(),
+	}
+	if config.CacheSize > 0 {
+		mempool.cache = newMapTxCache(config.CacheSize)
+	} else {
+		mempool.cache = nopTxCache{}
+	}
+	proxyAppConn.SetResponseCallback(mempool.globalCb)
+	for _, option := range options {
+		option(mempool)
+	}
+	return mempool
+}
+
+// WithPriorityPreCheck sets a filter for the mempool to reject a tx if f(tx)
+// returns an error. This is ran before CheckTx.
+func WithPriorityPreCheck(f PreCheckFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.preCheck = f }
+}
+
+// NOTE: not thread safe - should only be called once, on startup
+func (mem *PriorityMempool) EnableTxsAvailable() {
+	mem.txsAvailable = make(chan struct{}, 1)
+}
+
+// SetLogger sets the Logger.
+func (mem *PriorityMempool) SetLogger(l *slog.Logger) {
+	mem.logger = l
+}
+
+// *panics* if can't create directory or open file.
+// *not thread safe*
+func (mem *PriorityMempool) InitWAL() {
+	walDir := mem.config.WalDir()
+	err := osm.EnsureDir(walDir, 0o700)
+	if err != nil {
+		panic(errors.Wrap(err, "Error ensuring WAL dir"))
+	}
+	af, err := auto.OpenAutoFile(walDir + "/wal")
+	if err != nil {
+		panic(errors.Wrap(err, "Error opening WAL file"))
+	}
+	mem.wal = af
+}
+
+func (mem *PriorityMempool) CloseWAL() {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if err := mem.wal.Close(); err != nil {
+		mem.logger.Error("Error closing WAL", "err", err)
+	}
+	mem.wal = nil
+}
+
+func (mem *PriorityMempool) Lock() {
+	mem.mtx.Lock()
+}
+
+func (mem *PriorityMempool) Unlock() {
+	mem.mtx.Unlock()
+}
+
+func (mem *PriorityMempool) Size() int {
+	return mem.txs.Len()
+}
+
+func (mem *PriorityMempool) MaxTxBytes() int64 {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+	return mem.maxTxBytes
+}
+
+func (mem *PriorityMempool) TxsBytes() int64 {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+	return mem.txsBytes
+}
+
+func (mem *PriorityMempool) FlushAppConn() error {
+	return mem.proxyAppConn.FlushSync()
+}
+
+func (mem *PriorityMempool) Flush() {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	mem.cache.Reset()
+
+	mem.txsMtx.Lock()
+	defer mem.txsMtx.Unlock()
+
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		mem.txs.Remove(e)
+		e.DetachPrev()
+	}
+
+	mem.txsMap = make(map[[sha256.Size]byte]*priorityTx)
+	mem.senders = make(map[string][]*priorityTx)
+	mem.txsBytes = 0
+}
+
+// PendingSequences returns the sequences of the pending txs of sender, in
+// increasing order. A new tx can replace one of them by paying more, so the
+// application may accept a tx signed at one of these sequences on CheckTx.
+func (mem *PriorityMempool) PendingSequences(sender crypto.Address) []uint64 {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	txs := mem.senders[sender.String()]
+	if len(txs) == 0 {
+		return nil
+	}
+	seqs := make([]uint64, len(txs))
+	for i, memTx := range txs {
+		seqs[i] = memTx.sequence
+	}
+	return seqs
+}
+
+// TxsFront returns the first transaction in the arrival order for peer
+// goroutines to call .NextWait() on.
+func (mem *PriorityMempool) TxsFront() *clist.CElement {
+	return mem.txs.Front()
+}
+
+// TxsWaitChan returns a channel to wait on transactions. It will be closed
+// once the mempool is not empty.
+func (mem *PriorityMempool) TxsWaitChan() <-chan struct{} {
+	return mem.txs.WaitChan()
+}
+
+// It blocks if we're waiting on Update() or Reap().
+// cb: A callback from the CheckTx command.
+//
+//	It gets called from another goroutine.
+//
+// CONTRACT: Either cb will get called, or err returned.
+func (mem *PriorityMempool) CheckTx(tx types.Tx, cb func(abci.Response)) (err error) {
+	return mem.CheckTxWithInfo(tx, cb, TxInfo{SenderID: UnknownPeerID})
+}
+
+// CheckTxWithInfo implements Mempool. Unlike the CListMempool, a full mempool
+// does not reject the tx before it is checked by the app, as it may evict txs
+// with a lower priority.
+func (mem *PriorityMempool) CheckTxWithInfo(tx types.Tx, cb func(abci.Response), txInfo TxInfo) (err error) {
+	mem.mtx.Lock()
+	// use defer to unlock mutex because application (*local client*) might panic
+	defer mem.mtx.Unlock()
+
+	// Check max tx bytes
+	if txSize := int64(len(tx)); txSize > mem.maxTxBytes {
+		return TxTooLargeError{mem.maxTxBytes, txSize}
+	}
+
+	// Check custom preCheck function
+	if mem.preCheck != nil {
+		if err := mem.preCheck(tx); err != nil {
+			return err
+		}
+	}
+
+	// CACHE
+	if !mem.cache.Push(tx) {
+		// Record a new sender for a tx we've already seen.
+		mem.txsMtx.RLock()
+		if memTx, ok := mem.txsMap[txKey(tx)]; ok {
+			memTx.senders.LoadOrStore(txInfo.SenderID, true)
+		}
+		mem.txsMtx.RUnlock()
+
+		return ErrTxInCache
+	}
+	// END CACHE
+
+	// WAL
+	if mem.wal != nil {
+		// TODO: Notify administrators when WAL fails
+		_, err := mem.wal.Write([]byte(tx))
+		if err != nil {
+			mem.logger.Error("Error writing to WAL", "err", err)
+		}
+		_, err = mem.wal.Write([]byte("\n"))
+		if err != nil {
+			mem.logger.Error("Error writing to WAL", "err", err)
+		}
+	}
+	// END WAL
+
+	// NOTE: proxyAppConn may error if tx buffer is full
+	if err = mem.proxyAppConn.Error(); err != nil {
+		return err
+	}
+
+	reqRes := mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{Tx: tx})
+	reqRes.SetCallback(mem.reqResCb(tx, txInfo.SenderID, cb))
+
+	return nil
+}
+
+// Global callback that will be called after every ABCI response.
+// See CListMempool.globalCb.
+func (mem *PriorityMempool) globalCb(req abci.Request, res abci.Response) {
+	if mem.recheckTxs == nil {
+		return
+	}
+	mem.resCbRecheck(req, res)
+}
+
+// Request specific callback that should be set on individual reqRes objects
+// to incorporate local information when processing the response.
+// See CListMempool.reqResCb.
+//
+// The response passed to externalCb has an error if the app accepted the tx,
+// but the mempool did not (ie. it could not replace a tx, or make room for it).
+func (mem *PriorityMempool) reqResCb(tx []byte, peerID uint16, externalCb func(abci.Response)) func(res abci.Response) {
+	return func(res abci.Response) {
+		if mem.recheckTxs != nil {
+			// this should never happen
+			panic("recheck txs are not nil in reqResCb")
+		}
+
+		res = mem.resCbFirstTime(tx, peerID, res)
+
+		// Passed in by the caller of CheckTx, eg. the RPC.
+		// The external callback cannot modify the result.
+		if externalCb != nil {
+			externalCb(res)
+		}
+	}
+}
+
+// callback, which is called after the app checked the tx for the first time.
+//
+// The case where the app checks the tx for the second and subsequent times is
+// handled by the resCbRecheck callback.
+func (mem *PriorityMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Response) abci.Response {
+	checkRes, ok := res.(abci.ResponseCheckTx)
+	if !ok {
+		// ignore other messages
+		return res
+	}
+	if checkRes.Error != nil {
+		// ignore bad transaction
+		mem.logger.Debug("Rejected bad transaction", "tx", txID(tx), "res", checkRes, "err", checkRes.Error)
+		// remove from cache (it might be good later)
+		mem.cache.Remove(tx)
+		return res
+	}
+
+	memTx := &priorityTx{
+		mempoolTx: &mempoolTx{
+			height:    mem.height,
+			gasWanted: checkRes.GasWanted,
+			tx:        tx,
+		},
+		priority: checkRes.Priority,
+		sender:   checkRes.Sender,
+		sequence: checkRes.Sequence,
+	}
+	memTx.senders.Store(peerID, true)
+	if err := mem.addTx(memTx); err != nil {
+		mem.logger.Debug("Rejected transaction", "tx", txID(tx), "priority", memTx.priority, "err", err)
+		// remove from cache (it might be accepted later)
+		mem.cache.Remove(tx)
+		checkRes.Error = abci.StringError(err.Error())
+		return checkRes
+	}
+	mem.logger.Debug("Added good transaction",
+		"tx", txID(tx),
+		"res", checkRes,
+		"height", memTx.height,
+		"total", mem.Size(),
+	)
+	mem.notifyTxsAvailable()
+	return res
+}
+
+// callback, which is called after the app rechecked the tx.
+//
+// The case where the app checks the tx for the first time is handled by the
+// resCbFirstTime callback.
+func (mem *PriorityMempool) resCbRecheck(req abci.Request, res abci.Response) {
+	switch res := res.(type) {
+	case abci.ResponseCheckTx:
+		tx := req.(abci.RequestCheckTx).Tx
+		memTx := mem.recheckTxs[0]
+		if !bytes.Equal(tx, memTx.tx) {
+			panic(fmt.Sprintf(
+				"Unexpected tx response from proxy during recheck\nExpected %X, got %X",
+				memTx.tx,
+				tx))
+		}
+		if res.Error != nil {
+			// Tx became invalidated due to newly committed block.
+			mem.logger.Info("Tx is no longer valid", "tx", txID(tx), "res", res, "err", res.Error)
+			mem.txsMtx.Lock()
+			// NOTE: we remove tx from the cache because it might be good later
+			mem.removeTx(memTx, true)
+			mem.txsMtx.Unlock()
+		}
+		mem.recheckTxs = mem.recheckTxs[1:]
+		if len(mem.recheckTxs) == 0 {
+			mem.recheckDone()
+		}
+	default:
+		// ignore other messages
+	}
+}
+
+// recheckDone is called once all the txs were rechecked.
+func (mem *PriorityMempool) recheckDone() {
+	mem.recheckTxs = nil
+	atomic.StoreInt32(&mem.rechecking, 0)
+	mem.logger.Debug("Done rechecking txs")
+
+	// incase the recheck removed all txs
+	if mem.Size() > 0 {
+		mem.notifyTxsAvailable()
+	}
+}
+
+// addTx adds memTx to the mempool, replacing the pending tx of its sender
+// and sequence, and evicting the txs of lower priority to make room for it.
+// It returns an error if memTx cannot replace the pending tx, or if there is
+// no room for it.
+func (mem *PriorityMempool) addTx(memTx *priorityTx) error {
+	mem.txsMtx.Lock()
+	defer mem.txsMtx.Unlock()
+
+	// The tx may be checked twice, when the cache is disabled.
+	if _, ok := mem.txsMap[txKey(memTx.tx)]; ok {
+		return ErrTxInCache
+	}
+
+	var replaced *priorityTx
+	if memTx.sender != "" {
+		txs := mem.senders[memTx.sender]
+		if i := searchSequence(txs, memTx.sequence); i < len(txs) && txs[i].sequence == memTx.sequence {
+			replaced = txs[i]
+			if memTx.priority <= replaced.priority {
+				return TxReplacementError{memTx.priority, replaced.priority}
+			}
+		}
+	}
+
+	evicted, err := mem.evictable(memTx, replaced)
+	if err != nil {
+		return err
+	}
+	if replaced != nil {
+		mem.logger.Debug("Replaced transaction", "tx", txID(replaced.tx), "by", txID(memTx.tx))
+		mem.removeTx(replaced, true)
+	}
+	for _, evictedTx := range evicted {
+		mem.logger.Debug("Evicted transaction", "tx", txID(evictedTx.tx), "priority", evictedTx.priority)
+		// remove from cache, so it can be added back once there is room
+		mem.removeTx(evictedTx, true)
+	}
+
+	memTx.order = mem.nextTx
+	mem.nextTx++
+	memTx.elem = mem.txs.PushBack(memTx.mempoolTx)
+	mem.txsMap[txKey(memTx.tx)] = memTx
+	mem.txsBytes += int64(len(memTx.tx))
+	if memTx.sender != "" {
+		txs := mem.senders[memTx.sender]
+		i := searchSequence(txs, memTx.sequence)
+		txs = append(txs, nil)
+		copy(txs[i+1:], txs[i:])
+		txs[i] = memTx
+		mem.senders[memTx.sender] = txs
+	}
+
+	// Update the telemetry
+	mem.logTelemetry()
+	return nil
+}
+
+// evictable returns the txs to evict from the mempool for memTx to fit in,
+// replacing replaced if not nil, or an error if they do not have a lower
+// priority than memTx. Only the last tx of a sender is evicted, so that its
+// remaining txs have no gaps in their sequences, and never those of the
+// sender of memTx.
+//
+// NOTE: the caller must hold txsMtx.
+func (mem *PriorityMempool) evictable(memTx, replaced *priorityTx) ([]*priorityTx, error) {
+	var (
+		numTxs   = mem.txs.Len() + 1
+		txsBytes = mem.txsBytes + int64(len(memTx.tx))
+	)
+	if replaced != nil {
+		numTxs--
+		txsBytes -= int64(len(replaced.tx))
+	}
+	fits := func() bool {
+		return numTxs <= mem.config.Size && txsBytes <= mem.config.MaxPendingTxsBytes
+	}
+	if fits() {
+		return nil, nil
+	}
+
+	candidates := &txHeap{evict: true}
+	for sender, txs := range mem.senders {
+		if sender != memTx.sender {
+			candidates.txs = append(candidates.txs, txs[len(txs)-1])
+		}
+	}
+	for _, pending := range mem.txsMap {
+		if pending.sender == "" {
+			candidates.txs = append(candidates.txs, pending)
+		}
+	}
+	heap.Init(candidates)
+
+	var evicted []*priorityTx
+	for !fits() {
+		if candidates.Len() == 0 || candidates.txs[0].priority >= memTx.priority {
+			return nil, MempoolIsFullError{
+				mem.txs.Len(), mem.config.Size,
+				mem.txsBytes, mem.config.MaxPendingTxsBytes,
+			}
+		}
+		evictedTx := heap.Pop(candidates).(*priorityTx)
+		evicted = append(evicted, evictedTx)
+		numTxs--
+		txsBytes -= int64(len(evictedTx.tx))
+
+		if evictedTx.sender != "" {
+			txs := mem.senders[evictedTx.sender]
+			if i := searchSequence(txs, evictedTx.sequence); i > 0 {
+				heap.Push(candidates, txs[i-1])
+			}
+		}
+	}
+	return evicted, nil
+}
+
+// removeTx removes memTx from the mempool, if it is still in it.
+//
+// NOTE: the caller must hold txsMtx.
+func (mem *PriorityMempool) removeTx(memTx *priorityTx, removeFromCache bool) {
+	key := txKey(memTx.tx)
+	if mem.txsMap[key] != memTx {
+		return
+	}
+
+	mem.txs.Remove(memTx.elem)
+	memTx.elem.DetachPrev()
+	delete(mem.txsMap, key)
+	mem.txsBytes -= int64(len(memTx.tx))
+	if memTx.sender != "" {
+		txs := mem.senders[memTx.sender]
+		i := searchSequence(txs, memTx.sequence)
+		txs = append(txs[:i], txs[i+1:]...)
+		if len(txs) == 0 {
+			delete(mem.senders, memTx.sender)
+		} else {
+			mem.senders[memTx.sender] = txs
+		}
+	}
+
+	if removeFromCache {
+		mem.cache.Remove(memTx.tx)
+	}
+
+	// Update the telemetry
+	mem.logTelemetry()
+}
+
+// logTelemetry logs the mempool telemetry
+func (mem *PriorityMempool) logTelemetry() {
+	if !telemetry.MetricsEnabled() {
+		return
+	}
+
+	// Log the total number of mempool transactions
+	metrics.NumMempoolTxs.Record(context.Background(), int64(mem.txs.Len()))
+
+	// Log the total number of the mempool cache transactions
+	metrics.NumCachedTxs.Record(context.Background(), int64(mem.cache.Len()))
+}
+
+func (mem *PriorityMempool) TxsAvailable() <-chan struct{} {
+	return mem.txsAvailable
+}
+
+func (mem *PriorityMempool) notifyTxsAvailable() {
+	if mem.Size() == 0 {
+		panic("notified txs available but mempool is empty!")
+	}
+	if mem.txsAvailable != nil && !mem.notifiedTxsAvailable {
+		// channel cap is 1, so this will send once
+		mem.notifiedTxsAvailable = true
+		select {
+		case mem.txsAvailable <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// ReapMaxBytesMaxGas implements Mempool, reaping the txs by priority.
+func (mem *PriorityMempool) ReapMaxBytesMaxGas(maxDataBytes, maxGas int64) types.Txs {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if maxDataBytes == 0 {
+		panic("ReapMaxBytesMaxGas requires maxDataBytes > 0")
+	}
+
+	for atomic.LoadInt32(&mem.rechecking) > 0 {
+		// TODO: Something better?
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	var totalBytes int64
+	var totalGas int64
+	txs := make([]types.Tx, 0, mem.txs.Len())
+	mem.reap(func(memTx *priorityTx) bool {
+		// Check total size requirement
+		if maxDataBytes > -1 && totalBytes+int64(len(memTx.tx)) > maxDataBytes {
+			return false
+		}
+		totalBytes += int64(len(memTx.tx))
+		// Check total gas requirement.
+		// If maxGas is negative, skip this check.
+		newTotalGas := totalGas + memTx.gasWanted
+		if maxGas > -1 && newTotalGas > maxGas {
+			return false
+		}
+		totalGas = newTotalGas
+		txs = append(txs, memTx.tx)
+		return true
+	})
+	return txs
+}
+
+// ReapMaxTxs implements Mempool, reaping the txs by priority.
+func (mem *PriorityMempool) ReapMaxTxs(maxVal int) types.Txs {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if maxVal < 0 {
+		maxVal = mem.txs.Len()
+	}
+
+	for atomic.LoadInt32(&mem.rechecking) > 0 {
+		// TODO: Something better?
+		time.Sleep(time.Millisecond * 10)
+	}
+
+	txs := make([]types.Tx, 0, min(mem.txs.Len(), maxVal))
+	mem.reap(func(memTx *priorityTx) bool {
+		if len(txs) >= maxVal {
+			return false
+		}
+		txs = append(txs, memTx.tx)
+		return true
+	})
+	return txs
+}
+
+// reap calls fn with the txs by decreasing priority, and the txs of each
+// sender by increasing sequence, until it returns false. The txs of a sender
+// after a gap in its sequences are skipped.
+func (mem *PriorityMempool) reap(fn func(memTx *priorityTx) bool) {
+	mem.txsMtx.RLock()
+	defer mem.txsMtx.RUnlock()
+
+	// Only the first tx of each sender can be reaped first.
+	next := &txHeap{txs: make([]*priorityTx, 0, len(mem.txsMap))}
+	for _, txs := range mem.senders {
+		next.txs = append(next.txs, txs[0])
+	}
+	for _, memTx := range mem.txsMap {
+		if memTx.sender == "" {
+			next.txs = append(next.txs, memTx)
+		}
+	}
+	heap.Init(next)
+
+	for next.Len() > 0 {
+		memTx := heap.Pop(next).(*priorityTx)
+		if !fn(memTx) {
+			return
+		}
+
+		if memTx.sender != "" {
+			txs := mem.senders[memTx.sender]
+			i := searchSequence(txs, memTx.sequence) + 1
+			if i < len(txs) && txs[i].sequence == memTx.sequence+1 {
+				heap.Push(next, txs[i])
+			}
+		}
+	}
+}
+
+func (mem *PriorityMempool) Update(
+	height int64,
+	txs types.Txs,
+	deliverTxResponses []abci.ResponseDeliverTx,
+	preCheck PreCheckFunc,
+	maxTxBytes int64,
+) error {
+	// Set height
+	mem.height = height
+	mem.notifiedTxsAvailable = false
+
+	if preCheck != nil {
+		mem.preCheck = preCheck
+	}
+	if maxTxBytes != 0 {
+		mem.maxTxBytes = maxTxBytes
+	}
+
+	mem.txsMtx.Lock()
+	for i, tx := range txs {
+		if deliverTxResponses[i].Error == nil {
+			// Add valid committed tx to the cache (if missing).
+			_ = mem.cache.Push(tx)
+		} else {
+			// Allow invalid transactions to be resubmitted.
+			mem.cache.Remove(tx)
+		}
+
+		// Remove committed tx from the mempool.
+		if memTx, ok := mem.txsMap[txKey(tx)]; ok {
+			mem.removeTx(memTx, false)
+		}
+	}
+	mem.txsMtx.Unlock()
+
+	// Either recheck non-committed txs to see if they became invalid
+	// or just notify there're some txs left.
+	if mem.Size() > 0 {
+		if mem.config.Recheck {
+			mem.logger.Debug("Recheck txs", "numtxs", mem.Size(), "height", height)
+			mem.recheckTxsAsync()
+			// At this point, the txs are being rechecked, and possibly
+			// removed. Before Reap(), we should wait for the recheck to end.
+		} else {
+			mem.notifyTxsAvailable()
+		}
+	}
+
+	return nil
+}
+
+// recheckTxsAsync rechecks the txs, the txs of each sender by increasing
+// sequence, so that they are valid in the check state of the app.
+func (mem *PriorityMempool) recheckTxsAsync() {
+	if mem.Size() == 0 {
+		panic("recheckTxs is called, but the mempool is empty")
+	}
+
+	mem.txsMtx.Lock()
+	recheckTxs := make([]*priorityTx, 0, len(mem.txsMap))
+	for _, memTx := range mem.txsMap {
+		// check tx size
+		if int64(len(memTx.tx)) > mem.maxTxBytes {
+			mem.removeTx(memTx, false)
+			continue
+		}
+		// run precheck
+		if mem.preCheck != nil {
+			if err := mem.preCheck(memTx.tx); err != nil {
+				mem.removeTx(memTx, false)
+				continue
+			}
+		}
+		recheckTxs = append(recheckTxs, memTx)
+	}
+	mem.txsMtx.Unlock()
+
+	sort.Slice(recheckTxs, func(i, j int) bool {
+		a, b := recheckTxs[i], recheckTxs[j]
+		if a.sender != b.sender {
+			return a.sender < b.sender
+		}
+		if a.sequence != b.sequence {
+			return a.sequence < b.sequence
+		}
+		return a.order < b.order
+	})
+	if len(recheckTxs) == 0 {
+		return
+	}
+
+	atomic.StoreInt32(&mem.rechecking, 1)
+	mem.recheckTxs = recheckTxs
+
+	// Push txs to proxyAppConn
+	// NOTE: globalCb may be called concurrently.
+	for _, memTx := range recheckTxs {
+		mem.proxyAppConn.CheckTxAsync(abci.RequestCheckTx{
+			Tx:   memTx.tx,
+			Type: abci.CheckTxTypeRecheck,
+		})
+	}
+
+	mem.proxyAppConn.FlushAsync()
+}
+
+// --------------------------------------------------------------------------------
+
+// priorityTx is a transaction of the PriorityMempool.
+type priorityTx struct {
+	*mempoolTx
+
+	elem     *clist.CElement // of the mempoolTx, in the txs to gossip
+	priority int64
+	sender   string
+	sequence uint64
+	order    uint64 // arrival order, of the txs of the same priority
+}
+
+// searchSequence returns the index of the tx of sequence in the txs of a
+// sender, or where to insert it.
+func searchSequence(txs []*priorityTx, sequence uint64) int {
+	return sort.Search(len(txs), func(i int) bool {
+		return txs[i].sequence >= sequence
+	})
+}
+
+// txHeap is a heap of txs, which pops the tx of the highest priority and
+// earliest arrival first, or, if evict, the tx of the lowest priority and
+// latest arrival.
+type txHeap struct {
+	txs   []*priorityTx
+	evict bool
+}
+
+var _ heap.Interface = (*txHeap)(nil)
+
+func (h *txHeap) Len() int { return len(h.txs) }
+
+func (h *txHeap) Less(i, j int) bool {
+	a, b := h.txs[i], h.txs[j]
+	if h.evict {
+		a, b = b, a
+	}
+	if a.priority != b.priority {
+		return a.priority > b.priority
+	}
+	return a.order < b.order
+}
+
+func (h *txHeap) Swap(i, j int) { h.txs[i], h.txs[j] = h.txs[j], h.txs[i] }
+
+func (h *txHeap) Push(x any) { h.txs = append(h.txs, x.(*priorityTx)) }
+
+func (h *txHeap) Pop() any {
+	n := len(h.txs)
+	memTx := h.txs[n-1]
+	h.txs[n-1] = nil
+	h.txs = h.txs[:n-1]
+	return memTx
+}
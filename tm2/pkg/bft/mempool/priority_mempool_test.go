@@ -0,0 +1,240 @@
+package mempool
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/abci/example/kvstore"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+// priorityApp is an app whose txs are "sender/sequence/priority" (an empty
+// sender has no sequence). On recheck, it rejects the txs of the sequences
+// before the committed ones, and records the order of the rechecked txs.
+type priorityApp struct {
+	abci.BaseApplication
+
+	mtx       sync.Mutex
+	committed map[string]uint64
+	rechecked []string
+}
+
+func newPriorityApp() *priorityApp {
+	return &priorityApp{committed: make(map[string]uint64)}
+}
+
+func (app *priorityApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
+	parts := strings.Split(string(req.Tx), "/")
+	if len(parts) != 3 {
+		res.Error = abci.StringError("invalid tx")
+		return
+	}
+	sequence, _ := strconv.ParseUint(parts[1], 10, 64)
+	priority, _ := strconv.ParseInt(parts[2], 10, 64)
+
+	app.mtx.Lock()
+	defer app.mtx.Unlock()
+
+	if req.Type == abci.CheckTxTypeRecheck {
+		app.rechecked = append(app.rechecked, string(req.Tx))
+		if parts[0] != "" && sequence < app.committed[parts[0]] {
+			res.Error = abci.StringError("invalid sequence")
+			return
+		}
+	}
+
+	res.GasWanted = 1
+	res.Priority = priority
+	res.Sender = parts[0]
+	res.Sequence = sequence
+	return
+}
+
+func newPriorityMempoolWithApp(t *testing.T, cc proxy.ClientCreator, config *cfg.MempoolConfig) *PriorityMempool {
+	t.Helper()
+
+	appConnMem, _ := cc.NewABCIClient()
+	appConnMem.SetLogger(log.NewNoopLogger().With("module", "abci-client", "connection", "mempool"))
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() { appConnMem.Stop() })
+
+	mempool := NewPriorityMempool(config, appConnMem, 0, testMaxTxBytes)
+	mempool.SetLogger(log.NewNoopLogger())
+	return mempool
+}
+
+// checkPriorityTx checks tx, and returns the error of the response, if any.
+func checkPriorityTx(t *testing.T, mempool Mempool, tx string) abci.Error {
+	t.Helper()
+
+	var resErr abci.Error
+	err := mempool.CheckTx(types.Tx(tx), func(res abci.Response) {
+		resErr = res.(abci.ResponseCheckTx).Error
+	})
+	require.NoError(t, err)
+	return resErr
+}
+
+func reapedTxs(mempool Mempool) []string {
+	var txs []string
+	for _, tx := range mempool.ReapMaxTxs(-1) {
+		txs = append(txs, string(tx))
+	}
+	return txs
+}
+
+func TestPriorityMempool_Reap(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempoolWithApp(t, proxy.NewLocalClientCreator(newPriorityApp()), cfg.TestMempoolConfig())
+
+	for _, tx := range []string{
+		"a/0/1",
+		"a/1/10",
+		"a/3/100", // after a gap
+		"b/0/5",
+		"/0/7",
+	} {
+		require.Nil(t, checkPriorityTx(t, mempool, tx))
+	}
+	assert.Equal(t, 5, mempool.Size())
+
+	// By priority, and the txs of a sender by sequence.
+	assert.Equal(t, []string{"/0/7", "b/0/5", "a/0/1", "a/1/10"}, reapedTxs(mempool))
+	assert.Len(t, mempool.ReapMaxTxs(2), 2)
+
+	// Reaping stops at the first tx exceeding the max gas or bytes.
+	assert.Len(t, mempool.ReapMaxBytesMaxGas(-1, 3), 3)
+	assert.Len(t, mempool.ReapMaxBytesMaxGas(int64(len("/0/7")+len("b/0/5")), -1), 2)
+}
+
+func TestPriorityMempool_ReapMaxBytesMaxGas(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempoolWithApp(t, proxy.NewLocalClientCreator(kvstore.NewKVStoreApplication()), cfg.TestMempoolConfig())
+
+	// Txs without priority are reaped in their arrival order, like in the
+	// CListMempool.
+	txs := checkTxs(t, mempool, 20, UnknownPeerID, true)
+	assert.Equal(t, txs, mempool.ReapMaxBytesMaxGas(-1, -1))
+	assert.Equal(t, txs[:10], mempool.ReapMaxBytesMaxGas(1e6, 10))
+	assert.Equal(t, txs[:10], mempool.ReapMaxBytesMaxGas(200, -1))
+	assert.Empty(t, mempool.ReapMaxBytesMaxGas(10, -1))
+	assert.Empty(t, mempool.ReapMaxBytesMaxGas(1e6, 0))
+}
+
+func TestPriorityMempool_Replace(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempoolWithApp(t, proxy.NewLocalClientCreator(newPriorityApp()), cfg.TestMempoolConfig())
+
+	require.Nil(t, checkPriorityTx(t, mempool, "a/0/5"))
+	require.Nil(t, checkPriorityTx(t, mempool, "a/1/5"))
+
+	// A tx of the same sender and sequence must have a higher priority.
+	err := checkPriorityTx(t, mempool, "a/0/5\x00")
+	assert.ErrorContains(t, err, "must be higher")
+
+	require.Nil(t, checkPriorityTx(t, mempool, "a/0/6"))
+	assert.Equal(t, []string{"a/0/6", "a/1/5"}, reapedTxs(mempool))
+	assert.Equal(t, int64(len("a/0/6")+len("a/1/5")), mempool.TxsBytes())
+
+	// The replaced tx is no longer gossiped.
+	var gossiped []string
+	for e := mempool.TxsFront(); e != nil; e = e.Next() {
+		gossiped = append(gossiped, string(e.Value.(*mempoolTx).tx))
+	}
+	assert.Equal(t, []string{"a/1/5", "a/0/6"}, gossiped)
+}
+
+func TestPriorityMempool_PendingSequences(t *testing.T) {
+	t.Parallel()
+
+	mempool := newPriorityMempoolWithApp(t, proxy.NewLocalClientCreator(newPriorityApp()), cfg.TestMempoolConfig())
+
+	a := crypto.AddressFromPreimage([]byte("a"))
+	b := crypto.AddressFromPreimage([]byte("b"))
+	for _, tx := range []string{a.String() + "/3/1", a.String() + "/1/1", b.String() + "/0/1"} {
+		require.Nil(t, checkPriorityTx(t, mempool, tx))
+	}
+
+	assert.Equal(t, []uint64{1, 3}, mempool.PendingSequences(a))
+	assert.Equal(t, []uint64{0}, mempool.PendingSequences(b))
+	assert.Empty(t, mempool.PendingSequences(crypto.AddressFromPreimage([]byte("c"))))
+
+	// Once committed, a tx is no longer pending.
+	mempool.Lock()
+	require.NoError(t, mempool.Update(1, types.Txs{types.Tx(b.String() + "/0/1")}, abciResponses(1, nil), nil, 0))
+	mempool.Unlock()
+	assert.Empty(t, mempool.PendingSequences(b))
+}
+
+func TestPriorityMempool_Evict(t *testing.T) {
+	t.Parallel()
+
+	config := cfg.TestMempoolConfig()
+	config.Size = 3
+	mempool := newPriorityMempoolWithApp(t, proxy.NewLocalClientCreator(newPriorityApp()), config)
+
+	require.Nil(t, checkPriorityTx(t, mempool, "a/0/5"))
+	require.Nil(t, checkPriorityTx(t, mempool, "a/1/1"))
+	require.Nil(t, checkPriorityTx(t, mempool, "b/0/3"))
+
+	// The txs of the lowest priority are evicted, the last ones of a sender
+	// first.
+	require.Nil(t, checkPriorityTx(t, mempool, "/0/4"))
+	assert.Equal(t, []string{"a/0/5", "/0/4", "b/0/3"}, reapedTxs(mempool))
+
+	// Unless they have a higher priority.
+	err := checkPriorityTx(t, mempool, "/0/3")
+	assert.ErrorContains(t, err, "mempool is full")
+
+	// Nor are the txs of the same sender.
+	require.Nil(t, checkPriorityTx(t, mempool, "b/1/10"))
+	assert.Equal(t, []string{"a/0/5", "b/0/3", "b/1/10"}, reapedTxs(mempool))
+
+	// An evicted tx is removed from the cache, and can be checked again.
+	err = checkPriorityTx(t, mempool, "/0/4")
+	assert.ErrorContains(t, err, "mempool is full")
+}
+
+func TestPriorityMempool_Recheck(t *testing.T) {
+	t.Parallel()
+
+	app := newPriorityApp()
+	mempool := newPriorityMempoolWithApp(t, proxy.NewLocalClientCreator(app), cfg.TestMempoolConfig())
+
+	for _, tx := range []string{"a/1/1", "a/2/1", "b/0/1", "a/0/1"} {
+		require.Nil(t, checkPriorityTx(t, mempool, tx))
+	}
+	// A replacement, after the next txs of the sender.
+	require.Nil(t, checkPriorityTx(t, mempool, "a/0/2"))
+
+	// The remaining txs are rechecked by sender and sequence.
+	app.committed["b"] = 1
+	mempool.Lock()
+	require.NoError(t, mempool.Update(1, types.Txs{types.Tx("b/0/1")}, abciResponses(1, nil), nil, 0))
+	mempool.Unlock()
+	assert.Equal(t, []string{"a/0/2", "a/1/1", "a/2/1"}, app.rechecked)
+	assert.Equal(t, []string{"a/0/2", "a/1/1", "a/2/1"}, reapedTxs(mempool))
+
+	// The txs which are no longer valid are removed.
+	app.committed["a"] = 2
+	app.rechecked = nil
+	mempool.Lock()
+	require.NoError(t, mempool.Update(2, types.Txs{types.Tx("c/0/1")}, abciResponses(1, nil), nil, 0))
+	mempool.Unlock()
+	assert.Equal(t, []string{"a/0/2", "a/1/1", "a/2/1"}, app.rechecked)
+	assert.Equal(t, []string{"a/2/1"}, reapedTxs(mempool))
+	assert.Equal(t, int64(len("a/2/1")), mempool.TxsBytes())
+}
@@ -34,10 +34,26 @@ const (
 type Reactor struct {
 	p2p.BaseReactor
 	config  *cfg.MempoolConfig
-	mempool *CListMempool
+	mempool GossipMempool
 	ids     *mempoolIDs
 }
 
+// GossipMempool is a Mempool whose txs are gossiped by the Reactor, in the
+// order of its concurrent list of txs. It is implemented by the CListMempool
+// and the PriorityMempool.
+type GossipMempool interface {
+	Mempool
+
+	// SetLogger sets the Logger.
+	SetLogger(l *slog.Logger)
+
+	// TxsFront returns the first tx of the list, whose value is a *mempoolTx.
+	TxsFront() *clist.CElement
+
+	// TxsWaitChan returns a channel closed once the list is not empty.
+	TxsWaitChan() <-chan struct{}
+}
+
 type mempoolIDs struct {
 	mtx       sync.RWMutex
 	peerMap   map[p2pTypes.ID]uint16
@@ -102,7 +118,7 @@ func newMempoolIDs() *mempoolIDs {
 }
 
 // NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool) *Reactor {
+func NewReactor(config *cfg.MempoolConfig, mempool GossipMempool) *Reactor {
 	memR := &Reactor{
 		config:  config,
 		mempool: mempool,
@@ -5,6 +5,7 @@ package node
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log/slog"
 	"net"
@@ -19,15 +20,19 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	"github.com/gnolang/gno/tm2/pkg/bft/privval"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
 	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
 	"github.com/gnolang/gno/tm2/pkg/p2p/discovery"
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	bc "github.com/gnolang/gno/tm2/pkg/bft/blockchain"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
 	cs "github.com/gnolang/gno/tm2/pkg/bft/consensus"
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
+	memplcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
 	rpccore "github.com/gnolang/gno/tm2/pkg/bft/rpc/core"
 	_ "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
@@ -35,6 +40,7 @@ import (
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
+	"github.com/gnolang/gno/tm2/pkg/bft/statesync"
 	"github.com/gnolang/gno/tm2/pkg/bft/store"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
@@ -56,6 +62,7 @@ const (
 	blockchainReactorName = "BLOCKCHAIN"
 	consensusReactorName  = "CONSENSUS"
 	discoveryReactorName  = "DISCOVERY"
+	stateSyncReactorName  = "STATESYNC"
 )
 
 const (
@@ -64,6 +71,7 @@ const (
 	consensusModuleName  = "consensus"
 	p2pModuleName        = "p2p"
 	discoveryModuleName  = "discovery"
+	stateSyncModuleName  = "statesync"
 )
 
 // ------------------------------------------------------------------------------
@@ -205,6 +213,8 @@ type Node struct {
 	mempool           mempl.Mempool
 	consensusState    *cs.ConsensusState   // latest consensus state
 	consensusReactor  *cs.ConsensusReactor // for participating in the consensus
+	stateSyncReactor  *statesync.Reactor   // for bootstrapping from snapshots
+	stateSync         bool                 // whether to state sync on start
 	proxyApp          appconn.AppConns     // connection to the application
 	rpcListeners      []net.Listener       // rpc servers
 	rpcEnv            *rpccore.Environment // per-node RPC handler state
@@ -258,6 +268,12 @@ func createAndStartEventStoreService(
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to create file tx event store, %w", err)
 		}
+	case kv.EventStoreType:
+		// Transaction events should be indexed, to be searched
+		txEventStore, err = kv.NewTxEventStore(cfg.TxEventStore)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create kv tx event store, %w", err)
+		}
 	default:
 		// Transaction event storing should be omitted
 		txEventStore = null.NewNullEventStore()
@@ -307,16 +323,41 @@ func onlyValidatorIsUs(state sm.State, privVal types.PrivValidator) bool {
 	return privVal.PubKey().Address() == addr
 }
 
+// isFreshNode returns whether the node has no blocks and the app no state,
+// which is required to state sync.
+func isFreshNode(state sm.State, blockStore sm.BlockStore, proxyApp appconn.AppConns) (bool, error) {
+	if blockStore.Height() != 0 || state.LastBlockHeight >= state.InitialHeight {
+		return false, nil
+	}
+	res, err := proxyApp.Query().InfoSync(abci.RequestInfo{})
+	if err != nil {
+		return false, fmt.Errorf("error calling Info: %w", err)
+	}
+	return res.LastBlockHeight == 0, nil
+}
+
 func createMempoolAndMempoolReactor(config *cfg.Config, proxyApp appconn.AppConns,
 	state sm.State, logger *slog.Logger,
-) (*mempl.Reactor, *mempl.CListMempool) {
-	mempool := mempl.NewCListMempool(
-		config.Mempool,
-		proxyApp.Mempool(),
-		state.LastBlockHeight,
-		state.ConsensusParams.Block.MaxTxBytes,
-		mempl.WithPreCheck(sm.TxPreCheck(state)),
-	)
+) (*mempl.Reactor, mempl.GossipMempool) {
+	var mempool mempl.GossipMempool
+	switch config.Mempool.Type {
+	case memplcfg.PriorityType:
+		mempool = mempl.NewPriorityMempool(
+			config.Mempool,
+			proxyApp.Mempool(),
+			state.LastBlockHeight,
+			state.ConsensusParams.Block.MaxTxBytes,
+			mempl.WithPriorityPreCheck(sm.TxPreCheck(state)),
+		)
+	default:
+		mempool = mempl.NewCListMempool(
+			config.Mempool,
+			proxyApp.Mempool(),
+			state.LastBlockHeight,
+			state.ConsensusParams.Block.MaxTxBytes,
+			mempl.WithPreCheck(sm.TxPreCheck(state)),
+		)
+	}
 	mempoolLogger := logger.With("module", mempoolModuleName)
 	mempoolReactor := mempl.NewReactor(config.Mempool, mempool)
 	mempoolReactor.SetLogger(mempoolLogger)
@@ -351,7 +392,7 @@ func createConsensusReactor(config *cfg.Config,
 	state sm.State,
 	blockExec *sm.BlockExecutor,
 	blockStore sm.BlockStore,
-	mempool *mempl.CListMempool,
+	mempool mempl.Mempool,
 	privValidator types.PrivValidator,
 	fastSync bool,
 	evsw events.EventSwitch,
@@ -433,22 +474,41 @@ func NewNode(config *cfg.Config,
 		return nil, err
 	}
 
+	// Decide whether to state sync or not: only a fresh node can, and we
+	// don't state sync when the only validator is us.
+	stateSync := config.StateSync.Enable && !onlyValidatorIsUs(state, privValidator)
+	if stateSync {
+		fresh, err := isFreshNode(state, blockStore, proxyApp)
+		if err != nil {
+			return nil, err
+		}
+		if !fresh {
+			logger.Info("Found local state, skipping state sync")
+			stateSync = false
+		}
+	}
+
 	// Create the handshaker, which calls RequestInfo, sets the AppVersion on the state,
 	// and replays any blocks as necessary to sync tendermint with the app.
+	// When state syncing, the app is restored from a snapshot instead of
+	// being initialized from genesis.
 	consensusLogger := logger.With("module", consensusModuleName)
-	if err := doHandshake(stateDB, state, blockStore, genDoc, evsw, proxyApp, consensusLogger); err != nil {
-		return nil, err
-	}
+	if !stateSync {
+		if err := doHandshake(stateDB, state, blockStore, genDoc, evsw, proxyApp, consensusLogger); err != nil {
+			return nil, err
+		}
 
-	// Reload the state. It will have the Version.Consensus.App set by the
-	// Handshake, and may have other modifications as well (ie. depending on
-	// what happened during block replay).
-	state = sm.LoadState(stateDB)
+		// Reload the state. It will have the Version.Consensus.App set by the
+		// Handshake, and may have other modifications as well (ie. depending on
+		// what happened during block replay).
+		state = sm.LoadState(stateDB)
+	}
 
 	logNodeStartupInfo(state, privValidator.PubKey(), logger, consensusLogger)
 
 	// Decide whether to fast-sync or not
 	// We don't fast-sync when the only validator is us.
+	// When state syncing, fast sync starts once the state is restored.
 	fastSync := config.FastSyncMode && !onlyValidatorIsUs(state, privValidator)
 
 	// Make MempoolReactor
@@ -465,7 +525,7 @@ func NewNode(config *cfg.Config,
 	// Make ConsensusReactor
 	consensusReactor, consensusState := createConsensusReactor(
 		config, state, blockExec, blockStore, mempool,
-		privValidator, fastSync, evsw, consensusLogger,
+		privValidator, fastSync || stateSync, evsw, consensusLogger,
 	)
 
 	// Make BlockchainReactor
@@ -473,7 +533,7 @@ func NewNode(config *cfg.Config,
 		state,
 		blockExec,
 		blockStore,
-		fastSync,
+		fastSync && !stateSync,
 		consensusReactor.SwitchToConsensus,
 		logger,
 	)
@@ -493,6 +553,19 @@ func NewNode(config *cfg.Config,
 		},
 	}
 
+	// Make StateSyncReactor, which also serves the snapshots of the app
+	stateSyncReactor := statesync.NewReactor(
+		config.StateSync,
+		proxyApp.Snapshot(),
+		proxyApp.Query(),
+	)
+	stateSyncReactor.SetLogger(logger.With("module", stateSyncModuleName))
+
+	reactors = append(reactors, nodeReactor{
+		name:    stateSyncReactorName,
+		reactor: stateSyncReactor,
+	})
+
 	nodeInfo, err := makeNodeInfo(config, nodeKey, txEventStore, genDoc, state)
 	if err != nil {
 		return nil, errors.Wrap(err, "error making NodeInfo")
@@ -606,6 +679,8 @@ func NewNode(config *cfg.Config,
 		mempool:           mempool,
 		consensusState:    consensusState,
 		consensusReactor:  consensusReactor,
+		stateSyncReactor:  stateSyncReactor,
+		stateSync:         stateSync,
 		proxyApp:          proxyApp,
 		txEventStore:      txEventStore,
 		eventStoreService: eventStoreService,
@@ -694,6 +769,11 @@ func (n *Node) OnStart() error {
 	// Dial the persistent peers
 	n.sw.DialPeers(peerAddrs...)
 
+	// Restore the state from the snapshots of the peers, in the background
+	if n.stateSync {
+		go n.runStateSync()
+	}
+
 	// If early start, wait for genesis time now (RPC+P2P already running).
 	if n.earlyStart {
 		now := tmtime.Now()
@@ -706,6 +786,59 @@ func (n *Node) OnStart() error {
 	return nil
 }
 
+// runStateSync restores the app state from a snapshot of the peers, verified
+// with a light client, and bootstraps the node at its height. It then
+// switches to fast sync, or to consensus.
+func (n *Node) runStateSync() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-n.Quit():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ssCfg := n.config.StateSync
+	initialHeight := max(n.genesisDoc.InitialHeight, 1)
+
+	stateProvider, err := statesync.NewLightStateProvider(
+		ctx,
+		n.genesisDoc.ChainID,
+		initialHeight,
+		ssCfg.RPCServers,
+		light.TrustOptions{
+			Period: ssCfg.TrustPeriod,
+			Height: ssCfg.TrustHeight,
+			Hash:   ssCfg.TrustHashBytes(),
+		},
+	)
+	if err != nil {
+		n.Logger.Error("Unable to set up state sync", "err", err)
+		return
+	}
+
+	state, commit, err := n.stateSyncReactor.Sync(ctx, stateProvider)
+	if err != nil {
+		n.Logger.Error("State sync failed", "err", err)
+		return
+	}
+
+	sm.BootstrapState(n.stateDB, state)
+	n.blockStore.SaveSeenCommit(state.LastBlockHeight, commit)
+	n.Logger.Info("State sync done", "height", state.LastBlockHeight)
+
+	if n.config.FastSyncMode {
+		bcR := n.bcReactor.(*bc.BlockchainReactor)
+		if err := bcR.SwitchToFastSync(state); err != nil {
+			n.Logger.Error("Unable to switch to fast sync", "err", err)
+		}
+		return
+	}
+	n.consensusReactor.SwitchToConsensus(state, 0)
+}
+
 // OnStop stops the Node. It implements service.Service.
 func (n *Node) OnStop() {
 	n.BaseService.OnStop()
@@ -762,6 +895,9 @@ func (n *Node) Ready() <-chan struct{} {
 // configureRPC builds the per-node RPC Environment used by this node's
 // RPC handlers and by Local clients that don't need a network connection.
 func (n *Node) configureRPC() {
+	// Only some event stores can search transactions
+	txSearcher, _ := n.txEventStore.(eventstore.TxSearcher)
+
 	n.rpcEnv = &rpccore.Environment{
 		ProxyAppQuery: n.proxyApp.Query(),
 		StateDB:       n.stateDB,
@@ -773,6 +909,7 @@ func (n *Node) configureRPC() {
 		GenDoc:        n.genesisDoc,
 		EventSwitch:   n.evsw,
 		Mempool:       n.mempool,
+		TxSearcher:    txSearcher,
 		GetFastSync:   n.consensusReactor.FastSync,
 		Logger:        n.Logger.With("module", "rpc"),
 		Config:        *n.config.RPC,
@@ -822,10 +959,7 @@ func (n *Node) startRPC() (listeners []net.Listener, err error) {
 		rpcLogger := n.Logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
 		wm := rpcserver.NewWebsocketManager(routes,
-			rpcserver.OnDisconnect(func(remoteAddr string) {
-				// any cleanup...
-				// (we used to unsubscribe from all event subscriptions)
-			}),
+			rpcserver.OnDisconnect(n.rpcEnv.UnsubscribeClient),
 			rpcserver.ReadLimit(config.MaxBodyBytes),
 		)
 		wm.SetLogger(wmLogger)
@@ -987,6 +1121,7 @@ func makeNodeInfo(
 			bcChannel,
 			cs.StateChannel, cs.DataChannel, cs.VoteChannel, cs.VoteSetBitsChannel,
 			mempl.MempoolChannel,
+			statesync.SnapshotChannel, statesync.ChunkChannel,
 		},
 		Moniker: config.Moniker,
 		Other: p2pTypes.NodeInfoOther{
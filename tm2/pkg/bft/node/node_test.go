@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"os"
@@ -15,9 +16,14 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
+	memplcfg "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	sserver "github.com/gnolang/gno/tm2/pkg/bft/privval/signer/remote/server"
 	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	eventstore "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
@@ -348,6 +354,118 @@ func TestNodeReady(t *testing.T) {
 	require.GreaterOrEqual(t, n.BlockStore().Height(), int64(1))
 }
 
+func TestNodeTxSearch(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_tx_search_test")
+	defer os.RemoveAll(config.RootDir)
+	config.TxEventStore = &eventstore.Config{
+		EventStoreType: kv.EventStoreType,
+		Params: eventstore.EventStoreParams{
+			kv.Path:    config.RootDir,
+			kv.Backend: string(dbm.MemDBBackend),
+		},
+	}
+
+	// Create & start node
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewTestingLogger(t))
+	require.NoError(t, err)
+	require.Equal(t, kv.EventStoreType, n.txEventStore.GetType())
+
+	err = n.Start()
+	require.NoError(t, err)
+	defer n.Stop()
+
+	env := n.RPCEnvironment()
+	require.NotNil(t, env.TxSearcher)
+
+	// Commit a transaction, and search it
+	tx := types.Tx("key=value")
+	require.NoError(t, n.Mempool().CheckTx(tx, nil))
+
+	query := fmt.Sprintf("tx.hash='%X'", tx.Hash())
+	require.Eventually(t, func() bool {
+		res, err := env.TxSearch(&rpctypes.Context{}, query, 0, 0, "")
+		require.NoError(t, err)
+		return res.TotalCount == 1
+	}, 10*time.Second, 50*time.Millisecond)
+
+	res, err := env.TxSearch(&rpctypes.Context{}, query, 0, 0, "")
+	require.NoError(t, err)
+	assert.Equal(t, tx, res.Txs[0].Tx)
+
+	blocks, err := env.BlockSearch(&rpctypes.Context{}, query, 0, 0, "")
+	require.NoError(t, err)
+	require.Len(t, blocks.Blocks, 1)
+	assert.Equal(t, res.Txs[0].Height, blocks.Blocks[0].Block.Height)
+}
+
+func TestNodePriorityMempool(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_priority_mempool_test")
+	defer os.RemoveAll(config.RootDir)
+	config.Mempool.Type = memplcfg.PriorityType
+
+	// Create & start node
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewTestingLogger(t))
+	require.NoError(t, err)
+	require.IsType(t, &mempl.PriorityMempool{}, n.Mempool())
+
+	err = n.Start()
+	require.NoError(t, err)
+	defer n.Stop()
+
+	// Commit a transaction
+	tx := types.Tx("key=value")
+	require.NoError(t, n.Mempool().CheckTx(tx, nil))
+
+	require.Eventually(t, func() bool {
+		_, err := n.RPCEnvironment().Tx(&rpctypes.Context{}, tx.Hash())
+		return err == nil
+	}, 10*time.Second, 50*time.Millisecond)
+}
+
+func TestNodeSubscribe(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_subscribe_test")
+	defer os.RemoveAll(config.RootDir)
+	rpcAddr := testFreeAddr(t)
+	config.RPC.ListenAddress = "tcp://" + rpcAddr
+
+	// Create & start node
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewTestingLogger(t))
+	require.NoError(t, err)
+
+	err = n.Start()
+	require.NoError(t, err)
+	defer n.Stop()
+
+	c, err := client.NewWSClient("ws://" + rpcAddr + "/websocket")
+	require.NoError(t, err)
+	defer c.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	txs, err := c.Subscribe(ctx, "tm.event='Tx'")
+	require.NoError(t, err)
+
+	// Commit a transaction, and receive its event
+	tx := types.Tx("key=value")
+	require.NoError(t, n.Mempool().CheckTx(tx, nil))
+
+	select {
+	case res := <-txs:
+		assert.Equal(t, "tm.event='Tx'", res.Query)
+		event, ok := res.Event.(types.EventTx)
+		require.True(t, ok)
+		assert.Equal(t, tx, event.Result.Tx)
+	case <-ctx.Done():
+		t.Fatal("tx event not received")
+	}
+
+	require.NoError(t, c.UnsubscribeAll(ctx))
+
+	_, ok := <-txs
+	assert.False(t, ok)
+}
+
 func TestNodeSetAppVersion(t *testing.T) {
 	config, genesisFile := cfg.ResetTestRoot("node_app_version_test")
 	defer os.RemoveAll(config.RootDir)
@@ -407,6 +407,30 @@ func (b *RPCBatch) Tx(hash []byte) error {
 	return nil
 }
 
+func (b *RPCBatch) TxSearch(query string, page, perPage int, orderBy string) error {
+	// Prepare the RPC request
+	request, err := newRequest(txSearchMethod, searchParams(query, page, perPage, orderBy))
+	if err != nil {
+		return fmt.Errorf("unable to create request, %w", err)
+	}
+
+	b.addRequest(request, &ctypes.ResultTxSearch{})
+
+	return nil
+}
+
+func (b *RPCBatch) BlockSearch(query string, page, perPage int, orderBy string) error {
+	// Prepare the RPC request
+	request, err := newRequest(blockSearchMethod, searchParams(query, page, perPage, orderBy))
+	if err != nil {
+		return fmt.Errorf("unable to create request, %w", err)
+	}
+
+	b.addRequest(request, &ctypes.ResultBlockSearch{})
+
+	return nil
+}
+
 func (b *RPCBatch) Validators(height *int64) error {
 	params := map[string]any{}
 	if height != nil {
@@ -2,7 +2,9 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -38,14 +40,33 @@ const (
 	blockResultsMethod       = "block_results"
 	commitMethod             = "commit"
 	txMethod                 = "tx"
+	txSearchMethod           = "tx_search"
+	blockSearchMethod        = "block_search"
 	validatorsMethod         = "validators"
+	subscribeMethod          = "subscribe"
+	unsubscribeMethod        = "unsubscribe"
+	unsubscribeAllMethod     = "unsubscribe_all"
 )
 
+var _ EventsClient = (*RPCClient)(nil)
+
+var errSubscriptionsUnsupported = errors.New("subscriptions require a WS client")
+
 // RPCClient encompasses common RPC client methods
 type RPCClient struct {
 	requestTimeout time.Duration
 
 	caller rpcclient.Client
+
+	subscriptions    map[string]*subscription // query -> subscription
+	subscriptionsMux sync.Mutex
+}
+
+// subscription is an active subscription of the client to the events
+// matching a query
+type subscription struct {
+	id   rpctypes.JSONRPCID // ID of the subscription request
+	done chan struct{}      // closed when the subscription is removed
 }
 
 // NewRPCClient creates a new RPC client instance with the given caller
@@ -53,6 +74,7 @@ func NewRPCClient(caller rpcclient.Client, opts ...Option) *RPCClient {
 	c := &RPCClient{
 		requestTimeout: defaultTimeout,
 		caller:         caller,
+		subscriptions:  make(map[string]*subscription),
 	}
 
 	for _, opt := range opts {
@@ -96,6 +118,13 @@ func NewWSClient(rpcURL string, opts ...Option) (*RPCClient, error) {
 
 // Close attempts to gracefully close the RPC client
 func (c *RPCClient) Close() error {
+	c.subscriptionsMux.Lock()
+	for query, sub := range c.subscriptions {
+		close(sub.done)
+		delete(c.subscriptions, query)
+	}
+	c.subscriptionsMux.Unlock()
+
 	return c.caller.Close()
 }
 
@@ -331,6 +360,26 @@ func (c *RPCClient) Tx(ctx context.Context, hash []byte) (*ctypes.ResultTx, erro
 	)
 }
 
+func (c *RPCClient) TxSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	return sendRequestCommon[ctypes.ResultTxSearch](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		txSearchMethod,
+		searchParams(query, page, perPage, orderBy),
+	)
+}
+
+func (c *RPCClient) BlockSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	return sendRequestCommon[ctypes.ResultBlockSearch](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		blockSearchMethod,
+		searchParams(query, page, perPage, orderBy),
+	)
+}
+
 func (c *RPCClient) Validators(ctx context.Context, height *int64) (*ctypes.ResultValidators, error) {
 	params := map[string]any{}
 	if height != nil {
@@ -346,6 +395,157 @@ func (c *RPCClient) Validators(ctx context.Context, height *int64) (*ctypes.Resu
 	)
 }
 
+// Subscribe subscribes to the NewBlock and Tx events matching the query (see
+// the subscribe RPC method for its syntax), and returns the channel they are
+// received on. Subscriptions require a WS client.
+//
+// The channel is closed when the subscription is cancelled, either by
+// Unsubscribe, UnsubscribeAll or Close, or by the node if the events are not
+// read fast enough.
+func (c *RPCClient) Subscribe(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error) {
+	subscriber, ok := c.caller.(rpcclient.Subscriber)
+	if !ok {
+		return nil, errSubscriptionsUnsupported
+	}
+
+	request, err := newRequest(subscribeMethod, map[string]any{"query": query})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.requestTimeout)
+	defer cancel()
+
+	_, responses, err := subscriber.Subscribe(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("unable to call RPC method %s, %w", subscribeMethod, err)
+	}
+
+	sub := &subscription{
+		id:   request.ID,
+		done: make(chan struct{}),
+	}
+
+	c.subscriptionsMux.Lock()
+	if previous, ok := c.subscriptions[query]; ok {
+		// The node cancelled the previous subscription
+		close(previous.done)
+		subscriber.Unsubscribe(previous.id)
+	}
+	c.subscriptions[query] = sub
+	c.subscriptionsMux.Unlock()
+
+	events := make(chan ctypes.ResultEvent)
+
+	go func() {
+		defer close(events)
+
+		for {
+			var response rpctypes.RPCResponse
+
+			select {
+			case <-sub.done:
+				return
+			case response, ok = <-responses:
+			}
+
+			if !ok || response.Error != nil {
+				// The subscription was cancelled by the node,
+				// or the client was closed
+				c.removeSubscription(query, sub)
+
+				return
+			}
+
+			event, err := unmarshalResponseBytes[ctypes.ResultEvent](response.Result)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case <-sub.done:
+				return
+			case events <- *event:
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// Unsubscribe cancels the subscription to the query
+func (c *RPCClient) Unsubscribe(ctx context.Context, query string) error {
+	c.subscriptionsMux.Lock()
+	sub := c.subscriptions[query]
+	c.subscriptionsMux.Unlock()
+
+	if sub != nil {
+		c.removeSubscription(query, sub)
+	}
+
+	_, err := sendRequestCommon[ctypes.ResultUnsubscribe](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		unsubscribeMethod,
+		map[string]any{"query": query},
+	)
+
+	return err
+}
+
+// UnsubscribeAll cancels all the subscriptions of the client
+func (c *RPCClient) UnsubscribeAll(ctx context.Context) error {
+	c.subscriptionsMux.Lock()
+	subs := make(map[string]*subscription, len(c.subscriptions))
+	for query, sub := range c.subscriptions {
+		subs[query] = sub
+	}
+	c.subscriptionsMux.Unlock()
+
+	for query, sub := range subs {
+		c.removeSubscription(query, sub)
+	}
+
+	_, err := sendRequestCommon[ctypes.ResultUnsubscribe](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		unsubscribeAllMethod,
+		map[string]any{},
+	)
+
+	return err
+}
+
+// removeSubscription removes the subscription to the query, if it is still
+// sub, and stops receiving its events
+func (c *RPCClient) removeSubscription(query string, sub *subscription) {
+	c.subscriptionsMux.Lock()
+	defer c.subscriptionsMux.Unlock()
+
+	if c.subscriptions[query] != sub {
+		return
+	}
+
+	close(sub.done)
+	delete(c.subscriptions, query)
+
+	if subscriber, ok := c.caller.(rpcclient.Subscriber); ok {
+		subscriber.Unsubscribe(sub.id)
+	}
+}
+
+// searchParams returns the params of the search methods
+func searchParams(query string, page, perPage int, orderBy string) map[string]any {
+	return map[string]any{
+		"query":    query,
+		"page":     page,
+		"per_page": perPage,
+		"order_by": orderBy,
+	}
+}
+
 // newRequest creates a new request based on the method
 // and given params
 func newRequest(method string, params map[string]any) (rpctypes.RPCRequest, error) {
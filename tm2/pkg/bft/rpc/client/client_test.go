@@ -761,6 +761,89 @@ func TestRPCClient_Tx(t *testing.T) {
 	assert.Equal(t, expectedResult, result)
 }
 
+func TestRPCClient_TxSearch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		query   = "tx.height=10"
+		page    = 2
+		perPage = 5
+		orderBy = "desc"
+
+		expectedResult = &ctypes.ResultTxSearch{
+			Txs: []*ctypes.ResultTx{
+				{
+					Hash:   []byte("tx hash"),
+					Height: 10,
+				},
+			},
+			TotalCount: 6,
+		}
+
+		verifyFn = func(t *testing.T, params map[string]any) {
+			t.Helper()
+
+			assert.Equal(t, query, params["query"])
+			assert.Equal(t, fmt.Sprintf("%d", page), params["page"])
+			assert.Equal(t, fmt.Sprintf("%d", perPage), params["per_page"])
+			assert.Equal(t, orderBy, params["order_by"])
+		}
+
+		mockClient = generateMockRequestClient(
+			t,
+			txSearchMethod,
+			verifyFn,
+			expectedResult,
+		)
+	)
+
+	// Create the client
+	c := NewRPCClient(mockClient)
+
+	// Get the result
+	result, err := c.TxSearch(context.Background(), query, page, perPage, orderBy)
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResult, result)
+}
+
+func TestRPCClient_BlockSearch(t *testing.T) {
+	t.Parallel()
+
+	var (
+		query = "message.type='exec'"
+
+		expectedResult = &ctypes.ResultBlockSearch{
+			TotalCount: 10,
+		}
+
+		verifyFn = func(t *testing.T, params map[string]any) {
+			t.Helper()
+
+			assert.Equal(t, query, params["query"])
+			assert.Equal(t, "1", params["page"])
+			assert.Equal(t, "30", params["per_page"])
+			assert.Equal(t, "", params["order_by"])
+		}
+
+		mockClient = generateMockRequestClient(
+			t,
+			blockSearchMethod,
+			verifyFn,
+			expectedResult,
+		)
+	)
+
+	// Create the client
+	c := NewRPCClient(mockClient)
+
+	// Get the result
+	result, err := c.BlockSearch(context.Background(), query, 1, 30, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedResult, result)
+}
+
 func TestRPCClient_Validators(t *testing.T) {
 	t.Parallel()
 
@@ -869,3 +952,133 @@ func TestRPCClient_Batch(t *testing.T) {
 		assert.Equal(t, expectedStatuses[index], castResult)
 	}
 }
+
+func TestRPCClient_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("not a WS client", func(t *testing.T) {
+		t.Parallel()
+
+		c := NewRPCClient(&mockClient{})
+
+		_, err := c.Subscribe(context.Background(), "tm.event='Tx'")
+		assert.ErrorIs(t, err, errSubscriptionsUnsupported)
+	})
+
+	t.Run("events received until unsubscribed", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			query = "tm.event='NewBlock'"
+
+			expectedEvent = ctypes.ResultEvent{
+				Query: query,
+				Event: bfttypes.EventNewBlock{
+					Block: &bfttypes.Block{
+						Header: bfttypes.Header{Height: 10},
+					},
+				},
+			}
+
+			responses    = make(chan types.RPCResponse, 1)
+			unsubscribed = make(chan types.JSONRPCID, 1)
+			subscribeID  types.JSONRPCID
+		)
+
+		mockClient := &mockSubscriber{
+			mockClient: mockClient{
+				sendRequestFn: func(_ context.Context, request types.RPCRequest) (*types.RPCResponse, error) {
+					require.Equal(t, unsubscribeMethod, request.Method)
+
+					var params map[string]any
+					require.NoError(t, json.Unmarshal(request.Params, &params))
+					assert.Equal(t, query, params["query"])
+
+					return &types.RPCResponse{
+						JSONRPC: "2.0",
+						ID:      request.ID,
+						Result:  json.RawMessage("{}"),
+					}, nil
+				},
+			},
+			subscribeFn: func(
+				_ context.Context,
+				request types.RPCRequest,
+			) (*types.RPCResponse, <-chan types.RPCResponse, error) {
+				require.Equal(t, subscribeMethod, request.Method)
+
+				var params map[string]any
+				require.NoError(t, json.Unmarshal(request.Params, &params))
+				assert.Equal(t, query, params["query"])
+
+				subscribeID = request.ID
+
+				return &types.RPCResponse{JSONRPC: "2.0", ID: request.ID}, responses, nil
+			},
+			unsubscribeFn: func(id types.JSONRPCID) {
+				unsubscribed <- id
+			},
+		}
+
+		// Create the client
+		c := NewRPCClient(mockClient)
+
+		events, err := c.Subscribe(context.Background(), query)
+		require.NoError(t, err)
+
+		// Stream an event
+		result, err := amino.MarshalJSON(expectedEvent)
+		require.NoError(t, err)
+
+		responses <- types.RPCResponse{
+			JSONRPC: "2.0",
+			ID:      types.EventID(subscribeID),
+			Result:  result,
+		}
+
+		select {
+		case event := <-events:
+			assert.Equal(t, expectedEvent, event)
+		case <-time.After(5 * time.Second):
+			t.Fatal("event not received")
+		}
+
+		// Unsubscribe, which closes the events channel
+		require.NoError(t, c.Unsubscribe(context.Background(), query))
+
+		assert.Equal(t, subscribeID, <-unsubscribed)
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("subscription cancelled by the node", func(t *testing.T) {
+		t.Parallel()
+
+		responses := make(chan types.RPCResponse, 1)
+
+		mockClient := &mockSubscriber{
+			subscribeFn: func(
+				_ context.Context,
+				request types.RPCRequest,
+			) (*types.RPCResponse, <-chan types.RPCResponse, error) {
+				return &types.RPCResponse{JSONRPC: "2.0", ID: request.ID}, responses, nil
+			},
+		}
+
+		// Create the client
+		c := NewRPCClient(mockClient)
+
+		events, err := c.Subscribe(context.Background(), "tm.event='Tx'")
+		require.NoError(t, err)
+
+		responses <- types.RPCInternalError(nil, fmt.Errorf("subscription cancelled"))
+
+		select {
+		case _, ok := <-events:
+			assert.False(t, ok)
+		case <-time.After(5 * time.Second):
+			t.Fatal("events channel not closed")
+		}
+	})
+}
@@ -411,6 +411,30 @@ func TestRPCClient_E2E_Endpoints(t *testing.T) {
 				assert.Equal(t, expectedResult, result)
 			},
 		},
+		{
+			txSearchMethod,
+			&ctypes.ResultTxSearch{
+				TotalCount: 10,
+			},
+			func(client *RPCClient, expectedResult any) {
+				result, err := client.TxSearch(context.Background(), "tx.height=10", 1, 30, "asc")
+				require.NoError(t, err)
+
+				assert.Equal(t, expectedResult, result)
+			},
+		},
+		{
+			blockSearchMethod,
+			&ctypes.ResultBlockSearch{
+				TotalCount: 10,
+			},
+			func(client *RPCClient, expectedResult any) {
+				result, err := client.BlockSearch(context.Background(), "tx.height=10", 1, 30, "asc")
+				require.NoError(t, err)
+
+				assert.Equal(t, expectedResult, result)
+			},
+		},
 		{
 			validatorsMethod,
 			&ctypes.ResultValidators{
@@ -136,3 +136,11 @@ func (c *Local) Validators(_ context.Context, height *int64) (*ctypes.ResultVali
 func (c *Local) Tx(_ context.Context, hash []byte) (*ctypes.ResultTx, error) {
 	return c.env.Tx(c.ctx, hash)
 }
+
+func (c *Local) TxSearch(_ context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	return c.env.TxSearch(c.ctx, query, page, perPage, orderBy)
+}
+
+func (c *Local) BlockSearch(_ context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	return c.env.BlockSearch(c.ctx, query, page, perPage, orderBy)
+}
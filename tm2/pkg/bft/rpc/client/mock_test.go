@@ -10,6 +10,8 @@ type (
 	sendRequestDelegate func(context.Context, types.RPCRequest) (*types.RPCResponse, error)
 	sendBatchDelegate   func(context.Context, types.RPCRequests) (types.RPCResponses, error)
 	closeDelegate       func() error
+	subscribeDelegate   func(context.Context, types.RPCRequest) (*types.RPCResponse, <-chan types.RPCResponse, error)
+	unsubscribeDelegate func(types.JSONRPCID)
 )
 
 type mockClient struct {
@@ -41,3 +43,27 @@ func (m *mockClient) Close() error {
 
 	return nil
 }
+
+type mockSubscriber struct {
+	mockClient
+
+	subscribeFn   subscribeDelegate
+	unsubscribeFn unsubscribeDelegate
+}
+
+func (m *mockSubscriber) Subscribe(
+	ctx context.Context,
+	request types.RPCRequest,
+) (*types.RPCResponse, <-chan types.RPCResponse, error) {
+	if m.subscribeFn != nil {
+		return m.subscribeFn(ctx, request)
+	}
+
+	return nil, nil, nil
+}
+
+func (m *mockSubscriber) Unsubscribe(id types.JSONRPCID) {
+	if m.unsubscribeFn != nil {
+		m.unsubscribeFn(id)
+	}
+}
@@ -19,10 +19,8 @@ var DefaultABCIQueryOptions = ABCIQueryOptions{Height: 0, Prove: false}
 
 // Client wraps most important rpc calls a client would make.
 //
-// NOTE: Events cannot be subscribed to from the RPC APIs. For events
-// subscriptions and filters and queries, an external API must be used that
-// first synchronously consumes the events from the node's synchronous event
-// switch, or reads logged events from the filesystem.
+// NOTE: Events can only be subscribed to over a websocket connection, see
+// EventsClient.
 type Client interface {
 	ABCIClient
 	HistoryClient
@@ -89,4 +87,14 @@ type MempoolClient interface {
 
 type TxClient interface {
 	Tx(ctx context.Context, hash []byte) (*ctypes.ResultTx, error)
+	TxSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error)
+	BlockSearch(ctx context.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error)
+}
+
+// EventsClient subscribes to the NewBlock and Tx events of the node matching
+// a query, which requires a websocket connection.
+type EventsClient interface {
+	Subscribe(ctx context.Context, query string) (<-chan ctypes.ResultEvent, error)
+	Unsubscribe(ctx context.Context, query string) error
+	UnsubscribeAll(ctx context.Context) error
 }
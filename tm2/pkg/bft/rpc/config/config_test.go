@@ -28,6 +28,8 @@ func TestRPCConfigValidateBasic(t *testing.T) {
 	}{
 		{"negative grpc_max_open_connections", func(c *RPCConfig) { c.GRPCMaxOpenConnections = -1 }},
 		{"negative max_open_connections", func(c *RPCConfig) { c.MaxOpenConnections = -1 }},
+		{"negative max_subscription_clients", func(c *RPCConfig) { c.MaxSubscriptionClients = -1 }},
+		{"negative max_subscriptions_per_client", func(c *RPCConfig) { c.MaxSubscriptionsPerClient = -1 }},
 		{"negative timeout_broadcast_tx_commit", func(c *RPCConfig) { c.TimeoutBroadcastTxCommit = -time.Second }},
 		{"negative idle_timeout", func(c *RPCConfig) { c.IdleTimeout = -time.Second }},
 		{"negative max_body_bytes", func(c *RPCConfig) { c.MaxBodyBytes = -1 }},
@@ -153,6 +153,49 @@ func (env *Environment) BlockResults(ctx *rpctypes.Context, heightPtr *int64) (*
 	return res, nil
 }
 
+// BlockSearch allows you to search for the blocks with transactions matching
+// the query, by pages of perPage blocks. orderBy is either "asc" (default) or
+// "desc", by height. See the kv event store for the query syntax.
+func (env *Environment) BlockSearch(ctx *rpctypes.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultBlockSearch, error) {
+	_, span := traces.Tracer().Start(ctx.Context(), "BlockSearch")
+	defer span.End()
+	if env.TxSearcher == nil {
+		return nil, errTxIndexingDisabled
+	}
+
+	desc, err := parseOrderBy(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, perPage := searchPage(page, perPage)
+	heights, totalCount, err := env.TxSearcher.SearchBlocks(query, offset, perPage, desc)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validatePage(page, perPage, totalCount); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*ctypes.ResultBlock, 0, len(heights))
+	for _, height := range heights {
+		blockMeta := env.BlockStore.LoadBlockMeta(height)
+		if blockMeta == nil {
+			return nil, fmt.Errorf("block meta not found for height %d", height)
+		}
+		block := env.BlockStore.LoadBlock(height)
+		if block == nil {
+			return nil, fmt.Errorf("block not found for height %d", height)
+		}
+		blocks = append(blocks, &ctypes.ResultBlock{BlockMeta: blockMeta, Block: block})
+	}
+
+	return &ctypes.ResultBlockSearch{
+		Blocks:     blocks,
+		TotalCount: totalCount,
+	}, nil
+}
+
 func getHeight(currentHeight int64, heightPtr *int64) (int64, error) {
 	return getHeightWithMin(currentHeight, heightPtr, 1)
 }
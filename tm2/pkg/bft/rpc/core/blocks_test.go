@@ -197,6 +197,75 @@ func TestBlockHandler(t *testing.T) {
 	})
 }
 
+func TestBlockSearchHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("indexing disabled", func(t *testing.T) {
+		t.Parallel()
+
+		env := &Environment{}
+
+		result, err := env.BlockSearch(&rpctypes.Context{}, "tx.height=1", 0, 0, "")
+		require.Nil(t, result)
+		assert.ErrorIs(t, err, errTxIndexingDisabled)
+	})
+
+	t.Run("nil block", func(t *testing.T) {
+		t.Parallel()
+
+		env := &Environment{
+			BlockStore: &mockBlockStore{
+				loadBlockMetaFn: func(height int64) *types.BlockMeta {
+					return &types.BlockMeta{Header: types.Header{Height: height}}
+				},
+			},
+			TxSearcher: &mockTxSearcher{
+				searchBlocksFn: func(string, int, int, bool) ([]int64, int, error) {
+					return []int64{5}, 1, nil
+				},
+			},
+		}
+
+		result, err := env.BlockSearch(&rpctypes.Context{}, "tx.height=5", 0, 0, "")
+		require.Nil(t, result)
+		assert.ErrorContains(t, err, "block not found for height 5")
+	})
+
+	t.Run("blocks found", func(t *testing.T) {
+		t.Parallel()
+
+		env := &Environment{
+			BlockStore: &mockBlockStore{
+				loadBlockMetaFn: func(height int64) *types.BlockMeta {
+					return &types.BlockMeta{Header: types.Header{Height: height}}
+				},
+				loadBlockFn: func(height int64) *types.Block {
+					return &types.Block{Header: types.Header{Height: height}}
+				},
+			},
+			TxSearcher: &mockTxSearcher{
+				searchBlocksFn: func(query string, offset, limit int, desc bool) ([]int64, int, error) {
+					assert.Equal(t, "message.type='exec'", query)
+					assert.Equal(t, 0, offset)
+					assert.Equal(t, defaultPerPage, limit)
+					assert.False(t, desc)
+
+					return []int64{3, 7}, 2, nil
+				},
+			},
+		}
+
+		result, err := env.BlockSearch(&rpctypes.Context{}, "message.type='exec'", 0, 0, "asc")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Equal(t, 2, result.TotalCount)
+		require.Len(t, result.Blocks, 2)
+		assert.Equal(t, int64(3), result.Blocks[0].Block.Height)
+		assert.Equal(t, int64(7), result.Blocks[1].BlockMeta.Header.Height)
+	})
+}
+
 func TestCommitHandler(t *testing.T) {
 	t.Run("nil block meta", func(t *testing.T) {
 		var height int64 = 5
@@ -64,6 +64,21 @@ JSONRPC requests can be POST'd to the root RPC endpoint via HTTP (e.g. `http://l
 
 JSONRPC requests can be made via websocket. The websocket endpoint is at `/websocket`, e.g. `localhost:26657/websocket`.
 
+Websocket connections can also subscribe to the `NewBlock` and `Tx` events matching a query, with the `subscribe`,
+`unsubscribe` and `unsubscribe_all` methods. The events are streamed as responses whose ID is the one of the
+`subscribe` request, suffixed with `#event`.
+
+```json
+
+	{
+		"method": "subscribe",
+		"jsonrpc": "2.0",
+		"params": [ "tm.event='Tx' AND event.pkg_path='gno.land/r/demo/foo'" ],
+		"id": "0"
+	}
+
+```
+
 ## More Examples
 
 See the various bash tests using curl in `test/`, and examples using the `Go` API in `rpc/client/`.
@@ -95,6 +110,7 @@ Available endpoints:
 Endpoints that require arguments:
 /abci_query?path=_&data=_&prove=_
 /block?height=_
+/block_search?query=_&page=_&per_page=_&order_by=_
 /blockchain?minHeight=_&maxHeight=_
 /broadcast_tx_async?tx=_
 /broadcast_tx_commit?tx=_
@@ -103,6 +119,7 @@ Endpoints that require arguments:
 /dial_seeds?seeds=_
 /dial_persistent_peers?persistent_peers=_
 /tx?hash=_&prove=_
+/tx_search?query=_&page=_&per_page=_&order_by=_
 /unsafe_start_cpu_profiler?filename=_
 /unsafe_write_heap_profile?filename=_
 ```
@@ -0,0 +1,362 @@
+package core
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/random"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/traces"
+)
+
+// Keys of the conditions of subscription queries, in addition to the ones of
+// kv.Query.
+const (
+	keyEvent        = "tm.event"       // kind of event: NewBlock or Tx
+	keyEventType    = "event.type"     // type of an event emitted by the tx
+	keyEventPkgPath = "event.pkg_path" // package which emitted an event
+)
+
+// Kinds of events which can be subscribed to.
+const (
+	eventNewBlock = "NewBlock"
+	eventTx       = "Tx"
+)
+
+var (
+	errSubscriptionsDisabled = errors.New("subscriptions unavailable: no EventSwitch configured")
+	errNotWebsocket          = errors.New("subscriptions are only available over a websocket connection")
+	errAlreadySubscribed     = errors.New("already subscribed to query")
+	errNotSubscribed         = errors.New("not subscribed to query")
+	errSubscriptionCancelled = errors.New("subscription cancelled: the client is too slow to read its events")
+)
+
+// subscription is the subscription of a websocket connection to the events
+// matching a query.
+type subscription struct {
+	listenerID string
+	query      *kv.Query
+}
+
+// Subscribe subscribes the websocket connection to the NewBlock and Tx events
+// matching the query, which are then streamed to the connection as
+// ctypes.ResultEvent responses, with the rpctypes.EventID of the request.
+//
+// The syntax of the query is the one of tx_search, with the additional keys
+// tm.event ('NewBlock' or 'Tx'), event.type and event.pkg_path, which match
+// the events emitted by transactions, and <event type>.<attribute key>, which
+// matches all the attributes of the events and not only indexed ones. Only
+// the tm.event and height conditions match NewBlock events.
+//
+// A subscription is cancelled if the connection does not read its events
+// fast enough, after an error response is streamed.
+func (env *Environment) Subscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+	_, span := traces.Tracer().Start(ctx.Context(), "Subscribe")
+	defer span.End()
+	if env.EventSwitch == nil {
+		return nil, errSubscriptionsDisabled
+	}
+	if ctx.WSConn == nil {
+		return nil, errNotWebsocket
+	}
+
+	q, err := parseSubscriptionQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	env.subsMtx.Lock()
+	defer env.subsMtx.Unlock()
+
+	addr := ctx.RemoteAddr()
+	subs, ok := env.subscriptions[addr]
+	switch {
+	case !ok && env.Config.MaxSubscriptionClients > 0 &&
+		len(env.subscriptions) >= env.Config.MaxSubscriptionClients:
+		return nil, fmt.Errorf("max_subscription_clients %d reached", env.Config.MaxSubscriptionClients)
+	case env.Config.MaxSubscriptionsPerClient > 0 &&
+		len(subs) >= env.Config.MaxSubscriptionsPerClient:
+		return nil, fmt.Errorf("max_subscriptions_per_client %d reached", env.Config.MaxSubscriptionsPerClient)
+	}
+	if _, ok := subs[query]; ok {
+		return nil, errAlreadySubscribed
+	}
+
+	sub := &subscription{
+		listenerID: fmt.Sprintf("rpc-subscription#%v", random.RandStr(6)),
+		query:      q,
+	}
+	if env.subscriptions == nil {
+		env.subscriptions = make(map[string]map[string]*subscription)
+	}
+	if subs == nil {
+		subs = make(map[string]*subscription)
+		env.subscriptions[addr] = subs
+	}
+	subs[query] = sub
+
+	var (
+		conn    = ctx.WSConn
+		eventID = rpctypes.EventID(ctx.JSONReq.ID)
+	)
+	env.EventSwitch.AddListener(sub.listenerID, func(event events.Event) {
+		if !matchEvent(sub.query, event) {
+			return
+		}
+
+		resp := rpctypes.NewRPCSuccessResponse(eventID, ctypes.ResultEvent{Query: query, Event: event})
+		if conn.TryWriteRPCResponses(rpctypes.RPCResponses{resp}) {
+			return
+		}
+
+		// The connection is not reading its events fast enough.
+		if !env.removeSubscription(addr, query, sub) {
+			return // already removed
+		}
+		env.Logger.Info("Cancelled slow subscription", "remote", addr, "query", query)
+		go conn.WriteRPCResponses(rpctypes.RPCResponses{
+			rpctypes.RPCInternalError(eventID, errSubscriptionCancelled),
+		})
+	})
+
+	return &ctypes.ResultSubscribe{}, nil
+}
+
+// Unsubscribe cancels the subscription of the websocket connection to the
+// query.
+func (env *Environment) Unsubscribe(ctx *rpctypes.Context, query string) (*ctypes.ResultUnsubscribe, error) {
+	_, span := traces.Tracer().Start(ctx.Context(), "Unsubscribe")
+	defer span.End()
+	if ctx.WSConn == nil {
+		return nil, errNotWebsocket
+	}
+
+	if !env.removeSubscription(ctx.RemoteAddr(), query, nil) {
+		return nil, errNotSubscribed
+	}
+
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// UnsubscribeAll cancels all the subscriptions of the websocket connection.
+func (env *Environment) UnsubscribeAll(ctx *rpctypes.Context) (*ctypes.ResultUnsubscribe, error) {
+	_, span := traces.Tracer().Start(ctx.Context(), "UnsubscribeAll")
+	defer span.End()
+	if ctx.WSConn == nil {
+		return nil, errNotWebsocket
+	}
+
+	env.UnsubscribeClient(ctx.RemoteAddr())
+
+	return &ctypes.ResultUnsubscribe{}, nil
+}
+
+// UnsubscribeClient cancels all the subscriptions of the websocket connection
+// of the given remote address. It is meant to be called when the connection
+// is closed.
+func (env *Environment) UnsubscribeClient(remoteAddr string) {
+	env.subsMtx.Lock()
+	defer env.subsMtx.Unlock()
+
+	env.removeClientSubscriptions(remoteAddr)
+}
+
+// removeClientSubscriptions cancels all the subscriptions of the connection
+// of remoteAddr. subsMtx must be held.
+func (env *Environment) removeClientSubscriptions(remoteAddr string) {
+	for _, sub := range env.subscriptions[remoteAddr] {
+		env.EventSwitch.RemoveListener(sub.listenerID)
+	}
+	delete(env.subscriptions, remoteAddr)
+}
+
+// removeSubscription cancels the subscription of the connection of addr to
+// the query, if it is sub or sub is nil, and returns whether it was removed.
+func (env *Environment) removeSubscription(addr, query string, sub *subscription) bool {
+	env.subsMtx.Lock()
+	defer env.subsMtx.Unlock()
+
+	subs := env.subscriptions[addr]
+	current, ok := subs[query]
+	if !ok || (sub != nil && current != sub) {
+		return false
+	}
+
+	env.EventSwitch.RemoveListener(current.listenerID)
+	delete(subs, query)
+	if len(subs) == 0 {
+		delete(env.subscriptions, addr)
+	}
+
+	return true
+}
+
+// parseSubscriptionQuery parses the query of a subscription, and validates
+// its tm.event conditions.
+func parseSubscriptionQuery(query string) (*kv.Query, error) {
+	q, err := kv.ParseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cond := range q.Conditions {
+		if cond.Key != keyEvent {
+			continue
+		}
+		if cond.Value != eventNewBlock && cond.Value != eventTx {
+			return nil, fmt.Errorf("invalid %s %q in query, expected %s or %s", keyEvent, cond.Value, eventNewBlock, eventTx)
+		}
+	}
+
+	return q, nil
+}
+
+// matchEvent returns whether the event matches all the conditions of q.
+func matchEvent(q *kv.Query, event events.Event) bool {
+	switch ev := event.(type) {
+	case types.EventNewBlock:
+		for _, cond := range q.Conditions {
+			var ok bool
+			switch {
+			case cond.Key == keyEvent:
+				ok = cond.Value == eventNewBlock
+			case cond.Key == kv.KeyTxHeight || cond.Key == kv.KeyBlockHeight:
+				ok = matchHeight(cond, ev.Block.Height)
+			}
+			if !ok {
+				return false
+			}
+		}
+		return true
+	case types.EventTx:
+		m := txMatcher{result: ev.Result}
+		for _, cond := range q.Conditions {
+			if !m.match(cond) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// txMatcher matches the conditions of a query against a transaction result,
+// decoding the transaction only if a condition is on its messages.
+type txMatcher struct {
+	result  types.TxResult
+	decoded bool
+	msgs    []std.Msg
+}
+
+func (m *txMatcher) match(cond kv.Condition) bool {
+	switch cond.Key {
+	case keyEvent:
+		return cond.Value == eventTx
+	case kv.KeyTxHeight, kv.KeyBlockHeight:
+		return matchHeight(cond, m.result.Height)
+	case kv.KeyTxHash:
+		// already validated by the parser.
+		hash, _ := hex.DecodeString(cond.Value)
+		return bytes.Equal(hash, m.result.Tx.Hash())
+	case kv.KeyMessageRoute, kv.KeyMessageType, kv.KeyMessageSigner:
+		for _, msg := range m.messages() {
+			switch cond.Key {
+			case kv.KeyMessageRoute:
+				if msg.Route() == cond.Value {
+					return true
+				}
+			case kv.KeyMessageType:
+				if msg.Type() == cond.Value {
+					return true
+				}
+			default:
+				for _, signer := range msg.GetSigners() {
+					if signer.String() == cond.Value {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	default:
+		for _, event := range m.result.Response.Events {
+			if matchTxEvent(cond, event) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// messages returns the messages of the transaction, or nil if it is not a
+// std.Tx.
+func (m *txMatcher) messages() []std.Msg {
+	if !m.decoded {
+		m.decoded = true
+		var tx std.Tx
+		if err := amino.Unmarshal(m.result.Tx, &tx); err == nil {
+			m.msgs = tx.GetMsgs()
+		}
+	}
+	return m.msgs
+}
+
+// matchTxEvent returns whether an event emitted by a transaction matches
+// cond, on its type, package path or attributes.
+func matchTxEvent(cond kv.Condition, event abci.Event) bool {
+	var (
+		typ, pkgPath string
+		attrs        []abci.EventAttribute
+	)
+	switch ev := event.(type) {
+	case abci.AttributedEvent:
+		typ, pkgPath, attrs = ev.EventAttributes()
+	case abci.IndexedEvent:
+		typ, attrs = ev.IndexedAttributes()
+	default:
+		return false
+	}
+
+	switch cond.Key {
+	case keyEventType:
+		return typ == cond.Value
+	case keyEventPkgPath:
+		return pkgPath == cond.Value
+	}
+	for _, attr := range attrs {
+		if typ+"."+attr.Key == cond.Key && attr.Value == cond.Value {
+			return true
+		}
+	}
+	return false
+}
+
+func matchHeight(cond kv.Condition, height int64) bool {
+	// already validated by the parser.
+	h, _ := strconv.ParseInt(cond.Value, 10, 64)
+	switch cond.Op {
+	case kv.OpEqual:
+		return height == h
+	case kv.OpLess:
+		return height < h
+	case kv.OpLessEqual:
+		return height <= h
+	case kv.OpGreater:
+		return height > h
+	case kv.OpGreaterEqual:
+		return height >= h
+	default:
+		return false
+	}
+}
@@ -0,0 +1,297 @@
+package core
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEvent is an event emitted by a package, with attributes.
+type testEvent struct {
+	Type    string
+	PkgPath string
+	Attrs   []abci.EventAttribute
+}
+
+func (testEvent) AssertABCIEvent() {}
+
+func (e testEvent) EventAttributes() (string, string, []abci.EventAttribute) {
+	return e.Type, e.PkgPath, e.Attrs
+}
+
+var _ = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/core",
+	"core",
+	amino.GetCallersDirname(),
+).
+	WithDependencies(
+		abci.Package,
+	).
+	WithTypes(
+		testEvent{},
+	))
+
+// mockWSConn is a websocket connection which records the responses written
+// to it, and is slow if full is set.
+type mockWSConn struct {
+	remoteAddr string
+	full       bool
+	responses  chan rpctypes.RPCResponses
+}
+
+func newMockWSConn(remoteAddr string) *mockWSConn {
+	return &mockWSConn{
+		remoteAddr: remoteAddr,
+		responses:  make(chan rpctypes.RPCResponses, 10),
+	}
+}
+
+func (c *mockWSConn) GetRemoteAddr() string { return c.remoteAddr }
+
+func (c *mockWSConn) WriteRPCResponses(resp rpctypes.RPCResponses) { c.responses <- resp }
+
+func (c *mockWSConn) TryWriteRPCResponses(resp rpctypes.RPCResponses) bool {
+	if c.full {
+		return false
+	}
+	c.responses <- resp
+	return true
+}
+
+func (c *mockWSConn) Context() context.Context { return context.Background() }
+
+// wsContext returns the context of a request of the given ID over conn.
+func wsContext(conn rpctypes.WSRPCConnection, id string) *rpctypes.Context {
+	return &rpctypes.Context{
+		JSONReq: &rpctypes.RPCRequest{ID: rpctypes.JSONRPCStringID(id)},
+		WSConn:  conn,
+	}
+}
+
+func newEventsEnvironment(t *testing.T, config cfg.RPCConfig) *Environment {
+	t.Helper()
+
+	evsw := events.NewEventSwitch()
+	require.NoError(t, evsw.Start())
+	t.Cleanup(func() { evsw.Stop() })
+
+	return &Environment{
+		EventSwitch: evsw,
+		Config:      config,
+		Logger:      log.NewNoopLogger(),
+	}
+}
+
+var (
+	alice = crypto.AddressFromPreimage([]byte("alice"))
+	bob   = crypto.AddressFromPreimage([]byte("bob"))
+)
+
+// newTestEventTx returns the event of a transaction sending coins from alice
+// to bob, which emitted a transfer event from the given package.
+func newTestEventTx(t *testing.T, height int64, pkgPath string) types.EventTx {
+	t.Helper()
+
+	raw, err := amino.Marshal(std.Tx{
+		Msgs: []std.Msg{bank.NewMsgSend(alice, bob, std.MustParseCoins("1ugnot"))},
+	})
+	require.NoError(t, err)
+
+	return types.EventTx{Result: types.TxResult{
+		Height: height,
+		Tx:     raw,
+		Response: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Events: []abci.Event{
+					testEvent{
+						Type:    "Transfer",
+						PkgPath: pkgPath,
+						Attrs: []abci.EventAttribute{
+							{Key: "from", Value: alice.String()},
+							{Key: "amount", Value: "1"},
+						},
+					},
+					abci.EventString("not attributed"),
+				},
+			},
+		},
+	}}
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+
+	const query = "tm.event='Tx' AND event.pkg_path='gno.land/r/demo/foo'"
+
+	env := newEventsEnvironment(t, *cfg.DefaultRPCConfig())
+	conn := newMockWSConn("client")
+
+	_, err := env.Subscribe(wsContext(conn, "sub"), query)
+	require.NoError(t, err)
+
+	matching := newTestEventTx(t, 5, "gno.land/r/demo/foo")
+	env.EventSwitch.FireEvent(newTestEventTx(t, 4, "gno.land/r/demo/bar"))
+	env.EventSwitch.FireEvent(types.EventNewBlock{Block: &types.Block{}})
+	env.EventSwitch.FireEvent(matching)
+
+	require.Len(t, conn.responses, 1)
+	resp := <-conn.responses
+	require.Len(t, resp, 1)
+	require.Nil(t, resp[0].Error)
+	assert.Equal(t, rpctypes.EventID(rpctypes.JSONRPCStringID("sub")), resp[0].ID)
+
+	var result ctypes.ResultEvent
+	require.NoError(t, amino.UnmarshalJSON(resp[0].Result, &result))
+	assert.Equal(t, query, result.Query)
+	assert.Equal(t, matching, result.Event)
+
+	t.Run("already subscribed", func(t *testing.T) {
+		_, err := env.Subscribe(wsContext(conn, "again"), query)
+		assert.ErrorIs(t, err, errAlreadySubscribed)
+	})
+
+	t.Run("unsubscribed", func(t *testing.T) {
+		_, err := env.Unsubscribe(wsContext(conn, "unsub"), query)
+		require.NoError(t, err)
+
+		env.EventSwitch.FireEvent(matching)
+		assert.Empty(t, conn.responses)
+
+		_, err = env.Unsubscribe(wsContext(conn, "unsub"), query)
+		assert.ErrorIs(t, err, errNotSubscribed)
+	})
+}
+
+func TestSubscribe_Invalid(t *testing.T) {
+	t.Parallel()
+
+	env := newEventsEnvironment(t, *cfg.DefaultRPCConfig())
+
+	_, err := env.Subscribe(&rpctypes.Context{}, "tm.event='Tx'")
+	assert.ErrorIs(t, err, errNotWebsocket)
+
+	_, err = env.Subscribe(wsContext(newMockWSConn("client"), "sub"), "tm.event='Vote'")
+	assert.ErrorContains(t, err, "invalid tm.event")
+
+	_, err = env.Subscribe(wsContext(newMockWSConn("client"), "sub"), "tm.event>1")
+	assert.ErrorContains(t, err, "only supported for heights")
+
+	_, err = (&Environment{}).Subscribe(wsContext(newMockWSConn("client"), "sub"), "tm.event='Tx'")
+	assert.ErrorIs(t, err, errSubscriptionsDisabled)
+}
+
+func TestSubscribe_Limits(t *testing.T) {
+	t.Parallel()
+
+	config := *cfg.DefaultRPCConfig()
+	config.MaxSubscriptionClients = 1
+	config.MaxSubscriptionsPerClient = 2
+	env := newEventsEnvironment(t, config)
+
+	conn := newMockWSConn("client")
+	for _, query := range []string{"tm.event='Tx'", "tm.event='NewBlock'"} {
+		_, err := env.Subscribe(wsContext(conn, "sub"), query)
+		require.NoError(t, err)
+	}
+
+	_, err := env.Subscribe(wsContext(conn, "sub"), "tx.height>5")
+	assert.ErrorContains(t, err, "max_subscriptions_per_client 2 reached")
+
+	other := newMockWSConn("other")
+	_, err = env.Subscribe(wsContext(other, "sub"), "tm.event='Tx'")
+	assert.ErrorContains(t, err, "max_subscription_clients 1 reached")
+
+	// Unsubscribing all frees the slot of the client.
+	_, err = env.UnsubscribeAll(wsContext(conn, "unsub"))
+	require.NoError(t, err)
+
+	_, err = env.Subscribe(wsContext(other, "sub"), "tm.event='Tx'")
+	require.NoError(t, err)
+
+	// Disconnected clients free their slot too.
+	env.UnsubscribeClient(other.remoteAddr)
+
+	_, err = env.Subscribe(wsContext(conn, "sub"), "tm.event='Tx'")
+	require.NoError(t, err)
+}
+
+func TestSubscribe_SlowClient(t *testing.T) {
+	t.Parallel()
+
+	env := newEventsEnvironment(t, *cfg.DefaultRPCConfig())
+	conn := newMockWSConn("client")
+	conn.full = true
+
+	_, err := env.Subscribe(wsContext(conn, "sub"), "tm.event='NewBlock'")
+	require.NoError(t, err)
+
+	env.EventSwitch.FireEvent(types.EventNewBlock{Block: &types.Block{}})
+
+	// The subscription is cancelled, with an error streamed to the client.
+	resp := <-conn.responses
+	require.Len(t, resp, 1)
+	assert.Equal(t, rpctypes.EventID(rpctypes.JSONRPCStringID("sub")), resp[0].ID)
+	require.NotNil(t, resp[0].Error)
+	assert.Contains(t, resp[0].Error.Data, errSubscriptionCancelled.Error())
+
+	_, err = env.Unsubscribe(wsContext(conn, "unsub"), "tm.event='NewBlock'")
+	assert.ErrorIs(t, err, errNotSubscribed)
+}
+
+func TestMatchEvent(t *testing.T) {
+	t.Parallel()
+
+	tx := newTestEventTx(t, 5, "gno.land/r/demo/foo")
+	hash := strings.ToUpper(hex.EncodeToString(tx.Result.Tx.Hash()))
+	block := types.EventNewBlock{Block: &types.Block{Header: types.Header{Height: 5}}}
+
+	testTable := []struct {
+		query      string
+		matchTx    bool
+		matchBlock bool
+	}{
+		{"tm.event='Tx'", true, false},
+		{"tm.event='NewBlock'", false, true},
+		{"block.height=5", true, true},
+		{"tx.height>5", false, false},
+		{"tx.height<=5 AND tx.height>=5", true, true},
+		{"tx.hash='" + hash + "'", true, false},
+		{"tx.hash='00'", false, false},
+		{"message.route='bank' AND message.type='send'", true, false},
+		{"message.signer='" + alice.String() + "'", true, false},
+		{"message.signer='" + bob.String() + "'", false, false},
+		{"event.type='Transfer'", true, false},
+		{"event.type='Mint'", false, false},
+		{"event.pkg_path='gno.land/r/demo/foo'", true, false},
+		{"Transfer.from='" + alice.String() + "' AND Transfer.amount='1'", true, false},
+		{"Transfer.from='" + bob.String() + "'", false, false},
+		{"Mint.from='" + alice.String() + "'", false, false},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.query, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := parseSubscriptionQuery(testCase.query)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.matchTx, matchEvent(q, tx))
+			assert.Equal(t, testCase.matchBlock, matchEvent(q, block))
+		})
+	}
+}
@@ -162,3 +162,23 @@ func (m *mockTransport) NodeInfo() p2pTypes.NodeInfo {
 	}
 	return p2pTypes.NodeInfo{}
 }
+
+// mockTxSearcher implements the eventstore.TxSearcher interface for testing.
+type mockTxSearcher struct {
+	searchTxsFn    func(string, int, int, bool) ([]types.TxResult, int, error)
+	searchBlocksFn func(string, int, int, bool) ([]int64, int, error)
+}
+
+func (m *mockTxSearcher) SearchTxs(query string, offset, limit int, desc bool) ([]types.TxResult, int, error) {
+	if m.searchTxsFn != nil {
+		return m.searchTxsFn(query, offset, limit, desc)
+	}
+	return nil, 0, nil
+}
+
+func (m *mockTxSearcher) SearchBlocks(query string, offset, limit int, desc bool) ([]int64, int, error) {
+	if m.searchBlocksFn != nil {
+		return m.searchBlocksFn(query, offset, limit, desc)
+	}
+	return nil, 0, nil
+}
@@ -11,6 +11,7 @@ import (
 	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
 	cfg "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -63,6 +64,7 @@ type Environment struct {
 	Consensus    Consensus
 	P2PPeers     peers
 	P2PTransport transport
+	TxSearcher   eventstore.TxSearcher // nil if transactions are not indexed
 
 	// objects
 	PubKey      crypto.PubKey
@@ -79,6 +81,11 @@ type Environment struct {
 	txDispatcher *txDispatcher
 	started      bool
 	stopped      bool
+
+	// Event subscriptions of websocket connections, by remote address and
+	// query. See Subscribe.
+	subsMtx       sync.Mutex
+	subscriptions map[string]map[string]*subscription
 }
 
 // Start initializes any per-Environment background services. Currently this
@@ -115,6 +122,11 @@ func (env *Environment) Stop() error {
 			panic(fmt.Sprintf("txDispatcher.Stop: %v", err))
 		}
 	}
+	env.subsMtx.Lock()
+	for remoteAddr := range env.subscriptions {
+		env.removeClientSubscriptions(remoteAddr)
+	}
+	env.subsMtx.Unlock()
 	env.started = false
 	return nil
 }
@@ -139,6 +151,30 @@ func validatePage(page, perPage, totalCount int) (int, error) {
 	return page, nil
 }
 
+// searchPage returns the offset of the first result of the page of a search,
+// and the validated number of results per page. The page itself is validated
+// once the total number of results is known.
+func searchPage(page, perPage int) (offset, validPerPage int) {
+	perPage = validatePerPage(perPage)
+	if page > 1 {
+		offset = (page - 1) * perPage
+	}
+	return offset, perPage
+}
+
+// parseOrderBy returns whether the results of a search are in descending
+// order.
+func parseOrderBy(orderBy string) (bool, error) {
+	switch orderBy {
+	case "", "asc":
+		return false, nil
+	case "desc":
+		return true, nil
+	default:
+		return false, fmt.Errorf("expected order_by to be either asc or desc, given %q", orderBy)
+	}
+}
+
 func validatePerPage(perPage int) int {
 	if perPage < 1 {
 		return defaultPerPage
@@ -14,6 +14,11 @@ import (
 // profiler) are included.
 func (env *Environment) Routes(unsafe bool) map[string]*rpc.RPCFunc {
 	routes := map[string]*rpc.RPCFunc{
+		// subscribe/unsubscribe are reserved for websocket events.
+		"subscribe":       rpc.NewWSRPCFunc(env.Subscribe, "query"),
+		"unsubscribe":     rpc.NewWSRPCFunc(env.Unsubscribe, "query"),
+		"unsubscribe_all": rpc.NewWSRPCFunc(env.UnsubscribeAll, ""),
+
 		// info API
 		"health":               rpc.NewRPCFunc(env.Health, ""),
 		"status":               rpc.NewRPCFunc(env.Status, "heightGte"),
@@ -24,6 +29,8 @@ func (env *Environment) Routes(unsafe bool) map[string]*rpc.RPCFunc {
 		"block_results":        rpc.NewRPCFunc(env.BlockResults, "height"),
 		"commit":               rpc.NewRPCFunc(env.Commit, "height"),
 		"tx":                   rpc.NewRPCFunc(env.Tx, "hash"),
+		"tx_search":            rpc.NewRPCFunc(env.TxSearch, "query,page,per_page,order_by"),
+		"block_search":         rpc.NewRPCFunc(env.BlockSearch, "query,page,per_page,order_by"),
 		"validators":           rpc.NewRPCFunc(env.Validators, "height"),
 		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, ""),
 		"consensus_state":      rpc.NewRPCFunc(env.ConsensusState, ""),
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
@@ -70,3 +71,46 @@ func (env *Environment) Tx(ctx *rpctypes.Context, hash []byte) (*ctypes.ResultTx
 		Tx:       rawTx,
 	}, nil
 }
+
+var errTxIndexingDisabled = errors.New("transaction indexing is disabled")
+
+// TxSearch allows you to search for the results of the transactions matching
+// the query, by pages of perPage results. orderBy is either "asc" (default) or
+// "desc", by height and index. See the kv event store for the query syntax.
+func (env *Environment) TxSearch(ctx *rpctypes.Context, query string, page, perPage int, orderBy string) (*ctypes.ResultTxSearch, error) {
+	_, span := traces.Tracer().Start(ctx.Context(), "TxSearch")
+	defer span.End()
+	if env.TxSearcher == nil {
+		return nil, errTxIndexingDisabled
+	}
+
+	desc, err := parseOrderBy(orderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, perPage := searchPage(page, perPage)
+	results, totalCount, err := env.TxSearcher.SearchTxs(query, offset, perPage, desc)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := validatePage(page, perPage, totalCount); err != nil {
+		return nil, err
+	}
+
+	txs := make([]*ctypes.ResultTx, 0, len(results))
+	for _, result := range results {
+		txs = append(txs, &ctypes.ResultTx{
+			Hash:     result.Tx.Hash(),
+			Height:   result.Height,
+			Index:    result.Index,
+			TxResult: result.Response,
+			Tx:       result.Tx,
+		})
+	}
+
+	return &ctypes.ResultTxSearch{
+		Txs:        txs,
+		TotalCount: totalCount,
+	}, nil
+}
@@ -261,3 +261,86 @@ func TestTxHandler(t *testing.T) {
 		assert.ErrorContains(t, err, "block not found for height 10")
 	})
 }
+
+func TestTxSearchHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("indexing disabled", func(t *testing.T) {
+		t.Parallel()
+
+		env := &Environment{}
+
+		result, err := env.TxSearch(&rpctypes.Context{}, "tx.height=1", 0, 0, "")
+		require.Nil(t, result)
+		assert.ErrorIs(t, err, errTxIndexingDisabled)
+	})
+
+	t.Run("invalid order", func(t *testing.T) {
+		t.Parallel()
+
+		env := &Environment{TxSearcher: &mockTxSearcher{}}
+
+		result, err := env.TxSearch(&rpctypes.Context{}, "tx.height=1", 0, 0, "random")
+		require.Nil(t, result)
+		assert.ErrorContains(t, err, "expected order_by to be either asc or desc")
+	})
+
+	t.Run("page out of range", func(t *testing.T) {
+		t.Parallel()
+
+		env := &Environment{
+			TxSearcher: &mockTxSearcher{
+				searchTxsFn: func(string, int, int, bool) ([]types.TxResult, int, error) {
+					return nil, 10, nil
+				},
+			},
+		}
+
+		result, err := env.TxSearch(&rpctypes.Context{}, "tx.height=1", 3, 5, "")
+		require.Nil(t, result)
+		assert.ErrorContains(t, err, "page should be within [0, 2] range, given 3")
+	})
+
+	t.Run("results found", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			tx      = types.Tx("tx")
+			results = []types.TxResult{
+				{
+					Height: 10,
+					Index:  2,
+					Tx:     tx,
+					Response: abci.ResponseDeliverTx{
+						GasUsed: 100,
+					},
+				},
+			}
+		)
+
+		env := &Environment{
+			TxSearcher: &mockTxSearcher{
+				searchTxsFn: func(query string, offset, limit int, desc bool) ([]types.TxResult, int, error) {
+					assert.Equal(t, "tx.height=10", query)
+					assert.Equal(t, 5, offset)
+					assert.Equal(t, 5, limit)
+					assert.True(t, desc)
+
+					return results, 6, nil
+				},
+			},
+		}
+
+		result, err := env.TxSearch(&rpctypes.Context{}, "tx.height=10", 2, 5, "desc")
+		require.NoError(t, err)
+		require.NotNil(t, result)
+
+		assert.Equal(t, 6, result.TotalCount)
+		require.Len(t, result.Txs, 1)
+		assert.Equal(t, tx.Hash(), result.Txs[0].Hash)
+		assert.Equal(t, int64(10), result.Txs[0].Height)
+		assert.Equal(t, uint32(2), result.Txs[0].Index)
+		assert.Equal(t, results[0].Response, result.Txs[0].TxResult)
+		assert.Equal(t, tx, result.Txs[0].Tx)
+	})
+}
@@ -256,6 +256,12 @@ type ResultTxSearch struct {
 	TotalCount int         `json:"total_count"`
 }
 
+// Result of searching for blocks
+type ResultBlockSearch struct {
+	Blocks     []*ResultBlock `json:"blocks"`
+	TotalCount int            `json:"total_count"`
+}
+
 // List of mempool txs
 type ResultUnconfirmedTxs struct {
 	Count      int        `json:"n_txs"`
@@ -279,9 +285,12 @@ type (
 	ResultUnsafeFlushMempool struct{}
 	ResultUnsafeProfile      struct{}
 	ResultHealth             struct{}
+	ResultSubscribe          struct{}
+	ResultUnsubscribe        struct{}
 )
 
 // Event data from a subscription
 type ResultEvent struct {
+	Query string        `json:"query"`
 	Event types.TMEvent `json:"event"`
 }
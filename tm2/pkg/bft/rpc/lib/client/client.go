@@ -18,6 +18,20 @@ type Client interface {
 	Close() error
 }
 
+// Subscriber is a Client which receives the events the server streams
+// for subscriptions
+type Subscriber interface {
+	Client
+
+	// Subscribe sends a subscription request to the JSON-RPC layer, and
+	// returns its response and the channel of the events streamed for it
+	Subscribe(context.Context, types.RPCRequest) (*types.RPCResponse, <-chan types.RPCResponse, error)
+
+	// Unsubscribe stops receiving the events of the subscription request
+	// of the given ID, and closes its channel
+	Unsubscribe(types.JSONRPCID)
+}
+
 // Batch is the JSON-RPC batch abstraction
 type Batch interface {
 	// AddRequest adds a single request to the RPC batch
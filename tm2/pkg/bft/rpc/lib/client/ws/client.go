@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"sync"
 
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client"
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/log"
@@ -22,6 +23,12 @@ var (
 
 type responseCh chan<- types.RPCResponses
 
+// eventBufferSize is the number of events buffered for each subscription,
+// before the ones received are dropped.
+const eventBufferSize = 100
+
+var _ rpcclient.Subscriber = (*Client)(nil)
+
 // Client is a WebSocket client implementation
 type Client struct {
 	ctx           context.Context
@@ -34,6 +41,9 @@ type Client struct {
 
 	requestMap    map[string]responseCh
 	requestMapMux sync.Mutex
+
+	eventMap    map[string]chan types.RPCResponse // event ID -> events
+	eventMapMux sync.Mutex
 }
 
 // NewClient initializes and creates a new WS RPC client
@@ -47,6 +57,7 @@ func NewClient(rpcURL string, opts ...Option) (*Client, error) {
 	c := &Client{
 		conn:       conn,
 		requestMap: make(map[string]responseCh),
+		eventMap:   make(map[string]chan types.RPCResponse),
 		backlog:    make(chan any, 1),
 		logger:     log.NewNoopLogger(),
 	}
@@ -159,6 +170,84 @@ func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (typ
 	}
 }
 
+// Subscribe sends a subscription request to the server, and returns its
+// response and the channel of the events the server streams for it, which
+// are the responses with the types.EventID of the request. The channel is
+// closed by Unsubscribe, or when the client is closed.
+//
+// Events are dropped if the channel is full.
+func (c *Client) Subscribe(
+	ctx context.Context,
+	request types.RPCRequest,
+) (*types.RPCResponse, <-chan types.RPCResponse, error) {
+	eventID := types.EventID(request.ID).String()
+	eventCh := make(chan types.RPCResponse, eventBufferSize)
+
+	// Register the events channel before sending the request,
+	// as events can be received before its response
+	c.eventMapMux.Lock()
+	if c.ctx.Err() != nil {
+		c.eventMapMux.Unlock()
+
+		return nil, nil, context.Cause(c.ctx)
+	}
+	c.eventMap[eventID] = eventCh
+	c.eventMapMux.Unlock()
+
+	response, err := c.SendRequest(ctx, request)
+	if err != nil {
+		c.Unsubscribe(request.ID)
+
+		return nil, nil, err
+	}
+
+	if response.Error != nil {
+		c.Unsubscribe(request.ID)
+
+		return nil, nil, response.Error
+	}
+
+	return response, eventCh, nil
+}
+
+// Unsubscribe stops routing the events of the subscription request
+// of the given ID, and closes its channel
+func (c *Client) Unsubscribe(id types.JSONRPCID) {
+	eventID := types.EventID(id).String()
+
+	c.eventMapMux.Lock()
+	defer c.eventMapMux.Unlock()
+
+	if ch, ok := c.eventMap[eventID]; ok {
+		close(ch)
+		delete(c.eventMap, eventID)
+	}
+}
+
+// routeEvent sends the response to the channel of its subscription,
+// and returns false if it is not an event
+func (c *Client) routeEvent(response types.RPCResponse) bool {
+	if response.ID == nil {
+		return false
+	}
+
+	c.eventMapMux.Lock()
+	defer c.eventMapMux.Unlock()
+
+	ch, ok := c.eventMap[response.ID.String()]
+	if !ok {
+		return false
+	}
+
+	select {
+	case ch <- response:
+	default:
+		c.logger.Warn("event listener is full, dropping event", "id", response.ID)
+	}
+
+	return true
+}
+
 // generateIDHash generates a unique hash from the given IDs
 func generateIDHash(ids ...string) string {
 	hash := fnv.New128()
@@ -237,6 +326,11 @@ func (c *Client) runReadRoutine(ctx context.Context) {
 				continue
 			}
 
+			// Events of subscriptions are not responses to a request
+			if c.routeEvent(response) {
+				continue
+			}
+
 			// This is a single response, generate the unique ID
 			responseHash = generateIDHash(response.ID.String())
 			responses = types.RPCResponses{response}
@@ -285,7 +379,15 @@ func (c *Client) Close() error {
 // closeWithCause closes the client (and any open connection)
 // with the given cause
 func (c *Client) closeWithCause(err error) error {
+	c.eventMapMux.Lock()
 	c.cancelCauseFn(err)
 
+	// Close the event channels of the subscriptions
+	for eventID, ch := range c.eventMap {
+		close(ch)
+		delete(c.eventMap, eventID)
+	}
+	c.eventMapMux.Unlock()
+
 	return c.conn.Close()
 }
@@ -300,3 +300,83 @@ func TestClient_SendBatch(t *testing.T) {
 		assert.Equal(t, response.Error, resp[0].Error)
 	})
 }
+
+func TestClient_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upgrader = websocket.Upgrader{}
+
+		request = types.RPCRequest{
+			JSONRPC: "2.0",
+			ID:      types.JSONRPCStringID("id"),
+			Method:  "subscribe",
+		}
+
+		event = types.RPCResponse{
+			JSONRPC: "2.0",
+			ID:      types.EventID(request.ID),
+			Result:  json.RawMessage(`{"event":1}`),
+		}
+	)
+
+	// Create the server, which streams an event
+	// before and after the subscription response
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		mt, message, err := c.ReadMessage()
+		require.NoError(t, err)
+
+		var req types.RPCRequest
+		require.NoError(t, json.Unmarshal(message, &req))
+
+		for _, response := range []types.RPCResponse{
+			event,
+			{JSONRPC: "2.0", ID: req.ID},
+			event,
+		} {
+			marshalledResponse, err := json.Marshal(response)
+			require.NoError(t, err)
+
+			require.NoError(t, c.WriteMessage(mt, marshalledResponse))
+		}
+
+		// Wait for the client to close the connection
+		_, _, _ = c.ReadMessage()
+	}
+
+	s := createTestServer(t, http.HandlerFunc(handler))
+	url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+	// Create the client
+	c, err := NewClient(url)
+	require.NoError(t, err)
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	resp, eventCh, err := c.Subscribe(ctx, request)
+	require.NoError(t, err)
+
+	assert.Equal(t, request.ID, resp.ID)
+
+	for range 2 {
+		select {
+		case received := <-eventCh:
+			assert.Equal(t, event.ID, received.ID)
+			assert.JSONEq(t, string(event.Result), string(received.Result))
+		case <-ctx.Done():
+			t.Fatal("event not received")
+		}
+	}
+
+	// Closing the client closes the event channel
+	require.NoError(t, c.Close())
+
+	_, ok := <-eventCh
+	assert.False(t, ok)
+}
@@ -46,6 +46,12 @@ func (id JSONRPCIntID) String() string {
 	return fmt.Sprintf("%d", id)
 }
 
+// EventID returns the ID of the responses streamed over a websocket
+// connection for the events of the subscription request of the given ID.
+func EventID(id JSONRPCID) JSONRPCID {
+	return JSONRPCStringID(id.String() + "#event")
+}
+
 // parseID parses the given ID value
 func parseID(idValue any) (JSONRPCID, error) {
 	switch id := idValue.(type) {
@@ -0,0 +1,387 @@
+package kv
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	storetypes "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	_ eventstore.TxEventStore = (*TxEventStore)(nil)
+	_ eventstore.TxSearcher   = (*TxEventStore)(nil)
+)
+
+const (
+	EventStoreType = "kv"
+	Path           = "path"
+	Backend        = "backend"
+
+	// dbName is the name of the database in the directory of Path.
+	dbName = "tx_events"
+)
+
+var (
+	errMissingPath = errors.New("missing path param")
+	errInvalidType = errors.New("invalid config for kv event store specified")
+)
+
+// Prefixes of the keys of the database. A position is the height and the
+// index of a transaction, in big endian, so that keys sort by position.
+var (
+	prefixResult = []byte("r/") // position -> TxResult
+	prefixHash   = []byte("h/") // hash -> position
+	prefixAttr   = []byte("a/") // attribute key, value and position -> nothing
+)
+
+const positionLen = 8 + 4
+
+// maxSearchCount is the default maximum number of matches a search counts.
+// The count it returns is capped there, and the matches past it cannot be
+// paged through: this bounds the scan of a search to the end of its page, or
+// to maxSearchCount matches.
+const maxSearchCount = 10_000
+
+// TxEventStore is the implementation of a transaction event store which
+// indexes the transactions in a key/value database, to search them.
+//
+// Transactions are indexed by hash, height, route, type and signers of their
+// messages, and attributes of the abci.IndexedEvent they emitted.
+type TxEventStore struct {
+	path     string
+	backend  dbm.BackendType
+	db       dbm.DB
+	maxCount int // see maxSearchCount
+}
+
+// NewTxEventStore creates a new kv tx event store. The backend param is the
+// database backend, pebbledb by default.
+func NewTxEventStore(cfg *storetypes.Config) (*TxEventStore, error) {
+	// Parse config params
+	if EventStoreType != cfg.EventStoreType {
+		return nil, errInvalidType
+	}
+
+	path, ok := cfg.GetParam(Path).(string)
+	if !ok {
+		return nil, errMissingPath
+	}
+
+	backend := dbm.PebbleDBBackend
+	if b, ok := cfg.GetParam(Backend).(string); ok && b != "" {
+		backend = dbm.BackendType(b)
+	}
+
+	return &TxEventStore{
+		path:     path,
+		backend:  backend,
+		maxCount: maxSearchCount,
+	}, nil
+}
+
+// Start starts the kv transaction event store, by opening the database
+func (t *TxEventStore) Start() error {
+	db, err := dbm.NewDB(dbName, t.backend, t.path)
+	if err != nil {
+		return fmt.Errorf("unable to open database, %w", err)
+	}
+
+	t.db = db
+
+	return nil
+}
+
+// Stop stops the kv transaction event store, by closing the database
+func (t *TxEventStore) Stop() error {
+	if t.db == nil {
+		return nil
+	}
+
+	return t.db.Close()
+}
+
+// GetType returns the kv transaction event store type
+func (t *TxEventStore) GetType() string {
+	return EventStoreType
+}
+
+// Append stores the transaction result, and indexes it
+func (t *TxEventStore) Append(tx types.TxResult) error {
+	raw, err := amino.Marshal(tx)
+	if err != nil {
+		return fmt.Errorf("unable to marshal transaction, %w", err)
+	}
+
+	pos := encodePosition(tx.Height, tx.Index)
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	if err := batch.Set(concat(prefixResult, pos), raw); err != nil {
+		return err
+	}
+	if err := batch.Set(concat(prefixHash, tx.Tx.Hash()), pos); err != nil {
+		return err
+	}
+	for _, attr := range indexedAttributes(tx) {
+		if err := batch.Set(attrKey(attr.Key, attr.Value, pos), []byte{}); err != nil {
+			return err
+		}
+	}
+
+	if err := batch.WriteSync(); err != nil {
+		return fmt.Errorf("unable to index transaction, %w", err)
+	}
+
+	return nil
+}
+
+// SearchTxs returns the number of transactions matching the query, capped
+// at maxSearchCount, and the results of at most limit of them after skipping
+// offset.
+func (t *TxEventStore) SearchTxs(query string, offset, limit int, desc bool) ([]types.TxResult, int, error) {
+	var (
+		page  [][]byte
+		count int
+	)
+	err := t.search(query, desc, func(pos []byte) bool {
+		if count >= offset && count-offset < limit {
+			page = append(page, pos)
+		}
+		count++
+		return count < t.maxCount
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]types.TxResult, 0, len(page))
+	for _, pos := range page {
+		raw, err := t.db.Get(concat(prefixResult, pos))
+		if err != nil {
+			return nil, 0, err
+		}
+		var result types.TxResult
+		if err := amino.Unmarshal(raw, &result); err != nil {
+			return nil, 0, fmt.Errorf("unable to unmarshal transaction, %w", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, count, nil
+}
+
+// SearchBlocks returns the number of blocks with transactions matching the
+// query, capped at maxSearchCount, and the heights of at most limit of them
+// after skipping offset.
+func (t *TxEventStore) SearchBlocks(query string, offset, limit int, desc bool) ([]int64, int, error) {
+	var (
+		heights []int64
+		count   int
+		last    int64 = -1
+	)
+	// Positions are visited sorted, so are their heights.
+	err := t.search(query, desc, func(pos []byte) bool {
+		height, _ := decodePosition(pos)
+		if height == last {
+			return true
+		}
+		last = height
+		if count >= offset && count-offset < limit {
+			heights = append(heights, height)
+		}
+		count++
+		return count < t.maxCount
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return heights, count, nil
+}
+
+// search calls visit with the positions of the transactions matching the
+// query, increasing, or decreasing if desc is set, until visit returns false.
+func (t *TxEventStore) search(query string, desc bool, visit func(pos []byte) bool) error {
+	q, err := ParseQuery(query)
+	if err != nil {
+		return err
+	}
+
+	lo, hi := q.heightRange()
+	if lo > hi {
+		return nil
+	}
+
+	// The candidates are found with the first condition which is not on
+	// the height, and checked against the others.
+	var (
+		first *Condition
+		rest  []Condition
+	)
+	for i, cond := range q.Conditions {
+		switch {
+		case isHeightKey(cond.Key):
+		case first == nil:
+			first = &q.Conditions[i]
+		default:
+			rest = append(rest, cond)
+		}
+	}
+
+	var prefix []byte
+	switch {
+	case first == nil:
+		// Only heights: all the transactions in the range.
+		prefix = prefixResult
+	case first.Key == KeyTxHash:
+		pos, err := t.hashPosition(first.Value)
+		if err != nil || pos == nil {
+			return err
+		}
+		if height, _ := decodePosition(pos); height < lo || height > hi {
+			return nil
+		}
+		ok, err := t.matches(pos, rest)
+		if ok {
+			visit(pos)
+		}
+		return err
+	default:
+		prefix = attrKey(first.Key, first.Value, nil)
+	}
+
+	start, end := concat(prefix, encodePosition(lo, 0)), rangeEnd(prefix, hi)
+	var it dbm.Iterator
+	if desc {
+		it, err = t.db.ReverseIterator(start, end)
+	} else {
+		it, err = t.db.Iterator(start, end)
+	}
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		pos := slices.Clone(it.Key()[len(prefix):])
+		ok, err := t.matches(pos, rest)
+		if err != nil {
+			return err
+		}
+		if ok && !visit(pos) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// matches returns whether the transaction at pos matches all the conditions.
+func (t *TxEventStore) matches(pos []byte, conds []Condition) (bool, error) {
+	for _, cond := range conds {
+		if cond.Key == KeyTxHash {
+			hpos, err := t.hashPosition(cond.Value)
+			if err != nil || !slices.Equal(hpos, pos) {
+				return false, err
+			}
+			continue
+		}
+		ok, err := t.db.Has(attrKey(cond.Key, cond.Value, pos))
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// hashPosition returns the position of the transaction of the given hex hash,
+// or nil if it is not indexed.
+func (t *TxEventStore) hashPosition(hexHash string) ([]byte, error) {
+	hash, err := hex.DecodeString(hexHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.db.Get(concat(prefixHash, hash))
+}
+
+// indexedAttributes returns the attributes to index the transaction with: the
+// route, type and signers of its messages, and the indexed
+// attributes of its events, prefixed with their event type.
+func indexedAttributes(tx types.TxResult) []abci.EventAttribute {
+	var attrs []abci.EventAttribute
+
+	// Transactions which are not std.Tx are only indexed by their events.
+	var stdTx std.Tx
+	if err := amino.Unmarshal(tx.Tx, &stdTx); err == nil {
+		for _, msg := range stdTx.GetMsgs() {
+			attrs = append(attrs,
+				abci.EventAttribute{Key: KeyMessageRoute, Value: msg.Route()},
+				abci.EventAttribute{Key: KeyMessageType, Value: msg.Type()},
+			)
+			for _, signer := range msg.GetSigners() {
+				attrs = append(attrs, abci.EventAttribute{Key: KeyMessageSigner, Value: signer.String()})
+			}
+		}
+	}
+
+	for _, event := range tx.Response.Events {
+		ev, ok := event.(abci.IndexedEvent)
+		if !ok {
+			continue
+		}
+		typ, evAttrs := ev.IndexedAttributes()
+		for _, attr := range evAttrs {
+			attrs = append(attrs, abci.EventAttribute{Key: typ + "." + attr.Key, Value: attr.Value})
+		}
+	}
+
+	return attrs
+}
+
+func encodePosition(height int64, index uint32) []byte {
+	pos := make([]byte, positionLen)
+	binary.BigEndian.PutUint64(pos, uint64(height))
+	binary.BigEndian.PutUint32(pos[8:], index)
+	return pos
+}
+
+func decodePosition(pos []byte) (int64, uint32) {
+	return int64(binary.BigEndian.Uint64(pos)), binary.BigEndian.Uint32(pos[8:])
+}
+
+// attrKey returns the index key of an attribute, with its key and value
+// prefixed with their length so that they cannot be confused.
+func attrKey(key, value string, pos []byte) []byte {
+	bz := slices.Clone(prefixAttr)
+	bz = binary.BigEndian.AppendUint32(bz, uint32(len(key)))
+	bz = append(bz, key...)
+	bz = binary.BigEndian.AppendUint32(bz, uint32(len(value)))
+	bz = append(bz, value...)
+	return append(bz, pos...)
+}
+
+// rangeEnd returns the exclusive end of the positions under prefix up to
+// height, inclusive.
+func rangeEnd(prefix []byte, height int64) []byte {
+	if height == math.MaxInt64 {
+		// cannot be incremented.
+		return concat(prefix, encodePosition(height, ^uint32(0)), []byte{0})
+	}
+	return concat(prefix, encodePosition(height+1, 0))
+}
+
+func concat(parts ...[]byte) []byte {
+	return slices.Concat(parts...)
+}
@@ -0,0 +1,339 @@
+package kv
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	storetypes "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	_ "github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEvent is an event with its attributes indexed.
+type testEvent struct {
+	Type  string
+	Attrs []abci.EventAttribute
+}
+
+func (testEvent) AssertABCIEvent() {}
+
+func (e testEvent) IndexedAttributes() (string, []abci.EventAttribute) {
+	return e.Type, e.Attrs
+}
+
+var _ = amino.RegisterPackage(amino.NewPackage(
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/kv",
+	"kv",
+	amino.GetCallersDirname(),
+).
+	WithDependencies(
+		abci.Package,
+	).
+	WithTypes(
+		testEvent{},
+	))
+
+var (
+	alice = crypto.AddressFromPreimage([]byte("alice"))
+	bob   = crypto.AddressFromPreimage([]byte("bob"))
+)
+
+// newTestTx returns the result of a transaction sending coins from the given
+// address, which emitted a transfer event.
+func newTestTx(t *testing.T, height int64, index uint32, from, to crypto.Address) types.TxResult {
+	t.Helper()
+
+	tx := std.Tx{
+		Msgs: []std.Msg{bank.NewMsgSend(from, to, std.MustParseCoins("1ugnot"))},
+		Memo: "tx",
+	}
+	raw, err := amino.Marshal(tx)
+	require.NoError(t, err)
+
+	return types.TxResult{
+		Height: height,
+		Index:  index,
+		Tx:     raw,
+		Response: abci.ResponseDeliverTx{
+			ResponseBase: abci.ResponseBase{
+				Events: []abci.Event{
+					testEvent{
+						Type: "Transfer",
+						Attrs: []abci.EventAttribute{
+							{Key: "from", Value: from.String()},
+							{Key: "to", Value: to.String()},
+						},
+					},
+					abci.EventString("not indexed"),
+				},
+			},
+		},
+	}
+}
+
+func newTestEventStore(t *testing.T) *TxEventStore {
+	t.Helper()
+
+	eventStore, err := NewTxEventStore(&storetypes.Config{
+		EventStoreType: EventStoreType,
+		Params: map[string]any{
+			Path:    t.TempDir(),
+			Backend: string(dbm.MemDBBackend),
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, eventStore.Start())
+	t.Cleanup(func() {
+		require.NoError(t, eventStore.Stop())
+	})
+
+	return eventStore
+}
+
+func TestTxEventStore_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid type specified", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := NewTxEventStore(&storetypes.Config{
+			EventStoreType: "invalid",
+		})
+
+		assert.Nil(t, i)
+		assert.ErrorIs(t, err, errInvalidType)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := NewTxEventStore(&storetypes.Config{
+			EventStoreType: EventStoreType,
+		})
+
+		assert.Nil(t, i)
+		assert.ErrorIs(t, err, errMissingPath)
+	})
+
+	t.Run("default backend", func(t *testing.T) {
+		t.Parallel()
+
+		i, err := NewTxEventStore(&storetypes.Config{
+			EventStoreType: EventStoreType,
+			Params: map[string]any{
+				Path: ".",
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, ".", i.path)
+		assert.Equal(t, dbm.PebbleDBBackend, i.backend)
+		assert.Equal(t, EventStoreType, i.GetType())
+	})
+}
+
+func TestTxEventStore_Search(t *testing.T) {
+	t.Parallel()
+
+	eventStore := newTestEventStore(t)
+	txs := []types.TxResult{
+		newTestTx(t, 1, 0, alice, bob),
+		newTestTx(t, 1, 1, bob, alice),
+		newTestTx(t, 2, 0, alice, bob),
+		newTestTx(t, 5, 0, alice, alice),
+		{Height: 5, Index: 1, Tx: []byte("not a std.Tx")},
+	}
+	for _, tx := range txs {
+		require.NoError(t, eventStore.Append(tx))
+	}
+
+	testTable := []struct {
+		name     string
+		query    string
+		expected []types.TxResult
+	}{
+		{
+			"by hash",
+			"tx.hash='" + txHash(txs[2]) + "'",
+			txs[2:3],
+		},
+		{
+			"by unknown hash",
+			"tx.hash='00'",
+			nil,
+		},
+		{
+			"by height",
+			"tx.height=1",
+			txs[0:2],
+		},
+		{
+			"by block height",
+			"block.height>1 AND block.height<=5",
+			txs[2:5],
+		},
+		{
+			"by message type",
+			"message.route='bank' AND message.type='send'",
+			txs[0:4],
+		},
+		{
+			"by signer",
+			"message.signer='" + alice.String() + "'",
+			[]types.TxResult{txs[0], txs[2], txs[3]},
+		},
+		{
+			"by signer and height",
+			"message.signer='" + alice.String() + "' AND tx.height>=2",
+			txs[2:4],
+		},
+		{
+			"by event attributes",
+			"Transfer.from='" + alice.String() + "' AND Transfer.to='" + alice.String() + "'",
+			txs[3:4],
+		},
+		{
+			"by hash and other condition",
+			"Transfer.to='" + alice.String() + "' AND tx.hash='" + txHash(txs[1]) + "'",
+			txs[1:2],
+		},
+		{
+			"by attribute not indexed",
+			"message.memo='tx'",
+			nil,
+		},
+		{
+			"by empty height range",
+			"tx.height>2 AND tx.height<5",
+			nil,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			results, total, err := eventStore.SearchTxs(testCase.query, 0, 100, false)
+			require.NoError(t, err)
+
+			assert.Equal(t, len(testCase.expected), total)
+			assert.Equal(t, len(testCase.expected), len(results))
+			for i, result := range results {
+				assert.Equal(t, testCase.expected[i].Height, result.Height)
+				assert.Equal(t, testCase.expected[i].Index, result.Index)
+				assert.Equal(t, testCase.expected[i].Tx, result.Tx)
+			}
+		})
+	}
+
+	t.Run("stored results", func(t *testing.T) {
+		t.Parallel()
+
+		results, _, err := eventStore.SearchTxs("tx.height=1", 0, 1, false)
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.Equal(t, txs[0], results[0])
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := eventStore.SearchTxs("tx.height='one'", 0, 100, false)
+		assert.ErrorContains(t, err, "invalid height")
+	})
+}
+
+func TestTxEventStore_SearchPagination(t *testing.T) {
+	t.Parallel()
+
+	eventStore := newTestEventStore(t)
+	for height := int64(1); height <= 5; height++ {
+		for index := range uint32(2) {
+			require.NoError(t, eventStore.Append(newTestTx(t, height, index, alice, bob)))
+		}
+	}
+	query := "message.signer='" + alice.String() + "'"
+
+	results, total, err := eventStore.SearchTxs(query, 3, 4, false)
+	require.NoError(t, err)
+	assert.Equal(t, 10, total)
+	require.Len(t, results, 4)
+	assert.Equal(t, int64(2), results[0].Height)
+	assert.Equal(t, uint32(1), results[0].Index)
+	assert.Equal(t, int64(4), results[3].Height)
+	assert.Equal(t, uint32(0), results[3].Index)
+
+	results, total, err = eventStore.SearchTxs(query, 8, 4, true)
+	require.NoError(t, err)
+	assert.Equal(t, 10, total)
+	require.Len(t, results, 2)
+	assert.Equal(t, int64(1), results[0].Height)
+	assert.Equal(t, uint32(1), results[0].Index)
+	assert.Equal(t, uint32(0), results[1].Index)
+
+	results, _, err = eventStore.SearchTxs(query, 10, 4, false)
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	heights, total, err := eventStore.SearchBlocks(query+" AND tx.height>=2", 0, 3, false)
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []int64{2, 3, 4}, heights)
+
+	heights, total, err = eventStore.SearchBlocks(query, 1, 2, true)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	assert.Equal(t, []int64{4, 3}, heights)
+}
+
+func TestTxEventStore_SearchCountCap(t *testing.T) {
+	t.Parallel()
+
+	eventStore := newTestEventStore(t)
+	eventStore.maxCount = 5
+	for height := int64(1); height <= 4; height++ {
+		for index := range uint32(2) {
+			require.NoError(t, eventStore.Append(newTestTx(t, height, index, alice, bob)))
+		}
+	}
+	query := "message.signer='" + alice.String() + "'"
+
+	// The count stops at the cap, and so do the pages.
+	results, total, err := eventStore.SearchTxs(query, 0, 2, false)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	require.Len(t, results, 2)
+
+	results, total, err = eventStore.SearchTxs(query, 4, 2, true)
+	require.NoError(t, err)
+	assert.Equal(t, 5, total)
+	require.Len(t, results, 1)
+	assert.Equal(t, int64(2), results[0].Height)
+	assert.Equal(t, uint32(1), results[0].Index)
+
+	// Below the cap, the count is exact.
+	heights, total, err := eventStore.SearchBlocks(query, 1, 10, true)
+	require.NoError(t, err)
+	assert.Equal(t, 4, total)
+	assert.Equal(t, []int64{3, 2, 1}, heights)
+
+	eventStore.maxCount = 2
+	heights, total, err = eventStore.SearchBlocks(query, 0, 10, false)
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	assert.Equal(t, []int64{1, 2}, heights)
+}
+
+// txHash returns the hash of the transaction, in upper case hex.
+func txHash(tx types.TxResult) string {
+	return strings.ToUpper(hex.EncodeToString(tx.Tx.Hash()))
+}
@@ -0,0 +1,221 @@
+package kv
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Keys of the conditions with a meaning of their own. Any other key is the
+// one of an indexed attribute.
+const (
+	KeyTxHash      = "tx.hash"
+	KeyTxHeight    = "tx.height"
+	KeyBlockHeight = "block.height" // alias of tx.height
+
+	KeyMessageRoute  = "message.route"
+	KeyMessageType   = "message.type"
+	KeyMessageSigner = "message.signer"
+)
+
+// Operator is the comparison operator of a Condition.
+type Operator string
+
+const (
+	OpEqual        Operator = "="
+	OpLess         Operator = "<"
+	OpLessEqual    Operator = "<="
+	OpGreater      Operator = ">"
+	OpGreaterEqual Operator = ">="
+)
+
+var errEmptyQuery = errors.New("empty query")
+
+// Condition is a single condition of a Query, as in tx.height>=5.
+type Condition struct {
+	Key   string
+	Op    Operator
+	Value string
+}
+
+// Query is a conjunction of conditions, all of which a transaction must match.
+//
+// Its syntax is a list of conditions joined by AND, such as:
+//
+//	message.type='exec' AND Transfer.from='g1...' AND tx.height>=100
+//
+// The key of a condition is either tx.hash, tx.height (or block.height),
+// message.route, message.type, message.signer, or <event type>.<attribute
+// key> for the attributes of the events indexed by the application. Values
+// are single-quoted strings, where \' and \\ are escaped, or integers. Only
+// heights can be compared with <, <=, > and >=; other keys only with =.
+type Query struct {
+	Conditions []Condition
+}
+
+// ParseQuery parses a query string.
+func ParseQuery(s string) (*Query, error) {
+	p := &queryParser{s: s}
+	q := &Query{}
+	for {
+		p.skipSpaces()
+		if p.eof() {
+			break
+		}
+		if len(q.Conditions) > 0 {
+			if !p.consume("AND") {
+				return nil, p.errorf("expected AND")
+			}
+			p.skipSpaces()
+		}
+		cond, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+		q.Conditions = append(q.Conditions, cond)
+	}
+	if len(q.Conditions) == 0 {
+		return nil, errEmptyQuery
+	}
+	return q, nil
+}
+
+// heightRange returns the range of heights, both inclusive, matched by the
+// height conditions of q.
+func (q *Query) heightRange() (lo, hi int64) {
+	lo, hi = 0, math.MaxInt64
+	for _, cond := range q.Conditions {
+		if !isHeightKey(cond.Key) {
+			continue
+		}
+		// already validated by the parser.
+		h, _ := strconv.ParseInt(cond.Value, 10, 64)
+		switch cond.Op {
+		case OpEqual:
+			lo, hi = max(lo, h), min(hi, h)
+		case OpLess:
+			hi = min(hi, h-1)
+		case OpLessEqual:
+			hi = min(hi, h)
+		case OpGreater:
+			if h == math.MaxInt64 {
+				return 1, 0 // empty
+			}
+			lo = max(lo, h+1)
+		case OpGreaterEqual:
+			lo = max(lo, h)
+		}
+	}
+	return lo, hi
+}
+
+func isHeightKey(key string) bool {
+	return key == KeyTxHeight || key == KeyBlockHeight
+}
+
+type queryParser struct {
+	s   string
+	pos int
+}
+
+func (p *queryParser) condition() (Condition, error) {
+	var cond Condition
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t<>=", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	cond.Key = p.s[start:p.pos]
+	if cond.Key == "" {
+		return cond, p.errorf("expected key")
+	}
+	p.skipSpaces()
+	for _, op := range []Operator{OpLessEqual, OpGreaterEqual, OpEqual, OpLess, OpGreater} {
+		if p.consume(string(op)) {
+			cond.Op = op
+			break
+		}
+	}
+	if cond.Op == "" {
+		return cond, p.errorf("expected operator after %q", cond.Key)
+	}
+	p.skipSpaces()
+	value, quoted, err := p.value()
+	if err != nil {
+		return cond, err
+	}
+	cond.Value = value
+
+	switch {
+	case isHeightKey(cond.Key):
+		h, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || quoted || h < 0 {
+			return cond, fmt.Errorf("invalid height %q in query", value)
+		}
+	case cond.Op != OpEqual:
+		return cond, fmt.Errorf("operator %s is only supported for heights, not %q", cond.Op, cond.Key)
+	case cond.Key == KeyTxHash:
+		if _, err := hex.DecodeString(value); err != nil {
+			return cond, fmt.Errorf("invalid hash %q in query", value)
+		}
+	}
+	return cond, nil
+}
+
+// value parses a quoted string or an integer.
+func (p *queryParser) value() (string, bool, error) {
+	if p.eof() {
+		return "", false, p.errorf("expected value")
+	}
+	if p.s[p.pos] != '\'' {
+		start := p.pos
+		for !p.eof() && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		if start == p.pos {
+			return "", false, p.errorf("expected quoted string or integer")
+		}
+		return p.s[start:p.pos], false, nil
+	}
+	var sb strings.Builder
+	for p.pos++; !p.eof(); p.pos++ {
+		switch c := p.s[p.pos]; c {
+		case '\'':
+			p.pos++
+			return sb.String(), true, nil
+		case '\\':
+			p.pos++
+			if p.eof() {
+				return "", true, p.errorf("unterminated string")
+			}
+			sb.WriteByte(p.s[p.pos])
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return "", true, p.errorf("unterminated string")
+}
+
+func (p *queryParser) consume(tok string) bool {
+	if strings.HasPrefix(p.s[p.pos:], tok) {
+		p.pos += len(tok)
+		return true
+	}
+	return false
+}
+
+func (p *queryParser) skipSpaces() {
+	for !p.eof() && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+func (p *queryParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *queryParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("invalid query at position %d: %s", p.pos, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name     string
+		query    string
+		expected []Condition
+	}{
+		{
+			"single condition",
+			"tx.height=5",
+			[]Condition{{KeyTxHeight, OpEqual, "5"}},
+		},
+		{
+			"several conditions",
+			"  message.type = 'exec' AND Transfer.from='g1abc'AND block.height>=10 ",
+			[]Condition{
+				{KeyMessageType, OpEqual, "exec"},
+				{"Transfer.from", OpEqual, "g1abc"},
+				{KeyBlockHeight, OpGreaterEqual, "10"},
+			},
+		},
+		{
+			"escaped string",
+			`Note.text='it\'s a \\ AND'`,
+			[]Condition{{"Note.text", OpEqual, `it's a \ AND`}},
+		},
+		{
+			"all height operators",
+			"tx.height<9 AND tx.height<=8 AND tx.height>1 AND tx.height>=2",
+			[]Condition{
+				{KeyTxHeight, OpLess, "9"},
+				{KeyTxHeight, OpLessEqual, "8"},
+				{KeyTxHeight, OpGreater, "1"},
+				{KeyTxHeight, OpGreaterEqual, "2"},
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := ParseQuery(testCase.query)
+			require.NoError(t, err)
+
+			assert.Equal(t, testCase.expected, q.Conditions)
+		})
+	}
+}
+
+func TestParseQuery_Invalid(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name  string
+		query string
+		err   string
+	}{
+		{"empty", "  ", "empty query"},
+		{"missing operator", "tx.height", "expected operator"},
+		{"missing value", "tx.height=", "expected value"},
+		{"missing key", "='a'", "expected key"},
+		{"missing AND", "a.b='a' c.d='b'", "expected AND"},
+		{"unquoted string", "a.b=abc", "expected quoted string or integer"},
+		{"unterminated string", "a.b='abc", "unterminated string"},
+		{"quoted height", "tx.height='5'", "invalid height"},
+		{"compared string", "a.b>'a'", "operator > is only supported for heights"},
+		{"invalid hash", "tx.hash='xyz'", "invalid hash"},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseQuery(testCase.query)
+			assert.ErrorContains(t, err, testCase.err)
+		})
+	}
+}
+
+func TestQuery_HeightRange(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		query  string
+		lo, hi int64
+	}{
+		{"a.b='c'", 0, math.MaxInt64},
+		{"tx.height=5", 5, 5},
+		{"tx.height>5 AND block.height<10", 6, 9},
+		{"tx.height>=5 AND tx.height<=10 AND tx.height>7", 8, 10},
+		{"tx.height=5 AND tx.height=6", 6, 5},
+		{"tx.height>9223372036854775807", 1, 0},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.query, func(t *testing.T) {
+			t.Parallel()
+
+			q, err := ParseQuery(testCase.query)
+			require.NoError(t, err)
+
+			lo, hi := q.heightRange()
+			assert.Equal(t, testCase.lo, lo)
+			assert.Equal(t, testCase.hi, hi)
+		})
+	}
+}
@@ -22,3 +22,19 @@ type TxEventStore interface {
 	// to the event store
 	Append(result types.TxResult) error
 }
+
+// TxSearcher is implemented by the event stores which index the transactions
+// they store, so that they can be searched with a query.
+type TxSearcher interface {
+	// SearchTxs returns the number of transactions matching the query, and
+	// the results of at most limit of them after skipping offset, by
+	// increasing height and index, or decreasing if desc is set. Stores may
+	// cap the number, to bound the scan of a search.
+	SearchTxs(query string, offset, limit int, desc bool) ([]types.TxResult, int, error)
+
+	// SearchBlocks returns the number of blocks with transactions matching
+	// the query, and the heights of at most limit of them after skipping
+	// offset, increasing, or decreasing if desc is set. Stores may cap the
+	// number, as for SearchTxs.
+	SearchBlocks(query string, offset, limit int, desc bool) ([]int64, int, error)
+}
@@ -134,6 +134,22 @@ func saveState(db dbm.DB, state State, key []byte) {
 	db.SetSync(key, state.Bytes())
 }
 
+// BootstrapState persists the State of a node bootstrapped by state sync at
+// state.LastBlockHeight, which has no history: the validator sets and the
+// consensus params needed to validate and execute the next blocks are
+// persisted in full along with it.
+// This flushes the writes (e.g. calls SetSync).
+func BootstrapState(db dbm.DB, state State) {
+	height := state.LastBlockHeight + 1
+	if height > 1 && state.LastValidators != nil {
+		saveValidatorsInfo(db, height-1, height-1, state.LastValidators)
+	}
+	saveValidatorsInfo(db, height, height, state.Validators)
+	saveValidatorsInfo(db, height+1, height+1, state.NextValidators)
+	saveConsensusParamsInfo(db, height, height, state.ConsensusParams)
+	db.SetSync(stateKey, state.Bytes())
+}
+
 // ------------------------------------------------------------------------
 
 // ABCIResponses retains the responses
@@ -0,0 +1,192 @@
+package statesync
+
+import (
+	"context"
+	"sync"
+
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+// chunk is a chunk of a snapshot, received from the peer Sender.
+type chunk struct {
+	Index  uint32
+	Chunk  []byte
+	Sender p2pTypes.ID
+}
+
+// chunkQueue holds the chunks of the snapshot being restored, which are
+// fetched concurrently, at most window chunks ahead of the next one to apply,
+// and applied in order.
+type chunkQueue struct {
+	mtx sync.Mutex
+
+	total     uint32            // number of chunks of the snapshot
+	window    uint32            // number of chunks fetched ahead
+	next      uint32            // index of the next chunk to apply
+	allocated map[uint32]bool   // chunks being fetched, or fetched
+	chunks    map[uint32]*chunk // fetched chunks, not applied yet
+	updated   chan struct{}     // closed on every change of the queue
+	closed    bool
+}
+
+func newChunkQueue(total, window uint32) *chunkQueue {
+	return &chunkQueue{
+		total:     total,
+		window:    max(window, 1),
+		allocated: make(map[uint32]bool),
+		chunks:    make(map[uint32]*chunk),
+		updated:   make(chan struct{}),
+	}
+}
+
+// notify wakes up the waiters of the queue. The queue must be locked.
+func (q *chunkQueue) notify() {
+	close(q.updated)
+	q.updated = make(chan struct{})
+}
+
+// wait waits for a change of the queue, after it was unlocked.
+func wait(ctx context.Context, updated <-chan struct{}) error {
+	select {
+	case <-updated:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Allocate returns the index of the next chunk to fetch, waiting for the
+// window to move if needed. It returns an error if the queue is closed, or
+// ctx done.
+func (q *chunkQueue) Allocate(ctx context.Context) (uint32, error) {
+	for {
+		q.mtx.Lock()
+		if q.closed {
+			q.mtx.Unlock()
+			return 0, errChunkQueueClosed
+		}
+		end := min(q.next+q.window, q.total)
+		for index := q.next; index < end; index++ {
+			if !q.allocated[index] {
+				q.allocated[index] = true
+				q.mtx.Unlock()
+				return index, nil
+			}
+		}
+		updated := q.updated
+		q.mtx.Unlock()
+
+		if err := wait(ctx, updated); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Add adds the fetched chunk, and returns whether it was expected.
+func (q *chunkQueue) Add(c *chunk) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if q.closed || !q.allocated[c.Index] || c.Index < q.next || q.chunks[c.Index] != nil {
+		return false
+	}
+	q.chunks[c.Index] = c
+	q.notify()
+	return true
+}
+
+// WaitFor waits until the chunk at index is fetched, and returns whether it
+// was. It returns false if the chunk is to be fetched again, which happens
+// when Retry is called while waiting.
+func (q *chunkQueue) WaitFor(ctx context.Context, index uint32) (bool, error) {
+	for {
+		q.mtx.Lock()
+		switch {
+		case q.closed:
+			q.mtx.Unlock()
+			return false, errChunkQueueClosed
+		case index < q.next || q.chunks[index] != nil:
+			q.mtx.Unlock()
+			return true, nil
+		case !q.allocated[index]:
+			q.mtx.Unlock()
+			return false, nil
+		}
+		updated := q.updated
+		q.mtx.Unlock()
+
+		if err := wait(ctx, updated); err != nil {
+			return false, err
+		}
+	}
+}
+
+// Next waits for the next chunk to apply, and returns it.
+func (q *chunkQueue) Next(ctx context.Context) (*chunk, error) {
+	for {
+		q.mtx.Lock()
+		if q.closed {
+			q.mtx.Unlock()
+			return nil, errChunkQueueClosed
+		}
+		if c := q.chunks[q.next]; c != nil {
+			q.mtx.Unlock()
+			return c, nil
+		}
+		updated := q.updated
+		q.mtx.Unlock()
+
+		if err := wait(ctx, updated); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Advance marks the next chunk as applied.
+func (q *chunkQueue) Advance() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	delete(q.chunks, q.next)
+	q.next++
+	q.notify()
+}
+
+// Retry discards the chunk at index, to fetch it again.
+func (q *chunkQueue) Retry(index uint32) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if index < q.next {
+		return
+	}
+	delete(q.allocated, index)
+	delete(q.chunks, index)
+	q.notify()
+}
+
+// RetrySender discards the chunks fetched from sender which are not applied
+// yet, to fetch them again.
+func (q *chunkQueue) RetrySender(sender p2pTypes.ID) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	for index, c := range q.chunks {
+		if c.Sender == sender {
+			delete(q.allocated, index)
+			delete(q.chunks, index)
+		}
+	}
+	q.notify()
+}
+
+// Close closes the queue, which wakes up all its waiters.
+func (q *chunkQueue) Close() {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		q.notify()
+	}
+}
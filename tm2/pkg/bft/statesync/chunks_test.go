@@ -0,0 +1,120 @@
+package statesync
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkQueue_Allocate(t *testing.T) {
+	t.Parallel()
+
+	q := newChunkQueue(3, 2)
+	ctx := context.Background()
+
+	// Only the window is allocated.
+	for _, want := range []uint32{0, 1} {
+		index, err := q.Allocate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, index)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err := q.Allocate(timeoutCtx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// The window moves once the next chunk is applied.
+	require.True(t, q.Add(&chunk{Index: 0, Chunk: []byte{0}}))
+	c, err := q.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0), c.Index)
+	q.Advance()
+
+	index, err := q.Allocate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), index)
+
+	// Closing the queue releases the waiters.
+	done := make(chan error)
+	go func() {
+		_, err := q.Allocate(ctx)
+		done <- err
+	}()
+	q.Close()
+	require.ErrorIs(t, <-done, errChunkQueueClosed)
+}
+
+func TestChunkQueue_Add(t *testing.T) {
+	t.Parallel()
+
+	q := newChunkQueue(2, 2)
+	ctx := context.Background()
+
+	// Chunks which were not allocated are not expected.
+	assert.False(t, q.Add(&chunk{Index: 0}))
+
+	for range 2 {
+		_, err := q.Allocate(ctx)
+		require.NoError(t, err)
+	}
+
+	// Chunks are applied in order.
+	require.True(t, q.Add(&chunk{Index: 1, Chunk: []byte{1}}))
+	assert.False(t, q.Add(&chunk{Index: 1, Chunk: []byte{1}}))
+
+	next := make(chan *chunk)
+	go func() {
+		c, err := q.Next(ctx)
+		assert.NoError(t, err)
+		next <- c
+	}()
+	select {
+	case <-next:
+		t.Fatal("chunk 1 returned before chunk 0")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	require.True(t, q.Add(&chunk{Index: 0, Chunk: []byte{0}}))
+	assert.Equal(t, uint32(0), (<-next).Index)
+	q.Advance()
+
+	c, err := q.Next(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), c.Index)
+}
+
+func TestChunkQueue_Retry(t *testing.T) {
+	t.Parallel()
+
+	q := newChunkQueue(3, 3)
+	ctx := context.Background()
+
+	for range 3 {
+		_, err := q.Allocate(ctx)
+		require.NoError(t, err)
+	}
+	require.True(t, q.Add(&chunk{Index: 0, Sender: "a"}))
+	require.True(t, q.Add(&chunk{Index: 1, Sender: "b"}))
+	require.True(t, q.Add(&chunk{Index: 2, Sender: "a"}))
+
+	// A retried chunk is allocated again, and its waiters told so.
+	q.Retry(1)
+	fetched, err := q.WaitFor(ctx, 1)
+	require.NoError(t, err)
+	assert.False(t, fetched)
+
+	index, err := q.Allocate(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), index)
+
+	// The chunks of a rejected sender are fetched again.
+	q.RetrySender("a")
+	for _, want := range []uint32{0, 2} {
+		index, err := q.Allocate(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, want, index)
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// -----------------------------------------------------------------------------
+// StateSyncConfig
+
+// StateSyncConfig defines the configuration options for state sync, which
+// bootstraps a new node from a snapshot of the application state served by
+// its peers, instead of replaying the blocks from genesis
+type StateSyncConfig struct {
+	Enable              bool          `json:"enable" toml:"enable" comment:"Bootstrap a new node from a state sync snapshot of its peers, instead of replaying the blocks from genesis.\n The snapshot is verified with a light client against the trusted header below, and\n fetched from the peers: at least one RPC server is needed for the light client."`
+	RPCServers          []string      `json:"rpc_servers" toml:"rpc_servers" comment:"RPC servers of the light client verifying the snapshot (the first one is the primary)"`
+	TrustHeight         int64         `json:"trust_height" toml:"trust_height" comment:"Height of the trusted header, obtained from a trusted source"`
+	TrustHash           string        `json:"trust_hash" toml:"trust_hash" comment:"Hash of the trusted header, hex-encoded"`
+	TrustPeriod         time.Duration `json:"trust_period" toml:"trust_period" comment:"Period a trusted validator set stays trusted, which should be shorter than the unbonding period"`
+	DiscoveryTime       time.Duration `json:"discovery_time" toml:"discovery_time" comment:"Time spent discovering the snapshots of the peers before restoring one"`
+	ChunkRequestTimeout time.Duration `json:"chunk_request_timeout" toml:"chunk_request_timeout" comment:"Timeout of a chunk request, before requesting it from another peer"`
+	ChunkFetchers       int           `json:"chunk_fetchers" toml:"chunk_fetchers" comment:"Number of chunks fetched concurrently"`
+}
+
+// DefaultStateSyncConfig returns a default configuration for state sync
+func DefaultStateSyncConfig() *StateSyncConfig {
+	return &StateSyncConfig{
+		Enable:              false,
+		RPCServers:          []string{},
+		TrustPeriod:         168 * time.Hour,
+		DiscoveryTime:       15 * time.Second,
+		ChunkRequestTimeout: 15 * time.Second,
+		ChunkFetchers:       4,
+	}
+}
+
+// TestStateSyncConfig returns a configuration for testing state sync
+func TestStateSyncConfig() *StateSyncConfig {
+	cfg := DefaultStateSyncConfig()
+	cfg.DiscoveryTime = 100 * time.Millisecond
+	cfg.ChunkRequestTimeout = time.Second
+	return cfg
+}
+
+// TrustHashBytes returns the trusted header hash, decoded.
+func (cfg *StateSyncConfig) TrustHashBytes() []byte {
+	hash, err := hex.DecodeString(cfg.TrustHash)
+	if err != nil {
+		panic(err)
+	}
+	return hash
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *StateSyncConfig) ValidateBasic() error {
+	if cfg.DiscoveryTime < 0 {
+		return errors.New("discovery_time can't be negative")
+	}
+	if cfg.ChunkRequestTimeout < 0 {
+		return errors.New("chunk_request_timeout can't be negative")
+	}
+	if cfg.ChunkFetchers < 0 {
+		return errors.New("chunk_fetchers can't be negative")
+	}
+	if !cfg.Enable {
+		return nil
+	}
+
+	if len(cfg.RPCServers) == 0 {
+		return errors.New("at least one rpc server is required")
+	}
+	if cfg.TrustHeight <= 0 {
+		return errors.New("trust_height must be positive")
+	}
+	if cfg.TrustPeriod <= 0 {
+		return errors.New("trust_period must be positive")
+	}
+	if cfg.ChunkRequestTimeout == 0 {
+		return errors.New("chunk_request_timeout must be positive")
+	}
+	if cfg.ChunkFetchers == 0 {
+		return errors.New("chunk_fetchers must be positive")
+	}
+	if _, err := hex.DecodeString(cfg.TrustHash); err != nil || cfg.TrustHash == "" {
+		return errors.New("trust_hash must be a hex-encoded hash")
+	}
+	return nil
+}
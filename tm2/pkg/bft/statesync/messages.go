@@ -0,0 +1,121 @@
+package statesync
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+const (
+	// snapshotMsgSize is the maximum size of a snapshotsResponseMessage,
+	// whose metadata holds the hashes of the chunks of the snapshot.
+	snapshotMsgSize = 4 << 20
+	// chunkMsgSize is the maximum size of a chunkResponseMessage.
+	chunkMsgSize = 16 << 20
+)
+
+// StateSyncMessage is a message of the state sync reactor.
+type StateSyncMessage interface {
+	ValidateBasic() error
+}
+
+func decodeMsg(bz []byte) (msg StateSyncMessage, err error) {
+	if len(bz) > chunkMsgSize {
+		return msg, fmt.Errorf("msg exceeds max size (%d > %d)", len(bz), chunkMsgSize)
+	}
+	err = amino.Unmarshal(bz, &msg)
+	return
+}
+
+// -------------------------------------
+
+// snapshotsRequestMessage requests the recent snapshots of a peer.
+type snapshotsRequestMessage struct{}
+
+// ValidateBasic performs basic validation.
+func (m *snapshotsRequestMessage) ValidateBasic() error {
+	return nil
+}
+
+func (m *snapshotsRequestMessage) String() string {
+	return "[snapshotsRequestMessage]"
+}
+
+// -------------------------------------
+
+// snapshotsResponseMessage advertises a snapshot, in response to a
+// snapshotsRequestMessage.
+type snapshotsResponseMessage struct {
+	Height   int64
+	Format   uint32
+	Chunks   uint32
+	Hash     []byte
+	Metadata []byte
+}
+
+// ValidateBasic performs basic validation.
+func (m *snapshotsResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("non-positive height")
+	}
+	if m.Chunks == 0 {
+		return errors.New("no chunks")
+	}
+	if len(m.Hash) == 0 {
+		return errors.New("no snapshot hash")
+	}
+	return nil
+}
+
+func (m *snapshotsResponseMessage) String() string {
+	return fmt.Sprintf("[snapshotsResponseMessage %v/%v %X]", m.Height, m.Format, m.Hash)
+}
+
+// -------------------------------------
+
+// chunkRequestMessage requests a chunk of a snapshot.
+type chunkRequestMessage struct {
+	Height int64
+	Format uint32
+	Index  uint32
+}
+
+// ValidateBasic performs basic validation.
+func (m *chunkRequestMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("non-positive height")
+	}
+	return nil
+}
+
+func (m *chunkRequestMessage) String() string {
+	return fmt.Sprintf("[chunkRequestMessage %v/%v %v]", m.Height, m.Format, m.Index)
+}
+
+// -------------------------------------
+
+// chunkResponseMessage returns a chunk of a snapshot, or Missing if the peer
+// does not have it.
+type chunkResponseMessage struct {
+	Height  int64
+	Format  uint32
+	Index   uint32
+	Chunk   []byte
+	Missing bool
+}
+
+// ValidateBasic performs basic validation.
+func (m *chunkResponseMessage) ValidateBasic() error {
+	if m.Height <= 0 {
+		return errors.New("non-positive height")
+	}
+	if m.Missing && len(m.Chunk) > 0 {
+		return errors.New("missing chunk with contents")
+	}
+	return nil
+}
+
+func (m *chunkResponseMessage) String() string {
+	return fmt.Sprintf("[chunkResponseMessage %v/%v %v missing=%v]", m.Height, m.Format, m.Index, m.Missing)
+}
@@ -0,0 +1,38 @@
+package statesync
+
+// Internal test package: the reactor-message types (snapshotsRequestMessage
+// etc.) are unexported and can't be reached from outside.
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/amino/aminotest"
+)
+
+func TestCodecParity_StateSync(t *testing.T) {
+	t.Parallel()
+
+	cdc := amino.NewCodec()
+	cdc.RegisterPackage(Package)
+	cdc.Seal()
+
+	cases := []struct {
+		name string
+		v    any
+	}{
+		{"snapshotsRequestMessage", &snapshotsRequestMessage{}},
+		{"snapshotsResponseMessage", &snapshotsResponseMessage{Height: 100, Format: 1, Chunks: 3, Hash: []byte{0x01}, Metadata: []byte{0x02, 0x03}}},
+		{"chunkRequestMessage", &chunkRequestMessage{Height: 100, Format: 1, Index: 2}},
+		{"chunkResponseMessage", &chunkResponseMessage{Height: 100, Format: 1, Index: 2, Chunk: []byte{0x04}}},
+		{"chunkResponseMessage/missing", &chunkResponseMessage{Height: 100, Format: 1, Index: 2, Missing: true}},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("%d/%s", i, c.name), func(t *testing.T) {
+			t.Parallel()
+			aminotest.AssertCodecParity(t, cdc, c.v)
+		})
+	}
+}
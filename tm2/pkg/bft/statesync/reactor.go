@@ -0,0 +1,241 @@
+package statesync
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/statesync/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/p2p"
+)
+
+const (
+	// SnapshotChannel exchanges the snapshots of the peers.
+	SnapshotChannel = byte(0x60)
+	// ChunkChannel exchanges the chunks of the snapshots.
+	ChunkChannel = byte(0x61)
+
+	// recentSnapshots is the number of snapshots advertised to a peer.
+	recentSnapshots = 10
+)
+
+// Reactor serves the snapshots of the app to the peers, and restores the
+// app state from the snapshots of the peers when syncing (see Sync).
+type Reactor struct {
+	p2p.BaseReactor
+
+	cfg       *config.StateSyncConfig
+	conn      appconn.Snapshot
+	connQuery appconn.Query
+
+	mtx    sync.RWMutex
+	syncer *syncer // set while syncing
+}
+
+// NewReactor returns a new state sync reactor.
+func NewReactor(cfg *config.StateSyncConfig, conn appconn.Snapshot, connQuery appconn.Query) *Reactor {
+	r := &Reactor{
+		cfg:       cfg,
+		conn:      conn,
+		connQuery: connQuery,
+	}
+	r.BaseReactor = *p2p.NewBaseReactor("StateSyncReactor", r)
+	return r
+}
+
+// GetChannels implements Reactor.
+func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
+	return []*p2p.ChannelDescriptor{
+		{
+			ID:                  SnapshotChannel,
+			Priority:            5,
+			SendQueueCapacity:   10,
+			RecvMessageCapacity: snapshotMsgSize,
+		},
+		{
+			ID:                  ChunkChannel,
+			Priority:            3,
+			SendQueueCapacity:   10,
+			RecvBufferCapacity:  8 * 4096,
+			RecvMessageCapacity: chunkMsgSize,
+		},
+	}
+}
+
+// AddPeer implements Reactor, asking the peer for its snapshots when syncing.
+func (r *Reactor) AddPeer(peer p2p.PeerConn) {
+	if r.getSyncer() != nil {
+		peer.Send(SnapshotChannel, amino.MustMarshalAny(&snapshotsRequestMessage{}))
+	}
+}
+
+// RemovePeer implements Reactor.
+func (r *Reactor) RemovePeer(peer p2p.PeerConn, reason any) {
+	if s := r.getSyncer(); s != nil {
+		s.RemovePeer(peer.ID())
+	}
+}
+
+// Receive implements Reactor.
+func (r *Reactor) Receive(chID byte, src p2p.PeerConn, msgBytes []byte) {
+	msg, err := decodeMsg(msgBytes)
+	if err != nil {
+		r.Logger.Error("Error decoding message", "src", src, "chId", chID, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	if err = msg.ValidateBasic(); err != nil {
+		r.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
+		r.Switch.StopPeerForError(src, err)
+		return
+	}
+
+	r.Logger.Debug("Receive", "src", src, "chID", chID, "msg", msg)
+
+	switch msg := msg.(type) {
+	case *snapshotsRequestMessage:
+		r.respondSnapshots(src)
+	case *snapshotsResponseMessage:
+		if s := r.getSyncer(); s != nil {
+			s.AddSnapshot(src, &abci.Snapshot{
+				Height:   msg.Height,
+				Format:   msg.Format,
+				Chunks:   msg.Chunks,
+				Hash:     msg.Hash,
+				Metadata: msg.Metadata,
+			})
+		}
+	case *chunkRequestMessage:
+		r.respondChunk(src, msg)
+	case *chunkResponseMessage:
+		if s := r.getSyncer(); s != nil {
+			s.AddChunk(src, msg)
+		}
+	default:
+		r.Logger.Error(fmt.Sprintf("Unknown message type %v", reflect.TypeOf(msg)))
+	}
+}
+
+// respondSnapshots sends the recent snapshots of the app to the peer.
+func (r *Reactor) respondSnapshots(src p2p.PeerConn) {
+	res, err := r.conn.ListSnapshotsSync(abci.RequestListSnapshots{})
+	if err != nil {
+		r.Logger.Error("Unable to list snapshots", "err", err)
+		return
+	}
+	if res.Error != nil {
+		r.Logger.Error("Unable to list snapshots", "err", res.Error)
+		return
+	}
+
+	snapshots := res.Snapshots
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Height > snapshots[j].Height
+	})
+	if len(snapshots) > recentSnapshots {
+		snapshots = snapshots[:recentSnapshots]
+	}
+
+	for _, snapshot := range snapshots {
+		src.TrySend(SnapshotChannel, amino.MustMarshalAny(&snapshotsResponseMessage{
+			Height:   snapshot.Height,
+			Format:   snapshot.Format,
+			Chunks:   snapshot.Chunks,
+			Hash:     snapshot.Hash,
+			Metadata: snapshot.Metadata,
+		}))
+	}
+}
+
+// respondChunk sends the requested chunk to the peer, or tells it is missing.
+func (r *Reactor) respondChunk(src p2p.PeerConn, msg *chunkRequestMessage) {
+	res, err := r.conn.LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk{
+		Height: msg.Height,
+		Format: msg.Format,
+		Chunk:  msg.Index,
+	})
+	if err != nil {
+		r.Logger.Error("Unable to load snapshot chunk", "height", msg.Height, "index", msg.Index, "err", err)
+		return
+	}
+
+	missing := res.Error != nil || len(res.Chunk) == 0
+	if missing {
+		r.Logger.Info("Peer asking for a snapshot chunk we don't have", "src", src,
+			"height", msg.Height, "index", msg.Index)
+	}
+	src.TrySend(ChunkChannel, amino.MustMarshalAny(&chunkResponseMessage{
+		Height:  msg.Height,
+		Format:  msg.Format,
+		Index:   msg.Index,
+		Chunk:   res.Chunk,
+		Missing: missing,
+	}))
+}
+
+func (r *Reactor) getSyncer() *syncer {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	return r.syncer
+}
+
+// Sync restores the app state from the snapshots of the peers, verified by
+// stateProvider, and returns the state and commit of the height of the
+// restored snapshot, to bootstrap the node from.
+func (r *Reactor) Sync(ctx context.Context, stateProvider StateProvider) (sm.State, *types.Commit, error) {
+	r.mtx.Lock()
+	if r.syncer != nil {
+		r.mtx.Unlock()
+		return sm.State{}, nil, errors.New("a state sync is already running")
+	}
+	r.syncer = &syncer{
+		logger:              r.Logger,
+		stateProvider:       stateProvider,
+		conn:                r.conn,
+		connQuery:           r.connQuery,
+		snapshots:           newSnapshotPool(),
+		chunkFetchers:       r.cfg.ChunkFetchers,
+		chunkRequestTimeout: r.cfg.ChunkRequestTimeout,
+		requestSnapshots: func() {
+			r.Switch.Broadcast(SnapshotChannel, amino.MustMarshalAny(&snapshotsRequestMessage{}))
+		},
+		requestChunk: func(peer p2p.PeerConn, snapshot *abci.Snapshot, index uint32) {
+			peer.Send(ChunkChannel, amino.MustMarshalAny(&chunkRequestMessage{
+				Height: snapshot.Height,
+				Format: snapshot.Format,
+				Index:  index,
+			}))
+		},
+	}
+	s := r.syncer
+	r.mtx.Unlock()
+
+	defer func() {
+		r.mtx.Lock()
+		r.syncer = nil
+		r.mtx.Unlock()
+	}()
+
+	// Stop syncing with the reactor.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-r.Quit():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return s.SyncAny(ctx, r.cfg.DiscoveryTime)
+}
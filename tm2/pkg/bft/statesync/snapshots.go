@@ -0,0 +1,169 @@
+package statesync
+
+import (
+	"sort"
+	"sync"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/p2p"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/gnolang/gno/tm2/pkg/random"
+)
+
+// snapshotKey identifies a snapshot.
+type snapshotKey struct {
+	height int64
+	format uint32
+	hash   string
+}
+
+func keyOf(snapshot *abci.Snapshot) snapshotKey {
+	return snapshotKey{
+		height: snapshot.Height,
+		format: snapshot.Format,
+		hash:   string(snapshot.Hash),
+	}
+}
+
+// snapshotPool tracks the snapshots advertised by the peers, and the peers
+// which have each of them.
+type snapshotPool struct {
+	mtx sync.Mutex
+
+	snapshots     map[snapshotKey]*abci.Snapshot
+	peers         map[snapshotKey]map[p2pTypes.ID]p2p.PeerConn
+	rejected      map[snapshotKey]bool
+	rejectedPeers map[p2pTypes.ID]bool
+}
+
+func newSnapshotPool() *snapshotPool {
+	return &snapshotPool{
+		snapshots:     make(map[snapshotKey]*abci.Snapshot),
+		peers:         make(map[snapshotKey]map[p2pTypes.ID]p2p.PeerConn),
+		rejected:      make(map[snapshotKey]bool),
+		rejectedPeers: make(map[p2pTypes.ID]bool),
+	}
+}
+
+// Add adds the snapshot advertised by peer, and returns whether it is a new
+// snapshot. Snapshots which were rejected, or advertised by a rejected peer,
+// are ignored.
+func (p *snapshotPool) Add(peer p2p.PeerConn, snapshot *abci.Snapshot) bool {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	key := keyOf(snapshot)
+	if p.rejected[key] || p.rejectedPeers[peer.ID()] {
+		return false
+	}
+
+	if p.peers[key] == nil {
+		p.peers[key] = make(map[p2pTypes.ID]p2p.PeerConn)
+	}
+	p.peers[key][peer.ID()] = peer
+
+	if _, ok := p.snapshots[key]; ok {
+		return false
+	}
+	p.snapshots[key] = snapshot
+	return true
+}
+
+// Best returns the best snapshot to restore, or nil if there is none: the
+// most recent one, and the one with the most peers among those.
+func (p *snapshotPool) Best() *abci.Snapshot {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	candidates := make([]*abci.Snapshot, 0, len(p.snapshots))
+	for key, snapshot := range p.snapshots {
+		if len(p.peers[key]) > 0 {
+			candidates = append(candidates, snapshot)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.Height != b.Height {
+			return a.Height > b.Height
+		}
+		if a.Format != b.Format {
+			return a.Format > b.Format
+		}
+		return len(p.peers[keyOf(a)]) > len(p.peers[keyOf(b)])
+	})
+	return candidates[0]
+}
+
+// Reject rejects the snapshot, which is removed and never added again.
+func (p *snapshotPool) Reject(snapshot *abci.Snapshot) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	key := keyOf(snapshot)
+	p.rejected[key] = true
+	delete(p.snapshots, key)
+	delete(p.peers, key)
+}
+
+// GetPeer returns a random peer which has the snapshot, or nil if there is
+// none.
+func (p *snapshotPool) GetPeer(snapshot *abci.Snapshot) p2p.PeerConn {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	peers := p.peers[keyOf(snapshot)]
+	if len(peers) == 0 {
+		return nil
+	}
+	ids := make([]p2pTypes.ID, 0, len(peers))
+	for id := range peers {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return peers[ids[random.RandIntn(len(ids))]]
+}
+
+// NumPeers returns the number of peers which have the snapshot.
+func (p *snapshotPool) NumPeers(snapshot *abci.Snapshot) int {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return len(p.peers[keyOf(snapshot)])
+}
+
+// RemovePeerSnapshot removes the snapshot from those of the peer, which does
+// not have it anymore.
+func (p *snapshotPool) RemovePeerSnapshot(id p2pTypes.ID, snapshot *abci.Snapshot) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	delete(p.peers[keyOf(snapshot)], id)
+}
+
+// RemovePeer removes the peer from all the snapshots.
+func (p *snapshotPool) RemovePeer(id p2pTypes.ID) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.removePeer(id)
+}
+
+// RejectPeer removes the peer from all the snapshots, and ignores the
+// snapshots it advertises from now on.
+func (p *snapshotPool) RejectPeer(id p2pTypes.ID) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.rejectedPeers[id] = true
+	p.removePeer(id)
+}
+
+func (p *snapshotPool) removePeer(id p2pTypes.ID) {
+	for _, peers := range p.peers {
+		delete(peers, id)
+	}
+}
@@ -0,0 +1,71 @@
+package statesync
+
+import (
+	"testing"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/p2p/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotPool_Best(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pool  = newSnapshotPool()
+		peers = mock.GeneratePeers(t, 3)
+
+		old    = &abci.Snapshot{Height: 10, Format: 1, Chunks: 1, Hash: []byte{1}}
+		recent = &abci.Snapshot{Height: 20, Format: 1, Chunks: 1, Hash: []byte{2}}
+		other  = &abci.Snapshot{Height: 20, Format: 1, Chunks: 1, Hash: []byte{3}}
+	)
+
+	assert.Nil(t, pool.Best())
+
+	assert.True(t, pool.Add(peers[0], old))
+	assert.False(t, pool.Add(peers[1], old))
+	assert.True(t, pool.Add(peers[0], recent))
+	assert.True(t, pool.Add(peers[1], other))
+	assert.False(t, pool.Add(peers[2], other))
+
+	// The most recent snapshot with the most peers is the best.
+	assert.Equal(t, other, pool.Best())
+	assert.Equal(t, 2, pool.NumPeers(other))
+
+	// Rejected snapshots are never added again.
+	pool.Reject(other)
+	assert.False(t, pool.Add(peers[0], other))
+	assert.Equal(t, recent, pool.Best())
+
+	// Snapshots without peers are skipped.
+	pool.RemovePeerSnapshot(peers[0].ID(), recent)
+	assert.Nil(t, pool.GetPeer(recent))
+	assert.Equal(t, old, pool.Best())
+}
+
+func TestSnapshotPool_RejectPeer(t *testing.T) {
+	t.Parallel()
+
+	var (
+		pool  = newSnapshotPool()
+		peers = mock.GeneratePeers(t, 2)
+
+		snapshot = &abci.Snapshot{Height: 10, Format: 1, Chunks: 1, Hash: []byte{1}}
+	)
+
+	require.True(t, pool.Add(peers[0], snapshot))
+	require.False(t, pool.Add(peers[1], snapshot))
+
+	pool.RejectPeer(peers[0].ID())
+	for range 10 {
+		assert.Equal(t, peers[1].ID(), pool.GetPeer(snapshot).ID())
+	}
+
+	// The snapshots of a rejected peer are ignored.
+	pool.Add(peers[0], snapshot)
+	assert.Equal(t, 1, pool.NumPeers(snapshot))
+
+	pool.RemovePeer(peers[1].ID())
+	assert.Nil(t, pool.Best())
+}
@@ -0,0 +1,160 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/light"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	typesver "github.com/gnolang/gno/tm2/pkg/bft/types/version"
+	tmver "github.com/gnolang/gno/tm2/pkg/bft/version"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+// StateProvider provides the trusted data needed to bootstrap a node from the
+// snapshot of the application state at a height.
+type StateProvider interface {
+	// AppHash returns the app hash of the state after the block at height,
+	// which is the one of the header at height+1.
+	AppHash(ctx context.Context, height int64) ([]byte, error)
+	// Commit returns the commit of the block at height.
+	Commit(ctx context.Context, height int64) (*types.Commit, error)
+	// State returns the consensus state after the block at height.
+	State(ctx context.Context, height int64) (sm.State, error)
+}
+
+var _ StateProvider = (*lightStateProvider)(nil)
+
+// lightStateProvider is a StateProvider verifying the light blocks of the
+// chain with a light client, and their consensus params against the
+// verified headers.
+type lightStateProvider struct {
+	chainID       string
+	initialHeight int64
+	lc            *light.Client
+	rpc           rpcclient.Client
+}
+
+// NewLightStateProvider returns a StateProvider verifying the light blocks
+// fetched from the first of servers with a light client trusting
+// trustOptions.
+func NewLightStateProvider(
+	ctx context.Context,
+	chainID string,
+	initialHeight int64,
+	servers []string,
+	trustOptions light.TrustOptions,
+) (StateProvider, error) {
+	if len(servers) == 0 {
+		return nil, errors.New("at least one rpc server is required")
+	}
+
+	cli, err := rpcclient.NewHTTPClient(servers[0])
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the rpc client of %s, %w", servers[0], err)
+	}
+
+	lc, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		light.NewRPCProvider(cli),
+		light.NewDBStore(memdb.NewMemDB()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the light client, %w", err)
+	}
+
+	return &lightStateProvider{
+		chainID:       chainID,
+		initialHeight: initialHeight,
+		lc:            lc,
+		rpc:           cli,
+	}, nil
+}
+
+func (s *lightStateProvider) verify(ctx context.Context, height int64) (*light.LightBlock, error) {
+	return s.lc.VerifyLightBlockAtHeight(ctx, height, time.Now())
+}
+
+// AppHash implements StateProvider.
+func (s *lightStateProvider) AppHash(ctx context.Context, height int64) ([]byte, error) {
+	header, err := s.verify(ctx, height+1)
+	if err != nil {
+		return nil, err
+	}
+	// The state also needs the validators of height+2, which must be
+	// available before the snapshot is accepted.
+	if _, err := s.verify(ctx, height+2); err != nil {
+		return nil, err
+	}
+	return header.AppHash, nil
+}
+
+// Commit implements StateProvider.
+func (s *lightStateProvider) Commit(ctx context.Context, height int64) (*types.Commit, error) {
+	header, err := s.verify(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+	return header.Commit, nil
+}
+
+// State implements StateProvider.
+func (s *lightStateProvider) State(ctx context.Context, height int64) (sm.State, error) {
+	state := sm.State{
+		SoftwareVersion: tmver.Version,
+		BlockVersion:    typesver.BlockVersion,
+		ChainID:         s.chainID,
+		InitialHeight:   s.initialHeight,
+	}
+
+	// The light blocks at height and height+1 are those of the last block of
+	// the state and of the block executing it, and the one at height+2 holds
+	// the next validators.
+	last, err := s.verify(ctx, height)
+	if err != nil {
+		return sm.State{}, err
+	}
+	current, err := s.verify(ctx, height+1)
+	if err != nil {
+		return sm.State{}, err
+	}
+	next, err := s.verify(ctx, height+2)
+	if err != nil {
+		return sm.State{}, err
+	}
+
+	state.AppVersion = current.AppVersion
+	state.LastBlockHeight = last.Height
+	state.LastBlockTotalTx = last.TotalTxs
+	state.LastBlockTime = last.Time
+	state.LastBlockID = last.Commit.BlockID
+	state.AppHash = current.AppHash
+	state.LastResultsHash = current.LastResultsHash
+	state.LastValidators = last.ValidatorSet
+	state.Validators = current.ValidatorSet
+	state.NextValidators = next.ValidatorSet
+	state.LastHeightValidatorsChanged = next.Height
+
+	// The consensus params are not part of the light blocks: they are fetched
+	// from the rpc server, and checked against the verified header.
+	currentHeight := current.Height
+	res, err := s.rpc.ConsensusParams(ctx, &currentHeight)
+	if err != nil {
+		return sm.State{}, fmt.Errorf("unable to fetch the consensus params at height %d, %w", currentHeight, err)
+	}
+	if !bytes.Equal(res.ConsensusParams.Hash(), current.ConsensusHash) {
+		return sm.State{}, fmt.Errorf("consensus params hash %X does not match the header hash %X at height %d",
+			res.ConsensusParams.Hash(), current.ConsensusHash, currentHeight)
+	}
+	state.ConsensusParams = res.ConsensusParams
+	state.LastHeightConsensusParamsChanged = currentHeight
+
+	return state, nil
+}
@@ -0,0 +1,291 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/p2p"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+var (
+	// errRejectSnapshot is returned when the snapshot can't be restored,
+	// and another one should be tried.
+	errRejectSnapshot = errors.New("snapshot rejected")
+	// errNoSnapshotPeers is returned when no peer has the snapshot anymore.
+	errNoSnapshotPeers = errors.New("no peers have the snapshot")
+	// errVerifyFailed is returned when the restored app state does not
+	// match the snapshot, which leaves the app in an unknown state.
+	errVerifyFailed = errors.New("restored app state verification failed")
+
+	errChunkQueueClosed = errors.New("chunk queue closed")
+)
+
+// syncer restores the app state from the snapshots advertised by the peers:
+// it picks the best snapshot, fetches its chunks from the peers which have
+// it, and applies them to the app in order.
+type syncer struct {
+	logger              *slog.Logger
+	stateProvider       StateProvider
+	conn                appconn.Snapshot
+	connQuery           appconn.Query
+	snapshots           *snapshotPool
+	chunkFetchers       int
+	chunkRequestTimeout time.Duration
+
+	// requestSnapshots asks all the peers for their snapshots, and
+	// requestChunk asks peer for a chunk.
+	requestSnapshots func()
+	requestChunk     func(peer p2p.PeerConn, snapshot *abci.Snapshot, index uint32)
+
+	mtx      sync.Mutex
+	snapshot *abci.Snapshot // snapshot being restored, if any
+	chunks   *chunkQueue    // chunks of snapshot
+}
+
+// AddSnapshot adds the snapshot advertised by peer, and returns whether it
+// is a new snapshot.
+func (s *syncer) AddSnapshot(peer p2p.PeerConn, snapshot *abci.Snapshot) bool {
+	added := s.snapshots.Add(peer, snapshot)
+	if added {
+		s.logger.Info("Discovered new snapshot", "height", snapshot.Height, "format", snapshot.Format,
+			"hash", fmt.Sprintf("%X", snapshot.Hash), "peer", peer.ID())
+	}
+	return added
+}
+
+// AddChunk adds the chunk received from peer, if it is one of the snapshot
+// being restored. A missing chunk is fetched from another peer.
+func (s *syncer) AddChunk(peer p2p.PeerConn, msg *chunkResponseMessage) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	if s.snapshot == nil || s.snapshot.Height != msg.Height || s.snapshot.Format != msg.Format {
+		s.logger.Debug("Ignoring chunk of another snapshot", "height", msg.Height, "format", msg.Format, "peer", peer.ID())
+		return
+	}
+
+	if msg.Missing {
+		s.logger.Info("Peer does not have the snapshot anymore", "height", msg.Height, "peer", peer.ID())
+		s.snapshots.RemovePeerSnapshot(peer.ID(), s.snapshot)
+		s.chunks.Retry(msg.Index)
+		return
+	}
+
+	added := s.chunks.Add(&chunk{
+		Index:  msg.Index,
+		Chunk:  msg.Chunk,
+		Sender: peer.ID(),
+	})
+	if !added {
+		s.logger.Debug("Ignoring unexpected chunk", "height", msg.Height, "index", msg.Index, "peer", peer.ID())
+	}
+}
+
+// RemovePeer removes the peer from the snapshots it has.
+func (s *syncer) RemovePeer(id p2pTypes.ID) {
+	s.snapshots.RemovePeer(id)
+}
+
+// SyncAny discovers the snapshots of the peers, waiting discoveryTime for
+// new ones when there are none left, and restores the best one which can be
+// restored. It returns the state and commit of the height of the snapshot.
+func (s *syncer) SyncAny(ctx context.Context, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
+	for {
+		snapshot := s.snapshots.Best()
+		if snapshot == nil {
+			s.logger.Info("Discovering snapshots", "time", discoveryTime)
+			s.requestSnapshots()
+
+			select {
+			case <-ctx.Done():
+				return sm.State{}, nil, ctx.Err()
+			case <-time.After(discoveryTime):
+			}
+			continue
+		}
+
+		state, commit, err := s.Sync(ctx, snapshot)
+		switch {
+		case err == nil:
+			return state, commit, nil
+		case errors.Is(err, errRejectSnapshot), errors.Is(err, errNoSnapshotPeers):
+			s.logger.Info("Snapshot rejected", "height", snapshot.Height, "format", snapshot.Format,
+				"hash", fmt.Sprintf("%X", snapshot.Hash), "err", err)
+			s.snapshots.Reject(snapshot)
+		default:
+			return sm.State{}, nil, err
+		}
+	}
+}
+
+// Sync restores the snapshot, and returns the state and commit of its
+// height. The snapshot is verified against the app hash trusted by the
+// state provider.
+func (s *syncer) Sync(ctx context.Context, snapshot *abci.Snapshot) (sm.State, *types.Commit, error) {
+	s.logger.Info("Restoring snapshot", "height", snapshot.Height, "format", snapshot.Format,
+		"hash", fmt.Sprintf("%X", snapshot.Hash), "chunks", snapshot.Chunks)
+
+	// Fetch the trusted data first, so that a snapshot which can't be
+	// verified is not offered to the app.
+	appHash, err := s.stateProvider.AppHash(ctx, snapshot.Height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("%w: unable to verify the app hash, %w", errRejectSnapshot, err)
+	}
+	state, err := s.stateProvider.State(ctx, snapshot.Height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("%w: unable to build the state, %w", errRejectSnapshot, err)
+	}
+	commit, err := s.stateProvider.Commit(ctx, snapshot.Height)
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("%w: unable to fetch the commit, %w", errRejectSnapshot, err)
+	}
+
+	res, err := s.conn.OfferSnapshotSync(abci.RequestOfferSnapshot{
+		Snapshot: snapshot,
+		AppHash:  appHash,
+	})
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("unable to offer the snapshot, %w", err)
+	}
+	if res.Error != nil {
+		return sm.State{}, nil, fmt.Errorf("%w: offer refused, %v", errRejectSnapshot, res.Error)
+	}
+
+	if err := s.restore(ctx, snapshot); err != nil {
+		return sm.State{}, nil, err
+	}
+
+	// Make sure the app is at the state of the snapshot.
+	info, err := s.connQuery.InfoSync(abci.RequestInfo{})
+	if err != nil {
+		return sm.State{}, nil, fmt.Errorf("unable to query the app info, %w", err)
+	}
+	if info.LastBlockHeight != snapshot.Height {
+		return sm.State{}, nil, fmt.Errorf("%w: app height %d, expected %d",
+			errVerifyFailed, info.LastBlockHeight, snapshot.Height)
+	}
+	if !bytes.Equal(info.LastBlockAppHash, appHash) {
+		return sm.State{}, nil, fmt.Errorf("%w: app hash %X, expected %X",
+			errVerifyFailed, info.LastBlockAppHash, appHash)
+	}
+
+	s.logger.Info("Snapshot restored", "height", snapshot.Height, "app_hash", fmt.Sprintf("%X", appHash))
+	return state, commit, nil
+}
+
+// restore fetches the chunks of the snapshot offered to the app, and applies
+// them in order.
+func (s *syncer) restore(ctx context.Context, snapshot *abci.Snapshot) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	chunks := newChunkQueue(snapshot.Chunks, uint32(s.chunkFetchers))
+	defer chunks.Close()
+
+	s.mtx.Lock()
+	s.snapshot, s.chunks = snapshot, chunks
+	s.mtx.Unlock()
+	defer func() {
+		s.mtx.Lock()
+		s.snapshot, s.chunks = nil, nil
+		s.mtx.Unlock()
+	}()
+
+	for range s.chunkFetchers {
+		go s.fetchChunks(ctx, cancel, snapshot, chunks)
+	}
+
+	err := s.applyChunks(ctx, snapshot, chunks)
+	if err != nil && ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	return err
+}
+
+// fetchChunks fetches the chunks allocated from the queue, until it is
+// closed. It fails the restore if no peer has the snapshot anymore.
+func (s *syncer) fetchChunks(
+	ctx context.Context,
+	fail context.CancelCauseFunc,
+	snapshot *abci.Snapshot,
+	chunks *chunkQueue,
+) {
+	for {
+		index, err := chunks.Allocate(ctx)
+		if err != nil {
+			return
+		}
+
+		peer := s.snapshots.GetPeer(snapshot)
+		if peer == nil {
+			fail(errNoSnapshotPeers)
+			return
+		}
+
+		s.logger.Debug("Requesting snapshot chunk", "height", snapshot.Height, "index", index, "peer", peer.ID())
+		s.requestChunk(peer, snapshot, index)
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, s.chunkRequestTimeout)
+		fetched, err := chunks.WaitFor(timeoutCtx, index)
+		cancel()
+
+		switch {
+		case ctx.Err() != nil, errors.Is(err, errChunkQueueClosed):
+			return
+		case err != nil:
+			s.logger.Debug("Timed out waiting for snapshot chunk", "height", snapshot.Height, "index", index, "peer", peer.ID())
+			chunks.Retry(index)
+		case !fetched:
+			// The chunk is to be fetched again, maybe by another fetcher.
+		}
+	}
+}
+
+// applyChunks applies the chunks of the snapshot to the app, in order.
+func (s *syncer) applyChunks(ctx context.Context, snapshot *abci.Snapshot, chunks *chunkQueue) error {
+	for applied := uint32(0); applied < snapshot.Chunks; {
+		c, err := chunks.Next(ctx)
+		if err != nil {
+			return err
+		}
+
+		res, err := s.conn.ApplySnapshotChunkSync(abci.RequestApplySnapshotChunk{
+			Index:  c.Index,
+			Chunk:  c.Chunk,
+			Sender: string(c.Sender),
+		})
+		if err != nil {
+			return fmt.Errorf("unable to apply chunk %d, %w", c.Index, err)
+		}
+
+		for _, sender := range res.RejectSenders {
+			s.logger.Info("Rejecting snapshot chunk sender", "peer", sender)
+			s.snapshots.RejectPeer(p2pTypes.ID(sender))
+			chunks.RetrySender(p2pTypes.ID(sender))
+		}
+		for _, index := range res.RefetchChunks {
+			chunks.Retry(index)
+		}
+
+		if res.Error != nil {
+			if len(res.RefetchChunks) == 0 {
+				return fmt.Errorf("%w: chunk %d refused, %v", errRejectSnapshot, c.Index, res.Error)
+			}
+			continue
+		}
+
+		chunks.Advance()
+		applied++
+	}
+	return nil
+}
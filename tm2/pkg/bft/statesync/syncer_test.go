@@ -0,0 +1,264 @@
+package statesync
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/p2p"
+	"github.com/gnolang/gno/tm2/pkg/p2p/mock"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp is an app restoring snapshots whose chunks must be those of
+// chunks, and which refuses the offers of the heights of rejectOffers. The
+// restored app hash is the one offered, unless restoredAppHash is set.
+type testApp struct {
+	mtx sync.Mutex
+
+	chunks          [][]byte
+	rejectOffers    map[int64]bool
+	restoredAppHash []byte
+
+	offered *abci.RequestOfferSnapshot
+	applied []uint32
+	height  int64
+	appHash []byte
+}
+
+func (a *testApp) Error() error { return nil }
+
+func (a *testApp) EchoSync(msg string) (abci.ResponseEcho, error) {
+	return abci.ResponseEcho{Message: msg}, nil
+}
+
+func (a *testApp) InfoSync(abci.RequestInfo) (abci.ResponseInfo, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	return abci.ResponseInfo{LastBlockHeight: a.height, LastBlockAppHash: a.appHash}, nil
+}
+
+func (a *testApp) QuerySync(abci.RequestQuery) (abci.ResponseQuery, error) {
+	return abci.ResponseQuery{}, nil
+}
+
+func (a *testApp) ListSnapshotsSync(abci.RequestListSnapshots) (abci.ResponseListSnapshots, error) {
+	return abci.ResponseListSnapshots{}, nil
+}
+
+func (a *testApp) OfferSnapshotSync(req abci.RequestOfferSnapshot) (res abci.ResponseOfferSnapshot, _ error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if a.rejectOffers[req.Snapshot.Height] {
+		res.Error = abci.StringError("rejected")
+		return
+	}
+	a.offered = &req
+	a.applied = nil
+	return
+}
+
+func (a *testApp) LoadSnapshotChunkSync(abci.RequestLoadSnapshotChunk) (abci.ResponseLoadSnapshotChunk, error) {
+	return abci.ResponseLoadSnapshotChunk{}, nil
+}
+
+func (a *testApp) ApplySnapshotChunkSync(req abci.RequestApplySnapshotChunk) (res abci.ResponseApplySnapshotChunk, _ error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if int(req.Index) != len(a.applied) {
+		res.Error = abci.StringError("chunk out of order")
+		return
+	}
+	if !bytes.Equal(req.Chunk, a.chunks[req.Index]) {
+		res.Error = abci.StringError("chunk hash mismatch")
+		res.RefetchChunks = []uint32{req.Index}
+		res.RejectSenders = []string{req.Sender}
+		return
+	}
+
+	a.applied = append(a.applied, req.Index)
+	if len(a.applied) == len(a.chunks) {
+		a.height = a.offered.Snapshot.Height
+		a.appHash = a.offered.AppHash
+		if a.restoredAppHash != nil {
+			a.appHash = a.restoredAppHash
+		}
+	}
+	return
+}
+
+// testStateProvider trusts the app hash appHash at all heights.
+type testStateProvider struct {
+	appHash []byte
+}
+
+func (p *testStateProvider) AppHash(context.Context, int64) ([]byte, error) {
+	return p.appHash, nil
+}
+
+func (p *testStateProvider) Commit(context.Context, int64) (*types.Commit, error) {
+	return &types.Commit{}, nil
+}
+
+func (p *testStateProvider) State(_ context.Context, height int64) (sm.State, error) {
+	return sm.State{LastBlockHeight: height, AppHash: p.appHash}, nil
+}
+
+// newTestSyncer returns a syncer restoring into app, whose chunk requests are
+// served by serve.
+func newTestSyncer(
+	app *testApp,
+	serve func(peer p2p.PeerConn, snapshot *abci.Snapshot, index uint32) *chunkResponseMessage,
+) *syncer {
+	s := &syncer{
+		logger:              slog.New(slog.NewTextHandler(io.Discard, nil)),
+		stateProvider:       &testStateProvider{appHash: []byte("app_hash")},
+		conn:                app,
+		connQuery:           app,
+		snapshots:           newSnapshotPool(),
+		chunkFetchers:       2,
+		chunkRequestTimeout: time.Second,
+		requestSnapshots:    func() {},
+	}
+	s.requestChunk = func(peer p2p.PeerConn, snapshot *abci.Snapshot, index uint32) {
+		if msg := serve(peer, snapshot, index); msg != nil {
+			go s.AddChunk(peer, msg)
+		}
+	}
+	return s
+}
+
+func TestSyncer_SyncAny(t *testing.T) {
+	t.Parallel()
+
+	chunks := make([][]byte, 10)
+	for i := range chunks {
+		chunks[i] = []byte(fmt.Sprintf("chunk %d", i))
+	}
+
+	var (
+		app = &testApp{
+			chunks:       chunks,
+			rejectOffers: map[int64]bool{20: true},
+		}
+		peers = mock.GeneratePeers(t, 3)
+
+		rejected = &abci.Snapshot{Height: 20, Format: 1, Chunks: 10, Hash: []byte{2}}
+		snapshot = &abci.Snapshot{Height: 10, Format: 1, Chunks: 10, Hash: []byte{1}}
+
+		mtx   sync.Mutex
+		asked = make(map[p2pTypes.ID]bool)
+	)
+
+	s := newTestSyncer(app, func(peer p2p.PeerConn, snapshot *abci.Snapshot, index uint32) *chunkResponseMessage {
+		mtx.Lock()
+		asked[peer.ID()] = true
+		mtx.Unlock()
+
+		msg := &chunkResponseMessage{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Index:  index,
+			Chunk:  chunks[index],
+		}
+		switch peer.ID() {
+		case peers[1].ID():
+			// A peer serving invalid chunks.
+			msg.Chunk = []byte("invalid")
+		case peers[2].ID():
+			// A peer which pruned the snapshot.
+			msg.Chunk, msg.Missing = nil, true
+		}
+		return msg
+	})
+
+	for _, peer := range peers {
+		s.AddSnapshot(peer, rejected)
+		s.AddSnapshot(peer, snapshot)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	state, commit, err := s.SyncAny(ctx, 10*time.Millisecond)
+	require.NoError(t, err)
+	require.NotNil(t, commit)
+
+	assert.Equal(t, int64(10), state.LastBlockHeight)
+	assert.Len(t, app.applied, len(chunks))
+	assert.Equal(t, []byte("app_hash"), app.appHash)
+
+	// The peer serving invalid chunks was rejected, and the one which
+	// pruned the snapshot removed from it, if they were asked for chunks.
+	mtx.Lock()
+	defer mtx.Unlock()
+
+	assert.True(t, asked[peers[0].ID()])
+	for _, peer := range peers[1:] {
+		if asked[peer.ID()] {
+			assert.NotContains(t, s.snapshots.peers[keyOf(snapshot)], peer.ID())
+		}
+	}
+}
+
+func TestSyncer_Sync_NoPeers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		app   = &testApp{chunks: [][]byte{[]byte("chunk 0")}}
+		peers = mock.GeneratePeers(t, 1)
+
+		snapshot = &abci.Snapshot{Height: 10, Format: 1, Chunks: 1, Hash: []byte{1}}
+	)
+
+	s := newTestSyncer(app, func(_ p2p.PeerConn, snapshot *abci.Snapshot, index uint32) *chunkResponseMessage {
+		return &chunkResponseMessage{
+			Height:  snapshot.Height,
+			Format:  snapshot.Format,
+			Index:   index,
+			Missing: true,
+		}
+	})
+	s.AddSnapshot(peers[0], snapshot)
+
+	_, _, err := s.Sync(context.Background(), snapshot)
+	require.ErrorIs(t, err, errNoSnapshotPeers)
+}
+
+func TestSyncer_Sync_VerifyFailed(t *testing.T) {
+	t.Parallel()
+
+	var (
+		chunks = [][]byte{[]byte("chunk 0")}
+		app    = &testApp{chunks: chunks, restoredAppHash: []byte("other_hash")}
+		peers  = mock.GeneratePeers(t, 1)
+
+		snapshot = &abci.Snapshot{Height: 10, Format: 1, Chunks: 1, Hash: []byte{1}}
+	)
+
+	s := newTestSyncer(app, func(_ p2p.PeerConn, snapshot *abci.Snapshot, index uint32) *chunkResponseMessage {
+		return &chunkResponseMessage{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Index:  index,
+			Chunk:  chunks[index],
+		}
+	})
+	s.AddSnapshot(peers[0], snapshot)
+
+	_, _, err := s.Sync(context.Background(), snapshot)
+	require.ErrorIs(t, err, errVerifyFailed)
+}
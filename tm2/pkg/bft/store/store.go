@@ -209,6 +209,14 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	bs.db.SetSync(nil, nil)
 }
 
+// SaveSeenCommit persists the seenCommit of the block at height, without the
+// block. State sync uses it to bootstrap a node at height, whose blocks it
+// does not have, so that consensus can start at the next height.
+func (bs *BlockStore) SaveSeenCommit(height int64, seenCommit *types.Commit) {
+	seenCommitBytes := amino.MustMarshal(seenCommit)
+	bs.db.SetSync(calcSeenCommitKey(height), seenCommitBytes)
+}
+
 func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part) {
 	// Allow the genesis block at any height when the store is empty (InitialHeight > 1).
 	if bs.Height() != 0 && height != bs.Height()+1 {
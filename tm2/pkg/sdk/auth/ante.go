@@ -34,6 +34,15 @@ type AnteOptions struct {
 	// This is useful for development, and maybe production chains.
 	// Always check your settings and inspect genesis transactions.
 	VerifyGenesisSignatures bool
+
+	// If MaxReplacedSequences is not zero, a new single-signer tx whose
+	// signature is invalid at the account sequence is verified on CheckTx at
+	// the sequences of the pending txs of the account in the mempool (see
+	// sdk.Context.PendingTxs) among the MaxReplacedSequences previous ones,
+	// without incrementing the account sequence. This lets a tx replace a
+	// pending tx of the account in the prioritized mempool, by paying a higher
+	// gas price.
+	MaxReplacedSequences uint64
 }
 
 // NewAnteHandler returns an AnteHandler that checks and increments sequence
@@ -116,6 +125,15 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 		stdSigs := tx.GetSignatures()
 		isGenesis := ctx.BlockHeight() == 0
 		sessionAccounts := map[crypto.Address]std.DelegatedAccount{}
+		canReplace := ctx.IsCheckTx() && !ctx.IsReCheckTx() && !simulate &&
+			!isGenesis && len(stdSigs) == 1 && opts.MaxReplacedSequences > 0 &&
+			ctx.PendingTxs() != nil
+
+		// Mempool ordering, from the sequence of the first signer.
+		var (
+			sender   string
+			sequence uint64
+		)
 
 		// ——— Phase 1: Resolve all signers ———
 
@@ -278,15 +296,28 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 				return newCtx, res, true
 			}
 
+			replaced := false
 			if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
-				return newCtx, abciResult(std.ErrUnauthorized("signature verification failed; verify correct account, sequence, and chain-id")), true
+				if canReplace {
+					pending := ctx.PendingTxs().PendingSequences(sigAcc.GetAddress())
+					accSeq, replaced = replacedSequence(newCtx.ChainID(), tx, pubKey, sig.Signature,
+						pending, accNum, accSeq, opts.MaxReplacedSequences)
+				}
+				if !replaced {
+					return newCtx, abciResult(std.ErrUnauthorized("signature verification failed; verify correct account, sequence, and chain-id")), true
+				}
+			}
+			if i == 0 {
+				sender, sequence = sigAcc.GetAddress().String(), accSeq
 			}
 
-			if isSession {
+			// A replacing tx reuses the sequence of the replaced tx.
+			if !replaced {
 				sigAcc.SetSequence(sigAcc.GetSequence() + 1)
+			}
+			if isSession {
 				ak.SetSessionAccount(newCtx, signerAddrs[i], sigAcc)
 			} else {
-				sigAcc.SetSequence(sigAcc.GetSequence() + 1)
 				ak.SetAccount(newCtx, signerAccs[i])
 			}
 		}
@@ -297,8 +328,50 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			newCtx = newCtx.WithValue(std.SessionAccountsContextKey{}, sessionAccounts)
 		}
 
-		return newCtx, sdk.Result{GasWanted: tx.Fee.GasWanted}, false
+		return newCtx, sdk.Result{
+			GasWanted: tx.Fee.GasWanted,
+			Priority:  std.GasPrice{Gas: tx.Fee.GasWanted, Price: tx.Fee.GasFee}.Priority(priorityDenom(ctx)),
+			Sender:    sender,
+			Sequence:  sequence,
+		}, false
+	}
+}
+
+// replacedSequence returns the sequence, among the pending sequences of the
+// signer within the maxReplaced sequences before accSeq, at which sig signs
+// tx, if any. Committed sequences are never pending, so a tx signed at one of
+// them is rejected.
+func replacedSequence(
+	chainID string, tx std.Tx, pubKey crypto.PubKey, sig []byte,
+	pending []uint64, accNum, accSeq, maxReplaced uint64,
+) (uint64, bool) {
+	for _, seq := range pending {
+		if seq >= accSeq || accSeq-seq > maxReplaced {
+			continue
+		}
+		signBytes, err := tx.GetSignBytes(chainID, accNum, seq)
+		if err != nil {
+			return 0, false
+		}
+		if pubKey.VerifyBytes(signBytes, sig) {
+			return seq, true
+		}
+	}
+	return 0, false
+}
+
+// priorityDenom returns the denomination of the gas prices ranked by the
+// prioritized mempool: that of the block gas price or, if there is none, of
+// the first minimum gas price of the node. Fees in other denominations have a
+// priority of 0.
+func priorityDenom(ctx sdk.Context) string {
+	if gp, ok := ctx.Value(GasPriceContextKey{}).(std.GasPrice); ok && gp.Price.Denom != "" {
+		return gp.Price.Denom
+	}
+	if minGasPrices := ctx.MinGasPrices(); len(minGasPrices) > 0 {
+		return minGasPrices[0].Price.Denom
 	}
+	return ""
 }
 
 // GetSignerAcc returns an account for a given address that is expected to sign
@@ -307,6 +307,87 @@ func TestAnteHandlerSequences(t *testing.T) {
 	checkValidTx(t, anteHandler, ctx, tx, false)
 }
 
+// pendingTxs are the sequences of the pending txs of each account.
+type pendingTxs map[crypto.Address][]uint64
+
+func (p pendingTxs) PendingSequences(sender crypto.Address) []uint64 { return p[sender] }
+
+// Test the replacement of pending txs on CheckTx.
+func TestAnteHandlerReplacedSequences(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	opts := defaultAnteOptions()
+	opts.MaxReplacedSequences = 2
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, opts)
+	pending := pendingTxs{}
+	ctx := env.ctx.WithMode(sdk.RunTxModeCheck).
+		WithValue(GasPriceContextKey{}, std.GasPrice{Gas: 1, Price: std.NewCoin("atom", 0)}).
+		WithPendingTxs(pending)
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+	priv2, _, addr2 := tu.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	require.NoError(t, acc1.SetAccountNumber(0))
+	env.acck.SetAccount(ctx, acc1)
+	acc2 := env.acck.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(tu.NewTestCoins())
+	require.NoError(t, acc2.SetAccountNumber(1))
+	env.acck.SetAccount(ctx, acc2)
+
+	msgs := []std.Msg{tu.NewTestMsg(addr1)}
+	privs, accnums := []crypto.PrivKey{priv1}, []uint64{0}
+	newTx := func(seq uint64, fee std.Fee) std.Tx {
+		return tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{seq}, fee)
+	}
+
+	// txs at sequences 0, 1 and 2, of which 1 and 2 are pending and 0 is
+	// committed
+	for seq := range uint64(3) {
+		_, res, abort := anteHandler(ctx, newTx(seq, tu.NewTestFee()), false)
+		require.False(t, abort)
+		assert.Equal(t, addr1.String(), res.Sender)
+		assert.Equal(t, seq, res.Sequence)
+	}
+	pending[addr1] = []uint64{1, 2}
+
+	// a tx at a pending sequence replaces the pending tx, paying more
+	fee := std.NewFee(50000, std.NewCoin("atom", 300))
+	_, res, abort := anteHandler(ctx, newTx(1, fee), false)
+	require.False(t, abort)
+	assert.Equal(t, uint64(1), res.Sequence)
+	assert.Equal(t, std.GasPrice{Gas: fee.GasWanted, Price: fee.GasFee}.Priority("atom"), res.Priority)
+	assert.Positive(t, res.Priority)
+	assert.Equal(t, uint64(3), env.acck.GetAccount(ctx, addr1).GetSequence())
+
+	// a committed sequence is not replaced, even within MaxReplacedSequences
+	pending[addr1] = []uint64{2}
+	checkInvalidTx(t, anteHandler, ctx, newTx(1, fee), false, std.UnauthorizedError{})
+
+	// nor are pending sequences before the last MaxReplacedSequences ones
+	pending[addr1] = []uint64{0, 1, 2}
+	checkInvalidTx(t, anteHandler, ctx, newTx(0, fee), false, std.UnauthorizedError{})
+
+	// nor any sequence without a mempool reporting the pending txs
+	checkInvalidTx(t, anteHandler, ctx.WithPendingTxs(nil), newTx(2, fee), false, std.UnauthorizedError{})
+
+	// nor the pending txs on recheck
+	checkInvalidTx(t, anteHandler, ctx.WithIsReCheckTx(true), newTx(2, fee), false, std.UnauthorizedError{})
+
+	// nor those of several signers
+	msgs = []std.Msg{tu.NewTestMsg(addr1, addr2)}
+	privs, accnums = []crypto.PrivKey{priv1, priv2}, []uint64{0, 1}
+	tx := tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{3, 0}, fee)
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	tx = tu.NewTestTx(t, ctx.ChainID(), msgs, privs, accnums, []uint64{2, 1}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, std.UnauthorizedError{})
+}
+
 // Test logic around fee deduction.
 func TestAnteHandlerFees(t *testing.T) {
 	t.Parallel()
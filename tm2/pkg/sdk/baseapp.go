@@ -1,6 +1,7 @@
 package sdk
 
 import (
+	goerrors "errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -16,6 +17,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 	"github.com/gnolang/gno/tm2/pkg/store/trace"
 )
 
@@ -45,6 +47,12 @@ type BaseApp struct {
 
 	beginTxHook BeginTxHook // BaseApp-specific hook run before running transaction messages.
 	endTxHook   EndTxHook   // BaseApp-specific hook run after running transaction messages.
+	restorer    Restorer    // reload logic to run after a state sync restore
+
+	// state sync snapshots of the multistore, taken every snapshotInterval
+	// blocks if set.
+	snapshotManager  *snapshots.Manager
+	snapshotInterval uint64
 
 	// --------------------
 	// Volatile state
@@ -74,6 +82,9 @@ type BaseApp struct {
 	// block height at which to halt the chain and gracefully shutdown
 	haltHeight uint64
 
+	// txs pending in the mempool, which a tx may replace on CheckTx
+	pendingTxs PendingTxs
+
 	// application's version string
 	appVersion string
 }
@@ -630,11 +641,16 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 //
 // NOTE:CheckTx does not run the actual Msg handler function(s).
 func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
-	ctx := app.getContextForTx(RunTxModeCheck, req.Tx)
+	ctx := app.getContextForTx(RunTxModeCheck, req.Tx).
+		WithIsReCheckTx(req.Type == abci.CheckTxTypeRecheck).
+		WithPendingTxs(app.pendingTxs)
 	result := app.runTx(ctx, req.Tx)
 	res.ResponseBase = result.ResponseBase
 	res.GasWanted = result.GasWanted
 	res.GasUsed = result.GasUsed
+	res.Priority = result.Priority
+	res.Sender = result.Sender
+	res.Sequence = result.Sequence
 	return
 }
 
@@ -1028,22 +1044,122 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	// empty/reset the deliver state
 	app.deliverState = nil
 
+	// Snapshot the committed state, in the background.
+	if app.snapshotManager != nil && app.snapshotInterval > 0 && uint64(commitID.Version)%app.snapshotInterval == 0 {
+		if err := app.snapshotManager.Create(commitID.Version); err != nil {
+			app.logger.Error("Unable to start snapshot", "height", commitID.Version, "err", err)
+		}
+	}
+
 	// return.
 	res.Data = commitID.Hash
 
 	return
 }
 
+// ListSnapshots implements the ABCI interface.
+func (app *BaseApp) ListSnapshots(req abci.RequestListSnapshots) (res abci.ResponseListSnapshots) {
+	if app.snapshotManager == nil {
+		return
+	}
+
+	snapshots, err := app.snapshotManager.List()
+	if err != nil {
+		app.logger.Error("Unable to list snapshots", "err", err)
+		res.Error = ABCIError(err)
+		return
+	}
+	res.Snapshots = snapshots
+	return
+}
+
+// OfferSnapshot implements the ABCI interface. It starts restoring the
+// offered snapshot, which must restore the trusted app hash.
+func (app *BaseApp) OfferSnapshot(req abci.RequestOfferSnapshot) (res abci.ResponseOfferSnapshot) {
+	switch {
+	case app.snapshotManager == nil:
+		res.Error = ABCIError(errors.New("state sync snapshots are not enabled"))
+	case req.Snapshot == nil:
+		res.Error = ABCIError(errors.New("no snapshot offered"))
+	case app.checkState != nil:
+		res.Error = ABCIError(errors.New("cannot restore a snapshot into an initialized app"))
+	default:
+		if err := app.snapshotManager.Restore(*req.Snapshot, req.AppHash); err != nil {
+			app.logger.Info("Rejected snapshot", "height", req.Snapshot.Height, "err", err)
+			res.Error = ABCIError(err)
+		}
+	}
+	return
+}
+
+// LoadSnapshotChunk implements the ABCI interface.
+func (app *BaseApp) LoadSnapshotChunk(req abci.RequestLoadSnapshotChunk) (res abci.ResponseLoadSnapshotChunk) {
+	if app.snapshotManager == nil {
+		return
+	}
+
+	chunk, err := app.snapshotManager.LoadChunk(req.Height, req.Format, req.Chunk)
+	if err != nil {
+		res.Error = ABCIError(err)
+		return
+	}
+	res.Chunk = chunk
+	return
+}
+
+// ApplySnapshotChunk implements the ABCI interface. Once the last chunk is
+// applied, the app is reloaded from the restored multistore.
+func (app *BaseApp) ApplySnapshotChunk(req abci.RequestApplySnapshotChunk) (res abci.ResponseApplySnapshotChunk) {
+	if app.snapshotManager == nil {
+		res.Error = ABCIError(errors.New("state sync snapshots are not enabled"))
+		return
+	}
+
+	done, err := app.snapshotManager.RestoreChunk(req.Index, req.Chunk)
+	switch {
+	case goerrors.Is(err, snapshots.ErrChunkHashMismatch):
+		// The chunk is invalid, not the snapshot: fetch it from another peer.
+		res.Error = ABCIError(err)
+		res.RefetchChunks = []uint32{req.Index}
+		res.RejectSenders = []string{req.Sender}
+	case err != nil:
+		app.logger.Error("Unable to restore snapshot", "err", err)
+		res.Error = ABCIError(err)
+	case done:
+		if err := app.initFromMainStore(); err != nil {
+			res.Error = ABCIError(err)
+			return
+		}
+		if app.restorer != nil {
+			app.restorer(app.cms.MultiCacheWrap())
+		}
+		app.logger.Info("Restored snapshot", "height", app.LastBlockHeight(),
+			"hash", fmt.Sprintf("%X", app.cms.LastCommitID().Hash))
+	}
+	return
+}
+
 // SetHaltHeight sets the block height at which the node will halt after committing.
 func (app *BaseApp) SetHaltHeight(height uint64) {
 	app.haltHeight = height
 }
 
+// SetPendingTxs sets the txs pending in the mempool of the node, which a tx
+// may replace on CheckTx. It must be called before the node starts.
+func (app *BaseApp) SetPendingTxs(pendingTxs PendingTxs) {
+	app.pendingTxs = pendingTxs
+}
+
 func (app *BaseApp) Close() error {
 	if app.db == nil {
 		return nil
 	}
 
+	// Wait for the snapshot being taken, if any, as it reads the DB.
+	if app.snapshotManager != nil {
+		app.snapshotManager.Close()
+	}
+
 	// Release any open query snapshot before closing the DB.
 	// The multiStore holds a snapshot with refs=1 that is normally released by
 	// the next Commit(). On shutdown that swap never happens, so we must drain
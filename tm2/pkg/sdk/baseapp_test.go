@@ -10,6 +10,7 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -286,6 +287,73 @@ func TestInfo(t *testing.T) {
 	// TODO
 }
 
+func TestSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	// Take a snapshot every 2 blocks.
+	app := setupBaseApp(t, SetSnapshot(t.TempDir(), 2, 0))
+	defer app.Close()
+
+	for height := int64(1); height <= 2; height++ {
+		header := &bft.Header{ChainID: "test-chain", Height: height}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.deliverState.ctx.Store(mainKey).Set(nil, []byte(fmt.Sprintf("key%d", height)), []byte("value"))
+		app.Commit()
+	}
+	appHash := app.LastCommitID().Hash
+
+	var snapshots []abci.Snapshot
+	require.Eventually(t, func() bool {
+		snapshots = app.ListSnapshots(abci.RequestListSnapshots{}).Snapshots
+		return len(snapshots) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	snapshot := snapshots[0]
+	require.Equal(t, int64(2), snapshot.Height)
+
+	// Restore the snapshot into a fresh app.
+	restored := false
+	fresh := newBaseApp(t.Name(), memdb.NewMemDB(), SetSnapshot(t.TempDir(), 0, 0))
+	fresh.SetRestorer(func(store.MultiStore) { restored = true })
+	require.NoError(t, fresh.LoadLatestVersion())
+	defer fresh.Close()
+
+	// The snapshot must restore the trusted app hash.
+	res := fresh.OfferSnapshot(abci.RequestOfferSnapshot{Snapshot: &snapshot, AppHash: appHash})
+	require.Nil(t, res.Error)
+
+	for index := range snapshot.Chunks {
+		chunk := app.LoadSnapshotChunk(abci.RequestLoadSnapshotChunk{
+			Height: snapshot.Height,
+			Format: snapshot.Format,
+			Chunk:  index,
+		})
+		require.Nil(t, chunk.Error)
+
+		// A corrupted chunk is fetched again.
+		corrupted := append([]byte{}, chunk.Chunk...)
+		corrupted[0] ^= 0xff
+		bad := fresh.ApplySnapshotChunk(abci.RequestApplySnapshotChunk{Index: index, Chunk: corrupted, Sender: "bad"})
+		require.NotNil(t, bad.Error)
+		assert.Equal(t, []uint32{index}, bad.RefetchChunks)
+		assert.Equal(t, []string{"bad"}, bad.RejectSenders)
+
+		applied := fresh.ApplySnapshotChunk(abci.RequestApplySnapshotChunk{Index: index, Chunk: chunk.Chunk, Sender: "good"})
+		require.Nil(t, applied.Error)
+	}
+
+	assert.True(t, restored)
+	assert.Equal(t, int64(2), fresh.LastBlockHeight())
+	assert.Equal(t, appHash, fresh.LastCommitID().Hash)
+
+	info := fresh.Info(abci.RequestInfo{})
+	assert.Equal(t, int64(2), info.LastBlockHeight)
+	assert.Equal(t, appHash, info.LastBlockAppHash)
+
+	// An initialized app can't restore a snapshot.
+	res = fresh.OfferSnapshot(abci.RequestOfferSnapshot{Snapshot: &snapshot, AppHash: appHash})
+	require.NotNil(t, res.Error)
+}
+
 func TestBaseAppOptionSeal(t *testing.T) {
 	t.Parallel()
 
@@ -23,13 +23,21 @@ type AppConfig struct {
 
 	// The enforced state pruning stategy for the app
 	PruneStrategy types.PruneStrategy `json:"prune_strategy" toml:"prune_strategy" comment:"State pruning strategy [everything, nothing, syncable]"`
+
+	// The interval, in blocks, of the state sync snapshots of the app state
+	SnapshotInterval uint64 `json:"snapshot_interval" toml:"snapshot_interval" comment:"Interval, in blocks, of the state sync snapshots of the app state (0 to disable)"`
+
+	// The number of recent state sync snapshots to keep
+	SnapshotKeepRecent uint32 `json:"snapshot_keep_recent" toml:"snapshot_keep_recent" comment:"Number of recent state sync snapshots to keep (0 to keep all)"`
 }
 
 // DefaultAppConfig returns a default configuration for the application
 func DefaultAppConfig() *AppConfig {
 	return &AppConfig{
-		MinGasPrices:  "",
-		PruneStrategy: types.PruneSyncableStrategy,
+		MinGasPrices:       "",
+		PruneStrategy:      types.PruneSyncableStrategy,
+		SnapshotInterval:   0,
+		SnapshotKeepRecent: 2,
 	}
 }
 
@@ -21,6 +21,8 @@ here would be better just to add to the Context struct
 type Context struct {
 	ctx           context.Context
 	mode          RunTxMode
+	recheckTx     bool       // if true, the tx is rechecked after a commit
+	pendingTxs    PendingTxs // txs pending in the mempool, on CheckTx
 	ms            store.MultiStore
 	header        abci.Header
 	chainID       string
@@ -51,6 +53,8 @@ func (c Context) VoteInfos() []abci.VoteInfo    { return c.voteInfo }
 func (c Context) GasMeter() store.GasMeter      { return c.gasMeter }
 func (c Context) BlockGasMeter() store.GasMeter { return c.blockGasMeter }
 func (c Context) IsCheckTx() bool               { return c.mode == RunTxModeCheck }
+func (c Context) IsReCheckTx() bool             { return c.recheckTx }
+func (c Context) PendingTxs() PendingTxs        { return c.pendingTxs }
 func (c Context) MinGasPrices() []GasPrice      { return c.minGasPrices }
 func (c Context) EventLogger() *EventLogger     { return c.eventLogger }
 
@@ -92,6 +96,20 @@ func (c Context) WithMode(mode RunTxMode) Context {
 	return c
 }
 
+// WithIsReCheckTx sets whether the checked tx is a recheck of a tx already in
+// the mempool.
+func (c Context) WithIsReCheckTx(recheckTx bool) Context {
+	c.recheckTx = recheckTx
+	return c
+}
+
+// WithPendingTxs sets the txs pending in the mempool, which a checked tx may
+// replace.
+func (c Context) WithPendingTxs(pendingTxs PendingTxs) Context {
+	c.pendingTxs = pendingTxs
+	return c
+}
+
 func (c Context) WithMultiStore(ms store.MultiStore) Context {
 	c.ms = ms
 	return c
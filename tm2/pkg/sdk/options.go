@@ -5,6 +5,7 @@ import (
 
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 // File for storing in-package BaseApp optional functions,
@@ -29,6 +30,25 @@ func SetMinGasPrices(gasPricesStr string) func(*BaseApp) {
 	return func(bap *BaseApp) { bap.setMinGasPrices(gasPrices) }
 }
 
+// SetSnapshot returns an option that makes the app take a state sync snapshot
+// of its multistore every interval blocks, keeping the keepRecent most recent
+// ones in dir, and restore snapshots offered by state sync. Snapshots are
+// not taken if interval is 0, but can still be restored.
+func SetSnapshot(dir string, interval uint64, keepRecent uint32) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		ms, ok := bap.cms.(store.MultiStoreSnapshotter)
+		if !ok {
+			panic("state sync snapshots are not supported by the multistore")
+		}
+		manager, err := snapshots.NewManager(dir, ms, keepRecent, bap.logger.With("module", "snapshots"))
+		if err != nil {
+			panic(fmt.Sprintf("unable to create the snapshot manager: %v", err))
+		}
+		bap.snapshotManager = manager
+		bap.snapshotInterval = interval
+	}
+}
+
 func (app *BaseApp) SetName(name string) {
 	if app.sealed {
 		panic("SetName() on sealed BaseApp")
@@ -99,3 +119,10 @@ func (app *BaseApp) SetEndTxHook(endTx EndTxHook) {
 	}
 	app.endTxHook = endTx
 }
+
+func (app *BaseApp) SetRestorer(restorer Restorer) {
+	if app.sealed {
+		panic("SetRestorer() on sealed BaseApp")
+	}
+	app.restorer = restorer
+}
@@ -31,3 +31,13 @@ func MustParamStrings(key string, value any) []string {
 	}
 	return s
 }
+
+// MustParamBool asserts value is a bool and returns it.
+// Panics with a descriptive message if the type assertion fails.
+func MustParamBool(key string, value any) bool {
+	b, ok := value.(bool)
+	if !ok {
+		panic(fmt.Sprintf("invalid type for %s param: expected bool, got %T", key, value))
+	}
+	return b
+}
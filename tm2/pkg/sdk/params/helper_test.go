@@ -48,3 +48,17 @@ func TestMustParamStrings(t *testing.T) {
 		)
 	})
 }
+
+func TestMustParamBool(t *testing.T) {
+	t.Run("valid bool", func(t *testing.T) {
+		got := MustParamBool("flag", true)
+		require.True(t, got)
+	})
+
+	t.Run("wrong type panics", func(t *testing.T) {
+		assert.PanicsWithValue(t,
+			"invalid type for flag param: expected bool, got string",
+			func() { MustParamBool("flag", "true") },
+		)
+	})
+}
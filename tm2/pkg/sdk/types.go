@@ -2,6 +2,7 @@ package sdk
 
 import (
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
@@ -25,6 +26,19 @@ type Result struct {
 	abci.ResponseBase
 	GasWanted int64
 	GasUsed   int64
+
+	// Ordering of the tx in the mempool, set by the AnteHandler on CheckTx.
+	// See abci.ResponseCheckTx.
+	Priority int64
+	Sender   string
+	Sequence uint64
+}
+
+// PendingTxs reports the txs pending in the mempool, so that the AnteHandler
+// can let a tx replace one of them on CheckTx.
+type PendingTxs interface {
+	// PendingSequences returns the sequences of the pending txs of sender.
+	PendingSequences(sender crypto.Address) []uint64
 }
 
 // AnteHandler authenticates transactions, before their internal messages are handled.
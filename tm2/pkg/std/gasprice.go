@@ -2,6 +2,7 @@ package std
 
 import (
 	"fmt"
+	"math"
 	"math/big"
 	"strings"
 
@@ -81,6 +82,28 @@ func (gp GasPrice) IsGTE(gpB GasPrice) (bool, error) {
 	return prod1.Cmp(prod2) >= 0, nil
 }
 
+// PriorityScale is the number of price units per gas of a Priority of 1. The
+// gas price 1ugnot/1000gas has a priority of 1000.
+const PriorityScale = 1_000_000
+
+// Priority returns the price per gas of gp, in units of 1/PriorityScale,
+// capped to math.MaxInt64. It orders the txs of the prioritized mempool by
+// their effective gas price. Prices in another denomination than denom have
+// no value to compare, and a priority of 0.
+func (gp GasPrice) Priority(denom string) int64 {
+	if gp.Gas <= 0 || gp.Price.Amount <= 0 || denom == "" || gp.Price.Denom != denom {
+		return 0
+	}
+
+	priority := big.NewInt(gp.Price.Amount)
+	priority.Mul(priority, big.NewInt(PriorityScale))
+	priority.Quo(priority, big.NewInt(gp.Gas))
+	if !priority.IsInt64() {
+		return math.MaxInt64
+	}
+	return priority.Int64()
+}
+
 func (gp GasPrice) String() string {
 	return fmt.Sprintf("%s/%dgas", gp.Price.String(), gp.Gas)
 }
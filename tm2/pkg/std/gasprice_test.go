@@ -1,6 +1,7 @@
 package std
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -154,3 +155,29 @@ func TestGasPriceGTE(t *testing.T) {
 		})
 	}
 }
+
+func TestGasPricePriority(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		gp       GasPrice
+		expected int64
+	}{
+		{"fraction of a unit per gas", GasPrice{Gas: 1000, Price: Coin{Denom: "ugnot", Amount: 1}}, 1000},
+		{"units per gas", GasPrice{Gas: 10, Price: Coin{Denom: "ugnot", Amount: 25}}, 2_500_000},
+		{"zero price", GasPrice{Gas: 1000, Price: Coin{Denom: "ugnot"}}, 0},
+		{"zero gas", GasPrice{Price: Coin{Denom: "ugnot", Amount: 1}}, 0},
+		{"other denom", GasPrice{Gas: 1, Price: Coin{Denom: "junk", Amount: 1_000_000}}, 0},
+		{"empty denom", GasPrice{Gas: 1, Price: Coin{Amount: 1_000_000}}, 0},
+		{"capped", GasPrice{Gas: 1, Price: Coin{Denom: "ugnot", Amount: math.MaxInt64}}, math.MaxInt64},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.expected, tt.gp.Priority("ugnot"))
+		})
+	}
+}
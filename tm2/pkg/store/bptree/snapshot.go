@@ -0,0 +1,83 @@
+package bptree
+
+import (
+	goerrors "errors"
+
+	bp "github.com/gnolang/gno/tm2/pkg/bptree"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+var _ types.Snapshotter = (*Store)(nil)
+
+// Export implements types.Snapshotter. An empty tree exports no node.
+func (st *Store) Export(version int64, fn func(*types.SnapshotNode) error) error {
+	iTree, err := st.tree.GetImmutableTree(version)
+	if err != nil {
+		return err
+	}
+	defer iTree.Close()
+
+	exporter, err := iTree.Export(nil)
+	if goerrors.Is(err, bp.ErrNotInitializedTree) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer exporter.Close()
+
+	for {
+		node, err := exporter.Next()
+		if goerrors.Is(err, bp.ErrExportDone) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = fn(&types.SnapshotNode{
+			Key:           node.Key,
+			Value:         node.Value,
+			Height:        node.Height,
+			NumKeys:       node.NumKeys,
+			SeparatorKeys: node.SeparatorKeys,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Import implements types.Snapshotter.
+func (st *Store) Import(version int64) (types.SnapshotImporter, error) {
+	if st.opts.Immutable {
+		panic("Import on immutable bptree store")
+	}
+	importer, err := st.mtree.Import(version)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotImporter{importer}, nil
+}
+
+// snapshotImporter adapts a bp.Importer to types.SnapshotImporter.
+type snapshotImporter struct {
+	importer *bp.Importer
+}
+
+func (si *snapshotImporter) Add(node *types.SnapshotNode) error {
+	return si.importer.Add(&bp.ExportNode{
+		Key:           node.Key,
+		Value:         node.Value,
+		Height:        node.Height,
+		NumKeys:       node.NumKeys,
+		SeparatorKeys: node.SeparatorKeys,
+	})
+}
+
+func (si *snapshotImporter) Commit() error {
+	return si.importer.Commit()
+}
+
+func (si *snapshotImporter) Close() error {
+	return si.importer.Close()
+}
@@ -131,3 +131,42 @@ func (dsa Store) LoadVersion(ver int64) error {
 
 // dbm.DB implements Store.
 var _ types.Store = Store{}
+
+// Implements types.Snapshotter. The version is ignored: the store has no
+// history, so its DB must be a snapshot of the version (see
+// rootmulti.Snapshot). As the store is not merkleized, its content is not
+// covered by the app hash a restore is verified against.
+func (dsa Store) Export(_ int64, fn func(*types.SnapshotNode) error) error {
+	it, err := dsa.DB.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+
+	for ; it.Valid(); it.Next() {
+		if err := fn(&types.SnapshotNode{Key: it.Key(), Value: it.Value()}); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// Implements types.Snapshotter.
+func (dsa Store) Import(_ int64) (types.SnapshotImporter, error) {
+	return snapshotImporter{dsa.DB}, nil
+}
+
+// snapshotImporter sets the exported KVs into the DB; the multistore
+// restoring them writes the DB atomically on commit.
+type snapshotImporter struct {
+	db dbm.DB
+}
+
+func (si snapshotImporter) Add(node *types.SnapshotNode) error {
+	return si.db.Set(node.Key, node.Value)
+}
+
+func (si snapshotImporter) Commit() error { return nil }
+func (si snapshotImporter) Close() error  { return nil }
+
+var _ types.Snapshotter = Store{}
@@ -29,6 +29,11 @@ type (
 	DepthEstimator         = types.DepthEstimator
 	Checkpointable         = types.Checkpointable
 	InitialVersionSetter   = types.InitialVersionSetter
+	SnapshotNode           = types.SnapshotNode
+	Snapshotter            = types.Snapshotter
+	SnapshotImporter       = types.SnapshotImporter
+	MultiStoreSnapshotter  = types.MultiStoreSnapshotter
+	MultiStoreExporter     = types.MultiStoreExporter
 )
 
 var (
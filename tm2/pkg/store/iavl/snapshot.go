@@ -0,0 +1,79 @@
+package iavl
+
+import (
+	goerrors "errors"
+
+	"github.com/gnolang/gno/tm2/pkg/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+var _ types.Snapshotter = (*Store)(nil)
+
+// Export implements types.Snapshotter.
+func (st *Store) Export(version int64, fn func(*types.SnapshotNode) error) error {
+	iTree, err := st.tree.GetImmutable(version)
+	if err != nil {
+		return err
+	}
+
+	exporter, err := iTree.Export()
+	if err != nil {
+		return err
+	}
+	defer exporter.Close()
+
+	for {
+		node, err := exporter.Next()
+		if goerrors.Is(err, iavl.ErrExportDone) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		err = fn(&types.SnapshotNode{
+			Key:     node.Key,
+			Value:   node.Value,
+			Version: node.Version,
+			Height:  node.Height,
+		})
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Import implements types.Snapshotter.
+func (st *Store) Import(version int64) (types.SnapshotImporter, error) {
+	mt, ok := st.tree.(*iavl.MutableTree)
+	if !ok {
+		panic("Import on immutable iavl store")
+	}
+	importer, err := mt.Import(version)
+	if err != nil {
+		return nil, err
+	}
+	return &snapshotImporter{importer}, nil
+}
+
+// snapshotImporter adapts an iavl.Importer to types.SnapshotImporter.
+type snapshotImporter struct {
+	importer *iavl.Importer
+}
+
+func (si *snapshotImporter) Add(node *types.SnapshotNode) error {
+	return si.importer.Add(&iavl.ExportNode{
+		Key:     node.Key,
+		Value:   node.Value,
+		Version: node.Version,
+		Height:  node.Height,
+	})
+}
+
+func (si *snapshotImporter) Commit() error {
+	return si.importer.Commit()
+}
+
+func (si *snapshotImporter) Close() error {
+	si.importer.Close()
+	return nil
+}
@@ -0,0 +1,195 @@
+package rootmulti
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// maxSnapshotItemSize bounds the size of an item of a snapshot stream, so a
+// forged length prefix cannot make Restore allocate without limit.
+const maxSnapshotItemSize = 64 << 20
+
+var _ types.MultiStoreSnapshotter = (*multiStore)(nil)
+
+// snapshotItem is an item of a snapshot stream, which is the sequence of the
+// length-prefixed nodes of each store, with the stores sorted by name.
+type snapshotItem struct {
+	Store string
+	Node  *types.SnapshotNode
+}
+
+// Snapshot implements types.MultiStoreSnapshotter. Only the latest version
+// can be snapshotted, from the frozen query snapshot of the DB taken at its
+// commit, so that the stores without history (dbadapter) are exported as of
+// that version too.
+func (ms *multiStore) Snapshot(version int64) (types.MultiStoreExporter, error) {
+	if latest := ms.LastCommitID().Version; version != latest {
+		return nil, fmt.Errorf("cannot snapshot version %d, the latest version is %d", version, latest)
+	}
+	if ms.QuerySnapshot() == nil {
+		return nil, errors.New("cannot snapshot without a DB supporting snapshots")
+	}
+
+	ims, release, err := ms.immutableAtVersion(version)
+	if err != nil {
+		return nil, err
+	}
+	return &multiStoreExporter{ms: ims, version: version, release: release}, nil
+}
+
+type multiStoreExporter struct {
+	ms      *multiStore
+	version int64
+	release func()
+}
+
+// Export implements types.MultiStoreExporter.
+func (e *multiStoreExporter) Export(w io.Writer) error {
+	for _, name := range e.ms.storeNames() {
+		snapshotter, err := e.ms.snapshotter(name)
+		if err != nil {
+			return err
+		}
+		err = snapshotter.Export(e.version, func(node *types.SnapshotNode) error {
+			_, err := amino.MarshalSizedWriter(w, snapshotItem{Store: name, Node: node})
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("unable to export store %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Close implements types.MultiStoreExporter.
+func (e *multiStoreExporter) Close() {
+	e.release()
+}
+
+// Restore implements types.MultiStoreSnapshotter. On error, the restored
+// writes are discarded and the multistore is left empty.
+func (ms *multiStore) Restore(version int64, hash []byte, r io.Reader) (types.CommitID, error) {
+	if version <= 0 {
+		return types.CommitID{}, fmt.Errorf("cannot restore version %d", version)
+	}
+	if last := ms.LastCommitID(); !last.IsZero() {
+		return types.CommitID{}, fmt.Errorf("cannot restore into a multistore at version %d", last.Version)
+	}
+
+	commitInfo, err := ms.restore(version, r)
+	if err == nil && !bytes.Equal(commitInfo.Hash(), hash) {
+		err = fmt.Errorf("restored app hash %X does not match the trusted app hash %X", commitInfo.Hash(), hash)
+	}
+	if err != nil {
+		ms.collector.Reset()
+		if lerr := ms.LoadVersion(0); lerr != nil {
+			panic(fmt.Sprintf("rootmulti: unable to reset the stores after a failed restore: %v", lerr))
+		}
+		return types.CommitID{}, err
+	}
+
+	if err := ms.writeCommit(version, commitInfo); err != nil {
+		return types.CommitID{}, err
+	}
+	commitID := commitInfo.CommitID()
+	ms.setLastCommitID(commitID)
+	return commitID, nil
+}
+
+// restore imports the stores from the snapshot stream r, and returns the
+// commitInfo of the restored version. The writes are left in the collector.
+func (ms *multiStore) restore(version int64, r io.Reader) (commitInfo, error) {
+	br := bufio.NewReader(r)
+	item, err := readSnapshotItem(br)
+	if err != nil {
+		return commitInfo{}, err
+	}
+
+	storeInfos := make([]storeInfo, 0, len(ms.stores))
+	for _, name := range ms.storeNames() {
+		snapshotter, err := ms.snapshotter(name)
+		if err != nil {
+			return commitInfo{}, err
+		}
+		item, err = restoreStore(snapshotter, version, name, item, br)
+		if err != nil {
+			return commitInfo{}, fmt.Errorf("unable to restore store %q: %w", name, err)
+		}
+
+		si := storeInfo{Name: name}
+		si.Core.CommitID = ms.stores[ms.keysByName[name]].LastCommitID()
+		storeInfos = append(storeInfos, si)
+	}
+	if item != nil {
+		return commitInfo{}, fmt.Errorf("unexpected node of store %q", item.Store)
+	}
+
+	return commitInfo{Version: version, StoreInfos: storeInfos}, nil
+}
+
+// restoreStore imports the nodes of store name, starting with item, and
+// returns the first item of the next store, or nil at the end of the stream.
+func restoreStore(
+	snapshotter types.Snapshotter,
+	version int64,
+	name string,
+	item *snapshotItem,
+	br *bufio.Reader,
+) (*snapshotItem, error) {
+	importer, err := snapshotter.Import(version)
+	if err != nil {
+		return nil, err
+	}
+	defer importer.Close()
+
+	for ; item != nil && item.Store == name; item, err = readSnapshotItem(br) {
+		if item.Node == nil {
+			return nil, errors.New("empty node")
+		}
+		if err := importer.Add(item.Node); err != nil {
+			return nil, err
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item, importer.Commit()
+}
+
+// readSnapshotItem reads the next item of a snapshot stream, or returns nil
+// at its end.
+func readSnapshotItem(br *bufio.Reader) (*snapshotItem, error) {
+	if _, err := br.Peek(1); errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	var item snapshotItem
+	if _, err := amino.UnmarshalSizedReader(br, &item, maxSnapshotItemSize); err != nil {
+		return nil, fmt.Errorf("unable to read snapshot item: %w", err)
+	}
+	return &item, nil
+}
+
+// storeNames returns the names of the mounted stores, sorted.
+func (ms *multiStore) storeNames() []string {
+	names := make([]string, 0, len(ms.keysByName))
+	for name := range ms.keysByName {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (ms *multiStore) snapshotter(name string) (types.Snapshotter, error) {
+	snapshotter, ok := ms.stores[ms.keysByName[name]].(types.Snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("store %q does not support snapshots", name)
+	}
+	return snapshotter, nil
+}
@@ -0,0 +1,128 @@
+package rootmulti
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+
+	"github.com/gnolang/gno/tm2/pkg/store/bptree"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// newSnapshotMultiStore returns a loaded multistore with a store of each
+// kind, and an "empty" bptree store which is never written.
+func newSnapshotMultiStore(t *testing.T, db dbm.DB) *multiStore {
+	t.Helper()
+
+	ms := NewMultiStore(db)
+	ms.SetStoreOptions(types.StoreOptions{PruningOptions: types.PruneNothing})
+	ms.MountStoreWithDB(types.NewStoreKey("main"), bptree.FastStoreConstructor, nil)
+	ms.MountStoreWithDB(types.NewStoreKey("empty"), bptree.StoreConstructor, nil)
+	ms.MountStoreWithDB(types.NewStoreKey("iavl"), iavl.StoreConstructor, nil)
+	ms.MountStoreWithDB(types.NewStoreKey("base"), dbadapter.StoreConstructor, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	return ms
+}
+
+func TestMultiStoreSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	ms := newSnapshotMultiStore(t, memdb.NewMemDB())
+	for height := range 3 {
+		for i := range 100 {
+			key, value := fmt.Appendf(nil, "key%03d", i), fmt.Appendf(nil, "value%d-%d", height, i)
+			ms.getStoreByName("main").Set(nil, key, value)
+			ms.getStoreByName("iavl").Set(nil, key, value)
+			ms.getStoreByName("base").Set(nil, key, value)
+		}
+		ms.getStoreByName("main").Delete(nil, fmt.Appendf(nil, "key%03d", height))
+		ms.Commit()
+	}
+	commitID := ms.LastCommitID()
+
+	exporter, err := ms.Snapshot(commitID.Version)
+	require.NoError(t, err)
+	defer exporter.Close()
+
+	// Later commits are not part of the snapshot.
+	ms.getStoreByName("main").Set(nil, []byte("later"), []byte("value"))
+	ms.getStoreByName("base").Set(nil, []byte("later"), []byte("value"))
+	ms.Commit()
+
+	var buf bytes.Buffer
+	require.NoError(t, exporter.Export(&buf))
+
+	t.Run("restore", func(t *testing.T) {
+		t.Parallel()
+
+		db := memdb.NewMemDB()
+		restored := newSnapshotMultiStore(t, db)
+		cid, err := restored.Restore(commitID.Version, commitID.Hash, bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+		assert.Equal(t, commitID, cid)
+		assert.Equal(t, commitID, restored.LastCommitID())
+
+		for _, name := range []string{"main", "iavl", "base"} {
+			store := restored.getStoreByName(name)
+			assert.Nil(t, store.Get(nil, []byte("later")), name)
+			assert.Equal(t, []byte("value2-99"), store.Get(nil, []byte("key099")), name)
+		}
+		assert.Nil(t, restored.getStoreByName("main").Get(nil, []byte("key002")))
+
+		// The restored version is persisted, and the store keeps committing.
+		reloaded := newSnapshotMultiStore(t, db)
+		assert.Equal(t, commitID, reloaded.LastCommitID())
+		reloaded.getStoreByName("main").Set(nil, []byte("next"), []byte("value"))
+		assert.Equal(t, commitID.Version+1, reloaded.Commit().Version)
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		db := memdb.NewMemDB()
+		restored := newSnapshotMultiStore(t, db)
+		_, err := restored.Restore(commitID.Version, []byte("forged"), bytes.NewReader(buf.Bytes()))
+		require.ErrorContains(t, err, "does not match the trusted app hash")
+
+		// Nothing is written, and the multistore can restore again.
+		assert.Zero(t, restored.LastCommitID())
+		assert.Zero(t, getLatestVersion(db))
+		_, err = restored.Restore(commitID.Version, commitID.Hash, bytes.NewReader(buf.Bytes()))
+		require.NoError(t, err)
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		restored := newSnapshotMultiStore(t, memdb.NewMemDB())
+		_, err := restored.Restore(commitID.Version, commitID.Hash, bytes.NewReader(buf.Bytes()[:buf.Len()/2]))
+		require.Error(t, err)
+		assert.Zero(t, restored.LastCommitID())
+	})
+
+	t.Run("not empty", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := ms.Restore(commitID.Version, commitID.Hash, bytes.NewReader(buf.Bytes()))
+		require.ErrorContains(t, err, "cannot restore")
+	})
+}
+
+func TestMultiStoreSnapshot_NotLatest(t *testing.T) {
+	t.Parallel()
+
+	ms := newSnapshotMultiStore(t, memdb.NewMemDB())
+	ms.Commit()
+	ms.Commit()
+
+	_, err := ms.Snapshot(1)
+	require.ErrorContains(t, err, "cannot snapshot version 1")
+}
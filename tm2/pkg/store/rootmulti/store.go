@@ -284,6 +284,22 @@ func (ms *multiStore) Commit() types.CommitID {
 	}
 	commitInfo := commitStores(version, ms.stores)
 
+	if err := ms.writeCommit(version, commitInfo); err != nil {
+		panic("rootmulti: Commit() failed: " + err.Error())
+	}
+
+	// Prepare for next version.
+	commitID := types.CommitID{
+		Version: version,
+		Hash:    commitInfo.Hash(),
+	}
+	ms.setLastCommitID(commitID)
+	return commitID
+}
+
+// writeCommit persists the collected sub-store writes of version along with
+// its commitInfo in a single WriteSync, then refreshes the query snapshot.
+func (ms *multiStore) writeCommit(version int64, commitInfo commitInfo) error {
 	// Feed metadata into the same collector as IAVL/dbadapter writes so a
 	// single drain covers all four write sites. The Write() must precede
 	// Drain: batch ops enter the collector only on Write, and metadata that
@@ -294,30 +310,23 @@ func (ms *multiStore) Commit() types.CommitID {
 	setCommitInfo(metaBatch, version, commitInfo)
 	setLatestVersion(metaBatch, version)
 	if err := metaBatch.Write(); err != nil {
-		panic("rootmulti: Commit() metadata write failed: " + err.Error())
+		return fmt.Errorf("metadata write failed: %w", err)
 	}
 
 	// Drain the collector into a real batch and flush atomically.
 	realBatch := ms.db.NewBatch()
 	defer realBatch.Close()
 	if err := ms.collector.Drain(realBatch); err != nil {
-		panic("rootmulti: Commit() drain failed: " + err.Error())
+		return fmt.Errorf("drain failed: %w", err)
 	}
 	if err := realBatch.WriteSync(); err != nil {
-		panic("rootmulti: Commit() failed: " + err.Error())
+		return err
 	}
 
 	// Take a fresh snapshot of the now-consistent DB state.
 	// Queries will read from this until the next Commit.
 	ms.refreshQuerySnapshot()
-
-	// Prepare for next version.
-	commitID := types.CommitID{
-		Version: version,
-		Hash:    commitInfo.Hash(),
-	}
-	ms.setLastCommitID(commitID)
-	return commitID
+	return nil
 }
 
 // refreshQuerySnapshot replaces querySnapshot with a fresh snapshot of the
@@ -0,0 +1,408 @@
+// Package snapshots manages the snapshots of a multistore used by state sync:
+// it creates them on disk, serves their chunks to peers, and restores the
+// multistore from the chunks of a snapshot.
+package snapshots
+
+import (
+	"bytes"
+	"cmp"
+	"compress/zlib"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+const (
+	// Format is the format of the snapshots: the snapshot stream of the
+	// multistore (see types.MultiStoreExporter), compressed with zlib and
+	// split in chunks.
+	Format uint32 = 1
+
+	// DefaultChunkSize is the maximum size of a chunk.
+	DefaultChunkSize = 4 << 20
+
+	// snapshotFile is the file of a snapshot directory with its
+	// abci.Snapshot; the other files are its chunks, named by index.
+	snapshotFile = "snapshot"
+)
+
+var (
+	ErrUnknownFormat     = errors.New("unknown snapshot format")
+	ErrInvalidMetadata   = errors.New("invalid snapshot metadata")
+	ErrSnapshotNotFound  = errors.New("snapshot not found")
+	ErrChunkHashMismatch = errors.New("chunk hash mismatch")
+	ErrChunkOutOfOrder   = errors.New("chunk out of order")
+	ErrNoRestore         = errors.New("no snapshot is being restored")
+	ErrInProgress        = errors.New("a snapshot is already being created")
+
+	errRestoreAborted = errors.New("restore aborted")
+)
+
+// Metadata is the metadata of a snapshot, in abci.Snapshot.Metadata. The hash
+// of a snapshot is the SHA-256 hash of its metadata.
+type Metadata struct {
+	ChunkHashes [][]byte
+}
+
+// Manager creates the snapshots of a multistore in a directory, with one
+// subdirectory per height, and restores the multistore from snapshots.
+//
+// Snapshots are created one at a time, in the background; a single snapshot
+// is restored at a time, with its chunks applied in order.
+type Manager struct {
+	dir        string
+	ms         types.MultiStoreSnapshotter
+	keepRecent uint32
+	chunkSize  int
+	logger     *slog.Logger
+
+	mtx         sync.Mutex
+	creating    bool
+	restoration *restoration
+	wg          sync.WaitGroup
+}
+
+// restoration is a snapshot being restored: its chunks are written to the
+// pipe read by the multistore restore running in the background, whose
+// result is sent to done.
+type restoration struct {
+	snapshot abci.Snapshot
+	metadata Metadata
+	next     uint32
+	pw       *io.PipeWriter
+	done     chan error
+}
+
+// NewManager returns a Manager of the snapshots of ms in dir, keeping the
+// keepRecent most recent snapshots, or all of them if 0.
+func NewManager(dir string, ms types.MultiStoreSnapshotter, keepRecent uint32, logger *slog.Logger) (*Manager, error) {
+	if err := osm.EnsureDir(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create the snapshot directory, %w", err)
+	}
+	return &Manager{
+		dir:        dir,
+		ms:         ms,
+		keepRecent: keepRecent,
+		chunkSize:  DefaultChunkSize,
+		logger:     logger,
+	}, nil
+}
+
+// Create creates the snapshot of the multistore at height, which must be its
+// latest version. The multistore view is taken before Create returns, and
+// the snapshot is written in the background.
+func (m *Manager) Create(height int64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if m.creating {
+		return ErrInProgress
+	}
+	exporter, err := m.ms.Snapshot(height)
+	if err != nil {
+		return err
+	}
+	m.creating = true
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer func() {
+			m.mtx.Lock()
+			m.creating = false
+			m.mtx.Unlock()
+		}()
+		defer exporter.Close()
+
+		snapshot, err := m.create(height, exporter)
+		if err != nil {
+			m.logger.Error("failed to create snapshot", "height", height, "err", err)
+			return
+		}
+		m.logger.Info("created snapshot", "height", height, "chunks", snapshot.Chunks, "hash", fmt.Sprintf("%X", snapshot.Hash))
+
+		if err := m.Prune(); err != nil {
+			m.logger.Error("failed to prune snapshots", "err", err)
+		}
+	}()
+	return nil
+}
+
+// create writes the snapshot exported by exporter in a temporary directory,
+// which is then renamed to the snapshot directory of height.
+func (m *Manager) create(height int64, exporter types.MultiStoreExporter) (abci.Snapshot, error) {
+	tmpDir := filepath.Join(m.dir, fmt.Sprintf(".tmp-%d", height))
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return abci.Snapshot{}, err
+	}
+	if err := os.MkdirAll(tmpDir, 0o755); err != nil {
+		return abci.Snapshot{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cw := &chunkWriter{dir: tmpDir, size: m.chunkSize}
+	zw := zlib.NewWriter(cw)
+	if err := exporter.Export(zw); err != nil {
+		return abci.Snapshot{}, err
+	}
+	if err := zw.Close(); err != nil {
+		return abci.Snapshot{}, err
+	}
+	if err := cw.Close(); err != nil {
+		return abci.Snapshot{}, err
+	}
+
+	metadata := amino.MustMarshal(Metadata{ChunkHashes: cw.hashes})
+	hash := sha256.Sum256(metadata)
+	snapshot := abci.Snapshot{
+		Height:   height,
+		Format:   Format,
+		Chunks:   uint32(len(cw.hashes)),
+		Hash:     hash[:],
+		Metadata: metadata,
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, snapshotFile), amino.MustMarshal(snapshot), 0o644); err != nil {
+		return abci.Snapshot{}, err
+	}
+
+	dir := m.snapshotDir(height)
+	if err := os.RemoveAll(dir); err != nil {
+		return abci.Snapshot{}, err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return abci.Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// List returns the snapshots, the most recent first.
+func (m *Manager) List() ([]abci.Snapshot, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]abci.Snapshot, 0, len(entries))
+	for _, entry := range entries {
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		bz, err := os.ReadFile(filepath.Join(m.snapshotDir(height), snapshotFile))
+		if errors.Is(err, os.ErrNotExist) {
+			continue // being pruned
+		}
+		if err != nil {
+			return nil, err
+		}
+		var snapshot abci.Snapshot
+		if err := amino.Unmarshal(bz, &snapshot); err != nil {
+			return nil, fmt.Errorf("unable to read snapshot at height %d, %w", height, err)
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	slices.SortFunc(snapshots, func(a, b abci.Snapshot) int {
+		return cmp.Compare(b.Height, a.Height)
+	})
+	return snapshots, nil
+}
+
+// LoadChunk returns the chunk at index of the snapshot at height.
+func (m *Manager) LoadChunk(height int64, format, index uint32) ([]byte, error) {
+	if format != Format {
+		return nil, ErrUnknownFormat
+	}
+	chunk, err := os.ReadFile(filepath.Join(m.snapshotDir(height), strconv.FormatUint(uint64(index), 10)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrSnapshotNotFound
+	}
+	return chunk, err
+}
+
+// Prune deletes the snapshots but the keepRecent most recent ones.
+func (m *Manager) Prune() error {
+	if m.keepRecent == 0 {
+		return nil
+	}
+	snapshots, err := m.List()
+	if err != nil {
+		return err
+	}
+	for i := int(m.keepRecent); i < len(snapshots); i++ {
+		if err := os.RemoveAll(m.snapshotDir(snapshots[i].Height)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Restore starts restoring the multistore from snapshot, whose restored state
+// must hash to appHash. Its chunks are then applied with RestoreChunk. A
+// restore in progress is aborted.
+func (m *Manager) Restore(snapshot abci.Snapshot, appHash []byte) error {
+	if snapshot.Format != Format {
+		return fmt.Errorf("%w: %d", ErrUnknownFormat, snapshot.Format)
+	}
+	if hash := sha256.Sum256(snapshot.Metadata); !bytes.Equal(hash[:], snapshot.Hash) {
+		return fmt.Errorf("%w: hash %X does not match the snapshot hash %X", ErrInvalidMetadata, hash, snapshot.Hash)
+	}
+	var metadata Metadata
+	if err := amino.Unmarshal(snapshot.Metadata, &metadata); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidMetadata, err)
+	}
+	if snapshot.Chunks == 0 || int(snapshot.Chunks) != len(metadata.ChunkHashes) {
+		return fmt.Errorf("%w: %d chunks, %d chunk hashes", ErrInvalidMetadata, snapshot.Chunks, len(metadata.ChunkHashes))
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	m.abortRestore()
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		err := m.restore(snapshot.Height, appHash, pr)
+		pr.CloseWithError(err)
+		done <- err
+	}()
+
+	m.restoration = &restoration{
+		snapshot: snapshot,
+		metadata: metadata,
+		pw:       pw,
+		done:     done,
+	}
+	return nil
+}
+
+func (m *Manager) restore(height int64, appHash []byte, r io.Reader) error {
+	zr, err := zlib.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer zr.Close()
+
+	_, err = m.ms.Restore(height, appHash, zr)
+	return err
+}
+
+// RestoreChunk applies the chunk at index of the snapshot being restored,
+// and returns whether the restore is done. Chunks must be applied in order.
+// A chunk which does not match its hash can be applied again
+// (ErrChunkHashMismatch); any other error ends the restore.
+func (m *Manager) RestoreChunk(index uint32, chunk []byte) (bool, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	r := m.restoration
+	if r == nil {
+		return false, ErrNoRestore
+	}
+	if index != r.next {
+		return false, fmt.Errorf("%w: expected chunk %d, got %d", ErrChunkOutOfOrder, r.next, index)
+	}
+	if hash := sha256.Sum256(chunk); !bytes.Equal(hash[:], r.metadata.ChunkHashes[index]) {
+		return false, fmt.Errorf("%w: chunk %d", ErrChunkHashMismatch, index)
+	}
+
+	if _, err := r.pw.Write(chunk); err != nil {
+		// The restore ended, successfully if the stream ended before the
+		// last chunk.
+		m.restoration = nil
+		if err := <-r.done; err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	r.next++
+	if r.next < r.snapshot.Chunks {
+		return false, nil
+	}
+
+	r.pw.Close()
+	m.restoration = nil
+	if err := <-r.done; err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// abortRestore aborts the restore in progress, if any.
+func (m *Manager) abortRestore() {
+	if r := m.restoration; r != nil {
+		r.pw.CloseWithError(errRestoreAborted)
+		<-r.done
+		m.restoration = nil
+	}
+}
+
+// Close aborts the restore in progress, and waits for the snapshot being
+// created, if any.
+func (m *Manager) Close() {
+	m.mtx.Lock()
+	m.abortRestore()
+	m.mtx.Unlock()
+
+	m.wg.Wait()
+}
+
+func (m *Manager) snapshotDir(height int64) string {
+	return filepath.Join(m.dir, strconv.FormatInt(height, 10))
+}
+
+// chunkWriter splits what it is written in chunk files of size bytes, named
+// by index in dir, and records their hashes.
+type chunkWriter struct {
+	dir    string
+	size   int
+	buf    []byte
+	hashes [][]byte
+}
+
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		l := min(cw.size-len(cw.buf), len(p))
+		cw.buf = append(cw.buf, p[:l]...)
+		p = p[l:]
+		if len(cw.buf) == cw.size {
+			if err := cw.flush(); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return n, nil
+}
+
+// Close writes the last chunk.
+func (cw *chunkWriter) Close() error {
+	if len(cw.buf) == 0 && len(cw.hashes) > 0 {
+		return nil
+	}
+	return cw.flush()
+}
+
+func (cw *chunkWriter) flush() error {
+	name := filepath.Join(cw.dir, strconv.Itoa(len(cw.hashes)))
+	if err := os.WriteFile(name, cw.buf, 0o644); err != nil {
+		return err
+	}
+	hash := sha256.Sum256(cw.buf)
+	cw.hashes = append(cw.hashes, hash[:])
+	cw.buf = cw.buf[:0]
+	return nil
+}
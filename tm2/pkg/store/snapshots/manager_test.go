@@ -0,0 +1,185 @@
+package snapshots
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/store/bptree"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+var (
+	mainKey = types.NewStoreKey("main")
+	baseKey = types.NewStoreKey("base")
+)
+
+func newTestMultiStore(t *testing.T) types.CommitMultiStore {
+	t.Helper()
+
+	ms := rootmulti.NewMultiStore(memdb.NewMemDB())
+	ms.SetStoreOptions(types.StoreOptions{PruningOptions: types.PruneNothing})
+	ms.MountStoreWithDB(mainKey, bptree.StoreConstructor, nil)
+	ms.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	return ms
+}
+
+func newTestManager(t *testing.T, ms types.CommitMultiStore, keepRecent uint32) *Manager {
+	t.Helper()
+
+	m, err := NewManager(t.TempDir(), ms.(types.MultiStoreSnapshotter), keepRecent, log.NewNoopLogger())
+	require.NoError(t, err)
+	m.chunkSize = 1024
+	t.Cleanup(m.Close)
+	return m
+}
+
+// commitHeights commits n heights of KVs with hashes as values, which
+// compress poorly, so that the snapshots have several chunks.
+func commitHeights(ms types.CommitMultiStore, n int) {
+	for range n {
+		height := ms.LastCommitID().Version + 1
+		for i := range 100 {
+			key := fmt.Appendf(nil, "key%03d", i)
+			hash := sha256.Sum256(fmt.Appendf(nil, "%d-%d", height, i))
+			value := hash[:]
+			ms.GetStore(mainKey).Set(nil, key, value)
+			ms.GetStore(baseKey).Set(nil, key, value)
+		}
+		ms.Commit()
+	}
+}
+
+// createSnapshot creates the snapshot of the latest version of ms, and waits
+// for it.
+func createSnapshot(t *testing.T, m *Manager, ms types.CommitMultiStore) abci.Snapshot {
+	t.Helper()
+
+	height := ms.LastCommitID().Version
+	require.NoError(t, m.Create(height))
+	m.wg.Wait()
+
+	snapshots, err := m.List()
+	require.NoError(t, err)
+	require.NotEmpty(t, snapshots)
+	require.Equal(t, height, snapshots[0].Height)
+	return snapshots[0]
+}
+
+func TestManager_CreateRestore(t *testing.T) {
+	t.Parallel()
+
+	ms := newTestMultiStore(t)
+	m := newTestManager(t, ms, 0)
+	commitHeights(ms, 3)
+	commitID := ms.LastCommitID()
+
+	snapshot := createSnapshot(t, m, ms)
+	assert.Equal(t, Format, snapshot.Format)
+	require.Greater(t, snapshot.Chunks, uint32(1))
+
+	restoreAll := func(t *testing.T, target *Manager) {
+		t.Helper()
+
+		require.NoError(t, target.Restore(snapshot, commitID.Hash))
+		for i := range snapshot.Chunks {
+			chunk, err := m.LoadChunk(snapshot.Height, snapshot.Format, i)
+			require.NoError(t, err)
+			done, err := target.RestoreChunk(i, chunk)
+			require.NoError(t, err)
+			assert.Equal(t, i == snapshot.Chunks-1, done)
+		}
+	}
+
+	t.Run("restore", func(t *testing.T) {
+		t.Parallel()
+
+		restored := newTestMultiStore(t)
+		restoreAll(t, newTestManager(t, restored, 0))
+		assert.Equal(t, commitID, restored.LastCommitID())
+		assert.Equal(t, ms.GetStore(baseKey).Get(nil, []byte("key042")), restored.GetStore(baseKey).Get(nil, []byte("key042")))
+	})
+
+	t.Run("chunk errors", func(t *testing.T) {
+		t.Parallel()
+
+		restored := newTestMultiStore(t)
+		target := newTestManager(t, restored, 0)
+		_, err := target.RestoreChunk(0, nil)
+		require.ErrorIs(t, err, ErrNoRestore)
+
+		require.NoError(t, target.Restore(snapshot, commitID.Hash))
+		chunk, err := m.LoadChunk(snapshot.Height, snapshot.Format, 1)
+		require.NoError(t, err)
+		_, err = target.RestoreChunk(1, chunk)
+		require.ErrorIs(t, err, ErrChunkOutOfOrder)
+		_, err = target.RestoreChunk(0, chunk)
+		require.ErrorIs(t, err, ErrChunkHashMismatch)
+
+		// The restore goes on after a rejected chunk.
+		restoreAll(t, target)
+		assert.Equal(t, commitID, restored.LastCommitID())
+	})
+
+	t.Run("untrusted app hash", func(t *testing.T) {
+		t.Parallel()
+
+		restored := newTestMultiStore(t)
+		target := newTestManager(t, restored, 0)
+		require.NoError(t, target.Restore(snapshot, []byte("forged")))
+		var err error
+		for i := uint32(0); i < snapshot.Chunks && err == nil; i++ {
+			var chunk []byte
+			chunk, err = m.LoadChunk(snapshot.Height, snapshot.Format, i)
+			require.NoError(t, err)
+			_, err = target.RestoreChunk(i, chunk)
+		}
+		require.ErrorContains(t, err, "does not match the trusted app hash")
+		assert.Zero(t, restored.LastCommitID())
+	})
+
+	t.Run("invalid snapshot", func(t *testing.T) {
+		t.Parallel()
+
+		target := newTestManager(t, newTestMultiStore(t), 0)
+
+		other := snapshot
+		other.Format = 2
+		require.ErrorIs(t, target.Restore(other, commitID.Hash), ErrUnknownFormat)
+
+		other = snapshot
+		other.Chunks++
+		require.ErrorIs(t, target.Restore(other, commitID.Hash), ErrInvalidMetadata)
+
+		other = snapshot
+		other.Hash = []byte("forged")
+		require.ErrorIs(t, target.Restore(other, commitID.Hash), ErrInvalidMetadata)
+	})
+}
+
+func TestManager_Prune(t *testing.T) {
+	t.Parallel()
+
+	ms := newTestMultiStore(t)
+	m := newTestManager(t, ms, 2)
+	for range 3 {
+		commitHeights(ms, 1)
+		createSnapshot(t, m, ms)
+	}
+
+	snapshots, err := m.List()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3, 2}, []int64{snapshots[0].Height, snapshots[1].Height})
+
+	_, err = m.LoadChunk(1, Format, 0)
+	assert.ErrorIs(t, err, ErrSnapshotNotFound)
+}
@@ -0,0 +1,59 @@
+package types
+
+import "io"
+
+// SnapshotNode is a node of a CommitStore snapshot. It is a superset of the
+// nodes exported by the IAVL and B+ trees, which are imported back in the
+// order they were exported to recreate an identical tree; stores without a
+// tree (e.g. dbadapter) only use Key and Value.
+type SnapshotNode struct {
+	Key           []byte
+	Value         []byte
+	Version       int64    // IAVL only.
+	Height        int8     // IAVL: subtree height; B+ tree: see bptree.ExportNode.
+	NumKeys       int16    // B+ tree only.
+	SeparatorKeys [][]byte // B+ tree only.
+}
+
+// Snapshotter is implemented by CommitStores which can be exported at a
+// committed version, and restored from such an export.
+type Snapshotter interface {
+	// Export calls fn with each node of the store at version, in order. An
+	// error returned by fn stops the export and is returned.
+	Export(version int64, fn func(*SnapshotNode) error) error
+
+	// Import returns an importer restoring the store at version. The store
+	// must be empty.
+	Import(version int64) (SnapshotImporter, error)
+}
+
+// SnapshotImporter restores a CommitStore from the nodes of its export, added
+// in the order they were exported. Close must always be called; it discards
+// the import if it was not committed.
+type SnapshotImporter interface {
+	Add(node *SnapshotNode) error
+	Commit() error
+	Close() error
+}
+
+// MultiStoreSnapshotter is implemented by CommitMultiStores which can be
+// exported and restored as a whole, for state sync.
+type MultiStoreSnapshotter interface {
+	// Snapshot returns an exporter of the multistore at version, which is
+	// taken when Snapshot returns: the export itself may run concurrently
+	// with later commits. The caller must Close the exporter.
+	Snapshot(version int64) (MultiStoreExporter, error)
+
+	// Restore restores the multistore, which must be empty, at version from
+	// the stream written by MultiStoreExporter.Export. The commit info
+	// restored must hash to hash, the app hash trusted for version;
+	// otherwise nothing is written and an error is returned.
+	Restore(version int64, hash []byte, r io.Reader) (CommitID, error)
+}
+
+// MultiStoreExporter exports a multistore snapshot.
+type MultiStoreExporter interface {
+	// Export writes the snapshot stream to w.
+	Export(w io.Writer) error
+	Close()
+}